@@ -293,7 +293,8 @@ const deleteHostedZoneRecords = async function (
   envRoute53,
   appRoute53,
   acm,
-  envHostedZoneId
+  envHostedZoneId,
+  retainedDomains
 ) {
   let listCertificatesInput = {};
   let newCertOptions = [];
@@ -329,8 +330,13 @@ const deleteHostedZoneRecords = async function (
     listCertificatesInput.NextToken = listCertResp.NextToken;
   }
   const newCertSANs = new Set(newCertOptions.map((item) => item.DomainName));
+  const retainedSANs = new Set(retainedDomains || []);
   const recordOptionsToDelete = [];
   for (const oldCertOption of oldCertOptions) {
+    if (retainedSANs.has(oldCertOption.DomainName)) {
+      // The validation record is shared with a certificate that Copilot manages in another region.
+      continue;
+    }
     if (!newCertSANs.has(oldCertOption.DomainName)) {
       // This alias field is no longer in use, we can safely delete its validation.
       recordOptionsToDelete.push(oldCertOption);
@@ -403,13 +409,15 @@ const validateDomain = async function ({
  * @param {string} envHostedZoneId the environment Route53 Hosted Zone ID
  * @param {string} rootDnsRole the IAM role ARN that can manage domainName
  * @param {string} region the environment region
+ * @param {string[]} retainedDomains the domains whose validation records are kept in the hosted zones
  */
 const deleteCertificate = async function (
   arn,
   certDomain,
   region,
   envHostedZoneId,
-  rootDnsRole
+  rootDnsRole,
+  retainedDomains
 ) {
   const [acm, envRoute53, appRoute53] = clients(region, rootDnsRole);
   try {
@@ -455,7 +463,8 @@ const deleteCertificate = async function (
       envRoute53,
       appRoute53,
       acm,
-      envHostedZoneId
+      envHostedZoneId,
+      retainedDomains
     );
 
     await acm
@@ -647,7 +656,9 @@ exports.certificateRequestHandler = async function (event, context) {
             certDomain,
             props.Region,
             props.EnvHostedZoneId,
-            props.RootDNSRole
+            props.RootDNSRole,
+            // The environment certificate in another region shares the validation records of the environment domain.
+            props.RetainEnvDomainValidationRecords === "true" ? [certDomain, `*.${certDomain}`, ] : []
           );
         }
         break;
@@ -0,0 +1,222 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+"use strict";
+
+const aws = require("aws-sdk");
+
+const defaultSleep = function (ms) {
+  return new Promise((resolve) => setTimeout(resolve, ms));
+};
+
+// These are used for test purposes only
+let defaultResponseURL;
+let sleep = defaultSleep;
+
+/**
+ * Upload a CloudFormation response object to S3.
+ *
+ * @param {object} event the Lambda event payload received by the handler function
+ * @param {object} context the Lambda context received by the handler function
+ * @param {string} responseStatus the response status, either 'SUCCESS' or 'FAILED'
+ * @param {string} physicalResourceId CloudFormation physical resource ID
+ * @param {object} [responseData] arbitrary response data object
+ * @param {string} [reason] reason for failure, if any, to convey to the user
+ * @returns {Promise} Promise that is resolved on success, or rejected on connection error or HTTP error response
+ */
+let report = function (
+  event,
+  context,
+  responseStatus,
+  physicalResourceId,
+  responseData,
+  reason
+) {
+  return new Promise((resolve, reject) => {
+    const https = require("https");
+    const { URL } = require("url");
+
+    var responseBody = JSON.stringify({
+      Status: responseStatus,
+      Reason: reason,
+      PhysicalResourceId: physicalResourceId || context.logStreamName,
+      StackId: event.StackId,
+      RequestId: event.RequestId,
+      LogicalResourceId: event.LogicalResourceId,
+      Data: responseData,
+    });
+
+    const parsedUrl = new URL(event.ResponseURL || defaultResponseURL);
+    const options = {
+      hostname: parsedUrl.hostname,
+      port: 443,
+      path: parsedUrl.pathname + parsedUrl.search,
+      method: "PUT",
+      headers: {
+        "Content-Type": "",
+        "Content-Length": responseBody.length,
+      },
+    };
+
+    https
+      .request(options)
+      .on("error", reject)
+      .on("response", (res) => {
+        res.resume();
+        if (res.statusCode >= 400) {
+          reject(new Error(`Error ${res.statusCode}: ${res.statusMessage}`));
+        } else {
+          resolve();
+        }
+      })
+      .end(responseBody, "utf8");
+  });
+};
+
+/**
+ * Run the pre-deploy task and wait until it stops.
+ *
+ * @param {object} props Resource properties of the custom resource.
+ *
+ * @returns {string} The ARN of the task that exited successfully.
+ */
+const runPreDeployTask = async function (props) {
+  const ecs = new aws.ECS();
+  const deadline = Date.now() + parseInt(props.TimeoutInSeconds, 10) * 1000;
+  const runResp = await ecs
+    .runTask({
+      cluster: props.Cluster,
+      taskDefinition: props.TaskDefinition,
+      launchType: "FARGATE",
+      platformVersion: props.PlatformVersion,
+      startedBy: "copilot-pre-deploy",
+      networkConfiguration: {
+        awsvpcConfiguration: {
+          assignPublicIp: props.AssignPublicIp,
+          subnets: props.Subnets,
+          securityGroups: props.SecurityGroups,
+        },
+      },
+    })
+    .promise();
+  if (runResp.failures && runResp.failures.length > 0) {
+    const failure = runResp.failures[0];
+    throw new Error(`run pre-deploy task: ${failure.reason}`);
+  }
+  const taskARN = runResp.tasks[0].taskArn;
+
+  while (Date.now() < deadline) {
+    await sleep(10000);
+    const describeResp = await ecs
+      .describeTasks({
+        cluster: props.Cluster,
+        tasks: [taskARN],
+      })
+      .promise();
+    const task = describeResp.tasks[0];
+    if (task.lastStatus !== "STOPPED") {
+      continue;
+    }
+    const container = task.containers[0];
+    if (container.exitCode === 0) {
+      return taskARN;
+    }
+    if (container.exitCode === undefined || container.exitCode === null) {
+      throw new Error(
+        `pre-deploy task ${taskARN} stopped before its container exited: ${task.stoppedReason}`
+      );
+    }
+    throw new Error(
+      `pre-deploy task ${taskARN} exited with code ${container.exitCode}`
+    );
+  }
+
+  await ecs
+    .stopTask({
+      cluster: props.Cluster,
+      task: taskARN,
+      reason: "Pre-deploy task timed out",
+    })
+    .promise();
+  throw new Error(
+    `pre-deploy task ${taskARN} did not stop within ${props.TimeoutInSeconds} seconds`
+  );
+};
+
+/**
+ * Returns true if the stack is rolling back to its previous configuration.
+ *
+ * @param {string} stackId ID of the stack that owns the custom resource.
+ *
+ * @returns {boolean} Whether the stack is rolling back.
+ */
+const isRollingBack = async function (stackId) {
+  const cfn = new aws.CloudFormation();
+  const resp = await cfn
+    .describeStacks({
+      StackName: stackId,
+    })
+    .promise();
+  return resp.Stacks[0].StackStatus === "UPDATE_ROLLBACK_IN_PROGRESS";
+};
+
+/**
+ * Pre-deploy task handler, invoked by Lambda.
+ */
+exports.handler = async function (event, context) {
+  var responseData = {};
+  const props = event.ResourceProperties;
+  const physicalResourceId =
+    event.PhysicalResourceId ||
+    `copilot/apps/${props.App}/envs/${props.Env}/services/${props.Svc}/pre-deploy`;
+
+  try {
+    switch (event.RequestType) {
+      case "Create":
+        responseData.TaskArn = await runPreDeployTask(props);
+        break;
+      case "Update":
+        // The previous version of the task already ran before the previous rollout, so don't run it again.
+        if (await isRollingBack(event.StackId)) {
+          break;
+        }
+        responseData.TaskArn = await runPreDeployTask(props);
+        break;
+      case "Delete":
+        break;
+      default:
+        throw new Error(`Unsupported request type ${event.RequestType}`);
+    }
+    await report(event, context, "SUCCESS", physicalResourceId, responseData);
+  } catch (err) {
+    console.log(`Caught error ${err}.`);
+    await report(
+      event,
+      context,
+      "FAILED",
+      physicalResourceId,
+      null,
+      `${err.message} (Log: ${context.logGroupName}/${context.logStreamName})`
+    );
+  }
+};
+
+/**
+ * @private
+ */
+exports.withDefaultResponseURL = function (url) {
+  defaultResponseURL = url;
+};
+
+/**
+ * @private
+ */
+exports.withSleep = function (s) {
+  sleep = s;
+};
+
+/**
+ * @private
+ */
+exports.reset = function () {
+  sleep = defaultSleep;
+};
@@ -0,0 +1,248 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+"use strict";
+
+const aws = require("aws-sdk");
+
+// The maximum number of keys that can be deleted in a single DeleteObjects request.
+const maxKeysPerDelete = 1000;
+
+// These are used for test purposes only
+let defaultResponseURL;
+
+/**
+ * Upload a CloudFormation response object to S3.
+ *
+ * @param {object} event the Lambda event payload received by the handler function
+ * @param {object} context the Lambda context received by the handler function
+ * @param {string} responseStatus the response status, either 'SUCCESS' or 'FAILED'
+ * @param {string} physicalResourceId CloudFormation physical resource ID
+ * @param {object} [responseData] arbitrary response data object
+ * @param {string} [reason] reason for failure, if any, to convey to the user
+ * @returns {Promise} Promise that is resolved on success, or rejected on connection error or HTTP error response
+ */
+let report = function (
+  event,
+  context,
+  responseStatus,
+  physicalResourceId,
+  responseData,
+  reason
+) {
+  return new Promise((resolve, reject) => {
+    const https = require("https");
+    const { URL } = require("url");
+
+    var responseBody = JSON.stringify({
+      Status: responseStatus,
+      Reason: reason,
+      PhysicalResourceId: physicalResourceId || context.logStreamName,
+      StackId: event.StackId,
+      RequestId: event.RequestId,
+      LogicalResourceId: event.LogicalResourceId,
+      Data: responseData,
+    });
+
+    const parsedUrl = new URL(event.ResponseURL || defaultResponseURL);
+    const options = {
+      hostname: parsedUrl.hostname,
+      port: 443,
+      path: parsedUrl.pathname + parsedUrl.search,
+      method: "PUT",
+      headers: {
+        "Content-Type": "",
+        "Content-Length": responseBody.length,
+      },
+    };
+
+    https
+      .request(options)
+      .on("error", reject)
+      .on("response", (res) => {
+        res.resume();
+        if (res.statusCode >= 400) {
+          reject(new Error(`Error ${res.statusCode}: ${res.statusMessage}`));
+        } else {
+          resolve();
+        }
+      })
+      .end(responseBody, "utf8");
+  });
+};
+
+/**
+ * Copies the assets listed in the mapping file from the artifact bucket to the site bucket,
+ * and removes the objects of the site bucket that are no longer part of the site.
+ * Previous versions of the removed objects are kept since the site bucket is versioned.
+ *
+ * @param {string} srcBucket the name of the bucket that holds the uploaded assets and the mapping file
+ * @param {string} mappingFileKey the key of the mapping file, a JSON array of {path, destPath, contentType} objects
+ * @param {string} destBucket the name of the bucket that the site is served from
+ */
+const copyAssets = async function (srcBucket, mappingFileKey, destBucket) {
+  const s3 = new aws.S3();
+  const mappingFile = await s3
+    .getObject({
+      Bucket: srcBucket,
+      Key: mappingFileKey,
+    })
+    .promise();
+  const assets = JSON.parse(mappingFile.Body.toString());
+
+  await Promise.all(
+    assets.map((asset) =>
+      s3
+        .copyObject({
+          Bucket: destBucket,
+          Key: asset.destPath,
+          CopySource: `${srcBucket}/${asset.path}`,
+          ContentType: asset.contentType,
+          MetadataDirective: "REPLACE",
+        })
+        .promise()
+    )
+  );
+
+  const wanted = new Set(assets.map((asset) => asset.destPath));
+  let stale = [];
+  let continuationToken;
+  do {
+    const resp = await s3
+      .listObjectsV2({
+        Bucket: destBucket,
+        ContinuationToken: continuationToken,
+      })
+      .promise();
+    for (const object of resp.Contents || []) {
+      if (!wanted.has(object.Key)) {
+        stale.push({ Key: object.Key });
+      }
+    }
+    continuationToken = resp.NextContinuationToken;
+  } while (continuationToken);
+  await deleteObjects(s3, destBucket, stale);
+};
+
+/**
+ * Deletes every object version and delete marker of the site bucket so that the bucket can be deleted.
+ *
+ * @param {string} bucket the name of the bucket that the site is served from
+ */
+const emptyBucket = async function (bucket) {
+  const s3 = new aws.S3();
+  let keyMarker, versionIdMarker;
+  do {
+    let resp;
+    try {
+      resp = await s3
+        .listObjectVersions({
+          Bucket: bucket,
+          KeyMarker: keyMarker,
+          VersionIdMarker: versionIdMarker,
+        })
+        .promise();
+    } catch (err) {
+      if (err.code === "NoSuchBucket") {
+        return;
+      }
+      throw err;
+    }
+    const objects = [...(resp.Versions || []), ...(resp.DeleteMarkers || [])].map(
+      (version) => ({ Key: version.Key, VersionId: version.VersionId })
+    );
+    await deleteObjects(s3, bucket, objects);
+    keyMarker = resp.NextKeyMarker;
+    versionIdMarker = resp.NextVersionIdMarker;
+  } while (keyMarker || versionIdMarker);
+};
+
+/**
+ * Deletes the objects from the bucket in batches.
+ *
+ * @param {object} s3 the S3 client
+ * @param {string} bucket the name of the bucket
+ * @param {object[]} objects the {Key, VersionId} identifiers of the objects to delete
+ */
+const deleteObjects = async function (s3, bucket, objects) {
+  for (let i = 0; i < objects.length; i += maxKeysPerDelete) {
+    await s3
+      .deleteObjects({
+        Bucket: bucket,
+        Delete: {
+          Objects: objects.slice(i, i + maxKeysPerDelete),
+          Quiet: true,
+        },
+      })
+      .promise();
+  }
+};
+
+/**
+ * Invalidates every cached path of the distribution so that viewers get the new assets.
+ *
+ * @param {string} distributionId the ID of the CloudFront distribution in front of the site bucket
+ * @param {string} callerReference a unique value that prevents the invalidation from being replayed
+ */
+const invalidateCache = async function (distributionId, callerReference) {
+  const cloudfront = new aws.CloudFront();
+  await cloudfront
+    .createInvalidation({
+      DistributionId: distributionId,
+      InvalidationBatch: {
+        CallerReference: callerReference,
+        Paths: {
+          Quantity: 1,
+          Items: ["/*"],
+        },
+      },
+    })
+    .promise();
+};
+
+/**
+ * Static site assets handler, invoked by Lambda.
+ */
+exports.handler = async function (event, context) {
+  const props = event.ResourceProperties;
+  // The physical ID only changes when the site bucket is replaced, in which case the old bucket is emptied on delete.
+  const physicalResourceId = props.DestinationBucket;
+
+  try {
+    switch (event.RequestType) {
+      case "Create":
+      case "Update":
+        // Without a mapping file there are no new assets, so the files already in the site bucket are kept.
+        if (!props.MappingFileKey) {
+          break;
+        }
+        await copyAssets(props.SourceBucket, props.MappingFileKey, props.DestinationBucket);
+        if (event.RequestType === "Update") {
+          await invalidateCache(props.DistributionId, event.RequestId);
+        }
+        break;
+      case "Delete":
+        await emptyBucket(event.PhysicalResourceId);
+        break;
+      default:
+        throw new Error(`Unsupported request type ${event.RequestType}`);
+    }
+    await report(event, context, "SUCCESS", physicalResourceId);
+  } catch (err) {
+    console.log(`Caught error ${err}.`);
+    await report(
+      event,
+      context,
+      "FAILED",
+      physicalResourceId,
+      null,
+      `${err.message} (Log: ${context.logGroupName}/${context.logStreamName})`
+    );
+  }
+};
+
+/**
+ * @private
+ */
+exports.withDefaultResponseURL = function (url) {
+  defaultResponseURL = url;
+};
@@ -887,6 +887,83 @@ describe("DNS Validated Certificate Handler", () => {
       });
   });
 
+  test("Delete operation retains only the validation records of the environment domain if requested", () => {
+    const describeCertificateFake = sinon.fake.resolves({
+      Certificate: {
+        CertificateArn: testCertificateArn,
+        DomainValidationOptions: [
+          ...legacyCertValidatorOptions,
+          {
+            DomainName: `www.${testEnvName}.${testAppName}.${testDomainName}`,
+            ValidationStatus: "SUCCESS",
+            ResourceRecord: {
+              Name: testRRName,
+              Type: "CNAME",
+              Value: testRRValue3,
+            },
+          },
+        ],
+      },
+    });
+    AWS.mock("ACM", "describeCertificate", describeCertificateFake);
+
+    const deleteCertificateFake = sinon.fake.resolves({});
+    AWS.mock("ACM", "deleteCertificate", deleteCertificateFake);
+
+    const listCertificatesFake = sinon.fake.resolves({});
+    AWS.mock("ACM", "listCertificates", listCertificatesFake);
+
+    const changeResourceRecordSetsFake = sinon.fake.resolves({
+      ChangeInfo: {
+        Id: "bogus",
+      },
+    });
+    AWS.mock(
+      "Route53",
+      "changeResourceRecordSets",
+      changeResourceRecordSetsFake
+    );
+
+    const request = nock(ResponseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    return LambdaTester(handler.certificateRequestHandler)
+      .event({
+        RequestType: "Delete",
+        RequestId: testRequestId,
+        PhysicalResourceId: testCertificateArn,
+        ResourceProperties: {
+          AppName: testAppName,
+          EnvName: testEnvName,
+          DomainName: testDomainName,
+          EnvHostedZoneId: testHostedZoneId,
+          Region: "us-east-1",
+          RootDNSRole: testRootDNSRole,
+          RetainEnvDomainValidationRecords: "true",
+        },
+      })
+      .expectResolve(() => {
+        sinon.assert.calledOnce(changeResourceRecordSetsFake);
+        sinon.assert.calledWith(
+          changeResourceRecordSetsFake,
+          sinon.match({
+            ChangeBatch: testDeleteRecordChangebatch3,
+            HostedZoneId: testHostedZoneId,
+          })
+        );
+        sinon.assert.calledWith(
+          deleteCertificateFake,
+          sinon.match({
+            CertificateArn: testCertificateArn,
+          })
+        );
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
   test("Delete operation deletes the cert without record removal", () => {
     const describeCertificateFake = sinon.stub();
     describeCertificateFake.onFirstCall().resolves({
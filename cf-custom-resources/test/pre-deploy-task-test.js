@@ -0,0 +1,251 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+"use strict";
+
+describe("Pre-deploy task Handler", () => {
+  const AWS = require("aws-sdk-mock");
+  const sinon = require("sinon");
+  const PreDeployTask = require("../lib/pre-deploy-task");
+  const LambdaTester = require("lambda-tester").noVersionCheck();
+  const nock = require("nock");
+  const responseURL = "https://cloudwatch-response-mock.example.com/";
+  const testRequestId = "f4ef1b10-c39a-44e3-99c0-fbf7e53c3943";
+  const testStackId = "arn:aws:cloudformation:us-west-2:123456789012:stack/mockApp-testEnv-testSvc/1234";
+  const testTaskARN = "arn:aws:ecs:us-west-2:123456789012:task/mockCluster/4082490ee6c245e09d2145010aa1ba8d";
+  let origLog = console.log;
+
+  const testProps = {
+    App: "mockApp",
+    Env: "testEnv",
+    Svc: "testSvc",
+    Cluster: "mockCluster",
+    TaskDefinition: "arn:aws:ecs:us-west-2:123456789012:task-definition/mockApp-testEnv-testSvc-pre-deploy:2",
+    PlatformVersion: "LATEST",
+    AssignPublicIp: "DISABLED",
+    Subnets: ["subnet-1", "subnet-2"],
+    SecurityGroups: ["sg-1"],
+    TimeoutInSeconds: "600",
+  };
+
+  beforeEach(() => {
+    PreDeployTask.withDefaultResponseURL(responseURL);
+    PreDeployTask.withSleep(() => Promise.resolve());
+    // Prevent logging.
+    console.log = function () {};
+  });
+  afterEach(() => {
+    // Restore logger
+    AWS.restore();
+    PreDeployTask.reset();
+    console.log = origLog;
+  });
+
+  test("invalid operation should fail", () => {
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "FAILED" && body.Reason.startsWith("Unsupported request type OOPS");
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "OOPS",
+        ResponseURL: responseURL,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("create operation succeeds when the task exits successfully", () => {
+    const runTaskFake = sinon.fake.resolves({
+      tasks: [{ taskArn: testTaskARN }],
+    });
+    const describeTasksFake = sinon.stub();
+    describeTasksFake.onFirstCall().resolves({
+      tasks: [{ lastStatus: "RUNNING", containers: [{}] }],
+    });
+    describeTasksFake.onSecondCall().resolves({
+      tasks: [{ lastStatus: "STOPPED", containers: [{ exitCode: 0 }] }],
+    });
+    AWS.mock("ECS", "runTask", runTaskFake);
+    AWS.mock("ECS", "describeTasks", describeTasksFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "SUCCESS" &&
+          body.Data.TaskArn === testTaskARN &&
+          body.PhysicalResourceId === "copilot/apps/mockApp/envs/testEnv/services/testSvc/pre-deploy"
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "Create",
+        RequestId: testRequestId,
+        StackId: testStackId,
+        ResponseURL: responseURL,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(
+          runTaskFake,
+          sinon.match({
+            cluster: "mockCluster",
+            taskDefinition: testProps.TaskDefinition,
+            launchType: "FARGATE",
+            platformVersion: "LATEST",
+            startedBy: "copilot-pre-deploy",
+            networkConfiguration: {
+              awsvpcConfiguration: {
+                assignPublicIp: "DISABLED",
+                subnets: ["subnet-1", "subnet-2"],
+                securityGroups: ["sg-1"],
+              },
+            },
+          })
+        );
+        sinon.assert.calledTwice(describeTasksFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("create operation fails when the task exits with a non-zero code", () => {
+    AWS.mock("ECS", "runTask", sinon.fake.resolves({
+      tasks: [{ taskArn: testTaskARN }],
+    }));
+    AWS.mock("ECS", "describeTasks", sinon.fake.resolves({
+      tasks: [{ lastStatus: "STOPPED", containers: [{ exitCode: 1 }] }],
+    }));
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "FAILED" && body.Reason.startsWith(`pre-deploy task ${testTaskARN} exited with code 1`);
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "Create",
+        RequestId: testRequestId,
+        StackId: testStackId,
+        ResponseURL: responseURL,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("create operation fails when the task cannot be placed", () => {
+    AWS.mock("ECS", "runTask", sinon.fake.resolves({
+      tasks: [],
+      failures: [{ reason: "RESOURCE:MEMORY" }],
+    }));
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "FAILED" && body.Reason.startsWith("run pre-deploy task: RESOURCE:MEMORY");
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "Create",
+        RequestId: testRequestId,
+        StackId: testStackId,
+        ResponseURL: responseURL,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("update operation stops the task and fails on timeout", () => {
+    const stopTaskFake = sinon.fake.resolves({});
+    AWS.mock("CloudFormation", "describeStacks", sinon.fake.resolves({
+      Stacks: [{ StackStatus: "UPDATE_IN_PROGRESS" }],
+    }));
+    AWS.mock("ECS", "runTask", sinon.fake.resolves({
+      tasks: [{ taskArn: testTaskARN }],
+    }));
+    AWS.mock("ECS", "stopTask", stopTaskFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "FAILED" && body.Reason.startsWith(`pre-deploy task ${testTaskARN} did not stop within 0 seconds`);
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "Update",
+        RequestId: testRequestId,
+        StackId: testStackId,
+        PhysicalResourceId: "copilot/apps/mockApp/envs/testEnv/services/testSvc/pre-deploy",
+        ResponseURL: responseURL,
+        ResourceProperties: { ...testProps, TimeoutInSeconds: "0" },
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(
+          stopTaskFake,
+          sinon.match({
+            cluster: "mockCluster",
+            task: testTaskARN,
+          })
+        );
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("update operation does not run the task while the stack rolls back", () => {
+    const runTaskFake = sinon.stub();
+    AWS.mock("CloudFormation", "describeStacks", sinon.fake.resolves({
+      Stacks: [{ StackStatus: "UPDATE_ROLLBACK_IN_PROGRESS" }],
+    }));
+    AWS.mock("ECS", "runTask", runTaskFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "Update",
+        RequestId: testRequestId,
+        StackId: testStackId,
+        PhysicalResourceId: "copilot/apps/mockApp/envs/testEnv/services/testSvc/pre-deploy",
+        ResponseURL: responseURL,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        sinon.assert.notCalled(runTaskFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("delete operation does nothing", () => {
+    const runTaskFake = sinon.stub();
+    AWS.mock("ECS", "runTask", runTaskFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    return LambdaTester(PreDeployTask.handler)
+      .event({
+        RequestType: "Delete",
+        RequestId: testRequestId,
+        StackId: testStackId,
+        PhysicalResourceId: "copilot/apps/mockApp/envs/testEnv/services/testSvc/pre-deploy",
+        ResponseURL: responseURL,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        sinon.assert.notCalled(runTaskFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+});
@@ -0,0 +1,334 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+"use strict";
+
+describe("Static site assets handler", () => {
+  const AWS = require("aws-sdk-mock");
+  const sinon = require("sinon");
+  const StaticSiteAssets = require("../lib/static-site-assets");
+  const LambdaTester = require("lambda-tester").noVersionCheck();
+  const nock = require("nock");
+  const responseURL = "https://cloudwatch-response-mock.example.com/";
+  const testRequestId = "f4ef1b10-c39a-44e3-99c0-fbf7e53c3943";
+  let origLog = console.log;
+
+  const testSourceBucket = "mockArtifactBucket";
+  const testMappingFileKey = "manual/static-site/frontend/mappings/abc.json";
+  const testDestBucket = "mockSiteBucket";
+  const testDistributionId = "E2QWRUHAPOMQZL";
+  const testMapping = [
+    {
+      path: "manual/static-site/frontend/assets/123",
+      destPath: "index.html",
+      contentType: "text/html; charset=utf-8",
+    },
+    {
+      path: "manual/static-site/frontend/assets/456",
+      destPath: "js/app.js",
+      contentType: "text/javascript; charset=utf-8",
+    },
+  ];
+
+  beforeEach(() => {
+    StaticSiteAssets.withDefaultResponseURL(responseURL);
+    // Prevent logging.
+    console.log = function () {};
+  });
+  afterEach(() => {
+    // Restore logger
+    AWS.restore();
+    console.log = origLog;
+  });
+
+  test("invalid operation", () => {
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "FAILED" &&
+          body.Reason.startsWith("Unsupported request type OOPS")
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "OOPS",
+        ResourceProperties: {},
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("create operation copies the assets without invalidating the cache", () => {
+    const getObjectFake = sinon.fake.resolves({
+      Body: Buffer.from(JSON.stringify(testMapping)),
+    });
+    const copyObjectFake = sinon.fake.resolves({});
+    const listObjectsFake = sinon.fake.resolves({
+      Contents: [{ Key: "index.html" }],
+    });
+    const deleteObjectsFake = sinon.fake.resolves({});
+    const createInvalidationFake = sinon.fake.resolves({});
+    AWS.mock("S3", "getObject", getObjectFake);
+    AWS.mock("S3", "copyObject", copyObjectFake);
+    AWS.mock("S3", "listObjectsV2", listObjectsFake);
+    AWS.mock("S3", "deleteObjects", deleteObjectsFake);
+    AWS.mock("CloudFront", "createInvalidation", createInvalidationFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "SUCCESS" && body.PhysicalResourceId === testDestBucket
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "Create",
+        RequestId: testRequestId,
+        ResourceProperties: {
+          SourceBucket: testSourceBucket,
+          MappingFileKey: testMappingFileKey,
+          DestinationBucket: testDestBucket,
+          DistributionId: testDistributionId,
+        },
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(
+          getObjectFake,
+          sinon.match({
+            Bucket: testSourceBucket,
+            Key: testMappingFileKey,
+          })
+        );
+        sinon.assert.calledTwice(copyObjectFake);
+        sinon.assert.calledWith(
+          copyObjectFake,
+          sinon.match({
+            Bucket: testDestBucket,
+            Key: "js/app.js",
+            CopySource: "mockArtifactBucket/manual/static-site/frontend/assets/456",
+            ContentType: "text/javascript; charset=utf-8",
+            MetadataDirective: "REPLACE",
+          })
+        );
+        sinon.assert.notCalled(deleteObjectsFake);
+        sinon.assert.notCalled(createInvalidationFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("update operation removes stale objects and invalidates the cache", () => {
+    const getObjectFake = sinon.fake.resolves({
+      Body: Buffer.from(JSON.stringify(testMapping)),
+    });
+    const copyObjectFake = sinon.fake.resolves({});
+    const listObjectsFake = sinon.stub();
+    listObjectsFake.onFirstCall().resolves({
+      Contents: [{ Key: "index.html" }, { Key: "js/old.js" }],
+      NextContinuationToken: "token",
+    });
+    listObjectsFake.onSecondCall().resolves({
+      Contents: [{ Key: "js/app.js" }],
+    });
+    const deleteObjectsFake = sinon.fake.resolves({});
+    const createInvalidationFake = sinon.fake.resolves({});
+    AWS.mock("S3", "getObject", getObjectFake);
+    AWS.mock("S3", "copyObject", copyObjectFake);
+    AWS.mock("S3", "listObjectsV2", listObjectsFake);
+    AWS.mock("S3", "deleteObjects", deleteObjectsFake);
+    AWS.mock("CloudFront", "createInvalidation", createInvalidationFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "Update",
+        RequestId: testRequestId,
+        PhysicalResourceId: testDestBucket,
+        ResourceProperties: {
+          SourceBucket: testSourceBucket,
+          MappingFileKey: testMappingFileKey,
+          DestinationBucket: testDestBucket,
+          DistributionId: testDistributionId,
+        },
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(
+          listObjectsFake,
+          sinon.match({
+            Bucket: testDestBucket,
+            ContinuationToken: "token",
+          })
+        );
+        sinon.assert.calledOnce(deleteObjectsFake);
+        sinon.assert.calledWith(
+          deleteObjectsFake,
+          sinon.match({
+            Bucket: testDestBucket,
+            Delete: {
+              Objects: [{ Key: "js/old.js" }],
+              Quiet: true,
+            },
+          })
+        );
+        sinon.assert.calledWith(
+          createInvalidationFake,
+          sinon.match({
+            DistributionId: testDistributionId,
+            InvalidationBatch: {
+              CallerReference: testRequestId,
+              Paths: {
+                Quantity: 1,
+                Items: ["/*"],
+              },
+            },
+          })
+        );
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("update operation fails if the assets cannot be copied", () => {
+    const getObjectFake = sinon.fake.rejects(new Error("access denied"));
+    AWS.mock("S3", "getObject", getObjectFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "FAILED" && body.Reason.startsWith("access denied")
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "Update",
+        RequestId: testRequestId,
+        PhysicalResourceId: testDestBucket,
+        ResourceProperties: {
+          SourceBucket: testSourceBucket,
+          MappingFileKey: testMappingFileKey,
+          DestinationBucket: testDestBucket,
+          DistributionId: testDistributionId,
+        },
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("update operation keeps the site bucket untouched without a mapping file", () => {
+    const getObjectFake = sinon.fake.resolves({});
+    const deleteObjectsFake = sinon.fake.resolves({});
+    const createInvalidationFake = sinon.fake.resolves({});
+    AWS.mock("S3", "getObject", getObjectFake);
+    AWS.mock("S3", "deleteObjects", deleteObjectsFake);
+    AWS.mock("CloudFront", "createInvalidation", createInvalidationFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "SUCCESS" && body.PhysicalResourceId === testDestBucket
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "Update",
+        RequestId: testRequestId,
+        PhysicalResourceId: testDestBucket,
+        ResourceProperties: {
+          SourceBucket: "",
+          MappingFileKey: "",
+          DestinationBucket: testDestBucket,
+          DistributionId: testDistributionId,
+        },
+      })
+      .expectResolve(() => {
+        sinon.assert.notCalled(getObjectFake);
+        sinon.assert.notCalled(deleteObjectsFake);
+        sinon.assert.notCalled(createInvalidationFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("delete operation empties the site bucket", () => {
+    const listObjectVersionsFake = sinon.stub();
+    listObjectVersionsFake.onFirstCall().resolves({
+      Versions: [{ Key: "index.html", VersionId: "v2" }],
+      DeleteMarkers: [{ Key: "js/old.js", VersionId: "v1" }],
+      NextKeyMarker: "index.html",
+      NextVersionIdMarker: "v2",
+    });
+    listObjectVersionsFake.onSecondCall().resolves({
+      Versions: [{ Key: "index.html", VersionId: "v1" }],
+    });
+    const deleteObjectsFake = sinon.fake.resolves({});
+    AWS.mock("S3", "listObjectVersions", listObjectVersionsFake);
+    AWS.mock("S3", "deleteObjects", deleteObjectsFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "Delete",
+        RequestId: testRequestId,
+        PhysicalResourceId: testDestBucket,
+        ResourceProperties: {
+          DestinationBucket: testDestBucket,
+        },
+      })
+      .expectResolve(() => {
+        sinon.assert.calledTwice(deleteObjectsFake);
+        sinon.assert.calledWith(
+          deleteObjectsFake.firstCall,
+          sinon.match({
+            Bucket: testDestBucket,
+            Delete: {
+              Objects: [
+                { Key: "index.html", VersionId: "v2" },
+                { Key: "js/old.js", VersionId: "v1" },
+              ],
+              Quiet: true,
+            },
+          })
+        );
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("delete operation succeeds if the site bucket no longer exists", () => {
+    const err = new Error("The specified bucket does not exist");
+    err.code = "NoSuchBucket";
+    const listObjectVersionsFake = sinon.fake.rejects(err);
+    const deleteObjectsFake = sinon.fake.resolves({});
+    AWS.mock("S3", "listObjectVersions", listObjectVersionsFake);
+    AWS.mock("S3", "deleteObjects", deleteObjectsFake);
+    const request = nock(responseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    return LambdaTester(StaticSiteAssets.handler)
+      .event({
+        RequestType: "Delete",
+        RequestId: testRequestId,
+        PhysicalResourceId: testDestBucket,
+        ResourceProperties: {
+          DestinationBucket: testDestBucket,
+        },
+      })
+      .expectResolve(() => {
+        sinon.assert.notCalled(deleteObjectsFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+});
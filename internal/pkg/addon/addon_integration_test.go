@@ -33,6 +33,32 @@ func TestAddons(t *testing.T) {
 			}),
 			outFileName: "aurora.yml",
 		},
+		"aurora serverless v2 with proxy": {
+			addonMarshaler: addon.NewRDSTemplate(addon.RDSProps{
+				ClusterName:   "aurora",
+				Engine:        "PostgreSQL",
+				InitialDBName: "main",
+				Envs:          []string{"test"},
+
+				ServerlessVersion: addon.RDSServerlessVersionV2,
+				MinCapacity:       0.5,
+				MaxCapacity:       8,
+				ReaderCount:       1,
+				Proxy:             true,
+			}),
+			outFileName: "aurora-v2.yml",
+		},
+		"aurora provisioned": {
+			addonMarshaler: addon.NewRDSTemplate(addon.RDSProps{
+				ClusterName:   "aurora",
+				Engine:        "MySQL",
+				InitialDBName: "main",
+				Envs:          []string{"test"},
+
+				InstanceClass: "db.r6g.large",
+			}),
+			outFileName: "aurora-provisioned.yml",
+		},
 		"ddb": {
 			addonMarshaler: addon.NewDDBTemplate(&addon.DynamoDBProps{
 				StorageProps: &addon.StorageProps{
@@ -65,6 +91,42 @@ func TestAddons(t *testing.T) {
 			}),
 			outFileName: "ddb.yml",
 		},
+		"ddb with table settings": {
+			addonMarshaler: addon.NewDDBTemplate(&addon.DynamoDBProps{
+				StorageProps: &addon.StorageProps{
+					Name: "users",
+				},
+				Attributes: []addon.DDBAttribute{
+					{
+						Name:     aws.String("UserId"),
+						DataType: aws.String("S"),
+					},
+					{
+						Name:     aws.String("Email"),
+						DataType: aws.String("S"),
+					},
+				},
+				PartitionKey: aws.String("UserId"),
+				GSIs: []addon.DDBGlobalSecondaryIndex{
+					{
+						Name:             aws.String("byEmail"),
+						PartitionKey:     aws.String("Email"),
+						ProjectionType:   addon.DDBProjectionTypeInclude,
+						NonKeyAttributes: []string{"Name"},
+					},
+				},
+				TTLAttribute:   "ExpiresAt",
+				StreamViewType: "NEW_AND_OLD_IMAGES",
+				Provisioned: &addon.DDBProvisionedThroughput{
+					ReadCapacity:     5,
+					WriteCapacity:    5,
+					MaxReadCapacity:  50,
+					MaxWriteCapacity: 20,
+				},
+				PointInTimeRecovery: true,
+			}),
+			outFileName: "ddb-provisioned.yml",
+		},
 		"s3": {
 			addonMarshaler: addon.NewS3Template(&addon.S3Props{
 				StorageProps: &addon.StorageProps{
@@ -73,6 +135,33 @@ func TestAddons(t *testing.T) {
 			}),
 			outFileName: "bucket.yml",
 		},
+		"redis": {
+			addonMarshaler: addon.NewRedisTemplate(&addon.RedisProps{
+				StorageProps: &addon.StorageProps{
+					Name: "cache",
+				},
+				NodeType: "cache.t3.micro",
+			}),
+			outFileName: "redis.yml",
+		},
+		"sqs": {
+			addonMarshaler: addon.NewSQSTemplate(&addon.SQSProps{
+				StorageProps: &addon.StorageProps{
+					Name: "queue",
+				},
+				FIFO: true,
+			}),
+			outFileName: "queue.yml",
+		},
+		"opensearch": {
+			addonMarshaler: addon.NewOpenSearchTemplate(&addon.OpenSearchProps{
+				StorageProps: &addon.StorageProps{
+					Name: "search",
+				},
+				InstanceType: "t3.small.search",
+			}),
+			outFileName: "opensearch.yml",
+		},
 	}
 
 	for name, tc := range testCases {
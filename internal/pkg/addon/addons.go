@@ -58,6 +58,12 @@ func New(wlName string) (*Addons, error) {
 	}, nil
 }
 
+// NewEnvironment creates an Addons object for the resources shared by all workloads in an environment.
+// The templates are read from the "environments/addons/" directory of the workspace.
+func NewEnvironment() (*Addons, error) {
+	return New(workspace.EnvironmentsDirName)
+}
+
 // Template merges CloudFormation templates under the "addons/" directory of a workload
 // into a single CloudFormation template and returns it.
 //
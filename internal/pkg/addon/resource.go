@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package addon
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resource represents a resource from a CloudFormation template.
+type Resource struct {
+	// LogicalID is the logical ID of the resource.
+	LogicalID string
+	// Type is the CloudFormation type of the resource such as "AWS::S3::Bucket".
+	Type string
+	// DeletionPolicy is the deletion policy of the resource such as "Retain", empty if it's not defined.
+	DeletionPolicy string
+}
+
+// Resources parses the Resources section of a CloudFormation template and returns them in the order they are declared.
+func Resources(template string) ([]Resource, error) {
+	var tpl struct {
+		Resources yaml.Node `yaml:"Resources"`
+	}
+	if err := yaml.Unmarshal([]byte(template), &tpl); err != nil {
+		return nil, fmt.Errorf("unmarshal addon cloudformation template: %w", err)
+	}
+	if tpl.Resources.Kind != yaml.MappingNode {
+		return nil, errors.New(`"Resources" field in cloudformation template is not a map`)
+	}
+
+	var resources []Resource
+	for _, content := range mappingContents(&tpl.Resources) {
+		fields := struct {
+			Type           string `yaml:"Type"`
+			DeletionPolicy string `yaml:"DeletionPolicy"`
+		}{}
+		if err := content.valueNode.Decode(&fields); err != nil {
+			return nil, fmt.Errorf(`decode resource "%s": %w`, content.keyNode.Value, err)
+		}
+		resources = append(resources, Resource{
+			LogicalID:      content.keyNode.Value,
+			Type:           fields.Type,
+			DeletionPolicy: fields.DeletionPolicy,
+		})
+	}
+	return resources, nil
+}
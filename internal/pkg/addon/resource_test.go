@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package addon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResources(t *testing.T) {
+	testCases := map[string]struct {
+		template string
+
+		wanted    []Resource
+		wantedErr error
+	}{
+		"returns an error if Resources is not defined as a map": {
+			template:  "Resources: hello",
+			wantedErr: errors.New(`"Resources" field in cloudformation template is not a map`),
+		},
+		"returns an error if a resource is not a map": {
+			template: `
+Resources:
+  Hello: World
+`,
+			wantedErr: errors.New(`decode resource "Hello"`),
+		},
+		"returns resources in the order they are declared": {
+			template: `
+Resources:
+  mybucketBucket:
+    Type: AWS::S3::Bucket
+  mybucketBucketPolicy:
+    Type: AWS::S3::BucketPolicy
+    DeletionPolicy: Retain
+  mybucketAccessPolicy:
+    Type: AWS::IAM::ManagedPolicy
+Outputs:
+  mybucketName:
+    Value: !Ref mybucketBucket
+`,
+			wanted: []Resource{
+				{
+					LogicalID: "mybucketBucket",
+					Type:      "AWS::S3::Bucket",
+				},
+				{
+					LogicalID:      "mybucketBucketPolicy",
+					Type:           "AWS::S3::BucketPolicy",
+					DeletionPolicy: "Retain",
+				},
+				{
+					LogicalID: "mybucketAccessPolicy",
+					Type:      "AWS::IAM::ManagedPolicy",
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			out, err := Resources(tc.template)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, out)
+			}
+		})
+	}
+}
@@ -6,26 +6,39 @@ package addon
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
 
 	"github.com/aws/copilot-cli/internal/pkg/template"
 )
 
 const (
-	dynamoDbTemplatePath = "addons/ddb/cf.yml"
-	s3TemplatePath       = "addons/s3/cf.yml"
-	rdsTemplatePath      = "addons/aurora/cf.yml"
-	rdsRDWSTemplatePath  = "addons/aurora/rdws/cf.yml"
-	rdsRDWSParamsPath    = "addons/aurora/rdws/addons.parameters.yml"
+	dynamoDbTemplatePath   = "addons/ddb/cf.yml"
+	s3TemplatePath         = "addons/s3/cf.yml"
+	rdsTemplatePath        = "addons/aurora/cf.yml"
+	rdsRDWSTemplatePath    = "addons/aurora/rdws/cf.yml"
+	rdsRDWSParamsPath      = "addons/aurora/rdws/addons.parameters.yml"
+	redisTemplatePath      = "addons/redis/cf.yml"
+	sqsTemplatePath        = "addons/sqs/cf.yml"
+	openSearchTemplatePath = "addons/opensearch/cf.yml"
 )
 
 const (
 	// Engine types for RDS Aurora Serverless.
 	RDSEngineTypeMySQL      = "MySQL"
 	RDSEngineTypePostgreSQL = "PostgreSQL"
+
+	// Versions of Aurora Serverless.
+	RDSServerlessVersionV1 = "v1"
+	RDSServerlessVersionV2 = "v2"
+
+	// Default capacity range of an Aurora Serverless v2 cluster.
+	defaultRDSMinCapacity = 0.5
+	defaultRDSMaxCapacity = 8
 )
 
 var regexpMatchAttribute = regexp.MustCompile(`^(\S+):([sbnSBN])`)
@@ -92,6 +105,57 @@ func (r *RDSTemplate) MarshalBinary() ([]byte, error) {
 	return content.Bytes(), nil
 }
 
+// RedisTemplate contains configuration options which fully describe an ElastiCache Redis replication group.
+// Implements the encoding.BinaryMarshaler interface.
+type RedisTemplate struct {
+	RedisProps
+
+	parser template.Parser
+}
+
+// MarshalBinary serializes the content of the template into binary.
+func (r *RedisTemplate) MarshalBinary() ([]byte, error) {
+	content, err := r.parser.Parse(redisTemplatePath, *r, template.WithFuncs(storageTemplateFunctions))
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// SQSTemplate contains configuration options which fully describe an SQS queue.
+// Implements the encoding.BinaryMarshaler interface.
+type SQSTemplate struct {
+	SQSProps
+
+	parser template.Parser
+}
+
+// MarshalBinary serializes the content of the template into binary.
+func (q *SQSTemplate) MarshalBinary() ([]byte, error) {
+	content, err := q.parser.Parse(sqsTemplatePath, *q, template.WithFuncs(storageTemplateFunctions))
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// OpenSearchTemplate contains configuration options which fully describe an OpenSearch domain.
+// Implements the encoding.BinaryMarshaler interface.
+type OpenSearchTemplate struct {
+	OpenSearchProps
+
+	parser template.Parser
+}
+
+// MarshalBinary serializes the content of the template into binary.
+func (o *OpenSearchTemplate) MarshalBinary() ([]byte, error) {
+	content, err := o.parser.Parse(openSearchTemplatePath, *o, template.WithFuncs(storageTemplateFunctions))
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
 // RDSParams represents the addons.parameters.yml file for a RDS Aurora Serverless cluster.
 type RDSParams struct {
 	parser template.Parser
@@ -106,7 +170,7 @@ func (r *RDSParams) MarshalBinary() ([]byte, error) {
 	return content.Bytes(), nil
 }
 
-// StorageProps holds basic input properties for addon.NewDDBTemplate() or addon.NewS3Template().
+// StorageProps holds basic input properties shared by the storage templates such as addon.NewDDBTemplate() or addon.NewS3Template().
 type StorageProps struct {
 	Name string
 }
@@ -130,9 +194,15 @@ type DynamoDBProps struct {
 	*StorageProps
 	Attributes   []DDBAttribute
 	LSIs         []DDBLocalSecondaryIndex
+	GSIs         []DDBGlobalSecondaryIndex
 	SortKey      *string
 	PartitionKey *string
 	HasLSI       bool
+
+	TTLAttribute        string                    // Name of the attribute that holds the expiry time of items, empty if TTL is disabled.
+	StreamViewType      string                    // Information written to the table's stream, empty if streams are disabled.
+	Provisioned         *DDBProvisionedThroughput // Provisioned capacity of the table, nil for on-demand billing.
+	PointInTimeRecovery bool                      // Whether continuous backups are enabled.
 }
 
 // NewDDBTemplate creates a DynamoDB cloudformation template specifying attributes,
@@ -149,14 +219,60 @@ func NewDDBTemplate(input *DynamoDBProps) *DynamoDBTemplate {
 type RDSProps struct {
 	WorkloadType   string   // The type of the workload associated with the RDS addon.
 	ClusterName    string   // The name of the cluster.
-	Engine         string   // The engine type of the RDS Aurora Serverless cluster.
+	Engine         string   // The engine type of the RDS Aurora cluster.
 	InitialDBName  string   // The name of the initial database created inside the cluster.
 	ParameterGroup string   // The parameter group to use for the cluster.
 	Envs           []string // The copilot environments found inside the current app.
+
+	ServerlessVersion string  // The version of Aurora Serverless, either "v1" or "v2". Defaults to "v2" unless InstanceClass is set.
+	MinCapacity       float64 // The minimum Aurora capacity units of a Serverless v2 cluster. Defaults to 0.5.
+	MaxCapacity       float64 // The maximum Aurora capacity units of a Serverless v2 cluster. Defaults to 8.
+	InstanceClass     string  // The instance class of the DB instances of a provisioned cluster.
+	ReaderCount       int     // The number of reader instances in a Serverless v2 or provisioned cluster.
+	Proxy             bool    // Whether to put an RDS Proxy in front of the cluster.
+}
+
+// IsServerlessV1 returns true if the cluster is an Aurora Serverless v1 cluster.
+func (p RDSProps) IsServerlessV1() bool {
+	return p.ServerlessVersion == RDSServerlessVersionV1
+}
+
+// IsServerlessV2 returns true if the cluster is an Aurora Serverless v2 cluster.
+func (p RDSProps) IsServerlessV2() bool {
+	return p.ServerlessVersion == RDSServerlessVersionV2
+}
+
+// DBInstanceClass returns the instance class of the DB instances of a Serverless v2 or provisioned cluster.
+func (p RDSProps) DBInstanceClass() string {
+	if p.IsServerlessV2() {
+		return "db.serverless"
+	}
+	return p.InstanceClass
+}
+
+// Readers returns the indices of the reader instances of the cluster, starting at 1.
+func (p RDSProps) Readers() []int {
+	readers := make([]int, p.ReaderCount)
+	for i := range readers {
+		readers[i] = i + 1
+	}
+	return readers
 }
 
 // NewRDSTemplate creates a new RDS marshaler which can be used to write a RDS CloudFormation template.
+// The cluster is an Aurora Serverless v2 cluster unless a serverless version or an instance class is specified.
 func NewRDSTemplate(input RDSProps) *RDSTemplate {
+	if input.ServerlessVersion == "" && input.InstanceClass == "" {
+		input.ServerlessVersion = RDSServerlessVersionV2
+	}
+	if input.IsServerlessV2() {
+		if input.MinCapacity == 0 {
+			input.MinCapacity = defaultRDSMinCapacity
+		}
+		if input.MaxCapacity == 0 {
+			input.MaxCapacity = math.Max(defaultRDSMaxCapacity, input.MinCapacity)
+		}
+	}
 	return &RDSTemplate{
 		RDSProps: input,
 
@@ -164,6 +280,51 @@ func NewRDSTemplate(input RDSProps) *RDSTemplate {
 	}
 }
 
+// RedisProps holds ElastiCache Redis-specific properties for addon.NewRedisTemplate().
+type RedisProps struct {
+	*StorageProps
+	NodeType string // The compute and memory capacity of the nodes in the replication group.
+}
+
+// NewRedisTemplate creates a new Redis marshaler which can be used to write an ElastiCache CloudFormation template.
+func NewRedisTemplate(input *RedisProps) *RedisTemplate {
+	return &RedisTemplate{
+		RedisProps: *input,
+
+		parser: template.New(),
+	}
+}
+
+// SQSProps holds SQS-specific properties for addon.NewSQSTemplate().
+type SQSProps struct {
+	*StorageProps
+	FIFO bool // Whether the queue is a first-in-first-out queue.
+}
+
+// NewSQSTemplate creates a new SQS marshaler which can be used to write an SQS queue CloudFormation template.
+func NewSQSTemplate(input *SQSProps) *SQSTemplate {
+	return &SQSTemplate{
+		SQSProps: *input,
+
+		parser: template.New(),
+	}
+}
+
+// OpenSearchProps holds OpenSearch-specific properties for addon.NewOpenSearchTemplate().
+type OpenSearchProps struct {
+	*StorageProps
+	InstanceType string // The instance type of the data nodes in the domain.
+}
+
+// NewOpenSearchTemplate creates a new OpenSearch marshaler which can be used to write an OpenSearch domain CloudFormation template.
+func NewOpenSearchTemplate(input *OpenSearchProps) *OpenSearchTemplate {
+	return &OpenSearchTemplate{
+		OpenSearchProps: *input,
+
+		parser: template.New(),
+	}
+}
+
 // NewRDSParams creates a new RDS parameters marshaler.
 func NewRDSParams() *RDSParams {
 	return &RDSParams{
@@ -227,6 +388,43 @@ func (p *DynamoDBProps) BuildLocalSecondaryIndex(noLSI bool, lsiSorts []string)
 	return true, nil
 }
 
+// BuildGlobalSecondaryIndexes generates the GlobalSecondaryIndexes property configuration from inputs of the form
+// "name=<indexName>,partition=<keyName>:<dataType>[,sort=<keyName>:<dataType>][,projection=<type>][,include=<attr>|<attr>]".
+// Attributes used as keys are added to the table's attribute definitions if they're not defined yet.
+func (p *DynamoDBProps) BuildGlobalSecondaryIndexes(gsis []string) error {
+	for _, input := range gsis {
+		gsi, err := DDBGlobalSecondaryIndexFromInput(input)
+		if err != nil {
+			return err
+		}
+		keys := []*DDBAttribute{gsi.partitionKeyAttr}
+		if gsi.sortKeyAttr != nil {
+			keys = append(keys, gsi.sortKeyAttr)
+		}
+		for _, key := range keys {
+			if err := p.addAttribute(*key); err != nil {
+				return err
+			}
+		}
+		p.GSIs = append(p.GSIs, gsi)
+	}
+	return nil
+}
+
+func (p *DynamoDBProps) addAttribute(attr DDBAttribute) error {
+	for _, existing := range p.Attributes {
+		if aws.StringValue(existing.Name) != aws.StringValue(attr.Name) {
+			continue
+		}
+		if aws.StringValue(existing.DataType) != aws.StringValue(attr.DataType) {
+			return fmt.Errorf("attribute %s is defined with both types %s and %s", aws.StringValue(attr.Name), aws.StringValue(existing.DataType), aws.StringValue(attr.DataType))
+		}
+		return nil
+	}
+	p.Attributes = append(p.Attributes, attr)
+	return nil
+}
+
 // DDBAttribute holds the attribute definition of a DynamoDB attribute (keys, local secondary indices).
 type DDBAttribute struct {
 	Name     *string
@@ -253,6 +451,97 @@ type DDBLocalSecondaryIndex struct {
 	Name         *string
 }
 
+// Projection types of a DynamoDB secondary index.
+const (
+	DDBProjectionTypeAll      = "ALL"
+	DDBProjectionTypeKeysOnly = "KEYS_ONLY"
+	DDBProjectionTypeInclude  = "INCLUDE"
+)
+
+// DDBProjectionTypes are the projection types supported by a global secondary index.
+var DDBProjectionTypes = []string{DDBProjectionTypeAll, DDBProjectionTypeKeysOnly, DDBProjectionTypeInclude}
+
+// DDBGlobalSecondaryIndex holds a representation of a GSI.
+type DDBGlobalSecondaryIndex struct {
+	Name             *string
+	PartitionKey     *string
+	SortKey          *string
+	ProjectionType   string   // One of DDBProjectionTypes.
+	NonKeyAttributes []string // Attributes projected into the index if ProjectionType is INCLUDE.
+
+	partitionKeyAttr *DDBAttribute
+	sortKeyAttr      *DDBAttribute
+}
+
+// DDBGlobalSecondaryIndexFromInput parses a GSI out of an input of the form
+// "name=byEmail,partition=Email:S,sort=CreatedAt:N,projection=INCLUDE,include=Name|Age".
+// The projection type defaults to ALL.
+func DDBGlobalSecondaryIndexFromInput(input string) (DDBGlobalSecondaryIndex, error) {
+	gsi := DDBGlobalSecondaryIndex{
+		ProjectionType: DDBProjectionTypeAll,
+	}
+	for _, field := range strings.Split(input, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: field %q must be of the form key=value", input, field)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch key {
+		case "name":
+			gsi.Name = aws.String(value)
+		case "partition":
+			attr, err := DDBAttributeFromKey(value)
+			if err != nil {
+				return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: %w", input, err)
+			}
+			gsi.partitionKeyAttr = &attr
+			gsi.PartitionKey = attr.Name
+		case "sort":
+			attr, err := DDBAttributeFromKey(value)
+			if err != nil {
+				return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: %w", input, err)
+			}
+			gsi.sortKeyAttr = &attr
+			gsi.SortKey = attr.Name
+		case "projection":
+			gsi.ProjectionType = strings.ToUpper(value)
+		case "include":
+			gsi.NonKeyAttributes = strings.Split(value, "|")
+		default:
+			return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: unknown field %q", input, key)
+		}
+	}
+	if gsi.Name == nil || gsi.PartitionKey == nil {
+		return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: name and partition are required", input)
+	}
+	switch gsi.ProjectionType {
+	case DDBProjectionTypeAll, DDBProjectionTypeKeysOnly:
+		if len(gsi.NonKeyAttributes) != 0 {
+			return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: include can only be used with projection %s", input, DDBProjectionTypeInclude)
+		}
+	case DDBProjectionTypeInclude:
+		if len(gsi.NonKeyAttributes) == 0 {
+			return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: projection %s requires attributes to include", input, DDBProjectionTypeInclude)
+		}
+	default:
+		return DDBGlobalSecondaryIndex{}, fmt.Errorf("parse global secondary index %s: projection must be one of %s", input, strings.Join(DDBProjectionTypes, ", "))
+	}
+	return gsi, nil
+}
+
+// DDBProvisionedThroughput holds the provisioned capacity of a DynamoDB table.
+type DDBProvisionedThroughput struct {
+	ReadCapacity     int
+	WriteCapacity    int
+	MaxReadCapacity  int // Read capacity is auto scaled up to this value if it's greater than ReadCapacity.
+	MaxWriteCapacity int // Write capacity is auto scaled up to this value if it's greater than WriteCapacity.
+}
+
+// HasAutoScaling returns true if the read or write capacity of the table should be auto scaled.
+func (t *DDBProvisionedThroughput) HasAutoScaling() bool {
+	return t.MaxReadCapacity > t.ReadCapacity || t.MaxWriteCapacity > t.WriteCapacity
+}
+
 func newLSI(partitionKey string, lsis []string) ([]DDBLocalSecondaryIndex, error) {
 	var output []DDBLocalSecondaryIndex
 	for _, lsi := range lsis {
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/aws/copilot-cli/internal/pkg/template/mocks"
 	"github.com/golang/mock/gomock"
@@ -108,6 +109,144 @@ func TestS3Template_MarshalBinary(t *testing.T) {
 	}
 }
 
+func TestRedisTemplate_MarshalBinary(t *testing.T) {
+	testCases := map[string]struct {
+		mockDependencies func(ctrl *gomock.Controller, redis *RedisTemplate)
+
+		wantedBinary []byte
+		wantedError  error
+	}{
+		"error parsing template": {
+			mockDependencies: func(ctrl *gomock.Controller, redis *RedisTemplate) {
+				m := mocks.NewMockParser(ctrl)
+				redis.parser = m
+				m.EXPECT().Parse(redisTemplatePath, *redis, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+
+			wantedError: errors.New("some error"),
+		},
+		"returns rendered content": {
+			mockDependencies: func(ctrl *gomock.Controller, redis *RedisTemplate) {
+				m := mocks.NewMockParser(ctrl)
+				redis.parser = m
+				m.EXPECT().Parse(redisTemplatePath, *redis, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("hello")}, nil)
+
+			},
+
+			wantedBinary: []byte("hello"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			addon := &RedisTemplate{}
+			tc.mockDependencies(ctrl, addon)
+
+			// WHEN
+			b, err := addon.MarshalBinary()
+
+			// THEN
+			require.Equal(t, tc.wantedError, err)
+			require.Equal(t, tc.wantedBinary, b)
+		})
+	}
+}
+
+func TestSQSTemplate_MarshalBinary(t *testing.T) {
+	testCases := map[string]struct {
+		mockDependencies func(ctrl *gomock.Controller, sqs *SQSTemplate)
+
+		wantedBinary []byte
+		wantedError  error
+	}{
+		"error parsing template": {
+			mockDependencies: func(ctrl *gomock.Controller, sqs *SQSTemplate) {
+				m := mocks.NewMockParser(ctrl)
+				sqs.parser = m
+				m.EXPECT().Parse(sqsTemplatePath, *sqs, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+
+			wantedError: errors.New("some error"),
+		},
+		"returns rendered content": {
+			mockDependencies: func(ctrl *gomock.Controller, sqs *SQSTemplate) {
+				m := mocks.NewMockParser(ctrl)
+				sqs.parser = m
+				m.EXPECT().Parse(sqsTemplatePath, *sqs, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("hello")}, nil)
+
+			},
+
+			wantedBinary: []byte("hello"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			addon := &SQSTemplate{}
+			tc.mockDependencies(ctrl, addon)
+
+			// WHEN
+			b, err := addon.MarshalBinary()
+
+			// THEN
+			require.Equal(t, tc.wantedError, err)
+			require.Equal(t, tc.wantedBinary, b)
+		})
+	}
+}
+
+func TestOpenSearchTemplate_MarshalBinary(t *testing.T) {
+	testCases := map[string]struct {
+		mockDependencies func(ctrl *gomock.Controller, os *OpenSearchTemplate)
+
+		wantedBinary []byte
+		wantedError  error
+	}{
+		"error parsing template": {
+			mockDependencies: func(ctrl *gomock.Controller, os *OpenSearchTemplate) {
+				m := mocks.NewMockParser(ctrl)
+				os.parser = m
+				m.EXPECT().Parse(openSearchTemplatePath, *os, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+
+			wantedError: errors.New("some error"),
+		},
+		"returns rendered content": {
+			mockDependencies: func(ctrl *gomock.Controller, os *OpenSearchTemplate) {
+				m := mocks.NewMockParser(ctrl)
+				os.parser = m
+				m.EXPECT().Parse(openSearchTemplatePath, *os, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("hello")}, nil)
+
+			},
+
+			wantedBinary: []byte("hello"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			addon := &OpenSearchTemplate{}
+			tc.mockDependencies(ctrl, addon)
+
+			// WHEN
+			b, err := addon.MarshalBinary()
+
+			// THEN
+			require.Equal(t, tc.wantedError, err)
+			require.Equal(t, tc.wantedBinary, b)
+		})
+	}
+}
+
 func TestRDSTemplate_MarshalBinary(t *testing.T) {
 	testCases := map[string]struct {
 		workloadType     string
@@ -494,3 +633,129 @@ func TestBuildLocalSecondaryIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestDDBGlobalSecondaryIndexFromInput(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+
+		wantedGSI   DDBGlobalSecondaryIndex
+		wantedError error
+	}{
+		"defaults to projecting all attributes": {
+			input: "name=byEmail,partition=Email:S",
+			wantedGSI: DDBGlobalSecondaryIndex{
+				Name:           aws.String("byEmail"),
+				PartitionKey:   aws.String("Email"),
+				ProjectionType: "ALL",
+			},
+		},
+		"parses every field": {
+			input: "name=byEmail, partition=Email:S, sort=CreatedAt:N, projection=include, include=Name|Age",
+			wantedGSI: DDBGlobalSecondaryIndex{
+				Name:             aws.String("byEmail"),
+				PartitionKey:     aws.String("Email"),
+				SortKey:          aws.String("CreatedAt"),
+				ProjectionType:   "INCLUDE",
+				NonKeyAttributes: []string{"Name", "Age"},
+			},
+		},
+		"error if field is malformed": {
+			input:       "name=byEmail,partition",
+			wantedError: fmt.Errorf(`parse global secondary index name=byEmail,partition: field "partition" must be of the form key=value`),
+		},
+		"error if field is unknown": {
+			input:       "name=byEmail,partition=Email:S,capacity=5",
+			wantedError: fmt.Errorf(`parse global secondary index name=byEmail,partition=Email:S,capacity=5: unknown field "capacity"`),
+		},
+		"error if partition key is missing": {
+			input:       "name=byEmail",
+			wantedError: fmt.Errorf("parse global secondary index name=byEmail: name and partition are required"),
+		},
+		"error if key has no type": {
+			input:       "name=byEmail,partition=Email",
+			wantedError: fmt.Errorf("parse global secondary index name=byEmail,partition=Email: parse attribute from key: Email"),
+		},
+		"error if include is used without INCLUDE projection": {
+			input:       "name=byEmail,partition=Email:S,include=Name",
+			wantedError: fmt.Errorf("parse global secondary index name=byEmail,partition=Email:S,include=Name: include can only be used with projection INCLUDE"),
+		},
+		"error if INCLUDE projection has no attributes": {
+			input:       "name=byEmail,partition=Email:S,projection=INCLUDE",
+			wantedError: fmt.Errorf("parse global secondary index name=byEmail,partition=Email:S,projection=INCLUDE: projection INCLUDE requires attributes to include"),
+		},
+		"error if projection is invalid": {
+			input:       "name=byEmail,partition=Email:S,projection=SOME",
+			wantedError: fmt.Errorf("parse global secondary index name=byEmail,partition=Email:S,projection=SOME: projection must be one of ALL, KEYS_ONLY, INCLUDE"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := DDBGlobalSecondaryIndexFromInput(tc.input)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedGSI.Name, got.Name)
+			require.Equal(t, tc.wantedGSI.PartitionKey, got.PartitionKey)
+			require.Equal(t, tc.wantedGSI.SortKey, got.SortKey)
+			require.Equal(t, tc.wantedGSI.ProjectionType, got.ProjectionType)
+			require.Equal(t, tc.wantedGSI.NonKeyAttributes, got.NonKeyAttributes)
+		})
+	}
+}
+
+func TestBuildGlobalSecondaryIndexes(t *testing.T) {
+	testCases := map[string]struct {
+		inAttributes []DDBAttribute
+		inGSIs       []string
+
+		wantedAttributes []DDBAttribute
+		wantedGSINames   []string
+		wantedError      error
+	}{
+		"adds new key attributes only once": {
+			inAttributes: []DDBAttribute{
+				{Name: aws.String("UserId"), DataType: aws.String("S")},
+			},
+			inGSIs: []string{
+				"name=byEmail,partition=Email:S,sort=UserId:S",
+				"name=byEmailDate,partition=Email:S,sort=CreatedAt:N",
+			},
+			wantedAttributes: []DDBAttribute{
+				{Name: aws.String("UserId"), DataType: aws.String("S")},
+				{Name: aws.String("Email"), DataType: aws.String("S")},
+				{Name: aws.String("CreatedAt"), DataType: aws.String("N")},
+			},
+			wantedGSINames: []string{"byEmail", "byEmailDate"},
+		},
+		"error if attribute is redefined with another type": {
+			inAttributes: []DDBAttribute{
+				{Name: aws.String("UserId"), DataType: aws.String("S")},
+			},
+			inGSIs:      []string{"name=byUser,partition=UserId:N"},
+			wantedError: fmt.Errorf("attribute UserId is defined with both types S and N"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			props := DynamoDBProps{
+				Attributes: tc.inAttributes,
+			}
+
+			err := props.BuildGlobalSecondaryIndexes(tc.inGSIs)
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedAttributes, props.Attributes)
+			var names []string
+			for _, gsi := range props.GSIs {
+				names = append(names, aws.StringValue(gsi.Name))
+			}
+			require.Equal(t, tc.wantedGSINames, names)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dynamodb provides a client to make API requests to Amazon DynamoDB.
+package dynamodb
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+type api interface {
+	CreateBackup(input *dynamodb.CreateBackupInput) (*dynamodb.CreateBackupOutput, error)
+}
+
+// DynamoDB wraps an Amazon DynamoDB client.
+type DynamoDB struct {
+	client api
+}
+
+// New returns a DynamoDB client configured against the input session.
+func New(s *session.Session) *DynamoDB {
+	return &DynamoDB{
+		client: dynamodb.New(s),
+	}
+}
+
+// CreateBackup creates an on-demand backup of a table and returns the ARN of the backup.
+func (d *DynamoDB) CreateBackup(table, backup string) (string, error) {
+	out, err := d.client.CreateBackup(&dynamodb.CreateBackupInput{
+		TableName:  aws.String(table),
+		BackupName: aws.String(backup),
+	})
+	if err != nil {
+		return "", fmt.Errorf("create backup %s of table %s: %w", backup, table, err)
+	}
+	return aws.StringValue(out.BackupDetails.BackupArn), nil
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package dynamodb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/copilot-cli/internal/pkg/aws/dynamodb/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamoDB_CreateBackup(t *testing.T) {
+	testCases := map[string]struct {
+		mockClient func(m *mocks.Mockapi)
+
+		wantedARN string
+		wantedErr error
+	}{
+		"wraps the error if the backup cannot be created": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateBackup(&dynamodb.CreateBackupInput{
+					TableName:  aws.String("users"),
+					BackupName: aws.String("users-backup"),
+				}).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("create backup users-backup of table users: some error"),
+		},
+		"returns the ARN of the backup": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateBackup(&dynamodb.CreateBackupInput{
+					TableName:  aws.String("users"),
+					BackupName: aws.String("users-backup"),
+				}).Return(&dynamodb.CreateBackupOutput{
+					BackupDetails: &dynamodb.BackupDetails{
+						BackupArn: aws.String("arn:aws:dynamodb:us-west-2:123456789012:table/users/backup/01234"),
+					},
+				}, nil)
+			},
+			wantedARN: "arn:aws:dynamodb:us-west-2:123456789012:table/users/backup/01234",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockapi(ctrl)
+			tc.mockClient(mockClient)
+			ddb := DynamoDB{
+				client: mockClient,
+			}
+
+			// WHEN
+			arn, err := ddb.CreateBackup("users", "users-backup")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedARN, arn)
+		})
+	}
+}
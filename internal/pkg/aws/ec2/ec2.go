@@ -17,6 +17,8 @@ const (
 	defaultForAZFilterName  = "default-for-az"
 	internetGatewayIDPrefix = "igw-"
 
+	cloudFrontPrefixListName = "com.amazonaws.global.cloudfront.origin-facing"
+
 	// TagFilterName is the filter name format for tag filters
 	TagFilterName = "tag:%s"
 )
@@ -37,6 +39,7 @@ type api interface {
 	DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
 	DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
 	DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+	DescribeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error)
 }
 
 // Filter contains the name and values of a filter.
@@ -226,6 +229,25 @@ func (c *EC2) HasDNSSupport(vpcID string) (bool, error) {
 	return aws.BoolValue(resp.EnableDnsSupport.Value), nil
 }
 
+// CloudFrontManagedPrefixListID returns the ID of the AWS managed prefix list for the CloudFront origin-facing servers.
+func (c *EC2) CloudFrontManagedPrefixListID() (string, error) {
+	resp, err := c.client.DescribeManagedPrefixLists(&ec2.DescribeManagedPrefixListsInput{
+		Filters: toEC2Filter([]Filter{
+			{
+				Name:   "prefix-list-name",
+				Values: []string{cloudFrontPrefixListName},
+			},
+		}),
+	})
+	if err != nil {
+		return "", fmt.Errorf("describe managed prefix list %s: %w", cloudFrontPrefixListName, err)
+	}
+	if len(resp.PrefixLists) == 0 {
+		return "", fmt.Errorf("cannot find managed prefix list %s", cloudFrontPrefixListName)
+	}
+	return aws.StringValue(resp.PrefixLists[0].PrefixListId), nil
+}
+
 // VPCSubnets are all subnets within a VPC.
 type VPCSubnets struct {
 	Public  []Subnet
@@ -797,3 +797,64 @@ func TestEC2_HasDNSSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestEC2_CloudFrontManagedPrefixListID(t *testing.T) {
+	testCases := map[string]struct {
+		mockEC2Client func(m *mocks.Mockapi)
+
+		wantedError error
+		wantedID    string
+	}{
+		"fail to describe managed prefix lists": {
+			mockEC2Client: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeManagedPrefixLists(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: fmt.Errorf("describe managed prefix list com.amazonaws.global.cloudfront.origin-facing: some error"),
+		},
+		"fail if the prefix list does not exist": {
+			mockEC2Client: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeManagedPrefixLists(gomock.Any()).Return(&ec2.DescribeManagedPrefixListsOutput{}, nil)
+			},
+			wantedError: fmt.Errorf("cannot find managed prefix list com.amazonaws.global.cloudfront.origin-facing"),
+		},
+		"success": {
+			mockEC2Client: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeManagedPrefixLists(&ec2.DescribeManagedPrefixListsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("prefix-list-name"),
+							Values: aws.StringSlice([]string{"com.amazonaws.global.cloudfront.origin-facing"}),
+						},
+					},
+				}).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{
+						{
+							PrefixListId: aws.String("pl-mockid"),
+						},
+					},
+				}, nil)
+			},
+			wantedID: "pl-mockid",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockAPI := mocks.NewMockapi(ctrl)
+			tc.mockEC2Client(mockAPI)
+
+			ec2Client := EC2{
+				client: mockAPI,
+			}
+
+			id, err := ec2Client.CloudFrontManagedPrefixListID()
+			if tc.wantedError != nil {
+				require.EqualError(t, tc.wantedError, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedID, id)
+			}
+		})
+	}
+}
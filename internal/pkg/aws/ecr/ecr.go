@@ -127,6 +127,46 @@ func (c ECR) ListImages(repoName string) ([]Image, error) {
 	return images, nil
 }
 
+// ErrImageNotFound is returned when no image in a repository has the requested tag.
+type ErrImageNotFound struct {
+	repoName string
+	tag      string
+}
+
+func (e *ErrImageNotFound) Error() string {
+	return fmt.Sprintf("image with tag %s not found in ecr repo %s", e.tag, e.repoName)
+}
+
+// ImageDigest calls the ECR DescribeImages API and returns the digest of the image tagged
+// with the input tag in the input ECR repository name.
+// If no image has the tag, it returns an ErrImageNotFound.
+func (c ECR) ImageDigest(repoName, tag string) (string, error) {
+	resp, err := c.client.DescribeImages(&ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repoName),
+		ImageIds: []*ecr.ImageIdentifier{
+			{
+				ImageTag: aws.String(tag),
+			},
+		},
+	})
+	if err != nil {
+		if isImageNotFoundErr(err) {
+			return "", &ErrImageNotFound{
+				repoName: repoName,
+				tag:      tag,
+			}
+		}
+		return "", fmt.Errorf("ecr repo %s describe image with tag %s: %w", repoName, tag, err)
+	}
+	if len(resp.ImageDetails) == 0 {
+		return "", &ErrImageNotFound{
+			repoName: repoName,
+			tag:      tag,
+		}
+	}
+	return aws.StringValue(resp.ImageDetails[0].ImageDigest), nil
+}
+
 // DeleteImages calls the ECR BatchDeleteImage API with the input image list and repository name.
 func (c ECR) DeleteImages(images []Image, repoName string) error {
 	if len(images) == 0 {
@@ -204,3 +244,11 @@ func isRepoNotFoundErr(err error) bool {
 	}
 	return false
 }
+
+func isImageNotFoundErr(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	return aerr.Code() == ecr.ErrCodeImageNotFoundException
+}
@@ -264,6 +264,76 @@ func TestListImages(t *testing.T) {
 	}
 }
 
+func TestImageDigest(t *testing.T) {
+	mockRepoName := "mockRepoName"
+	mockTag := "mockTag"
+	mockError := errors.New("mockError")
+	mockDigest := "mockDigest"
+	mockInput := &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(mockRepoName),
+		ImageIds: []*ecr.ImageIdentifier{
+			{
+				ImageTag: aws.String(mockTag),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		mockECRClient func(m *mocks.Mockapi)
+
+		wantDigest string
+		wantError  error
+	}{
+		"should wrap error returned by ECR DescribeImages": {
+			mockECRClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeImages(mockInput).Return(nil, mockError)
+			},
+			wantError: fmt.Errorf("ecr repo %s describe image with tag %s: %w", mockRepoName, mockTag, mockError),
+		},
+		"should return ErrImageNotFound if the tag does not exist": {
+			mockECRClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeImages(mockInput).Return(nil, awserr.New(ecr.ErrCodeImageNotFoundException, "not found", nil))
+			},
+			wantError: &ErrImageNotFound{
+				repoName: mockRepoName,
+				tag:      mockTag,
+			},
+		},
+		"should return the digest of the tagged image": {
+			mockECRClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeImages(mockInput).Return(&ecr.DescribeImagesOutput{
+					ImageDetails: []*ecr.ImageDetail{
+						{
+							ImageDigest: aws.String(mockDigest),
+						},
+					},
+				}, nil)
+			},
+			wantDigest: mockDigest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockECRAPI := mocks.NewMockapi(ctrl)
+			tc.mockECRClient(mockECRAPI)
+
+			client := ECR{
+				mockECRAPI,
+			}
+
+			gotDigest, gotError := client.ImageDigest(mockRepoName, mockTag)
+
+			require.Equal(t, tc.wantDigest, gotDigest)
+			require.Equal(t, tc.wantError, gotError)
+		})
+	}
+}
+
 func TestDeleteImages(t *testing.T) {
 	mockRepoName := "mockRepoName"
 	mockError := errors.New("mockError")
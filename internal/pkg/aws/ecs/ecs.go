@@ -188,6 +188,11 @@ func (e *ECS) RunningTasksInFamily(cluster, family string) ([]*Task, error) {
 	return e.listTasks(cluster, withFamily(family), withRunningTasks())
 }
 
+// StoppedTasksInFamily calls ECS API and returns stopped ECS tasks within the same task definition family.
+func (e *ECS) StoppedTasksInFamily(cluster, family string) ([]*Task, error) {
+	return e.listTasks(cluster, withFamily(family), withStoppedTasks())
+}
+
 // RunningTasks calls ECS API and returns ECS tasks with the desired status to be RUNNING.
 func (e *ECS) RunningTasks(cluster string) ([]*Task, error) {
 	return e.listTasks(cluster, withRunningTasks())
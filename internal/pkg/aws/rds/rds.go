@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rds provides a client to make API requests to Amazon Relational Database Service.
+package rds
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+type api interface {
+	CreateDBClusterSnapshot(input *rds.CreateDBClusterSnapshotInput) (*rds.CreateDBClusterSnapshotOutput, error)
+}
+
+// RDS wraps an Amazon Relational Database Service client.
+type RDS struct {
+	client api
+}
+
+// New returns an RDS client configured against the input session.
+func New(s *session.Session) *RDS {
+	return &RDS{
+		client: rds.New(s),
+	}
+}
+
+// CreateDBClusterSnapshot creates a snapshot of a DB cluster and returns the ARN of the snapshot.
+func (r *RDS) CreateDBClusterSnapshot(cluster, snapshot string) (string, error) {
+	out, err := r.client.CreateDBClusterSnapshot(&rds.CreateDBClusterSnapshotInput{
+		DBClusterIdentifier:         aws.String(cluster),
+		DBClusterSnapshotIdentifier: aws.String(snapshot),
+	})
+	if err != nil {
+		return "", fmt.Errorf("create snapshot %s of DB cluster %s: %w", snapshot, cluster, err)
+	}
+	return aws.StringValue(out.DBClusterSnapshot.DBClusterSnapshotArn), nil
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/copilot-cli/internal/pkg/aws/rds/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRDS_CreateDBClusterSnapshot(t *testing.T) {
+	testCases := map[string]struct {
+		mockClient func(m *mocks.Mockapi)
+
+		wantedARN string
+		wantedErr error
+	}{
+		"wraps the error if the snapshot cannot be created": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDBClusterSnapshot(&rds.CreateDBClusterSnapshotInput{
+					DBClusterIdentifier:         aws.String("mycluster"),
+					DBClusterSnapshotIdentifier: aws.String("mycluster-snapshot"),
+				}).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("create snapshot mycluster-snapshot of DB cluster mycluster: some error"),
+		},
+		"returns the ARN of the snapshot": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDBClusterSnapshot(&rds.CreateDBClusterSnapshotInput{
+					DBClusterIdentifier:         aws.String("mycluster"),
+					DBClusterSnapshotIdentifier: aws.String("mycluster-snapshot"),
+				}).Return(&rds.CreateDBClusterSnapshotOutput{
+					DBClusterSnapshot: &rds.DBClusterSnapshot{
+						DBClusterSnapshotArn: aws.String("arn:aws:rds:us-west-2:123456789012:cluster-snapshot:mycluster-snapshot"),
+					},
+				}, nil)
+			},
+			wantedARN: "arn:aws:rds:us-west-2:123456789012:cluster-snapshot:mycluster-snapshot",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockapi(ctrl)
+			tc.mockClient(mockClient)
+			client := RDS{
+				client: mockClient,
+			}
+
+			// WHEN
+			arn, err := client.CreateDBClusterSnapshot("mycluster", "mycluster-snapshot")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedARN, arn)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net/url"
 	"strings"
 	
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -19,7 +20,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
-const notFound = "NotFound"
+const (
+	notFound = "NotFound"
+	// S3 EndpointsID
+	EndpointsID = s3.EndpointsID
+)
 
 type s3ManagerAPI interface {
 	Upload(input *s3manager.UploadInput, options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error)
@@ -29,6 +34,9 @@ type s3API interface {
 	ListObjectVersions(input *s3.ListObjectVersionsInput) (*s3.ListObjectVersionsOutput, error)
 	DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
 	HeadBucket(input *s3.HeadBucketInput) (*s3.HeadBucketOutput, error)
+	CreateBucket(input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error)
+	ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
+	CopyObject(input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error)
 }
 
 // NamedBinary is a named binary to be uploaded.
@@ -44,6 +52,7 @@ type CompressAndUploadFunc func(key string, objects ...NamedBinary) (url string,
 type S3 struct {
 	s3Manager s3ManagerAPI
 	s3Client  s3API
+	region    string
 }
 
 // New returns an S3 client configured against the input session.
@@ -51,6 +60,7 @@ func New(s *session.Session) *S3 {
 	return &S3{
 		s3Manager: s3manager.NewUploader(s),
 		s3Client:  s3.New(s),
+		region:    aws.StringValue(s.Config.Region),
 	}
 }
 
@@ -142,6 +152,46 @@ func (s *S3) EmptyBucket(bucket string) error {
 	}
 }
 
+// CopyBucket creates a new bucket named dst in the same region as the client, and copies the latest version
+// of every object in the src bucket to it.
+func (s *S3) CopyBucket(src, dst string) error {
+	in := &s3.CreateBucketInput{
+		Bucket: aws.String(dst),
+	}
+	if s.region != "" && s.region != "us-east-1" {
+		// Buckets in us-east-1 must not specify a location constraint.
+		in.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(s.region),
+		}
+	}
+	if _, err := s.s3Client.CreateBucket(in); err != nil {
+		return fmt.Errorf("create bucket %s: %w", dst, err)
+	}
+	listParams := &s3.ListObjectsV2Input{
+		Bucket: aws.String(src),
+	}
+	for {
+		listResp, err := s.s3Client.ListObjectsV2(listParams)
+		if err != nil {
+			return fmt.Errorf("list objects for bucket %s: %w", src, err)
+		}
+		for _, object := range listResp.Contents {
+			key := aws.StringValue(object.Key)
+			if _, err := s.s3Client.CopyObject(&s3.CopyObjectInput{
+				Bucket:     aws.String(dst),
+				Key:        object.Key,
+				CopySource: aws.String(url.PathEscape(src + "/" + key)),
+			}); err != nil {
+				return fmt.Errorf("copy object %s from bucket %s to %s: %w", key, src, dst, err)
+			}
+		}
+		if !aws.BoolValue(listResp.IsTruncated) {
+			return nil
+		}
+		listParams.ContinuationToken = listResp.NextContinuationToken
+	}
+}
+
 // ParseURL parses S3 object URL and returns the bucket name and the key.
 // For example: https://stackset-myapp-infrastru-pipelinebuiltartifactbuc-1nk5t9zkymh8r.s3-us-west-2.amazonaws.com/scripts/dns-cert-validator/dd2278811c3
 // returns "stackset-myapp-infrastru-pipelinebuiltartifactbuc-1nk5t9zkymh8r" and
@@ -375,6 +375,98 @@ func TestS3_EmptyBucket(t *testing.T) {
 	}
 }
 
+func TestS3_CopyBucket(t *testing.T) {
+	testCases := map[string]struct {
+		inRegion     string
+		mockS3Client func(m *mocks.Mocks3API)
+
+		wantErr error
+	}{
+		"should wrap the error if the bucket cannot be created": {
+			inRegion: "us-east-1",
+			mockS3Client: func(m *mocks.Mocks3API) {
+				m.EXPECT().CreateBucket(&s3.CreateBucketInput{
+					Bucket: aws.String("mockBucket-snapshot"),
+				}).Return(nil, errors.New("some error"))
+			},
+
+			wantErr: errors.New("create bucket mockBucket-snapshot: some error"),
+		},
+		"should wrap the error if an object cannot be copied": {
+			inRegion: "us-west-2",
+			mockS3Client: func(m *mocks.Mocks3API) {
+				m.EXPECT().CreateBucket(&s3.CreateBucketInput{
+					Bucket: aws.String("mockBucket-snapshot"),
+					CreateBucketConfiguration: &s3.CreateBucketConfiguration{
+						LocationConstraint: aws.String("us-west-2"),
+					},
+				}).Return(&s3.CreateBucketOutput{}, nil)
+				m.EXPECT().ListObjectsV2(&s3.ListObjectsV2Input{
+					Bucket: aws.String("mockBucket"),
+				}).Return(&s3.ListObjectsV2Output{
+					Contents: []*s3.Object{{Key: aws.String("mockKey")}},
+				}, nil)
+				m.EXPECT().CopyObject(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+
+			wantErr: errors.New("copy object mockKey from bucket mockBucket to mockBucket-snapshot: some error"),
+		},
+		"should copy every page of objects": {
+			inRegion: "us-west-2",
+			mockS3Client: func(m *mocks.Mocks3API) {
+				m.EXPECT().CreateBucket(gomock.Any()).Return(&s3.CreateBucketOutput{}, nil)
+				m.EXPECT().ListObjectsV2(&s3.ListObjectsV2Input{
+					Bucket: aws.String("mockBucket"),
+				}).Return(&s3.ListObjectsV2Output{
+					Contents:              []*s3.Object{{Key: aws.String("images/cat 1.png")}},
+					IsTruncated:           aws.Bool(true),
+					NextContinuationToken: aws.String("mockToken"),
+				}, nil)
+				m.EXPECT().CopyObject(&s3.CopyObjectInput{
+					Bucket:     aws.String("mockBucket-snapshot"),
+					Key:        aws.String("images/cat 1.png"),
+					CopySource: aws.String("mockBucket%2Fimages%2Fcat%201.png"),
+				}).Return(&s3.CopyObjectOutput{}, nil)
+				m.EXPECT().ListObjectsV2(&s3.ListObjectsV2Input{
+					Bucket:            aws.String("mockBucket"),
+					ContinuationToken: aws.String("mockToken"),
+				}).Return(&s3.ListObjectsV2Output{
+					Contents: []*s3.Object{{Key: aws.String("mockKey")}},
+				}, nil)
+				m.EXPECT().CopyObject(&s3.CopyObjectInput{
+					Bucket:     aws.String("mockBucket-snapshot"),
+					Key:        aws.String("mockKey"),
+					CopySource: aws.String("mockBucket%2FmockKey"),
+				}).Return(&s3.CopyObjectOutput{}, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockS3Client := mocks.NewMocks3API(ctrl)
+			tc.mockS3Client(mockS3Client)
+
+			service := S3{
+				s3Client: mockS3Client,
+				region:   tc.inRegion,
+			}
+
+			gotErr := service.CopyBucket("mockBucket", "mockBucket-snapshot")
+
+			if tc.wantErr != nil {
+				require.EqualError(t, gotErr, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
 func TestS3_ParseURL(t *testing.T) {
 	testCases := map[string]struct {
 		inURL string
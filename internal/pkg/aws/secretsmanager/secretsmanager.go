@@ -5,7 +5,9 @@
 package secretsmanager
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -19,6 +21,9 @@ type api interface {
 	CreateSecret(*secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error)
 	DeleteSecret(*secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error)
 	DescribeSecret(input *secretsmanager.DescribeSecretInput) (*secretsmanager.DescribeSecretOutput, error)
+	PutSecretValue(input *secretsmanager.PutSecretValueInput) (*secretsmanager.PutSecretValueOutput, error)
+	TagResource(input *secretsmanager.TagResourceInput) (*secretsmanager.TagResourceOutput, error)
+	RotateSecret(input *secretsmanager.RotateSecretInput) (*secretsmanager.RotateSecretOutput, error)
 }
 
 // SecretsManager wraps the AWS SecretManager client.
@@ -83,6 +88,98 @@ func (s *SecretsManager) DeleteSecret(secretName string) error {
 	return nil
 }
 
+// PutSecretInput contains fields needed to create or update a secret.
+type PutSecretInput struct {
+	Name      string
+	Value     string
+	Overwrite bool
+	Tags      map[string]string
+}
+
+// PutSecretOutput contains the ARN of the secret and whether an existing secret was overwritten.
+type PutSecretOutput struct {
+	ARN         string
+	Overwritten bool
+}
+
+// PutSecret tries to create the secret with the tags, and overwrites its value if the secret exists and `Overwrite` is true.
+// ErrSecretAlreadyExists is returned if the secret exists and `Overwrite` is false.
+func (s *SecretsManager) PutSecret(in PutSecretInput) (*PutSecretOutput, error) {
+	resp, err := s.secretsManager.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         aws.String(in.Name),
+		SecretString: aws.String(in.Value),
+		Tags:         convertTags(in.Tags),
+	})
+	if err == nil {
+		return &PutSecretOutput{
+			ARN: aws.StringValue(resp.ARN),
+		}, nil
+	}
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) || aerr.Code() != secretsmanager.ErrCodeResourceExistsException {
+		return nil, fmt.Errorf("create secret %s: %w", in.Name, err)
+	}
+	if !in.Overwrite {
+		return nil, &ErrSecretAlreadyExists{
+			secretName: in.Name,
+			parentErr:  err,
+		}
+	}
+
+	out, err := s.secretsManager.PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(in.Name),
+		SecretString: aws.String(in.Value),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update secret %s: %w", in.Name, err)
+	}
+	if _, err := s.secretsManager.TagResource(&secretsmanager.TagResourceInput{
+		SecretId: aws.String(in.Name),
+		Tags:     convertTags(in.Tags),
+	}); err != nil {
+		return nil, fmt.Errorf("add tags to secret %s: %w", in.Name, err)
+	}
+	return &PutSecretOutput{
+		ARN:         aws.StringValue(out.ARN),
+		Overwritten: true,
+	}, nil
+}
+
+// RotateSecret configures the Lambda function that rotates the secret every number of days.
+// The secret is not rotated immediately, so that it keeps its current value until the first scheduled rotation.
+func (s *SecretsManager) RotateSecret(secretName, lambdaARN string, days int64) error {
+	_, err := s.secretsManager.RotateSecret(&secretsmanager.RotateSecretInput{
+		SecretId:          aws.String(secretName),
+		RotationLambdaARN: aws.String(lambdaARN),
+		RotationRules: &secretsmanager.RotationRulesType{
+			AutomaticallyAfterDays: aws.Int64(days),
+		},
+		RotateImmediately: aws.Bool(false),
+	})
+	if err != nil {
+		return fmt.Errorf("configure rotation of secret %s: %w", secretName, err)
+	}
+	return nil
+}
+
+func convertTags(in map[string]string) []*secretsmanager.Tag {
+	// Sort the map so that the unit test won't be flaky.
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var tags []*secretsmanager.Tag
+	for _, key := range keys {
+		tags = append(tags, &secretsmanager.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(in[key]),
+		})
+	}
+	return tags
+}
+
 type DescribeSecretOutput struct {
 	Name        *string
 	CreatedDate *time.Time
@@ -251,3 +251,159 @@ func TestSecretsManager_DescribeSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretsManager_PutSecret(t *testing.T) {
+	mockInput := PutSecretInput{
+		Name:  "copilot/myapp/myenv/secrets/db",
+		Value: `{"username":"admin","password":"hunter2"}`,
+		Tags: map[string]string{
+			"copilot-environment": "myenv",
+			"copilot-application": "myapp",
+		},
+	}
+	mockTags := []*secretsmanager.Tag{
+		{
+			Key:   aws.String("copilot-application"),
+			Value: aws.String("myapp"),
+		},
+		{
+			Key:   aws.String("copilot-environment"),
+			Value: aws.String("myenv"),
+		},
+	}
+	mockExistsErr := awserr.New(secretsmanager.ErrCodeResourceExistsException, "", nil)
+
+	tests := map[string]struct {
+		inOverwrite bool
+		callMock    func(m *mocks.Mockapi)
+
+		wantedOutput *PutSecretOutput
+		wantedError  error
+	}{
+		"creates the secret with the tags": {
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateSecret(&secretsmanager.CreateSecretInput{
+					Name:         aws.String(mockInput.Name),
+					SecretString: aws.String(mockInput.Value),
+					Tags:         mockTags,
+				}).Return(&secretsmanager.CreateSecretOutput{ARN: aws.String("arn:secret")}, nil)
+			},
+			wantedOutput: &PutSecretOutput{ARN: "arn:secret"},
+		},
+		"wraps the error if the secret can't be created": {
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateSecret(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("create secret copilot/myapp/myenv/secrets/db: some error"),
+		},
+		"returns ErrSecretAlreadyExists if the secret exists without overwrite": {
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateSecret(gomock.Any()).Return(nil, mockExistsErr)
+			},
+			wantedError: &ErrSecretAlreadyExists{
+				secretName: mockInput.Name,
+				parentErr:  mockExistsErr,
+			},
+		},
+		"overwrites the value and the tags of an existing secret": {
+			inOverwrite: true,
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateSecret(gomock.Any()).Return(nil, mockExistsErr)
+				m.EXPECT().PutSecretValue(&secretsmanager.PutSecretValueInput{
+					SecretId:     aws.String(mockInput.Name),
+					SecretString: aws.String(mockInput.Value),
+				}).Return(&secretsmanager.PutSecretValueOutput{ARN: aws.String("arn:secret")}, nil)
+				m.EXPECT().TagResource(&secretsmanager.TagResourceInput{
+					SecretId: aws.String(mockInput.Name),
+					Tags:     mockTags,
+				}).Return(&secretsmanager.TagResourceOutput{}, nil)
+			},
+			wantedOutput: &PutSecretOutput{ARN: "arn:secret", Overwritten: true},
+		},
+		"wraps the error if the secret can't be tagged": {
+			inOverwrite: true,
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateSecret(gomock.Any()).Return(nil, mockExistsErr)
+				m.EXPECT().PutSecretValue(gomock.Any()).Return(&secretsmanager.PutSecretValueOutput{}, nil)
+				m.EXPECT().TagResource(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("add tags to secret copilot/myapp/myenv/secrets/db: some error"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSecretsManager := mocks.NewMockapi(ctrl)
+			sm := SecretsManager{
+				secretsManager: mockSecretsManager,
+			}
+			tc.callMock(mockSecretsManager)
+			in := mockInput
+			in.Overwrite = tc.inOverwrite
+
+			// WHEN
+			out, err := sm.PutSecret(in)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedOutput, out)
+		})
+	}
+}
+
+func TestSecretsManager_RotateSecret(t *testing.T) {
+	tests := map[string]struct {
+		callMock func(m *mocks.Mockapi)
+
+		wantedError error
+	}{
+		"configures the rotation without rotating immediately": {
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().RotateSecret(&secretsmanager.RotateSecretInput{
+					SecretId:          aws.String("copilot/myapp/myenv/secrets/db"),
+					RotationLambdaARN: aws.String("arn:aws:lambda:us-west-2:123456789012:function:rotate"),
+					RotationRules: &secretsmanager.RotationRulesType{
+						AutomaticallyAfterDays: aws.Int64(30),
+					},
+					RotateImmediately: aws.Bool(false),
+				}).Return(&secretsmanager.RotateSecretOutput{}, nil)
+			},
+		},
+		"wraps the error": {
+			callMock: func(m *mocks.Mockapi) {
+				m.EXPECT().RotateSecret(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("configure rotation of secret copilot/myapp/myenv/secrets/db: some error"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSecretsManager := mocks.NewMockapi(ctrl)
+			sm := SecretsManager{
+				secretsManager: mockSecretsManager,
+			}
+			tc.callMock(mockSecretsManager)
+
+			// WHEN
+			err := sm.RotateSecret("copilot/myapp/myenv/secrets/db", "arn:aws:lambda:us-west-2:123456789012:function:rotate", 30)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
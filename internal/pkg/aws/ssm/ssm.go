@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
@@ -19,6 +20,9 @@ import (
 type api interface {
 	PutParameter(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
 	AddTagsToResource(input *ssm.AddTagsToResourceInput) (*ssm.AddTagsToResourceOutput, error)
+	DescribeParameters(input *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error)
+	GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	DeleteParameter(input *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error)
 }
 
 // SSM wraps an AWS SSM client.
@@ -115,6 +119,107 @@ func (s *SSM) overwriteSecret(in PutSecretInput) (*PutSecretOutput, error) {
 	return (*PutSecretOutput)(output), nil
 }
 
+// SecretMetadata holds the metadata of a secret.
+type SecretMetadata struct {
+	Name             string
+	Version          int64
+	LastModified     time.Time
+	LastModifiedUser string
+}
+
+// Secret holds a secret and its value.
+type Secret struct {
+	SecretMetadata
+	Value string
+}
+
+// ListSecrets returns the metadata of the secrets that have all the tags.
+func (s *SSM) ListSecrets(tags map[string]string) ([]SecretMetadata, error) {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var filters []*ssm.ParameterStringFilter
+	for _, key := range keys {
+		filters = append(filters, &ssm.ParameterStringFilter{
+			Key:    aws.String(fmt.Sprintf("tag:%s", key)),
+			Option: aws.String("Equals"),
+			Values: aws.StringSlice([]string{tags[key]}),
+		})
+	}
+
+	var secrets []SecretMetadata
+	var nextToken *string
+	for {
+		out, err := s.client.DescribeParameters(&ssm.DescribeParametersInput{
+			ParameterFilters: filters,
+			NextToken:        nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe parameters: %w", err)
+		}
+		for _, param := range out.Parameters {
+			secrets = append(secrets, SecretMetadata{
+				Name:             aws.StringValue(param.Name),
+				Version:          aws.Int64Value(param.Version),
+				LastModified:     aws.TimeValue(param.LastModifiedDate),
+				LastModifiedUser: aws.StringValue(param.LastModifiedUser),
+			})
+		}
+		if out.NextToken == nil {
+			break
+		}
+		nextToken = out.NextToken
+	}
+	return secrets, nil
+}
+
+// GetSecret returns the secret. The value of the secret is only decrypted if withDecryption is true.
+// ErrParameterNotFound is returned if the secret doesn't exist.
+func (s *SSM) GetSecret(name string, withDecryption bool) (*Secret, error) {
+	out, err := s.client.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(withDecryption),
+	})
+	if err != nil {
+		if isParameterNotFound(err) {
+			return nil, &ErrParameterNotFound{name}
+		}
+		return nil, fmt.Errorf("get parameter %s: %w", name, err)
+	}
+	return &Secret{
+		SecretMetadata: SecretMetadata{
+			Name:         aws.StringValue(out.Parameter.Name),
+			Version:      aws.Int64Value(out.Parameter.Version),
+			LastModified: aws.TimeValue(out.Parameter.LastModifiedDate),
+		},
+		Value: aws.StringValue(out.Parameter.Value),
+	}, nil
+}
+
+// DeleteSecret deletes the secret.
+// ErrParameterNotFound is returned if the secret doesn't exist.
+func (s *SSM) DeleteSecret(name string) error {
+	_, err := s.client.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		if isParameterNotFound(err) {
+			return &ErrParameterNotFound{name}
+		}
+		return fmt.Errorf("delete parameter %s: %w", name, err)
+	}
+	return nil
+}
+
+func isParameterNotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == ssm.ErrCodeParameterNotFound
+	}
+	return false
+}
+
 func convertTags(inTags map[string]string) []*ssm.Tag {
 	// Sort the map so that the unit test won't be flaky.
 	keys := make([]string, 0, len(inTags))
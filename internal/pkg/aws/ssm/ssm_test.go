@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
@@ -361,3 +362,220 @@ func TestSSM_PutSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestSSM_ListSecrets(t *testing.T) {
+	mockTime := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockFilters := []*ssm.ParameterStringFilter{
+		{
+			Key:    aws.String("tag:copilot-application"),
+			Option: aws.String("Equals"),
+			Values: aws.StringSlice([]string{"myapp"}),
+		},
+		{
+			Key:    aws.String("tag:copilot-environment"),
+			Option: aws.String("Equals"),
+			Values: aws.StringSlice([]string{"myenv"}),
+		},
+	}
+	testCases := map[string]struct {
+		mockClient func(*mocks.Mockapi)
+
+		wantedSecrets []SecretMetadata
+		wantedError   error
+	}{
+		"wraps the error if the parameters can't be described": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeParameters(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("describe parameters: some error"),
+		},
+		"returns the secrets of every page": {
+			mockClient: func(m *mocks.Mockapi) {
+				gomock.InOrder(
+					m.EXPECT().DescribeParameters(&ssm.DescribeParametersInput{
+						ParameterFilters: mockFilters,
+					}).Return(&ssm.DescribeParametersOutput{
+						Parameters: []*ssm.ParameterMetadata{
+							{
+								Name:             aws.String("/copilot/myapp/myenv/secrets/db_password"),
+								Version:          aws.Int64(2),
+								LastModifiedDate: aws.Time(mockTime),
+								LastModifiedUser: aws.String("arn:aws:iam::123456789012:user/alice"),
+							},
+						},
+						NextToken: aws.String("token"),
+					}, nil),
+					m.EXPECT().DescribeParameters(&ssm.DescribeParametersInput{
+						ParameterFilters: mockFilters,
+						NextToken:        aws.String("token"),
+					}).Return(&ssm.DescribeParametersOutput{
+						Parameters: []*ssm.ParameterMetadata{
+							{
+								Name:             aws.String("/copilot/myapp/myenv/secrets/api_key"),
+								Version:          aws.Int64(1),
+								LastModifiedDate: aws.Time(mockTime),
+							},
+						},
+					}, nil),
+				)
+			},
+			wantedSecrets: []SecretMetadata{
+				{
+					Name:             "/copilot/myapp/myenv/secrets/db_password",
+					Version:          2,
+					LastModified:     mockTime,
+					LastModifiedUser: "arn:aws:iam::123456789012:user/alice",
+				},
+				{
+					Name:         "/copilot/myapp/myenv/secrets/api_key",
+					Version:      1,
+					LastModified: mockTime,
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSSMClient := mocks.NewMockapi(ctrl)
+			client := SSM{
+				client: mockSSMClient,
+			}
+			tc.mockClient(mockSSMClient)
+
+			got, err := client.ListSecrets(map[string]string{
+				deploy.AppTagKey: "myapp",
+				deploy.EnvTagKey: "myenv",
+			})
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedSecrets, got)
+			}
+		})
+	}
+}
+
+func TestSSM_GetSecret(t *testing.T) {
+	mockTime := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		inWithDecryption bool
+		mockClient       func(*mocks.Mockapi)
+
+		wantedSecret *Secret
+		wantedError  error
+	}{
+		"returns ErrParameterNotFound if the secret doesn't exist": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().GetParameter(gomock.Any()).Return(nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil))
+			},
+			wantedError: &ErrParameterNotFound{name: "/copilot/myapp/myenv/secrets/db_password"},
+		},
+		"wraps other errors": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().GetParameter(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("get parameter /copilot/myapp/myenv/secrets/db_password: some error"),
+		},
+		"returns the decrypted secret": {
+			inWithDecryption: true,
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().GetParameter(&ssm.GetParameterInput{
+					Name:           aws.String("/copilot/myapp/myenv/secrets/db_password"),
+					WithDecryption: aws.Bool(true),
+				}).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Name:             aws.String("/copilot/myapp/myenv/secrets/db_password"),
+						Version:          aws.Int64(3),
+						LastModifiedDate: aws.Time(mockTime),
+						Value:            aws.String("super secure password"),
+					},
+				}, nil)
+			},
+			wantedSecret: &Secret{
+				SecretMetadata: SecretMetadata{
+					Name:         "/copilot/myapp/myenv/secrets/db_password",
+					Version:      3,
+					LastModified: mockTime,
+				},
+				Value: "super secure password",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSSMClient := mocks.NewMockapi(ctrl)
+			client := SSM{
+				client: mockSSMClient,
+			}
+			tc.mockClient(mockSSMClient)
+
+			got, err := client.GetSecret("/copilot/myapp/myenv/secrets/db_password", tc.inWithDecryption)
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedSecret, got)
+			}
+		})
+	}
+}
+
+func TestSSM_DeleteSecret(t *testing.T) {
+	testCases := map[string]struct {
+		mockClient func(*mocks.Mockapi)
+
+		wantedError error
+	}{
+		"returns ErrParameterNotFound if the secret doesn't exist": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DeleteParameter(gomock.Any()).Return(nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil))
+			},
+			wantedError: &ErrParameterNotFound{name: "/copilot/myapp/myenv/secrets/db_password"},
+		},
+		"wraps other errors": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DeleteParameter(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("delete parameter /copilot/myapp/myenv/secrets/db_password: some error"),
+		},
+		"deletes the secret": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DeleteParameter(&ssm.DeleteParameterInput{
+					Name: aws.String("/copilot/myapp/myenv/secrets/db_password"),
+				}).Return(&ssm.DeleteParameterOutput{}, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSSMClient := mocks.NewMockapi(ctrl)
+			client := SSM{
+				client: mockSSMClient,
+			}
+			tc.mockClient(mockSSMClient)
+
+			err := client.DeleteSecret("/copilot/myapp/myenv/secrets/db_password")
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -40,6 +40,8 @@ type initAppVars struct {
 	name         string
 	domainName   string
 	resourceTags map[string]string
+
+	deploymentRetention int
 }
 
 type initAppOpts struct {
@@ -87,6 +89,9 @@ func newInitAppOpts(vars initAppVars) (*initAppOpts, error) {
 
 // Validate returns an error if the user's input is invalid.
 func (o *initAppOpts) Validate() error {
+	if o.deploymentRetention < 0 {
+		return fmt.Errorf("--%s cannot be negative", deploymentRetentionFlag)
+	}
 	if o.name != "" {
 		if err := o.validateAppName(o.name); err != nil {
 			return err
@@ -211,6 +216,8 @@ func (o *initAppOpts) Execute() error {
 		Domain:             o.domainName,
 		DomainHostedZoneID: hostedZoneID,
 		Tags:               o.resourceTags,
+
+		DeploymentRetention: o.deploymentRetention,
 	}); err != nil {
 		return err
 	}
@@ -319,7 +326,9 @@ An application is a collection of containerized services that operate together.`
   Create a new application with an existing domain name in Amazon Route53.
   /code $ copilot app init --domain example.com
   Create a new application with resource tags.
-  /code $ copilot app init --resource-tags department=MyDept,team=MyTeam`,
+  /code $ copilot app init --resource-tags department=MyDept,team=MyTeam
+  Create a new application that keeps the 50 most recent deployments of each workload in each environment.
+  /code $ copilot app init --deployment-retention 50`,
 		Args: reservedArgs,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newInitAppOpts(vars)
@@ -334,5 +343,6 @@ An application is a collection of containerized services that operate together.`
 	}
 	cmd.Flags().StringVar(&vars.domainName, domainNameFlag, "", domainNameFlagDescription)
 	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
+	cmd.Flags().IntVar(&vars.deploymentRetention, deploymentRetentionFlag, 0, deploymentRetentionFlagDescription)
 	return cmd
 }
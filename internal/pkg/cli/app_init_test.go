@@ -27,8 +27,9 @@ type initAppMocks struct {
 
 func TestInitAppOpts_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		inAppName    string
-		inDomainName string
+		inAppName             string
+		inDomainName          string
+		inDeploymentRetention int
 
 		mock func(m *initAppMocks)
 
@@ -37,6 +38,12 @@ func TestInitAppOpts_Validate(t *testing.T) {
 		"skip everything": {
 			mock: func(m *initAppMocks) {},
 		},
+		"invalid deployment retention": {
+			inDeploymentRetention: -1,
+			mock:                  func(m *initAppMocks) {},
+
+			wantedError: errors.New("--deployment-retention cannot be negative"),
+		},
 		"valid app name": {
 			inAppName: "metrics",
 			mock: func(m *initAppMocks) {
@@ -141,8 +148,9 @@ func TestInitAppOpts_Validate(t *testing.T) {
 				domainInfoGetter: m.mockDomainInfoGetter,
 				store:            m.mockStore,
 				initAppVars: initAppVars{
-					name:       tc.inAppName,
-					domainName: tc.inDomainName,
+					name:                tc.inAppName,
+					domainName:          tc.inDomainName,
+					deploymentRetention: tc.inDeploymentRetention,
 				},
 			}
 
@@ -37,10 +37,12 @@ type deployOpts struct {
 	deployWkld     actionCommand
 	setupDeployCmd func(*deployOpts, string)
 
-	sel    wsSelector
-	store  store
-	ws     wsWlDirReader
-	prompt prompter
+	sel          wsSelector
+	store        store
+	locker       locker
+	ws           wsWlDirReader
+	prompt       prompter
+	sessProvider *sessions.Provider
 
 	// values for logging
 	wlType string
@@ -64,48 +66,54 @@ func newDeployOpts(vars deployWkldVars) (*deployOpts, error) {
 		sel:            selector.NewWorkspaceSelect(prompter, store, ws),
 		ws:             ws,
 		prompt:         prompter,
+		locker:         store,
+		sessProvider:   sessProvider,
 
-		setupDeployCmd: func(o *deployOpts, workloadType string) {
-			switch {
-			case contains(workloadType, manifest.JobTypes()):
-				opts := &deployJobOpts{
-					deployWkldVars: o.deployWkldVars,
-
-					store:           o.store,
-					ws:              o.ws,
-					newInterpolator: newManifestInterpolator,
-					unmarshal:       manifest.UnmarshalWorkload,
-					sel:             selector.NewWorkspaceSelect(o.prompt, o.store, o.ws),
-					cmd:             exec.NewCmd(),
-					sessProvider:    sessProvider,
-				}
-				opts.newJobDeployer = func() (workloadDeployer, error) {
-					return newJobDeployer(opts)
-				}
-				o.deployWkld = opts
-			case contains(workloadType, manifest.ServiceTypes()):
-				opts := &deploySvcOpts{
-					deployWkldVars: o.deployWkldVars,
-
-					store:           o.store,
-					ws:              o.ws,
-					newInterpolator: newManifestInterpolator,
-					unmarshal:       manifest.UnmarshalWorkload,
-					spinner:         termprogress.NewSpinner(log.DiagnosticWriter),
-					sel:             selector.NewWorkspaceSelect(o.prompt, o.store, o.ws),
-					prompt:          o.prompt,
-					cmd:             exec.NewCmd(),
-					sessProvider:    sessProvider,
-				}
-				opts.newSvcDeployer = func() (workloadDeployer, error) {
-					return newSvcDeployer(opts)
-				}
-				o.deployWkld = opts
-			}
-		},
+		setupDeployCmd: setupWkldDeployCmd,
 	}, nil
 }
 
+// setupWkldDeployCmd sets the command to deploy a workload of the given type.
+func setupWkldDeployCmd(o *deployOpts, workloadType string) {
+	switch {
+	case contains(workloadType, manifest.JobTypes()):
+		opts := &deployJobOpts{
+			deployWkldVars: o.deployWkldVars,
+
+			store:           o.store,
+			ws:              o.ws,
+			newInterpolator: newManifestInterpolator,
+			unmarshal:       manifest.UnmarshalWorkload,
+			sel:             selector.NewWorkspaceSelect(o.prompt, o.store, o.ws),
+			cmd:             exec.NewCmd(),
+			sessProvider:    o.sessProvider,
+		}
+		opts.newJobDeployer = func() (workloadDeployer, error) {
+			return newJobDeployer(opts)
+		}
+		o.deployWkld = opts
+	case contains(workloadType, manifest.ServiceTypes()):
+		opts := &deploySvcOpts{
+			deployWkldVars: o.deployWkldVars,
+
+			store:           o.store,
+			locker:          o.locker,
+			ws:              o.ws,
+			newInterpolator: newManifestInterpolator,
+			unmarshal:       manifest.UnmarshalWorkload,
+			spinner:         termprogress.NewSpinner(log.DiagnosticWriter),
+			sel:             selector.NewWorkspaceSelect(o.prompt, o.store, o.ws),
+			prompt:          o.prompt,
+			cmd:             exec.NewCmd(),
+			sessProvider:    o.sessProvider,
+		}
+		opts.newSvcDeployer = func() (workloadDeployer, error) {
+			return newSvcDeployer(opts)
+		}
+		o.deployWkld = opts
+	}
+}
+
 func (o *deployOpts) Run() error {
 	if err := o.askName(); err != nil {
 		return err
@@ -191,6 +199,7 @@ func BuildDeployCmd() *cobra.Command {
 	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
 	cmd.Flags().BoolVar(&vars.forceNewUpdate, forceFlag, false, forceFlagDescription)
 	cmd.Flags().BoolVar(&vars.disableRollback, noRollbackFlag, false, noRollbackFlagDescription)
+	cmd.Flags().BoolVar(&vars.forceUnlock, forceUnlockFlag, false, forceUnlockFlagDescription)
 
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
@@ -6,12 +6,15 @@ package deploy
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 	"time"
 
@@ -21,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ssm"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/acm"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
 	"github.com/aws/copilot-cli/internal/pkg/template/artifactpath"
 
@@ -49,8 +53,10 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/docker/dockerengine"
 	"github.com/aws/copilot-cli/internal/pkg/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/exec"
+	"github.com/aws/copilot-cli/internal/pkg/logging"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
 	"github.com/aws/copilot-cli/internal/pkg/repository"
+	"github.com/aws/copilot-cli/internal/pkg/task"
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 	"github.com/aws/copilot-cli/internal/pkg/term/progress"
@@ -80,8 +86,15 @@ var (
 	ecsNLBAliasUsedWithoutDomainFriendlyText = fmt.Sprintf("To use %s, your application must be associated with a domain: %s",
 		color.HighlightCode("nlb.alias"),
 		color.HighlightCode("copilot app init --domain example.com"))
+	ecsCDNAliasUsedWithoutDomainFriendlyText = fmt.Sprintf("To use %s, your application must be associated with a domain: %s",
+		color.HighlightCode("http.cdn.aliases"),
+		color.HighlightCode("copilot app init --domain example.com"))
+	staticSiteAliasUsedWithoutDomainFriendlyText = fmt.Sprintf("To use %s, your application must be associated with a domain: %s",
+		color.HighlightCode("aliases"),
+		color.HighlightCode("copilot app init --domain example.com"))
 	fmtErrTopicSubscriptionNotAllowed = "SNS topic %s does not exist in environment %s"
 	resourceNameFormat                = "%s-%s-%s-%s" // Format for copilot resource names of form app-env-svc-name
+	latestImageTag                    = "latest"
 )
 
 // ActionRecommender contains methods that output action recommendation.
@@ -91,6 +104,7 @@ type ActionRecommender interface {
 
 type imageBuilderPusher interface {
 	BuildAndPush(docker repository.ContainerLoginBuildPusher, args *dockerengine.BuildArguments) (string, error)
+	Digest(tag string) (string, error)
 }
 
 type uploader interface {
@@ -119,6 +133,18 @@ type publicCIDRBlocksGetter interface {
 	PublicCIDRBlocks() ([]string, error)
 }
 
+type prefixListGetter interface {
+	CloudFrontManagedPrefixListID() (string, error)
+}
+
+type envParamsGetter interface {
+	Params() (map[string]string, error)
+}
+
+type stackDescriber interface {
+	Describe(name string) (*awscloudformation.StackDescription, error)
+}
+
 type customResourcesUploader interface {
 	UploadEnvironmentCustomResources(upload s3.CompressAndUploadFunc) (map[string]string, error)
 	UploadRequestDrivenWebServiceCustomResources(upload s3.CompressAndUploadFunc) (map[string]string, error)
@@ -138,6 +164,14 @@ type serviceForceUpdater interface {
 	LastUpdatedAt(app, env, svc string) (time.Time, error)
 }
 
+type preDeployTasksLister interface {
+	StoppedPreDeployTasks(app, env, svc string) ([]*awsecs.Task, error)
+}
+
+type taskLogsWriter interface {
+	WriteEventsUntilStopped() error
+}
+
 type spinner interface {
 	Start(label string)
 	Stop(label string)
@@ -151,11 +185,24 @@ type aliasCertValidator interface {
 	ValidateCertAliases(aliases []string, certs []string) error
 }
 
+type staticSiteFS interface {
+	fileReader
+	Walk(root string, walkFn filepath.WalkFunc) error
+}
+
+type cmdRunner interface {
+	Run(name string, args []string, opts ...exec.CmdOption) error
+}
+
 type workloadDeployer struct {
 	name          string
 	app           *config.Application
 	env           *config.Environment
 	imageTag      string
+	imageDigest   string
+	buildCache    bool
+	reuseImage    bool
+	ingressSource bool
 	resources     *stack.AppRegionalResources
 	mft           interface{}
 	workspacePath string
@@ -184,6 +231,18 @@ type WorkloadDeployerInput struct {
 	Env             *config.Environment
 	ImageTag        string
 	Mft             interface{}
+
+	// ImageDigest is the digest of an image that already exists in the repository.
+	// If set, the image is deployed by digest instead of being built.
+	ImageDigest string
+	// BuildCache uses the latest image in the repository as a BuildKit cache source, and exports the cache with the new image.
+	BuildCache bool
+	// ReuseImage skips building the image if the repository already has an image tagged with ImageTag,
+	// and deploys that image by digest instead.
+	ReuseImage bool
+	// IngressSource is true if other services in the environment accept traffic from this service,
+	// in which case the service's tasks get their own security group.
+	IngressSource bool
 }
 
 // NewWorkloadDeployer is the constructor for workloadDeployer.
@@ -236,6 +295,10 @@ func newWorkloadDeployer(in *WorkloadDeployerInput) (*workloadDeployer, error) {
 		app:                in.App,
 		env:                in.Env,
 		imageTag:           in.ImageTag,
+		imageDigest:        in.ImageDigest,
+		buildCache:         in.BuildCache,
+		reuseImage:         in.ReuseImage,
+		ingressSource:      in.IngressSource,
 		resources:          resources,
 		workspacePath:      workspacePath,
 		fs:                 &afero.Afero{Fs: afero.NewOsFs()},
@@ -257,8 +320,10 @@ func newWorkloadDeployer(in *WorkloadDeployerInput) (*workloadDeployer, error) {
 
 type svcDeployer struct {
 	*workloadDeployer
-	newSvcUpdater func(func(*session.Session) serviceForceUpdater) serviceForceUpdater
-	now           func() time.Time
+	newSvcUpdater     func(func(*session.Session) serviceForceUpdater) serviceForceUpdater
+	preDeployTasks    preDeployTasksLister
+	newTaskLogsWriter func(tasks []*task.Task) taskLogsWriter
+	now               func() time.Time
 }
 
 func newSvcDeployer(in *WorkloadDeployerInput) (*svcDeployer, error) {
@@ -271,6 +336,12 @@ func newSvcDeployer(in *WorkloadDeployerInput) (*svcDeployer, error) {
 		newSvcUpdater: func(f func(*session.Session) serviceForceUpdater) serviceForceUpdater {
 			return f(wkldDeployer.envSess)
 		},
+		preDeployTasks: ecs.New(wkldDeployer.envSess),
+		newTaskLogsWriter: func(tasks []*task.Task) taskLogsWriter {
+			// The pre-deploy task writes to the service's log group with the same stream names as "copilot task run".
+			groupName := fmt.Sprintf("%s-%s-%s", wkldDeployer.app.Name, wkldDeployer.env.Name, wkldDeployer.name)
+			return logging.NewTaskClient(wkldDeployer.envSess, groupName, tasks)
+		},
 		now: time.Now,
 	}, nil
 }
@@ -285,6 +356,8 @@ type lbSvcDeployer struct {
 	appVersionGetter       versionGetter
 	aliasCertValidator     aliasCertValidator
 	publicCIDRBlocksGetter publicCIDRBlocksGetter
+	prefixListGetter       prefixListGetter
+	envParamsGetter        envParamsGetter
 	lbMft                  *manifest.LoadBalancedWebService
 }
 
@@ -319,6 +392,8 @@ func NewLBDeployer(in *WorkloadDeployerInput) (*lbSvcDeployer, error) {
 		svcDeployer:            svcDeployer,
 		appVersionGetter:       versionGetter,
 		publicCIDRBlocksGetter: envDescriber,
+		prefixListGetter:       ec2.New(svcDeployer.envSess),
+		envParamsGetter:        envDescriber,
 		lbMft:                  lbMft,
 		aliasCertValidator:     acm.New(svcDeployer.envSess),
 	}, nil
@@ -326,7 +401,8 @@ func NewLBDeployer(in *WorkloadDeployerInput) (*lbSvcDeployer, error) {
 
 type backendSvcDeployer struct {
 	*svcDeployer
-	backendMft *manifest.BackendService
+	backendMft         *manifest.BackendService
+	aliasCertValidator aliasCertValidator
 }
 
 // IsServiceAvailableInRegion checks if service type exist in the given region.
@@ -345,8 +421,9 @@ func NewBackendDeployer(in *WorkloadDeployerInput) (*backendSvcDeployer, error)
 		return nil, fmt.Errorf("manifest is not of type %s", manifest.BackendServiceType)
 	}
 	return &backendSvcDeployer{
-		svcDeployer: svcDeployer,
-		backendMft:  bsMft,
+		svcDeployer:        svcDeployer,
+		backendMft:         bsMft,
+		aliasCertValidator: acm.New(svcDeployer.envSess),
 	}, nil
 }
 
@@ -444,11 +521,51 @@ func NewWorkerSvcDeployer(in *WorkloadDeployerInput) (*workerSvcDeployer, error)
 	}, nil
 }
 
+type staticSiteDeployer struct {
+	*workloadDeployer
+	appVersionGetter versionGetter
+	stackDescriber   stackDescriber
+	siteFS           staticSiteFS
+	cmd              cmdRunner
+	siteMft          *manifest.StaticSite
+}
+
+// IsServiceAvailableInRegion checks if service type exist in the given region.
+func (staticSiteDeployer) IsServiceAvailableInRegion(region string) (bool, error) {
+	return partitions.IsAvailableInRegion(s3.EndpointsID, region)
+}
+
+// NewStaticSiteDeployer is the constructor for staticSiteDeployer.
+func NewStaticSiteDeployer(in *WorkloadDeployerInput) (*staticSiteDeployer, error) {
+	wkldDeployer, err := newWorkloadDeployer(in)
+	if err != nil {
+		return nil, err
+	}
+	versionGetter, err := describe.NewAppDescriber(in.App.Name)
+	if err != nil {
+		return nil, fmt.Errorf("new app describer for application %s: %w", in.App.Name, err)
+	}
+	siteMft, ok := in.Mft.(*manifest.StaticSite)
+	if !ok {
+		return nil, fmt.Errorf("manifest is not of type %s", manifest.StaticSiteType)
+	}
+	return &staticSiteDeployer{
+		workloadDeployer: wkldDeployer,
+		appVersionGetter: versionGetter,
+		stackDescriber:   awscloudformation.New(wkldDeployer.envSess),
+		siteFS:           &afero.Afero{Fs: afero.NewOsFs()},
+		cmd:              exec.NewCmd(),
+		siteMft:          siteMft,
+	}, nil
+}
+
 // UploadArtifactsOutput is the output of UploadArtifacts.
 type UploadArtifactsOutput struct {
 	ImageDigest *string
 	EnvFileARN  string
 	AddonsURL   string
+
+	StaticSiteAssetMappingURL string
 }
 
 // StackRuntimeConfiguration contains runtime configuration for a workload CloudFormation stack.
@@ -461,6 +578,8 @@ type StackRuntimeConfiguration struct {
 	AddonsURL   string
 	RootUserARN string
 	Tags        map[string]string
+
+	StaticSiteAssetMappingURL string
 }
 
 // DeployWorkloadInput is the input of DeployWorkload.
@@ -671,6 +790,48 @@ func (d *jobDeployer) DeployWorkload(in *DeployWorkloadInput) (ActionRecommender
 	return nil, nil
 }
 
+// UploadArtifacts builds the files of the static site and uploads them to the artifact bucket.
+func (d *staticSiteDeployer) UploadArtifacts() (*UploadArtifactsOutput, error) {
+	if err := d.buildStaticSite(); err != nil {
+		return nil, err
+	}
+	url, err := d.uploadStaticSiteAssets()
+	if err != nil {
+		return nil, err
+	}
+	return &UploadArtifactsOutput{
+		StaticSiteAssetMappingURL: url,
+	}, nil
+}
+
+// GenerateCloudFormationTemplate genrates a CloudFormation template and parameters for a workload.
+func (d *staticSiteDeployer) GenerateCloudFormationTemplate(in *GenerateCloudFormationTemplateInput) (
+	*GenerateCloudFormationTemplateOutput, error) {
+	conf, err := d.stackConfiguration(&in.StackRuntimeConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	return d.generateCloudFormationTemplate(conf)
+}
+
+// DeployWorkload deploys a static site using CloudFormation.
+func (d *staticSiteDeployer) DeployWorkload(in *DeployWorkloadInput) (ActionRecommender, error) {
+	opts := []awscloudformation.StackOption{
+		awscloudformation.WithRoleARN(d.env.ExecutionRoleARN),
+	}
+	if in.DisableRollback {
+		opts = append(opts, awscloudformation.WithDisableRollback())
+	}
+	conf, err := d.stackConfiguration(&in.StackRuntimeConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.deployer.DeployService(os.Stderr, conf, d.resources.S3Bucket, opts...); err != nil {
+		return nil, fmt.Errorf("deploy static site: %w", err)
+	}
+	return nil, nil
+}
+
 func (d *workloadDeployer) generateCloudFormationTemplate(conf stackSerializer) (
 	*GenerateCloudFormationTemplateOutput, error) {
 	tpl, err := conf.Template()
@@ -698,6 +859,9 @@ func (d *svcDeployer) deploy(deployOptions Options, stackConfigOutput svcStackCo
 	if err := d.deployer.DeployService(os.Stderr, stackConfigOutput.conf, d.resources.S3Bucket, opts...); err != nil {
 		var errEmptyCS *awscloudformation.ErrChangeSetEmpty
 		if !errors.As(err, &errEmptyCS) {
+			if preDeploy := preDeployTask(d.mft); !preDeploy.IsEmpty() {
+				d.writeFailedPreDeployTaskLogs(cmdRunAt)
+			}
 			return fmt.Errorf("deploy service: %w", err)
 		}
 		if !deployOptions.ForceNewUpdate {
@@ -723,6 +887,56 @@ func (d *svcDeployer) deploy(deployOptions Options, stackConfigOutput svcStackCo
 	return nil
 }
 
+// writeFailedPreDeployTaskLogs writes the logs of the pre-deploy tasks that failed since the deployment started.
+// The logs are best effort as the deployment already failed, so errors are only surfaced as warnings.
+func (d *svcDeployer) writeFailedPreDeployTaskLogs(since time.Time) {
+	tasks, err := d.preDeployTasks.StoppedPreDeployTasks(d.app.Name, d.env.Name, d.name)
+	if err != nil {
+		log.Warningf("Failed to retrieve the pre-deploy task of service %s: %v\n", d.name, err)
+		return
+	}
+	var failed []*task.Task
+	for _, t := range tasks {
+		if t.CreatedAt == nil || t.CreatedAt.Before(since) || exitedSuccessfully(t) {
+			continue
+		}
+		failed = append(failed, &task.Task{
+			TaskARN:    aws.StringValue(t.TaskArn),
+			ClusterARN: aws.StringValue(t.ClusterArn),
+			StartedAt:  t.StartedAt,
+		})
+	}
+	if len(failed) == 0 {
+		return
+	}
+	log.Errorf("The pre-deploy task of service %s failed. Logs:\n", color.HighlightUserInput(d.name))
+	if err := d.newTaskLogsWriter(failed).WriteEventsUntilStopped(); err != nil {
+		log.Warningf("Failed to write the logs of the pre-deploy task: %v\n", err)
+	}
+}
+
+func exitedSuccessfully(t *awsecs.Task) bool {
+	for _, c := range t.Containers {
+		if c.ExitCode == nil || aws.Int64Value(c.ExitCode) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// preDeployTask returns the one-off task that must succeed before the service is updated.
+func preDeployTask(mft interface{}) manifest.PreDeployTask {
+	switch m := mft.(type) {
+	case *manifest.LoadBalancedWebService:
+		return m.DeployConfig.PreDeploy
+	case *manifest.BackendService:
+		return m.DeployConfig.PreDeploy
+	case *manifest.WorkerService:
+		return m.DeployConfig.PreDeploy
+	}
+	return manifest.PreDeployTask{}
+}
+
 type forceDeployInput struct {
 	spinner    spinner
 	svcUpdater serviceForceUpdater
@@ -766,11 +980,29 @@ func (d *workloadDeployer) uploadContainerImage(imgBuilderPusher imageBuilderPus
 	if !required {
 		return nil, nil
 	}
+	if d.imageDigest != "" {
+		return aws.String(d.imageDigest), nil
+	}
+	if d.reuseImage && d.imageTag != "" {
+		digest, err := imgBuilderPusher.Digest(d.imageTag)
+		if err == nil {
+			log.Infof("Reusing image %s with digest %s instead of building it.\n", color.HighlightResource(d.imageTag), digest)
+			return aws.String(digest), nil
+		}
+		var errImageNotFound *ecr.ErrImageNotFound
+		if !errors.As(err, &errImageNotFound) {
+			return nil, err
+		}
+	}
 	// If it is built from local Dockerfile, build and push to the ECR repo.
 	buildArg, err := buildArgs(d.name, d.imageTag, d.workspacePath, d.mft)
 	if err != nil {
 		return nil, err
 	}
+	if d.buildCache {
+		buildArg.CacheFrom = append(buildArg.CacheFrom, fmt.Sprintf("%s:%s", d.resources.RepositoryURLs[d.name], latestImageTag))
+		buildArg.InlineCache = true
+	}
 	digest, err := imgBuilderPusher.BuildAndPush(dockerengine.New(exec.NewCmd()), buildArg)
 	if err != nil {
 		return nil, fmt.Errorf("build and push image: %w", err)
@@ -865,6 +1097,77 @@ func (d *workloadDeployer) pushAddonsTemplateToS3Bucket(in *pushAddonsTemplateTo
 	return url, nil
 }
 
+func (d *staticSiteDeployer) buildStaticSite() error {
+	command := aws.StringValue(d.siteMft.Source.Build)
+	if command == "" {
+		return nil
+	}
+	name, args := "sh", []string{"-c", command}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd", []string{"/C", command}
+	}
+	log.Infof("Building the files of static site %s with %s.\n", color.HighlightUserInput(d.name), color.HighlightCode(command))
+	if err := d.cmd.Run(name, args, exec.Dir(d.workspacePath)); err != nil {
+		return fmt.Errorf("run build command %q: %w", command, err)
+	}
+	return nil
+}
+
+// staticSiteAsset maps an uploaded file to its path in the site bucket.
+// The mapping is read by the custom resource that copies the files to the site bucket.
+type staticSiteAsset struct {
+	ArtifactPath string `json:"path"`
+	DestPath     string `json:"destPath"`
+	ContentType  string `json:"contentType,omitempty"`
+}
+
+func (d *staticSiteDeployer) uploadStaticSiteAssets() (string, error) {
+	source := aws.StringValue(d.siteMft.Source.Path)
+	root := filepath.Join(d.workspacePath, source)
+	var assets []staticSiteAsset
+	err := d.siteFS.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		content, err := d.siteFS.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read file %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("get relative path of %s: %w", path, err)
+		}
+		key := artifactpath.StaticSiteAsset(d.name, content)
+		if _, err := d.s3Client.Upload(d.resources.S3Bucket, key, bytes.NewReader(content)); err != nil {
+			return fmt.Errorf("put static site asset %s to bucket %s: %w", rel, d.resources.S3Bucket, err)
+		}
+		assets = append(assets, staticSiteAsset{
+			ArtifactPath: key,
+			DestPath:     filepath.ToSlash(rel),
+			ContentType:  mime.TypeByExtension(filepath.Ext(path)),
+		})
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("upload the files of directory %s: %w", source, err)
+	}
+	if len(assets) == 0 {
+		return "", fmt.Errorf("no files found in directory %s", source)
+	}
+	mapping, err := json.Marshal(assets)
+	if err != nil {
+		return "", fmt.Errorf("marshal static site asset mapping: %w", err)
+	}
+	url, err := d.s3Client.Upload(d.resources.S3Bucket, artifactpath.StaticSiteAssetMapping(d.name, mapping), bytes.NewReader(mapping))
+	if err != nil {
+		return "", fmt.Errorf("put static site asset mapping to bucket %s: %w", d.resources.S3Bucket, err)
+	}
+	return url, nil
+}
+
 func (d *workloadDeployer) runtimeConfig(in *StackRuntimeConfiguration) (*stack.RuntimeConfig, error) {
 	endpoint, err := d.endpointGetter.ServiceDiscoveryEndpoint()
 	if err != nil {
@@ -872,26 +1175,34 @@ func (d *workloadDeployer) runtimeConfig(in *StackRuntimeConfiguration) (*stack.
 	}
 	if in.ImageDigest == nil {
 		return &stack.RuntimeConfig{
-			AddonsTemplateURL:        in.AddonsURL,
-			EnvFileARN:               in.EnvFileARN,
-			AdditionalTags:           in.Tags,
-			ServiceDiscoveryEndpoint: endpoint,
-			AccountID:                d.env.AccountID,
-			Region:                   d.env.Region,
+			AddonsTemplateURL:         in.AddonsURL,
+			EnvFileARN:                in.EnvFileARN,
+			StaticSiteAssetMappingURL: in.StaticSiteAssetMappingURL,
+			AdditionalTags:            in.Tags,
+			ServiceDiscoveryEndpoint:  endpoint,
+			AccountID:                 d.env.AccountID,
+			Region:                    d.env.Region,
+			IngressSource:             d.ingressSource,
 		}, nil
 	}
+	imageTag := d.imageTag
+	if d.imageDigest != "" {
+		// An existing image is always referred to by digest, since the tag may point to a different image.
+		imageTag = ""
+	}
 	return &stack.RuntimeConfig{
 		AddonsTemplateURL: in.AddonsURL,
 		EnvFileARN:        in.EnvFileARN,
 		AdditionalTags:    in.Tags,
 		Image: &stack.ECRImage{
 			RepoURL:  d.resources.RepositoryURLs[d.name],
-			ImageTag: d.imageTag,
+			ImageTag: imageTag,
 			Digest:   aws.StringValue(in.ImageDigest),
 		},
 		ServiceDiscoveryEndpoint: endpoint,
 		AccountID:                d.env.AccountID,
 		Region:                   d.env.Region,
+		IngressSource:            d.ingressSource,
 	}, nil
 }
 
@@ -908,10 +1219,23 @@ func (d *lbSvcDeployer) stackConfiguration(in *StackRuntimeConfiguration) (*svcS
 	if err := d.validateALBWSRuntime(); err != nil {
 		return nil, err
 	}
+	if err := validateEC2Runtime(d.lbMft.TaskConfig, d.lbMft.Network, d.env); err != nil {
+		return nil, err
+	}
 	if err := d.validateNLBWSRuntime(); err != nil {
 		return nil, err
 	}
+	if err := d.validateCDNRuntime(); err != nil {
+		return nil, err
+	}
 	var opts []stack.LoadBalancedWebServiceOption
+	if d.lbMft.HasCDN() {
+		prefixListID, err := d.prefixListGetter.CloudFrontManagedPrefixListID()
+		if err != nil {
+			return nil, fmt.Errorf("get the CloudFront managed prefix list in environment %s: %w", d.env.Name, err)
+		}
+		opts = append(opts, stack.WithCDN(prefixListID))
+	}
 	if !d.lbMft.NLBConfig.IsEmpty() {
 		cidrBlocks, err := d.publicCIDRBlocksGetter.PublicCIDRBlocks()
 		if err != nil {
@@ -942,7 +1266,17 @@ func (d *backendSvcDeployer) stackConfiguration(in *StackRuntimeConfiguration) (
 	if err != nil {
 		return nil, err
 	}
-	conf, err := stack.NewBackendService(d.backendMft, d.env.Name, d.app.Name, *rc)
+	if err := d.validateInternalALBRuntime(); err != nil {
+		return nil, err
+	}
+	if err := validateEC2Runtime(d.backendMft.TaskConfig, d.backendMft.Network, d.env); err != nil {
+		return nil, err
+	}
+	var opts []stack.BackendServiceOption
+	if d.backendMft.InternalALBEnabled() && d.env.HasInternalCerts() {
+		opts = append(opts, stack.WithInternalHTTPS())
+	}
+	conf, err := stack.NewBackendService(d.backendMft, d.env.Name, d.app.Name, *rc, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("create stack configuration: %w", err)
 	}
@@ -1026,6 +1360,9 @@ func (d *workerSvcDeployer) stackConfiguration(in *StackRuntimeConfiguration) (*
 	if err != nil {
 		return nil, err
 	}
+	if err := validateEC2Runtime(d.wsMft.TaskConfig, d.wsMft.Network, d.env); err != nil {
+		return nil, err
+	}
 	var topics []deploy.Topic
 	topics, err = d.topicLister.ListSNSTopics(d.app.Name, d.env.Name)
 	if err != nil {
@@ -1072,6 +1409,62 @@ func (d *jobDeployer) stackConfiguration(in *StackRuntimeConfiguration) (*jobSta
 	}, nil
 }
 
+func (d *staticSiteDeployer) stackConfiguration(in *StackRuntimeConfiguration) (cloudformation.StackConfiguration, error) {
+	rc, err := d.runtimeConfig(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.validateAliases(); err != nil {
+		return nil, err
+	}
+	if rc.StaticSiteAssetMappingURL == "" {
+		// Reuse the files of the deployed site if no new assets were uploaded, so that the site bucket isn't emptied.
+		url, err := d.deployedAssetMappingURL()
+		if err != nil {
+			return nil, err
+		}
+		rc.StaticSiteAssetMappingURL = url
+	}
+	conf, err := stack.NewStaticSite(stack.StaticSiteConfig{
+		App:           d.app,
+		Env:           d.env,
+		Manifest:      d.siteMft,
+		RuntimeConfig: *rc,
+		RootUserARN:   in.RootUserARN,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create stack configuration: %w", err)
+	}
+	return conf, nil
+}
+
+// deployedAssetMappingURL returns the URL of the asset mapping file of the deployed static site.
+// It returns an empty string if the site hasn't been deployed yet.
+func (d *staticSiteDeployer) deployedAssetMappingURL() (string, error) {
+	stackName := stack.NameForService(d.app.Name, d.env.Name, d.name)
+	descr, err := d.stackDescriber.Describe(stackName)
+	if err != nil {
+		var errNotFound *awscloudformation.ErrStackNotFound
+		if errors.As(err, &errNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get the deployed asset mapping of static site %s: %w", d.name, err)
+	}
+	var bucket, key string
+	for _, param := range descr.Parameters {
+		switch aws.StringValue(param.ParameterKey) {
+		case stack.StaticSiteAssetMappingFileBucketParamKey:
+			bucket = aws.StringValue(param.ParameterValue)
+		case stack.StaticSiteAssetMappingFileKeyParamKey:
+			key = aws.StringValue(param.ParameterValue)
+		}
+	}
+	if key == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, d.env.Region, key), nil
+}
+
 func buildArgs(name, imageTag, workspacePath string, unmarshaledManifest interface{}) (*dockerengine.BuildArguments, error) {
 	type dfArgs interface {
 		BuildArgs(rootDirectory string) *manifest.DockerBuildArgs
@@ -1197,7 +1590,33 @@ func validateAppVersionForAlias(appName string, appVersionGetter versionGetter)
 	return nil
 }
 
+// validateEC2Runtime returns an error if the tasks run on EC2 instances that the environment can't provide.
+func validateEC2Runtime(task manifest.TaskConfig, network manifest.NetworkConfig, env *config.Environment) error {
+	if !task.IsEC2() {
+		return nil
+	}
+	if !env.HasEC2Capacity() {
+		return fmt.Errorf(`cannot specify "platform.launch_type" %s when env %s does not have EC2 capacity`, manifest.LaunchTypeEC2, env.Name)
+	}
+	// Tasks with their own network interface on EC2 instances can't get a public IP, so they need NAT gateways to reach the internet.
+	placement := network.VPC.Placement
+	if task.Platform.NetworkMode() == manifest.NetworkModeAWSVPC && (placement == nil || *placement != manifest.PrivateSubnetPlacement) {
+		return fmt.Errorf(`"network.vpc.placement" must be %s when "platform.network_mode" is %s on EC2 instances; otherwise set "platform.network_mode" to %s`,
+			manifest.PrivateSubnetPlacement, manifest.NetworkModeAWSVPC, manifest.NetworkModeBridge)
+	}
+	if task.Platform.IsEmpty() {
+		return nil
+	}
+	if envArch := env.CustomConfig.EC2Capacity.Arch; manifest.IsArmArch(task.Platform.Arch()) != manifest.IsArmArch(envArch) {
+		return fmt.Errorf(`"platform" architecture %s does not match the %s architecture of the EC2 instances in env %s`, task.Platform.Arch(), envArch, env.Name)
+	}
+	return nil
+}
+
 func (d *lbSvcDeployer) validateALBWSRuntime() error {
+	if !d.lbMft.RoutingRule.WAF.IsEmpty() && d.env.HasWAF() {
+		return fmt.Errorf("cannot specify http.waf when env %s already associates a web ACL with its load balancer", d.env.Name)
+	}
 	if d.lbMft.RoutingRule.Alias.IsEmpty() {
 		if d.env.HasImportedCerts() {
 			return &errSvcWithNoALBAliasDeployingToEnvWithImportedCerts{
@@ -1228,6 +1647,26 @@ func (d *lbSvcDeployer) validateALBWSRuntime() error {
 	return fmt.Errorf("cannot specify http.alias when application is not associated with a domain and env %s doesn't import one or more certificates", d.env.Name)
 }
 
+func (d *backendSvcDeployer) validateInternalALBRuntime() error {
+	if !d.backendMft.InternalALBEnabled() || !d.env.HasInternalCerts() {
+		return nil
+	}
+	if d.backendMft.RoutingRule.Alias.IsEmpty() {
+		return &errSvcWithNoALBAliasDeployingToEnvWithImportedCerts{
+			name:    d.name,
+			envName: d.env.Name,
+		}
+	}
+	aliases, err := d.backendMft.RoutingRule.Alias.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf("convert aliases to string slice: %w", err)
+	}
+	if err := d.aliasCertValidator.ValidateCertAliases(aliases, d.env.CustomConfig.InternalCertARNs); err != nil {
+		return fmt.Errorf("validate aliases against the internal load balancer certificate for env %s: %w", d.env.Name, err)
+	}
+	return nil
+}
+
 func (d *lbSvcDeployer) validateNLBWSRuntime() error {
 	if d.lbMft.NLBConfig.Aliases.IsEmpty() {
 		return nil
@@ -1246,6 +1685,73 @@ func (d *lbSvcDeployer) validateNLBWSRuntime() error {
 	return validateLBSvcAlias(d.lbMft.NLBConfig.Aliases, d.app, d.env.Name)
 }
 
+func (d *lbSvcDeployer) validateCDNRuntime() error {
+	if !d.lbMft.HasCDN() {
+		return nil
+	}
+	params, err := d.envParamsGetter.Params()
+	if err != nil {
+		return fmt.Errorf("get parameters of environment %s: %w", d.env.Name, err)
+	}
+	// The public load balancer of an environment sits behind at most one CloudFront distribution.
+	for _, wkld := range strings.Split(params[stack.EnvParamCDNWorkloadsKey], ",") {
+		if wkld != "" && wkld != d.name {
+			return fmt.Errorf("environment %s already has a CloudFront distribution for service %s", d.env.Name, wkld)
+		}
+	}
+	if d.lbMft.RoutingRule.CDN.Advanced.Aliases.IsEmpty() {
+		return nil
+	}
+	if d.app.Domain == "" {
+		log.Errorf(ecsCDNAliasUsedWithoutDomainFriendlyText)
+		return fmt.Errorf("cannot specify http.cdn.aliases when application is not associated with a domain")
+	}
+	if err := validateAppVersionForAlias(d.app.Name, d.appVersionGetter); err != nil {
+		logAppVersionOutdatedError(aws.StringValue(d.lbMft.Name))
+		return err
+	}
+	aliases, err := d.lbMft.RoutingRule.CDN.Advanced.Aliases.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf("convert aliases to string slice: %w", err)
+	}
+	return validateDistributionAliases(aliases, "http.cdn", d.app, d.env.Name)
+}
+
+func (d *staticSiteDeployer) validateAliases() error {
+	if d.siteMft.Aliases.IsEmpty() {
+		return nil
+	}
+	if d.app.Domain == "" {
+		log.Errorf(staticSiteAliasUsedWithoutDomainFriendlyText)
+		return fmt.Errorf("cannot specify aliases when application is not associated with a domain")
+	}
+	if err := validateAppVersionForAlias(d.app.Name, d.appVersionGetter); err != nil {
+		logAppVersionOutdatedError(aws.StringValue(d.siteMft.Name))
+		return err
+	}
+	aliases, err := d.siteMft.Aliases.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf("convert aliases to string slice: %w", err)
+	}
+	return validateDistributionAliases(aliases, "aliases", d.app, d.env.Name)
+}
+
+// validateDistributionAliases returns an error if an alias of a CloudFront distribution
+// can't be created in the hosted zone of the environment.
+func validateDistributionAliases(aliases []string, field string, app *config.Application, envName string) error {
+	envDomain := fmt.Sprintf("%s.%s.%s", envName, app.Name, app.Domain)
+	for _, alias := range aliases {
+		if alias == envDomain {
+			continue
+		}
+		if name := strings.TrimSuffix(alias, "."+envDomain); name != alias && !strings.Contains(name, ".") {
+			continue
+		}
+		return fmt.Errorf(`alias "%s" for %s must match either %s or <name>.%s`, alias, field, envDomain, envDomain)
+	}
+	return nil
+}
+
 func validateLBSvcAlias(aliases manifest.Alias, app *config.Application, envName string) error {
 	if aliases.IsEmpty() {
 		return nil
@@ -4,25 +4,34 @@
 package deploy
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	awscfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/addon"
 	"github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ecr"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/config"
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/copilot-cli/internal/pkg/docker/dockerengine"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/task"
+	"github.com/aws/copilot-cli/internal/pkg/template/artifactpath"
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 
@@ -34,6 +43,8 @@ type deployMocks struct {
 	mockEndpointGetter         *mocks.MockendpointGetter
 	mockSpinner                *mocks.Mockspinner
 	mockPublicCIDRBlocksGetter *mocks.MockpublicCIDRBlocksGetter
+	mockPrefixListGetter       *mocks.MockprefixListGetter
+	mockEnvParamsGetter        *mocks.MockenvParamsGetter
 	mockSNSTopicsLister        *mocks.MocksnsTopicsLister
 	mockServiceDeployer        *mocks.MockserviceDeployer
 	mockServiceForceUpdater    *mocks.MockserviceForceUpdater
@@ -81,9 +92,13 @@ func TestWorkloadDeployer_UploadArtifacts(t *testing.T) {
 		mockBadEnvFileS3URL = "badURL"
 		mockEnvFileS3URL    = "https://stackset-demo-infrastruc-pipelinebuiltartifactbuc-11dj7ctf52wyf.s3.us-west-2.amazonaws.com/manual/1638391936/env"
 		mockEnvFileS3ARN    = "arn:aws:s3:::stackset-demo-infrastruc-pipelinebuiltartifactbuc-11dj7ctf52wyf/manual/1638391936/env"
+		mockRepoURL         = "mockRepoURL"
 	)
 	mockResources := &stack.AppRegionalResources{
 		S3Bucket: mockS3Bucket,
+		RepositoryURLs: map[string]string{
+			mockName: mockRepoURL,
+		},
 	}
 	mockEnvFilePath := fmt.Sprintf("%s/%s/%s/%s.env", "manual", "env-files", mockEnvFile, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
 	mockAddonPath := fmt.Sprintf("%s/%s/%s/%s.yml", "manual", "addons", mockName, "1307990e6ba5ca145eb35e99182a9bec46531bc54ddf656a602c780fa0240dee")
@@ -91,6 +106,9 @@ func TestWorkloadDeployer_UploadArtifacts(t *testing.T) {
 	tests := map[string]struct {
 		inEnvFile       string
 		inBuildRequired bool
+		inBuildCache    bool
+		inReuseImage    bool
+		inImageDigest   string
 		inRegion        string
 
 		mock func(m *deployMocks)
@@ -128,6 +146,72 @@ func TestWorkloadDeployer_UploadArtifacts(t *testing.T) {
 			},
 			wantImageDigest: aws.String("mockDigest"),
 		},
+		"build with the latest image as cache": {
+			inBuildRequired: true,
+			inBuildCache:    true,
+			mock: func(m *deployMocks) {
+				m.mockImageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
+					Dockerfile:  "mockDockerfile",
+					Context:     "mockContext",
+					Platform:    "mockContainerPlatform",
+					Tags:        []string{mockImageTag},
+					CacheFrom:   []string{"mockRepoURL:latest"},
+					InlineCache: true,
+				}).Return("mockDigest", nil)
+				m.mockTemplater.EXPECT().Template().Return("", &addon.ErrAddonsNotFound{
+					WlName: "mockWkld",
+				})
+			},
+			wantImageDigest: aws.String("mockDigest"),
+		},
+		"reuse the image if it already exists in the repository": {
+			inBuildRequired: true,
+			inReuseImage:    true,
+			mock: func(m *deployMocks) {
+				m.mockImageBuilderPusher.EXPECT().Digest(mockImageTag).Return("mockExistingDigest", nil)
+				m.mockImageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), gomock.Any()).Times(0)
+				m.mockTemplater.EXPECT().Template().Return("", &addon.ErrAddonsNotFound{
+					WlName: "mockWkld",
+				})
+			},
+			wantImageDigest: aws.String("mockExistingDigest"),
+		},
+		"build the image if it does not exist in the repository": {
+			inBuildRequired: true,
+			inReuseImage:    true,
+			mock: func(m *deployMocks) {
+				m.mockImageBuilderPusher.EXPECT().Digest(mockImageTag).Return("", fmt.Errorf("get digest: %w", &ecr.ErrImageNotFound{}))
+				m.mockImageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
+					Dockerfile: "mockDockerfile",
+					Context:    "mockContext",
+					Platform:   "mockContainerPlatform",
+					Tags:       []string{mockImageTag},
+				}).Return("mockDigest", nil)
+				m.mockTemplater.EXPECT().Template().Return("", &addon.ErrAddonsNotFound{
+					WlName: "mockWkld",
+				})
+			},
+			wantImageDigest: aws.String("mockDigest"),
+		},
+		"deploy the image digest without building": {
+			inBuildRequired: true,
+			inImageDigest:   "sha256:promoted",
+			mock: func(m *deployMocks) {
+				m.mockImageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), gomock.Any()).Times(0)
+				m.mockTemplater.EXPECT().Template().Return("", &addon.ErrAddonsNotFound{
+					WlName: "mockWkld",
+				})
+			},
+			wantImageDigest: aws.String("sha256:promoted"),
+		},
+		"error if fail to look up the existing image": {
+			inBuildRequired: true,
+			inReuseImage:    true,
+			mock: func(m *deployMocks) {
+				m.mockImageBuilderPusher.EXPECT().Digest(mockImageTag).Return("", mockError)
+			},
+			wantErr: mockError,
+		},
 		"error if fail to read env file": {
 			inEnvFile: mockEnvFile,
 			mock: func(m *deployMocks) {
@@ -229,6 +313,9 @@ func TestWorkloadDeployer_UploadArtifacts(t *testing.T) {
 				},
 				resources:     mockResources,
 				imageTag:      mockImageTag,
+				buildCache:    tc.inBuildCache,
+				reuseImage:    tc.inReuseImage,
+				imageDigest:   tc.inImageDigest,
 				workspacePath: mockWorkspacePath,
 				mft: &mockWorkloadMft{
 					fileName:      tc.inEnvFile,
@@ -274,6 +361,8 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 	tests := map[string]struct {
 		inAliases         manifest.Alias
 		inNLB             manifest.NetworkLoadBalancerConfiguration
+		inWAF             manifest.WebACLArgsOrARN
+		inCDN             manifest.CDNBoolOrArgs
 		inApp             *config.Application
 		inEnvironment     *config.Environment
 		inForceDeploy     bool
@@ -305,6 +394,29 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 			},
 			wantErr: fmt.Errorf("cannot deploy service mockWkld without http.alias to environment mockEnv with certificate imported"),
 		},
+		"fail if waf is specified while env has a web ACL": {
+			inWAF: manifest.WebACLArgsOrARN{
+				Advanced: manifest.WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+				CustomConfig: &config.CustomizeEnv{
+					WAF: &config.WAF{
+						WebACLARN: "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3",
+					},
+				},
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deployMocks) {
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+			},
+			wantErr: fmt.Errorf("cannot specify http.waf when env mockEnv already associates a web ACL with its load balancer"),
+		},
 		"fail to validate certificate aliases": {
 			inEnvironment: &config.Environment{
 				Name:   mockEnvName,
@@ -327,7 +439,9 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 		},
 		"fail to get public CIDR blocks": {
 			inNLB: manifest.NetworkLoadBalancerConfiguration{
-				Port: aws.String("443/tcp"),
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("443/tcp"),
+				},
 			},
 			inEnvironment: &config.Environment{
 				Name:   mockEnvName,
@@ -358,7 +472,9 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 		},
 		"nlb alias used while app is not associated with a domain": {
 			inNLB: manifest.NetworkLoadBalancerConfiguration{
-				Port:    aws.String("80"),
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("80"),
+				},
 				Aliases: manifest.Alias{String: aws.String("mockAlias")},
 			},
 			inEnvironment: &config.Environment{
@@ -376,7 +492,9 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 		"nlb alias used while env has imported certs": {
 			inAliases: manifest.Alias{String: aws.String("mockAlias")},
 			inNLB: manifest.NetworkLoadBalancerConfiguration{
-				Port:    aws.String("80"),
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("80"),
+				},
 				Aliases: manifest.Alias{String: aws.String("mockAlias")},
 			},
 			inEnvironment: &config.Environment{
@@ -429,7 +547,9 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 		},
 		"fail to enable nlb alias because of incompatible app version": {
 			inNLB: manifest.NetworkLoadBalancerConfiguration{
-				Port:    aws.String("80"),
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("80"),
+				},
 				Aliases: manifest.Alias{String: aws.String("mockAlias")},
 			},
 			inEnvironment: &config.Environment{
@@ -464,7 +584,9 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 		},
 		"fail to enable nlb alias because of invalid alias": {
 			inNLB: manifest.NetworkLoadBalancerConfiguration{
-				Port:    aws.String("80"),
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("80"),
+				},
 				Aliases: manifest.Alias{String: aws.String("v1.v2.mockDomain")},
 			},
 			inEnvironment: &config.Environment{
@@ -481,6 +603,100 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 			},
 			wantErr: fmt.Errorf(`alias "v1.v2.mockDomain" is not supported in hosted zones managed by Copilot`),
 		},
+		"cdn alias used while app is not associated with a domain": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Advanced: manifest.CDNArgs{
+					Aliases: manifest.Alias{String: aws.String("www.mockEnv.mockApp.mockDomain")},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deployMocks) {
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockEnvParamsGetter.EXPECT().Params().Return(map[string]string{}, nil)
+			},
+			wantErr: errors.New("cannot specify http.cdn.aliases when application is not associated with a domain"),
+		},
+		"fail to enable cdn alias because of invalid alias": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Advanced: manifest.CDNArgs{
+					Aliases: manifest.Alias{String: aws.String("www.mockApp.mockDomain")},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name:   mockAppName,
+				Domain: "mockDomain",
+			},
+			mock: func(m *deployMocks) {
+				m.mockVersionGetter.EXPECT().Version().Return("v1.0.0", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockEnvParamsGetter.EXPECT().Params().Return(map[string]string{}, nil)
+			},
+			wantErr: fmt.Errorf(`alias "www.mockApp.mockDomain" for http.cdn must match either mockEnv.mockApp.mockDomain or <name>.mockEnv.mockApp.mockDomain`),
+		},
+		"fail to get the environment parameters for cdn": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deployMocks) {
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockEnvParamsGetter.EXPECT().Params().Return(nil, mockError)
+			},
+			wantErr: fmt.Errorf("get parameters of environment mockEnv: some error"),
+		},
+		"fail if another service in the environment has a CloudFront distribution": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deployMocks) {
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockEnvParamsGetter.EXPECT().Params().Return(map[string]string{
+					"CDNWorkloads": "frontend",
+				}, nil)
+			},
+			wantErr: fmt.Errorf("environment mockEnv already has a CloudFront distribution for service frontend"),
+		},
+		"fail to get the CloudFront managed prefix list": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deployMocks) {
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockEnvParamsGetter.EXPECT().Params().Return(map[string]string{}, nil)
+				m.mockPrefixListGetter.EXPECT().CloudFrontManagedPrefixListID().Return("", mockError)
+			},
+			wantErr: fmt.Errorf("get the CloudFront managed prefix list in environment mockEnv: some error"),
+		},
 		"error if fail to deploy service": {
 			inEnvironment: &config.Environment{
 				Name:   mockEnvName,
@@ -625,6 +841,30 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), "mockBucket", gomock.Any()).Return(nil)
 			},
 		},
+		"success with cdn": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Advanced: manifest.CDNArgs{
+					Aliases: manifest.Alias{StringSlice: []string{"mockEnv.mockApp.mockDomain", "www.mockEnv.mockApp.mockDomain"}},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name:   mockAppName,
+				Domain: "mockDomain",
+			},
+			mock: func(m *deployMocks) {
+				m.mockVersionGetter.EXPECT().Version().Return("v1.0.0", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockEnvParamsGetter.EXPECT().Params().Return(map[string]string{
+					"CDNWorkloads": mockName,
+				}, nil)
+				m.mockPrefixListGetter.EXPECT().CloudFrontManagedPrefixListID().Return("pl-3b927c52", nil)
+				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), "mockBucket", gomock.Any()).Return(nil)
+			},
+		},
 		"success with force update": {
 			inForceDeploy: true,
 			inEnvironment: &config.Environment{
@@ -659,6 +899,8 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 				mockServiceForceUpdater:    mocks.NewMockserviceForceUpdater(ctrl),
 				mockSpinner:                mocks.NewMockspinner(ctrl),
 				mockPublicCIDRBlocksGetter: mocks.NewMockpublicCIDRBlocksGetter(ctrl),
+				mockPrefixListGetter:       mocks.NewMockprefixListGetter(ctrl),
+				mockEnvParamsGetter:        mocks.NewMockenvParamsGetter(ctrl),
 				mockValidator:              mocks.NewMockaliasCertValidator(ctrl),
 			}
 			tc.mock(m)
@@ -683,6 +925,8 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 				},
 				appVersionGetter:       m.mockVersionGetter,
 				publicCIDRBlocksGetter: m.mockPublicCIDRBlocksGetter,
+				prefixListGetter:       m.mockPrefixListGetter,
+				envParamsGetter:        m.mockEnvParamsGetter,
 				aliasCertValidator:     m.mockValidator,
 				lbMft: &manifest.LoadBalancedWebService{
 					Workload: manifest.Workload{
@@ -701,6 +945,8 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 							RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
 								Path:  aws.String("/"),
 								Alias: tc.inAliases,
+								WAF:   tc.inWAF,
+								CDN:   tc.inCDN,
 							},
 						},
 						NLBConfig: tc.inNLB,
@@ -724,6 +970,174 @@ func TestWorkloadDeployer_DeployWorkload(t *testing.T) {
 	}
 }
 
+func TestWorkloadDeployer_runtimeConfig(t *testing.T) {
+	testCases := map[string]struct {
+		inImageTag    string
+		inImageDigest string
+
+		wantedLocation string
+	}{
+		"refer to a built image by tag": {
+			inImageTag:     "v1.0",
+			wantedLocation: "mockRepoURL:v1.0",
+		},
+		"refer to an existing image by digest even if a tag is set": {
+			inImageTag:     "v1.0",
+			inImageDigest:  "sha256:promoted",
+			wantedLocation: "mockRepoURL@sha256:promoted",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockEndpointGetter := mocks.NewMockendpointGetter(ctrl)
+			mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+			deployer := &workloadDeployer{
+				name:        "mockWkld",
+				env:         &config.Environment{Name: "mockEnv"},
+				imageTag:    tc.inImageTag,
+				imageDigest: tc.inImageDigest,
+				resources: &stack.AppRegionalResources{
+					RepositoryURLs: map[string]string{
+						"mockWkld": "mockRepoURL",
+					},
+				},
+				endpointGetter: mockEndpointGetter,
+			}
+
+			rc, err := deployer.runtimeConfig(&StackRuntimeConfiguration{
+				ImageDigest: aws.String("sha256:promoted"),
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedLocation, rc.Image.GetLocation())
+		})
+	}
+}
+
+func TestSvcDeployer_deployWithPreDeployTask(t *testing.T) {
+	const (
+		mockAppName     = "mockApp"
+		mockEnvName     = "mockEnv"
+		mockName        = "mockSvc"
+		mockClusterARN  = "arn:aws:ecs:us-west-2:123456789012:cluster/mockCluster"
+		mockFailedTask  = "arn:aws:ecs:us-west-2:123456789012:task/mockCluster/4082490ee6c245e09d2145010aa1ba8d"
+		mockSucceedTask = "arn:aws:ecs:us-west-2:123456789012:task/mockCluster/93c7a2b6ff4f4e3c8f0a8b3d5f2b1c7e"
+	)
+	mockNowTime := time.Unix(1494505750, 0)
+	mockBeforeTime := time.Unix(1494505743, 0)
+	mockAfterTime := time.Unix(1494505756, 0)
+	mockPreDeploy := manifest.PreDeployTask{
+		Command: manifest.CommandOverride{
+			String: aws.String("./migrate up"),
+		},
+	}
+	testCases := map[string]struct {
+		inPreDeploy manifest.PreDeployTask
+
+		mockPreDeployTasks func(m *mocks.MockpreDeployTasksLister)
+		wantedLogsOfTasks  []*task.Task
+	}{
+		"does not look up the pre-deploy task if it is not configured": {
+			mockPreDeployTasks: func(m *mocks.MockpreDeployTasksLister) {},
+		},
+		"does not write logs if the pre-deploy task cannot be retrieved": {
+			inPreDeploy: mockPreDeploy,
+			mockPreDeployTasks: func(m *mocks.MockpreDeployTasksLister) {
+				m.EXPECT().StoppedPreDeployTasks(mockAppName, mockEnvName, mockName).Return(nil, errors.New("some error"))
+			},
+		},
+		"does not write logs if the pre-deploy task succeeded": {
+			inPreDeploy: mockPreDeploy,
+			mockPreDeployTasks: func(m *mocks.MockpreDeployTasksLister) {
+				m.EXPECT().StoppedPreDeployTasks(mockAppName, mockEnvName, mockName).Return([]*ecs.Task{
+					{
+						TaskArn:    aws.String(mockSucceedTask),
+						ClusterArn: aws.String(mockClusterARN),
+						CreatedAt:  &mockAfterTime,
+						Containers: []*awsecs.Container{{ExitCode: aws.Int64(0)}},
+					},
+				}, nil)
+			},
+		},
+		"writes the logs of the pre-deploy task that failed during the deployment": {
+			inPreDeploy: mockPreDeploy,
+			mockPreDeployTasks: func(m *mocks.MockpreDeployTasksLister) {
+				m.EXPECT().StoppedPreDeployTasks(mockAppName, mockEnvName, mockName).Return([]*ecs.Task{
+					{
+						TaskArn:    aws.String(mockSucceedTask),
+						ClusterArn: aws.String(mockClusterARN),
+						CreatedAt:  &mockBeforeTime,
+						Containers: []*awsecs.Container{{ExitCode: aws.Int64(1)}},
+					},
+					{
+						TaskArn:    aws.String(mockFailedTask),
+						ClusterArn: aws.String(mockClusterARN),
+						CreatedAt:  &mockAfterTime,
+						StartedAt:  &mockAfterTime,
+						Containers: []*awsecs.Container{{ExitCode: aws.Int64(1)}},
+					},
+				}, nil)
+			},
+			wantedLogsOfTasks: []*task.Task{
+				{
+					TaskARN:    mockFailedTask,
+					ClusterARN: mockClusterARN,
+					StartedAt:  &mockAfterTime,
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockServiceDeployer := mocks.NewMockserviceDeployer(ctrl)
+			mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), "mockBucket", gomock.Any()).Return(errors.New("some error"))
+			mockPreDeployTasks := mocks.NewMockpreDeployTasksLister(ctrl)
+			tc.mockPreDeployTasks(mockPreDeployTasks)
+			mockLogsWriter := mocks.NewMocktaskLogsWriter(ctrl)
+			var gotLogsOfTasks []*task.Task
+			if tc.wantedLogsOfTasks != nil {
+				mockLogsWriter.EXPECT().WriteEventsUntilStopped().Return(nil)
+			}
+			mft := &manifest.BackendService{
+				Workload: manifest.Workload{
+					Name: aws.String(mockName),
+				},
+			}
+			mft.DeployConfig.PreDeploy = tc.inPreDeploy
+			deployer := &svcDeployer{
+				workloadDeployer: &workloadDeployer{
+					name:      mockName,
+					app:       &config.Application{Name: mockAppName},
+					env:       &config.Environment{Name: mockEnvName},
+					resources: &stack.AppRegionalResources{S3Bucket: "mockBucket"},
+					mft:       mft,
+					deployer:  mockServiceDeployer,
+				},
+				preDeployTasks: mockPreDeployTasks,
+				newTaskLogsWriter: func(tasks []*task.Task) taskLogsWriter {
+					gotLogsOfTasks = tasks
+					return mockLogsWriter
+				},
+				now: func() time.Time {
+					return mockNowTime
+				},
+			}
+
+			// WHEN
+			err := deployer.deploy(Options{}, svcStackConfigurationOutput{})
+
+			// THEN
+			require.EqualError(t, err, "deploy service: some error")
+			require.Equal(t, tc.wantedLogsOfTasks, gotLogsOfTasks)
+		})
+	}
+}
+
 type deployRDSvcMocks struct {
 	mockVersionGetter  *mocks.MockversionGetter
 	mockEndpointGetter *mocks.MockendpointGetter
@@ -1048,6 +1462,114 @@ func TestSvcDeployOpts_stackConfiguration_worker(t *testing.T) {
 	}
 }
 
+func TestBackendSvcDeployer_validateInternalALBRuntime(t *testing.T) {
+	mockError := errors.New("some error")
+	mockAliases := []string{"api.internal.example.com"}
+	mockCertARNs := []string{"mockInternalCertARN"}
+	mockEnvWithCerts := &config.Environment{
+		Name: "mockEnv",
+		CustomConfig: &config.CustomizeEnv{
+			InternalCertARNs: mockCertARNs,
+		},
+	}
+	testCases := map[string]struct {
+		inRoutingRule manifest.RoutingRuleConfigOrBool
+		inEnvironment *config.Environment
+
+		mock func(m *mocks.MockaliasCertValidator)
+
+		wantErr error
+	}{
+		"skip validation if the internal load balancer is not enabled": {
+			inEnvironment: mockEnvWithCerts,
+			mock:          func(m *mocks.MockaliasCertValidator) {},
+		},
+		"skip validation if the env does not import internal certificates": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name: "mockEnv",
+			},
+			mock: func(m *mocks.MockaliasCertValidator) {},
+		},
+		"fail if alias is not specified with env has internal certs": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+				},
+			},
+			inEnvironment: mockEnvWithCerts,
+			mock:          func(m *mocks.MockaliasCertValidator) {},
+			wantErr:       errors.New("cannot deploy service mockSvc without http.alias to environment mockEnv with certificate imported"),
+		},
+		"fail to validate certificate aliases": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+					Alias: manifest.Alias{
+						StringSlice: mockAliases,
+					},
+				},
+			},
+			inEnvironment: mockEnvWithCerts,
+			mock: func(m *mocks.MockaliasCertValidator) {
+				m.EXPECT().ValidateCertAliases(mockAliases, mockCertARNs).Return(mockError)
+			},
+			wantErr: errors.New("validate aliases against the internal load balancer certificate for env mockEnv: some error"),
+		},
+		"success": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+					Alias: manifest.Alias{
+						StringSlice: mockAliases,
+					},
+				},
+			},
+			inEnvironment: mockEnvWithCerts,
+			mock: func(m *mocks.MockaliasCertValidator) {
+				m.EXPECT().ValidateCertAliases(mockAliases, mockCertARNs).Return(nil)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockValidator := mocks.NewMockaliasCertValidator(ctrl)
+			tc.mock(mockValidator)
+			deployer := backendSvcDeployer{
+				svcDeployer: &svcDeployer{
+					workloadDeployer: &workloadDeployer{
+						name: "mockSvc",
+						env:  tc.inEnvironment,
+					},
+				},
+				backendMft: &manifest.BackendService{
+					BackendServiceConfig: manifest.BackendServiceConfig{
+						RoutingRule: tc.inRoutingRule,
+					},
+				},
+				aliasCertValidator: mockValidator,
+			}
+
+			// WHEN
+			err := deployer.validateInternalALBRuntime()
+
+			// THEN
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_validateTopicsExist(t *testing.T) {
 	mockApp := "app"
 	mockEnv := "env"
@@ -1103,3 +1625,403 @@ func Test_validateTopicsExist(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateEC2Runtime(t *testing.T) {
+	ec2Env := &config.Environment{
+		Name: "test",
+		CustomConfig: &config.CustomizeEnv{
+			EC2Capacity: &config.EC2Capacity{
+				InstanceTypes: []string{"m6g.large"},
+				Arch:          "arm64",
+				MaxSize:       10,
+			},
+		},
+	}
+	var privateNetwork manifest.NetworkConfig
+	privateNetwork.VPC.Placement = (*manifest.Placement)(aws.String("private"))
+	testCases := map[string]struct {
+		inPlatform manifest.PlatformArgsOrString
+		inNetwork  manifest.NetworkConfig
+		inEnv      *config.Environment
+
+		wantErr string
+	}{
+		"tasks run on Fargate": {
+			inEnv: &config.Environment{
+				Name: "test",
+			},
+		},
+		"environment does not have EC2 capacity": {
+			inPlatform: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					LaunchType: aws.String("ec2"),
+				},
+			},
+			inEnv: &config.Environment{
+				Name: "test",
+			},
+			wantErr: `cannot specify "platform.launch_type" ec2 when env test does not have EC2 capacity`,
+		},
+		"architecture does not match the EC2 instances": {
+			inPlatform: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					OSFamily:   aws.String("linux"),
+					Arch:       aws.String("x86_64"),
+					LaunchType: aws.String("ec2"),
+				},
+			},
+			inNetwork: privateNetwork,
+			inEnv:     ec2Env,
+			wantErr:   `"platform" architecture x86_64 does not match the arm64 architecture of the EC2 instances in env test`,
+		},
+		"architecture matches the EC2 instances": {
+			inPlatform: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					OSFamily:   aws.String("linux"),
+					Arch:       aws.String("arm64"),
+					LaunchType: aws.String("ec2"),
+				},
+			},
+			inNetwork: privateNetwork,
+			inEnv:     ec2Env,
+		},
+		"tasks in awsvpc network mode are placed in public subnets": {
+			inPlatform: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					LaunchType: aws.String("ec2"),
+				},
+			},
+			inEnv:   ec2Env,
+			wantErr: `"network.vpc.placement" must be private when "platform.network_mode" is awsvpc on EC2 instances; otherwise set "platform.network_mode" to bridge`,
+		},
+		"tasks in awsvpc network mode are placed in private subnets": {
+			inPlatform: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					LaunchType: aws.String("ec2"),
+				},
+			},
+			inNetwork: privateNetwork,
+			inEnv:     ec2Env,
+		},
+		"tasks in bridge network mode are placed in public subnets": {
+			inPlatform: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					LaunchType:  aws.String("ec2"),
+					NetworkMode: aws.String("bridge"),
+				},
+			},
+			inEnv: ec2Env,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateEC2Runtime(manifest.TaskConfig{Platform: tc.inPlatform}, tc.inNetwork, tc.inEnv)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStaticSiteDeployer_UploadArtifacts(t *testing.T) {
+	const (
+		mockName          = "frontend"
+		mockS3Bucket      = "mockBucket"
+		mockWorkspacePath = "/copilot"
+		mockMappingURL    = "https://mockBucket.s3.us-west-2.amazonaws.com/manual/static-site/frontend/mappings/abc.json"
+	)
+	indexContent, appContent := []byte("<html></html>"), []byte("console.log('hi')")
+	mockError := errors.New("some error")
+	testCases := map[string]struct {
+		inBuild string
+		inFiles map[string][]byte
+		mock    func(cmd *mocks.MockcmdRunner, uploader *mocks.Mockuploader)
+
+		wantedMapping []staticSiteAsset
+		wantedURL     string
+		wantedErr     error
+	}{
+		"error if the build command fails": {
+			inBuild: "npm run build",
+			mock: func(cmd *mocks.MockcmdRunner, _ *mocks.Mockuploader) {
+				cmd.EXPECT().Run("sh", []string{"-c", "npm run build"}, gomock.Any()).Return(mockError)
+			},
+			wantedErr: errors.New(`run build command "npm run build": some error`),
+		},
+		"error if the source directory has no files": {
+			inFiles: map[string][]byte{},
+			mock:    func(_ *mocks.MockcmdRunner, _ *mocks.Mockuploader) {},
+
+			wantedErr: errors.New("no files found in directory dist"),
+		},
+		"error if an asset cannot be uploaded": {
+			inFiles: map[string][]byte{
+				"dist/index.html": indexContent,
+			},
+			mock: func(_ *mocks.MockcmdRunner, uploader *mocks.Mockuploader) {
+				uploader.EXPECT().Upload(mockS3Bucket, artifactpath.StaticSiteAsset(mockName, indexContent), gomock.Any()).Return("", mockError)
+			},
+			wantedErr: errors.New("upload the files of directory dist: put static site asset index.html to bucket mockBucket: some error"),
+		},
+		"build and upload the assets and their mapping": {
+			inBuild: "npm run build",
+			inFiles: map[string][]byte{
+				"dist/index.html": indexContent,
+				"dist/js/app.js":  appContent,
+				"src/main.js":     []byte("not uploaded"),
+			},
+			mock: func(cmd *mocks.MockcmdRunner, uploader *mocks.Mockuploader) {
+				cmd.EXPECT().Run("sh", []string{"-c", "npm run build"}, gomock.Any()).Return(nil)
+				uploader.EXPECT().Upload(mockS3Bucket, artifactpath.StaticSiteAsset(mockName, indexContent), gomock.Any()).Return("", nil)
+				uploader.EXPECT().Upload(mockS3Bucket, artifactpath.StaticSiteAsset(mockName, appContent), gomock.Any()).Return("", nil)
+				uploader.EXPECT().Upload(mockS3Bucket, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, key string, data io.Reader) (string, error) {
+						content, err := io.ReadAll(data)
+						require.NoError(t, err)
+						require.Equal(t, artifactpath.StaticSiteAssetMapping(mockName, content), key)
+						var mapping []staticSiteAsset
+						require.NoError(t, json.Unmarshal(content, &mapping))
+						require.Equal(t, []staticSiteAsset{
+							{
+								ArtifactPath: artifactpath.StaticSiteAsset(mockName, indexContent),
+								DestPath:     "index.html",
+								ContentType:  mime.TypeByExtension(".html"),
+							},
+							{
+								ArtifactPath: artifactpath.StaticSiteAsset(mockName, appContent),
+								DestPath:     "js/app.js",
+								ContentType:  mime.TypeByExtension(".js"),
+							},
+						}, mapping)
+						return mockMappingURL, nil
+					})
+			},
+			wantedURL: mockMappingURL,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCmd := mocks.NewMockcmdRunner(ctrl)
+			mockUploader := mocks.NewMockuploader(ctrl)
+			tc.mock(mockCmd, mockUploader)
+			fs := afero.NewMemMapFs()
+			require.NoError(t, fs.MkdirAll(filepath.Join(mockWorkspacePath, "dist"), 0755))
+			for path, content := range tc.inFiles {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join(mockWorkspacePath, path), content, 0644))
+			}
+			mft := manifest.NewStaticSite(manifest.StaticSiteProps{
+				Name:       mockName,
+				SourcePath: "dist",
+			})
+			if tc.inBuild != "" {
+				mft.Source.Build = aws.String(tc.inBuild)
+			}
+			deployer := &staticSiteDeployer{
+				workloadDeployer: &workloadDeployer{
+					name:          mockName,
+					workspacePath: mockWorkspacePath,
+					resources: &stack.AppRegionalResources{
+						S3Bucket: mockS3Bucket,
+					},
+					s3Client: mockUploader,
+				},
+				siteFS:  &afero.Afero{Fs: fs},
+				cmd:     mockCmd,
+				siteMft: mft,
+			}
+
+			// WHEN
+			got, err := deployer.UploadArtifacts()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedURL, got.StaticSiteAssetMappingURL)
+		})
+	}
+}
+
+func TestStaticSiteDeployer_validateAliases(t *testing.T) {
+	mockApp := &config.Application{
+		Name:   "phonetool",
+		Domain: "example.com",
+	}
+	testCases := map[string]struct {
+		inApp     *config.Application
+		inAliases manifest.Alias
+		mock      func(m *mocks.MockversionGetter)
+
+		wantedErr error
+	}{
+		"no aliases": {
+			inApp: &config.Application{Name: "phonetool"},
+			mock:  func(m *mocks.MockversionGetter) {},
+		},
+		"error if the application has no domain": {
+			inApp:     &config.Application{Name: "phonetool"},
+			inAliases: manifest.Alias{String: aws.String("test.phonetool.example.com")},
+			mock:      func(m *mocks.MockversionGetter) {},
+
+			wantedErr: errors.New("cannot specify aliases when application is not associated with a domain"),
+		},
+		"error if the application version is outdated": {
+			inApp:     mockApp,
+			inAliases: manifest.Alias{String: aws.String("test.phonetool.example.com")},
+			mock: func(m *mocks.MockversionGetter) {
+				m.EXPECT().Version().Return("v0.0.0", nil)
+			},
+
+			wantedErr: fmt.Errorf("alias is not compatible with application versions below %s", deploy.AliasLeastAppTemplateVersion),
+		},
+		"error if an alias is outside of the environment's hosted zone": {
+			inApp:     mockApp,
+			inAliases: manifest.Alias{StringSlice: []string{"www.test.phonetool.example.com", "phonetool.example.com"}},
+			mock: func(m *mocks.MockversionGetter) {
+				m.EXPECT().Version().Return(deploy.AliasLeastAppTemplateVersion, nil)
+			},
+
+			wantedErr: errors.New(`alias "phonetool.example.com" for aliases must match either test.phonetool.example.com or <name>.test.phonetool.example.com`),
+		},
+		"success": {
+			inApp:     mockApp,
+			inAliases: manifest.Alias{StringSlice: []string{"test.phonetool.example.com", "www.test.phonetool.example.com"}},
+			mock: func(m *mocks.MockversionGetter) {
+				m.EXPECT().Version().Return(deploy.AliasLeastAppTemplateVersion, nil)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockVersionGetter := mocks.NewMockversionGetter(ctrl)
+			tc.mock(mockVersionGetter)
+			mft := manifest.NewStaticSite(manifest.StaticSiteProps{
+				Name:       "frontend",
+				SourcePath: "dist",
+			})
+			mft.Aliases = tc.inAliases
+			deployer := &staticSiteDeployer{
+				workloadDeployer: &workloadDeployer{
+					name: "frontend",
+					app:  tc.inApp,
+					env: &config.Environment{
+						Name: "test",
+					},
+				},
+				appVersionGetter: mockVersionGetter,
+				siteMft:          mft,
+			}
+
+			// WHEN
+			err := deployer.validateAliases()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStaticSiteDeployer_deployedAssetMappingURL(t *testing.T) {
+	const mockStackName = "phonetool-test-frontend"
+	testCases := map[string]struct {
+		mock func(m *mocks.MockstackDescriber)
+
+		wantedURL string
+		wantedErr error
+	}{
+		"error describing the stack": {
+			mock: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe(mockStackName).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("get the deployed asset mapping of static site frontend: some error"),
+		},
+		"no mapping if the site isn't deployed yet": {
+			mock: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe(mockStackName).Return(nil, &cloudformation.ErrStackNotFound{})
+			},
+		},
+		"no mapping if the deployed site has no assets": {
+			mock: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe(mockStackName).Return(&cloudformation.StackDescription{
+					Parameters: []*awscfn.Parameter{
+						{
+							ParameterKey:   aws.String(stack.StaticSiteAssetMappingFileBucketParamKey),
+							ParameterValue: aws.String(""),
+						},
+						{
+							ParameterKey:   aws.String(stack.StaticSiteAssetMappingFileKeyParamKey),
+							ParameterValue: aws.String(""),
+						},
+					},
+				}, nil)
+			},
+		},
+		"returns the mapping of the deployed site": {
+			mock: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe(mockStackName).Return(&cloudformation.StackDescription{
+					Parameters: []*awscfn.Parameter{
+						{
+							ParameterKey:   aws.String(stack.WorkloadNameParamKey),
+							ParameterValue: aws.String("frontend"),
+						},
+						{
+							ParameterKey:   aws.String(stack.StaticSiteAssetMappingFileBucketParamKey),
+							ParameterValue: aws.String("mockBucket"),
+						},
+						{
+							ParameterKey:   aws.String(stack.StaticSiteAssetMappingFileKeyParamKey),
+							ParameterValue: aws.String("manual/static-site/frontend/mappings/abc.json"),
+						},
+					},
+				}, nil)
+			},
+			wantedURL: "https://mockBucket.s3.us-west-2.amazonaws.com/manual/static-site/frontend/mappings/abc.json",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockStackDescriber := mocks.NewMockstackDescriber(ctrl)
+			tc.mock(mockStackDescriber)
+			deployer := &staticSiteDeployer{
+				workloadDeployer: &workloadDeployer{
+					name: "frontend",
+					app:  &config.Application{Name: "phonetool"},
+					env: &config.Environment{
+						Name:   "test",
+						Region: "us-west-2",
+					},
+				},
+				stackDescriber: mockStackDescriber,
+			}
+
+			// WHEN
+			got, err := deployer.deployedAssetMappingURL()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedURL, got)
+		})
+	}
+}
@@ -6,14 +6,17 @@ package mocks
 
 import (
 	io "io"
+	filepath "path/filepath"
 	reflect "reflect"
 	time "time"
 
 	cloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	ecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	s3 "github.com/aws/copilot-cli/internal/pkg/aws/s3"
 	deploy "github.com/aws/copilot-cli/internal/pkg/deploy"
 	cloudformation0 "github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation"
 	dockerengine "github.com/aws/copilot-cli/internal/pkg/docker/dockerengine"
+	exec "github.com/aws/copilot-cli/internal/pkg/exec"
 	repository "github.com/aws/copilot-cli/internal/pkg/repository"
 	progress "github.com/aws/copilot-cli/internal/pkg/term/progress"
 	gomock "github.com/golang/mock/gomock"
@@ -94,6 +97,21 @@ func (mr *MockimageBuilderPusherMockRecorder) BuildAndPush(docker, args interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildAndPush", reflect.TypeOf((*MockimageBuilderPusher)(nil).BuildAndPush), docker, args)
 }
 
+// Digest mocks base method.
+func (m *MockimageBuilderPusher) Digest(tag string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Digest", tag)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Digest indicates an expected call of Digest.
+func (mr *MockimageBuilderPusherMockRecorder) Digest(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Digest", reflect.TypeOf((*MockimageBuilderPusher)(nil).Digest), tag)
+}
+
 // Mockuploader is a mock of uploader interface.
 type Mockuploader struct {
 	ctrl     *gomock.Controller
@@ -357,6 +375,120 @@ func (mr *MockpublicCIDRBlocksGetterMockRecorder) PublicCIDRBlocks() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicCIDRBlocks", reflect.TypeOf((*MockpublicCIDRBlocksGetter)(nil).PublicCIDRBlocks))
 }
 
+// MockprefixListGetter is a mock of prefixListGetter interface.
+type MockprefixListGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockprefixListGetterMockRecorder
+}
+
+// MockprefixListGetterMockRecorder is the mock recorder for MockprefixListGetter.
+type MockprefixListGetterMockRecorder struct {
+	mock *MockprefixListGetter
+}
+
+// NewMockprefixListGetter creates a new mock instance.
+func NewMockprefixListGetter(ctrl *gomock.Controller) *MockprefixListGetter {
+	mock := &MockprefixListGetter{ctrl: ctrl}
+	mock.recorder = &MockprefixListGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprefixListGetter) EXPECT() *MockprefixListGetterMockRecorder {
+	return m.recorder
+}
+
+// CloudFrontManagedPrefixListID mocks base method.
+func (m *MockprefixListGetter) CloudFrontManagedPrefixListID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudFrontManagedPrefixListID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloudFrontManagedPrefixListID indicates an expected call of CloudFrontManagedPrefixListID.
+func (mr *MockprefixListGetterMockRecorder) CloudFrontManagedPrefixListID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudFrontManagedPrefixListID", reflect.TypeOf((*MockprefixListGetter)(nil).CloudFrontManagedPrefixListID))
+}
+
+// MockenvParamsGetter is a mock of envParamsGetter interface.
+type MockenvParamsGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockenvParamsGetterMockRecorder
+}
+
+// MockenvParamsGetterMockRecorder is the mock recorder for MockenvParamsGetter.
+type MockenvParamsGetterMockRecorder struct {
+	mock *MockenvParamsGetter
+}
+
+// NewMockenvParamsGetter creates a new mock instance.
+func NewMockenvParamsGetter(ctrl *gomock.Controller) *MockenvParamsGetter {
+	mock := &MockenvParamsGetter{ctrl: ctrl}
+	mock.recorder = &MockenvParamsGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockenvParamsGetter) EXPECT() *MockenvParamsGetterMockRecorder {
+	return m.recorder
+}
+
+// Params mocks base method.
+func (m *MockenvParamsGetter) Params() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Params")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Params indicates an expected call of Params.
+func (mr *MockenvParamsGetterMockRecorder) Params() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Params", reflect.TypeOf((*MockenvParamsGetter)(nil).Params))
+}
+
+// MockstackDescriber is a mock of stackDescriber interface.
+type MockstackDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockstackDescriberMockRecorder
+}
+
+// MockstackDescriberMockRecorder is the mock recorder for MockstackDescriber.
+type MockstackDescriberMockRecorder struct {
+	mock *MockstackDescriber
+}
+
+// NewMockstackDescriber creates a new mock instance.
+func NewMockstackDescriber(ctrl *gomock.Controller) *MockstackDescriber {
+	mock := &MockstackDescriber{ctrl: ctrl}
+	mock.recorder = &MockstackDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstackDescriber) EXPECT() *MockstackDescriberMockRecorder {
+	return m.recorder
+}
+
+// Describe mocks base method.
+func (m *MockstackDescriber) Describe(name string) (*cloudformation.StackDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Describe", name)
+	ret0, _ := ret[0].(*cloudformation.StackDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Describe indicates an expected call of Describe.
+func (mr *MockstackDescriberMockRecorder) Describe(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Describe", reflect.TypeOf((*MockstackDescriber)(nil).Describe), name)
+}
+
 // MockcustomResourcesUploader is a mock of customResourcesUploader interface.
 type MockcustomResourcesUploader struct {
 	ctrl     *gomock.Controller
@@ -557,6 +689,81 @@ func (mr *MockserviceForceUpdaterMockRecorder) LastUpdatedAt(app, env, svc inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUpdatedAt", reflect.TypeOf((*MockserviceForceUpdater)(nil).LastUpdatedAt), app, env, svc)
 }
 
+// MockpreDeployTasksLister is a mock of preDeployTasksLister interface.
+type MockpreDeployTasksLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockpreDeployTasksListerMockRecorder
+}
+
+// MockpreDeployTasksListerMockRecorder is the mock recorder for MockpreDeployTasksLister.
+type MockpreDeployTasksListerMockRecorder struct {
+	mock *MockpreDeployTasksLister
+}
+
+// NewMockpreDeployTasksLister creates a new mock instance.
+func NewMockpreDeployTasksLister(ctrl *gomock.Controller) *MockpreDeployTasksLister {
+	mock := &MockpreDeployTasksLister{ctrl: ctrl}
+	mock.recorder = &MockpreDeployTasksListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpreDeployTasksLister) EXPECT() *MockpreDeployTasksListerMockRecorder {
+	return m.recorder
+}
+
+// StoppedPreDeployTasks mocks base method.
+func (m *MockpreDeployTasksLister) StoppedPreDeployTasks(app, env, svc string) ([]*ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoppedPreDeployTasks", app, env, svc)
+	ret0, _ := ret[0].([]*ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoppedPreDeployTasks indicates an expected call of StoppedPreDeployTasks.
+func (mr *MockpreDeployTasksListerMockRecorder) StoppedPreDeployTasks(app, env, svc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoppedPreDeployTasks", reflect.TypeOf((*MockpreDeployTasksLister)(nil).StoppedPreDeployTasks), app, env, svc)
+}
+
+// MocktaskLogsWriter is a mock of taskLogsWriter interface.
+type MocktaskLogsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MocktaskLogsWriterMockRecorder
+}
+
+// MocktaskLogsWriterMockRecorder is the mock recorder for MocktaskLogsWriter.
+type MocktaskLogsWriterMockRecorder struct {
+	mock *MocktaskLogsWriter
+}
+
+// NewMocktaskLogsWriter creates a new mock instance.
+func NewMocktaskLogsWriter(ctrl *gomock.Controller) *MocktaskLogsWriter {
+	mock := &MocktaskLogsWriter{ctrl: ctrl}
+	mock.recorder = &MocktaskLogsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktaskLogsWriter) EXPECT() *MocktaskLogsWriterMockRecorder {
+	return m.recorder
+}
+
+// WriteEventsUntilStopped mocks base method.
+func (m *MocktaskLogsWriter) WriteEventsUntilStopped() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteEventsUntilStopped")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteEventsUntilStopped indicates an expected call of WriteEventsUntilStopped.
+func (mr *MocktaskLogsWriterMockRecorder) WriteEventsUntilStopped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEventsUntilStopped", reflect.TypeOf((*MocktaskLogsWriter)(nil).WriteEventsUntilStopped))
+}
+
 // Mockspinner is a mock of spinner interface.
 type Mockspinner struct {
 	ctrl     *gomock.Controller
@@ -679,6 +886,100 @@ func (mr *MockaliasCertValidatorMockRecorder) ValidateCertAliases(aliases, certs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCertAliases", reflect.TypeOf((*MockaliasCertValidator)(nil).ValidateCertAliases), aliases, certs)
 }
 
+// MockstaticSiteFS is a mock of staticSiteFS interface.
+type MockstaticSiteFS struct {
+	ctrl     *gomock.Controller
+	recorder *MockstaticSiteFSMockRecorder
+}
+
+// MockstaticSiteFSMockRecorder is the mock recorder for MockstaticSiteFS.
+type MockstaticSiteFSMockRecorder struct {
+	mock *MockstaticSiteFS
+}
+
+// NewMockstaticSiteFS creates a new mock instance.
+func NewMockstaticSiteFS(ctrl *gomock.Controller) *MockstaticSiteFS {
+	mock := &MockstaticSiteFS{ctrl: ctrl}
+	mock.recorder = &MockstaticSiteFSMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstaticSiteFS) EXPECT() *MockstaticSiteFSMockRecorder {
+	return m.recorder
+}
+
+// ReadFile mocks base method.
+func (m *MockstaticSiteFS) ReadFile(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile.
+func (mr *MockstaticSiteFSMockRecorder) ReadFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockstaticSiteFS)(nil).ReadFile), arg0)
+}
+
+// Walk mocks base method.
+func (m *MockstaticSiteFS) Walk(root string, walkFn filepath.WalkFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Walk", root, walkFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Walk indicates an expected call of Walk.
+func (mr *MockstaticSiteFSMockRecorder) Walk(root, walkFn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Walk", reflect.TypeOf((*MockstaticSiteFS)(nil).Walk), root, walkFn)
+}
+
+// MockcmdRunner is a mock of cmdRunner interface.
+type MockcmdRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockcmdRunnerMockRecorder
+}
+
+// MockcmdRunnerMockRecorder is the mock recorder for MockcmdRunner.
+type MockcmdRunnerMockRecorder struct {
+	mock *MockcmdRunner
+}
+
+// NewMockcmdRunner creates a new mock instance.
+func NewMockcmdRunner(ctrl *gomock.Controller) *MockcmdRunner {
+	mock := &MockcmdRunner{ctrl: ctrl}
+	mock.recorder = &MockcmdRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcmdRunner) EXPECT() *MockcmdRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockcmdRunner) Run(name string, args []string, opts ...exec.CmdOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Run", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockcmdRunnerMockRecorder) Run(name, args interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockcmdRunner)(nil).Run), varargs...)
+}
+
 // MocktimeoutError is a mock of timeoutError interface.
 type MocktimeoutError struct {
 	ctrl     *gomock.Controller
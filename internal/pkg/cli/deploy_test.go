@@ -164,3 +164,71 @@ func TestDeployOpts_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestDeployOpts_Run_Service(t *testing.T) {
+	testCases := map[string]struct {
+		inForceUnlock bool
+
+		mockLocker func(m *mocks.Mocklocker)
+
+		wantedErr string
+	}{
+		"takes the deployment lock of the service": {
+			mockLocker: func(m *mocks.Mocklocker) {
+				m.EXPECT().AcquireLock("app", "workloads/test/fe", lockTTL).Return(nil, errors.New("some error"))
+			},
+			wantedErr: "execute svc deploy: some error",
+		},
+		"releases the deployment lock first with --force-unlock": {
+			inForceUnlock: true,
+			mockLocker: func(m *mocks.Mocklocker) {
+				gomock.InOrder(
+					m.EXPECT().ForceReleaseLock("app", "workloads/test/fe").Return(nil),
+					m.EXPECT().AcquireLock("app", "workloads/test/fe", lockTTL).Return(nil, errors.New("some error")),
+				)
+			},
+			wantedErr: "execute svc deploy: some error",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockstore(ctrl)
+			mockStore.EXPECT().GetWorkload("app", "fe").Return(&config.Workload{
+				App:  "app",
+				Name: "fe",
+				Type: "Load Balanced Web Service",
+			}, nil)
+			mockStore.EXPECT().GetApplication("app").Return(&config.Application{Name: "app"}, nil)
+			mockStore.EXPECT().GetEnvironment("app", "test").Return(&config.Environment{Name: "test"}, nil)
+			mockWs := mocks.NewMockwsWlDirReader(ctrl)
+			mockWs.EXPECT().ListServices().Return([]string{"fe"}, nil)
+			mockLocker := mocks.NewMocklocker(ctrl)
+			tc.mockLocker(mockLocker)
+			opts := &deployOpts{
+				deployWkldVars: deployWkldVars{
+					appName:     "app",
+					name:        "fe",
+					envName:     "test",
+					forceUnlock: tc.inForceUnlock,
+
+					clientConfigured: true,
+				},
+				store:  mockStore,
+				locker: mockLocker,
+				ws:     mockWs,
+
+				setupDeployCmd: setupWkldDeployCmd,
+			}
+
+			// WHEN
+			err := opts.Run()
+
+			// THEN
+			require.EqualError(t, err, tc.wantedErr)
+		})
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/service/ssm"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
@@ -26,6 +28,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	deploycfn "github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
 	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
@@ -66,6 +69,9 @@ https://aws.github.io/copilot-cli/docs/credentials/#environment-credentials`
 	envInitRegionPrompt        = "Which region?"
 	envInitDefaultRegionOption = "us-west-2"
 
+	defaultEC2CapacityArch    = manifest.ArchX86
+	defaultEC2CapacityMaxSize = 10
+
 	fmtDNSDelegationStart    = "Sharing DNS permissions for this application to account %s."
 	fmtDNSDelegationFailed   = "Failed to grant DNS permissions to account %s.\n\n"
 	fmtDNSDelegationComplete = "Shared DNS permissions for this application to account %s.\n\n"
@@ -134,6 +140,96 @@ func (v telemetryVars) toConfig() *config.Telemetry {
 	}
 }
 
+const (
+	wafMinRateLimit = 100
+	wafMaxRateLimit = 2000000000
+)
+
+var wafManagedRuleRegexp = regexp.MustCompile(`^AWSManagedRules[a-zA-Z0-9]+$`)
+
+type wafVars struct {
+	WebACLARN    string
+	ManagedRules []string
+	RateLimit    int
+}
+
+func (v wafVars) isSet() bool {
+	return v.WebACLARN != "" || len(v.ManagedRules) != 0 || v.RateLimit != 0
+}
+
+func (v wafVars) validate() error {
+	if v.WebACLARN != "" && (len(v.ManagedRules) != 0 || v.RateLimit != 0) {
+		return fmt.Errorf("cannot specify --%s with --%s or --%s", wafARNFlag, wafManagedRulesFlag, wafRateLimitFlag)
+	}
+	if v.WebACLARN != "" {
+		parsed, err := arn.Parse(v.WebACLARN)
+		if err != nil {
+			return fmt.Errorf("parse --%s: %w", wafARNFlag, err)
+		}
+		if parsed.Service != "wafv2" || !strings.HasPrefix(parsed.Resource, "regional/webacl/") {
+			return fmt.Errorf("--%s must be the ARN of a regional AWS WAF web ACL", wafARNFlag)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, rule := range v.ManagedRules {
+		if !wafManagedRuleRegexp.MatchString(rule) {
+			return fmt.Errorf("--%s value %q is not the name of an AWS managed rule group", wafManagedRulesFlag, rule)
+		}
+		if seen[rule] {
+			return fmt.Errorf("--%s value %q is specified more than once", wafManagedRulesFlag, rule)
+		}
+		seen[rule] = true
+	}
+	if v.RateLimit != 0 && (v.RateLimit < wafMinRateLimit || v.RateLimit > wafMaxRateLimit) {
+		return fmt.Errorf("--%s must be between %d and %d", wafRateLimitFlag, wafMinRateLimit, wafMaxRateLimit)
+	}
+	return nil
+}
+
+func (v wafVars) toConfig() *config.WAF {
+	if !v.isSet() {
+		return nil
+	}
+	return &config.WAF{
+		WebACLARN:    v.WebACLARN,
+		ManagedRules: v.ManagedRules,
+		RateLimit:    v.RateLimit,
+	}
+}
+
+type ec2CapacityVars struct {
+	InstanceTypes  []string
+	Arch           string
+	MinSize        int
+	MaxSize        int
+	SpotPercentage int
+}
+
+func (v ec2CapacityVars) isSet() bool {
+	return len(v.InstanceTypes) != 0 || v.Arch != "" || v.MinSize != 0 || v.MaxSize != 0 || v.SpotPercentage != 0
+}
+
+func (v ec2CapacityVars) toConfig() *config.EC2Capacity {
+	if !v.isSet() {
+		return nil
+	}
+	arch := v.Arch
+	if arch == "" {
+		arch = defaultEC2CapacityArch
+	}
+	maxSize := v.MaxSize
+	if maxSize == 0 {
+		maxSize = defaultEC2CapacityMaxSize
+	}
+	return &config.EC2Capacity{
+		InstanceTypes:  v.InstanceTypes,
+		Arch:           arch,
+		MinSize:        v.MinSize,
+		MaxSize:        maxSize,
+		SpotPercentage: v.SpotPercentage,
+	}
+}
+
 type initEnvVars struct {
 	appName       string
 	name          string // Name for the environment.
@@ -141,13 +237,17 @@ type initEnvVars struct {
 	isProduction  bool   // True means retain resources even after deletion.
 	defaultConfig bool   // True means using default environment configuration.
 
-	importVPC   importVPCVars // Existing VPC resources to use instead of creating new ones.
-	adjustVPC   adjustVPCVars // Configure parameters for VPC resources generated while initializing an environment.
-	telemetry   telemetryVars // Configure observability and monitoring settings.
-	importCerts []string      // Addtional existing ACM certificates to use.
-
-	tempCreds tempCredsVars // Temporary credentials to initialize the environment. Mutually exclusive with the profile.
-	region    string        // The region to create the environment in.
+	importVPC     importVPCVars   // Existing VPC resources to use instead of creating new ones.
+	adjustVPC     adjustVPCVars   // Configure parameters for VPC resources generated while initializing an environment.
+	telemetry     telemetryVars   // Configure observability and monitoring settings.
+	importCerts   []string        // Addtional existing ACM certificates to use.
+	internalCerts []string        // Existing ACM certificates to use for the internal load balancer.
+	waf           wafVars         // Configure an AWS WAF web ACL for the internet-facing load balancer.
+	ec2Capacity   ec2CapacityVars // Configure EC2 instances that the cluster can place tasks on.
+
+	tempCreds      tempCredsVars // Temporary credentials to initialize the environment. Mutually exclusive with the profile.
+	region         string        // The region to create the environment in.
+	cfnServiceRole string        // The role that CloudFormation assumes to create the environment stack.
 }
 
 type initEnvOpts struct {
@@ -322,9 +422,12 @@ func (o *initEnvOpts) Execute() error {
 	}
 	env.Prod = o.isProduction
 	customizedEnv := config.CustomizeEnv{
-		ImportVPC:      o.importVPCConfig(),
-		VPCConfig:      o.adjustVPCConfig(),
-		ImportCertARNs: o.importCerts,
+		ImportVPC:        o.importVPCConfig(),
+		VPCConfig:        o.adjustVPCConfig(),
+		ImportCertARNs:   o.importCerts,
+		InternalCertARNs: o.internalCerts,
+		WAF:              o.waf.toConfig(),
+		EC2Capacity:      o.ec2Capacity.toConfig(),
 	}
 	if !customizedEnv.IsEmpty() {
 		env.CustomConfig = &customizedEnv
@@ -382,6 +485,36 @@ func (o *initEnvOpts) validateCustomizedResources() error {
 			return errors.New("at least two availability zones must be provided to enable Load Balancing")
 		}
 	}
+	if err := o.waf.validate(); err != nil {
+		return err
+	}
+	if o.ec2Capacity.isSet() {
+		if err := o.validateEC2Capacity(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *initEnvOpts) validateEC2Capacity() error {
+	if len(o.ec2Capacity.InstanceTypes) == 0 {
+		return fmt.Errorf("--%s must be specified to configure EC2 capacity", ec2InstanceTypesFlag)
+	}
+	if o.ec2Capacity.Arch != "" && o.ec2Capacity.Arch != manifest.ArchX86 && o.ec2Capacity.Arch != manifest.ArchARM64 {
+		return fmt.Errorf("--%s must be one of %s or %s", ec2ArchFlag, manifest.ArchX86, manifest.ArchARM64)
+	}
+	if o.ec2Capacity.MinSize < 0 {
+		return fmt.Errorf("--%s cannot be negative", ec2MinSizeFlag)
+	}
+	if o.ec2Capacity.MaxSize < 0 {
+		return fmt.Errorf("--%s cannot be negative", ec2MaxSizeFlag)
+	}
+	if maxSize := o.ec2Capacity.toConfig().MaxSize; o.ec2Capacity.MinSize > maxSize {
+		return fmt.Errorf("--%s (%d) cannot be greater than --%s (%d)", ec2MinSizeFlag, o.ec2Capacity.MinSize, ec2MaxSizeFlag, maxSize)
+	}
+	if o.ec2Capacity.SpotPercentage < 0 || o.ec2Capacity.SpotPercentage > 100 {
+		return fmt.Errorf("--%s must be between 0 and 100", ec2SpotPercentageFlag)
+	}
 	return nil
 }
 
@@ -707,9 +840,13 @@ func (o *initEnvOpts) deployEnv(app *config.Application,
 		ArtifactBucketKeyARN: artifactBucketKeyARN,
 		AdjustVPCConfig:      o.adjustVPCConfig(),
 		ImportCertARNs:       o.importCerts,
+		InternalCertARNs:     o.internalCerts,
+		WAF:                  o.waf.toConfig(),
+		EC2Capacity:          o.ec2Capacity.toConfig(),
 		ImportVPCConfig:      o.importVPCConfig(),
 		Telemetry:            o.telemetry.toConfig(),
 		Version:              deploy.LatestEnvTemplateVersion,
+		CFNServiceRoleARN:    o.cfnServiceRole,
 	}
 
 	if err := o.cleanUpDanglingRoles(o.appName, o.name); err != nil {
@@ -830,7 +967,11 @@ func buildEnvInitCmd() *cobra.Command {
   /code $ copilot env init --override-vpc-cidr 10.1.0.0/16 \
   /code --override-az-names us-west-2b,us-west-2c \
   /code --override-public-cidrs 10.1.0.0/24,10.1.1.0/24 \
-  /code --override-private-cidrs 10.1.2.0/24,10.1.3.0/24`,
+  /code --override-private-cidrs 10.1.2.0/24,10.1.3.0/24
+
+  Creates an environment whose cluster can also place tasks on Graviton EC2 instances.
+  /code $ copilot env init --ec2-instance-types m6g.large,c6g.large --ec2-arch arm64 \
+  /code --ec2-max-size 6 --ec2-spot-percentage 50`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newInitEnvOpts(vars)
 			if err != nil {
@@ -846,6 +987,7 @@ func buildEnvInitCmd() *cobra.Command {
 	cmd.Flags().StringVar(&vars.tempCreds.SecretAccessKey, secretAccessKeyFlag, "", secretAccessKeyFlagDescription)
 	cmd.Flags().StringVar(&vars.tempCreds.SessionToken, sessionTokenFlag, "", sessionTokenFlagDescription)
 	cmd.Flags().StringVar(&vars.region, regionFlag, "", envRegionTokenFlagDescription)
+	cmd.Flags().StringVar(&vars.cfnServiceRole, cfnServiceRoleFlag, "", cfnServiceRoleFlagDescription)
 
 	cmd.Flags().BoolVar(&vars.isProduction, prodEnvFlag, false, prodEnvFlagDescription) // Deprecated. Use telemetry flags instead.
 	cmd.Flags().BoolVar(&vars.telemetry.EnableContainerInsights, enableContainerInsightsFlag, false, enableContainerInsightsFlagDescription)
@@ -854,6 +996,15 @@ func buildEnvInitCmd() *cobra.Command {
 	cmd.Flags().StringSliceVar(&vars.importVPC.PublicSubnetIDs, publicSubnetsFlag, nil, publicSubnetsFlagDescription)
 	cmd.Flags().StringSliceVar(&vars.importVPC.PrivateSubnetIDs, privateSubnetsFlag, nil, privateSubnetsFlagDescription)
 	cmd.Flags().StringSliceVar(&vars.importCerts, certsFlag, nil, certsFlagDescription)
+	cmd.Flags().StringSliceVar(&vars.internalCerts, internalCertsFlag, nil, internalCertsFlagDescription)
+	cmd.Flags().StringVar(&vars.waf.WebACLARN, wafARNFlag, "", wafARNFlagDescription)
+	cmd.Flags().StringSliceVar(&vars.waf.ManagedRules, wafManagedRulesFlag, nil, wafManagedRulesFlagDescription)
+	cmd.Flags().IntVar(&vars.waf.RateLimit, wafRateLimitFlag, 0, wafRateLimitFlagDescription)
+	cmd.Flags().StringSliceVar(&vars.ec2Capacity.InstanceTypes, ec2InstanceTypesFlag, nil, ec2InstanceTypesFlagDescription)
+	cmd.Flags().StringVar(&vars.ec2Capacity.Arch, ec2ArchFlag, "", ec2ArchFlagDescription)
+	cmd.Flags().IntVar(&vars.ec2Capacity.MinSize, ec2MinSizeFlag, 0, ec2MinSizeFlagDescription)
+	cmd.Flags().IntVar(&vars.ec2Capacity.MaxSize, ec2MaxSizeFlag, 0, ec2MaxSizeFlagDescription)
+	cmd.Flags().IntVar(&vars.ec2Capacity.SpotPercentage, ec2SpotPercentageFlag, 0, ec2SpotPercentageFlagDescription)
 	cmd.Flags().IPNetVar(&vars.adjustVPC.CIDR, overrideVPCCIDRFlag, net.IPNet{}, overrideVPCCIDRFlagDescription)
 	cmd.Flags().StringSliceVar(&vars.adjustVPC.AZs, overrideAZsFlag, nil, overrideAZsFlagDescription)
 	// TODO: use IPNetSliceVar when it is available (https://github.com/spf13/pflag/issues/273).
@@ -869,6 +1020,7 @@ func buildEnvInitCmd() *cobra.Command {
 	flags.AddFlag(cmd.Flags().Lookup(secretAccessKeyFlag))
 	flags.AddFlag(cmd.Flags().Lookup(sessionTokenFlag))
 	flags.AddFlag(cmd.Flags().Lookup(regionFlag))
+	flags.AddFlag(cmd.Flags().Lookup(cfnServiceRoleFlag))
 	flags.AddFlag(cmd.Flags().Lookup(defaultConfigFlag))
 
 	resourcesImportFlags := pflag.NewFlagSet("Import Existing Resources", pflag.ContinueOnError)
@@ -876,11 +1028,20 @@ func buildEnvInitCmd() *cobra.Command {
 	resourcesImportFlags.AddFlag(cmd.Flags().Lookup(publicSubnetsFlag))
 	resourcesImportFlags.AddFlag(cmd.Flags().Lookup(privateSubnetsFlag))
 	resourcesImportFlags.AddFlag(cmd.Flags().Lookup(certsFlag))
+	resourcesImportFlags.AddFlag(cmd.Flags().Lookup(internalCertsFlag))
+	resourcesImportFlags.AddFlag(cmd.Flags().Lookup(wafARNFlag))
 	resourcesConfigFlags := pflag.NewFlagSet("Configure Default Resources", pflag.ContinueOnError)
 	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(overrideVPCCIDRFlag))
 	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(overrideAZsFlag))
 	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(overridePublicSubnetCIDRsFlag))
 	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(overridePrivateSubnetCIDRsFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(wafManagedRulesFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(wafRateLimitFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(ec2InstanceTypesFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(ec2ArchFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(ec2MinSizeFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(ec2MaxSizeFlag))
+	resourcesConfigFlags.AddFlag(cmd.Flags().Lookup(ec2SpotPercentageFlag))
 
 	telemetryFlags := pflag.NewFlagSet("Telemetry", pflag.ContinueOnError)
 	telemetryFlags.AddFlag(cmd.Flags().Lookup(enableContainerInsightsFlag))
@@ -53,6 +53,12 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 		inAZs         []string
 		inPublicCIDRs []string
 
+		inWAFARN          string
+		inWAFManagedRules []string
+		inWAFRateLimit    int
+
+		inEC2Capacity ec2CapacityVars
+
 		inProfileName     string
 		inAccessKeyID     string
 		inSecretAccessKey string
@@ -111,6 +117,113 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 			},
 			wantedErrMsg: fmt.Sprintf("cannot import or configure vpc if --%s is set", defaultConfigFlag),
 		},
+		"cannot import a web ACL and configure managed rules": {
+			inEnvName:         "test-pdx",
+			inAppName:         "phonetool",
+			inWAFARN:          "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3",
+			inWAFManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: fmt.Sprintf("cannot specify --%s with --%s or --%s", wafARNFlag, wafManagedRulesFlag, wafRateLimitFlag),
+		},
+		"should err if the imported web ACL is not a regional web ACL": {
+			inEnvName: "test-pdx",
+			inAppName: "phonetool",
+			inWAFARN:  "arn:aws:wafv2:us-east-1:123456789012:global/webacl/mock/a1b2c3",
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: fmt.Sprintf("--%s must be the ARN of a regional AWS WAF web ACL", wafARNFlag),
+		},
+		"should err if a managed rule is not an AWS managed rule group": {
+			inEnvName:         "test-pdx",
+			inAppName:         "phonetool",
+			inWAFManagedRules: []string{"AWSManagedRulesCommonRuleSet", "MyRuleGroup"},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: fmt.Sprintf(`--%s value "MyRuleGroup" is not the name of an AWS managed rule group`, wafManagedRulesFlag),
+		},
+		"should err if a managed rule is specified more than once": {
+			inEnvName:         "test-pdx",
+			inAppName:         "phonetool",
+			inWAFManagedRules: []string{"AWSManagedRulesCommonRuleSet", "AWSManagedRulesCommonRuleSet"},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: fmt.Sprintf(`--%s value "AWSManagedRulesCommonRuleSet" is specified more than once`, wafManagedRulesFlag),
+		},
+		"should err if the rate limit is out of range": {
+			inEnvName:      "test-pdx",
+			inAppName:      "phonetool",
+			inWAFRateLimit: 50,
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: fmt.Sprintf("--%s must be between 100 and 2000000000", wafRateLimitFlag),
+		},
+		"should err if EC2 capacity is configured without instance types": {
+			inEnvName: "test-pdx",
+			inAppName: "phonetool",
+			inEC2Capacity: ec2CapacityVars{
+				MaxSize: 5,
+			},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: "--ec2-instance-types must be specified to configure EC2 capacity",
+		},
+		"should err if the EC2 architecture is invalid": {
+			inEnvName: "test-pdx",
+			inAppName: "phonetool",
+			inEC2Capacity: ec2CapacityVars{
+				InstanceTypes: []string{"m6g.large"},
+				Arch:          "arm",
+			},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: "--ec2-arch must be one of x86_64 or arm64",
+		},
+		"should err if the minimum number of EC2 instances is greater than the default maximum": {
+			inEnvName: "test-pdx",
+			inAppName: "phonetool",
+			inEC2Capacity: ec2CapacityVars{
+				InstanceTypes: []string{"m6g.large"},
+				MinSize:       20,
+			},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: "--ec2-min-size (20) cannot be greater than --ec2-max-size (10)",
+		},
+		"should err if the spot percentage is out of range": {
+			inEnvName: "test-pdx",
+			inAppName: "phonetool",
+			inEC2Capacity: ec2CapacityVars{
+				InstanceTypes:  []string{"m6g.large"},
+				SpotPercentage: 101,
+			},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+			wantedErrMsg: "--ec2-spot-percentage must be between 0 and 100",
+		},
+		"valid EC2 capacity": {
+			inEnvName: "test-pdx",
+			inAppName: "phonetool",
+			inEC2Capacity: ec2CapacityVars{
+				InstanceTypes:  []string{"m6g.large", "c6g.large"},
+				Arch:           "arm64",
+				MinSize:        1,
+				MaxSize:        4,
+				SpotPercentage: 50,
+			},
+			setupMocks: func(m initEnvMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "test-pdx").Return(nil, &config.ErrNoSuchEnvironment{})
+			},
+		},
 		"should err if both profile and access key id are set": {
 			inAppName:     "phonetool",
 			inEnvName:     "test",
@@ -197,8 +310,14 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 						PrivateSubnetIDs: tc.inPrivateIDs,
 						ID:               tc.inVPCID,
 					},
-					appName: tc.inAppName,
-					profile: tc.inProfileName,
+					waf: wafVars{
+						WebACLARN:    tc.inWAFARN,
+						ManagedRules: tc.inWAFManagedRules,
+						RateLimit:    tc.inWAFRateLimit,
+					},
+					ec2Capacity: tc.inEC2Capacity,
+					appName:     tc.inAppName,
+					profile:     tc.inProfileName,
 					tempCreds: tempCredsVars{
 						AccessKeyID:     tc.inAccessKeyID,
 						SecretAccessKey: tc.inSecretAccessKey,
@@ -844,6 +963,7 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 func TestInitEnvOpts_Execute(t *testing.T) {
 	testCases := map[string]struct {
 		enableContainerInsights bool
+		cfnServiceRole          string
 
 		expectStore             func(m *mocks.Mockstore)
 		expectDeployer          func(m *mocks.Mockdeployer)
@@ -1148,6 +1268,55 @@ func TestInitEnvOpts_Execute(t *testing.T) {
 				m.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(map[string]string{"mockCustomResource": "mockURL"}, nil)
 			},
 		},
+		"creates the environment stack with the CloudFormation service role": {
+			cfnServiceRole: "arn:aws:iam::1234:role/phonetool-pipeline-PreviewCFNExecutionRole",
+			expectStore: func(m *mocks.Mockstore) {
+				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+			},
+			expectIdentity: func(m *mocks.MockidentityService) {
+				m.EXPECT().Get().Return(identity.Caller{RootUserARN: "some arn", Account: "1234"}, nil).Times(2)
+			},
+			expectIAM: func(m *mocks.MockroleManager) {
+				m.EXPECT().CreateECSServiceLinkedRole().Return(nil)
+			},
+			expectCFN: func(m *mocks.MockstackExistChecker) {
+				m.EXPECT().Exists("phonetool-test").Return(true, nil)
+			},
+			expectProgress: func(m *mocks.Mockprogress) {
+				m.EXPECT().Start(fmt.Sprintf(fmtAddEnvToAppStart, "1234", "us-west-2", "phonetool"))
+				m.EXPECT().Stop(log.Ssuccessf(fmtAddEnvToAppComplete, "1234", "us-west-2", "phonetool"))
+			},
+			expectDeployer: func(m *mocks.Mockdeployer) {
+				m.EXPECT().AddEnvToApp(gomock.Any()).Return(nil)
+				m.EXPECT().DeployAndRenderEnvironment(gomock.Any(), &deploy.CreateEnvironmentInput{
+					Name: "test",
+					App: deploy.AppInformation{
+						Name:                "phonetool",
+						AccountPrincipalARN: "some arn",
+					},
+					CustomResourcesURLs: map[string]string{"mockCustomResource": "mockURL"},
+					Telemetry: &config.Telemetry{
+						EnableContainerInsights: false,
+					},
+					Version:              deploy.LatestEnvTemplateVersion,
+					ArtifactBucketARN:    "arn:aws:s3:::mockBucket",
+					ArtifactBucketKeyARN: "mockKMS",
+					CFNServiceRoleARN:    "arn:aws:iam::1234:role/phonetool-pipeline-PreviewCFNExecutionRole",
+				}).Return(nil)
+				m.EXPECT().GetEnvironment("phonetool", "test").Return(nil, errors.New("some error"))
+			},
+			expectAppCFN: func(m *mocks.MockappResourcesGetter) {
+				m.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket:  "mockBucket",
+						KMSKeyARN: "mockKMS",
+					}, nil)
+			},
+			expectResourcesUploader: func(m *mocks.MockcustomResourcesUploader) {
+				m.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(map[string]string{"mockCustomResource": "mockURL"}, nil)
+			},
+			wantedErrorS: "get environment struct for test: some error",
+		},
 		"failed to delegate DNS (app has Domain and env and apps are different)": {
 			expectStore: func(m *mocks.Mockstore) {
 				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool", AccountID: "1234", Domain: "amazon.com"}, nil)
@@ -1271,6 +1440,7 @@ func TestInitEnvOpts_Execute(t *testing.T) {
 					telemetry: telemetryVars{
 						EnableContainerInsights: tc.enableContainerInsights,
 					},
+					cfnServiceRole: tc.cfnServiceRole,
 				},
 				store:       mockStore,
 				envDeployer: mockDeployer,
@@ -6,9 +6,11 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/addon"
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
 
 	"github.com/aws/aws-sdk-go/aws/endpoints"
@@ -22,13 +24,16 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/describe"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
 	"github.com/aws/copilot-cli/internal/pkg/template"
+	"github.com/aws/copilot-cli/internal/pkg/template/artifactpath"
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 	termprogress "github.com/aws/copilot-cli/internal/pkg/term/progress"
 	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
 	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -45,9 +50,12 @@ to support the latest Copilot features.`
 
 // envUpgradeVars holds flag values.
 type envUpgradeVars struct {
-	appName string // Required. Name of the application.
-	name    string // Required. Name of the environment.
-	all     bool   // True means all environments should be upgraded.
+	appName     string // Required. Name of the application.
+	name        string // Required. Name of the environment.
+	all         bool   // True means all environments should be upgraded.
+	forceUnlock bool   // True means the environment's lock should be released before upgrading.
+
+	waf wafVars // Configure an AWS WAF web ACL for the internet-facing load balancer.
 }
 
 // envUpgradeOpts represents the env upgrade command and holds the necessary data
@@ -56,11 +64,13 @@ type envUpgradeOpts struct {
 	envUpgradeVars
 
 	store              store
+	locker             locker
 	sel                appEnvSelector
 	legacyEnvTemplater templater
 	prog               progress
 	appCFN             appResourcesGetter
 	uploader           customResourcesUploader
+	envAddons          envAddonsTemplater // Nil if the command is not run from a workspace.
 
 	// Constructors for clients that can be initialized only at runtime.
 	// These functions are overridden in tests to provide mocks.
@@ -76,11 +86,12 @@ func newEnvUpgradeOpts(vars envUpgradeVars) (*envUpgradeOpts, error) {
 		return nil, err
 	}
 	store := config.NewSSMStore(identity.New(defaultSession), ssm.New(defaultSession), aws.StringValue(defaultSession.Config.Region))
-	return &envUpgradeOpts{
+	opts := &envUpgradeOpts{
 		envUpgradeVars: vars,
 
-		store: store,
-		sel:   selector.NewSelect(prompt.New(), store),
+		store:  store,
+		locker: store,
+		sel:    selector.NewSelect(prompt.New(), store),
 		legacyEnvTemplater: stack.NewEnvStackConfig(&deploy.CreateEnvironmentInput{
 			Version: deploy.LegacyEnvTemplateVersion,
 			App: deploy.AppInformation{
@@ -116,7 +127,12 @@ func newEnvUpgradeOpts(vars envUpgradeVars) (*envUpgradeOpts, error) {
 			}
 			return s3.New(sess), nil
 		},
-	}, nil
+	}
+	// Environments can be upgraded outside of a workspace, in which case there are no environment addons to deploy.
+	if envAddons, err := addon.NewEnvironment(); err == nil {
+		opts.envAddons = envAddons
+	}
+	return opts, nil
 }
 
 // Validate returns an error if the values passed by flags are invalid.
@@ -125,8 +141,14 @@ func (o *envUpgradeOpts) Validate() error {
 		return fmt.Errorf("cannot specify both --%s and --%s flags", allFlag, nameFlag)
 	}
 	if o.all {
+		if o.waf.isSet() {
+			return fmt.Errorf("cannot specify --%s, --%s or --%s with --%s", wafARNFlag, wafManagedRulesFlag, wafRateLimitFlag, allFlag)
+		}
 		return nil
 	}
+	if err := o.waf.validate(); err != nil {
+		return err
+	}
 	if o.name != "" {
 		if _, err := o.store.GetEnvironment(o.appName, o.name); err != nil {
 			var errEnvDoesNotExist *config.ErrNoSuchEnvironment
@@ -184,7 +206,11 @@ func (o *envUpgradeOpts) Execute() error {
 		if err != nil {
 			return fmt.Errorf("upload custom resources to bucket %s: %w", resources.S3Bucket, err)
 		}
-		if err := o.upgrade(env, app, s3.FormatARN(endpoints.AwsPartitionID, resources.S3Bucket), resources.KMSKeyARN, urls); err != nil {
+		addons, err := o.uploadEnvAddons(s3Client, resources.S3Bucket)
+		if err != nil {
+			return err
+		}
+		if err := o.upgrade(env, app, s3.FormatARN(endpoints.AwsPartitionID, resources.S3Bucket), resources.KMSKeyARN, urls, addons); err != nil {
 			return err
 		}
 	}
@@ -212,15 +238,88 @@ func (o *envUpgradeOpts) listEnvsToUpgrade() ([]*config.Environment, error) {
 	return envs, nil
 }
 
+// uploadEnvAddons uploads the environment addons template to the bucket and returns the addons to deploy with the environment.
+// If there are no environment addons in the workspace, returns nil.
+func (o *envUpgradeOpts) uploadEnvAddons(s3Client uploader, bucket string) (*deploy.EnvAddons, error) {
+	if o.envAddons == nil {
+		return nil, nil
+	}
+	tpl, err := o.envAddons.Template()
+	if err != nil {
+		var notFoundErr *addon.ErrAddonsNotFound
+		if !errors.As(err, &notFoundErr) {
+			return nil, fmt.Errorf("retrieve environment addons template: %w", err)
+		}
+		if notFoundErr.ParentErr == nil {
+			// The addons directory exists but its templates were all deleted, redeploy to remove the addons stack.
+			return &deploy.EnvAddons{}, nil
+		}
+		return nil, nil
+	}
+	params, err := o.envAddons.Parameters()
+	if err != nil {
+		return nil, fmt.Errorf("parse environment addons parameters: %w", err)
+	}
+	outputs, err := addon.Outputs(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("get environment addons outputs: %w", err)
+	}
+	url, err := s3Client.Upload(bucket, artifactpath.Addons(workspace.EnvironmentsDirName, []byte(tpl)), strings.NewReader(tpl))
+	if err != nil {
+		return nil, fmt.Errorf("put environment addons artifact to bucket %s: %w", bucket, err)
+	}
+	var outputNames []string
+	for _, out := range outputs {
+		outputNames = append(outputNames, out.Name)
+	}
+	return &deploy.EnvAddons{
+		URL:         url,
+		ExtraParams: params,
+		Outputs:     outputNames,
+	}, nil
+}
+
 func (o *envUpgradeOpts) upgrade(env *config.Environment, app *config.Application,
-	artifactBucketARN, artifactBucketKeyARN string, customResourcesURLs map[string]string) (err error) {
+	artifactBucketARN, artifactBucketKeyARN string, customResourcesURLs map[string]string, addons *deploy.EnvAddons) (err error) {
 	version, err := o.envVersion(env.Name)
 	if err != nil {
 		return err
 	}
-	if !shouldUpgradeEnv(env.Name, version) {
+	configureWAF := o.waf.isSet()
+	if configureWAF {
+		if semver.Compare(version, deploy.LatestEnvTemplateVersion) > 0 {
+			return fmt.Errorf("cannot configure AWS WAF for environment %s: its version %s is newer than %s, are you using the latest version of AWS Copilot?",
+				env.Name, version, deploy.LatestEnvTemplateVersion)
+		}
+		if env.CustomConfig == nil {
+			env.CustomConfig = &config.CustomizeEnv{}
+		}
+		env.CustomConfig.WAF = o.waf.toConfig()
+	}
+	upgradeTemplate := shouldUpgradeEnv(env.Name, version)
+	if !upgradeTemplate && !configureWAF && !shouldDeployEnvAddons(version, addons) {
 		return nil
 	}
+	upgrader, err := o.newTemplateUpgrader(env)
+	if err != nil {
+		return err
+	}
+	if !upgradeTemplate && !configureWAF {
+		changed, err := envAddonsChanged(upgrader, o.appName, env.Name, addons)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			log.Debugf("Addons of environment %s are already up to date, skip deployment.\n", env.Name)
+			return nil
+		}
+	}
+	// Only lock the environment once we know that its stack will be updated.
+	release, err := acquireLock(o.locker, o.appName, config.EnvironmentLockID(env.Name), o.forceUnlock)
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	o.prog.Start(fmt.Sprintf(fmtEnvUpgradeStart, color.HighlightUserInput(env.Name), color.Emphasize(version), color.Emphasize(deploy.LatestEnvTemplateVersion)))
 	defer func() {
@@ -230,14 +329,26 @@ func (o *envUpgradeOpts) upgrade(env *config.Environment, app *config.Applicatio
 		}
 		o.prog.Stop(log.Ssuccessf(fmtEnvUpgradeComplete, color.HighlightUserInput(env.Name), color.Emphasize(deploy.LatestEnvTemplateVersion)))
 	}()
-	upgrader, err := o.newTemplateUpgrader(env)
-	if err != nil {
+	if version == deploy.LegacyEnvTemplateVersion {
+		if err := o.upgradeLegacyEnvironment(upgrader, env, app, artifactBucketARN, artifactBucketKeyARN, customResourcesURLs, version, deploy.LatestEnvTemplateVersion); err != nil {
+			return err
+		}
+		if (addons == nil || addons.URL == "") && !configureWAF {
+			return nil
+		}
+		// Legacy templates can't hold addons or a web ACL, deploy them once the environment is on the latest version.
+		version = deploy.LatestEnvTemplateVersion
+	}
+	if err := o.upgradeEnvironment(upgrader, env, app, artifactBucketARN, artifactBucketKeyARN, customResourcesURLs, addons, version, deploy.LatestEnvTemplateVersion); err != nil {
 		return err
 	}
-	if version == deploy.LegacyEnvTemplateVersion {
-		return o.upgradeLegacyEnvironment(upgrader, env, app, artifactBucketARN, artifactBucketKeyARN, customResourcesURLs, version, deploy.LatestEnvTemplateVersion)
+	if !configureWAF {
+		return nil
+	}
+	if err := o.store.UpdateEnvironment(env); err != nil {
+		return fmt.Errorf("update configuration of environment %s: %w", env.Name, err)
 	}
-	return o.upgradeEnvironment(upgrader, env, app, artifactBucketARN, artifactBucketKeyARN, customResourcesURLs, version, deploy.LatestEnvTemplateVersion)
+	return nil
 }
 
 func (o *envUpgradeOpts) envVersion(name string) (string, error) {
@@ -271,16 +382,84 @@ Are you using the latest version of AWS Copilot?`, env, deploy.LatestEnvTemplate
 	return false
 }
 
+// shouldDeployEnvAddons returns true if the environment is already on the latest version
+// but there are environment addons that need to be deployed.
+func shouldDeployEnvAddons(version string, addons *deploy.EnvAddons) bool {
+	return addons != nil && semver.Compare(version, deploy.LatestEnvTemplateVersion) == 0
+}
+
+// envAddonsChanged returns true if the addons differ from the addons stack in the deployed environment template.
+func envAddonsChanged(cfn envTemplater, appName, envName string, addons *deploy.EnvAddons) (bool, error) {
+	tpl, err := cfn.EnvironmentTemplate(appName, envName)
+	if err != nil {
+		return false, fmt.Errorf("get template of environment %s in app %s: %w", envName, appName, err)
+	}
+	var deployed struct {
+		Resources struct {
+			AddonsStack *struct {
+				Properties struct {
+					Parameters  map[string]yaml.Node `yaml:"Parameters"`
+					TemplateURL string               `yaml:"TemplateURL"`
+				} `yaml:"Properties"`
+			} `yaml:"AddonsStack"`
+		} `yaml:"Resources"`
+	}
+	if err := yaml.Unmarshal([]byte(tpl), &deployed); err != nil {
+		return false, fmt.Errorf("unmarshal template of environment %s in app %s: %w", envName, appName, err)
+	}
+	stack := deployed.Resources.AddonsStack
+	if stack == nil || addons.URL == "" {
+		return (stack == nil) != (addons.URL == ""), nil
+	}
+	if stack.Properties.TemplateURL != addons.URL {
+		return true, nil
+	}
+	params := make(map[string]yaml.Node)
+	if err := yaml.Unmarshal([]byte(addons.ExtraParams), &params); err != nil {
+		return false, fmt.Errorf("unmarshal environment addons parameters: %w", err)
+	}
+	deployedParams := stack.Properties.Parameters
+	for _, name := range []string{"App", "Env", "Name"} {
+		delete(deployedParams, name)
+	}
+	if len(params) != len(deployedParams) {
+		return true, nil
+	}
+	for name, value := range params {
+		deployedValue, ok := deployedParams[name]
+		if !ok {
+			return true, nil
+		}
+		want, err := yaml.Marshal(&value)
+		if err != nil {
+			return false, fmt.Errorf("marshal environment addons parameter %s: %w", name, err)
+		}
+		got, err := yaml.Marshal(&deployedValue)
+		if err != nil {
+			return false, fmt.Errorf("marshal deployed environment addons parameter %s: %w", name, err)
+		}
+		if string(want) != string(got) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (o *envUpgradeOpts) upgradeEnvironment(upgrader envUpgrader, conf *config.Environment, app *config.Application,
 	artifactBucketARN, artifactBucketKeyARN string,
-	customResourcesURLs map[string]string, fromVersion, toVersion string) error {
+	customResourcesURLs map[string]string, addons *deploy.EnvAddons, fromVersion, toVersion string) error {
 	var importedVPC *config.ImportVPC
 	var adjustedVPC *config.AdjustVPC
-	var importCertARNs []string
+	var importCertARNs, internalCertARNs []string
+	var waf *config.WAF
+	var ec2Capacity *config.EC2Capacity
 	if conf.CustomConfig != nil {
 		importedVPC = conf.CustomConfig.ImportVPC
 		adjustedVPC = conf.CustomConfig.VPCConfig
 		importCertARNs = conf.CustomConfig.ImportCertARNs
+		internalCertARNs = conf.CustomConfig.InternalCertARNs
+		waf = conf.CustomConfig.WAF
+		ec2Capacity = conf.CustomConfig.EC2Capacity
 	}
 
 	if err := upgrader.UpgradeEnvironment(&deploy.CreateEnvironmentInput{
@@ -296,8 +475,12 @@ func (o *envUpgradeOpts) upgradeEnvironment(upgrader envUpgrader, conf *config.E
 		ImportVPCConfig:      importedVPC,
 		AdjustVPCConfig:      adjustedVPC,
 		ImportCertARNs:       importCertARNs,
+		InternalCertARNs:     internalCertARNs,
+		WAF:                  waf,
+		EC2Capacity:          ec2Capacity,
 		CFNServiceRoleARN:    conf.ExecutionRoleARN,
 		Telemetry:            conf.Telemetry,
+		Addons:               addons,
 	}); err != nil {
 		return fmt.Errorf("upgrade environment %s from version %s to version %s: %v", conf.Name, fromVersion, toVersion, err)
 	}
@@ -410,5 +593,9 @@ func buildEnvUpgradeCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", envFlagDescription)
 	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
 	cmd.Flags().BoolVar(&vars.all, allFlag, false, upgradeAllEnvsDescription)
+	cmd.Flags().BoolVar(&vars.forceUnlock, forceUnlockFlag, false, forceUnlockFlagDescription)
+	cmd.Flags().StringVar(&vars.waf.WebACLARN, wafARNFlag, "", wafARNFlagDescription)
+	cmd.Flags().StringSliceVar(&vars.waf.ManagedRules, wafManagedRulesFlag, nil, wafManagedRulesFlagDescription)
+	cmd.Flags().IntVar(&vars.waf.RateLimit, wafRateLimitFlag, 0, wafRateLimitFlagDescription)
 	return cmd
 }
@@ -6,7 +6,9 @@ package cli
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/aws/copilot-cli/internal/pkg/addon"
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
 	"github.com/aws/copilot-cli/internal/pkg/config"
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
@@ -94,6 +96,34 @@ func TestEnvUpgradeOpts_Validate(t *testing.T) {
 			},
 			wantedErr: nil,
 		},
+		"should not allow --all with WAF flags": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						all:     true,
+						waf: wafVars{
+							ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+						},
+					},
+				}
+			},
+			wantedErr: errors.New("cannot specify --import-waf-arn, --waf-managed-rules or --waf-rate-limit with --all"),
+		},
+		"should validate WAF flags": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+						waf: wafVars{
+							RateLimit: 2000000001,
+						},
+					},
+				}
+			},
+			wantedErr: errors.New("--waf-rate-limit must be between 100 and 2000000000"),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -196,6 +226,18 @@ to support the latest Copilot features.`,
 	}
 }
 
+const mockEnvAddonsTemplate = `Resources:
+  UsersTable:
+    Type: AWS::DynamoDB::Table
+  UsersAccessPolicy:
+    Type: AWS::IAM::ManagedPolicy
+Outputs:
+  UsersTableName:
+    Value: !Ref UsersTable
+  UsersAccessPolicy:
+    Value: !Ref UsersAccessPolicy
+`
+
 func TestEnvUpgradeOpts_Execute(t *testing.T) {
 	testCases := map[string]struct {
 		given     func(ctrl *gomock.Controller) *envUpgradeOpts
@@ -246,6 +288,273 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 				}
 			},
 		},
+		"should wrap the error if the environment addons template cannot be uploaded": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:    "phonetool",
+						Name:   "test",
+						Region: "us-west-2",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket: "mockBucket",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(nil, nil)
+				mockAddons := mocks.NewMockenvAddonsTemplater(ctrl)
+				mockAddons.EXPECT().Template().Return(mockEnvAddonsTemplate, nil)
+				mockAddons.EXPECT().Parameters().Return("", nil)
+				mockS3 := mocks.NewMockuploader(ctrl)
+				mockS3.EXPECT().Upload("mockBucket", gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+					},
+					store:     mockStore,
+					uploader:  mockUploader,
+					appCFN:    mockAppCFN,
+					envAddons: mockAddons,
+					newS3: func(region string) (uploader, error) {
+						return mockS3, nil
+					},
+				}
+			},
+			wantedErr: errors.New("put environment addons artifact to bucket mockBucket: some error"),
+		},
+		"should deploy environment addons even if the environment version is already latest": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return(deploy.LatestEnvTemplateVersion, nil)
+
+				mockProg := mocks.NewMockprogress(ctrl)
+				mockProg.EXPECT().Start(gomock.Any())
+				mockProg.EXPECT().Stop(gomock.Any())
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:              "phonetool",
+						Name:             "test",
+						Region:           "us-west-2",
+						ExecutionRoleARN: "execARN",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket:  "mockBucket",
+						KMSKeyARN: "mockKMS",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(map[string]string{"mockCustomResource": "mockURL"}, nil)
+				mockAddons := mocks.NewMockenvAddonsTemplater(ctrl)
+				mockAddons.EXPECT().Template().Return(mockEnvAddonsTemplate, nil)
+				mockAddons.EXPECT().Parameters().Return("VpcId: !Ref VPC\n", nil)
+				mockS3 := mocks.NewMockuploader(ctrl)
+				mockS3.EXPECT().Upload("mockBucket", gomock.Any(), gomock.Any()).Return("mockAddonsURL", nil)
+
+				mockUpgrader := mocks.NewMockenvTemplateUpgrader(ctrl)
+				mockUpgrader.EXPECT().EnvironmentTemplate("phonetool", "test").Return(`
+Resources:
+  AddonsStack:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      Parameters:
+        App: !Ref AppName
+        Env: !Ref EnvironmentName
+        Name: !Ref EnvironmentName
+      TemplateURL: mockAddonsURL`, nil)
+				mockUpgrader.EXPECT().UpgradeEnvironment(&deploy.CreateEnvironmentInput{
+					Version: deploy.LatestEnvTemplateVersion,
+					App: deploy.AppInformation{
+						Name: "phonetool",
+					},
+					Name:                 "test",
+					CFNServiceRoleARN:    "execARN",
+					CustomResourcesURLs:  map[string]string{"mockCustomResource": "mockURL"},
+					ArtifactBucketARN:    "arn:aws:s3:::mockBucket",
+					ArtifactBucketKeyARN: "mockKMS",
+					Addons: &deploy.EnvAddons{
+						URL:         "mockAddonsURL",
+						ExtraParams: "VpcId: !Ref VPC\n",
+						Outputs:     []string{"UsersTableName", "UsersAccessPolicy"},
+					},
+				}).Return(nil)
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+					},
+					store:  mockStore,
+					prog:   mockProg,
+					locker: mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mockUpgrader, nil
+					},
+					uploader:  mockUploader,
+					appCFN:    mockAppCFN,
+					envAddons: mockAddons,
+					newS3: func(region string) (uploader, error) {
+						return mockS3, nil
+					},
+				}
+			},
+		},
+		"should skip deploying environment addons that haven't changed": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return(deploy.LatestEnvTemplateVersion, nil)
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:    "phonetool",
+						Name:   "test",
+						Region: "us-west-2",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket: "mockBucket",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(nil, nil)
+				mockAddons := mocks.NewMockenvAddonsTemplater(ctrl)
+				mockAddons.EXPECT().Template().Return(mockEnvAddonsTemplate, nil)
+				mockAddons.EXPECT().Parameters().Return("VpcId: !Ref VPC\n", nil)
+				mockS3 := mocks.NewMockuploader(ctrl)
+				mockS3.EXPECT().Upload("mockBucket", gomock.Any(), gomock.Any()).Return("mockAddonsURL", nil)
+
+				mockUpgrader := mocks.NewMockenvTemplateUpgrader(ctrl)
+				mockUpgrader.EXPECT().EnvironmentTemplate("phonetool", "test").Return(`
+Resources:
+  AddonsStack:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      Parameters:
+        App: !Ref AppName
+        Env: !Ref EnvironmentName
+        Name: !Ref EnvironmentName
+        VpcId: !Ref VPC
+      TemplateURL: mockAddonsURL`, nil)
+				mockUpgrader.EXPECT().UpgradeEnvironment(gomock.Any()).Times(0)
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+					},
+					store:  mockStore,
+					locker: mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mockUpgrader, nil
+					},
+					uploader:  mockUploader,
+					appCFN:    mockAppCFN,
+					envAddons: mockAddons,
+					newS3: func(region string) (uploader, error) {
+						return mockS3, nil
+					},
+				}
+			},
+		},
+		"should remove the environment addons stack if all its templates are deleted": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return(deploy.LatestEnvTemplateVersion, nil)
+
+				mockProg := mocks.NewMockprogress(ctrl)
+				mockProg.EXPECT().Start(gomock.Any())
+				mockProg.EXPECT().Stop(gomock.Any())
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:              "phonetool",
+						Name:             "test",
+						Region:           "us-west-2",
+						ExecutionRoleARN: "execARN",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket:  "mockBucket",
+						KMSKeyARN: "mockKMS",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(map[string]string{"mockCustomResource": "mockURL"}, nil)
+				mockAddons := mocks.NewMockenvAddonsTemplater(ctrl)
+				mockAddons.EXPECT().Template().Return("", &addon.ErrAddonsNotFound{WlName: "environments"})
+
+				mockUpgrader := mocks.NewMockenvTemplateUpgrader(ctrl)
+				mockUpgrader.EXPECT().EnvironmentTemplate("phonetool", "test").Return(`
+Resources:
+  AddonsStack:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: mockAddonsURL`, nil)
+				mockUpgrader.EXPECT().UpgradeEnvironment(&deploy.CreateEnvironmentInput{
+					Version: deploy.LatestEnvTemplateVersion,
+					App: deploy.AppInformation{
+						Name: "phonetool",
+					},
+					Name:                 "test",
+					CFNServiceRoleARN:    "execARN",
+					CustomResourcesURLs:  map[string]string{"mockCustomResource": "mockURL"},
+					ArtifactBucketARN:    "arn:aws:s3:::mockBucket",
+					ArtifactBucketKeyARN: "mockKMS",
+					Addons:               &deploy.EnvAddons{},
+				}).Return(nil)
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+					},
+					store:  mockStore,
+					prog:   mockProg,
+					locker: mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mockUpgrader, nil
+					},
+					uploader:  mockUploader,
+					appCFN:    mockAppCFN,
+					envAddons: mockAddons,
+					newS3: func(region string) (uploader, error) {
+						return mocks.NewMockuploader(ctrl), nil
+					},
+				}
+			},
+		},
 		"should upgrade non-legacy environments with UpgradeEnvironment call": {
 			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
 				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
@@ -255,6 +564,10 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 				mockProg.EXPECT().Start(gomock.Any())
 				mockProg.EXPECT().Stop(gomock.Any())
 
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
 				mockStore := mocks.NewMockstore(ctrl)
 				mockStore.EXPECT().GetEnvironment("phonetool", "test").
 					Return(&config.Environment{
@@ -305,8 +618,95 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 						appName: "phonetool",
 						name:    "test",
 					},
-					store: mockStore,
-					prog:  mockProg,
+					store:  mockStore,
+					prog:   mockProg,
+					locker: mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mockUpgrader, nil
+					},
+					uploader: mockUploader,
+					appCFN:   mockAppCFN,
+					newS3: func(region string) (uploader, error) {
+						return mocks.NewMockuploader(ctrl), nil
+					},
+				}
+			},
+		},
+		"should configure AWS WAF for an environment already on the latest version": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return(deploy.LatestEnvTemplateVersion, nil)
+
+				mockProg := mocks.NewMockprogress(ctrl)
+				mockProg.EXPECT().Start(gomock.Any())
+				mockProg.EXPECT().Stop(gomock.Any())
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:              "phonetool",
+						Name:             "test",
+						Region:           "us-west-2",
+						ExecutionRoleARN: "execARN",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockStore.EXPECT().UpdateEnvironment(&config.Environment{
+					App:              "phonetool",
+					Name:             "test",
+					Region:           "us-west-2",
+					ExecutionRoleARN: "execARN",
+					CustomConfig: &config.CustomizeEnv{
+						WAF: &config.WAF{
+							ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+							RateLimit:    2000,
+						},
+					},
+				}).Return(nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket:  "mockBucket",
+						KMSKeyARN: "mockKMS",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(map[string]string{"mockCustomResource": "mockURL"}, nil)
+
+				mockUpgrader := mocks.NewMockenvTemplateUpgrader(ctrl)
+				mockUpgrader.EXPECT().UpgradeEnvironment(&deploy.CreateEnvironmentInput{
+					Version: deploy.LatestEnvTemplateVersion,
+					App: deploy.AppInformation{
+						Name: "phonetool",
+					},
+					Name: "test",
+					WAF: &config.WAF{
+						ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+						RateLimit:    2000,
+					},
+					CFNServiceRoleARN:    "execARN",
+					CustomResourcesURLs:  map[string]string{"mockCustomResource": "mockURL"},
+					ArtifactBucketARN:    "arn:aws:s3:::mockBucket",
+					ArtifactBucketKeyARN: "mockKMS",
+				}).Return(nil)
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+						waf: wafVars{
+							ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+							RateLimit:    2000,
+						},
+					},
+					store:  mockStore,
+					prog:   mockProg,
+					locker: mockLocker,
 					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
 						return mockEnvTpl, nil
 					},
@@ -330,6 +730,10 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 				mockProg.EXPECT().Start(gomock.Any())
 				mockProg.EXPECT().Stop(gomock.Any())
 
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
 				mockStore := mocks.NewMockstore(ctrl)
 				mockStore.EXPECT().GetEnvironment("phonetool", "test").
 					Return(&config.Environment{
@@ -385,6 +789,7 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 					store:              mockStore,
 					legacyEnvTemplater: mockTemplater,
 					prog:               mockProg,
+					locker:             mockLocker,
 					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
 						return mockEnvTpl, nil
 					},
@@ -399,6 +804,86 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 				}
 			},
 		},
+		"should deploy environment addons after upgrading a legacy environment": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return(deploy.LegacyEnvTemplateVersion, nil)
+
+				mockProg := mocks.NewMockprogress(ctrl)
+				mockProg.EXPECT().Start(gomock.Any())
+				mockProg.EXPECT().Stop(gomock.Any())
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:    "phonetool",
+						Name:   "test",
+						Region: "us-west-2",
+					}, nil)
+				mockStore.EXPECT().ListServices("phonetool").Return(nil, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket: "mockBucket",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(nil, nil)
+				mockAddons := mocks.NewMockenvAddonsTemplater(ctrl)
+				mockAddons.EXPECT().Template().Return(mockEnvAddonsTemplate, nil)
+				mockAddons.EXPECT().Parameters().Return("", nil)
+				mockS3 := mocks.NewMockuploader(ctrl)
+				mockS3.EXPECT().Upload("mockBucket", gomock.Any(), gomock.Any()).Return("mockAddonsURL", nil)
+
+				mockTemplater := mocks.NewMocktemplater(ctrl)
+				mockTemplater.EXPECT().Template().Return("template", nil)
+
+				mockUpgrader := mocks.NewMockenvTemplateUpgrader(ctrl)
+				mockUpgrader.EXPECT().EnvironmentTemplate("phonetool", "test").Return("template", nil)
+				gomock.InOrder(
+					mockUpgrader.EXPECT().UpgradeLegacyEnvironment(gomock.Any()).Return(nil),
+					mockUpgrader.EXPECT().UpgradeEnvironment(&deploy.CreateEnvironmentInput{
+						Version: deploy.LatestEnvTemplateVersion,
+						App: deploy.AppInformation{
+							Name: "phonetool",
+						},
+						Name:              "test",
+						ArtifactBucketARN: "arn:aws:s3:::mockBucket",
+						Addons: &deploy.EnvAddons{
+							URL:     "mockAddonsURL",
+							Outputs: []string{"UsersTableName", "UsersAccessPolicy"},
+						},
+					}).Return(nil),
+				)
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+					},
+					store:              mockStore,
+					legacyEnvTemplater: mockTemplater,
+					prog:               mockProg,
+					locker:             mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mockUpgrader, nil
+					},
+					uploader:  mockUploader,
+					appCFN:    mockAppCFN,
+					envAddons: mockAddons,
+					newS3: func(region string) (uploader, error) {
+						return mockS3, nil
+					},
+				}
+			},
+		},
 		"should upgrade legacy environments with imported VPC": {
 			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
 				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
@@ -408,6 +893,10 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 				mockProg.EXPECT().Start(gomock.Any())
 				mockProg.EXPECT().Stop(gomock.Any())
 
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
 				mockStore := mocks.NewMockstore(ctrl)
 				mockStore.EXPECT().GetEnvironment("phonetool", "test").
 					Return(&config.Environment{
@@ -456,6 +945,7 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 					store:              mockStore,
 					legacyEnvTemplater: mockTemplater,
 					prog:               mockProg,
+					locker:             mockLocker,
 					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
 						return mockEnvTpl, nil
 					},
@@ -479,6 +969,10 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 				mockProg.EXPECT().Start(gomock.Any())
 				mockProg.EXPECT().Stop(gomock.Any())
 
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+
 				mockStore := mocks.NewMockstore(ctrl)
 				mockStore.EXPECT().GetEnvironment("phonetool", "test").
 					Return(&config.Environment{
@@ -512,6 +1006,7 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 					store:              mockStore,
 					legacyEnvTemplater: mockTemplater,
 					prog:               mockProg,
+					locker:             mockLocker,
 					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
 						return mockEnvTpl, nil
 					},
@@ -527,6 +1022,108 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 			},
 			wantedErr: errors.New("cannot upgrade environment due to missing vpc configuration"),
 		},
+		"should return an error if another upgrade of the environment is in progress": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return("v0.1.0", nil)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:    "phonetool",
+						Name:   "test",
+						Region: "us-west-2",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket: "mockBucket",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(nil, nil)
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(nil, &config.ErrLocked{
+					Lock: &config.Lock{
+						ID:         "environments/test",
+						Owner:      "arn:aws:iam::123456789012:user/bob",
+						AcquiredAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+					},
+				})
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName: "phonetool",
+						name:    "test",
+					},
+					store:  mockStore,
+					locker: mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mocks.NewMockenvTemplateUpgrader(ctrl), nil
+					},
+					uploader: mockUploader,
+					appCFN:   mockAppCFN,
+					newS3: func(region string) (uploader, error) {
+						return mocks.NewMockuploader(ctrl), nil
+					},
+				}
+			},
+			wantedErr: errors.New("environments/test is locked by arn:aws:iam::123456789012:user/bob since 2020-05-01T10:00:00Z: wait for the other deployment to finish, or run again with `--force-unlock` if it is no longer running"),
+		},
+		"should release the lock first if forced": {
+			given: func(ctrl *gomock.Controller) *envUpgradeOpts {
+				mockEnvTpl := mocks.NewMockversionGetter(ctrl)
+				mockEnvTpl.EXPECT().Version().Return("v0.1.0", nil)
+
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().GetEnvironment("phonetool", "test").
+					Return(&config.Environment{
+						App:    "phonetool",
+						Name:   "test",
+						Region: "us-west-2",
+					}, nil)
+				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				mockAppCFN := mocks.NewMockappResourcesGetter(ctrl)
+				mockAppCFN.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket: "mockBucket",
+					}, nil)
+				mockUploader := mocks.NewMockcustomResourcesUploader(ctrl)
+				mockUploader.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(nil, nil)
+
+				mockLocker := mocks.NewMocklocker(ctrl)
+				gomock.InOrder(
+					mockLocker.EXPECT().ForceReleaseLock("phonetool", "environments/test").Return(nil),
+					mockLocker.EXPECT().AcquireLock("phonetool", "environments/test", lockTTL).Return(nil, errors.New("some error")),
+				)
+
+				return &envUpgradeOpts{
+					envUpgradeVars: envUpgradeVars{
+						appName:     "phonetool",
+						name:        "test",
+						forceUnlock: true,
+					},
+					store:  mockStore,
+					locker: mockLocker,
+					newEnvVersionGetter: func(_, _ string) (versionGetter, error) {
+						return mockEnvTpl, nil
+					},
+					newTemplateUpgrader: func(conf *config.Environment) (envTemplateUpgrader, error) {
+						return mocks.NewMockenvTemplateUpgrader(ctrl), nil
+					},
+					uploader: mockUploader,
+					appCFN:   mockAppCFN,
+					newS3: func(region string) (uploader, error) {
+						return mocks.NewMockuploader(ctrl), nil
+					},
+				}
+			},
+			wantedErr: errors.New("some error"),
+		},
 	}
 
 	for name, tc := range testCases {
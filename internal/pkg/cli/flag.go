@@ -14,19 +14,20 @@ import (
 // Long flag names.
 const (
 	// Common flags.
-	nameFlag       = "name"
-	appFlag        = "app"
-	envFlag        = "env"
-	workloadFlag   = "workload"
-	svcTypeFlag    = "svc-type"
-	jobTypeFlag    = "job-type"
-	typeFlag       = "type"
-	profileFlag    = "profile"
-	yesFlag        = "yes"
-	jsonFlag       = "json"
-	allFlag        = "all"
-	forceFlag      = "force"
-	noRollbackFlag = "no-rollback"
+	nameFlag        = "name"
+	appFlag         = "app"
+	envFlag         = "env"
+	workloadFlag    = "workload"
+	svcTypeFlag     = "svc-type"
+	jobTypeFlag     = "job-type"
+	typeFlag        = "type"
+	profileFlag     = "profile"
+	yesFlag         = "yes"
+	jsonFlag        = "json"
+	allFlag         = "all"
+	forceFlag       = "force"
+	noRollbackFlag  = "no-rollback"
+	forceUnlockFlag = "force-unlock"
 	// Command specific flags.
 	dockerFileFlag        = "dockerfile"
 	dockerFileContextFlag = "build-context"
@@ -34,6 +35,10 @@ const (
 	resourceTagsFlag      = "resource-tags"
 	stackOutputDirFlag    = "output-dir"
 	uploadAssetsFlag      = "upload-assets"
+	buildCacheFlag        = "build-cache"
+	reuseImageFlag        = "reuse-image"
+	fromEnvFlag           = "from"
+	toEnvFlag             = "to"
 	limitFlag             = "limit"
 	followFlag            = "follow"
 	sinceFlag             = "since"
@@ -57,15 +62,37 @@ const (
 	noSubscriptionFlag  = "no-subscribe"
 	subscribeTopicsFlag = "subscribe-topics"
 
-	storageTypeFlag              = "storage-type"
-	storagePartitionKeyFlag      = "partition-key"
-	storageSortKeyFlag           = "sort-key"
-	storageNoSortFlag            = "no-sort"
-	storageLSIConfigFlag         = "lsi"
-	storageNoLSIFlag             = "no-lsi"
-	storageRDSEngineFlag         = "engine"
-	storageRDSInitialDBFlag      = "initial-db"
-	storageRDSParameterGroupFlag = "parameter-group"
+	storageTypeFlag                   = "storage-type"
+	storagePartitionKeyFlag           = "partition-key"
+	storageSortKeyFlag                = "sort-key"
+	storageNoSortFlag                 = "no-sort"
+	storageLSIConfigFlag              = "lsi"
+	storageNoLSIFlag                  = "no-lsi"
+	storageGSIConfigFlag              = "gsi"
+	storageNoGSIFlag                  = "no-gsi"
+	storageTTLAttributeFlag           = "ttl-attribute"
+	storageStreamViewTypeFlag         = "stream-view-type"
+	storageBillingModeFlag            = "billing-mode"
+	storageReadCapacityFlag           = "read-capacity"
+	storageWriteCapacityFlag          = "write-capacity"
+	storageMaxReadCapacityFlag        = "max-read-capacity"
+	storageMaxWriteCapacityFlag       = "max-write-capacity"
+	storagePITRFlag                   = "pitr"
+	storageRDSEngineFlag              = "engine"
+	storageRDSInitialDBFlag           = "initial-db"
+	storageRDSParameterGroupFlag      = "parameter-group"
+	storageRDSServerlessVersionFlag   = "serverless-version"
+	storageRDSMinCapacityFlag         = "min-capacity"
+	storageRDSMaxCapacityFlag         = "max-capacity"
+	storageRDSInstanceClassFlag       = "db-instance-class"
+	storageRDSReaderCountFlag         = "readers"
+	storageRDSProxyFlag               = "proxy"
+	storageRedisNodeTypeFlag          = "node-type"
+	storageSQSFIFOFlag                = "fifo"
+	storageOpenSearchInstanceTypeFlag = "instance-type"
+	storageEnvLevelFlag               = "env-level"
+	storageSnapshotFlag               = "snapshot"
+	revealFlag                        = "reveal"
 
 	taskGroupNameFlag            = "task-group-name"
 	countFlag                    = "count"
@@ -91,6 +118,15 @@ const (
 	publicSubnetsFlag              = "import-public-subnets"
 	privateSubnetsFlag             = "import-private-subnets"
 	certsFlag                      = "import-cert-arns"
+	internalCertsFlag              = "import-internal-cert-arns"
+	wafARNFlag                     = "import-waf-arn"
+	wafManagedRulesFlag            = "waf-managed-rules"
+	wafRateLimitFlag               = "waf-rate-limit"
+	ec2InstanceTypesFlag           = "ec2-instance-types"
+	ec2ArchFlag                    = "ec2-arch"
+	ec2MinSizeFlag                 = "ec2-min-size"
+	ec2MaxSizeFlag                 = "ec2-max-size"
+	ec2SpotPercentageFlag          = "ec2-spot-percentage"
 	overrideVPCCIDRFlag            = "override-vpc-cidr"
 	overrideAZsFlag                = "override-az-names"
 	overridePublicSubnetCIDRsFlag  = "override-public-cidrs"
@@ -104,6 +140,7 @@ const (
 	secretAccessKeyFlag = "aws-secret-access-key"
 	sessionTokenFlag    = "aws-session-token"
 	regionFlag          = "region"
+	cfnServiceRoleFlag  = "cfn-service-role"
 
 	retriesFlag  = "retries"
 	timeoutFlag  = "timeout"
@@ -115,8 +152,14 @@ const (
 	valuesFlag        = "values"
 	overwriteFlag     = "overwrite"
 	inputFilePathFlag = "cli-input-yaml"
+	secretStoreFlag   = "store"
+
+	rotationLambdasFlag = "rotation-lambdas"
+	rotationDaysFlag    = "rotation-days"
 
 	includeStateMachineLogsFlag = "include-state-machine"
+
+	deploymentRetentionFlag = "deployment-retention"
 )
 
 // Short flag names.
@@ -182,6 +225,13 @@ Mutually exclusive with the --%s flag.`, inputFilePathFlag)
 Mutually exclusive with the --%s flag.`, inputFilePathFlag)
 	secretInputFilePathFlagDescription = fmt.Sprintf(`Optional. A YAML file in which the secret values are specified.
 Mutually exclusive with the -%s ,--%s and --%s flags.`, nameFlagShort, nameFlag, valuesFlag)
+	secretStoreFlagDescription = fmt.Sprintf(`Optional. Where to store the secret. Must be one of:
+%s.`, strings.Join(template.QuoteSliceFunc(secretStores), ", "))
+	secretRotationLambdasFlagDescription = fmt.Sprintf(`Optional. ARNs of the Lambda functions that rotate the secret in each environment.
+Specified as <environment>=<lambda ARN> separated by commas. The functions must be tagged
+with the copilot-application and copilot-environment tags. Requires --%s %s.`, secretStoreFlag, secretStoreSecretsManager)
+	secretRotationDaysFlagDescription = fmt.Sprintf(`Optional. Number of days between automatic rotations of the secret.
+Requires --%s.`, rotationLambdasFlag)
 
 	repoURLFlagDescription = fmt.Sprintf(`The repository URL to trigger your pipeline.
 Supported providers are: %s.`, strings.Join(manifest.PipelineProviders, ", "))
@@ -204,13 +254,24 @@ const (
 rollback in case of deployment failure.
 We do not recommend using this flag for a
 production environment.`
+	forceUnlockFlagDescription = `Optional. Release the deployment lock held by
+another user before deploying.`
 
 	imageTagFlagDescription     = `Optional. The container image tag.`
 	resourceTagsFlagDescription = `Optional. Labels with a key and value separated by commas.
 Allows you to categorize resources.`
+	deploymentRetentionFlagDescription = `Optional. Number of deployment records to keep for each
+service and job in each environment. By default, every deployment is kept.`
 	stackOutputDirFlagDescription = "Optional. Writes the stack template and template configuration to a directory."
 	uploadAssetsFlagDescription   = `Optional. Whether to upload assets (container images, Lambda functions, etc.).
 Uploaded asset locations are filled in the template configuration.`
+	fromEnvFlagDescription    = "Name of the environment to promote the image from."
+	toEnvFlagDescription      = "Name of the environment to promote the image to."
+	historyEnvFlagDescription = "Optional. Only list the deployments to this environment."
+	buildCacheFlagDescription = `Optional. Whether to use the latest image in the ECR repository as a build cache.
+Requires Docker BuildKit.`
+	reuseImageFlagDescription = `Optional. Whether to skip building the container image if the ECR repository
+already has an image with the same tag.`
 	prodEnvFlagDescription = "If the environment contains production services."
 
 	limitFlagDescription = `Optional. The maximum number of log events returned. Default is 10
@@ -255,10 +316,47 @@ Must be of the format '<keyName>:<dataType>'.`
 	storageNoLSIFlagDescription     = `Optional. Don't ask about configuring alternate sort keys.`
 	storageLSIConfigFlagDescription = `Optional. Attribute to use as an alternate sort key. May be specified up to 5 times.
 Must be of the format '<keyName>:<dataType>'.`
-	storageRDSEngineFlagDescription = `The database engine used in the cluster.
+	storageGSIConfigFlagDescription = `Optional. Global secondary index to add to the DDB table. May be specified up to 20 times.
+Must be of the format 'name=<indexName>,partition=<keyName>:<dataType>[,sort=<keyName>:<dataType>]
+[,projection=ALL|KEYS_ONLY|INCLUDE][,include=<attribute>|<attribute>]'.`
+	storageNoGSIFlagDescription          = `Optional. Don't ask about configuring global secondary indexes.`
+	storageTTLAttributeFlagDescription   = "Optional. Attribute that holds the expiry time of items in the DDB table."
+	storageStreamViewTypeFlagDescription = `Optional. Enable a stream on the DDB table.
+Must be one of "NEW_IMAGE", "OLD_IMAGE", "NEW_AND_OLD_IMAGES" or "KEYS_ONLY".`
+	storageBillingModeFlagDescription = `Optional. Billing mode of the DDB table.
+Must be either "on-demand" or "provisioned".`
+	storageReadCapacityFlagDescription     = "Optional. Read capacity units of a provisioned DDB table."
+	storageWriteCapacityFlagDescription    = "Optional. Write capacity units of a provisioned DDB table."
+	storageMaxReadCapacityFlagDescription  = "Optional. Auto scale the read capacity of a provisioned DDB table up to this value."
+	storageMaxWriteCapacityFlagDescription = "Optional. Auto scale the write capacity of a provisioned DDB table up to this value."
+	storagePITRFlagDescription             = "Optional. Enable point-in-time recovery for the DDB table."
+	storageRDSEngineFlagDescription        = `The database engine used in the cluster.
 Must be either "MySQL" or "PostgreSQL".`
-	storageRDSInitialDBFlagDescription      = "The initial database to create in the cluster."
-	storageRDSParameterGroupFlagDescription = "Optional. The name of the parameter group to associate with the cluster."
+	storageRDSInitialDBFlagDescription         = "The initial database to create in the cluster."
+	storageRDSParameterGroupFlagDescription    = "Optional. The name of the parameter group to associate with the cluster."
+	storageRDSServerlessVersionFlagDescription = `Optional. The version of Aurora Serverless of the cluster.
+Must be either "v1" or "v2". Defaults to "v2" unless --db-instance-class is set.`
+	storageRDSMinCapacityFlagDescription = `Optional. The minimum Aurora capacity units of a Serverless v2 cluster.
+Defaults to 0.5.`
+	storageRDSMaxCapacityFlagDescription = `Optional. The maximum Aurora capacity units of a Serverless v2 cluster.
+Defaults to 8.`
+	storageRDSInstanceClassFlagDescription = `Optional. Create a provisioned cluster with DB instances of this class.
+For example: "db.r6g.large".`
+	storageRDSReaderCountFlagDescription = "Optional. The number of reader instances in a Serverless v2 or provisioned cluster."
+	storageRDSProxyFlagDescription       = "Optional. Put an RDS Proxy in front of the cluster to pool connections."
+	storageRedisNodeTypeFlagDescription  = `Optional. The node type of the Redis replication group.
+Defaults to "cache.t3.micro".`
+	storageSQSFIFOFlagDescription                = "Optional. Create a first-in-first-out queue."
+	storageOpenSearchInstanceTypeFlagDescription = `Optional. The instance type of the OpenSearch domain's data nodes.
+Defaults to "t3.small.search".`
+	storageEnvLevelFlagDescription = `Optional. Share the storage resource among all workloads in an environment.
+The template is written to copilot/environments/addons instead of a workload's addons directory.`
+	storageDeleteNameFlagDescription     = "Name of the storage resource to delete."
+	storageDeleteWorkloadFlagDescription = "Optional. Name of the service or job that the storage resource belongs to."
+	storageDeleteEnvLevelFlagDescription = "Optional. Delete a storage resource shared by all workloads in an environment."
+	storageSnapshotFlagDescription       = `Optional. Back up the DynamoDB tables and Aurora clusters, and copy the objects
+of the S3 buckets, before they are deleted.
+By default, you are asked for each environment the storage resource is deployed in.`
 
 	countFlagDescription         = "Optional. The number of tasks to set up."
 	cpuFlagDescription           = "Optional. The number of CPU units to reserve for each task."
@@ -282,6 +380,22 @@ Cannot be specified with any other flags.`
 	publicSubnetsFlagDescription   = "Optional. Use existing public subnet IDs."
 	privateSubnetsFlagDescription  = "Optional. Use existing private subnet IDs."
 	certsFlagDescription           = "Optional. Apply existing ACM certificates to the internet-facing load balancer."
+	internalCertsFlagDescription   = "Optional. Apply existing ACM certificates to the internal load balancer."
+	wafARNFlagDescription          = "Optional. Associate an existing regional AWS WAF web ACL with the internet-facing load balancer."
+	wafManagedRulesFlagDescription = `Optional. Names of AWS managed rule groups to protect the internet-facing load balancer with.
+For example, AWSManagedRulesCommonRuleSet.`
+	wafRateLimitFlagDescription = `Optional. Maximum number of requests a single IP address can make
+within a five-minute period before being blocked by AWS WAF.`
+	ec2InstanceTypesFlagDescription = `Optional. EC2 instance types for the cluster's Auto Scaling group capacity provider.
+For example, m6g.large,c6g.large.`
+	ec2ArchFlagDescription = `Optional. CPU architecture of the EC2 instances. Must be one of x86_64 or arm64.
+(default x86_64)`
+	ec2MinSizeFlagDescription = `Optional. Minimum number of EC2 instances in the cluster.
+(default 0)`
+	ec2MaxSizeFlagDescription = `Optional. Maximum number of EC2 instances in the cluster.
+(default 10)`
+	ec2SpotPercentageFlagDescription = `Optional. Percentage of EC2 instances to launch as Spot Instances.
+The rest are launched as On-Demand Instances. (default 0)`
 	overrideVPCCIDRFlagDescription = `Optional. Global CIDR to use for VPC.
 (default 10.0.0.0/16)`
 	overrideAZsFlagDescription = `Optional. Availability Zone names.
@@ -299,6 +413,8 @@ Cannot be specified with any other flags.`
 	secretAccessKeyFlagDescription = "Optional. An AWS secret access key."
 	sessionTokenFlagDescription    = "Optional. An AWS session token for temporary credentials."
 	envRegionTokenFlagDescription  = "Optional. An AWS region where the environment will be created."
+	cfnServiceRoleFlagDescription  = `Optional. ARN of an IAM role that AWS CloudFormation assumes
+to create the environment stack instead of your credentials.`
 
 	retriesFlagDescription = "Optional. The number of times to try restarting the job on a failure."
 	timeoutFlagDescription = `Optional. The total execution time for the task, including retries.
@@ -316,4 +432,13 @@ are also accepted.`
 	containerFlagDescription   = "Optional. The specific container you want to exec in. By default the first essential container will be used."
 
 	secretOverwriteFlagDescription = "Optional. Whether to overwrite an existing secret."
+	secretListEnvFlagDescription   = "Optional. Only list the secrets in this environment."
+	secretShowNameFlagDescription  = "Name of the secret."
+	secretRevealFlagDescription    = "Optional. Show the decrypted values of the secret."
+
+	secretDeleteNameFlagDescription   = "Name of the secret to delete."
+	secretDeleteEnvFlagDescription    = "Optional. Only delete the secret from this environment."
+	secretRotateNameFlagDescription   = "Name of the secret to rotate."
+	secretRotateValuesFlagDescription = `Optional. New values of the secret in each environment. Specified as <environment>=<value> separated by commas.
+By default, you are asked for the new value in each environment the secret exists in.`
 )
@@ -160,6 +160,7 @@ func newInitOpts(vars initVars) (*initOpts, error) {
 		},
 
 		store:           configStore,
+		locker:          configStore,
 		prompt:          prompt,
 		ws:              ws,
 		newInterpolator: newManifestInterpolator,
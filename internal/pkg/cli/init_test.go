@@ -127,6 +127,10 @@ func TestInitOpts_Run(t *testing.T) {
 						Value: manifest.WorkerServiceType,
 						Hint:  "Events to SQS to ECS on Fargate",
 					},
+					{
+						Value: manifest.StaticSiteType,
+						Hint:  "Internet to CloudFront to S3",
+					},
 					{
 						Value: manifest.ScheduledJobType,
 						Hint:  "Scheduled event to State Machine to Fargate",
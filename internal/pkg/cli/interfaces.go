@@ -6,6 +6,7 @@ package cli
 import (
 	"encoding"
 	"io"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/secretsmanager"
 
@@ -110,6 +111,7 @@ type applicationDeleter interface {
 
 type environmentStore interface {
 	environmentCreator
+	environmentUpdater
 	environmentGetter
 	environmentLister
 	environmentDeleter
@@ -119,6 +121,10 @@ type environmentCreator interface {
 	CreateEnvironment(env *config.Environment) error
 }
 
+type environmentUpdater interface {
+	UpdateEnvironment(env *config.Environment) error
+}
+
 type environmentGetter interface {
 	GetEnvironment(appName string, environmentName string) (*config.Environment, error)
 }
@@ -131,12 +137,25 @@ type environmentDeleter interface {
 	DeleteEnvironment(appName, environmentName string) error
 }
 
+type deploymentStore interface {
+	CreateDeployment(d *config.Deployment) error
+	PruneDeployments(appName, wkldName, envName string, keep int) error
+	ListDeployments(appName, wkldName, envName string) ([]*config.Deployment, error)
+}
+
+type locker interface {
+	AcquireLock(appName, id string, ttl time.Duration) (*config.Lock, error)
+	ReleaseLock(lock *config.Lock) error
+	ForceReleaseLock(appName, id string) error
+}
+
 type store interface {
 	applicationStore
 	environmentStore
 	serviceStore
 	jobStore
 	wlStore
+	deploymentStore
 }
 
 type deployedEnvironmentLister interface {
@@ -184,6 +203,11 @@ type templater interface {
 	Template() (string, error)
 }
 
+type envAddonsTemplater interface {
+	templater
+	Parameters() (string, error)
+}
+
 type runner interface {
 	Run(name string, args []string, options ...exec.CmdOption) error
 }
@@ -305,6 +329,17 @@ type wsAddonManager interface {
 	wlLister
 }
 
+type wsStorageReader interface {
+	ReadAddonsDir(svcName string) ([]string, error)
+	ReadAddon(svc, fname string) ([]byte, error)
+	wlLister
+}
+
+type wsStorageDeleter interface {
+	wsStorageReader
+	DeleteAddon(svc, fname string) error
+}
+
 type uploader interface {
 	Upload(bucket, key string, data io.Reader) (string, error)
 	ZipAndUpload(bucket, key string, files ...s3.NamedBinary) (string, error)
@@ -320,6 +355,18 @@ type bucketEmptier interface {
 	EmptyBucket(bucket string) error
 }
 
+type bucketCopier interface {
+	CopyBucket(src, dst string) error
+}
+
+type tableBackupCreator interface {
+	CreateBackup(table, backup string) (string, error)
+}
+
+type dbClusterSnapshotCreator interface {
+	CreateDBClusterSnapshot(cluster, snapshot string) (string, error)
+}
+
 // Interfaces for deploying resources through CloudFormation. Facilitates mocking.
 type environmentDeployer interface {
 	DeployAndRenderEnvironment(out termprogress.FileWriter, env *deploy.CreateEnvironmentInput) error
@@ -549,6 +596,14 @@ type serviceDescriber interface {
 	DescribeService(app, env, svc string) (*ecs.ServiceDesc, error)
 }
 
+type taskDefinitionDescriber interface {
+	TaskDefinition(app, env, svc string) (*awsecs.TaskDefinition, error)
+}
+
+type imageDigestGetter interface {
+	ImageDigest(repoName, tag string) (string, error)
+}
+
 type apprunnerServiceDescriber interface {
 	ServiceARN(env string) (string, error)
 }
@@ -586,6 +641,11 @@ type stackExistChecker interface {
 	Exists(string) (bool, error)
 }
 
+type stackResourcesDescriber interface {
+	stackExistChecker
+	StackResources(name string) ([]*awscloudformation.StackResource, error)
+}
+
 type runningTaskSelector interface {
 	RunningTask(prompt, help string, opts ...selector.TaskOpts) (*awsecs.Task, error)
 }
@@ -611,6 +671,22 @@ type secretPutter interface {
 	PutSecret(in ssm.PutSecretInput) (*ssm.PutSecretOutput, error)
 }
 
+type secretsManagerPutter interface {
+	PutSecret(in secretsmanager.PutSecretInput) (*secretsmanager.PutSecretOutput, error)
+	RotateSecret(secretName, lambdaARN string, days int64) error
+}
+
+type secretParameterStore interface {
+	secretPutter
+	ListSecrets(tags map[string]string) ([]ssm.SecretMetadata, error)
+	GetSecret(name string, withDecryption bool) (*ssm.Secret, error)
+	DeleteSecret(name string) error
+}
+
+type serviceForceUpdater interface {
+	ForceUpdateService(app, env, svc string) error
+}
+
 type servicePauser interface {
 	PauseService(svcARN string) error
 }
@@ -46,6 +46,7 @@ type deployJobOpts struct {
 	targetEnv       *config.Environment
 	appliedManifest interface{}
 	rootUserARN     string
+	callerARN       string
 }
 
 func newJobDeployOpts(vars deployWkldVars) (*deployJobOpts, error) {
@@ -193,6 +194,17 @@ After fixing the deployment, you can:
 		}
 		return fmt.Errorf("deploy job %s to environment %s: %w", o.name, o.envName, err)
 	}
+	recordDeployment(&recordDeploymentInput{
+		app:         o.appName,
+		env:         o.envName,
+		name:        o.name,
+		deployedBy:  o.callerARN,
+		imageDigest: aws.StringValue(uploadOut.ImageDigest),
+		retention:   o.targetApp.DeploymentRetention,
+		ws:          o.ws,
+		runner:      o.cmd,
+		store:       o.store,
+	})
 	log.Successf("Deployed %s.\n", color.HighlightUserInput(o.name))
 	return nil
 }
@@ -231,6 +243,7 @@ func (o *deployJobOpts) configureClients() error {
 		return fmt.Errorf("get identity: %w", err)
 	}
 	o.rootUserARN = caller.RootUserARN
+	o.callerARN = caller.ARN
 
 	return nil
 }
@@ -0,0 +1,144 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+)
+
+const (
+	jobHistoryJobNamePrompt     = "Which job of %s would you like to show the deployment history of?"
+	jobHistoryJobNameHelpPrompt = "Every deployment of the job with Copilot will be listed."
+)
+
+type jobHistoryVars struct {
+	appName          string
+	jobName          string
+	envName          string
+	shouldOutputJSON bool
+}
+
+type jobHistoryOpts struct {
+	jobHistoryVars
+
+	w     io.Writer
+	store store
+	sel   configSelector
+}
+
+func newJobHistoryOpts(vars jobHistoryVars) (*jobHistoryOpts, error) {
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("job history"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	ssmStore := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	return &jobHistoryOpts{
+		jobHistoryVars: vars,
+		w:              log.OutputWriter,
+		store:          ssmStore,
+		sel:            selector.NewConfigSelect(prompt.New(), ssmStore),
+	}, nil
+}
+
+// Validate returns an error for any invalid optional flags.
+func (o *jobHistoryOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *jobHistoryOpts) Ask() error {
+	if err := o.validateOrAskApp(); err != nil {
+		return err
+	}
+	if err := o.validateOrAskJobName(); err != nil {
+		return err
+	}
+	if o.envName != "" {
+		if _, err := o.store.GetEnvironment(o.appName, o.envName); err != nil {
+			return fmt.Errorf("get environment %s configuration: %w", o.envName, err)
+		}
+	}
+	return nil
+}
+
+// Execute lists the deployments of the job.
+func (o *jobHistoryOpts) Execute() error {
+	deployments, err := o.store.ListDeployments(o.appName, o.jobName, o.envName)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 && !o.shouldOutputJSON {
+		log.Infof("No deployments found for job %s.\n", color.HighlightUserInput(o.jobName))
+		return nil
+	}
+	return writeDeployments(o.w, deployments, o.shouldOutputJSON)
+}
+
+func (o *jobHistoryOpts) validateOrAskApp() error {
+	if o.appName != "" {
+		_, err := o.store.GetApplication(o.appName)
+		return err
+	}
+	appName, err := o.sel.Application(jobAppNamePrompt, svcAppNameHelpPrompt)
+	if err != nil {
+		return fmt.Errorf("select application name: %w", err)
+	}
+	o.appName = appName
+	return nil
+}
+
+func (o *jobHistoryOpts) validateOrAskJobName() error {
+	if o.jobName != "" {
+		_, err := o.store.GetJob(o.appName, o.jobName)
+		return err
+	}
+	jobName, err := o.sel.Job(fmt.Sprintf(jobHistoryJobNamePrompt, color.HighlightUserInput(o.appName)),
+		jobHistoryJobNameHelpPrompt, o.appName)
+	if err != nil {
+		return fmt.Errorf("select job for application %s: %w", o.appName, err)
+	}
+	o.jobName = jobName
+	return nil
+}
+
+// buildJobHistoryCmd builds the command for listing the deployments of a job.
+func buildJobHistoryCmd() *cobra.Command {
+	vars := jobHistoryVars{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Lists the deployments of a job.",
+		Long:  "Lists who deployed a job, when, and with which image, git commit and manifest.",
+		Example: `
+  Lists the deployments of the "report" job to the "prod" environment.
+  /code $ copilot job history -n report --env prod
+  Lists the deployments of the "report" job to every environment in JSON format.
+  /code $ copilot job history -n report --json`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newJobHistoryOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.jobName, nameFlag, nameFlagShort, "", jobFlagDescription)
+	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", historyEnvFlagDescription)
+	cmd.Flags().BoolVar(&vars.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	return cmd
+}
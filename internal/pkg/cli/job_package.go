@@ -35,6 +35,8 @@ type packageJobVars struct {
 	tag          string
 	outputDir    string
 	uploadAssets bool
+	buildCache   bool
+	reuseImage   bool
 }
 
 type packageJobOpts struct {
@@ -83,6 +85,8 @@ func newPackageJobOpts(vars packageJobVars) (*packageJobOpts, error) {
 				tag:          imageTagFromGit(o.runner, o.tag),
 				outputDir:    o.outputDir,
 				uploadAssets: o.uploadAssets,
+				buildCache:   o.buildCache,
+				reuseImage:   o.reuseImage,
 			},
 			runner:           o.runner,
 			initAddonsClient: initPackageAddonsClient,
@@ -195,5 +199,7 @@ func buildJobPackageCmd() *cobra.Command {
 	cmd.Flags().StringVar(&vars.tag, imageTagFlag, "", imageTagFlagDescription)
 	cmd.Flags().StringVar(&vars.outputDir, stackOutputDirFlag, "", stackOutputDirFlagDescription)
 	cmd.Flags().BoolVar(&vars.uploadAssets, uploadAssetsFlag, false, uploadAssetsFlagDescription)
+	cmd.Flags().BoolVar(&vars.buildCache, buildCacheFlag, false, buildCacheFlagDescription)
+	cmd.Flags().BoolVar(&vars.reuseImage, reuseImageFlag, false, reuseImageFlagDescription)
 	return cmd
 }
@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+)
+
+// lockTTL is how long a deployment lock is held before others can take it over,
+// in case the process holding it exits without releasing it.
+const lockTTL = time.Hour
+
+// acquireLock takes the deployment lock with the given ID and returns a function to release it.
+// If forceUnlock is true, the lock is released first regardless of who holds it.
+func acquireLock(l locker, appName, id string, forceUnlock bool) (release func(), err error) {
+	if forceUnlock {
+		if err := l.ForceReleaseLock(appName, id); err != nil {
+			return nil, err
+		}
+	}
+	lock, err := l.AcquireLock(appName, id, lockTTL)
+	if err != nil {
+		var errLocked *config.ErrLocked
+		if errors.As(err, &errLocked) {
+			return nil, fmt.Errorf("%w: wait for the other deployment to finish, or run again with %s if it is no longer running",
+				err, color.HighlightCode("--"+forceUnlockFlag))
+		}
+		return nil, err
+	}
+	return func() {
+		if err := l.ReleaseLock(lock); err != nil {
+			log.Warningf("Failed to release lock %s: %v\n", id, err)
+		}
+	}, nil
+}
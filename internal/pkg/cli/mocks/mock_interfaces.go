@@ -8,6 +8,7 @@ import (
 	encoding "encoding"
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	session "github.com/aws/aws-sdk-go/aws/session"
 	cloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
@@ -798,6 +799,20 @@ func (mr *MockenvironmentStoreMockRecorder) ListEnvironments(appName interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*MockenvironmentStore)(nil).ListEnvironments), appName)
 }
 
+// UpdateEnvironment mocks base method.
+func (m *MockenvironmentStore) UpdateEnvironment(env *config.Environment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnvironment", env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnvironment indicates an expected call of UpdateEnvironment.
+func (mr *MockenvironmentStoreMockRecorder) UpdateEnvironment(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*MockenvironmentStore)(nil).UpdateEnvironment), env)
+}
+
 // MockenvironmentCreator is a mock of environmentCreator interface.
 type MockenvironmentCreator struct {
 	ctrl     *gomock.Controller
@@ -835,6 +850,43 @@ func (mr *MockenvironmentCreatorMockRecorder) CreateEnvironment(env interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironment", reflect.TypeOf((*MockenvironmentCreator)(nil).CreateEnvironment), env)
 }
 
+// MockenvironmentUpdater is a mock of environmentUpdater interface.
+type MockenvironmentUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockenvironmentUpdaterMockRecorder
+}
+
+// MockenvironmentUpdaterMockRecorder is the mock recorder for MockenvironmentUpdater.
+type MockenvironmentUpdaterMockRecorder struct {
+	mock *MockenvironmentUpdater
+}
+
+// NewMockenvironmentUpdater creates a new mock instance.
+func NewMockenvironmentUpdater(ctrl *gomock.Controller) *MockenvironmentUpdater {
+	mock := &MockenvironmentUpdater{ctrl: ctrl}
+	mock.recorder = &MockenvironmentUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockenvironmentUpdater) EXPECT() *MockenvironmentUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateEnvironment mocks base method.
+func (m *MockenvironmentUpdater) UpdateEnvironment(env *config.Environment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnvironment", env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnvironment indicates an expected call of UpdateEnvironment.
+func (mr *MockenvironmentUpdaterMockRecorder) UpdateEnvironment(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*MockenvironmentUpdater)(nil).UpdateEnvironment), env)
+}
+
 // MockenvironmentGetter is a mock of environmentGetter interface.
 type MockenvironmentGetter struct {
 	ctrl     *gomock.Controller
@@ -948,6 +1000,138 @@ func (mr *MockenvironmentDeleterMockRecorder) DeleteEnvironment(appName, environ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*MockenvironmentDeleter)(nil).DeleteEnvironment), appName, environmentName)
 }
 
+// MockdeploymentStore is a mock of deploymentStore interface.
+type MockdeploymentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeploymentStoreMockRecorder
+}
+
+// MockdeploymentStoreMockRecorder is the mock recorder for MockdeploymentStore.
+type MockdeploymentStoreMockRecorder struct {
+	mock *MockdeploymentStore
+}
+
+// NewMockdeploymentStore creates a new mock instance.
+func NewMockdeploymentStore(ctrl *gomock.Controller) *MockdeploymentStore {
+	mock := &MockdeploymentStore{ctrl: ctrl}
+	mock.recorder = &MockdeploymentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeploymentStore) EXPECT() *MockdeploymentStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateDeployment mocks base method.
+func (m *MockdeploymentStore) CreateDeployment(d *config.Deployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeployment", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeployment indicates an expected call of CreateDeployment.
+func (mr *MockdeploymentStoreMockRecorder) CreateDeployment(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockdeploymentStore)(nil).CreateDeployment), d)
+}
+
+// ListDeployments mocks base method.
+func (m *MockdeploymentStore) ListDeployments(appName, wkldName, envName string) ([]*config.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeployments", appName, wkldName, envName)
+	ret0, _ := ret[0].([]*config.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeployments indicates an expected call of ListDeployments.
+func (mr *MockdeploymentStoreMockRecorder) ListDeployments(appName, wkldName, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeployments", reflect.TypeOf((*MockdeploymentStore)(nil).ListDeployments), appName, wkldName, envName)
+}
+
+// PruneDeployments mocks base method.
+func (m *MockdeploymentStore) PruneDeployments(appName, wkldName, envName string, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneDeployments", appName, wkldName, envName, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneDeployments indicates an expected call of PruneDeployments.
+func (mr *MockdeploymentStoreMockRecorder) PruneDeployments(appName, wkldName, envName, keep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDeployments", reflect.TypeOf((*MockdeploymentStore)(nil).PruneDeployments), appName, wkldName, envName, keep)
+}
+
+// Mocklocker is a mock of locker interface.
+type Mocklocker struct {
+	ctrl     *gomock.Controller
+	recorder *MocklockerMockRecorder
+}
+
+// MocklockerMockRecorder is the mock recorder for Mocklocker.
+type MocklockerMockRecorder struct {
+	mock *Mocklocker
+}
+
+// NewMocklocker creates a new mock instance.
+func NewMocklocker(ctrl *gomock.Controller) *Mocklocker {
+	mock := &Mocklocker{ctrl: ctrl}
+	mock.recorder = &MocklockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklocker) EXPECT() *MocklockerMockRecorder {
+	return m.recorder
+}
+
+// AcquireLock mocks base method.
+func (m *Mocklocker) AcquireLock(appName, id string, ttl time.Duration) (*config.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLock", appName, id, ttl)
+	ret0, _ := ret[0].(*config.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLock indicates an expected call of AcquireLock.
+func (mr *MocklockerMockRecorder) AcquireLock(appName, id, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLock", reflect.TypeOf((*Mocklocker)(nil).AcquireLock), appName, id, ttl)
+}
+
+// ForceReleaseLock mocks base method.
+func (m *Mocklocker) ForceReleaseLock(appName, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceReleaseLock", appName, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceReleaseLock indicates an expected call of ForceReleaseLock.
+func (mr *MocklockerMockRecorder) ForceReleaseLock(appName, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceReleaseLock", reflect.TypeOf((*Mocklocker)(nil).ForceReleaseLock), appName, id)
+}
+
+// ReleaseLock mocks base method.
+func (m *Mocklocker) ReleaseLock(lock *config.Lock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLock", lock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLock indicates an expected call of ReleaseLock.
+func (mr *MocklockerMockRecorder) ReleaseLock(lock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*Mocklocker)(nil).ReleaseLock), lock)
+}
+
 // Mockstore is a mock of store interface.
 type Mockstore struct {
 	ctrl     *gomock.Controller
@@ -985,6 +1169,20 @@ func (mr *MockstoreMockRecorder) CreateApplication(app interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplication", reflect.TypeOf((*Mockstore)(nil).CreateApplication), app)
 }
 
+// CreateDeployment mocks base method.
+func (m *Mockstore) CreateDeployment(d *config.Deployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeployment", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeployment indicates an expected call of CreateDeployment.
+func (mr *MockstoreMockRecorder) CreateDeployment(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*Mockstore)(nil).CreateDeployment), d)
+}
+
 // CreateEnvironment mocks base method.
 func (m *Mockstore) CreateEnvironment(env *config.Environment) error {
 	m.ctrl.T.Helper()
@@ -1173,6 +1371,21 @@ func (mr *MockstoreMockRecorder) ListApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplications", reflect.TypeOf((*Mockstore)(nil).ListApplications))
 }
 
+// ListDeployments mocks base method.
+func (m *Mockstore) ListDeployments(appName, wkldName, envName string) ([]*config.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeployments", appName, wkldName, envName)
+	ret0, _ := ret[0].([]*config.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeployments indicates an expected call of ListDeployments.
+func (mr *MockstoreMockRecorder) ListDeployments(appName, wkldName, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeployments", reflect.TypeOf((*Mockstore)(nil).ListDeployments), appName, wkldName, envName)
+}
+
 // ListEnvironments mocks base method.
 func (m *Mockstore) ListEnvironments(appName string) ([]*config.Environment, error) {
 	m.ctrl.T.Helper()
@@ -1233,6 +1446,20 @@ func (mr *MockstoreMockRecorder) ListWorkloads(appName interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkloads", reflect.TypeOf((*Mockstore)(nil).ListWorkloads), appName)
 }
 
+// PruneDeployments mocks base method.
+func (m *Mockstore) PruneDeployments(appName, wkldName, envName string, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneDeployments", appName, wkldName, envName, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneDeployments indicates an expected call of PruneDeployments.
+func (mr *MockstoreMockRecorder) PruneDeployments(appName, wkldName, envName, keep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDeployments", reflect.TypeOf((*Mockstore)(nil).PruneDeployments), appName, wkldName, envName, keep)
+}
+
 // UpdateApplication mocks base method.
 func (m *Mockstore) UpdateApplication(app *config.Application) error {
 	m.ctrl.T.Helper()
@@ -1247,6 +1474,20 @@ func (mr *MockstoreMockRecorder) UpdateApplication(app interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplication", reflect.TypeOf((*Mockstore)(nil).UpdateApplication), app)
 }
 
+// UpdateEnvironment mocks base method.
+func (m *Mockstore) UpdateEnvironment(env *config.Environment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnvironment", env)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnvironment indicates an expected call of UpdateEnvironment.
+func (mr *MockstoreMockRecorder) UpdateEnvironment(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*Mockstore)(nil).UpdateEnvironment), env)
+}
+
 // MockdeployedEnvironmentLister is a mock of deployedEnvironmentLister interface.
 type MockdeployedEnvironmentLister struct {
 	ctrl     *gomock.Controller
@@ -1706,6 +1947,59 @@ func (mr *MocktemplaterMockRecorder) Template() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Template", reflect.TypeOf((*Mocktemplater)(nil).Template))
 }
 
+// MockenvAddonsTemplater is a mock of envAddonsTemplater interface.
+type MockenvAddonsTemplater struct {
+	ctrl     *gomock.Controller
+	recorder *MockenvAddonsTemplaterMockRecorder
+}
+
+// MockenvAddonsTemplaterMockRecorder is the mock recorder for MockenvAddonsTemplater.
+type MockenvAddonsTemplaterMockRecorder struct {
+	mock *MockenvAddonsTemplater
+}
+
+// NewMockenvAddonsTemplater creates a new mock instance.
+func NewMockenvAddonsTemplater(ctrl *gomock.Controller) *MockenvAddonsTemplater {
+	mock := &MockenvAddonsTemplater{ctrl: ctrl}
+	mock.recorder = &MockenvAddonsTemplaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockenvAddonsTemplater) EXPECT() *MockenvAddonsTemplaterMockRecorder {
+	return m.recorder
+}
+
+// Parameters mocks base method.
+func (m *MockenvAddonsTemplater) Parameters() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parameters")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parameters indicates an expected call of Parameters.
+func (mr *MockenvAddonsTemplaterMockRecorder) Parameters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parameters", reflect.TypeOf((*MockenvAddonsTemplater)(nil).Parameters))
+}
+
+// Template mocks base method.
+func (m *MockenvAddonsTemplater) Template() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Template")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Template indicates an expected call of Template.
+func (mr *MockenvAddonsTemplaterMockRecorder) Template() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Template", reflect.TypeOf((*MockenvAddonsTemplater)(nil).Template))
+}
+
 // Mockrunner is a mock of runner interface.
 type Mockrunner struct {
 	ctrl     *gomock.Controller
@@ -3032,6 +3326,156 @@ func (mr *MockwsAddonManagerMockRecorder) WriteAddon(f, svc, name interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteAddon", reflect.TypeOf((*MockwsAddonManager)(nil).WriteAddon), f, svc, name)
 }
 
+// MockwsStorageReader is a mock of wsStorageReader interface.
+type MockwsStorageReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockwsStorageReaderMockRecorder
+}
+
+// MockwsStorageReaderMockRecorder is the mock recorder for MockwsStorageReader.
+type MockwsStorageReaderMockRecorder struct {
+	mock *MockwsStorageReader
+}
+
+// NewMockwsStorageReader creates a new mock instance.
+func NewMockwsStorageReader(ctrl *gomock.Controller) *MockwsStorageReader {
+	mock := &MockwsStorageReader{ctrl: ctrl}
+	mock.recorder = &MockwsStorageReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwsStorageReader) EXPECT() *MockwsStorageReaderMockRecorder {
+	return m.recorder
+}
+
+// ListWorkloads mocks base method.
+func (m *MockwsStorageReader) ListWorkloads() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkloads")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkloads indicates an expected call of ListWorkloads.
+func (mr *MockwsStorageReaderMockRecorder) ListWorkloads() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkloads", reflect.TypeOf((*MockwsStorageReader)(nil).ListWorkloads))
+}
+
+// ReadAddon mocks base method.
+func (m *MockwsStorageReader) ReadAddon(svc, fname string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAddon", svc, fname)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAddon indicates an expected call of ReadAddon.
+func (mr *MockwsStorageReaderMockRecorder) ReadAddon(svc, fname interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAddon", reflect.TypeOf((*MockwsStorageReader)(nil).ReadAddon), svc, fname)
+}
+
+// ReadAddonsDir mocks base method.
+func (m *MockwsStorageReader) ReadAddonsDir(svcName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAddonsDir", svcName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAddonsDir indicates an expected call of ReadAddonsDir.
+func (mr *MockwsStorageReaderMockRecorder) ReadAddonsDir(svcName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAddonsDir", reflect.TypeOf((*MockwsStorageReader)(nil).ReadAddonsDir), svcName)
+}
+
+// MockwsStorageDeleter is a mock of wsStorageDeleter interface.
+type MockwsStorageDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockwsStorageDeleterMockRecorder
+}
+
+// MockwsStorageDeleterMockRecorder is the mock recorder for MockwsStorageDeleter.
+type MockwsStorageDeleterMockRecorder struct {
+	mock *MockwsStorageDeleter
+}
+
+// NewMockwsStorageDeleter creates a new mock instance.
+func NewMockwsStorageDeleter(ctrl *gomock.Controller) *MockwsStorageDeleter {
+	mock := &MockwsStorageDeleter{ctrl: ctrl}
+	mock.recorder = &MockwsStorageDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwsStorageDeleter) EXPECT() *MockwsStorageDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteAddon mocks base method.
+func (m *MockwsStorageDeleter) DeleteAddon(svc, fname string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddon", svc, fname)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddon indicates an expected call of DeleteAddon.
+func (mr *MockwsStorageDeleterMockRecorder) DeleteAddon(svc, fname interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddon", reflect.TypeOf((*MockwsStorageDeleter)(nil).DeleteAddon), svc, fname)
+}
+
+// ListWorkloads mocks base method.
+func (m *MockwsStorageDeleter) ListWorkloads() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkloads")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkloads indicates an expected call of ListWorkloads.
+func (mr *MockwsStorageDeleterMockRecorder) ListWorkloads() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkloads", reflect.TypeOf((*MockwsStorageDeleter)(nil).ListWorkloads))
+}
+
+// ReadAddon mocks base method.
+func (m *MockwsStorageDeleter) ReadAddon(svc, fname string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAddon", svc, fname)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAddon indicates an expected call of ReadAddon.
+func (mr *MockwsStorageDeleterMockRecorder) ReadAddon(svc, fname interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAddon", reflect.TypeOf((*MockwsStorageDeleter)(nil).ReadAddon), svc, fname)
+}
+
+// ReadAddonsDir mocks base method.
+func (m *MockwsStorageDeleter) ReadAddonsDir(svcName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAddonsDir", svcName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAddonsDir indicates an expected call of ReadAddonsDir.
+func (mr *MockwsStorageDeleterMockRecorder) ReadAddonsDir(svcName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAddonsDir", reflect.TypeOf((*MockwsStorageDeleter)(nil).ReadAddonsDir), svcName)
+}
+
 // Mockuploader is a mock of uploader interface.
 type Mockuploader struct {
 	ctrl     *gomock.Controller
@@ -3195,6 +3639,119 @@ func (mr *MockbucketEmptierMockRecorder) EmptyBucket(bucket interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyBucket", reflect.TypeOf((*MockbucketEmptier)(nil).EmptyBucket), bucket)
 }
 
+// MockbucketCopier is a mock of bucketCopier interface.
+type MockbucketCopier struct {
+	ctrl     *gomock.Controller
+	recorder *MockbucketCopierMockRecorder
+}
+
+// MockbucketCopierMockRecorder is the mock recorder for MockbucketCopier.
+type MockbucketCopierMockRecorder struct {
+	mock *MockbucketCopier
+}
+
+// NewMockbucketCopier creates a new mock instance.
+func NewMockbucketCopier(ctrl *gomock.Controller) *MockbucketCopier {
+	mock := &MockbucketCopier{ctrl: ctrl}
+	mock.recorder = &MockbucketCopierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbucketCopier) EXPECT() *MockbucketCopierMockRecorder {
+	return m.recorder
+}
+
+// CopyBucket mocks base method.
+func (m *MockbucketCopier) CopyBucket(src, dst string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyBucket", src, dst)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyBucket indicates an expected call of CopyBucket.
+func (mr *MockbucketCopierMockRecorder) CopyBucket(src, dst interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyBucket", reflect.TypeOf((*MockbucketCopier)(nil).CopyBucket), src, dst)
+}
+
+// MocktableBackupCreator is a mock of tableBackupCreator interface.
+type MocktableBackupCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktableBackupCreatorMockRecorder
+}
+
+// MocktableBackupCreatorMockRecorder is the mock recorder for MocktableBackupCreator.
+type MocktableBackupCreatorMockRecorder struct {
+	mock *MocktableBackupCreator
+}
+
+// NewMocktableBackupCreator creates a new mock instance.
+func NewMocktableBackupCreator(ctrl *gomock.Controller) *MocktableBackupCreator {
+	mock := &MocktableBackupCreator{ctrl: ctrl}
+	mock.recorder = &MocktableBackupCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktableBackupCreator) EXPECT() *MocktableBackupCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateBackup mocks base method.
+func (m *MocktableBackupCreator) CreateBackup(table, backup string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBackup", table, backup)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBackup indicates an expected call of CreateBackup.
+func (mr *MocktableBackupCreatorMockRecorder) CreateBackup(table, backup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBackup", reflect.TypeOf((*MocktableBackupCreator)(nil).CreateBackup), table, backup)
+}
+
+// MockdbClusterSnapshotCreator is a mock of dbClusterSnapshotCreator interface.
+type MockdbClusterSnapshotCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockdbClusterSnapshotCreatorMockRecorder
+}
+
+// MockdbClusterSnapshotCreatorMockRecorder is the mock recorder for MockdbClusterSnapshotCreator.
+type MockdbClusterSnapshotCreatorMockRecorder struct {
+	mock *MockdbClusterSnapshotCreator
+}
+
+// NewMockdbClusterSnapshotCreator creates a new mock instance.
+func NewMockdbClusterSnapshotCreator(ctrl *gomock.Controller) *MockdbClusterSnapshotCreator {
+	mock := &MockdbClusterSnapshotCreator{ctrl: ctrl}
+	mock.recorder = &MockdbClusterSnapshotCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdbClusterSnapshotCreator) EXPECT() *MockdbClusterSnapshotCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateDBClusterSnapshot mocks base method.
+func (m *MockdbClusterSnapshotCreator) CreateDBClusterSnapshot(cluster, snapshot string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDBClusterSnapshot", cluster, snapshot)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDBClusterSnapshot indicates an expected call of CreateDBClusterSnapshot.
+func (mr *MockdbClusterSnapshotCreatorMockRecorder) CreateDBClusterSnapshot(cluster, snapshot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDBClusterSnapshot", reflect.TypeOf((*MockdbClusterSnapshotCreator)(nil).CreateDBClusterSnapshot), cluster, snapshot)
+}
+
 // MockenvironmentDeployer is a mock of environmentDeployer interface.
 type MockenvironmentDeployer struct {
 	ctrl     *gomock.Controller
@@ -5856,6 +6413,82 @@ func (mr *MockserviceDescriberMockRecorder) DescribeService(app, env, svc interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeService", reflect.TypeOf((*MockserviceDescriber)(nil).DescribeService), app, env, svc)
 }
 
+// MocktaskDefinitionDescriber is a mock of taskDefinitionDescriber interface.
+type MocktaskDefinitionDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MocktaskDefinitionDescriberMockRecorder
+}
+
+// MocktaskDefinitionDescriberMockRecorder is the mock recorder for MocktaskDefinitionDescriber.
+type MocktaskDefinitionDescriberMockRecorder struct {
+	mock *MocktaskDefinitionDescriber
+}
+
+// NewMocktaskDefinitionDescriber creates a new mock instance.
+func NewMocktaskDefinitionDescriber(ctrl *gomock.Controller) *MocktaskDefinitionDescriber {
+	mock := &MocktaskDefinitionDescriber{ctrl: ctrl}
+	mock.recorder = &MocktaskDefinitionDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktaskDefinitionDescriber) EXPECT() *MocktaskDefinitionDescriberMockRecorder {
+	return m.recorder
+}
+
+// TaskDefinition mocks base method.
+func (m *MocktaskDefinitionDescriber) TaskDefinition(app, env, svc string) (*ecs.TaskDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskDefinition", app, env, svc)
+	ret0, _ := ret[0].(*ecs.TaskDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaskDefinition indicates an expected call of TaskDefinition.
+func (mr *MocktaskDefinitionDescriberMockRecorder) TaskDefinition(app, env, svc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskDefinition", reflect.TypeOf((*MocktaskDefinitionDescriber)(nil).TaskDefinition), app, env, svc)
+}
+
+// MockimageDigestGetter is a mock of imageDigestGetter interface.
+type MockimageDigestGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockimageDigestGetterMockRecorder
+}
+
+// MockimageDigestGetterMockRecorder is the mock recorder for MockimageDigestGetter.
+type MockimageDigestGetterMockRecorder struct {
+	mock *MockimageDigestGetter
+}
+
+// NewMockimageDigestGetter creates a new mock instance.
+func NewMockimageDigestGetter(ctrl *gomock.Controller) *MockimageDigestGetter {
+	mock := &MockimageDigestGetter{ctrl: ctrl}
+	mock.recorder = &MockimageDigestGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockimageDigestGetter) EXPECT() *MockimageDigestGetterMockRecorder {
+	return m.recorder
+}
+
+// ImageDigest mocks base method.
+func (m *MockimageDigestGetter) ImageDigest(repoName, tag string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageDigest", repoName, tag)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImageDigest indicates an expected call of ImageDigest.
+func (mr *MockimageDigestGetterMockRecorder) ImageDigest(repoName, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageDigest", reflect.TypeOf((*MockimageDigestGetter)(nil).ImageDigest), repoName, tag)
+}
+
 // MockapprunnerServiceDescriber is a mock of apprunnerServiceDescriber interface.
 type MockapprunnerServiceDescriber struct {
 	ctrl     *gomock.Controller
@@ -6226,6 +6859,59 @@ func (mr *MockstackExistCheckerMockRecorder) Exists(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockstackExistChecker)(nil).Exists), arg0)
 }
 
+// MockstackResourcesDescriber is a mock of stackResourcesDescriber interface.
+type MockstackResourcesDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockstackResourcesDescriberMockRecorder
+}
+
+// MockstackResourcesDescriberMockRecorder is the mock recorder for MockstackResourcesDescriber.
+type MockstackResourcesDescriberMockRecorder struct {
+	mock *MockstackResourcesDescriber
+}
+
+// NewMockstackResourcesDescriber creates a new mock instance.
+func NewMockstackResourcesDescriber(ctrl *gomock.Controller) *MockstackResourcesDescriber {
+	mock := &MockstackResourcesDescriber{ctrl: ctrl}
+	mock.recorder = &MockstackResourcesDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstackResourcesDescriber) EXPECT() *MockstackResourcesDescriberMockRecorder {
+	return m.recorder
+}
+
+// Exists mocks base method.
+func (m *MockstackResourcesDescriber) Exists(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockstackResourcesDescriberMockRecorder) Exists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockstackResourcesDescriber)(nil).Exists), arg0)
+}
+
+// StackResources mocks base method.
+func (m *MockstackResourcesDescriber) StackResources(name string) ([]*cloudformation.StackResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StackResources", name)
+	ret0, _ := ret[0].([]*cloudformation.StackResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StackResources indicates an expected call of StackResources.
+func (mr *MockstackResourcesDescriberMockRecorder) StackResources(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StackResources", reflect.TypeOf((*MockstackResourcesDescriber)(nil).StackResources), name)
+}
+
 // MockrunningTaskSelector is a mock of runningTaskSelector interface.
 type MockrunningTaskSelector struct {
 	ctrl     *gomock.Controller
@@ -6473,6 +7159,177 @@ func (mr *MocksecretPutterMockRecorder) PutSecret(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSecret", reflect.TypeOf((*MocksecretPutter)(nil).PutSecret), in)
 }
 
+// MocksecretsManagerPutter is a mock of secretsManagerPutter interface.
+type MocksecretsManagerPutter struct {
+	ctrl     *gomock.Controller
+	recorder *MocksecretsManagerPutterMockRecorder
+}
+
+// MocksecretsManagerPutterMockRecorder is the mock recorder for MocksecretsManagerPutter.
+type MocksecretsManagerPutterMockRecorder struct {
+	mock *MocksecretsManagerPutter
+}
+
+// NewMocksecretsManagerPutter creates a new mock instance.
+func NewMocksecretsManagerPutter(ctrl *gomock.Controller) *MocksecretsManagerPutter {
+	mock := &MocksecretsManagerPutter{ctrl: ctrl}
+	mock.recorder = &MocksecretsManagerPutterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksecretsManagerPutter) EXPECT() *MocksecretsManagerPutterMockRecorder {
+	return m.recorder
+}
+
+// PutSecret mocks base method.
+func (m *MocksecretsManagerPutter) PutSecret(in secretsmanager.PutSecretInput) (*secretsmanager.PutSecretOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSecret", in)
+	ret0, _ := ret[0].(*secretsmanager.PutSecretOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutSecret indicates an expected call of PutSecret.
+func (mr *MocksecretsManagerPutterMockRecorder) PutSecret(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSecret", reflect.TypeOf((*MocksecretsManagerPutter)(nil).PutSecret), in)
+}
+
+// RotateSecret mocks base method.
+func (m *MocksecretsManagerPutter) RotateSecret(secretName, lambdaARN string, days int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecret", secretName, lambdaARN, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSecret indicates an expected call of RotateSecret.
+func (mr *MocksecretsManagerPutterMockRecorder) RotateSecret(secretName, lambdaARN, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MocksecretsManagerPutter)(nil).RotateSecret), secretName, lambdaARN, days)
+}
+
+// MocksecretParameterStore is a mock of secretParameterStore interface.
+type MocksecretParameterStore struct {
+	ctrl     *gomock.Controller
+	recorder *MocksecretParameterStoreMockRecorder
+}
+
+// MocksecretParameterStoreMockRecorder is the mock recorder for MocksecretParameterStore.
+type MocksecretParameterStoreMockRecorder struct {
+	mock *MocksecretParameterStore
+}
+
+// NewMocksecretParameterStore creates a new mock instance.
+func NewMocksecretParameterStore(ctrl *gomock.Controller) *MocksecretParameterStore {
+	mock := &MocksecretParameterStore{ctrl: ctrl}
+	mock.recorder = &MocksecretParameterStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksecretParameterStore) EXPECT() *MocksecretParameterStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteSecret mocks base method.
+func (m *MocksecretParameterStore) DeleteSecret(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MocksecretParameterStoreMockRecorder) DeleteSecret(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MocksecretParameterStore)(nil).DeleteSecret), name)
+}
+
+// GetSecret mocks base method.
+func (m *MocksecretParameterStore) GetSecret(name string, withDecryption bool) (*ssm.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", name, withDecryption)
+	ret0, _ := ret[0].(*ssm.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MocksecretParameterStoreMockRecorder) GetSecret(name, withDecryption interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MocksecretParameterStore)(nil).GetSecret), name, withDecryption)
+}
+
+// ListSecrets mocks base method.
+func (m *MocksecretParameterStore) ListSecrets(tags map[string]string) ([]ssm.SecretMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", tags)
+	ret0, _ := ret[0].([]ssm.SecretMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MocksecretParameterStoreMockRecorder) ListSecrets(tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MocksecretParameterStore)(nil).ListSecrets), tags)
+}
+
+// PutSecret mocks base method.
+func (m *MocksecretParameterStore) PutSecret(in ssm.PutSecretInput) (*ssm.PutSecretOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSecret", in)
+	ret0, _ := ret[0].(*ssm.PutSecretOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutSecret indicates an expected call of PutSecret.
+func (mr *MocksecretParameterStoreMockRecorder) PutSecret(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSecret", reflect.TypeOf((*MocksecretParameterStore)(nil).PutSecret), in)
+}
+
+// MockserviceForceUpdater is a mock of serviceForceUpdater interface.
+type MockserviceForceUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceForceUpdaterMockRecorder
+}
+
+// MockserviceForceUpdaterMockRecorder is the mock recorder for MockserviceForceUpdater.
+type MockserviceForceUpdaterMockRecorder struct {
+	mock *MockserviceForceUpdater
+}
+
+// NewMockserviceForceUpdater creates a new mock instance.
+func NewMockserviceForceUpdater(ctrl *gomock.Controller) *MockserviceForceUpdater {
+	mock := &MockserviceForceUpdater{ctrl: ctrl}
+	mock.recorder = &MockserviceForceUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceForceUpdater) EXPECT() *MockserviceForceUpdaterMockRecorder {
+	return m.recorder
+}
+
+// ForceUpdateService mocks base method.
+func (m *MockserviceForceUpdater) ForceUpdateService(app, env, svc string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceUpdateService", app, env, svc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceUpdateService indicates an expected call of ForceUpdateService.
+func (mr *MockserviceForceUpdaterMockRecorder) ForceUpdateService(app, env, svc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceUpdateService", reflect.TypeOf((*MockserviceForceUpdater)(nil).ForceUpdateService), app, env, svc)
+}
+
 // MockservicePauser is a mock of servicePauser interface.
 type MockservicePauser struct {
 	ctrl     *gomock.Controller
@@ -27,6 +27,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
 	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+	"github.com/aws/copilot-cli/internal/pkg/version"
 	"github.com/aws/copilot-cli/internal/pkg/workspace"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -56,10 +57,14 @@ const (
 
 const connectionsURL = "https://console.aws.amazon.com/codesuite/settings/connections"
 
+// fmtCopilotBinaryURL is the location of the linux binary downloaded by the pipeline's build projects.
+const fmtCopilotBinaryURL = "%s/copilot-linux-%s"
+
 type deployPipelineVars struct {
 	appName          string
 	name             string
 	skipConfirmation bool
+	forceUnlock      bool
 }
 
 type deployPipelineOpts struct {
@@ -71,6 +76,7 @@ type deployPipelineOpts struct {
 	prompt                          prompter
 	region                          string
 	store                           store
+	locker                          locker
 	ws                              wsPipelineReader
 	codestar                        codestar
 	newSvcListCmd                   func(io.Writer, string) cmd
@@ -112,6 +118,7 @@ func newDeployPipelineOpts(vars deployPipelineVars) (*deployPipelineOpts, error)
 		region:             aws.StringValue(defaultSession.Config.Region),
 		deployPipelineVars: vars,
 		store:              store,
+		locker:             store,
 		prog:               termprogress.NewSpinner(log.DiagnosticWriter),
 		prompt:             prompter,
 		sel:                selector.NewWsPipelineSelect(prompter, ws),
@@ -188,10 +195,15 @@ func (o *deployPipelineOpts) Ask() error {
 
 // Execute creates a new pipeline or updates the current pipeline if it already exists.
 func (o *deployPipelineOpts) Execute() error {
+	release, err := acquireLock(o.locker, o.appName, config.PipelineLockID(o.pipeline.Name), o.forceUnlock)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// bootstrap pipeline resources
 	o.prog.Start(fmt.Sprintf(fmtPipelineDeployResourcesStart, color.HighlightUserInput(o.appName)))
-	err := o.pipelineDeployer.AddPipelineResourcesToApp(o.app, o.region)
-	if err != nil {
+	if err := o.pipelineDeployer.AddPipelineResourcesToApp(o.app, o.region); err != nil {
 		o.prog.Stop(log.Serrorf(fmtPipelineDeployResourcesFailed, color.HighlightUserInput(o.appName)))
 		return fmt.Errorf("add pipeline resources to application %s in %s: %w", o.appName, o.region, err)
 	}
@@ -253,6 +265,14 @@ func (o *deployPipelineOpts) Execute() error {
 		ArtifactBuckets: artifactBuckets,
 		AdditionalTags:  o.app.Tags,
 	}
+	if pipeline.Preview != nil {
+		if _, ok := source.(*deploy.GitHubV1Source); ok {
+			return fmt.Errorf("preview environments are not supported for %s sources, please migrate to a %s connection", manifest.GithubV1ProviderName, manifest.GithubProviderName)
+		}
+		var preview deploy.PreviewEnvironments
+		preview.Init(pipeline.Preview, fmt.Sprintf(fmtCopilotBinaryURL, binaryS3BucketPath, version.Version))
+		deployPipelineInput.Preview = &preview
+	}
 
 	if err := o.deployPipeline(deployPipelineInput); err != nil {
 		return err
@@ -489,5 +509,6 @@ func buildPipelineDeployCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, "", appFlagDescription)
 	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", pipelineFlagDescription)
 	cmd.Flags().BoolVar(&vars.skipConfirmation, yesFlag, false, yesFlagDescription)
+	cmd.Flags().BoolVar(&vars.forceUnlock, forceUnlockFlag, false, forceUnlockFlagDescription)
 	return cmd
 }
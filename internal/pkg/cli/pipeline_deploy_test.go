@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
 	"github.com/aws/copilot-cli/internal/pkg/config"
@@ -230,9 +231,26 @@ func TestDeployPipelineOpts_Execute(t *testing.T) {
 		inPipelineName string
 		inRegion       string
 		inPipelineFile string
+		mockLocker     func(m *mocks.Mocklocker) // Defaults to acquiring and releasing the lock successfully.
 		callMocks      func(m deployPipelineMocks)
 		expectedError  error
 	}{
+		"returns an error if the pipeline is being deployed by someone else": {
+			inApp:     &app,
+			inAppName: appName,
+			inRegion:  region,
+			mockLocker: func(m *mocks.Mocklocker) {
+				m.EXPECT().AcquireLock(appName, "pipelines/pipepiper", lockTTL).Return(nil, &config.ErrLocked{
+					Lock: &config.Lock{
+						ID:         "pipelines/pipepiper",
+						Owner:      "arn:aws:iam::123456789012:user/bob",
+						AcquiredAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+					},
+				})
+			},
+			callMocks:     func(m deployPipelineMocks) {},
+			expectedError: errors.New("pipelines/pipepiper is locked by arn:aws:iam::123456789012:user/bob since 2020-05-01T10:00:00Z: wait for the other deployment to finish, or run again with `--force-unlock` if it is no longer running"),
+		},
 		"create and deploy pipeline": {
 			inApp:     &app,
 			inAppName: appName,
@@ -731,6 +749,7 @@ func TestDeployPipelineOpts_Execute(t *testing.T) {
 			mockProgress := mocks.NewMockprogress(ctrl)
 			mockPrompt := mocks.NewMockprompter(ctrl)
 			mockActionCmd := mocks.NewMockactionCommand(ctrl)
+			mockLocker := mocks.NewMocklocker(ctrl)
 
 			mocks := deployPipelineMocks{
 				store:                  mockStore,
@@ -743,6 +762,12 @@ func TestDeployPipelineOpts_Execute(t *testing.T) {
 			}
 
 			tc.callMocks(mocks)
+			if tc.mockLocker != nil {
+				tc.mockLocker(mockLocker)
+			} else {
+				mockLocker.EXPECT().AcquireLock(appName, "pipelines/pipepiper", lockTTL).Return(&config.Lock{}, nil)
+				mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+			}
 
 			opts := &deployPipelineOpts{
 				deployPipelineVars: deployPipelineVars{
@@ -754,6 +779,7 @@ func TestDeployPipelineOpts_Execute(t *testing.T) {
 				app:              tc.inApp,
 				region:           tc.inRegion,
 				store:            mockStore,
+				locker:           mockLocker,
 				prog:             mockProgress,
 				prompt:           mockPrompt,
 				newSvcListCmd: func(w io.Writer, app string) cmd {
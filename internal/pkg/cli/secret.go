@@ -4,8 +4,19 @@
 package cli
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/aws/copilot-cli/cmd/copilot/template"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
 	"github.com/aws/copilot-cli/internal/pkg/cli/group"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +29,10 @@ Secrets are sensitive information that you need in your application.`,
 	}
 
 	cmd.AddCommand(buildSecretInitCmd())
+	cmd.AddCommand(buildSecretListCmd())
+	cmd.AddCommand(buildSecretShowCmd())
+	cmd.AddCommand(buildSecretDeleteCmd())
+	cmd.AddCommand(buildSecretRotateCmd())
 
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
@@ -25,3 +40,217 @@ Secrets are sensitive information that you need in your application.`,
 	}
 	return cmd
 }
+
+// envSecret is a secret of an application in one of its environments.
+type envSecret struct {
+	name string // Name of the secret, such as "db_password".
+	env  string
+	ssm.SecretMetadata
+
+	services []string // Deployed services that reference the secret.
+	jobs     []string // Deployed jobs that reference the secret.
+
+	clients *secretEnvClients
+}
+
+func (s *envSecret) workloads() []string {
+	return append(append([]string{}, s.services...), s.jobs...)
+}
+
+// secretEnvClients holds the clients to manage the secrets of an environment.
+type secretEnvClients struct {
+	params   secretParameterStore
+	taskDefs taskDefinitionDescriber
+	services serviceForceUpdater
+}
+
+// secretFinder finds the secrets of an application and the deployed workloads that reference them.
+type secretFinder struct {
+	store         store
+	deployStore   deployedEnvironmentLister
+	newEnvClients func(env *config.Environment) (*secretEnvClients, error)
+
+	clients map[string]*secretEnvClients
+}
+
+func newSecretFinder(sessProvider *sessions.Provider, store store) (*secretFinder, error) {
+	deployStore, err := deploy.NewStore(sessProvider, store)
+	if err != nil {
+		return nil, fmt.Errorf("connect to copilot deploy store: %w", err)
+	}
+	return &secretFinder{
+		store:       store,
+		deployStore: deployStore,
+		newEnvClients: func(env *config.Environment) (*secretEnvClients, error) {
+			sess, err := sessProvider.FromRole(env.ManagerRoleARN, env.Region)
+			if err != nil {
+				return nil, fmt.Errorf("create session from environment manager role %s in region %s: %w", env.ManagerRoleARN, env.Region, err)
+			}
+			ecsClient := ecs.New(sess)
+			return &secretEnvClients{
+				params:   ssm.New(sess),
+				taskDefs: ecsClient,
+				services: ecsClient,
+			}, nil
+		},
+	}, nil
+}
+
+func (f *secretFinder) clientsFor(env *config.Environment) (*secretEnvClients, error) {
+	if f.clients == nil {
+		f.clients = make(map[string]*secretEnvClients)
+	}
+	if clients, ok := f.clients[env.Name]; ok {
+		return clients, nil
+	}
+	clients, err := f.newEnvClients(env)
+	if err != nil {
+		return nil, err
+	}
+	f.clients[env.Name] = clients
+	return clients, nil
+}
+
+// find returns the secrets of the application in the environments, in the order of the environments.
+// If name is not empty, only the secrets with that name or parameter name are returned.
+func (f *secretFinder) find(app string, envs []*config.Environment, name string) ([]*envSecret, error) {
+	wls, err := f.store.ListWorkloads(app)
+	if err != nil {
+		return nil, fmt.Errorf("list workloads in application %s: %w", app, err)
+	}
+	isECS := make(map[string]bool)
+	for _, wl := range wls {
+		isECS[wl.Name] = wl.Type != manifest.RequestDrivenWebServiceType
+	}
+
+	var secrets []*envSecret
+	for _, env := range envs {
+		clients, err := f.clientsFor(env)
+		if err != nil {
+			return nil, err
+		}
+		params, err := clients.params.ListSecrets(map[string]string{
+			deploy.AppTagKey: app,
+			deploy.EnvTagKey: env.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list secrets in environment %s: %w", env.Name, err)
+		}
+		var found []*envSecret
+		for _, param := range params {
+			secret := &envSecret{
+				name:           secretNameFromParameter(app, env.Name, param.Name),
+				env:            env.Name,
+				SecretMetadata: param,
+				clients:        clients,
+			}
+			if name != "" && secret.name != name && param.Name != name {
+				continue
+			}
+			found = append(found, secret)
+		}
+		if len(found) == 0 {
+			continue
+		}
+		sort.SliceStable(found, func(i, j int) bool {
+			return found[i].name < found[j].name
+		})
+
+		svcs, err := f.deployStore.ListDeployedServices(app, env.Name)
+		if err != nil {
+			return nil, fmt.Errorf("list deployed services in environment %s: %w", env.Name, err)
+		}
+		svcRefs, err := secretReferences(clients.taskDefs, app, env.Name, svcs, isECS)
+		if err != nil {
+			return nil, err
+		}
+		jobs, err := f.deployStore.ListDeployedJobs(app, env.Name)
+		if err != nil {
+			return nil, fmt.Errorf("list deployed jobs in environment %s: %w", env.Name, err)
+		}
+		jobRefs, err := secretReferences(clients.taskDefs, app, env.Name, jobs, isECS)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range found {
+			secret.services = svcRefs[normalizeParameterName(secret.Name)]
+			secret.jobs = jobRefs[normalizeParameterName(secret.Name)]
+		}
+		secrets = append(secrets, found...)
+	}
+	return secrets, nil
+}
+
+// selectName prompts the user for the name of one of the secrets of the application.
+func (f *secretFinder) selectName(p prompter, app string, envs []*config.Environment, msg, help string) (string, error) {
+	secrets, err := f.find(app, envs, "")
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, secret := range secrets {
+		if seen[secret.name] {
+			continue
+		}
+		seen[secret.name] = true
+		names = append(names, secret.name)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no secrets found in application %s", app)
+	}
+	sort.Strings(names)
+	if len(names) == 1 {
+		return names[0], nil
+	}
+	name, err := p.SelectOne(msg, help, names, prompt.WithFinalMessage("Secret:"))
+	if err != nil {
+		return "", fmt.Errorf("select secret: %w", err)
+	}
+	return name, nil
+}
+
+// secretReferences returns the ECS workloads that reference each parameter in their task definitions.
+func secretReferences(describer taskDefinitionDescriber, app, env string, wls []string, isECS map[string]bool) (map[string][]string, error) {
+	refs := make(map[string][]string)
+	for _, wl := range wls {
+		if !isECS[wl] {
+			continue
+		}
+		taskDef, err := describer.TaskDefinition(app, env, wl)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, secret := range taskDef.Secrets() {
+			param := normalizeParameterName(secret.ValueFrom)
+			if seen[param] {
+				continue
+			}
+			seen[param] = true
+			refs[param] = append(refs[param], wl)
+		}
+	}
+	return refs, nil
+}
+
+// normalizeParameterName returns the parameter name with a leading slash from either a parameter name or ARN.
+func normalizeParameterName(nameOrARN string) string {
+	name := nameOrARN
+	if strings.HasPrefix(nameOrARN, "arn:") {
+		if i := strings.Index(nameOrARN, ":parameter"); i != -1 {
+			name = nameOrARN[i+len(":parameter"):]
+		}
+	}
+	return "/" + strings.TrimPrefix(name, "/")
+}
+
+// secretNameFromParameter returns the name of a secret created by "secret init" from its parameter name.
+// Other parameters keep their full name.
+func secretNameFromParameter(app, env, param string) string {
+	prefix := fmt.Sprintf(fmtSecretParameterName, app, env, "")
+	if strings.HasPrefix(param, prefix) {
+		return strings.TrimPrefix(param, prefix)
+	}
+	return param
+}
@@ -0,0 +1,200 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsssm "github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/dustin/go-humanize/english"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+)
+
+const (
+	secretDeleteAppNamePrompt     = "Which application's secret would you like to delete?"
+	secretDeleteAppNameHelpPrompt = "An application groups all of your environments together."
+	secretDeleteNamePrompt        = "Which secret would you like to delete?"
+	secretDeleteNameHelpPrompt    = "The secrets of the application in all of its environments."
+
+	fmtSecretDeleteConfirmPrompt = "Are you sure you want to delete secret %s from %s %s?"
+	secretDeleteConfirmHelp      = "This deletes the secret from SSM Parameter Store. It can't be recovered."
+)
+
+var errSecretDeleteCancelled = errors.New("secret delete cancelled - no changes made")
+
+type deleteSecretVars struct {
+	appName          string
+	name             string
+	envName          string
+	skipConfirmation bool
+}
+
+type deleteSecretOpts struct {
+	deleteSecretVars
+
+	store  store
+	sel    appSelector
+	prompt prompter
+	finder *secretFinder
+
+	referencedBy []string // Deployed workloads that reference the deleted secret.
+}
+
+func newDeleteSecretOpts(vars deleteSecretVars) (*deleteSecretOpts, error) {
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("secret delete"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), awsssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	finder, err := newSecretFinder(sessProvider, store)
+	if err != nil {
+		return nil, err
+	}
+	prompter := prompt.New()
+	return &deleteSecretOpts{
+		deleteSecretVars: vars,
+		store:            store,
+		sel:              selector.NewSelect(prompter, store),
+		prompt:           prompter,
+		finder:           finder,
+	}, nil
+}
+
+// Validate is a no-op for this command.
+func (o *deleteSecretOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *deleteSecretOpts) Ask() error {
+	if o.appName != "" {
+		if _, err := o.store.GetApplication(o.appName); err != nil {
+			return err
+		}
+	} else {
+		name, err := o.sel.Application(secretDeleteAppNamePrompt, secretDeleteAppNameHelpPrompt)
+		if err != nil {
+			return fmt.Errorf("select application name: %w", err)
+		}
+		o.appName = name
+	}
+	envs, err := secretEnvironments(o.store, o.appName, o.envName)
+	if err != nil {
+		return err
+	}
+	if o.name != "" {
+		return nil
+	}
+	name, err := o.finder.selectName(o.prompt, o.appName, envs, secretDeleteNamePrompt, secretDeleteNameHelpPrompt)
+	if err != nil {
+		return err
+	}
+	o.name = name
+	return nil
+}
+
+// Execute deletes the secret from the environments.
+func (o *deleteSecretOpts) Execute() error {
+	envs, err := secretEnvironments(o.store, o.appName, o.envName)
+	if err != nil {
+		return err
+	}
+	secrets, err := o.finder.find(o.appName, envs, o.name)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return fmt.Errorf("secret %s not found in application %s", o.name, o.appName)
+	}
+
+	var envNames []string
+	seen := make(map[string]bool)
+	for _, s := range secrets {
+		envNames = append(envNames, s.env)
+		for _, wl := range s.workloads() {
+			if !seen[wl] {
+				seen[wl] = true
+				o.referencedBy = append(o.referencedBy, wl)
+			}
+		}
+		if wls := s.workloads(); len(wls) != 0 {
+			log.Warningf("Secret %s is referenced by %s in environment %s. New tasks fail to start until the secret is removed from the manifest and redeployed.\n",
+				s.name, english.WordSeries(wls, "and"), s.env)
+		}
+	}
+	if !o.skipConfirmation {
+		confirmed, err := o.prompt.Confirm(
+			fmt.Sprintf(fmtSecretDeleteConfirmPrompt, o.name, english.PluralWord(len(envNames), "environment", "environments"), english.WordSeries(envNames, "and")),
+			secretDeleteConfirmHelp,
+			prompt.WithConfirmFinalMessage())
+		if err != nil {
+			return fmt.Errorf("confirm secret deletion: %w", err)
+		}
+		if !confirmed {
+			return errSecretDeleteCancelled
+		}
+	}
+
+	for _, s := range secrets {
+		if err := s.clients.params.DeleteSecret(s.Name); err != nil {
+			var errNotFound *ssm.ErrParameterNotFound
+			if !errors.As(err, &errNotFound) {
+				return fmt.Errorf("delete secret %s from environment %s: %w", s.name, s.env, err)
+			}
+		}
+		log.Successf("Deleted secret %s from environment %s.\n", color.HighlightUserInput(s.name), color.HighlightUserInput(s.env))
+	}
+	return nil
+}
+
+// RecommendActions returns follow-up actions the user can take after successfully executing the command.
+func (o *deleteSecretOpts) RecommendActions() error {
+	if len(o.referencedBy) == 0 {
+		return nil
+	}
+	logRecommendedActions([]string{
+		fmt.Sprintf("Remove the secret from the %s section of the manifest of %s.", color.HighlightCode("secrets"), english.WordSeries(o.referencedBy, "and")),
+		fmt.Sprintf("Run %s or %s to redeploy them.", color.HighlightCode("copilot svc deploy"), color.HighlightCode("copilot job deploy")),
+	})
+	return nil
+}
+
+// buildSecretDeleteCmd builds the command for deleting a secret.
+func buildSecretDeleteCmd() *cobra.Command {
+	vars := deleteSecretVars{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes a secret from your environments.",
+		Long:  "Deletes a secret from SSM Parameter Store in every environment of an application, or in a single environment.",
+		Example: `
+  Delete the "db_password" secret from every environment.
+  /code $ copilot secret delete -n db_password
+  Delete the "db_password" secret from the "test" environment without confirmation.
+  /code $ copilot secret delete -n db_password -e test --yes`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newDeleteSecretOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", secretDeleteNameFlagDescription)
+	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", secretDeleteEnvFlagDescription)
+	cmd.Flags().BoolVar(&vars.skipConfirmation, yesFlag, false, yesFlagDescription)
+	return cmd
+}
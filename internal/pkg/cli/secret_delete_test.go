@@ -0,0 +1,108 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+func TestDeleteSecretOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inName             string
+		inEnvName          string
+		inSkipConfirmation bool
+
+		setupMocks func(m *secretMocks)
+
+		wantedReferencedBy []string
+		wantedErr          error
+	}{
+		"error if the secret doesn't exist": {
+			inName: "orders_key",
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+			},
+			wantedErr: errors.New("secret orders_key not found in application phonetool"),
+		},
+		"returns an error if the deletion is cancelled": {
+			inName: "db_password",
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.prompt.EXPECT().Confirm("Are you sure you want to delete secret db_password from environments test and prod?", secretDeleteConfirmHelp, gomock.Any()).
+					Return(false, nil)
+				m.params["test"].EXPECT().DeleteSecret(gomock.Any()).Times(0)
+				m.params["prod"].EXPECT().DeleteSecret(gomock.Any()).Times(0)
+			},
+			wantedErr: errSecretDeleteCancelled,
+		},
+		"deletes the secret from every environment": {
+			inName: "db_password",
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.prompt.EXPECT().Confirm(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.params["test"].EXPECT().DeleteSecret("/copilot/phonetool/test/secrets/db_password").Return(nil)
+				m.params["prod"].EXPECT().DeleteSecret("/copilot/phonetool/prod/secrets/db_password").Return(&ssm.ErrParameterNotFound{})
+			},
+			wantedReferencedBy: []string{"api", "report"},
+		},
+		"deletes the secret from a single environment": {
+			inName:             "db_password",
+			inEnvName:          "prod",
+			inSkipConfirmation: true,
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "prod").Return(&config.Environment{Name: "prod"}, nil)
+				m.mockSecretsInApp()
+				m.params["prod"].EXPECT().DeleteSecret("/copilot/phonetool/prod/secrets/db_password").Return(nil)
+			},
+		},
+		"wraps the error if the secret can't be deleted": {
+			inName:             "api_key",
+			inSkipConfirmation: true,
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.params["test"].EXPECT().DeleteSecret("/copilot/phonetool/test/secrets/api_key").Return(errors.New("some error"))
+			},
+			wantedErr: errors.New("delete secret api_key from environment test: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			opts := &deleteSecretOpts{
+				deleteSecretVars: deleteSecretVars{
+					appName:          "phonetool",
+					name:             tc.inName,
+					envName:          tc.inEnvName,
+					skipConfirmation: tc.inSkipConfirmation,
+				},
+				store:  m.store,
+				prompt: m.prompt,
+				finder: m.finder(),
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedReferencedBy, opts.referencedBy)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -13,6 +14,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/secretsmanager"
 
 	"github.com/dustin/go-humanize/english"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
 	"github.com/aws/copilot-cli/internal/pkg/config"
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
@@ -37,8 +40,21 @@ import (
 const (
 	fmtSecretParameterName           = "/copilot/%s/%s/secrets/%s"
 	fmtSecretParameterNameMftExample = "/copilot/${COPILOT_APPLICATION_NAME}/${COPILOT_ENVIRONMENT_NAME}/secrets/%s"
+
+	fmtSecretsManagerSecretName           = "copilot/%s/%s/secrets/%s"
+	fmtSecretsManagerSecretNameMftExample = "copilot/${COPILOT_APPLICATION_NAME}/${COPILOT_ENVIRONMENT_NAME}/secrets/%s"
+)
+
+// Backends where secrets can be stored.
+const (
+	secretStoreSSM            = "ssm"
+	secretStoreSecretsManager = "secretsmanager"
 )
 
+var secretStores = []string{secretStoreSSM, secretStoreSecretsManager}
+
+const defaultSecretRotationDays = 30
+
 const (
 	secretInitAppPrompt     = "Which application do you want to add the secret to?"
 	secretInitAppPromptHelp = "The secret can then be versioned by your existing environments inside the application."
@@ -57,12 +73,17 @@ type secretInitVars struct {
 	values        map[string]string
 	inputFilePath string
 	overwrite     bool
+	secretStore   string
+
+	rotationLambdas map[string]string
+	rotationDays    int
 }
 
 type secretInitOpts struct {
 	secretInitVars
 
 	secretValues map[string]map[string]string
+	jsonSecrets  map[string]bool // Secrets whose values are JSON key/value pairs.
 
 	store store
 	fs    afero.Fs
@@ -72,8 +93,9 @@ type secretInitOpts struct {
 
 	shouldShowOverwriteHint bool
 
-	envUpgradeCMDs map[string]actionCommand
-	secretPutters  map[string]secretPutter
+	envUpgradeCMDs        map[string]actionCommand
+	secretPutters         map[string]secretPutter
+	secretsManagerPutters map[string]secretsManagerPutter
 
 	configureClientsForEnv func(envName string) error
 	readFile               func() ([]byte, error)
@@ -93,8 +115,9 @@ func newSecretInitOpts(vars secretInitVars) (*secretInitOpts, error) {
 		store:          store,
 		fs:             &afero.Afero{Fs: afero.NewOsFs()},
 
-		envUpgradeCMDs: make(map[string]actionCommand),
-		secretPutters:  make(map[string]secretPutter),
+		envUpgradeCMDs:        make(map[string]actionCommand),
+		secretPutters:         make(map[string]secretPutter),
+		secretsManagerPutters: make(map[string]secretsManagerPutter),
 
 		prompter: prompter,
 		selector: selector.NewSelect(prompter, store),
@@ -119,6 +142,7 @@ func newSecretInitOpts(vars secretInitVars) (*secretInitOpts, error) {
 			return fmt.Errorf("create session from environment manager role %s in region %s: %w", env.ManagerRoleARN, env.Region, err)
 		}
 		opts.secretPutters[envName] = ssm.New(sess)
+		opts.secretsManagerPutters[envName] = secretsmanager.New(sess)
 
 		return nil
 	}
@@ -149,6 +173,10 @@ func (o *secretInitOpts) Validate() error {
 		return errors.New("cannot specify `--cli-input-yaml` with `--values`")
 	}
 
+	if err := o.validateSecretStore(); err != nil {
+		return err
+	}
+
 	if o.appName != "" {
 		_, err := o.store.GetApplication(o.appName)
 		if err != nil {
@@ -161,6 +189,11 @@ func (o *secretInitOpts) Validate() error {
 				}
 			}
 		}
+		for env := range o.rotationLambdas {
+			if _, err := o.targetEnv(env); err != nil {
+				return err
+			}
+		}
 	}
 
 	if o.name != "" {
@@ -177,6 +210,23 @@ func (o *secretInitOpts) Validate() error {
 	return nil
 }
 
+func (o *secretInitOpts) validateSecretStore() error {
+	switch o.secretStore {
+	case "", secretStoreSSM:
+		if o.rotationLambdas != nil {
+			return fmt.Errorf("`--%s` requires `--%s %s`", rotationLambdasFlag, secretStoreFlag, secretStoreSecretsManager)
+		}
+		return nil
+	case secretStoreSecretsManager:
+		if o.rotationLambdas != nil && o.rotationDays <= 0 {
+			return fmt.Errorf("`--%s` must be a positive number of days", rotationDaysFlag)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid secret store %s: must be one of %s", o.secretStore, english.WordSeries(template.QuoteSliceFunc(secretStores), "or"))
+	}
+}
+
 // Ask prompts the user for any required or important fields that are not provided.
 func (o *secretInitOpts) Ask() error {
 	if o.overwrite {
@@ -271,7 +321,11 @@ func (o *secretInitOpts) putSecret(secretName string, values map[string]string)
 
 	errorsForEnvironments := make(map[string]error)
 	for envName, value := range values {
-		err := o.putSecretInEnv(secretName, envName, value)
+		put := o.putSecretInEnv
+		if o.secretStore == secretStoreSecretsManager {
+			put = o.putSecretsManagerSecretInEnv
+		}
+		err := put(secretName, envName, value)
 		if err != nil {
 			errorsForEnvironments[envName] = err
 			continue
@@ -325,6 +379,68 @@ func (o *secretInitOpts) putSecretInEnv(secretName, envName, value string) error
 	return nil
 }
 
+func (o *secretInitOpts) putSecretsManagerSecretInEnv(secretName, envName, value string) error {
+	name := fmt.Sprintf(fmtSecretsManagerSecretName, o.appName, envName, secretName)
+	putter := o.secretsManagerPutters[envName]
+	out, err := putter.PutSecret(secretsmanager.PutSecretInput{
+		Name:      name,
+		Value:     value,
+		Overwrite: o.overwrite,
+		Tags: map[string]string{
+			deploy.AppTagKey: o.appName,
+			deploy.EnvTagKey: envName,
+		},
+	})
+	if err != nil {
+		var targetErr *secretsmanager.ErrSecretAlreadyExists
+		if errors.As(err, &targetErr) {
+			o.shouldShowOverwriteHint = true
+			log.Successf("Secret %s already exists in environment %s as %s. Did not overwrite. \n", color.HighlightUserInput(secretName), color.HighlightUserInput(envName), color.HighlightResource(name))
+			return nil
+		}
+		return err
+	}
+	if out.Overwritten {
+		log.Successln(fmt.Sprintf("Secret %s already exists in environment %s. Overwritten.", name, color.HighlightUserInput(envName)))
+	} else {
+		log.Successln(fmt.Sprintf("Successfully put secret %s in environment %s as %s.", color.HighlightUserInput(secretName), color.HighlightUserInput(envName), color.HighlightResource(name)))
+	}
+
+	lambdaARN, ok := o.rotationLambdas[envName]
+	if !ok {
+		return nil
+	}
+	if err := putter.RotateSecret(name, lambdaARN, int64(o.rotationDays)); err != nil {
+		return err
+	}
+	log.Successln(fmt.Sprintf("Configured secret %s in environment %s to rotate every %d days.", color.HighlightUserInput(secretName), color.HighlightUserInput(envName), o.rotationDays))
+	return nil
+}
+
+// secretInputValue is the value of a secret in an environment in the input file.
+// It is either a string, or key/value pairs that are stored as a JSON object.
+type secretInputValue struct {
+	value  string
+	isJSON bool
+}
+
+// UnmarshalYAML implements the yaml(v3) interface. It marshals key/value pairs to a JSON string.
+func (v *secretInputValue) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return value.Decode(&v.value)
+	}
+	var pairs map[string]string
+	if err := value.Decode(&pairs); err != nil {
+		return err
+	}
+	data, err := json.Marshal(pairs)
+	if err != nil {
+		return fmt.Errorf("marshal key/value pairs to JSON: %w", err)
+	}
+	v.value, v.isJSON = string(data), true
+	return nil
+}
+
 func (o *secretInitOpts) parseSecretsInputFile() (map[string]map[string]string, error) {
 	raw, err := o.readFile()
 	if err != nil {
@@ -332,13 +448,29 @@ func (o *secretInitOpts) parseSecretsInputFile() (map[string]map[string]string,
 	}
 
 	type inputFile struct {
-		Secrets map[string]map[string]string `yaml:",inline"`
+		Secrets map[string]map[string]secretInputValue `yaml:",inline"`
 	}
 	var f inputFile
 	if err := yaml.Unmarshal(raw, &f); err != nil {
 		return nil, fmt.Errorf("unmarshal input file: %w", err)
 	}
-	return f.Secrets, nil
+	secrets := make(map[string]map[string]string, len(f.Secrets))
+	for name, envValues := range f.Secrets {
+		secrets[name] = make(map[string]string, len(envValues))
+		for env, v := range envValues {
+			if v.isJSON {
+				if o.secretStore != secretStoreSecretsManager {
+					return nil, fmt.Errorf("key/value pairs of secret %s in environment %s require `--%s %s`", name, env, secretStoreFlag, secretStoreSecretsManager)
+				}
+				if o.jsonSecrets == nil {
+					o.jsonSecrets = make(map[string]bool)
+				}
+				o.jsonSecrets[name] = true
+			}
+			secrets[name][env] = v.value
+		}
+	}
+	return secrets, nil
 }
 
 func (o *secretInitOpts) askForAppName() error {
@@ -412,6 +544,13 @@ func (o *secretInitOpts) RecommendActions() error {
 	secretsManifestExample := "secrets:"
 	for secretName := range o.secretValues {
 		currSecret := fmt.Sprintf("%s: %s", secretName, fmt.Sprintf(fmtSecretParameterNameMftExample, secretName))
+		if o.secretStore == secretStoreSecretsManager {
+			smName := fmt.Sprintf(fmtSecretsManagerSecretNameMftExample, secretName)
+			if o.jsonSecrets[secretName] {
+				smName = fmt.Sprintf("%s:<key>", smName)
+			}
+			currSecret = fmt.Sprintf("%s:\n      secretsmanager: %s", secretName, smName)
+		}
 		secretsManifestExample = fmt.Sprintf("%s\n%s", secretsManifestExample, fmt.Sprintf("    %s", currSecret))
 	}
 
@@ -474,14 +613,16 @@ func buildSecretInitCmd() *cobra.Command {
 	vars := secretInitVars{}
 	cmd := &cobra.Command{
 		Use:   "init",
-		Short: "Create or update secrets in SSM Parameter Store.",
+		Short: "Create or update secrets in SSM Parameter Store or AWS Secrets Manager.",
 		Example: `
 Create a secret with prompts. 
 /code $ copilot secret init
 Create a secret named db-password in multiple environments.
 /code $ copilot secret init --name db-password
 Create secrets from input.yml. For the format of the YAML file, please see https://aws.github.io/copilot-cli/docs/commands/secret-init/.
-/code $ copilot secret init --cli-input-yaml input.yml`,
+/code $ copilot secret init --cli-input-yaml input.yml
+Create a secret in AWS Secrets Manager that is rotated every 7 days in the prod environment.
+/code $ copilot secret init --name db-creds --store secretsmanager --rotation-lambdas prod=arn:aws:lambda:us-west-2:123456789012:function:rotate --rotation-days 7`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newSecretInitOpts(vars)
 			if err != nil {
@@ -514,5 +655,8 @@ Create secrets from input.yml. For the format of the YAML file, please see https
 	cmd.Flags().StringToStringVar(&vars.values, valuesFlag, nil, secretValuesFlagDescription)
 	cmd.Flags().BoolVar(&vars.overwrite, overwriteFlag, false, secretOverwriteFlagDescription)
 	cmd.Flags().StringVar(&vars.inputFilePath, inputFilePathFlag, "", secretInputFilePathFlagDescription)
+	cmd.Flags().StringVar(&vars.secretStore, secretStoreFlag, secretStoreSSM, secretStoreFlagDescription)
+	cmd.Flags().StringToStringVar(&vars.rotationLambdas, rotationLambdasFlag, nil, secretRotationLambdasFlagDescription)
+	cmd.Flags().IntVar(&vars.rotationDays, rotationDaysFlag, defaultSecretRotationDays, secretRotationDaysFlagDescription)
 	return cmd
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/aws/copilot-cli/internal/pkg/config"
 
+	"github.com/aws/copilot-cli/internal/pkg/aws/secretsmanager"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
 	"github.com/golang/mock/gomock"
@@ -34,6 +35,9 @@ func TestSecretInitOpts_Validate(t *testing.T) {
 		inValues        map[string]string
 		inOverwrite     bool
 		inInputFilePath string
+		inStore         string
+		inRotation      map[string]string
+		inRotationDays  int
 
 		setupMocks func(m secretInitMocks)
 
@@ -109,6 +113,40 @@ func TestSecretInitOpts_Validate(t *testing.T) {
 			setupMocks:      func(m secretInitMocks) {},
 			wantedError:     errors.New("cannot specify `--cli-input-yaml` with `--values`"),
 		},
+		"error if the secret store is invalid": {
+			inStore:     "vault",
+			setupMocks:  func(m secretInitMocks) {},
+			wantedError: errors.New(`invalid secret store vault: must be one of "ssm" or "secretsmanager"`),
+		},
+		"error if rotation lambdas are specified with ssm": {
+			inStore: "ssm",
+			inRotation: map[string]string{
+				"prod": "arn:aws:lambda:us-west-2:123456789012:function:rotate",
+			},
+			setupMocks:  func(m secretInitMocks) {},
+			wantedError: errors.New("`--rotation-lambdas` requires `--store secretsmanager`"),
+		},
+		"error if the rotation days are not positive": {
+			inStore: "secretsmanager",
+			inRotation: map[string]string{
+				"prod": "arn:aws:lambda:us-west-2:123456789012:function:rotate",
+			},
+			setupMocks:  func(m secretInitMocks) {},
+			wantedError: errors.New("`--rotation-days` must be a positive number of days"),
+		},
+		"error if the environment of a rotation lambda does not exist": {
+			inApp:   "dragon_slaying",
+			inStore: "secretsmanager",
+			inRotation: map[string]string{
+				"prod": "arn:aws:lambda:us-west-2:123456789012:function:rotate",
+			},
+			inRotationDays: 30,
+			setupMocks: func(m secretInitMocks) {
+				m.mockStore.EXPECT().GetApplication("dragon_slaying").Return(&config.Application{}, nil)
+				m.mockStore.EXPECT().GetEnvironment("dragon_slaying", "prod").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("get environment prod in application dragon_slaying: some error"),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -125,6 +163,10 @@ func TestSecretInitOpts_Validate(t *testing.T) {
 					values:        tc.inValues,
 					inputFilePath: tc.inInputFilePath,
 					overwrite:     tc.inOverwrite,
+					secretStore:   tc.inStore,
+
+					rotationLambdas: tc.inRotation,
+					rotationDays:    tc.inRotationDays,
 				},
 				fs:    &afero.Afero{Fs: afero.NewMemMapFs()},
 				store: mockStore,
@@ -343,6 +385,7 @@ func TestSecretInitOpts_Ask(t *testing.T) {
 type secretInitExecuteMocks struct {
 	mockStore        *mocks.Mockstore
 	mockSecretPutter *mocks.MocksecretPutter
+	mockSMPutter     *mocks.MocksecretsManagerPutter
 	mockEnvUpgrader  *mocks.MockactionCommand
 }
 
@@ -364,6 +407,8 @@ func TestSecretInitOpts_Execute(t *testing.T) {
 		inInputFilePath string
 
 		inOverwrite bool
+		inStore     string
+		inRotation  map[string]string
 
 		mockInputFileContent []byte
 		setupMocks           func(m secretInitExecuteMocks)
@@ -557,6 +602,75 @@ db-host:
 				},
 			},
 		},
+		"successfully create key/value secrets in Secrets Manager with rotation": {
+			inAppName:       testApp,
+			inInputFilePath: "some/file",
+			inStore:         secretStoreSecretsManager,
+			inRotation: map[string]string{
+				"prod": "arn:aws:lambda:us-west-2:123456789012:function:rotate",
+			},
+
+			mockInputFileContent: []byte(`db-creds:
+    test:
+      username: admin
+      password: test-password
+    prod: '{"username":"admin","password":"prod-password"}'`),
+			setupMocks: func(m secretInitExecuteMocks) {
+				m.mockSMPutter.EXPECT().PutSecret(secretsmanager.PutSecretInput{
+					Name:  "copilot/test-app/test/secrets/db-creds",
+					Value: `{"password":"test-password","username":"admin"}`,
+					Tags: map[string]string{
+						deploy.AppTagKey: "test-app",
+						deploy.EnvTagKey: "test",
+					},
+				}).Return(&secretsmanager.PutSecretOutput{}, nil)
+				m.mockSMPutter.EXPECT().PutSecret(secretsmanager.PutSecretInput{
+					Name:  "copilot/test-app/prod/secrets/db-creds",
+					Value: `{"username":"admin","password":"prod-password"}`,
+					Tags: map[string]string{
+						deploy.AppTagKey: "test-app",
+						deploy.EnvTagKey: "prod",
+					},
+				}).Return(&secretsmanager.PutSecretOutput{}, nil)
+				m.mockSMPutter.EXPECT().RotateSecret("copilot/test-app/prod/secrets/db-creds", "arn:aws:lambda:us-west-2:123456789012:function:rotate", int64(30)).Return(nil)
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil).Times(2)
+			},
+		},
+		"do not overwrite or rotate an existing Secrets Manager secret": {
+			inAppName: testApp,
+			inName:    testName,
+			inValues: map[string]string{
+				"prod": "prod-password",
+			},
+			inStore: secretStoreSecretsManager,
+			inRotation: map[string]string{
+				"prod": "arn:aws:lambda:us-west-2:123456789012:function:rotate",
+			},
+
+			setupMocks: func(m secretInitExecuteMocks) {
+				m.mockSMPutter.EXPECT().PutSecret(gomock.Any()).Return(nil, &secretsmanager.ErrSecretAlreadyExists{})
+				m.mockSMPutter.EXPECT().RotateSecret(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
+			},
+		},
+		"wrap the error if the rotation of a Secrets Manager secret fails": {
+			inAppName: testApp,
+			inName:    testName,
+			inValues: map[string]string{
+				"prod": "prod-password",
+			},
+			inStore: secretStoreSecretsManager,
+			inRotation: map[string]string{
+				"prod": "arn:aws:lambda:us-west-2:123456789012:function:rotate",
+			},
+
+			setupMocks: func(m secretInitExecuteMocks) {
+				m.mockSMPutter.EXPECT().PutSecret(gomock.Any()).Return(&secretsmanager.PutSecretOutput{}, nil)
+				m.mockSMPutter.EXPECT().RotateSecret(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
+			},
+			wantedError: errors.New("put secret db-password in environment prod: some error"),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -567,6 +681,7 @@ db-host:
 			m := secretInitExecuteMocks{
 				mockStore:        mocks.NewMockstore(ctrl),
 				mockSecretPutter: mocks.NewMocksecretPutter(ctrl),
+				mockSMPutter:     mocks.NewMocksecretsManagerPutter(ctrl),
 				mockEnvUpgrader:  mocks.NewMockactionCommand(ctrl),
 			}
 			tc.setupMocks(m)
@@ -578,11 +693,16 @@ db-host:
 					values:        tc.inValues,
 					overwrite:     tc.inOverwrite,
 					inputFilePath: tc.inInputFilePath,
+					secretStore:   tc.inStore,
+
+					rotationLambdas: tc.inRotation,
+					rotationDays:    defaultSecretRotationDays,
 				},
 				store: m.mockStore,
 
-				secretPutters:  make(map[string]secretPutter),
-				envUpgradeCMDs: make(map[string]actionCommand),
+				secretPutters:         make(map[string]secretPutter),
+				secretsManagerPutters: make(map[string]secretsManagerPutter),
+				envUpgradeCMDs:        make(map[string]actionCommand),
 				readFile: func() ([]byte, error) {
 					return tc.mockInputFileContent, nil
 				},
@@ -590,6 +710,7 @@ db-host:
 
 			opts.configureClientsForEnv = func(envName string) error {
 				opts.secretPutters[envName] = m.mockSecretPutter
+				opts.secretsManagerPutters[envName] = m.mockSMPutter
 				opts.envUpgradeCMDs[envName] = m.mockEnvUpgrader
 				return nil
 			}
@@ -638,4 +759,47 @@ test-only-secret:
 		require.NoError(t, err)
 		require.Equal(t, expected, secrets)
 	})
+
+	t.Run("key/value pairs are marshaled to JSON for Secrets Manager", func(t *testing.T) {
+		opts := secretInitOpts{
+			secretInitVars: secretInitVars{
+				secretStore: secretStoreSecretsManager,
+			},
+			readFile: func() ([]byte, error) {
+				raw := `db-creds:
+    test:
+      username: admin
+      password: test-password
+db-host:
+    test: test-host`
+				return []byte(raw), nil
+			},
+		}
+
+		secrets, err := opts.parseSecretsInputFile()
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string]string{
+			"db-creds": {
+				"test": `{"password":"test-password","username":"admin"}`,
+			},
+			"db-host": {
+				"test": "test-host",
+			},
+		}, secrets)
+		require.Equal(t, map[string]bool{"db-creds": true}, opts.jsonSecrets)
+	})
+
+	t.Run("key/value pairs require Secrets Manager", func(t *testing.T) {
+		opts := secretInitOpts{
+			readFile: func() ([]byte, error) {
+				raw := `db-creds:
+    test:
+      username: admin`
+				return []byte(raw), nil
+			},
+		}
+
+		_, err := opts.parseSecretsInputFile()
+		require.EqualError(t, err, "key/value pairs of secret db-creds in environment test require `--store secretsmanager`")
+	})
 }
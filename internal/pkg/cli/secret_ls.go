@@ -0,0 +1,195 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+)
+
+const (
+	secretListAppNamePrompt     = "Which application's secrets would you like to list?"
+	secretListAppNameHelpPrompt = "An application groups all of your environments together."
+)
+
+type listSecretVars struct {
+	appName          string
+	envName          string
+	shouldOutputJSON bool
+}
+
+type listSecretOpts struct {
+	listSecretVars
+
+	w      io.Writer
+	store  store
+	sel    appSelector
+	finder *secretFinder
+}
+
+func newListSecretOpts(vars listSecretVars) (*listSecretOpts, error) {
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("secret ls"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	finder, err := newSecretFinder(sessProvider, store)
+	if err != nil {
+		return nil, err
+	}
+	return &listSecretOpts{
+		listSecretVars: vars,
+		w:              log.OutputWriter,
+		store:          store,
+		sel:            selector.NewSelect(prompt.New(), store),
+		finder:         finder,
+	}, nil
+}
+
+// Validate is a no-op for this command.
+func (o *listSecretOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *listSecretOpts) Ask() error {
+	if o.appName != "" {
+		if _, err := o.store.GetApplication(o.appName); err != nil {
+			return err
+		}
+	} else {
+		name, err := o.sel.Application(secretListAppNamePrompt, secretListAppNameHelpPrompt)
+		if err != nil {
+			return fmt.Errorf("select application name: %w", err)
+		}
+		o.appName = name
+	}
+	if o.envName != "" {
+		if _, err := o.store.GetEnvironment(o.appName, o.envName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type secretListing struct {
+	Name          string    `json:"name"`
+	Environment   string    `json:"environment"`
+	ParameterName string    `json:"parameterName"`
+	Version       int64     `json:"version"`
+	LastModified  time.Time `json:"lastModified"`
+	ReferencedBy  []string  `json:"referencedBy"`
+}
+
+// Execute lists the secrets of the application and the deployed workloads that reference them.
+func (o *listSecretOpts) Execute() error {
+	envs, err := secretEnvironments(o.store, o.appName, o.envName)
+	if err != nil {
+		return err
+	}
+	secrets, err := o.finder.find(o.appName, envs, "")
+	if err != nil {
+		return err
+	}
+	// Group the secrets by name, keeping the order of the environments.
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return secrets[i].name < secrets[j].name
+	})
+	listings := make([]secretListing, 0, len(secrets))
+	for _, s := range secrets {
+		listings = append(listings, secretListing{
+			Name:          s.name,
+			Environment:   s.env,
+			ParameterName: s.Name,
+			Version:       s.Version,
+			LastModified:  s.LastModified,
+			ReferencedBy:  s.workloads(),
+		})
+	}
+
+	if o.shouldOutputJSON {
+		data, err := json.Marshal(struct {
+			Secrets []secretListing `json:"secrets"`
+		}{
+			Secrets: listings,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal secrets: %w", err)
+		}
+		fmt.Fprintf(o.w, "%s\n", data)
+		return nil
+	}
+	if len(listings) == 0 {
+		log.Infof("No secrets found in application %s. Run %s to add one.\n", o.appName, color.HighlightCode("copilot secret init"))
+		return nil
+	}
+	writer := tabwriter.NewWriter(o.w, historyMinCellWidth, historyTabWidth, historyCellPaddingWidth, historyPaddingChar, 0)
+	headers := []string{"Name", "Environment", "Version", "Last Modified", "Referenced By"}
+	fmt.Fprintf(writer, "%s\n", strings.Join(headers, "\t"))
+	fmt.Fprintf(writer, "%s\n", strings.Join(underlineHeaders(headers), "\t"))
+	for _, l := range listings {
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\n", l.Name, l.Environment, l.Version, l.LastModified.Format(time.RFC3339), valueOrDash(strings.Join(l.ReferencedBy, ", ")))
+	}
+	return writer.Flush()
+}
+
+// secretEnvironments returns the environment if its name is not empty, otherwise every environment of the application.
+func secretEnvironments(store store, app, env string) ([]*config.Environment, error) {
+	if env != "" {
+		e, err := store.GetEnvironment(app, env)
+		if err != nil {
+			return nil, fmt.Errorf("get environment %s in application %s: %w", env, app, err)
+		}
+		return []*config.Environment{e}, nil
+	}
+	envs, err := store.ListEnvironments(app)
+	if err != nil {
+		return nil, fmt.Errorf("list environments in application %s: %w", app, err)
+	}
+	return envs, nil
+}
+
+// buildSecretListCmd builds the command for listing the secrets of an application.
+func buildSecretListCmd() *cobra.Command {
+	vars := listSecretVars{}
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "Lists the secrets of an application.",
+		Long:  "Lists the secrets of an application, the environments they are in and the deployed workloads that reference them.",
+		Example: `
+  Lists the secrets of the "my-app" application.
+  /code $ copilot secret ls -a my-app
+  Lists the secrets in the "test" environment in JSON format.
+  /code $ copilot secret ls -e test --json`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newListSecretOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", secretListEnvFlagDescription)
+	cmd.Flags().BoolVar(&vars.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	return cmd
+}
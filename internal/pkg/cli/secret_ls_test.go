@@ -0,0 +1,149 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+func TestListSecretOpts_Ask(t *testing.T) {
+	testCases := map[string]struct {
+		inAppName string
+		inEnvName string
+
+		setupMocks func(m *secretMocks)
+
+		wantedAppName string
+		wantedErr     error
+	}{
+		"prompts for the application name": {
+			setupMocks: func(m *secretMocks) {
+				m.sel.EXPECT().Application(secretListAppNamePrompt, secretListAppNameHelpPrompt).Return("phonetool", nil)
+			},
+			wantedAppName: "phonetool",
+		},
+		"wraps the error if the application can't be selected": {
+			setupMocks: func(m *secretMocks) {
+				m.sel.EXPECT().Application(gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+			},
+			wantedErr: errors.New("select application name: some error"),
+		},
+		"validates the environment": {
+			inAppName: "phonetool",
+			inEnvName: "test",
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "test").Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			opts := &listSecretOpts{
+				listSecretVars: listSecretVars{
+					appName: tc.inAppName,
+					envName: tc.inEnvName,
+				},
+				store: m.store,
+				sel:   m.sel,
+			}
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedAppName, opts.appName)
+		})
+	}
+}
+
+func TestListSecretOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inEnvName string
+		inJSON    bool
+
+		setupMocks func(m *secretMocks)
+
+		wantedContent string
+		wantedErr     error
+	}{
+		"wraps the error if the environments can't be listed": {
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("list environments in application phonetool: some error"),
+		},
+		"writes a table of the secrets grouped by name": {
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+			},
+			wantedContent: `Name         Environment  Version     Last Modified         Referenced By
+----         -----------  -------     -------------         -------------
+api_key      test         1           2022-03-01T10:00:00Z  -
+db_password  test         3           2022-03-01T10:00:00Z  api, report
+db_password  prod         1           2022-03-01T10:00:00Z  -
+`,
+		},
+		"writes the secrets of an environment in JSON format": {
+			inEnvName: "prod",
+			inJSON:    true,
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetEnvironment("phonetool", "prod").Return(&config.Environment{Name: "prod"}, nil)
+				m.mockSecretsInApp()
+			},
+			wantedContent: `{"secrets":[{"name":"db_password","environment":"prod","parameterName":"/copilot/phonetool/prod/secrets/db_password","version":1,"lastModified":"2022-03-01T10:00:00Z","referencedBy":[]}]}` + "\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			buf := new(bytes.Buffer)
+			opts := &listSecretOpts{
+				listSecretVars: listSecretVars{
+					appName:          "phonetool",
+					envName:          tc.inEnvName,
+					shouldOutputJSON: tc.inJSON,
+				},
+				w:      buf,
+				store:  m.store,
+				finder: m.finder(),
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, buf.String())
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsssm "github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/dustin/go-humanize/english"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+)
+
+const (
+	secretRotateAppNamePrompt     = "Which application's secret would you like to rotate?"
+	secretRotateAppNameHelpPrompt = "An application groups all of your environments together."
+	secretRotateNamePrompt        = "Which secret would you like to rotate?"
+	secretRotateNameHelpPrompt    = "The secrets of the application in all of its environments."
+
+	fmtSecretRotateValuePrompt     = "What is the new value of secret %s in environment %s?"
+	fmtSecretRotateValuePromptHelp = "If you do not wish to rotate the secret %s in environment %s, you can leave this blank by pressing 'Enter' without entering any value."
+)
+
+type rotateSecretVars struct {
+	appName string
+	name    string
+	values  map[string]string
+}
+
+type rotateSecretOpts struct {
+	rotateSecretVars
+
+	store  store
+	sel    appSelector
+	prompt prompter
+	finder *secretFinder
+}
+
+func newRotateSecretOpts(vars rotateSecretVars) (*rotateSecretOpts, error) {
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("secret rotate"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), awsssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	finder, err := newSecretFinder(sessProvider, store)
+	if err != nil {
+		return nil, err
+	}
+	prompter := prompt.New()
+	return &rotateSecretOpts{
+		rotateSecretVars: vars,
+		store:            store,
+		sel:              selector.NewSelect(prompter, store),
+		prompt:           prompter,
+		finder:           finder,
+	}, nil
+}
+
+// Validate is a no-op for this command.
+func (o *rotateSecretOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *rotateSecretOpts) Ask() error {
+	if o.appName != "" {
+		if _, err := o.store.GetApplication(o.appName); err != nil {
+			return err
+		}
+	} else {
+		name, err := o.sel.Application(secretRotateAppNamePrompt, secretRotateAppNameHelpPrompt)
+		if err != nil {
+			return fmt.Errorf("select application name: %w", err)
+		}
+		o.appName = name
+	}
+	for env := range o.values {
+		if _, err := o.store.GetEnvironment(o.appName, env); err != nil {
+			return fmt.Errorf("get environment %s in application %s: %w", env, o.appName, err)
+		}
+	}
+	envs, err := secretEnvironments(o.store, o.appName, "")
+	if err != nil {
+		return err
+	}
+	if o.name == "" {
+		name, err := o.finder.selectName(o.prompt, o.appName, envs, secretRotateNamePrompt, secretRotateNameHelpPrompt)
+		if err != nil {
+			return err
+		}
+		o.name = name
+	}
+	if o.values != nil {
+		return nil
+	}
+	secrets, err := o.finder.find(o.appName, envs, o.name)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return fmt.Errorf("secret %s not found in application %s", o.name, o.appName)
+	}
+	values := make(map[string]string)
+	for _, s := range secrets {
+		value, err := o.prompt.GetSecret(
+			fmt.Sprintf(fmtSecretRotateValuePrompt, color.HighlightUserInput(o.name), s.env),
+			fmt.Sprintf(fmtSecretRotateValuePromptHelp, color.HighlightUserInput(o.name), s.env),
+			prompt.WithFinalMessage(fmt.Sprintf("New %s secret value:", s.env)),
+		)
+		if err != nil {
+			return fmt.Errorf("get new value of secret %s in environment %s: %w", o.name, s.env, err)
+		}
+		if value != "" {
+			values[s.env] = value
+		}
+	}
+	o.values = values
+	return nil
+}
+
+// Execute updates the values of the secret and forces a new deployment of the services that reference it.
+func (o *rotateSecretOpts) Execute() error {
+	if len(o.values) == 0 {
+		log.Infof("No new value for secret %s. No changes made.\n", color.HighlightUserInput(o.name))
+		return nil
+	}
+	envs, err := secretEnvironments(o.store, o.appName, "")
+	if err != nil {
+		return err
+	}
+	secrets, err := o.finder.find(o.appName, envs, o.name)
+	if err != nil {
+		return err
+	}
+	secretIn := make(map[string]*envSecret)
+	for _, s := range secrets {
+		secretIn[s.env] = s
+	}
+	for env := range o.values {
+		if _, ok := secretIn[env]; !ok {
+			return fmt.Errorf("secret %s does not exist in environment %s, run %s to create it", o.name, env, color.HighlightCode("copilot secret init"))
+		}
+	}
+
+	for _, s := range secrets {
+		value, ok := o.values[s.env]
+		if !ok {
+			continue
+		}
+		out, err := s.clients.params.PutSecret(ssm.PutSecretInput{
+			Name:      s.Name,
+			Value:     value,
+			Overwrite: true,
+			Tags: map[string]string{
+				deploy.AppTagKey: o.appName,
+				deploy.EnvTagKey: s.env,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("rotate secret %s in environment %s: %w", s.name, s.env, err)
+		}
+		log.Successf("Rotated secret %s in environment %s to version %d.\n", color.HighlightUserInput(s.name), color.HighlightUserInput(s.env), aws.Int64Value(out.Version))
+		for _, svc := range s.services {
+			if err := s.clients.services.ForceUpdateService(o.appName, s.env, svc); err != nil {
+				return fmt.Errorf("force a new deployment of service %s in environment %s: %w", svc, s.env, err)
+			}
+			log.Successf("Forced a new deployment of service %s in environment %s.\n", color.HighlightUserInput(svc), color.HighlightUserInput(s.env))
+		}
+		if len(s.jobs) != 0 {
+			log.Infof("The new value is used the next time %s %s in environment %s.\n",
+				english.WordSeries(s.jobs, "and"), english.PluralWord(len(s.jobs), "runs", "run"), s.env)
+		}
+	}
+	return nil
+}
+
+// buildSecretRotateCmd builds the command for rotating a secret.
+func buildSecretRotateCmd() *cobra.Command {
+	vars := rotateSecretVars{}
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Updates the value of a secret and redeploys the services that use it.",
+		Long: `Updates the value of a secret in SSM Parameter Store.
+The deployed services that reference the secret are redeployed to pick up the new value.`,
+		Example: `
+  Rotate the "db_password" secret, and enter the new value for each environment.
+  /code $ copilot secret rotate -n db_password
+  Rotate the "db_password" secret in the "test" environment only.
+  /code $ copilot secret rotate -n db_password --values test=newpassword`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newRotateSecretOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", secretRotateNameFlagDescription)
+	cmd.Flags().StringToStringVar(&vars.values, valuesFlag, nil, secretRotateValuesFlagDescription)
+	return cmd
+}
@@ -0,0 +1,159 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+func TestRotateSecretOpts_Ask(t *testing.T) {
+	testCases := map[string]struct {
+		inValues map[string]string
+
+		setupMocks func(m *secretMocks)
+
+		wantedValues map[string]string
+		wantedErr    error
+	}{
+		"validates the environments of the values": {
+			inValues: map[string]string{"staging": "hunter2"},
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "staging").Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("get environment staging in application phonetool: some error"),
+		},
+		"prompts for a new value in each environment the secret exists in": {
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.mockSecretsInApp()
+				m.prompt.EXPECT().GetSecret("What is the new value of secret db_password in environment test?", gomock.Any(), gomock.Any()).Return("hunter2", nil)
+				m.prompt.EXPECT().GetSecret("What is the new value of secret db_password in environment prod?", gomock.Any(), gomock.Any()).Return("", nil)
+			},
+			wantedValues: map[string]string{"test": "hunter2"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			opts := &rotateSecretOpts{
+				rotateSecretVars: rotateSecretVars{
+					appName: "phonetool",
+					name:    "db_password",
+					values:  tc.inValues,
+				},
+				store:  m.store,
+				sel:    m.sel,
+				prompt: m.prompt,
+				finder: m.finder(),
+			}
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedValues, opts.values)
+		})
+	}
+}
+
+func TestRotateSecretOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inName   string
+		inValues map[string]string
+
+		setupMocks func(m *secretMocks)
+
+		wantedErr error
+	}{
+		"no-op without new values": {
+			inName:     "db_password",
+			inValues:   map[string]string{},
+			setupMocks: func(m *secretMocks) {},
+		},
+		"error if the secret doesn't exist in an environment": {
+			inName:   "api_key",
+			inValues: map[string]string{"prod": "hunter2"},
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+			},
+			wantedErr: errors.New("secret api_key does not exist in environment prod, run `copilot secret init` to create it"),
+		},
+		"updates the secret and redeploys the services that reference it": {
+			inName:   "db_password",
+			inValues: map[string]string{"test": "hunter2", "prod": "hunter3"},
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.params["test"].EXPECT().PutSecret(ssm.PutSecretInput{
+					Name:      "/copilot/phonetool/test/secrets/db_password",
+					Value:     "hunter2",
+					Overwrite: true,
+					Tags: map[string]string{
+						"copilot-application": "phonetool",
+						"copilot-environment": "test",
+					},
+				}).Return(&ssm.PutSecretOutput{Version: aws.Int64(4)}, nil)
+				m.services.EXPECT().ForceUpdateService("phonetool", "test", "api").Return(nil)
+				m.params["prod"].EXPECT().PutSecret(gomock.Any()).Return(&ssm.PutSecretOutput{Version: aws.Int64(2)}, nil)
+			},
+		},
+		"wraps the error if a service can't be redeployed": {
+			inName:   "db_password",
+			inValues: map[string]string{"test": "hunter2"},
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.params["test"].EXPECT().PutSecret(gomock.Any()).Return(&ssm.PutSecretOutput{Version: aws.Int64(4)}, nil)
+				m.services.EXPECT().ForceUpdateService("phonetool", "test", "api").Return(errors.New("some error"))
+			},
+			wantedErr: errors.New("force a new deployment of service api in environment test: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			opts := &rotateSecretOpts{
+				rotateSecretVars: rotateSecretVars{
+					appName: "phonetool",
+					name:    tc.inName,
+					values:  tc.inValues,
+				},
+				store:  m.store,
+				finder: m.finder(),
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
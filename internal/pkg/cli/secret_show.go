@@ -0,0 +1,232 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+)
+
+const (
+	secretShowAppNamePrompt     = "Which application's secret would you like to show?"
+	secretShowAppNameHelpPrompt = "An application groups all of your environments together."
+	secretShowNamePrompt        = "Which secret would you like to show?"
+	secretShowNameHelpPrompt    = "The secrets of the application in all of its environments."
+)
+
+type showSecretVars struct {
+	appName          string
+	name             string
+	shouldReveal     bool
+	shouldOutputJSON bool
+}
+
+type showSecretOpts struct {
+	showSecretVars
+
+	w      io.Writer
+	store  store
+	sel    appSelector
+	prompt prompter
+	finder *secretFinder
+}
+
+func newShowSecretOpts(vars showSecretVars) (*showSecretOpts, error) {
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("secret show"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	finder, err := newSecretFinder(sessProvider, store)
+	if err != nil {
+		return nil, err
+	}
+	prompter := prompt.New()
+	return &showSecretOpts{
+		showSecretVars: vars,
+		w:              log.OutputWriter,
+		store:          store,
+		sel:            selector.NewSelect(prompter, store),
+		prompt:         prompter,
+		finder:         finder,
+	}, nil
+}
+
+// Validate is a no-op for this command.
+func (o *showSecretOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *showSecretOpts) Ask() error {
+	if o.appName != "" {
+		if _, err := o.store.GetApplication(o.appName); err != nil {
+			return err
+		}
+	} else {
+		name, err := o.sel.Application(secretShowAppNamePrompt, secretShowAppNameHelpPrompt)
+		if err != nil {
+			return fmt.Errorf("select application name: %w", err)
+		}
+		o.appName = name
+	}
+	if o.name != "" {
+		return nil
+	}
+	envs, err := secretEnvironments(o.store, o.appName, "")
+	if err != nil {
+		return err
+	}
+	name, err := o.finder.selectName(o.prompt, o.appName, envs, secretShowNamePrompt, secretShowNameHelpPrompt)
+	if err != nil {
+		return err
+	}
+	o.name = name
+	return nil
+}
+
+type secretEnvDescription struct {
+	Environment      string    `json:"environment"`
+	ParameterName    string    `json:"parameterName"`
+	Version          int64     `json:"version"`
+	LastModified     time.Time `json:"lastModified"`
+	LastModifiedUser string    `json:"lastModifiedBy,omitempty"`
+	ReferencedBy     []string  `json:"referencedBy"`
+	Value            string    `json:"value,omitempty"`
+}
+
+type secretDescription struct {
+	Name         string                 `json:"name"`
+	Environments []secretEnvDescription `json:"environments"`
+}
+
+// HumanString returns the stringified secret description in human readable format.
+func (d *secretDescription) HumanString(withValues bool) string {
+	var b strings.Builder
+	writer := tabwriter.NewWriter(&b, historyMinCellWidth, historyTabWidth, historyCellPaddingWidth, historyPaddingChar, 0)
+	fmt.Fprint(writer, color.Bold.Sprint("About\n\n"))
+	writer.Flush()
+	fmt.Fprintf(writer, "  %s\t%s\n", "Name", d.Name)
+	fmt.Fprint(writer, color.Bold.Sprint("\nEnvironments\n\n"))
+	writer.Flush()
+	headers := []string{"Environment", "Parameter", "Version", "Last Modified", "Modified By", "Referenced By"}
+	fmt.Fprintf(writer, "  %s\n", strings.Join(headers, "\t"))
+	fmt.Fprintf(writer, "  %s\n", strings.Join(underlineHeaders(headers), "\t"))
+	for _, env := range d.Environments {
+		fmt.Fprintf(writer, "  %s\t%s\t%d\t%s\t%s\t%s\n", env.Environment, env.ParameterName, env.Version,
+			env.LastModified.Format(time.RFC3339), valueOrDash(env.LastModifiedUser), valueOrDash(strings.Join(env.ReferencedBy, ", ")))
+	}
+	writer.Flush()
+	if withValues {
+		fmt.Fprint(writer, color.Bold.Sprint("\nValues\n\n"))
+		writer.Flush()
+		headers := []string{"Environment", "Value"}
+		fmt.Fprintf(writer, "  %s\n", strings.Join(headers, "\t"))
+		fmt.Fprintf(writer, "  %s\n", strings.Join(underlineHeaders(headers), "\t"))
+		for _, env := range d.Environments {
+			fmt.Fprintf(writer, "  %s\t%s\n", env.Environment, env.Value)
+		}
+		writer.Flush()
+	}
+	return b.String()
+}
+
+// Execute shows the metadata of the secret in each environment, and its values if requested.
+func (o *showSecretOpts) Execute() error {
+	envs, err := secretEnvironments(o.store, o.appName, "")
+	if err != nil {
+		return err
+	}
+	secrets, err := o.finder.find(o.appName, envs, o.name)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return fmt.Errorf("secret %s not found in application %s", o.name, o.appName)
+	}
+	desc := &secretDescription{
+		Name: secrets[0].name,
+	}
+	for _, s := range secrets {
+		env := secretEnvDescription{
+			Environment:      s.env,
+			ParameterName:    s.Name,
+			Version:          s.Version,
+			LastModified:     s.LastModified,
+			LastModifiedUser: s.LastModifiedUser,
+			ReferencedBy:     s.workloads(),
+		}
+		if o.shouldReveal {
+			secret, err := s.clients.params.GetSecret(s.Name, true)
+			if err != nil {
+				return fmt.Errorf("get value of secret %s in environment %s: %w", s.name, s.env, err)
+			}
+			env.Value = secret.Value
+		}
+		desc.Environments = append(desc.Environments, env)
+	}
+
+	if o.shouldOutputJSON {
+		data, err := json.Marshal(desc)
+		if err != nil {
+			return fmt.Errorf("marshal secret %s: %w", o.name, err)
+		}
+		fmt.Fprintf(o.w, "%s\n", data)
+		return nil
+	}
+	fmt.Fprint(o.w, desc.HumanString(o.shouldReveal))
+	return nil
+}
+
+func underlineHeaders(headers []string) []string {
+	var lines []string
+	for _, header := range headers {
+		lines = append(lines, strings.Repeat("-", len(header)))
+	}
+	return lines
+}
+
+// buildSecretShowCmd builds the command for showing a secret.
+func buildSecretShowCmd() *cobra.Command {
+	vars := showSecretVars{}
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Shows info about a secret.",
+		Long:  "Shows the version, last modification and the deployed workloads that reference a secret in each environment.",
+		Example: `
+  Shows info about the "db_password" secret.
+  /code $ copilot secret show -n db_password
+  Shows info about the "db_password" secret, including its values.
+  /code $ copilot secret show -n db_password --reveal`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newShowSecretOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", secretShowNameFlagDescription)
+	cmd.Flags().BoolVar(&vars.shouldReveal, revealFlag, false, secretRevealFlagDescription)
+	cmd.Flags().BoolVar(&vars.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	return cmd
+}
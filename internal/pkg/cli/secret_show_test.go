@@ -0,0 +1,176 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+func TestShowSecretOpts_Ask(t *testing.T) {
+	testCases := map[string]struct {
+		inName string
+
+		setupMocks func(m *secretMocks)
+
+		wantedName string
+		wantedErr  error
+	}{
+		"does not prompt if the name is provided": {
+			inName: "db_password",
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+			},
+			wantedName: "db_password",
+		},
+		"prompts for one of the secrets of the application": {
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.mockSecretsInApp()
+				m.prompt.EXPECT().SelectOne(secretShowNamePrompt, secretShowNameHelpPrompt, []string{"api_key", "db_password"}, gomock.Any()).
+					Return("db_password", nil)
+			},
+			wantedName: "db_password",
+		},
+		"error if the application has no secrets": {
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}}, nil)
+				m.store.EXPECT().ListWorkloads("phonetool").Return(nil, nil)
+				m.params["test"].EXPECT().ListSecrets(gomock.Any()).Return(nil, nil)
+			},
+			wantedErr: errors.New("no secrets found in application phonetool"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			opts := &showSecretOpts{
+				showSecretVars: showSecretVars{
+					appName: "phonetool",
+					name:    tc.inName,
+				},
+				store:  m.store,
+				sel:    m.sel,
+				prompt: m.prompt,
+				finder: m.finder(),
+			}
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedName, opts.name)
+		})
+	}
+}
+
+func TestShowSecretOpts_Execute(t *testing.T) {
+	testCases := map[string]struct {
+		inName   string
+		inReveal bool
+		inJSON   bool
+
+		setupMocks func(m *secretMocks)
+
+		wantedContent string
+		wantedErr     error
+	}{
+		"error if the secret doesn't exist": {
+			inName: "orders_key",
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+			},
+			wantedErr: errors.New("secret orders_key not found in application phonetool"),
+		},
+		"wraps the error if a value can't be retrieved": {
+			inName:   "db_password",
+			inReveal: true,
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.params["test"].EXPECT().GetSecret("/copilot/phonetool/test/secrets/db_password", true).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("get value of secret db_password in environment test: some error"),
+		},
+		"writes the secret in human format": {
+			inName: "db_password",
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+			},
+			wantedContent: `About
+
+  Name      db_password
+
+Environments
+
+  Environment  Parameter                                    Version     Last Modified         Modified By                           Referenced By
+  -----------  ---------                                    -------     -------------         -----------                           -------------
+  test         /copilot/phonetool/test/secrets/db_password  3           2022-03-01T10:00:00Z  arn:aws:iam::123456789012:user/alice  api, report
+  prod         /copilot/phonetool/prod/secrets/db_password  1           2022-03-01T10:00:00Z  -                                     -
+`,
+		},
+		"writes the secret and its values in JSON format": {
+			inName:   "api_key",
+			inReveal: true,
+			inJSON:   true,
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+				m.params["test"].EXPECT().GetSecret("/copilot/phonetool/test/secrets/api_key", true).Return(&ssm.Secret{
+					Value: "hunter2",
+				}, nil)
+			},
+			wantedContent: `{"name":"api_key","environments":[{"environment":"test","parameterName":"/copilot/phonetool/test/secrets/api_key","version":1,"lastModified":"2022-03-01T10:00:00Z","referencedBy":[],"value":"hunter2"}]}` + "\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			buf := new(bytes.Buffer)
+			opts := &showSecretOpts{
+				showSecretVars: showSecretVars{
+					appName:          "phonetool",
+					name:             tc.inName,
+					shouldReveal:     tc.inReveal,
+					shouldOutputJSON: tc.inJSON,
+				},
+				w:      buf,
+				store:  m.store,
+				finder: m.finder(),
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, buf.String())
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	awsecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+)
+
+var mockSecretModifiedAt = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+type secretMocks struct {
+	store       *mocks.Mockstore
+	deployStore *mocks.MockdeployedEnvironmentLister
+	params      map[string]*mocks.MocksecretParameterStore
+	taskDefs    *mocks.MocktaskDefinitionDescriber
+	services    *mocks.MockserviceForceUpdater
+	sel         *mocks.MockappSelector
+	prompt      *mocks.Mockprompter
+}
+
+func newSecretMocks(ctrl *gomock.Controller) *secretMocks {
+	return &secretMocks{
+		store:       mocks.NewMockstore(ctrl),
+		deployStore: mocks.NewMockdeployedEnvironmentLister(ctrl),
+		params: map[string]*mocks.MocksecretParameterStore{
+			"test": mocks.NewMocksecretParameterStore(ctrl),
+			"prod": mocks.NewMocksecretParameterStore(ctrl),
+		},
+		taskDefs: mocks.NewMocktaskDefinitionDescriber(ctrl),
+		services: mocks.NewMockserviceForceUpdater(ctrl),
+		sel:      mocks.NewMockappSelector(ctrl),
+		prompt:   mocks.NewMockprompter(ctrl),
+	}
+}
+
+func (m *secretMocks) finder() *secretFinder {
+	return &secretFinder{
+		store:       m.store,
+		deployStore: m.deployStore,
+		newEnvClients: func(env *config.Environment) (*secretEnvClients, error) {
+			return &secretEnvClients{
+				params:   m.params[env.Name],
+				taskDefs: m.taskDefs,
+				services: m.services,
+			}, nil
+		},
+	}
+}
+
+// mockSecretsInApp sets up a "phonetool" application where "db_password" exists in the test and prod environments,
+// and "api_key" only in the test environment.
+// In the test environment, the "api" service references db_password by ARN and the "report" job references it by name.
+func (m *secretMocks) mockSecretsInApp() {
+	m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}, {Name: "prod"}}, nil).AnyTimes()
+	m.store.EXPECT().ListWorkloads("phonetool").Return([]*config.Workload{
+		{Name: "api", Type: manifest.LoadBalancedWebServiceType},
+		{Name: "frontend", Type: manifest.RequestDrivenWebServiceType},
+		{Name: "report", Type: manifest.ScheduledJobType},
+	}, nil).AnyTimes()
+	m.params["test"].EXPECT().ListSecrets(map[string]string{
+		"copilot-application": "phonetool",
+		"copilot-environment": "test",
+	}).Return([]ssm.SecretMetadata{
+		{
+			Name:             "/copilot/phonetool/test/secrets/db_password",
+			Version:          3,
+			LastModified:     mockSecretModifiedAt,
+			LastModifiedUser: "arn:aws:iam::123456789012:user/alice",
+		},
+		{
+			Name:         "/copilot/phonetool/test/secrets/api_key",
+			Version:      1,
+			LastModified: mockSecretModifiedAt,
+		},
+	}, nil).AnyTimes()
+	m.params["prod"].EXPECT().ListSecrets(map[string]string{
+		"copilot-application": "phonetool",
+		"copilot-environment": "prod",
+	}).Return([]ssm.SecretMetadata{
+		{
+			Name:         "/copilot/phonetool/prod/secrets/db_password",
+			Version:      1,
+			LastModified: mockSecretModifiedAt,
+		},
+	}, nil).AnyTimes()
+	m.deployStore.EXPECT().ListDeployedServices("phonetool", "test").Return([]string{"api", "frontend"}, nil).AnyTimes()
+	m.deployStore.EXPECT().ListDeployedJobs("phonetool", "test").Return([]string{"report"}, nil).AnyTimes()
+	m.deployStore.EXPECT().ListDeployedServices("phonetool", "prod").Return(nil, nil).AnyTimes()
+	m.deployStore.EXPECT().ListDeployedJobs("phonetool", "prod").Return(nil, nil).AnyTimes()
+	m.taskDefs.EXPECT().TaskDefinition("phonetool", "test", "api").Return(mockTaskDefWithSecrets(
+		"arn:aws:ssm:us-west-2:123456789012:parameter/copilot/phonetool/test/secrets/db_password",
+	), nil).AnyTimes()
+	m.taskDefs.EXPECT().TaskDefinition("phonetool", "test", "report").Return(mockTaskDefWithSecrets(
+		"/copilot/phonetool/test/secrets/db_password",
+		"/copilot/phonetool/test/secrets/db_password",
+	), nil).AnyTimes()
+}
+
+func mockTaskDefWithSecrets(valueFroms ...string) *awsecs.TaskDefinition {
+	var secrets []*ecs.Secret
+	for i, valueFrom := range valueFroms {
+		secrets = append(secrets, &ecs.Secret{
+			Name:      aws.String("SECRET_" + string(rune('A'+i))),
+			ValueFrom: aws.String(valueFrom),
+		})
+	}
+	return &awsecs.TaskDefinition{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{
+				Name:    aws.String("main"),
+				Secrets: secrets,
+			},
+		},
+	}
+}
+
+func TestSecretFinder_find(t *testing.T) {
+	testCases := map[string]struct {
+		inName     string
+		setupMocks func(m *secretMocks)
+
+		wantedSecrets []*envSecret
+		wantedErr     error
+	}{
+		"wraps the error if the secrets can't be listed": {
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().ListWorkloads("phonetool").Return(nil, nil)
+				m.params["test"].EXPECT().ListSecrets(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("list secrets in environment test: some error"),
+		},
+		"returns the error if a task definition can't be retrieved": {
+			setupMocks: func(m *secretMocks) {
+				m.store.EXPECT().ListWorkloads("phonetool").Return([]*config.Workload{{Name: "api", Type: manifest.BackendServiceType}}, nil)
+				m.params["test"].EXPECT().ListSecrets(gomock.Any()).Return([]ssm.SecretMetadata{{Name: "/copilot/phonetool/test/secrets/db_password"}}, nil)
+				m.deployStore.EXPECT().ListDeployedServices("phonetool", "test").Return([]string{"api"}, nil)
+				m.taskDefs.EXPECT().TaskDefinition("phonetool", "test", "api").Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("some error"),
+		},
+		"returns the secrets with the ECS workloads that reference them": {
+			inName: "db_password",
+			setupMocks: func(m *secretMocks) {
+				m.mockSecretsInApp()
+			},
+			wantedSecrets: []*envSecret{
+				{
+					name: "db_password",
+					env:  "test",
+					SecretMetadata: ssm.SecretMetadata{
+						Name:             "/copilot/phonetool/test/secrets/db_password",
+						Version:          3,
+						LastModified:     mockSecretModifiedAt,
+						LastModifiedUser: "arn:aws:iam::123456789012:user/alice",
+					},
+					services: []string{"api"},
+					jobs:     []string{"report"},
+				},
+				{
+					name: "db_password",
+					env:  "prod",
+					SecretMetadata: ssm.SecretMetadata{
+						Name:         "/copilot/phonetool/prod/secrets/db_password",
+						Version:      1,
+						LastModified: mockSecretModifiedAt,
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newSecretMocks(ctrl)
+			tc.setupMocks(m)
+			finder := m.finder()
+
+			// WHEN
+			got, err := finder.find("phonetool", []*config.Environment{{Name: "test"}, {Name: "prod"}}, tc.inName)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range got {
+				s.clients = nil
+			}
+			require.Equal(t, tc.wantedSecrets, got)
+		})
+	}
+}
+
+func TestSecretNameFromParameter(t *testing.T) {
+	testCases := map[string]struct {
+		inParam string
+		wanted  string
+	}{
+		"trims the prefix of secrets created by secret init": {
+			inParam: "/copilot/phonetool/test/secrets/db_password",
+			wanted:  "db_password",
+		},
+		"keeps the name of other parameters": {
+			inParam: "/shared/db_password",
+			wanted:  "/shared/db_password",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, secretNameFromParameter("phonetool", "test", tc.inParam))
+		})
+	}
+}
+
+func TestNormalizeParameterName(t *testing.T) {
+	testCases := map[string]struct {
+		in     string
+		wanted string
+	}{
+		"parameter name": {
+			in:     "/copilot/phonetool/test/secrets/db_password",
+			wanted: "/copilot/phonetool/test/secrets/db_password",
+		},
+		"parameter name without a leading slash": {
+			in:     "db_password",
+			wanted: "/db_password",
+		},
+		"parameter ARN": {
+			in:     "arn:aws:ssm:us-west-2:123456789012:parameter/copilot/phonetool/test/secrets/db_password",
+			wanted: "/copilot/phonetool/test/secrets/db_password",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, normalizeParameterName(tc.in))
+		})
+	}
+}
@@ -19,6 +19,8 @@ Augment your services with S3 buckets, NoSQL and SQL databases.`,
 	}
 
 	cmd.AddCommand(buildStorageInitCmd())
+	cmd.AddCommand(buildStorageListCmd())
+	cmd.AddCommand(buildStorageDeleteCmd())
 
 	cmd.SetUsageTemplate(template.Usage)
 
@@ -0,0 +1,431 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/dustin/go-humanize/english"
+	"github.com/spf13/cobra"
+
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/dynamodb"
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/rds"
+	"github.com/aws/copilot-cli/internal/pkg/aws/s3"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
+)
+
+const (
+	storageDeleteAppNamePrompt     = "Which application does the storage belong to?"
+	storageDeleteAppNameHelpPrompt = "The storage is removed from the environments of the application the next time its workload is deployed."
+	storageDeleteNamePrompt        = "Which storage would you like to delete?"
+	storageDeleteNameHelpPrompt    = "The storage resources in your workspace and the workload they belong to."
+
+	fmtStorageDeleteConfirmPrompt = "Are you sure you want to delete %s from %s?"
+	storageDeleteConfirmHelp      = "This deletes the addon template of the storage from your workspace. Its resources are deleted the next time it's deployed."
+	fmtStorageSnapshotPrompt      = "Would you like to snapshot %s %s in environment %s before it's deleted?"
+	storageSnapshotHelp           = "DynamoDB tables are backed up and Aurora clusters get a cluster snapshot. S3 has no snapshots, so the latest version of each object of S3 buckets is copied to a new bucket instead."
+
+	storageSnapshotTimeFormat = "20060102-150405"
+	maxBucketNameLength       = 63
+)
+
+var errStorageDeleteCancelled = errors.New("storage delete cancelled - no changes made")
+
+// Deletion policies that keep a resource when it's removed from a stack.
+var retainDeletionPolicies = map[string]bool{
+	"Retain":               true,
+	"RetainExceptOnCreate": true,
+}
+
+// storageEnvClients holds the clients to inspect and snapshot storage in an environment.
+type storageEnvClients struct {
+	cfn stackResourcesDescriber
+	ddb tableBackupCreator
+	rds dbClusterSnapshotCreator
+	s3  bucketCopier
+}
+
+// storageDeployment is a storage addon deployed in an environment.
+type storageDeployment struct {
+	env         string
+	clients     *storageEnvClients
+	physicalIDs map[string]string
+}
+
+type deleteStorageVars struct {
+	appName          string
+	name             string
+	workloadName     string
+	envLevel         bool
+	skipConfirmation bool
+	snapshot         bool
+}
+
+type deleteStorageOpts struct {
+	deleteStorageVars
+
+	ws     wsStorageDeleter
+	store  store
+	sel    appSelector
+	prompt prompter
+
+	newEnvClients func(env *config.Environment) (*storageEnvClients, error)
+	now           func() time.Time
+
+	// Cached variables.
+	target       *storageAddon
+	deployedEnvs []string
+}
+
+func newDeleteStorageOpts(vars deleteStorageVars) (*deleteStorageOpts, error) {
+	ws, err := workspace.New()
+	if err != nil {
+		return nil, fmt.Errorf("new workspace: %w", err)
+	}
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("storage delete"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	prompter := prompt.New()
+	return &deleteStorageOpts{
+		deleteStorageVars: vars,
+		ws:                ws,
+		store:             store,
+		sel:               selector.NewSelect(prompter, store),
+		prompt:            prompter,
+		newEnvClients: func(env *config.Environment) (*storageEnvClients, error) {
+			sess, err := sessProvider.FromRole(env.ManagerRoleARN, env.Region)
+			if err != nil {
+				return nil, fmt.Errorf("create session from environment manager role %s in region %s: %w", env.ManagerRoleARN, env.Region, err)
+			}
+			return &storageEnvClients{
+				cfn: awscloudformation.New(sess),
+				ddb: dynamodb.New(sess),
+				rds: rds.New(sess),
+				s3:  s3.New(sess),
+			}, nil
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Validate returns an error for any invalid optional flags.
+func (o *deleteStorageOpts) Validate() error {
+	if o.envLevel && o.workloadName != "" {
+		return fmt.Errorf("cannot specify both --%s and --%s", storageEnvLevelFlag, workloadFlag)
+	}
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *deleteStorageOpts) Ask() error {
+	if err := o.validateOrAskApp(); err != nil {
+		return err
+	}
+	return o.validateOrAskStorage()
+}
+
+// Execute deletes the addon template of the storage after snapshotting its deployed resources.
+func (o *deleteStorageOpts) Execute() error {
+	deployments, err := o.deployments()
+	if err != nil {
+		return err
+	}
+	o.warnDeletion(deployments)
+	if !o.skipConfirmation {
+		confirmed, err := o.prompt.Confirm(
+			fmt.Sprintf(fmtStorageDeleteConfirmPrompt, color.HighlightUserInput(o.target.name), o.ownerDescription()),
+			storageDeleteConfirmHelp,
+			prompt.WithConfirmFinalMessage())
+		if err != nil {
+			return fmt.Errorf("storage delete confirmation prompt: %w", err)
+		}
+		if !confirmed {
+			return errStorageDeleteCancelled
+		}
+	}
+	for _, d := range deployments {
+		if err := o.snapshotStorage(d); err != nil {
+			return err
+		}
+	}
+	if err := o.ws.DeleteAddon(o.target.owner, o.target.fileName); err != nil {
+		return fmt.Errorf("delete addon %s under %s: %w", o.target.fileName, o.target.owner, err)
+	}
+	log.Successf("Deleted storage %s from %s.\n", color.HighlightUserInput(o.target.name), o.ownerDescription())
+	o.warnUnusedParameters()
+	return nil
+}
+
+// RecommendActions returns follow-up actions the user can take after successfully executing the command.
+func (o *deleteStorageOpts) RecommendActions() error {
+	if len(o.deployedEnvs) == 0 {
+		return nil
+	}
+	if o.target.isEnvLevel() {
+		logRecommendedActions([]string{
+			fmt.Sprintf("Remove the outputs of %s from the %s field of your workloads' manifests and redeploy them.",
+				color.HighlightUserInput(o.target.name), color.HighlightCode("env_addons")),
+			fmt.Sprintf("Run %s to delete the resources from your environments.", color.HighlightCode("copilot env upgrade --all")),
+		})
+		return nil
+	}
+	var actions []string
+	for _, env := range o.deployedEnvs {
+		actions = append(actions, fmt.Sprintf("Run %s to delete the resources from the %s environment.",
+			color.HighlightCode(fmt.Sprintf("copilot deploy --name %s --env %s", o.target.owner, env)), env))
+	}
+	logRecommendedActions(actions)
+	return nil
+}
+
+func (o *deleteStorageOpts) validateOrAskApp() error {
+	if o.appName != "" {
+		_, err := o.store.GetApplication(o.appName)
+		return err
+	}
+	name, err := o.sel.Application(storageDeleteAppNamePrompt, storageDeleteAppNameHelpPrompt)
+	if err != nil {
+		return fmt.Errorf("select application name: %w", err)
+	}
+	o.appName = name
+	return nil
+}
+
+func (o *deleteStorageOpts) validateOrAskStorage() error {
+	addons, err := listStorageAddons(o.ws)
+	if err != nil {
+		return err
+	}
+	var candidates []*storageAddon
+	for _, a := range addons {
+		if o.name != "" && a.name != o.name {
+			continue
+		}
+		if o.workloadName != "" && a.owner != o.workloadName {
+			continue
+		}
+		if o.envLevel && !a.isEnvLevel() {
+			continue
+		}
+		candidates = append(candidates, a)
+	}
+	if len(candidates) == 0 {
+		if o.name != "" {
+			return fmt.Errorf("storage %s not found in the workspace", o.name)
+		}
+		return errors.New("no storage found in the workspace")
+	}
+	if len(candidates) == 1 {
+		o.target = candidates[0]
+		return nil
+	}
+	labels := make([]string, len(candidates))
+	candidateFor := make(map[string]*storageAddon)
+	for i, a := range candidates {
+		labels[i] = fmt.Sprintf("%s (%s)", a.name, a.owner)
+		if a.isEnvLevel() {
+			labels[i] = fmt.Sprintf("%s %s", a.name, storageEnvLevelOwnerName)
+		}
+		candidateFor[labels[i]] = a
+	}
+	label, err := o.prompt.SelectOne(storageDeleteNamePrompt, storageDeleteNameHelpPrompt, labels, prompt.WithFinalMessage("Storage:"))
+	if err != nil {
+		return fmt.Errorf("select storage to delete: %w", err)
+	}
+	o.target = candidateFor[label]
+	return nil
+}
+
+// deployments returns the environments the storage is deployed in.
+func (o *deleteStorageOpts) deployments() ([]*storageDeployment, error) {
+	envs, err := o.store.ListEnvironments(o.appName)
+	if err != nil {
+		return nil, fmt.Errorf("list environments for application %s: %w", o.appName, err)
+	}
+	var deployments []*storageDeployment
+	for _, env := range envs {
+		clients, err := o.newEnvClients(env)
+		if err != nil {
+			return nil, err
+		}
+		physicalIDs, err := deployedStorage(clients.cfn, o.appName, env.Name, o.target)
+		if err != nil {
+			return nil, fmt.Errorf("get deployment status of storage %s in environment %s: %w", o.target.name, env.Name, err)
+		}
+		if physicalIDs == nil {
+			continue
+		}
+		deployments = append(deployments, &storageDeployment{
+			env:         env.Name,
+			clients:     clients,
+			physicalIDs: physicalIDs,
+		})
+		o.deployedEnvs = append(o.deployedEnvs, env.Name)
+	}
+	return deployments, nil
+}
+
+func (o *deleteStorageOpts) warnDeletion(deployments []*storageDeployment) {
+	if len(deployments) == 0 {
+		log.Infof("Storage %s is not deployed in any environment.\n", color.HighlightUserInput(o.target.name))
+		return
+	}
+	envs := english.WordSeries(o.deployedEnvs, "and")
+	for _, r := range o.target.resources {
+		if retainDeletionPolicies[r.DeletionPolicy] {
+			log.Warningf("%s %s has a %s deletion policy: it will be removed from the stack but not deleted. Delete it manually from %s once you no longer need it.\n",
+				storageTypeForCFNType[r.Type], r.LogicalID, r.DeletionPolicy, english.PluralWord(len(deployments), "environment", "")+" "+envs)
+			continue
+		}
+		log.Warningf("%s %s will be permanently deleted from %s.\n",
+			storageTypeForCFNType[r.Type], r.LogicalID, english.PluralWord(len(deployments), "environment", "")+" "+envs)
+		if r.Type == "AWS::S3::Bucket" {
+			log.Warningln("CloudFormation can only delete empty buckets, empty the bucket before you deploy.")
+		}
+	}
+}
+
+// snapshotStorage backs up the DynamoDB tables and Aurora clusters of the storage in an environment, and copies its S3 buckets.
+func (o *deleteStorageOpts) snapshotStorage(d *storageDeployment) error {
+	suffix := o.now().UTC().Format(storageSnapshotTimeFormat)
+	for _, r := range o.target.resources {
+		if retainDeletionPolicies[r.DeletionPolicy] {
+			continue
+		}
+		physicalID, ok := d.physicalIDs[r.LogicalID]
+		if !ok {
+			continue
+		}
+		switch r.Type {
+		case "AWS::DynamoDB::Table", "AWS::RDS::DBCluster", "AWS::S3::Bucket":
+		default:
+			continue
+		}
+		ok, err := o.shouldSnapshot(storageTypeForCFNType[r.Type], physicalID, d.env)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		switch r.Type {
+		case "AWS::DynamoDB::Table":
+			arn, err := d.clients.ddb.CreateBackup(physicalID, fmt.Sprintf("%s-%s", physicalID, suffix))
+			if err != nil {
+				return err
+			}
+			log.Successf("Created backup %s of DynamoDB table %s.\n", color.HighlightResource(arn), physicalID)
+		case "AWS::RDS::DBCluster":
+			arn, err := d.clients.rds.CreateDBClusterSnapshot(physicalID, fmt.Sprintf("%s-snapshot-%s", physicalID, suffix))
+			if err != nil {
+				return err
+			}
+			log.Successf("Created snapshot %s of Aurora cluster %s.\n", color.HighlightResource(arn), physicalID)
+		case "AWS::S3::Bucket":
+			dst := copyBucketName(o.appName, d.env, physicalID, suffix)
+			if err := d.clients.s3.CopyBucket(physicalID, dst); err != nil {
+				return err
+			}
+			log.Successf("Copied the objects of S3 bucket %s to %s.\n", physicalID, color.HighlightResource(dst))
+		}
+	}
+	return nil
+}
+
+func (o *deleteStorageOpts) shouldSnapshot(storageType, physicalID, env string) (bool, error) {
+	if o.snapshot {
+		return true, nil
+	}
+	if o.skipConfirmation {
+		return false, nil
+	}
+	ok, err := o.prompt.Confirm(
+		fmt.Sprintf(fmtStorageSnapshotPrompt, storageType, color.HighlightResource(physicalID), color.HighlightUserInput(env)),
+		storageSnapshotHelp)
+	if err != nil {
+		return false, fmt.Errorf("confirm snapshot of %s %s: %w", storageType, physicalID, err)
+	}
+	return ok, nil
+}
+
+// warnUnusedParameters warns if the parameters file that "storage init" may have written next to the template is left behind.
+func (o *deleteStorageOpts) warnUnusedParameters() {
+	fnames, err := o.ws.ReadAddonsDir(o.target.owner)
+	if err != nil {
+		return
+	}
+	for _, fname := range fnames {
+		if fname == "addons.parameters.yml" || fname == "addons.parameters.yaml" {
+			log.Warningf("Remove the parameters that only %s used from %s, CloudFormation fails on parameters that the templates don't declare.\n",
+				o.target.name, fname)
+			return
+		}
+	}
+}
+
+func (o *deleteStorageOpts) ownerDescription() string {
+	if o.target.isEnvLevel() {
+		return "your environments"
+	}
+	return fmt.Sprintf("workload %s", color.HighlightUserInput(o.target.owner))
+}
+
+// copyBucketName returns a name for the copy of a bucket that fits in the length limit of bucket names.
+// The name always starts with "<app>-<env>-", the only bucket names the environment manager role can create.
+func copyBucketName(app, env, bucket, suffix string) string {
+	prefix := bucket
+	if envPrefix := fmt.Sprintf("%s-%s-", app, env); !strings.HasPrefix(prefix, envPrefix) {
+		prefix = envPrefix + prefix
+	}
+	if max := maxBucketNameLength - len(suffix) - 1; len(prefix) > max {
+		prefix = strings.TrimRight(prefix[:max], "-.")
+	}
+	return fmt.Sprintf("%s-%s", prefix, suffix)
+}
+
+// buildStorageDeleteCmd builds the command for deleting a storage resource.
+func buildStorageDeleteCmd() *cobra.Command {
+	vars := deleteStorageVars{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes a storage resource from your workspace.",
+		Long: `Deletes a storage resource from your workspace.
+The resources are deleted from your environments the next time the workload or environment is deployed.`,
+		Example: `
+  Delete the "my-table" DynamoDB table of the "frontend" service.
+  /code $ copilot storage delete -n my-table -w frontend
+  Delete the "my-bucket" S3 bucket shared by all workloads, and snapshot it without being asked.
+  /code $ copilot storage delete -n my-bucket --env-level --snapshot --yes`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newDeleteStorageOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", storageDeleteNameFlagDescription)
+	cmd.Flags().StringVarP(&vars.workloadName, workloadFlag, workloadFlagShort, "", storageDeleteWorkloadFlagDescription)
+	cmd.Flags().BoolVar(&vars.envLevel, storageEnvLevelFlag, false, storageDeleteEnvLevelFlagDescription)
+	cmd.Flags().BoolVar(&vars.snapshot, storageSnapshotFlag, false, storageSnapshotFlagDescription)
+	cmd.Flags().BoolVar(&vars.skipConfirmation, yesFlag, false, yesFlagDescription)
+	return cmd
+}
@@ -0,0 +1,378 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/addon"
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+type storageDeleteMocks struct {
+	ws     *mocks.MockwsStorageDeleter
+	store  *mocks.Mockstore
+	sel    *mocks.MockappSelector
+	prompt *mocks.Mockprompter
+	cfn    *mocks.MockstackResourcesDescriber
+	ddb    *mocks.MocktableBackupCreator
+	rds    *mocks.MockdbClusterSnapshotCreator
+	s3     *mocks.MockbucketCopier
+}
+
+func TestDeleteStorageOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inWorkload string
+		inEnvLevel bool
+
+		wantedErr error
+	}{
+		"error if both env level and workload are set": {
+			inWorkload: "frontend",
+			inEnvLevel: true,
+			wantedErr:  errors.New("cannot specify both --env-level and --workload"),
+		},
+		"valid": {
+			inEnvLevel: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			opts := &deleteStorageOpts{
+				deleteStorageVars: deleteStorageVars{
+					workloadName: tc.inWorkload,
+					envLevel:     tc.inEnvLevel,
+				},
+			}
+
+			// WHEN
+			err := opts.Validate()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteStorageOpts_Ask(t *testing.T) {
+	mockWorkspace := func(m *mocks.MockwsStorageDeleter) {
+		m.EXPECT().ListWorkloads().Return([]string{"frontend", "api"}, nil)
+		m.EXPECT().ReadAddonsDir("frontend").Return([]string{"users.yml"}, nil)
+		m.EXPECT().ReadAddon("frontend", "users.yml").Return([]byte(mockUsersTableAddon), nil)
+		m.EXPECT().ReadAddonsDir("api").Return([]string{"users.yml"}, nil)
+		m.EXPECT().ReadAddon("api", "users.yml").Return([]byte(mockUsersTableAddon), nil)
+		m.EXPECT().ReadAddonsDir("environments").Return([]string{"assets.yml"}, nil)
+		m.EXPECT().ReadAddon("environments", "assets.yml").Return([]byte(mockAssetsBucketAddon), nil)
+	}
+	testCases := map[string]struct {
+		inAppName  string
+		inName     string
+		inWorkload string
+		inEnvLevel bool
+
+		setupMocks func(m *storageDeleteMocks)
+
+		wantedAppName string
+		wantedName    string
+		wantedOwner   string
+		wantedErr     error
+	}{
+		"wraps the error if the application can't be selected": {
+			setupMocks: func(m *storageDeleteMocks) {
+				m.sel.EXPECT().Application(storageDeleteAppNamePrompt, storageDeleteAppNameHelpPrompt).Return("", errors.New("some error"))
+			},
+			wantedErr: errors.New("select application name: some error"),
+		},
+		"error if the storage doesn't exist in the workspace": {
+			inAppName: "phonetool",
+			inName:    "orders",
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				mockWorkspace(m.ws)
+			},
+			wantedErr: errors.New("storage orders not found in the workspace"),
+		},
+		"selects the only storage that matches the flags": {
+			inAppName:  "phonetool",
+			inName:     "users",
+			inWorkload: "api",
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				mockWorkspace(m.ws)
+			},
+			wantedAppName: "phonetool",
+			wantedName:    "users",
+			wantedOwner:   "api",
+		},
+		"selects env level storage": {
+			inAppName:  "phonetool",
+			inEnvLevel: true,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				mockWorkspace(m.ws)
+			},
+			wantedAppName: "phonetool",
+			wantedName:    "assets",
+			wantedOwner:   "environments",
+		},
+		"prompts for the storage if several match": {
+			setupMocks: func(m *storageDeleteMocks) {
+				m.sel.EXPECT().Application(gomock.Any(), gomock.Any()).Return("phonetool", nil)
+				mockWorkspace(m.ws)
+				m.prompt.EXPECT().SelectOne(storageDeleteNamePrompt, storageDeleteNameHelpPrompt,
+					[]string{"users (frontend)", "users (api)", "assets (env-level)"}, gomock.Any()).Return("users (api)", nil)
+			},
+			wantedAppName: "phonetool",
+			wantedName:    "users",
+			wantedOwner:   "api",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := &storageDeleteMocks{
+				ws:     mocks.NewMockwsStorageDeleter(ctrl),
+				store:  mocks.NewMockstore(ctrl),
+				sel:    mocks.NewMockappSelector(ctrl),
+				prompt: mocks.NewMockprompter(ctrl),
+			}
+			tc.setupMocks(m)
+			opts := &deleteStorageOpts{
+				deleteStorageVars: deleteStorageVars{
+					appName:      tc.inAppName,
+					name:         tc.inName,
+					workloadName: tc.inWorkload,
+					envLevel:     tc.inEnvLevel,
+				},
+				ws:     m.ws,
+				store:  m.store,
+				sel:    m.sel,
+				prompt: m.prompt,
+			}
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedAppName, opts.appName)
+			require.Equal(t, tc.wantedName, opts.target.name)
+			require.Equal(t, tc.wantedOwner, opts.target.owner)
+		})
+	}
+}
+
+func TestDeleteStorageOpts_Execute(t *testing.T) {
+	usersTable := &storageAddon{
+		name:        "users",
+		fileName:    "users.yml",
+		owner:       "frontend",
+		storageType: dynamoDBStorageType,
+		resources:   []addon.Resource{{LogicalID: "users", Type: "AWS::DynamoDB::Table"}},
+	}
+	assetsBucket := &storageAddon{
+		name:        "assets",
+		fileName:    "assets.yml",
+		owner:       "environments",
+		storageType: s3StorageType,
+		resources:   []addon.Resource{{LogicalID: "assetsBucket", Type: "AWS::S3::Bucket"}},
+	}
+	mockDeployed := func(m *mocks.MockstackResourcesDescriber, parent, logicalID, physicalID string) {
+		m.EXPECT().Exists(parent).Return(true, nil)
+		m.EXPECT().StackResources(parent).Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String("AddonsStack"),
+				PhysicalResourceId: aws.String(parent + "-addons"),
+			},
+		}, nil)
+		m.EXPECT().StackResources(parent+"-addons").Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String(logicalID),
+				PhysicalResourceId: aws.String(physicalID),
+			},
+		}, nil)
+	}
+	testCases := map[string]struct {
+		inTarget           *storageAddon
+		inSkipConfirmation bool
+		inSnapshot         bool
+
+		setupMocks func(m *storageDeleteMocks)
+
+		wantedDeployedEnvs []string
+		wantedErr          error
+	}{
+		"returns an error if the deletion is cancelled": {
+			inTarget: usersTable,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}}, nil)
+				m.cfn.EXPECT().Exists("phonetool-test-frontend").Return(false, nil)
+				m.prompt.EXPECT().Confirm("Are you sure you want to delete users from workload frontend?", storageDeleteConfirmHelp, gomock.Any()).Return(false, nil)
+				m.ws.EXPECT().DeleteAddon(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantedErr: errStorageDeleteCancelled,
+		},
+		"deletes the addon of storage that isn't deployed": {
+			inTarget:           usersTable,
+			inSkipConfirmation: true,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}}, nil)
+				m.cfn.EXPECT().Exists("phonetool-test-frontend").Return(false, nil)
+				m.ws.EXPECT().DeleteAddon("frontend", "users.yml").Return(nil)
+				m.ws.EXPECT().ReadAddonsDir("frontend").Return([]string{}, nil)
+			},
+		},
+		"backs up a deployed table if the user agrees": {
+			inTarget: usersTable,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}, {Name: "prod"}}, nil)
+				mockDeployed(m.cfn, "phonetool-test-frontend", "users", "phonetool-test-frontend-users")
+				mockDeployed(m.cfn, "phonetool-prod-frontend", "users", "phonetool-prod-frontend-users")
+				m.prompt.EXPECT().Confirm(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.prompt.EXPECT().Confirm(gomock.Any(), storageSnapshotHelp).Return(false, nil)
+				m.prompt.EXPECT().Confirm(gomock.Any(), storageSnapshotHelp).Return(true, nil)
+				m.ddb.EXPECT().CreateBackup("phonetool-prod-frontend-users", "phonetool-prod-frontend-users-20261018-093000").Return("arn:backup", nil)
+				m.ws.EXPECT().DeleteAddon("frontend", "users.yml").Return(nil)
+				m.ws.EXPECT().ReadAddonsDir("frontend").Return([]string{"addons.parameters.yml"}, nil)
+			},
+			wantedDeployedEnvs: []string{"test", "prod"},
+		},
+		"copies a deployed bucket without prompting with the snapshot flag": {
+			inTarget:           assetsBucket,
+			inSkipConfirmation: true,
+			inSnapshot:         true,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}}, nil)
+				mockDeployed(m.cfn, "phonetool-test", "assetsBucket", "phonetool-test-addonsstack-assetsbucket-1a2b3c4d5e6f7")
+				m.s3.EXPECT().CopyBucket("phonetool-test-addonsstack-assetsbucket-1a2b3c4d5e6f7", "phonetool-test-addonsstack-assetsbucket-1a2b3c4-20261018-093000").Return(nil)
+				m.ws.EXPECT().DeleteAddon("environments", "assets.yml").Return(nil)
+				m.ws.EXPECT().ReadAddonsDir("environments").Return([]string{}, nil)
+			},
+			wantedDeployedEnvs: []string{"test"},
+		},
+		"does not delete the addon if the snapshot fails": {
+			inTarget:           usersTable,
+			inSkipConfirmation: true,
+			inSnapshot:         true,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}}, nil)
+				mockDeployed(m.cfn, "phonetool-test-frontend", "users", "phonetool-test-frontend-users")
+				m.ddb.EXPECT().CreateBackup(gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+				m.ws.EXPECT().DeleteAddon(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantedErr: errors.New("some error"),
+		},
+		"wraps the error if the addon can't be deleted": {
+			inTarget:           usersTable,
+			inSkipConfirmation: true,
+			setupMocks: func(m *storageDeleteMocks) {
+				m.store.EXPECT().ListEnvironments("phonetool").Return(nil, nil)
+				m.ws.EXPECT().DeleteAddon("frontend", "users.yml").Return(errors.New("some error"))
+			},
+			wantedErr: errors.New("delete addon users.yml under frontend: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := &storageDeleteMocks{
+				ws:     mocks.NewMockwsStorageDeleter(ctrl),
+				store:  mocks.NewMockstore(ctrl),
+				prompt: mocks.NewMockprompter(ctrl),
+				cfn:    mocks.NewMockstackResourcesDescriber(ctrl),
+				ddb:    mocks.NewMocktableBackupCreator(ctrl),
+				rds:    mocks.NewMockdbClusterSnapshotCreator(ctrl),
+				s3:     mocks.NewMockbucketCopier(ctrl),
+			}
+			tc.setupMocks(m)
+			opts := &deleteStorageOpts{
+				deleteStorageVars: deleteStorageVars{
+					appName:          "phonetool",
+					skipConfirmation: tc.inSkipConfirmation,
+					snapshot:         tc.inSnapshot,
+				},
+				ws:     m.ws,
+				store:  m.store,
+				prompt: m.prompt,
+				newEnvClients: func(env *config.Environment) (*storageEnvClients, error) {
+					return &storageEnvClients{
+						cfn: m.cfn,
+						ddb: m.ddb,
+						rds: m.rds,
+						s3:  m.s3,
+					}, nil
+				},
+				now: func() time.Time {
+					return time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+				},
+				target: tc.inTarget,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedDeployedEnvs, opts.deployedEnvs)
+		})
+	}
+}
+
+func TestCopyBucketName(t *testing.T) {
+	testCases := map[string]struct {
+		inBucket string
+
+		wanted string
+	}{
+		"prefixes names that don't start with the app and env names": {
+			inBucket: "my-bucket",
+			wanted:   "phonetool-test-my-bucket-20261018-093000",
+		},
+		"keeps names that start with the app and env names": {
+			inBucket: "phonetool-test-assetsbucket",
+			wanted:   "phonetool-test-assetsbucket-20261018-093000",
+		},
+		"truncates long names to the bucket name limit": {
+			inBucket: "phonetool-test-addonsstack-1a2b3c4d5e-assetsbucket-1a2b3c4d5e6f7",
+			wanted:   "phonetool-test-addonsstack-1a2b3c4d5e-assetsbuc-20261018-093000",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := copyBucketName("phonetool", "test", tc.inBucket, "20261018-093000")
+
+			require.Equal(t, tc.wanted, got)
+			require.LessOrEqual(t, len(got), maxBucketNameLength)
+		})
+	}
+}
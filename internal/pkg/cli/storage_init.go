@@ -7,6 +7,9 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -28,28 +31,40 @@ import (
 )
 
 const (
-	dynamoDBStorageType = "DynamoDB"
-	s3StorageType       = "S3"
-	rdsStorageType      = "Aurora"
+	dynamoDBStorageType   = "DynamoDB"
+	s3StorageType         = "S3"
+	rdsStorageType        = "Aurora"
+	redisStorageType      = "Redis"
+	sqsStorageType        = "SQS"
+	openSearchStorageType = "OpenSearch"
 )
 
 var storageTypes = []string{
 	dynamoDBStorageType,
 	s3StorageType,
 	rdsStorageType,
+	redisStorageType,
+	sqsStorageType,
+	openSearchStorageType,
 }
 
 // Displayed options for storage types
 const (
-	dynamoDBStorageTypeOption = "DynamoDB"
-	s3StorageTypeOption       = "S3"
-	rdsStorageTypeOption      = "Aurora Serverless"
+	dynamoDBStorageTypeOption   = "DynamoDB"
+	s3StorageTypeOption         = "S3"
+	rdsStorageTypeOption        = "Aurora Serverless"
+	redisStorageTypeOption      = "ElastiCache Redis"
+	sqsStorageTypeOption        = "SQS"
+	openSearchStorageTypeOption = "OpenSearch"
 )
 
 var optionToStorageType = map[string]string{
-	dynamoDBStorageTypeOption: dynamoDBStorageType,
-	s3StorageTypeOption:       s3StorageType,
-	rdsStorageTypeOption:      rdsStorageType,
+	dynamoDBStorageTypeOption:   dynamoDBStorageType,
+	s3StorageTypeOption:         s3StorageType,
+	rdsStorageTypeOption:        rdsStorageType,
+	redisStorageTypeOption:      redisStorageType,
+	sqsStorageTypeOption:        sqsStorageType,
+	openSearchStorageTypeOption: openSearchStorageType,
 }
 
 var storageTypeOptions = map[string]prompt.Option{
@@ -65,12 +80,27 @@ var storageTypeOptions = map[string]prompt.Option{
 		Value: rdsStorageTypeOption,
 		Hint:  "SQL",
 	},
+	redisStorageType: {
+		Value: redisStorageTypeOption,
+		Hint:  "In-memory cache",
+	},
+	sqsStorageType: {
+		Value: sqsStorageTypeOption,
+		Hint:  "Queue",
+	},
+	openSearchStorageType: {
+		Value: openSearchStorageTypeOption,
+		Hint:  "Search",
+	},
 }
 
 const (
 	s3BucketFriendlyText      = "S3 Bucket"
 	dynamoDBTableFriendlyText = "DynamoDB Table"
 	rdsFriendlyText           = "Database Cluster"
+	redisFriendlyText         = "Redis Replication Group"
+	sqsFriendlyText           = "SQS Queue"
+	openSearchFriendlyText    = "OpenSearch Domain"
 )
 
 // General-purpose prompts, collected for all storage resources.
@@ -80,6 +110,9 @@ var (
 DynamoDB is a key-value and document database that delivers single-digit millisecond performance at any scale.
 S3 is a web object store built to store and retrieve any amount of data from anywhere on the Internet.
 Aurora Serverless is an on-demand autoscaling configuration for Amazon Aurora, a MySQL and PostgreSQL-compatible relational database.
+ElastiCache Redis is a fully managed, Redis-compatible in-memory data store.
+SQS is a fully managed message queue to decouple your workloads.
+OpenSearch is a fully managed search and analytics engine.
 `
 
 	fmtStorageInitNamePrompt = "What would you like to " + color.Emphasize("name") + " this %s?"
@@ -108,6 +141,46 @@ partition key but a different sort key. You may specify up to 5 alternate sort k
 
 	storageInitDDBLSINamePrompt = "What would you like to name this " + color.Emphasize("alternate sort key") + "?"
 	storageInitDDBLSINameHelp   = "You can use the characters [a-zA-Z0-9.-_]"
+
+	storageInitDDBGSIPrompt = "Would you like to add any global secondary indexes to this table?"
+	storageInitDDBGSIHelp   = `Global Secondary Indexes let you query the table with a different partition key and an optional sort key.
+You may specify up to 20 global secondary indexes.`
+
+	storageInitDDBMoreGSIPrompt = "Would you like to add more global secondary indexes to this table?"
+
+	storageInitDDBGSINamePrompt = "What would you like to name this " + color.Emphasize("global secondary index") + "?"
+	storageInitDDBGSINameHelp   = "You can use the characters [a-zA-Z0-9.-_]"
+
+	storageInitDDBGSISortKeyConfirm = "Would you like to add a sort key to this index?"
+
+	storageInitDDBGSIProjectionPrompt = "Which attributes would you like to " + color.Emphasize("project") + " into this index?"
+	storageInitDDBGSIProjectionHelp   = `ALL projects every attribute of the table, KEYS_ONLY projects only the index and primary keys,
+and INCLUDE projects the keys along with the attributes you choose.`
+
+	storageInitDDBGSIIncludePrompt = "Which other attributes would you like to include in this index?"
+	storageInitDDBGSIIncludeHelp   = "A comma separated list of attribute names, for example: Name,Age"
+
+	storageInitDDBSettingsConfirm = "Would you like to configure item expiry, streams, capacity, or backups for this table?"
+	storageInitDDBSettingsHelp    = "By default the table is billed per request, and has no item expiry, no stream and no point-in-time recovery."
+
+	storageInitDDBTTLPrompt = "Which attribute holds the " + color.Emphasize("expiry time") + " of items? Leave empty to disable expiry."
+	storageInitDDBTTLHelp   = "DynamoDB deletes items once the epoch time in seconds stored in this attribute is in the past."
+
+	storageInitDDBStreamPrompt = "What would you like to write to the table's " + color.Emphasize("stream") + "?"
+	storageInitDDBStreamHelp   = `A stream captures changes to the items of the table.
+NEW_IMAGE and OLD_IMAGE write the item after or before the change, NEW_AND_OLD_IMAGES writes both, and KEYS_ONLY writes only its keys.`
+
+	storageInitDDBBillingModePrompt = "Which " + color.Emphasize("billing mode") + " would you like to use for this table?"
+	storageInitDDBBillingModeHelp   = `With on-demand billing you pay per request. With provisioned billing you pay for read and write capacity units,
+which can be auto scaled.`
+
+	fmtStorageInitDDBCapacityPrompt    = "How many %s capacity units would you like to provision?"
+	storageInitDDBAutoScalingConfirm   = "Would you like to auto scale the capacity of this table?"
+	storageInitDDBAutoScalingHelp      = "Capacity is scaled up to keep its utilization around 70%, and back down to the provisioned capacity."
+	fmtStorageInitDDBMaxCapacityPrompt = "What is the maximum number of %s capacity units?"
+
+	storageInitDDBPITRConfirm = "Would you like to enable point-in-time recovery for this table?"
+	storageInitDDBPITRHelp    = "Point-in-time recovery continuously backs up the table, so that it can be restored to any second in the last 35 days."
 )
 
 // DynamoDB specific constants and variables.
@@ -124,6 +197,31 @@ var attributeTypes = []string{
 	ddbBinaryType,
 }
 
+// DynamoDB billing modes.
+const (
+	ddbBillingModeOnDemand    = "on-demand"
+	ddbBillingModeProvisioned = "provisioned"
+
+	ddbBillingModeOnDemandOption    = "On-demand"
+	ddbBillingModeProvisionedOption = "Provisioned"
+
+	ddbStreamViewTypeNoneOption = "None"
+
+	defaultDDBCapacity = "5"
+)
+
+var ddbBillingModeOptions = map[string]string{
+	ddbBillingModeOnDemandOption:    ddbBillingModeOnDemand,
+	ddbBillingModeProvisionedOption: ddbBillingModeProvisioned,
+}
+
+var ddbStreamViewTypes = []string{
+	"NEW_IMAGE",
+	"OLD_IMAGE",
+	"NEW_AND_OLD_IMAGES",
+	"KEYS_ONLY",
+}
+
 // RDS Aurora Serverless specific questions and help prompts.
 var (
 	storageInitRDSInitialDBNamePrompt = "What would you like to name the initial database in your cluster?"
@@ -143,12 +241,31 @@ var engineTypes = []string{
 	engineTypePostgreSQL,
 }
 
+// RDS Aurora cluster configuration limits.
+const (
+	minRDSCapacity    = 0.5
+	maxRDSCapacity    = 128
+	maxRDSReaderCount = 15
+)
+
+var rdsServerlessVersions = []string{
+	addon.RDSServerlessVersionV1,
+	addon.RDSServerlessVersionV2,
+}
+
+// Default instance sizes for Redis and OpenSearch.
+const (
+	defaultRedisNodeType          = "cache.t3.micro"
+	defaultOpenSearchInstanceType = "t3.small.search"
+)
+
 var errUnavailableAddonParams = errors.New("addon does not require parameters")
 
 type initStorageVars struct {
 	storageType  string
 	storageName  string
 	workloadName string
+	envLevel     bool // True means the storage resource is shared by all workloads in an environment.
 
 	// Dynamo DB specific values collected via flags or prompts
 	partitionKey string
@@ -156,11 +273,39 @@ type initStorageVars struct {
 	lsiSorts     []string // lsi sort keys collected as "name:T" where T is one of [SNB]
 	noLSI        bool
 	noSort       bool
+	gsis         []string // gsis collected as "name=<name>,partition=<key>:T[,sort=<key>:T][,projection=<type>][,include=<attr>|<attr>]"
+	noGSI        bool
+
+	ttlAttribute     string
+	streamViewType   string
+	billingMode      string
+	readCapacity     int
+	writeCapacity    int
+	maxReadCapacity  int
+	maxWriteCapacity int
+	pitr             bool
 
 	// RDS Aurora Serverless specific values collected via flags or prompts
 	rdsEngine         string
 	rdsParameterGroup string
 	rdsInitialDBName  string
+
+	// RDS Aurora cluster type and capacity collected via flags.
+	rdsServerlessVersion string
+	rdsMinCapacity       float64
+	rdsMaxCapacity       float64
+	rdsInstanceClass     string
+	rdsReaderCount       int
+	rdsProxy             bool
+
+	// Redis specific values collected via flags.
+	redisNodeType string
+
+	// SQS specific values collected via flags.
+	sqsFIFO bool
+
+	// OpenSearch specific values collected via flags.
+	openSearchInstanceType string
 }
 
 type initStorageOpts struct {
@@ -174,6 +319,10 @@ type initStorageOpts struct {
 	sel    wsSelector
 	prompt prompter
 
+	// Prompt for the DDB settings that weren't specified by flags.
+	promptForGSIs          bool
+	promptForTableSettings bool
+
 	// Cached data.
 	workloadType string
 }
@@ -208,6 +357,9 @@ func (o *initStorageOpts) Validate() error {
 	if o.appName == "" {
 		return errNoAppInWorkspace
 	}
+	if o.envLevel && o.workloadName != "" {
+		return fmt.Errorf("cannot specify both --%s and --%s", storageEnvLevelFlag, workloadFlag)
+	}
 	if o.workloadName != "" {
 		if err := o.validateWorkloadName(); err != nil {
 			return err
@@ -244,6 +396,43 @@ func (o *initStorageOpts) Validate() error {
 			return err
 		}
 	}
+	return o.validateRDSCluster()
+}
+
+func (o *initStorageOpts) validateRDSCluster() error {
+	if o.rdsServerlessVersion != "" && !contains(o.rdsServerlessVersion, rdsServerlessVersions) {
+		return fmt.Errorf("invalid serverless version %s: must be one of %s", o.rdsServerlessVersion, prettify(rdsServerlessVersions))
+	}
+	if o.rdsServerlessVersion != "" && o.rdsInstanceClass != "" {
+		return fmt.Errorf("cannot specify both --%s and --%s", storageRDSServerlessVersionFlag, storageRDSInstanceClassFlag)
+	}
+	hasCapacity := o.rdsMinCapacity != 0 || o.rdsMaxCapacity != 0
+	if hasCapacity && o.rdsInstanceClass != "" {
+		return fmt.Errorf("--%s and --%s can only be used with Aurora Serverless v2", storageRDSMinCapacityFlag, storageRDSMaxCapacityFlag)
+	}
+	if o.rdsServerlessVersion == addon.RDSServerlessVersionV1 {
+		if hasCapacity || o.rdsReaderCount != 0 || o.rdsProxy {
+			return fmt.Errorf("--%s, --%s, --%s and --%s are not supported by Aurora Serverless v1",
+				storageRDSMinCapacityFlag, storageRDSMaxCapacityFlag, storageRDSReaderCountFlag, storageRDSProxyFlag)
+		}
+	}
+	for flag, capacity := range map[string]float64{
+		storageRDSMinCapacityFlag: o.rdsMinCapacity,
+		storageRDSMaxCapacityFlag: o.rdsMaxCapacity,
+	} {
+		if capacity == 0 {
+			continue
+		}
+		if capacity < minRDSCapacity || capacity > maxRDSCapacity || math.Mod(capacity, minRDSCapacity) != 0 {
+			return fmt.Errorf("--%s must be a multiple of %v between %v and %v", flag, minRDSCapacity, minRDSCapacity, maxRDSCapacity)
+		}
+	}
+	if o.rdsMinCapacity != 0 && o.rdsMaxCapacity != 0 && o.rdsMinCapacity > o.rdsMaxCapacity {
+		return fmt.Errorf("--%s must be less than or equal to --%s", storageRDSMinCapacityFlag, storageRDSMaxCapacityFlag)
+	}
+	if o.rdsReaderCount < 0 || o.rdsReaderCount > maxRDSReaderCount {
+		return fmt.Errorf("--%s must be between 0 and %d", storageRDSReaderCountFlag, maxRDSReaderCount)
+	}
 	return nil
 }
 
@@ -272,6 +461,48 @@ func (o *initStorageOpts) validateDDB() error {
 			return err
 		}
 	}
+	// --no-gsi and --gsi are mutually exclusive.
+	if o.noGSI && len(o.gsis) != 0 {
+		return fmt.Errorf("validate GSI configuration: cannot specify --%s and --%s options at once", storageNoGSIFlag, storageGSIConfigFlag)
+	}
+	if len(o.gsis) != 0 {
+		if err := validateGSIs(o.gsis); err != nil {
+			return err
+		}
+	}
+	if o.streamViewType != "" && !contains(o.streamViewType, ddbStreamViewTypes) {
+		return fmt.Errorf("invalid stream view type %s: must be one of %s", o.streamViewType, strings.Join(ddbStreamViewTypes, ", "))
+	}
+	return o.validateDDBCapacity()
+}
+
+func (o *initStorageOpts) validateDDBCapacity() error {
+	switch o.billingMode {
+	case "", ddbBillingModeOnDemand:
+		if o.readCapacity != 0 || o.writeCapacity != 0 || o.maxReadCapacity != 0 || o.maxWriteCapacity != 0 {
+			return fmt.Errorf("capacity can only be configured with --%s %s", storageBillingModeFlag, ddbBillingModeProvisioned)
+		}
+		return nil
+	case ddbBillingModeProvisioned:
+	default:
+		return fmt.Errorf("invalid billing mode %s: must be either %q or %q", o.billingMode, ddbBillingModeOnDemand, ddbBillingModeProvisioned)
+	}
+	for flag, val := range map[string]int{
+		storageReadCapacityFlag:     o.readCapacity,
+		storageWriteCapacityFlag:    o.writeCapacity,
+		storageMaxReadCapacityFlag:  o.maxReadCapacity,
+		storageMaxWriteCapacityFlag: o.maxWriteCapacity,
+	} {
+		if val < 0 {
+			return fmt.Errorf("--%s must be a positive number", flag)
+		}
+	}
+	if o.maxReadCapacity != 0 && o.maxReadCapacity < o.readCapacity {
+		return fmt.Errorf("--%s must be greater than or equal to --%s", storageMaxReadCapacityFlag, storageReadCapacityFlag)
+	}
+	if o.maxWriteCapacity != 0 && o.maxWriteCapacity < o.writeCapacity {
+		return fmt.Errorf("--%s must be greater than or equal to --%s", storageMaxWriteCapacityFlag, storageWriteCapacityFlag)
+	}
 	return nil
 }
 
@@ -298,6 +529,15 @@ func (o *initStorageOpts) Ask() error {
 		if err := o.askDynamoLSIConfig(); err != nil {
 			return err
 		}
+		if err := o.askDynamoGSIConfig(); err != nil {
+			return err
+		}
+		if err := o.askDynamoTableSettings(); err != nil {
+			return err
+		}
+		if err := o.askDynamoCapacity(); err != nil {
+			return err
+		}
 	case rdsStorageType:
 		if err := o.askAuroraEngineType(); err != nil {
 			return err
@@ -318,8 +558,11 @@ func (o *initStorageOpts) askStorageType() error {
 	for _, st := range storageTypes {
 		options = append(options, storageTypeOptions[st])
 	}
-	storageTypeOption, err := o.prompt.SelectOption(fmt.Sprintf(
-		fmtStorageInitTypePrompt, color.HighlightUserInput(o.workloadName)),
+	target := color.HighlightUserInput(o.workloadName)
+	if o.envLevel {
+		target = "all workloads in your environments"
+	}
+	storageTypeOption, err := o.prompt.SelectOption(fmt.Sprintf(fmtStorageInitTypePrompt, target),
 		storageInitTypeHelp,
 		options,
 		prompt.WithFinalMessage("Storage type:"))
@@ -377,7 +620,20 @@ func (o *initStorageOpts) askStorageName() error {
 		validator = dynamoTableNameValidation
 		friendlyText = dynamoDBTableFriendlyText
 	case rdsStorageType:
-		return o.askStorageNameWithDefault(rdsFriendlyText, fmt.Sprintf(fmtRDSStorageNameDefault, o.workloadName), rdsNameValidation)
+		owner := o.workloadName
+		if o.envLevel {
+			owner = o.appName
+		}
+		return o.askStorageNameWithDefault(rdsFriendlyText, fmt.Sprintf(fmtRDSStorageNameDefault, owner), rdsNameValidation)
+	case redisStorageType:
+		validator = dynamoTableNameValidation
+		friendlyText = redisFriendlyText
+	case sqsStorageType:
+		validator = dynamoTableNameValidation
+		friendlyText = sqsFriendlyText
+	case openSearchStorageType:
+		validator = dynamoTableNameValidation
+		friendlyText = openSearchFriendlyText
 	}
 
 	name, err := o.prompt.Get(fmt.Sprintf(fmtStorageInitNamePrompt,
@@ -393,7 +649,7 @@ func (o *initStorageOpts) askStorageName() error {
 }
 
 func (o *initStorageOpts) askStorageWl() error {
-	if o.workloadName != "" {
+	if o.workloadName != "" || o.envLevel {
 		return nil
 	}
 	workload, err := o.sel.Workload(storageInitSvcPrompt, "")
@@ -544,6 +800,219 @@ func (o *initStorageOpts) askDynamoLSIConfig() error {
 	}
 }
 
+func (o *initStorageOpts) askDynamoGSIConfig() error {
+	// GSIs have already been specified by flags, or the user doesn't want any.
+	if !o.promptForGSIs {
+		return nil
+	}
+	moreGSI, err := o.prompt.Confirm(storageInitDDBGSIPrompt, storageInitDDBGSIHelp, prompt.WithFinalMessage("Global secondary indexes?"))
+	if err != nil {
+		return fmt.Errorf("confirm add global secondary index: %w", err)
+	}
+	for moreGSI {
+		if len(o.gsis) >= 20 {
+			log.Infoln("You may not specify more than 20 global secondary indexes. Continuing...")
+			break
+		}
+		gsi, err := o.askDynamoGSI()
+		if err != nil {
+			return err
+		}
+		o.gsis = append(o.gsis, gsi)
+
+		moreGSI, err = o.prompt.Confirm(
+			storageInitDDBMoreGSIPrompt,
+			storageInitDDBGSIHelp,
+			prompt.WithFinalMessage("Global secondary indexes?"),
+		)
+		if err != nil {
+			return fmt.Errorf("confirm add global secondary index: %w", err)
+		}
+	}
+	o.noGSI = len(o.gsis) == 0
+	return nil
+}
+
+// askDynamoGSI prompts for a single global secondary index, and returns it in the same format as the --gsi flag.
+func (o *initStorageOpts) askDynamoGSI() (string, error) {
+	name, err := o.prompt.Get(storageInitDDBGSINamePrompt,
+		storageInitDDBGSINameHelp,
+		dynamoTableNameValidation,
+		prompt.WithFinalMessage("Global secondary index:"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("get DDB global secondary index name: %w", err)
+	}
+	fields := []string{"name=" + name}
+
+	partitionKey, err := o.askDynamoGSIKey(name, "partition key")
+	if err != nil {
+		return "", err
+	}
+	fields = append(fields, "partition="+partitionKey)
+
+	hasSortKey, err := o.prompt.Confirm(storageInitDDBGSISortKeyConfirm, storageInitDDBSortKeyHelp, prompt.WithFinalMessage("Sort key?"))
+	if err != nil {
+		return "", fmt.Errorf("confirm DDB global secondary index sort key: %w", err)
+	}
+	if hasSortKey {
+		sortKey, err := o.askDynamoGSIKey(name, "sort key")
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, "sort="+sortKey)
+	}
+
+	projection, err := o.prompt.SelectOne(storageInitDDBGSIProjectionPrompt,
+		storageInitDDBGSIProjectionHelp,
+		addon.DDBProjectionTypes,
+		prompt.WithFinalMessage("Projection:"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("select DDB global secondary index projection: %w", err)
+	}
+	fields = append(fields, "projection="+projection)
+	if projection != addon.DDBProjectionTypeInclude {
+		return strings.Join(fields, ","), nil
+	}
+	include, err := o.prompt.Get(storageInitDDBGSIIncludePrompt,
+		storageInitDDBGSIIncludeHelp,
+		func(val interface{}) error {
+			if val == "" {
+				return errValueEmpty
+			}
+			return nil
+		},
+		prompt.WithFinalMessage("Included attributes:"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("get DDB global secondary index included attributes: %w", err)
+	}
+	var attrs []string
+	for _, attr := range strings.Split(include, ",") {
+		attrs = append(attrs, strings.TrimSpace(attr))
+	}
+	fields = append(fields, "include="+strings.Join(attrs, "|"))
+	return strings.Join(fields, ","), nil
+}
+
+func (o *initStorageOpts) askDynamoGSIKey(indexName, keyName string) (string, error) {
+	keyPrompt := fmt.Sprintf(fmtStorageInitDDBKeyPrompt, color.Emphasize(keyName), color.HighlightUserInput(indexName))
+	key, err := o.prompt.Get(keyPrompt,
+		"",
+		dynamoAttributeNameValidation,
+		prompt.WithFinalMessage(strings.Title(keyName)+":"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("get DDB global secondary index %s: %w", keyName, err)
+	}
+	keyType, err := o.prompt.SelectOne(fmt.Sprintf(fmtStorageInitDDBKeyTypePrompt, keyName),
+		fmt.Sprintf(fmtStorageInitDDBKeyTypeHelp, keyName),
+		attributeTypes,
+		prompt.WithFinalMessage("Datatype:"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("get DDB global secondary index %s datatype: %w", keyName, err)
+	}
+	return key + ":" + keyType, nil
+}
+
+// askDynamoTableSettings prompts for the table's item expiry, stream, billing mode and backups
+// unless any of them have been specified by flags.
+func (o *initStorageOpts) askDynamoTableSettings() error {
+	if !o.promptForTableSettings {
+		return nil
+	}
+	configure, err := o.prompt.Confirm(storageInitDDBSettingsConfirm, storageInitDDBSettingsHelp, prompt.WithFinalMessage("Configure table settings?"))
+	if err != nil {
+		return fmt.Errorf("confirm configure DDB table settings: %w", err)
+	}
+	if !configure {
+		return nil
+	}
+
+	ttl, err := o.prompt.Get(storageInitDDBTTLPrompt, storageInitDDBTTLHelp, func(val interface{}) error {
+		if val == "" {
+			return nil
+		}
+		return dynamoAttributeNameValidation(val)
+	}, prompt.WithFinalMessage("Expiry attribute:"))
+	if err != nil {
+		return fmt.Errorf("get DDB TTL attribute: %w", err)
+	}
+	o.ttlAttribute = ttl
+
+	stream, err := o.prompt.SelectOne(storageInitDDBStreamPrompt, storageInitDDBStreamHelp,
+		append([]string{ddbStreamViewTypeNoneOption}, ddbStreamViewTypes...),
+		prompt.WithFinalMessage("Stream:"))
+	if err != nil {
+		return fmt.Errorf("select DDB stream view type: %w", err)
+	}
+	if stream != ddbStreamViewTypeNoneOption {
+		o.streamViewType = stream
+	}
+
+	billingMode, err := o.prompt.SelectOne(storageInitDDBBillingModePrompt, storageInitDDBBillingModeHelp,
+		[]string{ddbBillingModeOnDemandOption, ddbBillingModeProvisionedOption},
+		prompt.WithFinalMessage("Billing mode:"))
+	if err != nil {
+		return fmt.Errorf("select DDB billing mode: %w", err)
+	}
+	o.billingMode = ddbBillingModeOptions[billingMode]
+
+	pitr, err := o.prompt.Confirm(storageInitDDBPITRConfirm, storageInitDDBPITRHelp, prompt.WithFinalMessage("Point-in-time recovery?"))
+	if err != nil {
+		return fmt.Errorf("confirm DDB point-in-time recovery: %w", err)
+	}
+	o.pitr = pitr
+	return nil
+}
+
+// askDynamoCapacity prompts for the capacity of a provisioned table if it hasn't been specified by flags.
+func (o *initStorageOpts) askDynamoCapacity() error {
+	if o.billingMode != ddbBillingModeProvisioned || (o.readCapacity != 0 && o.writeCapacity != 0) {
+		return nil
+	}
+	var err error
+	if o.readCapacity == 0 {
+		if o.readCapacity, err = o.askDynamoCapacityUnits(fmt.Sprintf(fmtStorageInitDDBCapacityPrompt, "read"), defaultDDBCapacity, "Read capacity:"); err != nil {
+			return fmt.Errorf("get DDB read capacity: %w", err)
+		}
+	}
+	if o.writeCapacity == 0 {
+		if o.writeCapacity, err = o.askDynamoCapacityUnits(fmt.Sprintf(fmtStorageInitDDBCapacityPrompt, "write"), defaultDDBCapacity, "Write capacity:"); err != nil {
+			return fmt.Errorf("get DDB write capacity: %w", err)
+		}
+	}
+	if o.maxReadCapacity != 0 || o.maxWriteCapacity != 0 {
+		return nil
+	}
+	autoScale, err := o.prompt.Confirm(storageInitDDBAutoScalingConfirm, storageInitDDBAutoScalingHelp, prompt.WithFinalMessage("Auto scaling?"))
+	if err != nil {
+		return fmt.Errorf("confirm DDB auto scaling: %w", err)
+	}
+	if !autoScale {
+		return nil
+	}
+	if o.maxReadCapacity, err = o.askDynamoCapacityUnits(fmt.Sprintf(fmtStorageInitDDBMaxCapacityPrompt, "read"), strconv.Itoa(o.readCapacity*2), "Max read capacity:"); err != nil {
+		return fmt.Errorf("get DDB max read capacity: %w", err)
+	}
+	if o.maxWriteCapacity, err = o.askDynamoCapacityUnits(fmt.Sprintf(fmtStorageInitDDBMaxCapacityPrompt, "write"), strconv.Itoa(o.writeCapacity*2), "Max write capacity:"); err != nil {
+		return fmt.Errorf("get DDB max write capacity: %w", err)
+	}
+	return nil
+}
+
+func (o *initStorageOpts) askDynamoCapacityUnits(msg, defaultUnits, finalMsg string) (int, error) {
+	units, err := o.prompt.Get(msg, "", validatePositiveInt,
+		prompt.WithDefaultInput(defaultUnits),
+		prompt.WithFinalMessage(finalMsg))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(units)
+}
+
 func (o *initStorageOpts) askAuroraEngineType() error {
 	if o.rdsEngine != "" {
 		return nil
@@ -600,8 +1069,12 @@ func (o *initStorageOpts) validateWorkloadName() error {
 }
 
 func (o *initStorageOpts) Execute() error {
-	if err := o.readWorkloadType(); err != nil {
-		return err
+	addonsOwner := workspace.EnvironmentsDirName
+	if !o.envLevel {
+		if err := o.readWorkloadType(); err != nil {
+			return err
+		}
+		addonsOwner = o.workloadName
 	}
 
 	addonBlobs, err := o.addonBlobs()
@@ -609,7 +1082,7 @@ func (o *initStorageOpts) Execute() error {
 		return err
 	}
 	for _, addon := range addonBlobs {
-		path, err := o.ws.WriteAddon(addon.blob, o.workloadName, addon.name)
+		path, err := o.ws.WriteAddon(addon.blob, addonsOwner, addon.name)
 		if err != nil {
 			e, ok := err.(*workspace.ErrFileExists)
 			if !ok {
@@ -672,6 +1145,12 @@ func (o *initStorageOpts) newAddonTemplate() (encoding.BinaryMarshaler, error) {
 		templateBlob, err = o.newS3Template()
 	case rdsStorageType:
 		templateBlob, err = o.newRDSTemplate()
+	case redisStorageType:
+		templateBlob, err = o.newRedisTemplate()
+	case sqsStorageType:
+		templateBlob, err = o.newSQSTemplate()
+	case openSearchStorageType:
+		templateBlob, err = o.newOpenSearchTemplate()
 	}
 	if err != nil {
 		return nil, err
@@ -712,6 +1191,21 @@ func (o *initStorageOpts) newDDBTemplate() (*addon.DynamoDBTemplate, error) {
 		}
 	}
 
+	if err := props.BuildGlobalSecondaryIndexes(o.gsis); err != nil {
+		return nil, err
+	}
+	props.TTLAttribute = o.ttlAttribute
+	props.StreamViewType = o.streamViewType
+	props.PointInTimeRecovery = o.pitr
+	if o.billingMode == ddbBillingModeProvisioned {
+		props.Provisioned = &addon.DDBProvisionedThroughput{
+			ReadCapacity:     o.readCapacity,
+			WriteCapacity:    o.writeCapacity,
+			MaxReadCapacity:  o.maxReadCapacity,
+			MaxWriteCapacity: o.maxWriteCapacity,
+		}
+	}
+
 	return addon.NewDDBTemplate(&props), nil
 }
 
@@ -740,13 +1234,51 @@ func (o *initStorageOpts) newRDSTemplate() (*addon.RDSTemplate, error) {
 		return nil, err
 	}
 
-	return addon.NewRDSTemplate(addon.RDSProps{
+	props := addon.RDSProps{
 		ClusterName:    o.storageName,
 		Engine:         engine,
 		InitialDBName:  o.rdsInitialDBName,
 		ParameterGroup: o.rdsParameterGroup,
 		Envs:           envs,
 		WorkloadType:   o.workloadType,
+
+		ServerlessVersion: o.rdsServerlessVersion,
+		MinCapacity:       o.rdsMinCapacity,
+		MaxCapacity:       o.rdsMaxCapacity,
+		InstanceClass:     o.rdsInstanceClass,
+		ReaderCount:       o.rdsReaderCount,
+		Proxy:             o.rdsProxy,
+	}
+	if props.ServerlessVersion == addon.RDSServerlessVersionV1 {
+		log.Warningln("Aurora Serverless v1 is being retired. We recommend using Aurora Serverless v2 for new clusters.")
+	}
+	return addon.NewRDSTemplate(props), nil
+}
+
+func (o *initStorageOpts) newRedisTemplate() (*addon.RedisTemplate, error) {
+	return addon.NewRedisTemplate(&addon.RedisProps{
+		StorageProps: &addon.StorageProps{
+			Name: o.storageName,
+		},
+		NodeType: o.redisNodeType,
+	}), nil
+}
+
+func (o *initStorageOpts) newSQSTemplate() (*addon.SQSTemplate, error) {
+	return addon.NewSQSTemplate(&addon.SQSProps{
+		StorageProps: &addon.StorageProps{
+			Name: o.storageName,
+		},
+		FIFO: o.sqsFIFO,
+	}), nil
+}
+
+func (o *initStorageOpts) newOpenSearchTemplate() (*addon.OpenSearchTemplate, error) {
+	return addon.NewOpenSearchTemplate(&addon.OpenSearchProps{
+		StorageProps: &addon.StorageProps{
+			Name: o.storageName,
+		},
+		InstanceType: o.openSearchInstanceType,
 	}), nil
 }
 
@@ -776,6 +1308,9 @@ func (o *initStorageOpts) readWorkloadType() error {
 }
 
 func (o *initStorageOpts) RecommendActions() error {
+	if o.envLevel {
+		return o.recommendEnvLevelActions()
+	}
 	var (
 		retrieveEnvVarCode string
 		newVar             string
@@ -796,6 +1331,24 @@ const client = new AWS.SecretsManager({
 const dbSecret = await client.getSecretValue({SecretId: process.env.%s}).promise();
 const {username, host, dbname, password, port} = JSON.parse(dbSecret.SecretString);`, newVar)
 		}
+		if o.rdsProxy && o.workloadType != manifest.RequestDrivenWebServiceType {
+			proxyVar := template.ToSnakeCaseFunc(template.StripNonAlphaNumFunc(o.storageName) + "ProxyEndpoint")
+			newVar = fmt.Sprintf("%s and %s", newVar, proxyVar)
+			retrieveEnvVarCode = fmt.Sprintf("%s\n// Connect through the RDS Proxy instead of the cluster's host.\nconst proxyHost = process.env.%s", retrieveEnvVarCode, proxyVar)
+		}
+	case redisStorageType:
+		prefix := template.ToSnakeCaseFunc(template.StripNonAlphaNumFunc(o.storageName))
+		newVar = fmt.Sprintf("%s_ENDPOINT, %s_PORT and %s_AUTH_TOKEN", prefix, prefix, prefix)
+		retrieveEnvVarCode = fmt.Sprintf(`const client = redis.createClient({
+    url: `+"`rediss://${process.env.%s_ENDPOINT}:${process.env.%s_PORT}`"+`,
+    password: process.env.%s_AUTH_TOKEN,
+});`, prefix, prefix, prefix)
+	case sqsStorageType:
+		newVar = template.ToSnakeCaseFunc(template.StripNonAlphaNumFunc(o.storageName) + "URL")
+		retrieveEnvVarCode = fmt.Sprintf("const queueUrl = process.env.%s", newVar)
+	case openSearchStorageType:
+		newVar = template.ToSnakeCaseFunc(template.StripNonAlphaNumFunc(o.storageName) + "Endpoint")
+		retrieveEnvVarCode = fmt.Sprintf("const node = `https://${process.env.%s}`", newVar)
 	}
 
 	actionRetrieveEnvVar := fmt.Sprintf(
@@ -815,6 +1368,76 @@ For example, in JavaScript you can write:
 	return nil
 }
 
+func (o *initStorageOpts) recommendEnvLevelActions() error {
+	imports := o.envAddonsImports()
+	var fields []string
+	for _, field := range []struct {
+		name    string
+		outputs []string
+	}{
+		{"variables", imports.Variables},
+		{"secrets", imports.Secrets},
+		{"policies", imports.Policies},
+		{"security_groups", imports.SecurityGroups},
+	} {
+		if len(field.outputs) == 0 {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("  %s: [%s]", field.name, strings.Join(field.outputs, ", ")))
+	}
+	actionImport := fmt.Sprintf(`Update the manifest of each workload that uses %s with:
+%s`,
+		o.storageName,
+		color.HighlightCodeBlock("env_addons:\n"+strings.Join(fields, "\n")))
+
+	deployCmd := "copilot env upgrade --all"
+	actionDeploy := fmt.Sprintf("Run %s to deploy your storage resources with your environments, then redeploy the workloads.", color.HighlightCode(deployCmd))
+	logRecommendedActions([]string{
+		actionImport,
+		actionDeploy,
+	})
+	return nil
+}
+
+// envAddonsImports returns the outputs of the environment-level storage template that workloads should import.
+func (o *initStorageOpts) envAddonsImports() manifest.EnvAddonsImports {
+	id := template.StripNonAlphaNumFunc(o.storageName)
+	switch o.storageType {
+	case dynamoDBStorageType, s3StorageType:
+		return manifest.EnvAddonsImports{
+			Variables: []string{template.EnvVarNameFunc(o.storageName)},
+			Policies:  []string{id + "AccessPolicy"},
+		}
+	case rdsStorageType:
+		imports := manifest.EnvAddonsImports{
+			Secrets:        []string{id + "Secret"},
+			SecurityGroups: []string{id + "SecurityGroup"},
+		}
+		if o.rdsProxy {
+			imports.Variables = []string{id + "ProxyEndpoint"}
+		}
+		return imports
+	case redisStorageType:
+		return manifest.EnvAddonsImports{
+			Variables:      []string{id + "Endpoint", id + "Port"},
+			Secrets:        []string{id + "AuthToken"},
+			SecurityGroups: []string{id + "SecurityGroup"},
+		}
+	case sqsStorageType:
+		return manifest.EnvAddonsImports{
+			Variables: []string{id + "URL"},
+			Policies:  []string{id + "AccessPolicy"},
+		}
+	case openSearchStorageType:
+		return manifest.EnvAddonsImports{
+			Variables:      []string{id + "Endpoint"},
+			Policies:       []string{id + "AccessPolicy"},
+			SecurityGroups: []string{id + "SecurityGroup"},
+		}
+	}
+	return manifest.EnvAddonsImports{}
+}
+
 // buildStorageInitCmd builds the command and adds it to the CLI.
 func buildStorageInitCmd() *cobra.Command {
 	vars := initStorageVars{}
@@ -831,34 +1454,78 @@ Resource names are injected into your containers as environment variables for ea
   /code $ copilot storage init -n my-table -t DynamoDB -w frontend --partition-key Email:S --sort-key UserId:N --no-lsi
   Create a DynamoDB table with multiple alternate sort keys.
   /code $ copilot storage init -n my-table -t DynamoDB -w frontend --partition-key Email:S --sort-key UserId:N --lsi Points:N --lsi Goodness:N
-  Create an RDS Aurora Serverless cluster using PostgreSQL as the database engine.
-  /code $ copilot storage init -n my-cluster -t Aurora -w frontend --engine PostgreSQL`,
+  Create a provisioned DynamoDB table with a global secondary index, item expiry and auto scaling.
+  /code $ copilot storage init -n my-table -t DynamoDB -w frontend --partition-key UserId:S --no-sort \
+  /code   --gsi name=byEmail,partition=Email:S,projection=KEYS_ONLY --ttl-attribute ExpiresAt \
+  /code   --billing-mode provisioned --read-capacity 5 --write-capacity 5 --max-read-capacity 50 --max-write-capacity 20
+  Create an RDS Aurora Serverless v2 cluster using PostgreSQL as the database engine.
+  /code $ copilot storage init -n my-cluster -t Aurora -w frontend --engine PostgreSQL
+  Create a provisioned RDS Aurora cluster with a reader instance behind an RDS Proxy.
+  /code $ copilot storage init -n my-cluster -t Aurora -w frontend --engine MySQL --db-instance-class db.r6g.large --readers 1 --proxy
+  Create an ElastiCache Redis replication group with larger nodes.
+  /code $ copilot storage init -n my-cache -t Redis -w frontend --node-type cache.m6g.large
+  Create a FIFO SQS queue.
+  /code $ copilot storage init -n my-queue -t SQS -w worker --fifo
+  Create an OpenSearch domain.
+  /code $ copilot storage init -n my-search -t OpenSearch -w frontend
+  Create a DynamoDB table shared by all workloads in an environment.
+  /code $ copilot storage init -n my-table -t DynamoDB --env-level --partition-key UserId:S --no-sort`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newStorageInitOpts(vars)
 			if err != nil {
 				return err
 			}
+			opts.promptForGSIs = !cmd.Flags().Changed(storageGSIConfigFlag) && !cmd.Flags().Changed(storageNoGSIFlag)
+			opts.promptForTableSettings = true
+			for _, flag := range []string{storageTTLAttributeFlag, storageStreamViewTypeFlag, storageBillingModeFlag,
+				storageReadCapacityFlag, storageWriteCapacityFlag, storageMaxReadCapacityFlag, storageMaxWriteCapacityFlag, storagePITRFlag} {
+				if cmd.Flags().Changed(flag) {
+					opts.promptForTableSettings = false
+				}
+			}
 			return run(opts)
 		}),
 	}
 	cmd.Flags().StringVarP(&vars.storageName, nameFlag, nameFlagShort, "", storageFlagDescription)
 	cmd.Flags().StringVarP(&vars.storageType, storageTypeFlag, typeFlagShort, "", storageTypeFlagDescription)
 	cmd.Flags().StringVarP(&vars.workloadName, workloadFlag, workloadFlagShort, "", storageWorkloadFlagDescription)
+	cmd.Flags().BoolVar(&vars.envLevel, storageEnvLevelFlag, false, storageEnvLevelFlagDescription)
 
 	cmd.Flags().StringVar(&vars.partitionKey, storagePartitionKeyFlag, "", storagePartitionKeyFlagDescription)
 	cmd.Flags().StringVar(&vars.sortKey, storageSortKeyFlag, "", storageSortKeyFlagDescription)
 	cmd.Flags().StringArrayVar(&vars.lsiSorts, storageLSIConfigFlag, []string{}, storageLSIConfigFlagDescription)
 	cmd.Flags().BoolVar(&vars.noLSI, storageNoLSIFlag, false, storageNoLSIFlagDescription)
 	cmd.Flags().BoolVar(&vars.noSort, storageNoSortFlag, false, storageNoSortFlagDescription)
+	cmd.Flags().StringArrayVar(&vars.gsis, storageGSIConfigFlag, []string{}, storageGSIConfigFlagDescription)
+	cmd.Flags().BoolVar(&vars.noGSI, storageNoGSIFlag, false, storageNoGSIFlagDescription)
+	cmd.Flags().StringVar(&vars.ttlAttribute, storageTTLAttributeFlag, "", storageTTLAttributeFlagDescription)
+	cmd.Flags().StringVar(&vars.streamViewType, storageStreamViewTypeFlag, "", storageStreamViewTypeFlagDescription)
+	cmd.Flags().StringVar(&vars.billingMode, storageBillingModeFlag, ddbBillingModeOnDemand, storageBillingModeFlagDescription)
+	cmd.Flags().IntVar(&vars.readCapacity, storageReadCapacityFlag, 0, storageReadCapacityFlagDescription)
+	cmd.Flags().IntVar(&vars.writeCapacity, storageWriteCapacityFlag, 0, storageWriteCapacityFlagDescription)
+	cmd.Flags().IntVar(&vars.maxReadCapacity, storageMaxReadCapacityFlag, 0, storageMaxReadCapacityFlagDescription)
+	cmd.Flags().IntVar(&vars.maxWriteCapacity, storageMaxWriteCapacityFlag, 0, storageMaxWriteCapacityFlagDescription)
+	cmd.Flags().BoolVar(&vars.pitr, storagePITRFlag, false, storagePITRFlagDescription)
 
 	cmd.Flags().StringVar(&vars.rdsEngine, storageRDSEngineFlag, "", storageRDSEngineFlagDescription)
 	cmd.Flags().StringVar(&vars.rdsInitialDBName, storageRDSInitialDBFlag, "", storageRDSInitialDBFlagDescription)
 	cmd.Flags().StringVar(&vars.rdsParameterGroup, storageRDSParameterGroupFlag, "", storageRDSParameterGroupFlagDescription)
+	cmd.Flags().StringVar(&vars.rdsServerlessVersion, storageRDSServerlessVersionFlag, "", storageRDSServerlessVersionFlagDescription)
+	cmd.Flags().Float64Var(&vars.rdsMinCapacity, storageRDSMinCapacityFlag, 0, storageRDSMinCapacityFlagDescription)
+	cmd.Flags().Float64Var(&vars.rdsMaxCapacity, storageRDSMaxCapacityFlag, 0, storageRDSMaxCapacityFlagDescription)
+	cmd.Flags().StringVar(&vars.rdsInstanceClass, storageRDSInstanceClassFlag, "", storageRDSInstanceClassFlagDescription)
+	cmd.Flags().IntVar(&vars.rdsReaderCount, storageRDSReaderCountFlag, 0, storageRDSReaderCountFlagDescription)
+	cmd.Flags().BoolVar(&vars.rdsProxy, storageRDSProxyFlag, false, storageRDSProxyFlagDescription)
+
+	cmd.Flags().StringVar(&vars.redisNodeType, storageRedisNodeTypeFlag, defaultRedisNodeType, storageRedisNodeTypeFlagDescription)
+	cmd.Flags().BoolVar(&vars.sqsFIFO, storageSQSFIFOFlag, false, storageSQSFIFOFlagDescription)
+	cmd.Flags().StringVar(&vars.openSearchInstanceType, storageOpenSearchInstanceTypeFlag, defaultOpenSearchInstanceType, storageOpenSearchInstanceTypeFlagDescription)
 
 	requiredFlags := pflag.NewFlagSet("Required", pflag.ContinueOnError)
 	requiredFlags.AddFlag(cmd.Flags().Lookup(nameFlag))
 	requiredFlags.AddFlag(cmd.Flags().Lookup(storageTypeFlag))
 	requiredFlags.AddFlag(cmd.Flags().Lookup(workloadFlag))
+	requiredFlags.AddFlag(cmd.Flags().Lookup(storageEnvLevelFlag))
 
 	ddbFlags := pflag.NewFlagSet("DynamoDB", pflag.ContinueOnError)
 	ddbFlags.AddFlag(cmd.Flags().Lookup(storagePartitionKeyFlag))
@@ -866,18 +1533,46 @@ Resource names are injected into your containers as environment variables for ea
 	ddbFlags.AddFlag(cmd.Flags().Lookup(storageNoSortFlag))
 	ddbFlags.AddFlag(cmd.Flags().Lookup(storageLSIConfigFlag))
 	ddbFlags.AddFlag(cmd.Flags().Lookup(storageNoLSIFlag))
-
-	auroraFlags := pflag.NewFlagSet("Aurora Serverless", pflag.ContinueOnError)
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageGSIConfigFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageNoGSIFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageTTLAttributeFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageStreamViewTypeFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageBillingModeFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageReadCapacityFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageWriteCapacityFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageMaxReadCapacityFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storageMaxWriteCapacityFlag))
+	ddbFlags.AddFlag(cmd.Flags().Lookup(storagePITRFlag))
+
+	auroraFlags := pflag.NewFlagSet("Aurora", pflag.ContinueOnError)
 	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSEngineFlag))
 	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSInitialDBFlag))
 	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSParameterGroupFlag))
+	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSServerlessVersionFlag))
+	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSMinCapacityFlag))
+	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSMaxCapacityFlag))
+	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSInstanceClassFlag))
+	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSReaderCountFlag))
+	auroraFlags.AddFlag(cmd.Flags().Lookup(storageRDSProxyFlag))
+
+	redisFlags := pflag.NewFlagSet("Redis", pflag.ContinueOnError)
+	redisFlags.AddFlag(cmd.Flags().Lookup(storageRedisNodeTypeFlag))
+
+	sqsFlags := pflag.NewFlagSet("SQS", pflag.ContinueOnError)
+	sqsFlags.AddFlag(cmd.Flags().Lookup(storageSQSFIFOFlag))
+
+	openSearchFlags := pflag.NewFlagSet("OpenSearch", pflag.ContinueOnError)
+	openSearchFlags.AddFlag(cmd.Flags().Lookup(storageOpenSearchInstanceTypeFlag))
 
 	cmd.Annotations = map[string]string{
 		// The order of the sections we want to display.
-		"sections":          `Required,DynamoDB,Aurora Serverless`,
-		"Required":          requiredFlags.FlagUsages(),
-		"DynamoDB":          ddbFlags.FlagUsages(),
-		"Aurora Serverless": auroraFlags.FlagUsages(),
+		"sections":   `Required,DynamoDB,Aurora,Redis,SQS,OpenSearch`,
+		"Required":   requiredFlags.FlagUsages(),
+		"DynamoDB":   ddbFlags.FlagUsages(),
+		"Aurora":     auroraFlags.FlagUsages(),
+		"Redis":      redisFlags.FlagUsages(),
+		"SQS":        sqsFlags.FlagUsages(),
+		"OpenSearch": openSearchFlags.FlagUsages(),
 	}
 	cmd.SetUsageTemplate(`{{h1 "Usage"}}{{if .Runnable}}
   {{.UseLine}}{{end}}{{$annotations := .Annotations}}{{$sections := split .Annotations.sections ","}}{{if gt (len $sections) 0}}
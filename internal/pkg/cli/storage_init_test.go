@@ -4,6 +4,7 @@
 package cli
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"testing"
@@ -29,8 +30,22 @@ func TestStorageInitOpts_Validate(t *testing.T) {
 		inLSISorts    []string
 		inNoSort      bool
 		inNoLSI       bool
+		inGSIs        []string
+		inNoGSI       bool
+		inStreamView  string
+		inBillingMode string
+		inReadCap     int
+		inMaxReadCap  int
 		inEngine      string
 
+		inServerlessVersion string
+		inInstanceClass     string
+		inMinCapacity       float64
+		inMaxCapacity       float64
+		inReaderCount       int
+		inProxy             bool
+		inEnvLevel          bool
+
 		mockWs    func(m *mocks.MockwsAddonManager)
 		mockStore func(m *mocks.Mockstore)
 
@@ -42,6 +57,25 @@ func TestStorageInitOpts_Validate(t *testing.T) {
 
 			wantedErr: errNoAppInWorkspace,
 		},
+		"cannot specify both env level and workload": {
+			inAppName:  "bowie",
+			inSvcName:  "frontend",
+			inEnvLevel: true,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("cannot specify both --env-level and --workload"),
+		},
+		"successfully validates env level storage": {
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inStorageName: "users",
+			inEnvLevel:    true,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+		},
 		"svc not in workspace": {
 			mockWs: func(m *mocks.MockwsAddonManager) {
 				m.EXPECT().ListWorkloads().Return([]string{"bad", "workspace"}, nil)
@@ -164,6 +198,68 @@ func TestStorageInitOpts_Validate(t *testing.T) {
 			inNoSort:      true,
 			wantedErr:     fmt.Errorf("validate LSI configuration: cannot specify --no-sort and --lsi options at once"),
 		},
+		"fails when --no-gsi and --gsi are both provided": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inGSIs:        []string{"name=byEmail,partition=Email:S"},
+			inNoGSI:       true,
+			wantedErr:     fmt.Errorf("validate GSI configuration: cannot specify --no-gsi and --gsi options at once"),
+		},
+		"fails on invalid GSI": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inGSIs:        []string{"name=byEmail"},
+			wantedErr:     fmt.Errorf("parse global secondary index name=byEmail: name and partition are required"),
+		},
+		"fails on invalid stream view type": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inStreamView:  "ALL",
+			wantedErr:     fmt.Errorf("invalid stream view type ALL: must be one of NEW_IMAGE, OLD_IMAGE, NEW_AND_OLD_IMAGES, KEYS_ONLY"),
+		},
+		"fails on invalid billing mode": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inBillingMode: "reserved",
+			wantedErr:     fmt.Errorf(`invalid billing mode reserved: must be either "on-demand" or "provisioned"`),
+		},
+		"fails when capacity is provided for an on-demand table": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inReadCap:     5,
+			wantedErr:     fmt.Errorf("capacity can only be configured with --billing-mode provisioned"),
+		},
+		"fails when max capacity is lower than capacity": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inBillingMode: ddbBillingModeProvisioned,
+			inReadCap:     10,
+			inMaxReadCap:  5,
+			wantedErr:     fmt.Errorf("--max-read-capacity must be greater than or equal to --read-capacity"),
+		},
+		"successfully validates provisioned capacity": {
+			mockWs:        func(m *mocks.MockwsAddonManager) {},
+			mockStore:     func(m *mocks.Mockstore) {},
+			inAppName:     "bowie",
+			inStorageType: dynamoDBStorageType,
+			inGSIs:        []string{"name=byEmail,partition=Email:S"},
+			inStreamView:  "NEW_AND_OLD_IMAGES",
+			inBillingMode: ddbBillingModeProvisioned,
+			inReadCap:     5,
+			inMaxReadCap:  50,
+		},
 		"invalid database engine type": {
 			inAppName: "meow",
 			inEngine:  "mysql",
@@ -173,6 +269,84 @@ func TestStorageInitOpts_Validate(t *testing.T) {
 
 			wantedErr: errors.New("invalid engine type mysql: must be one of \"MySQL\", \"PostgreSQL\""),
 		},
+		"invalid serverless version": {
+			inAppName:           "meow",
+			inServerlessVersion: "v3",
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New(`invalid serverless version v3: must be one of "v1", "v2"`),
+		},
+		"fails when both serverless version and instance class are provided": {
+			inAppName:           "meow",
+			inServerlessVersion: "v2",
+			inInstanceClass:     "db.r6g.large",
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("cannot specify both --serverless-version and --db-instance-class"),
+		},
+		"fails when capacity is provided for a provisioned cluster": {
+			inAppName:       "meow",
+			inInstanceClass: "db.r6g.large",
+			inMaxCapacity:   16,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("--min-capacity and --max-capacity can only be used with Aurora Serverless v2"),
+		},
+		"fails when a proxy is requested for a serverless v1 cluster": {
+			inAppName:           "meow",
+			inServerlessVersion: "v1",
+			inProxy:             true,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("--min-capacity, --max-capacity, --readers and --proxy are not supported by Aurora Serverless v1"),
+		},
+		"fails when capacity is not a multiple of 0.5": {
+			inAppName:     "meow",
+			inMinCapacity: 0.7,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("--min-capacity must be a multiple of 0.5 between 0.5 and 128"),
+		},
+		"fails when min capacity is greater than max capacity": {
+			inAppName:     "meow",
+			inMinCapacity: 16,
+			inMaxCapacity: 8,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("--min-capacity must be less than or equal to --max-capacity"),
+		},
+		"fails when there are too many readers": {
+			inAppName:     "meow",
+			inReaderCount: 16,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedErr: errors.New("--readers must be between 0 and 15"),
+		},
+		"successfully validates a serverless v2 cluster with a proxy": {
+			inAppName:           "meow",
+			inServerlessVersion: "v2",
+			inMinCapacity:       1,
+			inMaxCapacity:       32,
+			inReaderCount:       2,
+			inProxy:             true,
+
+			mockWs:    func(m *mocks.MockwsAddonManager) {},
+			mockStore: func(m *mocks.Mockstore) {},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -193,7 +367,24 @@ func TestStorageInitOpts_Validate(t *testing.T) {
 					lsiSorts:     tc.inLSISorts,
 					noLSI:        tc.inNoLSI,
 					noSort:       tc.inNoSort,
-					rdsEngine:    tc.inEngine,
+					gsis:         tc.inGSIs,
+					noGSI:        tc.inNoGSI,
+
+					streamViewType:  tc.inStreamView,
+					billingMode:     tc.inBillingMode,
+					readCapacity:    tc.inReadCap,
+					maxReadCapacity: tc.inMaxReadCap,
+
+					rdsEngine: tc.inEngine,
+
+					rdsServerlessVersion: tc.inServerlessVersion,
+					rdsInstanceClass:     tc.inInstanceClass,
+					rdsMinCapacity:       tc.inMinCapacity,
+					rdsMaxCapacity:       tc.inMaxCapacity,
+					rdsReaderCount:       tc.inReaderCount,
+					rdsProxy:             tc.inProxy,
+
+					envLevel: tc.inEnvLevel,
 				},
 				appName: tc.inAppName,
 				ws:      mockWs,
@@ -236,8 +427,12 @@ func TestStorageInitOpts_Ask(t *testing.T) {
 		inNoLSI       bool
 		inNoSort      bool
 
+		inPromptForGSIs          bool
+		inPromptForTableSettings bool
+
 		inDBEngine      string
 		inInitialDBName string
+		inEnvLevel      bool
 
 		mockPrompt func(m *mocks.Mockprompter)
 		mockCfg    func(m *mocks.MockwsSelector)
@@ -266,6 +461,18 @@ func TestStorageInitOpts_Ask(t *testing.T) {
 						Value: rdsStorageTypeOption,
 						Hint:  "SQL",
 					},
+					{
+						Value: redisStorageTypeOption,
+						Hint:  "In-memory cache",
+					},
+					{
+						Value: sqsStorageTypeOption,
+						Hint:  "Queue",
+					},
+					{
+						Value: openSearchStorageTypeOption,
+						Hint:  "Search",
+					},
 				}
 				m.EXPECT().SelectOption(gomock.Any(), gomock.Any(), gomock.Eq(options), gomock.Any()).Return(s3StorageType, nil)
 			},
@@ -273,6 +480,42 @@ func TestStorageInitOpts_Ask(t *testing.T) {
 
 			wantedErr: nil,
 		},
+		"does not ask for a workload for env level storage": {
+			inAppName:     wantedAppName,
+			inStorageType: s3StorageType,
+			inStorageName: wantedBucketName,
+			inEnvLevel:    true,
+
+			mockPrompt: func(m *mocks.Mockprompter) {},
+			mockCfg:    func(m *mocks.MockwsSelector) {},
+
+			wantedVars: &initStorageVars{
+				storageType: s3StorageType,
+				storageName: wantedBucketName,
+				envLevel:    true,
+			},
+		},
+		"asks for an aurora cluster name defaulting to the app name for env level storage": {
+			inAppName:       wantedAppName,
+			inStorageType:   rdsStorageType,
+			inDBEngine:      wantedDBEngine,
+			inInitialDBName: wantedInitialDBName,
+			inEnvLevel:      true,
+
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("ddos-cluster", nil)
+			},
+			mockCfg: func(m *mocks.MockwsSelector) {},
+
+			wantedVars: &initStorageVars{
+				storageType:      rdsStorageType,
+				storageName:      "ddos-cluster",
+				rdsEngine:        wantedDBEngine,
+				rdsInitialDBName: wantedInitialDBName,
+				envLevel:         true,
+			},
+		},
 		"error if storage type not gotten": {
 			inAppName:     wantedAppName,
 			inSvcName:     wantedSvcName,
@@ -678,6 +921,122 @@ func TestStorageInitOpts_Ask(t *testing.T) {
 				noSort:       true,
 			},
 		},
+		"ask for GSI if not specified": {
+			inAppName:     wantedAppName,
+			inSvcName:     wantedSvcName,
+			inStorageType: dynamoDBStorageType,
+			inStorageName: wantedTableName,
+			inPartition:   wantedPartitionKey,
+			inNoSort:      true,
+
+			inPromptForGSIs: true,
+
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBGSIPrompt), gomock.Eq(storageInitDDBGSIHelp), gomock.Any()).Return(true, nil)
+				m.EXPECT().Get(gomock.Eq(storageInitDDBGSINamePrompt), gomock.Eq(storageInitDDBGSINameHelp), gomock.Any(), gomock.Any()).Return("byEmail", nil)
+				m.EXPECT().Get(gomock.Eq(fmt.Sprintf(fmtStorageInitDDBKeyPrompt, color.Emphasize("partition key"), color.HighlightUserInput("byEmail"))), gomock.Any(), gomock.Any(), gomock.Any()).Return("Email", nil)
+				m.EXPECT().SelectOne(gomock.Eq(fmt.Sprintf(fmtStorageInitDDBKeyTypePrompt, "partition key")), gomock.Any(), gomock.Eq(attributeTypes), gomock.Any()).Return(ddbStringType, nil)
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBGSISortKeyConfirm), gomock.Any(), gomock.Any()).Return(false, nil)
+				m.EXPECT().SelectOne(gomock.Eq(storageInitDDBGSIProjectionPrompt), gomock.Any(), gomock.Any(), gomock.Any()).Return("INCLUDE", nil)
+				m.EXPECT().Get(gomock.Eq(storageInitDDBGSIIncludePrompt), gomock.Any(), gomock.Any(), gomock.Any()).Return("Name, Age", nil)
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBMoreGSIPrompt), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			mockCfg: func(m *mocks.MockwsSelector) {},
+			wantedVars: &initStorageVars{
+				storageName:  wantedTableName,
+				workloadName: wantedSvcName,
+				storageType:  dynamoDBStorageType,
+
+				partitionKey: wantedPartitionKey,
+				noLSI:        true,
+				noSort:       true,
+				gsis:         []string{"name=byEmail,partition=Email:String,projection=INCLUDE,include=Name|Age"},
+			},
+		},
+		"error if GSI name not returned": {
+			inAppName:     wantedAppName,
+			inSvcName:     wantedSvcName,
+			inStorageType: dynamoDBStorageType,
+			inStorageName: wantedTableName,
+			inPartition:   wantedPartitionKey,
+			inNoSort:      true,
+
+			inPromptForGSIs: true,
+
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBGSIPrompt), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.EXPECT().Get(gomock.Eq(storageInitDDBGSINamePrompt), gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+			},
+			mockCfg: func(m *mocks.MockwsSelector) {},
+
+			wantedErr: fmt.Errorf("get DDB global secondary index name: some error"),
+		},
+		"ask for table settings and capacity if not specified": {
+			inAppName:     wantedAppName,
+			inSvcName:     wantedSvcName,
+			inStorageType: dynamoDBStorageType,
+			inStorageName: wantedTableName,
+			inPartition:   wantedPartitionKey,
+			inNoSort:      true,
+
+			inPromptForTableSettings: true,
+
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBSettingsConfirm), gomock.Eq(storageInitDDBSettingsHelp), gomock.Any()).Return(true, nil)
+				m.EXPECT().Get(gomock.Eq(storageInitDDBTTLPrompt), gomock.Eq(storageInitDDBTTLHelp), gomock.Any(), gomock.Any()).Return("ExpiresAt", nil)
+				m.EXPECT().SelectOne(gomock.Eq(storageInitDDBStreamPrompt), gomock.Any(), gomock.Eq([]string{"None", "NEW_IMAGE", "OLD_IMAGE", "NEW_AND_OLD_IMAGES", "KEYS_ONLY"}), gomock.Any()).Return("NEW_IMAGE", nil)
+				m.EXPECT().SelectOne(gomock.Eq(storageInitDDBBillingModePrompt), gomock.Any(), gomock.Any(), gomock.Any()).Return(ddbBillingModeProvisionedOption, nil)
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBPITRConfirm), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.EXPECT().Get(gomock.Eq(fmt.Sprintf(fmtStorageInitDDBCapacityPrompt, "read")), gomock.Any(), gomock.Any(), gomock.Any()).Return("10", nil)
+				m.EXPECT().Get(gomock.Eq(fmt.Sprintf(fmtStorageInitDDBCapacityPrompt, "write")), gomock.Any(), gomock.Any(), gomock.Any()).Return("5", nil)
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBAutoScalingConfirm), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.EXPECT().Get(gomock.Eq(fmt.Sprintf(fmtStorageInitDDBMaxCapacityPrompt, "read")), gomock.Any(), gomock.Any(), gomock.Any()).Return("100", nil)
+				m.EXPECT().Get(gomock.Eq(fmt.Sprintf(fmtStorageInitDDBMaxCapacityPrompt, "write")), gomock.Any(), gomock.Any(), gomock.Any()).Return("50", nil)
+			},
+			mockCfg: func(m *mocks.MockwsSelector) {},
+			wantedVars: &initStorageVars{
+				storageName:  wantedTableName,
+				workloadName: wantedSvcName,
+				storageType:  dynamoDBStorageType,
+
+				partitionKey: wantedPartitionKey,
+				noLSI:        true,
+				noSort:       true,
+
+				ttlAttribute:     "ExpiresAt",
+				streamViewType:   "NEW_IMAGE",
+				billingMode:      ddbBillingModeProvisioned,
+				readCapacity:     10,
+				writeCapacity:    5,
+				maxReadCapacity:  100,
+				maxWriteCapacity: 50,
+				pitr:             true,
+			},
+		},
+		"skip table settings if declined": {
+			inAppName:     wantedAppName,
+			inSvcName:     wantedSvcName,
+			inStorageType: dynamoDBStorageType,
+			inStorageName: wantedTableName,
+			inPartition:   wantedPartitionKey,
+			inNoSort:      true,
+
+			inPromptForTableSettings: true,
+
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Confirm(gomock.Eq(storageInitDDBSettingsConfirm), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			mockCfg: func(m *mocks.MockwsSelector) {},
+			wantedVars: &initStorageVars{
+				storageName:  wantedTableName,
+				workloadName: wantedSvcName,
+				storageType:  dynamoDBStorageType,
+
+				partitionKey: wantedPartitionKey,
+				noLSI:        true,
+				noSort:       true,
+			},
+		},
 		"error if lsi name misspecified": {
 			inAppName:     wantedAppName,
 			inSvcName:     wantedSvcName,
@@ -874,11 +1233,15 @@ func TestStorageInitOpts_Ask(t *testing.T) {
 
 					rdsEngine:        tc.inDBEngine,
 					rdsInitialDBName: tc.inInitialDBName,
+					envLevel:         tc.inEnvLevel,
 				},
 				appName: tc.inAppName,
 				sel:     mockConfig,
 				prompt:  mockPrompt,
 				ws:      mockWS,
+
+				promptForGSIs:          tc.inPromptForGSIs,
+				promptForTableSettings: tc.inPromptForTableSettings,
 			}
 			tc.mockPrompt(mockPrompt)
 			tc.mockCfg(mockConfig)
@@ -920,10 +1283,29 @@ func TestStorageInitOpts_Execute(t *testing.T) {
 		inLSISorts  []string
 		inNoLSI     bool
 		inNoSort    bool
-
-		inEngine         string
-		inInitialDBName  string
-		inParameterGroup string
+		inGSIs      []string
+		inTTL       string
+		inStream    string
+		inBilling   string
+		inReadCap   int
+		inWriteCap  int
+		inMaxRead   int
+		inPITR      bool
+
+		inEngine            string
+		inInitialDBName     string
+		inParameterGroup    string
+		inServerlessVersion string
+		inInstanceClass     string
+		inMaxCapacity       float64
+		inReaderCount       int
+		inProxy             bool
+
+		inNodeType     string
+		inFIFO         bool
+		inInstanceType string
+
+		inEnvLevel bool
 
 		mockWs    func(m *mocks.MockwsAddonManager)
 		mockStore func(m *mocks.Mockstore)
@@ -975,6 +1357,38 @@ func TestStorageInitOpts_Execute(t *testing.T) {
 
 			wantedErr: nil,
 		},
+		"happy calls for DDB with GSI and table settings": {
+			inAppName:     wantedAppName,
+			inStorageType: dynamoDBStorageType,
+			inSvcName:     wantedSvcName,
+			inStorageName: "my-table",
+			inPartition:   wantedPartitionKey,
+			inNoSort:      true,
+			inGSIs:        []string{"name=byPhoto,partition=PhotoId:Number,projection=KEYS_ONLY"},
+			inTTL:         "ExpiresAt",
+			inStream:      "NEW_IMAGE",
+			inBilling:     ddbBillingModeProvisioned,
+			inReadCap:     5,
+			inWriteCap:    5,
+			inMaxRead:     50,
+			inPITR:        true,
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().ReadWorkloadManifest(wantedSvcName).Return([]byte("type: Backend Service"), nil)
+				m.EXPECT().WriteAddon(gomock.Any(), wantedSvcName, "my-table").DoAndReturn(func(f encoding.BinaryMarshaler, _, _ string) (string, error) {
+					b, err := f.MarshalBinary()
+					require.NoError(t, err)
+					require.Contains(t, string(b), "IndexName: byPhoto")
+					require.Contains(t, string(b), "AttributeName: ExpiresAt")
+					require.Contains(t, string(b), "StreamViewType: NEW_IMAGE")
+					require.Contains(t, string(b), "BillingMode: PROVISIONED")
+					require.Contains(t, string(b), "PointInTimeRecoveryEnabled: true")
+					require.Contains(t, string(b), "mytableReadScalableTarget")
+					require.NotContains(t, string(b), "mytableWriteScalableTarget")
+					return "/frontend/addons/my-table.yml", nil
+				})
+			},
+		},
 		"happy calls for RDS with LBWS": {
 			inSvcName:        wantedSvcName,
 			inStorageType:    rdsStorageType,
@@ -991,6 +1405,54 @@ func TestStorageInitOpts_Execute(t *testing.T) {
 			},
 			wantedErr: nil,
 		},
+		"happy calls for RDS defaults to Serverless v2": {
+			inSvcName:     wantedSvcName,
+			inStorageType: rdsStorageType,
+			inStorageName: "mycluster",
+			inEngine:      engineTypePostgreSQL,
+			inMaxCapacity: 16,
+			inProxy:       true,
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().ReadWorkloadManifest(wantedSvcName).Return([]byte("type: Load Balanced Web Service"), nil)
+				m.EXPECT().WriteAddon(gomock.Any(), wantedSvcName, "mycluster").DoAndReturn(func(f encoding.BinaryMarshaler, _, _ string) (string, error) {
+					b, err := f.MarshalBinary()
+					require.NoError(t, err)
+					require.Contains(t, string(b), `"DBMinCapacity": 0.5`)
+					require.Contains(t, string(b), `"DBMaxCapacity": 16`)
+					require.Contains(t, string(b), "DBInstanceClass: db.serverless")
+					require.Contains(t, string(b), "Type: AWS::RDS::DBProxy")
+					require.NotContains(t, string(b), "EngineMode: serverless")
+					return "/frontend/addons/mycluster.yml", nil
+				})
+			},
+			mockStore: func(m *mocks.Mockstore) {
+				m.EXPECT().ListEnvironments(gomock.Any()).AnyTimes()
+			},
+		},
+		"happy calls for a provisioned RDS cluster": {
+			inSvcName:       wantedSvcName,
+			inStorageType:   rdsStorageType,
+			inStorageName:   "mycluster",
+			inEngine:        engineTypeMySQL,
+			inInstanceClass: "db.r6g.large",
+			inReaderCount:   2,
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().ReadWorkloadManifest(wantedSvcName).Return([]byte("type: Backend Service"), nil)
+				m.EXPECT().WriteAddon(gomock.Any(), wantedSvcName, "mycluster").DoAndReturn(func(f encoding.BinaryMarshaler, _, _ string) (string, error) {
+					b, err := f.MarshalBinary()
+					require.NoError(t, err)
+					require.Contains(t, string(b), "DBInstanceClass: db.r6g.large")
+					require.Contains(t, string(b), "myclusterDBReaderInstance2:")
+					require.NotContains(t, string(b), "ServerlessV2ScalingConfiguration")
+					return "/frontend/addons/mycluster.yml", nil
+				})
+			},
+			mockStore: func(m *mocks.Mockstore) {
+				m.EXPECT().ListEnvironments(gomock.Any()).AnyTimes()
+			},
+		},
 		"happy calls for RDS with a RDWS": {
 			inSvcName:        wantedSvcName,
 			inStorageType:    rdsStorageType,
@@ -1008,6 +1470,57 @@ func TestStorageInitOpts_Execute(t *testing.T) {
 			},
 			wantedErr: nil,
 		},
+		"happy calls for Redis": {
+			inAppName:     wantedAppName,
+			inStorageType: redisStorageType,
+			inSvcName:     wantedSvcName,
+			inStorageName: "my-cache",
+			inNodeType:    "cache.m6g.large",
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().ReadWorkloadManifest(wantedSvcName).Return([]byte("type: Load Balanced Web Service"), nil)
+				m.EXPECT().WriteAddon(gomock.Any(), wantedSvcName, "my-cache").DoAndReturn(func(f encoding.BinaryMarshaler, _, _ string) (string, error) {
+					b, err := f.MarshalBinary()
+					require.NoError(t, err)
+					require.Contains(t, string(b), "Default: cache.m6g.large")
+					return "/frontend/addons/my-cache.yml", nil
+				})
+			},
+		},
+		"happy calls for a FIFO SQS queue": {
+			inAppName:     wantedAppName,
+			inStorageType: sqsStorageType,
+			inSvcName:     wantedSvcName,
+			inStorageName: "my-queue",
+			inFIFO:        true,
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().ReadWorkloadManifest(wantedSvcName).Return([]byte("type: Worker Service"), nil)
+				m.EXPECT().WriteAddon(gomock.Any(), wantedSvcName, "my-queue").DoAndReturn(func(f encoding.BinaryMarshaler, _, _ string) (string, error) {
+					b, err := f.MarshalBinary()
+					require.NoError(t, err)
+					require.Contains(t, string(b), "FifoQueue: true")
+					return "/frontend/addons/my-queue.yml", nil
+				})
+			},
+		},
+		"happy calls for OpenSearch": {
+			inAppName:      wantedAppName,
+			inStorageType:  openSearchStorageType,
+			inSvcName:      wantedSvcName,
+			inStorageName:  "my-search",
+			inInstanceType: "m6g.large.search",
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().ReadWorkloadManifest(wantedSvcName).Return([]byte("type: Backend Service"), nil)
+				m.EXPECT().WriteAddon(gomock.Any(), wantedSvcName, "my-search").DoAndReturn(func(f encoding.BinaryMarshaler, _, _ string) (string, error) {
+					b, err := f.MarshalBinary()
+					require.NoError(t, err)
+					require.Contains(t, string(b), "Default: m6g.large.search")
+					return "/frontend/addons/my-search.yml", nil
+				})
+			},
+		},
 		"error addon exists": {
 			inAppName:     wantedAppName,
 			inStorageType: s3StorageType,
@@ -1024,6 +1537,33 @@ func TestStorageInitOpts_Execute(t *testing.T) {
 
 			wantedErr: fmt.Errorf("addon file already exists: %w", fileExistsError),
 		},
+		"happy calls for env level DDB": {
+			inAppName:     wantedAppName,
+			inStorageType: dynamoDBStorageType,
+			inStorageName: "my-table",
+			inNoLSI:       true,
+			inNoSort:      true,
+			inPartition:   wantedPartitionKey,
+			inEnvLevel:    true,
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().WriteAddon(gomock.Any(), "environments", "my-table").Return("/environments/addons/my-table.yml", nil)
+			},
+		},
+		"happy calls for env level RDS": {
+			inAppName:     wantedAppName,
+			inStorageType: rdsStorageType,
+			inStorageName: "shared-cluster",
+			inEngine:      engineTypePostgreSQL,
+			inEnvLevel:    true,
+
+			mockWs: func(m *mocks.MockwsAddonManager) {
+				m.EXPECT().WriteAddon(gomock.Any(), "environments", "shared-cluster").Return("/environments/addons/shared-cluster.yml", nil)
+			},
+			mockStore: func(m *mocks.Mockstore) {
+				m.EXPECT().ListEnvironments(gomock.Any()).AnyTimes()
+			},
+		},
 		"unexpected read workload manifest error handled": {
 			inAppName:     wantedAppName,
 			inStorageType: s3StorageType,
@@ -1068,9 +1608,30 @@ func TestStorageInitOpts_Execute(t *testing.T) {
 					lsiSorts:     tc.inLSISorts,
 					noLSI:        tc.inNoLSI,
 					noSort:       tc.inNoSort,
+					gsis:         tc.inGSIs,
+
+					ttlAttribute:    tc.inTTL,
+					streamViewType:  tc.inStream,
+					billingMode:     tc.inBilling,
+					readCapacity:    tc.inReadCap,
+					writeCapacity:   tc.inWriteCap,
+					maxReadCapacity: tc.inMaxRead,
+					pitr:            tc.inPITR,
 
 					rdsEngine:         tc.inEngine,
 					rdsParameterGroup: tc.inParameterGroup,
+
+					rdsServerlessVersion: tc.inServerlessVersion,
+					rdsInstanceClass:     tc.inInstanceClass,
+					rdsMaxCapacity:       tc.inMaxCapacity,
+					rdsReaderCount:       tc.inReaderCount,
+					rdsProxy:             tc.inProxy,
+
+					redisNodeType:          tc.inNodeType,
+					sqsFIFO:                tc.inFIFO,
+					openSearchInstanceType: tc.inInstanceType,
+
+					envLevel: tc.inEnvLevel,
 				},
 				appName: tc.inAppName,
 				ws:      mockAddon,
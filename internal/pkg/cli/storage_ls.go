@@ -0,0 +1,331 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/addon"
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
+)
+
+const (
+	storageListAppNamePrompt     = "Which application's storage would you like to list?"
+	storageListAppNameHelpPrompt = "The deployment status of the storage is retrieved from the environments of the application."
+
+	storageEnvLevelOwnerName = "(env-level)"
+)
+
+// storageTypeForCFNType maps the CloudFormation type of a storage resource to the storage type of "storage init".
+var storageTypeForCFNType = map[string]string{
+	"AWS::DynamoDB::Table":               dynamoDBStorageType,
+	"AWS::S3::Bucket":                    s3StorageType,
+	"AWS::RDS::DBCluster":                rdsStorageType,
+	"AWS::ElastiCache::ReplicationGroup": redisStorageType,
+	"AWS::SQS::Queue":                    sqsStorageType,
+	"AWS::OpenSearchService::Domain":     openSearchStorageType,
+}
+
+// storageAddon is an addon template in the workspace that holds storage resources.
+type storageAddon struct {
+	name     string // Name of the storage, the file name without its extension.
+	fileName string
+	owner    string // Name of the workload that owns the addon, or workspace.EnvironmentsDirName.
+
+	storageType string
+	resources   []addon.Resource // Storage resources in the template, in the order they are declared.
+}
+
+func (a *storageAddon) isEnvLevel() bool {
+	return a.owner == workspace.EnvironmentsDirName
+}
+
+// parentStackName returns the name of the stack that the addon is nested in for an environment.
+func (a *storageAddon) parentStackName(app, env string) string {
+	if a.isEnvLevel() {
+		return stack.NameForEnv(app, env)
+	}
+	return stack.NameForService(app, env, a.owner)
+}
+
+// listStorageAddons returns the storage addons of every workload and of the environments in the workspace.
+func listStorageAddons(ws wsStorageReader) ([]*storageAddon, error) {
+	wls, err := ws.ListWorkloads()
+	if err != nil {
+		return nil, fmt.Errorf("list workloads in the workspace: %w", err)
+	}
+	var addons []*storageAddon
+	for _, owner := range append(wls, workspace.EnvironmentsDirName) {
+		fnames, err := ws.ReadAddonsDir(owner)
+		if err != nil {
+			// The owner doesn't have an addons directory.
+			continue
+		}
+		for _, fname := range fnames {
+			ext := filepath.Ext(fname)
+			if ext != ".yml" && ext != ".yaml" {
+				continue
+			}
+			name := strings.TrimSuffix(fname, ext)
+			if name == "addons.parameters" {
+				continue
+			}
+			content, err := ws.ReadAddon(owner, fname)
+			if err != nil {
+				return nil, fmt.Errorf("read addon %s under %s: %w", fname, owner, err)
+			}
+			resources, err := addon.Resources(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("parse addon %s under %s: %w", fname, owner, err)
+			}
+			var storage []addon.Resource
+			for _, r := range resources {
+				if _, ok := storageTypeForCFNType[r.Type]; ok {
+					storage = append(storage, r)
+				}
+			}
+			if len(storage) == 0 {
+				continue
+			}
+			addons = append(addons, &storageAddon{
+				name:        name,
+				fileName:    fname,
+				owner:       owner,
+				storageType: storageTypeForCFNType[storage[0].Type],
+				resources:   storage,
+			})
+		}
+	}
+	return addons, nil
+}
+
+// deployedStorage returns the physical IDs of the storage resources of an addon by logical ID.
+// If the addon isn't deployed in the environment, returns nil.
+func deployedStorage(cfn stackResourcesDescriber, app, env string, a *storageAddon) (map[string]string, error) {
+	parent := a.parentStackName(app, env)
+	exists, err := cfn.Exists(parent)
+	if err != nil {
+		return nil, fmt.Errorf("check if stack %s exists: %w", parent, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	resources, err := cfn.StackResources(parent)
+	if err != nil {
+		return nil, err
+	}
+	var addonsStackID string
+	for _, r := range resources {
+		if aws.StringValue(r.LogicalResourceId) == addon.StackName {
+			addonsStackID = aws.StringValue(r.PhysicalResourceId)
+		}
+	}
+	if addonsStackID == "" {
+		return nil, nil
+	}
+	addonsResources, err := cfn.StackResources(addonsStackID)
+	if err != nil {
+		return nil, err
+	}
+	physicalIDs := make(map[string]string)
+	for _, r := range addonsResources {
+		if aws.StringValue(r.PhysicalResourceId) == "" {
+			continue
+		}
+		physicalIDs[aws.StringValue(r.LogicalResourceId)] = aws.StringValue(r.PhysicalResourceId)
+	}
+	if _, ok := physicalIDs[a.resources[0].LogicalID]; !ok {
+		return nil, nil
+	}
+	return physicalIDs, nil
+}
+
+type listStorageVars struct {
+	appName          string
+	shouldOutputJSON bool
+}
+
+type listStorageOpts struct {
+	listStorageVars
+
+	w     io.Writer
+	ws    wsStorageReader
+	store store
+	sel   appSelector
+
+	newStackResourcesDescriber func(env *config.Environment) (stackResourcesDescriber, error)
+}
+
+func newListStorageOpts(vars listStorageVars) (*listStorageOpts, error) {
+	ws, err := workspace.New()
+	if err != nil {
+		return nil, fmt.Errorf("new workspace: %w", err)
+	}
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("storage ls"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	return &listStorageOpts{
+		listStorageVars: vars,
+		w:               log.OutputWriter,
+		ws:              ws,
+		store:           store,
+		sel:             selector.NewSelect(prompt.New(), store),
+		newStackResourcesDescriber: func(env *config.Environment) (stackResourcesDescriber, error) {
+			sess, err := sessProvider.FromRole(env.ManagerRoleARN, env.Region)
+			if err != nil {
+				return nil, fmt.Errorf("create session from environment manager role %s in region %s: %w", env.ManagerRoleARN, env.Region, err)
+			}
+			return awscloudformation.New(sess), nil
+		},
+	}, nil
+}
+
+// Validate is a no-op for this command.
+func (o *listStorageOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *listStorageOpts) Ask() error {
+	if o.appName != "" {
+		_, err := o.store.GetApplication(o.appName)
+		return err
+	}
+	name, err := o.sel.Application(storageListAppNamePrompt, storageListAppNameHelpPrompt)
+	if err != nil {
+		return fmt.Errorf("select application name: %w", err)
+	}
+	o.appName = name
+	return nil
+}
+
+type storageListing struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Workload     string   `json:"workload,omitempty"`
+	EnvLevel     bool     `json:"envLevel"`
+	Environments []string `json:"deployedIn"`
+}
+
+// Execute lists the storage addons in the workspace and the environments they are deployed in.
+func (o *listStorageOpts) Execute() error {
+	addons, err := listStorageAddons(o.ws)
+	if err != nil {
+		return err
+	}
+	envs, err := o.store.ListEnvironments(o.appName)
+	if err != nil {
+		return fmt.Errorf("list environments for application %s: %w", o.appName, err)
+	}
+	describers := make(map[string]stackResourcesDescriber)
+	for _, env := range envs {
+		describer, err := o.newStackResourcesDescriber(env)
+		if err != nil {
+			return err
+		}
+		describers[env.Name] = describer
+	}
+
+	listings := make([]storageListing, 0, len(addons))
+	for _, a := range addons {
+		listing := storageListing{
+			Name:         a.name,
+			Type:         a.storageType,
+			EnvLevel:     a.isEnvLevel(),
+			Environments: []string{},
+		}
+		if !a.isEnvLevel() {
+			listing.Workload = a.owner
+		}
+		for _, env := range envs {
+			deployed, err := deployedStorage(describers[env.Name], o.appName, env.Name, a)
+			if err != nil {
+				return fmt.Errorf("get deployment status of storage %s in environment %s: %w", a.name, env.Name, err)
+			}
+			if deployed != nil {
+				listing.Environments = append(listing.Environments, env.Name)
+			}
+		}
+		listings = append(listings, listing)
+	}
+
+	if o.shouldOutputJSON {
+		data, err := json.Marshal(struct {
+			Storage []storageListing `json:"storage"`
+		}{
+			Storage: listings,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal storage: %w", err)
+		}
+		fmt.Fprintf(o.w, "%s\n", data)
+		return nil
+	}
+	if len(listings) == 0 {
+		log.Infof("No storage found in the workspace. Run %s to add one.\n", color.HighlightCode("copilot storage init"))
+		return nil
+	}
+	writer := tabwriter.NewWriter(o.w, historyMinCellWidth, historyTabWidth, historyCellPaddingWidth, historyPaddingChar, 0)
+	headers := []string{"Name", "Type", "Workload", "Deployed In"}
+	fmt.Fprintf(writer, "%s\n", strings.Join(headers, "\t"))
+	var underlines []string
+	for _, header := range headers {
+		underlines = append(underlines, strings.Repeat("-", len(header)))
+	}
+	fmt.Fprintf(writer, "%s\n", strings.Join(underlines, "\t"))
+	for _, l := range listings {
+		owner := l.Workload
+		if l.EnvLevel {
+			owner = storageEnvLevelOwnerName
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", l.Name, l.Type, owner, valueOrDash(strings.Join(l.Environments, ", ")))
+	}
+	return writer.Flush()
+}
+
+// buildStorageListCmd builds the command for listing the storage in the workspace.
+func buildStorageListCmd() *cobra.Command {
+	vars := listStorageVars{}
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "Lists the storage resources in your workspace.",
+		Long:  "Lists the storage resources in your workspace, the workload they belong to and the environments they are deployed in.",
+		Example: `
+  Lists the storage resources in the workspace.
+  /code $ copilot storage ls
+  Lists the storage resources in the workspace in JSON format.
+  /code $ copilot storage ls --json`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newListStorageOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().BoolVar(&vars.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	return cmd
+}
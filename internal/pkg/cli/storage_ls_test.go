@@ -0,0 +1,257 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+const mockUsersTableAddon = `
+Resources:
+  users:
+    Type: AWS::DynamoDB::Table
+  usersAccessPolicy:
+    Type: AWS::IAM::ManagedPolicy
+`
+
+const mockAssetsBucketAddon = `
+Resources:
+  assetsBucket:
+    Type: AWS::S3::Bucket
+  assetsBucketPolicy:
+    Type: AWS::S3::BucketPolicy
+    DeletionPolicy: Retain
+`
+
+const mockAlarmsAddon = `
+Resources:
+  HighLatencyAlarm:
+    Type: AWS::CloudWatch::Alarm
+`
+
+type storageListMocks struct {
+	ws    *mocks.MockwsStorageReader
+	store *mocks.Mockstore
+	cfn   *mocks.MockstackResourcesDescriber
+}
+
+func TestListStorageOpts_Ask(t *testing.T) {
+	testCases := map[string]struct {
+		inAppName string
+
+		setupMocks func(sel *mocks.MockappSelector, store *mocks.Mockstore)
+
+		wantedAppName string
+		wantedErr     error
+	}{
+		"validates the application name if it's provided": {
+			inAppName: "phonetool",
+			setupMocks: func(sel *mocks.MockappSelector, store *mocks.Mockstore) {
+				store.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+			},
+			wantedAppName: "phonetool",
+		},
+		"prompts for the application name": {
+			setupMocks: func(sel *mocks.MockappSelector, store *mocks.Mockstore) {
+				sel.EXPECT().Application(storageListAppNamePrompt, storageListAppNameHelpPrompt).Return("phonetool", nil)
+			},
+			wantedAppName: "phonetool",
+		},
+		"wraps the error if the application can't be selected": {
+			setupMocks: func(sel *mocks.MockappSelector, store *mocks.Mockstore) {
+				sel.EXPECT().Application(gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+			},
+			wantedErr: errors.New("select application name: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			sel := mocks.NewMockappSelector(ctrl)
+			store := mocks.NewMockstore(ctrl)
+			tc.setupMocks(sel, store)
+			opts := &listStorageOpts{
+				listStorageVars: listStorageVars{
+					appName: tc.inAppName,
+				},
+				sel:   sel,
+				store: store,
+			}
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedAppName, opts.appName)
+		})
+	}
+}
+
+func TestListStorageOpts_Execute(t *testing.T) {
+	mockWorkspace := func(m *mocks.MockwsStorageReader) {
+		m.EXPECT().ListWorkloads().Return([]string{"frontend", "api"}, nil)
+		m.EXPECT().ReadAddonsDir("frontend").Return([]string{"users.yml", "alarms.yml", "addons.parameters.yml", "README.md"}, nil)
+		m.EXPECT().ReadAddon("frontend", "users.yml").Return([]byte(mockUsersTableAddon), nil)
+		m.EXPECT().ReadAddon("frontend", "alarms.yml").Return([]byte(mockAlarmsAddon), nil)
+		m.EXPECT().ReadAddonsDir("api").Return(nil, errors.New("open copilot/api/addons: no such file or directory"))
+		m.EXPECT().ReadAddonsDir("environments").Return([]string{"assets.yaml"}, nil)
+		m.EXPECT().ReadAddon("environments", "assets.yaml").Return([]byte(mockAssetsBucketAddon), nil)
+	}
+	mockDeployments := func(m *mocks.MockstackResourcesDescriber) {
+		m.EXPECT().Exists("phonetool-test-frontend").Return(true, nil)
+		m.EXPECT().StackResources("phonetool-test-frontend").Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String("AddonsStack"),
+				PhysicalResourceId: aws.String("arn:aws:cloudformation:us-west-2:123456789012:stack/frontend-addons/1"),
+			},
+		}, nil)
+		m.EXPECT().StackResources("arn:aws:cloudformation:us-west-2:123456789012:stack/frontend-addons/1").Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String("users"),
+				PhysicalResourceId: aws.String("phonetool-test-frontend-users"),
+			},
+		}, nil)
+		m.EXPECT().Exists("phonetool-prod-frontend").Return(false, nil)
+		m.EXPECT().Exists("phonetool-test").Return(true, nil)
+		m.EXPECT().StackResources("phonetool-test").Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String("VPC"),
+				PhysicalResourceId: aws.String("vpc-1234"),
+			},
+		}, nil)
+		m.EXPECT().Exists("phonetool-prod").Return(true, nil)
+		m.EXPECT().StackResources("phonetool-prod").Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String("AddonsStack"),
+				PhysicalResourceId: aws.String("arn:aws:cloudformation:us-west-2:123456789012:stack/env-addons/1"),
+			},
+		}, nil)
+		m.EXPECT().StackResources("arn:aws:cloudformation:us-west-2:123456789012:stack/env-addons/1").Return([]*awscloudformation.StackResource{
+			{
+				LogicalResourceId:  aws.String("assetsBucket"),
+				PhysicalResourceId: aws.String("phonetool-prod-assetsbucket-1a2b3c"),
+			},
+		}, nil)
+	}
+	testCases := map[string]struct {
+		inJSON bool
+
+		setupMocks func(m *storageListMocks)
+
+		wantedContent string
+		wantedErr     error
+	}{
+		"wraps the error if the workloads can't be listed": {
+			setupMocks: func(m *storageListMocks) {
+				m.ws.EXPECT().ListWorkloads().Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("list workloads in the workspace: some error"),
+		},
+		"wraps the error if an addon can't be parsed": {
+			setupMocks: func(m *storageListMocks) {
+				m.ws.EXPECT().ListWorkloads().Return([]string{"frontend"}, nil)
+				m.ws.EXPECT().ReadAddonsDir("frontend").Return([]string{"users.yml"}, nil)
+				m.ws.EXPECT().ReadAddon("frontend", "users.yml").Return([]byte("Resources: hello"), nil)
+			},
+			wantedErr: errors.New(`parse addon users.yml under frontend: "Resources" field in cloudformation template is not a map`),
+		},
+		"wraps the error if the deployment status can't be retrieved": {
+			setupMocks: func(m *storageListMocks) {
+				m.ws.EXPECT().ListWorkloads().Return([]string{"frontend"}, nil)
+				m.ws.EXPECT().ReadAddonsDir("frontend").Return([]string{"users.yml"}, nil)
+				m.ws.EXPECT().ReadAddon("frontend", "users.yml").Return([]byte(mockUsersTableAddon), nil)
+				m.ws.EXPECT().ReadAddonsDir("environments").Return(nil, errors.New("no such file or directory"))
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}}, nil)
+				m.cfn.EXPECT().Exists("phonetool-test-frontend").Return(false, errors.New("some error"))
+			},
+			wantedErr: errors.New("get deployment status of storage users in environment test: check if stack phonetool-test-frontend exists: some error"),
+		},
+		"writes a table of the storage and the environments it's deployed in": {
+			setupMocks: func(m *storageListMocks) {
+				mockWorkspace(m.ws)
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}, {Name: "prod"}}, nil)
+				mockDeployments(m.cfn)
+			},
+			wantedContent: `Name        Type        Workload     Deployed In
+----        ----        --------     -----------
+users       DynamoDB    frontend     test
+assets      S3          (env-level)  prod
+`,
+		},
+		"writes the storage in JSON format": {
+			inJSON: true,
+			setupMocks: func(m *storageListMocks) {
+				mockWorkspace(m.ws)
+				m.store.EXPECT().ListEnvironments("phonetool").Return([]*config.Environment{{Name: "test"}, {Name: "prod"}}, nil)
+				mockDeployments(m.cfn)
+			},
+			wantedContent: `{"storage":[{"name":"users","type":"DynamoDB","workload":"frontend","envLevel":false,"deployedIn":["test"]},{"name":"assets","type":"S3","envLevel":true,"deployedIn":["prod"]}]}` + "\n",
+		},
+		"writes an empty list in JSON format if there is no storage": {
+			inJSON: true,
+			setupMocks: func(m *storageListMocks) {
+				m.ws.EXPECT().ListWorkloads().Return(nil, nil)
+				m.ws.EXPECT().ReadAddonsDir("environments").Return(nil, errors.New("no such file or directory"))
+				m.store.EXPECT().ListEnvironments("phonetool").Return(nil, nil)
+			},
+			wantedContent: `{"storage":[]}` + "\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := &storageListMocks{
+				ws:    mocks.NewMockwsStorageReader(ctrl),
+				store: mocks.NewMockstore(ctrl),
+				cfn:   mocks.NewMockstackResourcesDescriber(ctrl),
+			}
+			tc.setupMocks(m)
+			buf := new(bytes.Buffer)
+			opts := &listStorageOpts{
+				listStorageVars: listStorageVars{
+					appName:          "phonetool",
+					shouldOutputJSON: tc.inJSON,
+				},
+				w:     buf,
+				ws:    m.ws,
+				store: m.store,
+				newStackResourcesDescriber: func(env *config.Environment) (stackResourcesDescriber, error) {
+					return m.cfn, nil
+				},
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, buf.String())
+		})
+	}
+}
@@ -4,10 +4,15 @@
 package cli
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/dustin/go-humanize/english"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
 	"github.com/aws/copilot-cli/internal/pkg/aws/tags"
@@ -37,6 +42,7 @@ type deployWkldVars struct {
 	resourceTags    map[string]string
 	forceNewUpdate  bool // NOTE: this variable is not applicable for a job workload currently.
 	disableRollback bool
+	forceUnlock     bool // NOTE: this variable is not applicable for a job workload currently.
 
 	// To facilitate unit tests.
 	clientConfigured bool
@@ -46,6 +52,7 @@ type deploySvcOpts struct {
 	deployWkldVars
 
 	store           store
+	locker          locker
 	ws              wsWlDirReader
 	unmarshal       func([]byte) (manifest.WorkloadManifest, error)
 	newInterpolator func(app, env string) interpolator
@@ -53,6 +60,7 @@ type deploySvcOpts struct {
 	envUpgradeCmd   actionCommand
 	sessProvider    *sessions.Provider
 	newSvcDeployer  func() (workloadDeployer, error)
+	imageDigest     string // Deploy an existing image by digest instead of building one. Set by "svc promote".
 
 	spinner progress
 	sel     wsSelector
@@ -64,6 +72,7 @@ type deploySvcOpts struct {
 	svcType         string
 	appliedManifest interface{}
 	rootUserARN     string
+	callerARN       string
 	deployRecs      deploy.ActionRecommender
 }
 
@@ -85,6 +94,7 @@ func newSvcDeployOpts(vars deployWkldVars) (*deploySvcOpts, error) {
 		deployWkldVars: vars,
 
 		store:           store,
+		locker:          store,
 		ws:              ws,
 		unmarshal:       manifest.UnmarshalWorkload,
 		spinner:         termprogress.NewSpinner(log.DiagnosticWriter),
@@ -106,6 +116,17 @@ func newSvcDeployer(o *deploySvcOpts) (workloadDeployer, error) {
 	if err != nil {
 		return nil, err
 	}
+	ingressSource, err := isIngressSource(&workloadManifestInput{
+		name:         o.name,
+		appName:      o.appName,
+		envName:      o.envName,
+		interpolator: o.newInterpolator(o.appName, o.envName),
+		ws:           o.ws,
+		unmarshal:    o.unmarshal,
+	})
+	if err != nil {
+		return nil, err
+	}
 	var deployer workloadDeployer
 	in := deploy.WorkloadDeployerInput{
 		SessionProvider: o.sessProvider,
@@ -113,7 +134,9 @@ func newSvcDeployer(o *deploySvcOpts) (workloadDeployer, error) {
 		App:             targetApp,
 		Env:             o.targetEnv,
 		ImageTag:        o.imageTag,
+		ImageDigest:     o.imageDigest,
 		Mft:             o.appliedManifest,
+		IngressSource:   ingressSource,
 	}
 	switch t := o.appliedManifest.(type) {
 	case *manifest.LoadBalancedWebService:
@@ -124,6 +147,8 @@ func newSvcDeployer(o *deploySvcOpts) (workloadDeployer, error) {
 		deployer, err = deploy.NewRDWSDeployer(&in)
 	case *manifest.WorkerService:
 		deployer, err = deploy.NewWorkerSvcDeployer(&in)
+	case *manifest.StaticSite:
+		deployer, err = deploy.NewStaticSiteDeployer(&in)
 	default:
 		return nil, fmt.Errorf("unknown manifest type %T while creating the CloudFormation stack", t)
 	}
@@ -170,6 +195,11 @@ func (o *deploySvcOpts) Execute() error {
 			return err
 		}
 	}
+	release, err := acquireLock(o.locker, o.appName, config.WorkloadLockID(o.envName, o.name), o.forceUnlock)
+	if err != nil {
+		return err
+	}
+	defer release()
 	if err := o.envUpgradeCmd.Execute(); err != nil {
 		return fmt.Errorf(`execute "env upgrade --app %s --name %s": %v`, o.appName, o.envName, err)
 	}
@@ -213,6 +243,8 @@ func (o *deploySvcOpts) Execute() error {
 			AddonsURL:   uploadOut.AddonsURL,
 			RootUserARN: o.rootUserARN,
 			Tags:        tags.Merge(targetApp.Tags, o.resourceTags),
+
+			StaticSiteAssetMappingURL: uploadOut.StaticSiteAssetMappingURL,
 		},
 		Options: deploy.Options{
 			ForceNewUpdate:  o.forceNewUpdate,
@@ -234,6 +266,17 @@ After fixing the deployment, you can:
 		return fmt.Errorf("deploy service %s to environment %s: %w", o.name, o.envName, err)
 	}
 	o.deployRecs = deployRecs
+	recordDeployment(&recordDeploymentInput{
+		app:         o.appName,
+		env:         o.envName,
+		name:        o.name,
+		deployedBy:  o.callerARN,
+		imageDigest: aws.StringValue(uploadOut.ImageDigest),
+		retention:   o.targetApp.DeploymentRetention,
+		ws:          o.ws,
+		runner:      o.cmd,
+		store:       o.store,
+	})
 	log.Successf("Deployed service %s.\n", color.HighlightUserInput(o.name))
 	return nil
 }
@@ -301,7 +344,9 @@ func (o *deploySvcOpts) validateOrAskEnvName() error {
 }
 
 func (o *deploySvcOpts) configureClients() error {
-	o.imageTag = imageTagFromGit(o.cmd, o.imageTag) // Best effort assign git tag.
+	if o.imageDigest == "" {
+		o.imageTag = imageTagFromGit(o.cmd, o.imageTag) // Best effort assign git tag.
+	}
 	env, err := o.store.GetEnvironment(o.appName, o.envName)
 	if err != nil {
 		return fmt.Errorf("get environment %s configuration: %w", o.envName, err)
@@ -314,8 +359,9 @@ func (o *deploySvcOpts) configureClients() error {
 	o.svcType = svc.Type
 
 	cmd, err := newEnvUpgradeOpts(envUpgradeVars{
-		appName: o.appName,
-		name:    env.Name,
+		appName:     o.appName,
+		name:        env.Name,
+		forceUnlock: o.forceUnlock,
 	})
 	if err != nil {
 		return fmt.Errorf("new env upgrade command: %v", err)
@@ -334,6 +380,7 @@ func (o *deploySvcOpts) configureClients() error {
 		return fmt.Errorf("get identity: %w", err)
 	}
 	o.rootUserARN = caller.RootUserARN
+	o.callerARN = caller.ARN
 
 	return nil
 }
@@ -368,9 +415,283 @@ func workloadManifest(in *workloadManifestInput) (interface{}, error) {
 	if err := envMft.Validate(); err != nil {
 		return nil, fmt.Errorf("validate manifest against environment %s: %s", in.envName, err)
 	}
+	if mft, ok := envMft.(ingressRestricter); ok {
+		if err := validateIngressSources(in.name, mft.IngressSources(), in.ws); err != nil {
+			return nil, fmt.Errorf("validate manifest against environment %s: %w", in.envName, err)
+		}
+	}
+	if mft, ok := envMft.(httpListenerRuler); ok {
+		if err := validateListenerRules(in, mft.HTTPListenerRules()); err != nil {
+			return nil, fmt.Errorf("validate manifest against environment %s: %w", in.envName, err)
+		}
+	}
+	if mft, ok := envMft.(webACLAssociator); ok && mft.HasWAF() {
+		if err := validateWAF(in); err != nil {
+			return nil, fmt.Errorf("validate manifest against environment %s: %w", in.envName, err)
+		}
+	}
+	if mft, ok := envMft.(cdnDistributor); ok && mft.HasCDN() {
+		if err := validateCDN(in); err != nil {
+			return nil, fmt.Errorf("validate manifest against environment %s: %w", in.envName, err)
+		}
+	}
 	return envMft, nil
 }
 
+type webACLAssociator interface {
+	HasWAF() bool
+}
+
+// validateWAF returns an error if another Load Balanced Web Service in the workspace also associates a web ACL
+// with the public load balancer of the environment, since a load balancer can only have one web ACL.
+func validateWAF(in *workloadManifestInput) error {
+	svcs, err := in.ws.ListServices()
+	if err != nil {
+		return fmt.Errorf("list services in the workspace: %w", err)
+	}
+	for _, svc := range svcs {
+		if svc == in.name {
+			continue
+		}
+		mft, ok := workspaceManifest(in, svc).(webACLAssociator)
+		if ok && mft.HasWAF() {
+			return fmt.Errorf(`"http.waf" cannot be specified by both service %s and service %s`, in.name, svc)
+		}
+	}
+	return nil
+}
+
+type cdnDistributor interface {
+	HasCDN() bool
+}
+
+// validateCDN returns an error if another Load Balanced Web Service in the workspace also puts a CloudFront distribution
+// in front of the public load balancer of the environment, since the load balancer only accepts traffic from a single one.
+func validateCDN(in *workloadManifestInput) error {
+	svcs, err := in.ws.ListServices()
+	if err != nil {
+		return fmt.Errorf("list services in the workspace: %w", err)
+	}
+	for _, svc := range svcs {
+		if svc == in.name {
+			continue
+		}
+		mft, ok := workspaceManifest(in, svc).(cdnDistributor)
+		if ok && mft.HasCDN() {
+			return fmt.Errorf(`"http.cdn" cannot be specified by both service %s and service %s`, in.name, svc)
+		}
+	}
+	return nil
+}
+
+type httpListenerRuler interface {
+	HTTPListenerRules() []manifest.ListenerRule
+}
+
+// ownedListenerRule is a listener rule along with the service and the manifest field that defines it.
+type ownedListenerRule struct {
+	svc   string
+	field string
+	rule  manifest.ListenerRule
+}
+
+func (r ownedListenerRule) String() string {
+	return fmt.Sprintf(`"%s" of service %s`, r.field, r.svc)
+}
+
+func ownedListenerRules(svc string, rules []manifest.ListenerRule) []ownedListenerRule {
+	owned := make([]ownedListenerRule, len(rules))
+	for i, rule := range rules {
+		field := "http"
+		if i > 0 {
+			field = fmt.Sprintf("http.additional_rules[%d]", i-1)
+		}
+		owned[i] = ownedListenerRule{
+			svc:   svc,
+			field: field,
+			rule:  rule,
+		}
+	}
+	return owned
+}
+
+// validateListenerRules returns an error if a listener rule of the service has the same priority as,
+// or matches the same requests as, another rule of the service or of a Load Balanced Web Service in the workspace.
+func validateListenerRules(in *workloadManifestInput, rules []manifest.ListenerRule) error {
+	if len(rules) < 2 {
+		// Only the main routing rule is defined, its priority is assigned at deployment time.
+		return nil
+	}
+	svcs, err := in.ws.ListServices()
+	if err != nil {
+		return fmt.Errorf("list services in the workspace: %w", err)
+	}
+	var others []ownedListenerRule
+	for _, svc := range svcs {
+		if svc == in.name {
+			continue
+		}
+		others = append(others, ownedListenerRules(svc, workspaceListenerRules(in, svc))...)
+	}
+	owned := ownedListenerRules(in.name, rules)
+	for i, rule := range owned {
+		candidates := append(append([]ownedListenerRule{}, owned[i+1:]...), others...)
+		for _, other := range candidates {
+			if rule.rule.Priority != nil && other.rule.Priority != nil &&
+				aws.IntValue(rule.rule.Priority) == aws.IntValue(other.rule.Priority) {
+				return fmt.Errorf("%s has the same priority %d as %s", rule, aws.IntValue(rule.rule.Priority), other)
+			}
+			if listenerRuleConditions(rule.rule) == listenerRuleConditions(other.rule) {
+				return fmt.Errorf("%s matches the same requests as %s", rule, other)
+			}
+		}
+	}
+	return nil
+}
+
+// workspaceListenerRules returns the listener rules of a service in the workspace for the environment.
+func workspaceListenerRules(in *workloadManifestInput, svc string) []manifest.ListenerRule {
+	ruler, ok := workspaceManifest(in, svc).(httpListenerRuler)
+	if !ok {
+		return nil
+	}
+	return ruler.HTTPListenerRules()
+}
+
+// workspaceManifest returns the manifest of a service in the workspace with the environment overrides applied.
+// Manifests that can't be read are skipped, they are validated when the service itself is deployed.
+func workspaceManifest(in *workloadManifestInput, svc string) manifest.WorkloadManifest {
+	raw, err := in.ws.ReadWorkloadManifest(svc)
+	if err != nil {
+		return nil
+	}
+	interpolated, err := in.interpolator.Interpolate(string(raw))
+	if err != nil {
+		return nil
+	}
+	mft, err := in.unmarshal([]byte(interpolated))
+	if err != nil {
+		return nil
+	}
+	envMft, err := mft.ApplyEnv(in.envName)
+	if err != nil {
+		return nil
+	}
+	return envMft
+}
+
+// listenerRuleConditions returns a normalized representation of the conditions of a listener rule.
+func listenerRuleConditions(rule manifest.ListenerRule) string {
+	hosts := make([]string, len(rule.Hosts))
+	for i, host := range rule.Hosts {
+		hosts[i] = strings.ToLower(host)
+	}
+	var headers []string
+	for name, values := range rule.Headers {
+		sorted := append([]string(nil), values...)
+		sort.Strings(sorted)
+		headers = append(headers, fmt.Sprintf("%s=%s", strings.ToLower(name), strings.Join(sorted, "|")))
+	}
+	var queries []string
+	for key, value := range rule.QueryStrings {
+		queries = append(queries, fmt.Sprintf("%s=%s", key, value))
+	}
+	var ips []string
+	for _, ip := range rule.AllowedSourceIps {
+		ips = append(ips, string(ip))
+	}
+	conditions := make([]string, 0, 5)
+	for _, values := range [][]string{rule.PathPatterns(), hosts, headers, queries, ips} {
+		sort.Strings(values)
+		conditions = append(conditions, strings.Join(values, ","))
+	}
+	return strings.Join(conditions, ";")
+}
+
+type ingressRestricter interface {
+	IngressSources() []string
+}
+
+// isIngressSource returns true if another service in the workspace accepts traffic from the service in "network.vpc.ingress.from".
+func isIngressSource(in *workloadManifestInput) (bool, error) {
+	svcs, err := in.ws.ListServices()
+	if err != nil {
+		return false, fmt.Errorf("list services in the workspace: %w", err)
+	}
+	for _, svc := range svcs {
+		if svc == in.name {
+			continue
+		}
+		mft, ok := workspaceManifest(in, svc).(ingressRestricter)
+		if ok && contains(in.name, mft.IngressSources()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validateIngressSources returns an error if a service allowed to send traffic to svc is not part of the workspace.
+func validateIngressSources(svc string, sources []string, ws serviceLister) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	svcs, err := ws.ListServices()
+	if err != nil {
+		return fmt.Errorf("list services in the workspace: %w", err)
+	}
+	for _, source := range sources {
+		if source == svc {
+			return fmt.Errorf(`service %s cannot allow ingress from itself in "network.vpc.ingress.from"`, svc)
+		}
+		if !contains(source, svcs) {
+			return fmt.Errorf(`service %s in "network.vpc.ingress.from" does not exist in the workspace: must be one of %s`, source, english.WordSeries(svcs, "or"))
+		}
+	}
+	return nil
+}
+
+type recordDeploymentInput struct {
+	app         string
+	env         string
+	name        string
+	deployedBy  string
+	imageDigest string
+	retention   int // Number of deployment records to keep, zero keeps every record.
+	ws          wsWlDirReader
+	runner      runner
+	store       deploymentStore
+}
+
+// recordDeployment saves who deployed what to the store on a best-effort basis,
+// a failure to record the deployment doesn't fail the deployment itself.
+func recordDeployment(in *recordDeploymentInput) {
+	d := &config.Deployment{
+		App:         in.app,
+		Env:         in.env,
+		Name:        in.name,
+		DeployedBy:  in.deployedBy,
+		DeployedAt:  time.Now().UTC(),
+		ImageDigest: in.imageDigest,
+	}
+	if commit, err := describeGitChanges(in.runner); err == nil {
+		d.GitCommit = commit
+		d.GitDirty, _ = hasUncommitedGitChanges(in.runner)
+	}
+	if raw, err := in.ws.ReadWorkloadManifest(in.name); err == nil {
+		d.ManifestHash = fmt.Sprintf("%x", sha256.Sum256(raw))
+	}
+	if err := in.store.CreateDeployment(d); err != nil {
+		log.Warningf("Failed to record the deployment of %s to environment %s: %v\n", in.name, in.env, err)
+		return
+	}
+	if in.retention == 0 {
+		return
+	}
+	if err := in.store.PruneDeployments(in.app, in.name, in.env, in.retention); err != nil {
+		log.Warningf("Failed to delete the old deployment records of %s to environment %s: %v\n", in.name, in.env, err)
+	}
+}
+
 func (o *deploySvcOpts) uriRecommendedActions() ([]string, error) {
 	type reachable interface {
 		Port() (uint16, bool)
@@ -461,6 +782,7 @@ func buildSvcDeployCmd() *cobra.Command {
 	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
 	cmd.Flags().BoolVar(&vars.forceNewUpdate, forceFlag, false, forceFlagDescription)
 	cmd.Flags().BoolVar(&vars.disableRollback, noRollbackFlag, false, noRollbackFlagDescription)
+	cmd.Flags().BoolVar(&vars.forceUnlock, forceUnlockFlag, false, forceUnlockFlagDescription)
 
 	return cmd
 }
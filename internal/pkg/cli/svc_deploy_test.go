@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
@@ -126,6 +128,9 @@ type deployMocks struct {
 	mockEnvUpgrader  *mocks.MockactionCommand
 	mockInterpolator *mocks.Mockinterpolator
 	mockWsReader     *mocks.MockwsWlDirReader
+	mockStore        *mocks.Mockstore
+	mockRunner       *mocks.Mockrunner
+	mockLocker       *mocks.Mocklocker
 }
 
 func TestSvcDeployOpts_Execute(t *testing.T) {
@@ -136,12 +141,29 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 	)
 	mockError := errors.New("some error")
 	testCases := map[string]struct {
+		inRetention int
+
 		mock func(m *deployMocks)
 
 		wantedError error
 	}{
+		"error if the service is being deployed by someone else": {
+			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(nil, &config.ErrLocked{
+					Lock: &config.Lock{
+						ID:         "workloads/prod-iad/frontend",
+						Owner:      "arn:aws:iam::123456789012:user/bob",
+						AcquiredAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+					},
+				})
+			},
+
+			wantedError: errors.New("workloads/prod-iad/frontend is locked by arn:aws:iam::123456789012:user/bob since 2020-05-01T10:00:00Z: wait for the other deployment to finish, or run again with `--force-unlock` if it is no longer running"),
+		},
 		"error if failed to upgrade environment": {
 			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
 				m.mockEnvUpgrader.EXPECT().Execute().Return(mockError)
 			},
 
@@ -149,6 +171,8 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 		},
 		"error out if fail to read workload manifest": {
 			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
 				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
 				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return(nil, mockError)
 			},
@@ -157,6 +181,8 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 		},
 		"error out if fail to interpolate workload manifest": {
 			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
 				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
 				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte(""), nil)
 				m.mockInterpolator.EXPECT().Interpolate("").Return("", mockError)
@@ -166,6 +192,8 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 		},
 		"error if failed to upload artifacts": {
 			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
 				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
 				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte(""), nil)
 				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
@@ -177,6 +205,8 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 		},
 		"error if failed to deploy service": {
 			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
 				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
 				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte(""), nil)
 				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
@@ -187,6 +217,78 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 
 			wantedError: fmt.Errorf("deploy service frontend to environment prod-iad: some error"),
 		},
+		"record the deployment after deploying the service": {
+			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
+				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte("name: frontend"), nil).Times(2)
+				m.mockInterpolator.EXPECT().Interpolate("name: frontend").Return("name: frontend", nil)
+				m.mockDeployer.EXPECT().IsServiceAvailableInRegion("").Return(true, nil)
+				m.mockDeployer.EXPECT().UploadArtifacts().Return(&deploy.UploadArtifactsOutput{
+					ImageDigest: aws.String("sha256:abc"),
+				}, nil)
+				m.mockDeployer.EXPECT().DeployWorkload(gomock.Any()).Return(nil, nil)
+				m.mockRunner.EXPECT().Run("git", []string{"describe", "--always"}, gomock.Any(), gomock.Any()).Return(mockError)
+				m.mockStore.EXPECT().CreateDeployment(gomock.Any()).DoAndReturn(func(d *config.Deployment) error {
+					require.Equal(t, mockAppName, d.App)
+					require.Equal(t, mockEnvName, d.Env)
+					require.Equal(t, mockSvcName, d.Name)
+					require.Equal(t, "arn:aws:iam::123456789012:user/alice", d.DeployedBy)
+					require.Equal(t, "sha256:abc", d.ImageDigest)
+					require.Equal(t, "", d.GitCommit)
+					require.Equal(t, "cf1654f90e3a3c99fe11b0aa1378998da46aed036eaac5b7041d32c9b29bbfa3", d.ManifestHash)
+					require.False(t, d.DeployedAt.IsZero())
+					return nil
+				})
+			},
+		},
+		"deployment succeeds even if the deployment can't be recorded": {
+			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
+				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte(""), nil).Times(2)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockDeployer.EXPECT().IsServiceAvailableInRegion("").Return(true, nil)
+				m.mockDeployer.EXPECT().UploadArtifacts().Return(&deploy.UploadArtifactsOutput{}, nil)
+				m.mockDeployer.EXPECT().DeployWorkload(gomock.Any()).Return(nil, nil)
+				m.mockRunner.EXPECT().Run("git", []string{"describe", "--always"}, gomock.Any(), gomock.Any()).Return(mockError)
+				m.mockStore.EXPECT().CreateDeployment(gomock.Any()).Return(mockError)
+			},
+		},
+		"prune old deployment records if the application limits their retention": {
+			inRetention: 10,
+			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
+				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte(""), nil).Times(2)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockDeployer.EXPECT().IsServiceAvailableInRegion("").Return(true, nil)
+				m.mockDeployer.EXPECT().UploadArtifacts().Return(&deploy.UploadArtifactsOutput{}, nil)
+				m.mockDeployer.EXPECT().DeployWorkload(gomock.Any()).Return(nil, nil)
+				m.mockRunner.EXPECT().Run("git", []string{"describe", "--always"}, gomock.Any(), gomock.Any()).Return(mockError)
+				m.mockStore.EXPECT().CreateDeployment(gomock.Any()).Return(nil)
+				m.mockStore.EXPECT().PruneDeployments(mockAppName, mockSvcName, mockEnvName, 10).Return(nil)
+			},
+		},
+		"deployment succeeds even if old deployment records can't be pruned": {
+			inRetention: 10,
+			mock: func(m *deployMocks) {
+				m.mockLocker.EXPECT().AcquireLock(mockAppName, "workloads/prod-iad/frontend", lockTTL).Return(&config.Lock{}, nil)
+				m.mockLocker.EXPECT().ReleaseLock(&config.Lock{}).Return(nil)
+				m.mockEnvUpgrader.EXPECT().Execute().Return(nil)
+				m.mockWsReader.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte(""), nil).Times(2)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockDeployer.EXPECT().IsServiceAvailableInRegion("").Return(true, nil)
+				m.mockDeployer.EXPECT().UploadArtifacts().Return(&deploy.UploadArtifactsOutput{}, nil)
+				m.mockDeployer.EXPECT().DeployWorkload(gomock.Any()).Return(nil, nil)
+				m.mockRunner.EXPECT().Run("git", []string{"describe", "--always"}, gomock.Any(), gomock.Any()).Return(mockError)
+				m.mockStore.EXPECT().CreateDeployment(gomock.Any()).Return(nil)
+				m.mockStore.EXPECT().PruneDeployments(mockAppName, mockSvcName, mockEnvName, 10).Return(mockError)
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -200,6 +302,9 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 				mockEnvUpgrader:  mocks.NewMockactionCommand(ctrl),
 				mockInterpolator: mocks.NewMockinterpolator(ctrl),
 				mockWsReader:     mocks.NewMockwsWlDirReader(ctrl),
+				mockStore:        mocks.NewMockstore(ctrl),
+				mockRunner:       mocks.NewMockrunner(ctrl),
+				mockLocker:       mocks.NewMocklocker(ctrl),
 			}
 			tc.mock(m)
 
@@ -218,12 +323,18 @@ func TestSvcDeployOpts_Execute(t *testing.T) {
 				newInterpolator: func(app, env string) interpolator {
 					return m.mockInterpolator
 				},
-				ws: m.mockWsReader,
+				ws:     m.mockWsReader,
+				store:  m.mockStore,
+				locker: m.mockLocker,
+				cmd:    m.mockRunner,
 				unmarshal: func(b []byte) (manifest.WorkloadManifest, error) {
 					return &mockWorkloadMft{}, nil
 				},
-				targetApp: &config.Application{},
+				targetApp: &config.Application{
+					DeploymentRetention: tc.inRetention,
+				},
 				targetEnv: &config.Environment{},
+				callerARN: "arn:aws:iam::123456789012:user/alice",
 			}
 
 			// WHEN
@@ -248,3 +359,420 @@ func (m *mockWorkloadMft) ApplyEnv(envName string) (manifest.WorkloadManifest, e
 func (m *mockWorkloadMft) Validate() error {
 	return nil
 }
+
+func TestValidateIngressSources(t *testing.T) {
+	testCases := map[string]struct {
+		sources []string
+		mockWs  func(m *mocks.MockwsWlDirReader)
+
+		wantedError error
+	}{
+		"no-op if there are no sources": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {},
+		},
+		"error if failed to list services": {
+			sources: []string{"api"},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("list services in the workspace: some error"),
+		},
+		"error if the service allows ingress from itself": {
+			sources: []string{"frontend"},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+			},
+			wantedError: errors.New(`service frontend cannot allow ingress from itself in "network.vpc.ingress.from"`),
+		},
+		"error if a source does not exist in the workspace": {
+			sources: []string{"api", "worker"},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+			},
+			wantedError: errors.New(`service worker in "network.vpc.ingress.from" does not exist in the workspace: must be one of frontend or api`),
+		},
+		"success": {
+			sources: []string{"api"},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ws := mocks.NewMockwsWlDirReader(ctrl)
+			tc.mockWs(ws)
+
+			// WHEN
+			err := validateIngressSources("frontend", tc.sources, ws)
+
+			// THEN
+			if tc.wantedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantedError.Error())
+			}
+		})
+	}
+}
+
+func TestIsIngressSource(t *testing.T) {
+	const apiManifest = `name: api
+type: Backend Service
+image:
+  location: nginx
+  port: 8080
+network:
+  vpc:
+    ingress:
+      from: [frontend]
+`
+	const workerManifest = `name: worker
+type: Worker Service
+image:
+  location: nginx
+`
+	testCases := map[string]struct {
+		mockWs func(m *mocks.MockwsWlDirReader)
+
+		wanted      bool
+		wantedError error
+	}{
+		"error if failed to list services": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("list services in the workspace: some error"),
+		},
+		"false if no other service accepts traffic from the service": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "worker"}, nil)
+				m.EXPECT().ReadWorkloadManifest("worker").Return([]byte(workerManifest), nil)
+			},
+		},
+		"true if another service accepts traffic from the service": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "worker", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("worker").Return([]byte(workerManifest), nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ws := mocks.NewMockwsWlDirReader(ctrl)
+			tc.mockWs(ws)
+			interpolator := mocks.NewMockinterpolator(ctrl)
+			interpolator.EXPECT().Interpolate(gomock.Any()).DoAndReturn(func(s string) (string, error) {
+				return s, nil
+			}).AnyTimes()
+
+			// WHEN
+			got, err := isIngressSource(&workloadManifestInput{
+				name:         "frontend",
+				envName:      "test",
+				ws:           ws,
+				interpolator: interpolator,
+				unmarshal:    manifest.UnmarshalWorkload,
+			})
+
+			// THEN
+			if tc.wantedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			} else {
+				require.EqualError(t, err, tc.wantedError.Error())
+			}
+		})
+	}
+}
+
+func TestValidateListenerRules(t *testing.T) {
+	const apiManifest = `name: api
+type: Load Balanced Web Service
+image:
+  location: nginx
+  port: 80
+http:
+  path: api
+  additional_rules:
+    - priority: 10
+      path: /admin
+      hosts: [admin.example.com]
+environments:
+  test:
+    http:
+      additional_rules:
+        - priority: 30
+          path: /admin
+`
+	const workerManifest = `name: worker
+type: Worker Service
+image:
+  location: nginx
+`
+	testCases := map[string]struct {
+		inRules []manifest.ListenerRule
+		mockWs  func(m *mocks.MockwsWlDirReader)
+
+		wantedError error
+	}{
+		"no-op if there are no additional rules": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {},
+		},
+		"error if failed to list services": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+				{Priority: aws.Int(10), Path: aws.String("/old")},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("list services in the workspace: some error"),
+		},
+		"error if rules of the service match the same requests": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+				{Priority: aws.Int(10), Path: aws.String("/")},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend"}, nil)
+			},
+			wantedError: errors.New(`"http" of service frontend matches the same requests as "http.additional_rules[0]" of service frontend`),
+		},
+		"error if a rule has the same priority as a rule of another service": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+				{Priority: aws.Int(30), Path: aws.String("/old")},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api", "worker"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+				m.EXPECT().ReadWorkloadManifest("worker").Return([]byte(workerManifest), nil)
+			},
+			wantedError: errors.New(`"http.additional_rules[0]" of service frontend has the same priority 30 as "http.additional_rules[0]" of service api`),
+		},
+		"error if a rule matches the same requests as the main rule of another service": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+				{Priority: aws.Int(20), Path: aws.String("/api/")},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+			wantedError: errors.New(`"http.additional_rules[0]" of service frontend matches the same requests as "http" of service api`),
+		},
+		"skips manifests that can't be read": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+				{Priority: aws.Int(10), Path: aws.String("/api")},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return(nil, errors.New("some error"))
+			},
+		},
+		"success": {
+			inRules: []manifest.ListenerRule{
+				{Path: aws.String("/")},
+				{Priority: aws.Int(20), Path: aws.String("/admin"), Hosts: []string{"admin.example.com"}},
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ws := mocks.NewMockwsWlDirReader(ctrl)
+			tc.mockWs(ws)
+			interpolator := mocks.NewMockinterpolator(ctrl)
+			interpolator.EXPECT().Interpolate(gomock.Any()).DoAndReturn(func(s string) (string, error) {
+				return s, nil
+			}).AnyTimes()
+
+			// WHEN
+			err := validateListenerRules(&workloadManifestInput{
+				name:         "frontend",
+				envName:      "test",
+				ws:           ws,
+				interpolator: interpolator,
+				unmarshal:    manifest.UnmarshalWorkload,
+			}, tc.inRules)
+
+			// THEN
+			if tc.wantedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantedError.Error())
+			}
+		})
+	}
+}
+
+func TestValidateWAF(t *testing.T) {
+	const apiManifest = `name: api
+type: Load Balanced Web Service
+image:
+  location: nginx
+  port: 80
+http:
+  path: api
+environments:
+  test:
+    http:
+      waf:
+        managed_rules: [AWSManagedRulesCommonRuleSet]
+`
+	testCases := map[string]struct {
+		inEnvName string
+		mockWs    func(m *mocks.MockwsWlDirReader)
+
+		wantedError error
+	}{
+		"error if failed to list services": {
+			inEnvName: "test",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("list services in the workspace: some error"),
+		},
+		"error if another service associates a web ACL in the environment": {
+			inEnvName: "test",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+			wantedError: errors.New(`"http.waf" cannot be specified by both service frontend and service api`),
+		},
+		"success if another service associates a web ACL in a different environment": {
+			inEnvName: "prod",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ws := mocks.NewMockwsWlDirReader(ctrl)
+			tc.mockWs(ws)
+			interpolator := mocks.NewMockinterpolator(ctrl)
+			interpolator.EXPECT().Interpolate(gomock.Any()).DoAndReturn(func(s string) (string, error) {
+				return s, nil
+			}).AnyTimes()
+
+			// WHEN
+			err := validateWAF(&workloadManifestInput{
+				name:         "frontend",
+				envName:      tc.inEnvName,
+				ws:           ws,
+				interpolator: interpolator,
+				unmarshal:    manifest.UnmarshalWorkload,
+			})
+
+			// THEN
+			if tc.wantedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantedError.Error())
+			}
+		})
+	}
+}
+
+func TestValidateCDN(t *testing.T) {
+	const apiManifest = `name: api
+type: Load Balanced Web Service
+image:
+  location: nginx
+  port: 80
+http:
+  path: api
+environments:
+  test:
+    http:
+      cdn: true
+`
+	testCases := map[string]struct {
+		inEnvName string
+		mockWs    func(m *mocks.MockwsWlDirReader)
+
+		wantedError error
+	}{
+		"error if failed to list services": {
+			inEnvName: "test",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("list services in the workspace: some error"),
+		},
+		"error if another service has a CloudFront distribution in the environment": {
+			inEnvName: "test",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+			wantedError: errors.New(`"http.cdn" cannot be specified by both service frontend and service api`),
+		},
+		"success if another service has a CloudFront distribution in a different environment": {
+			inEnvName: "prod",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListServices().Return([]string{"frontend", "api"}, nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return([]byte(apiManifest), nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ws := mocks.NewMockwsWlDirReader(ctrl)
+			tc.mockWs(ws)
+			interpolator := mocks.NewMockinterpolator(ctrl)
+			interpolator.EXPECT().Interpolate(gomock.Any()).DoAndReturn(func(s string) (string, error) {
+				return s, nil
+			}).AnyTimes()
+
+			// WHEN
+			err := validateCDN(&workloadManifestInput{
+				name:         "frontend",
+				envName:      tc.inEnvName,
+				ws:           ws,
+				interpolator: interpolator,
+				unmarshal:    manifest.UnmarshalWorkload,
+			})
+
+			// THEN
+			if tc.wantedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantedError.Error())
+			}
+		})
+	}
+}
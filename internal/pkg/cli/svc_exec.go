@@ -113,8 +113,8 @@ func (o *svcExecOpts) Execute() error {
 	if err != nil {
 		return fmt.Errorf("get workload: %w", err)
 	}
-	if wkld.Type == manifest.RequestDrivenWebServiceType {
-		return fmt.Errorf("executing a command in a running container part of a service is not supported for services with type: '%s'", manifest.RequestDrivenWebServiceType)
+	if wkld.Type == manifest.RequestDrivenWebServiceType || wkld.Type == manifest.StaticSiteType {
+		return fmt.Errorf("executing a command in a running container part of a service is not supported for services with type: '%s'", wkld.Type)
 	}
 	sess, err := o.envSession()
 	if err != nil {
@@ -412,6 +412,11 @@ func TestSvcExec_Execute(t *testing.T) {
 		Name: "mockSvc",
 		Type: "Request-Driven Web Service",
 	}
+	mockStaticSiteWl := config.Workload{
+		App:  "mockApp",
+		Name: "mockSvc",
+		Type: "Static Site",
+	}
 	mockError := errors.New("some error")
 	testCases := map[string]struct {
 		containerName string
@@ -436,6 +441,14 @@ func TestSvcExec_Execute(t *testing.T) {
 			},
 			wantedError: fmt.Errorf("executing a command in a running container part of a service is not supported for services with type: 'Request-Driven Web Service'"),
 		},
+		"return error if service type is Static Site": {
+			setupMocks: func(m execSvcMocks) {
+				gomock.InOrder(
+					m.storeSvc.EXPECT().GetWorkload("mockApp", "mockSvc").Return(&mockStaticSiteWl, nil),
+				)
+			},
+			wantedError: fmt.Errorf("executing a command in a running container part of a service is not supported for services with type: 'Static Site'"),
+		},
 		"return error if fail to get environment": {
 			setupMocks: func(m execSvcMocks) {
 				gomock.InOrder(
@@ -0,0 +1,211 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+)
+
+const (
+	svcHistorySvcNamePrompt     = "Which service of %s would you like to show the deployment history of?"
+	svcHistorySvcNameHelpPrompt = "Every deployment of the service with Copilot will be listed."
+
+	// Display settings.
+	historyMinCellWidth     = 12  // minimum number of characters in a table's cell.
+	historyTabWidth         = 4   // number of characters in between columns.
+	historyCellPaddingWidth = 2   // number of padding characters added by default to a cell.
+	historyPaddingChar      = ' ' // character in between columns.
+	historyShortHashLength  = 12  // number of characters of a hash to display.
+)
+
+type svcHistoryVars struct {
+	appName          string
+	svcName          string
+	envName          string
+	shouldOutputJSON bool
+}
+
+type svcHistoryOpts struct {
+	svcHistoryVars
+
+	w     io.Writer
+	store store
+	sel   configSelector
+}
+
+func newSvcHistoryOpts(vars svcHistoryVars) (*svcHistoryOpts, error) {
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("svc history"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, fmt.Errorf("default session: %v", err)
+	}
+	ssmStore := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	return &svcHistoryOpts{
+		svcHistoryVars: vars,
+		w:              log.OutputWriter,
+		store:          ssmStore,
+		sel:            selector.NewConfigSelect(prompt.New(), ssmStore),
+	}, nil
+}
+
+// Validate returns an error for any invalid optional flags.
+func (o *svcHistoryOpts) Validate() error {
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *svcHistoryOpts) Ask() error {
+	if err := o.validateOrAskApp(); err != nil {
+		return err
+	}
+	if err := o.validateOrAskSvcName(); err != nil {
+		return err
+	}
+	if o.envName != "" {
+		if _, err := o.store.GetEnvironment(o.appName, o.envName); err != nil {
+			return fmt.Errorf("get environment %s configuration: %w", o.envName, err)
+		}
+	}
+	return nil
+}
+
+// Execute lists the deployments of the service.
+func (o *svcHistoryOpts) Execute() error {
+	deployments, err := o.store.ListDeployments(o.appName, o.svcName, o.envName)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 && !o.shouldOutputJSON {
+		log.Infof("No deployments found for service %s.\n", color.HighlightUserInput(o.svcName))
+		return nil
+	}
+	return writeDeployments(o.w, deployments, o.shouldOutputJSON)
+}
+
+// writeDeployments writes the deployments of a workload to w as a table, or as JSON if asJSON is true.
+func writeDeployments(w io.Writer, deployments []*config.Deployment, asJSON bool) error {
+	if asJSON {
+		data, err := json.Marshal(struct {
+			Deployments []*config.Deployment `json:"deployments"`
+		}{
+			Deployments: deployments,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal deployments: %w", err)
+		}
+		fmt.Fprintf(w, "%s\n", data)
+		return nil
+	}
+	writer := tabwriter.NewWriter(w, historyMinCellWidth, historyTabWidth, historyCellPaddingWidth, historyPaddingChar, 0)
+	headers := []string{"Environment", "Deployed At", "Deployed By", "Image Digest", "Git Commit", "Manifest Hash"}
+	fmt.Fprintf(writer, "%s\n", strings.Join(headers, "\t"))
+	var underlines []string
+	for _, header := range headers {
+		underlines = append(underlines, strings.Repeat("-", len(header)))
+	}
+	fmt.Fprintf(writer, "%s\n", strings.Join(underlines, "\t"))
+	for _, d := range deployments {
+		commit := d.GitCommit
+		if d.GitDirty {
+			commit += " (dirty)"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", d.Env, d.DeployedAt.Format(time.RFC3339), d.DeployedBy,
+			shortDigest(d.ImageDigest), valueOrDash(commit), valueOrDash(shortHash(d.ManifestHash)))
+	}
+	return writer.Flush()
+}
+
+func (o *svcHistoryOpts) validateOrAskApp() error {
+	if o.appName != "" {
+		_, err := o.store.GetApplication(o.appName)
+		return err
+	}
+	appName, err := o.sel.Application(svcAppNamePrompt, svcAppNameHelpPrompt)
+	if err != nil {
+		return fmt.Errorf("select application name: %w", err)
+	}
+	o.appName = appName
+	return nil
+}
+
+func (o *svcHistoryOpts) validateOrAskSvcName() error {
+	if o.svcName != "" {
+		_, err := o.store.GetService(o.appName, o.svcName)
+		return err
+	}
+	svcName, err := o.sel.Service(fmt.Sprintf(svcHistorySvcNamePrompt, color.HighlightUserInput(o.appName)),
+		svcHistorySvcNameHelpPrompt, o.appName)
+	if err != nil {
+		return fmt.Errorf("select service for application %s: %w", o.appName, err)
+	}
+	o.svcName = svcName
+	return nil
+}
+
+// shortDigest truncates an image digest like "sha256:<hex>" to a short, human-readable length.
+func shortDigest(digest string) string {
+	algorithm, hash, found := strings.Cut(digest, ":")
+	if !found {
+		return valueOrDash(shortHash(digest))
+	}
+	return fmt.Sprintf("%s:%s", algorithm, shortHash(hash))
+}
+
+func shortHash(hash string) string {
+	if len(hash) <= historyShortHashLength {
+		return hash
+	}
+	return hash[:historyShortHashLength]
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// buildSvcHistoryCmd builds the command for listing the deployments of a service.
+func buildSvcHistoryCmd() *cobra.Command {
+	vars := svcHistoryVars{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Lists the deployments of a service.",
+		Long:  "Lists who deployed a service, when, and with which image, git commit and manifest.",
+		Example: `
+  Lists the deployments of the "frontend" service to the "prod" environment.
+  /code $ copilot svc history -n frontend --env prod
+  Lists the deployments of the "frontend" service to every environment in JSON format.
+  /code $ copilot svc history -n frontend --json`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newSvcHistoryOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.svcName, nameFlag, nameFlagShort, "", svcFlagDescription)
+	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", historyEnvFlagDescription)
+	cmd.Flags().BoolVar(&vars.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
+	return cmd
+}
@@ -0,0 +1,179 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+)
+
+type svcHistoryMocks struct {
+	store *mocks.Mockstore
+	sel   *mocks.MockconfigSelector
+}
+
+func TestSvcHistoryOpts_Ask(t *testing.T) {
+	mockError := errors.New("some error")
+	testCases := map[string]struct {
+		inAppName string
+		inSvcName string
+		inEnvName string
+
+		setupMocks func(m *svcHistoryMocks)
+
+		wantedAppName string
+		wantedSvcName string
+		wantedError   error
+	}{
+		"validate instead of prompting": {
+			inAppName: "phonetool",
+			inSvcName: "frontend",
+			inEnvName: "prod",
+			setupMocks: func(m *svcHistoryMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.store.EXPECT().GetService("phonetool", "frontend").Return(&config.Workload{}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "prod").Return(&config.Environment{}, nil)
+			},
+			wantedAppName: "phonetool",
+			wantedSvcName: "frontend",
+		},
+		"prompt for application and service names": {
+			setupMocks: func(m *svcHistoryMocks) {
+				m.sel.EXPECT().Application(svcAppNamePrompt, svcAppNameHelpPrompt).Return("phonetool", nil)
+				m.sel.EXPECT().Service(gomock.Any(), svcHistorySvcNameHelpPrompt, "phonetool").Return("frontend", nil)
+			},
+			wantedAppName: "phonetool",
+			wantedSvcName: "frontend",
+		},
+		"error if the environment does not exist": {
+			inAppName: "phonetool",
+			inSvcName: "frontend",
+			inEnvName: "prod",
+			setupMocks: func(m *svcHistoryMocks) {
+				m.store.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+				m.store.EXPECT().GetService("phonetool", "frontend").Return(&config.Workload{}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "prod").Return(nil, mockError)
+			},
+			wantedError: errors.New("get environment prod configuration: some error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &svcHistoryMocks{
+				store: mocks.NewMockstore(ctrl),
+				sel:   mocks.NewMockconfigSelector(ctrl),
+			}
+			tc.setupMocks(m)
+			opts := svcHistoryOpts{
+				svcHistoryVars: svcHistoryVars{
+					appName: tc.inAppName,
+					svcName: tc.inSvcName,
+					envName: tc.inEnvName,
+				},
+				store: m.store,
+				sel:   m.sel,
+			}
+
+			err := opts.Ask()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedAppName, opts.appName)
+				require.Equal(t, tc.wantedSvcName, opts.svcName)
+			}
+		})
+	}
+}
+
+func TestSvcHistoryOpts_Execute(t *testing.T) {
+	deployments := []*config.Deployment{
+		{
+			App:          "phonetool",
+			Env:          "prod",
+			Name:         "frontend",
+			DeployedBy:   "arn:aws:iam::123456789012:user/alice",
+			DeployedAt:   time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+			ImageDigest:  "sha256:18f7d8a4e0c5b7a9e0e5d2f36e1c4f3a",
+			GitCommit:    "4b5c6d7",
+			GitDirty:     true,
+			ManifestHash: "cf1654f90e3a3c99fe11b0aa1378998da46aed036eaac5b7041d32c9b29bbfa3",
+		},
+	}
+	testCases := map[string]struct {
+		inJSON bool
+
+		setupMocks func(m *svcHistoryMocks)
+
+		wantedContent string
+		wantedError   error
+	}{
+		"error if fail to list deployments": {
+			setupMocks: func(m *svcHistoryMocks) {
+				m.store.EXPECT().ListDeployments("phonetool", "frontend", "prod").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("some error"),
+		},
+		"prints deployments in a table": {
+			setupMocks: func(m *svcHistoryMocks) {
+				m.store.EXPECT().ListDeployments("phonetool", "frontend", "prod").Return(deployments, nil)
+			},
+			wantedContent: `Environment  Deployed At           Deployed By                           Image Digest         Git Commit       Manifest Hash
+-----------  -----------           -----------                           ------------         ----------       -------------
+prod         2022-03-04T05:06:07Z  arn:aws:iam::123456789012:user/alice  sha256:18f7d8a4e0c5  4b5c6d7 (dirty)  cf1654f90e3a
+`,
+		},
+		"prints deployments in JSON": {
+			inJSON: true,
+			setupMocks: func(m *svcHistoryMocks) {
+				m.store.EXPECT().ListDeployments("phonetool", "frontend", "prod").Return(deployments, nil)
+			},
+			wantedContent: `{"deployments":[{"app":"phonetool","env":"prod","name":"frontend","deployedBy":"arn:aws:iam::123456789012:user/alice","deployedAt":"2022-03-04T05:06:07Z","imageDigest":"sha256:18f7d8a4e0c5b7a9e0e5d2f36e1c4f3a","gitCommit":"4b5c6d7","gitDirty":true,"manifestHash":"cf1654f90e3a3c99fe11b0aa1378998da46aed036eaac5b7041d32c9b29bbfa3"}]}
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &svcHistoryMocks{
+				store: mocks.NewMockstore(ctrl),
+			}
+			tc.setupMocks(m)
+			b := &bytes.Buffer{}
+			opts := svcHistoryOpts{
+				svcHistoryVars: svcHistoryVars{
+					appName:          "phonetool",
+					svcName:          "frontend",
+					envName:          "prod",
+					shouldOutputJSON: tc.inJSON,
+				},
+				store: m.store,
+				w:     b,
+			}
+
+			err := opts.Execute()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedContent, b.String())
+			}
+		})
+	}
+}
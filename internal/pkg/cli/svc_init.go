@@ -40,9 +40,10 @@ Other repositories are specified with either repository-url/image:tag or reposit
 )
 
 const (
-	defaultSvcPortString = "80"
-	service              = "service"
-	job                  = "job"
+	defaultSvcPortString        = "80"
+	defaultStaticSiteSourcePath = "dist"
+	service                     = "service"
+	job                         = "job"
 )
 
 var (
@@ -57,11 +58,15 @@ A %s is a private, non internet-facing service accessible from other services in
 To learn more see: https://git.io/JEEJt
 
 A %s is a private service that can consume messages published to topics in your application.
-To learn more see: https://git.io/JEEJY`,
+To learn more see: https://git.io/JEEJY
+
+A %s is an internet-facing website whose static assets are stored in an S3 bucket and served by a CloudFront distribution.
+To learn more see: https://aws.github.io/copilot-cli/docs/manifest/static-site/`,
 		manifest.RequestDrivenWebServiceType,
 		manifest.LoadBalancedWebServiceType,
 		manifest.BackendServiceType,
 		manifest.WorkerServiceType,
+		manifest.StaticSiteType,
 	)
 
 	fmtWkldInitNamePrompt     = "What do you want to %s this %s?"
@@ -77,6 +82,10 @@ Deployed resources (such as your ECR repository, logs) will contain this %[1]s's
 	svcInitSvcPortHelpPrompt = `The port will be used by the load balancer to route incoming traffic to this service.
 You should set this to the port which your Dockerfile uses to communicate with the internet.`
 
+	svcInitSourcePathPrompt     = "What is the path to the %s of your static site's assets?"
+	svcInitSourcePathHelpPrompt = `The directory, relative to your workspace root, that contains the files to upload to the site's S3 bucket.
+If you have a build step, this is the directory where the build writes its output.`
+
 	svcInitPublisherPrompt     = "Which topics do you want to subscribe to?"
 	svcInitPublisherHelpPrompt = `A publisher is an existing SNS Topic to which a service publishes messages. 
 These messages can be consumed by the Worker Service.`
@@ -89,6 +98,7 @@ var serviceTypeHints = map[string]string{
 	manifest.LoadBalancedWebServiceType:  "Internet to ECS on Fargate",
 	manifest.BackendServiceType:          "ECS on Fargate",
 	manifest.WorkerServiceType:           "Events to SQS to ECS on Fargate",
+	manifest.StaticSiteType:              "Internet to CloudFront to S3",
 }
 
 type initWkldVars struct {
@@ -124,6 +134,7 @@ type initSvcOpts struct {
 	manifestPath string
 	platform     *manifest.PlatformString
 	topics       []manifest.TopicSubscription
+	sourcePath   string
 
 	// For workspace validation.
 	wsAppName         string
@@ -196,6 +207,9 @@ func (o *initSvcOpts) Validate() error {
 	if o.dockerfilePath != "" && o.image != "" {
 		return fmt.Errorf("--%s and --%s cannot be specified together", dockerFileFlag, imageFlag)
 	}
+	if o.wkldType == manifest.StaticSiteType && (o.dockerfilePath != "" || o.image != "") {
+		return fmt.Errorf("--%s and --%s cannot be specified for a %s", dockerFileFlag, imageFlag, manifest.StaticSiteType)
+	}
 	if o.dockerfilePath != "" {
 		if _, err := o.fs.Stat(o.dockerfilePath); err != nil {
 			return err
@@ -259,6 +273,9 @@ func (o *initSvcOpts) Ask() error {
 	if shouldSkipAsking {
 		return nil
 	}
+	if o.wkldType == manifest.StaticSiteType {
+		return o.askStaticSiteSource()
+	}
 	err = o.askDockerfile()
 	if err != nil {
 		return err
@@ -289,7 +306,7 @@ func (o *initSvcOpts) Execute() error {
 		}
 	}
 	// If the user passes in an image, their docker engine isn't necessarily running, and we can't do anything with the platform because we're not building the Docker image.
-	if o.image == "" {
+	if o.image == "" && o.wkldType != manifest.StaticSiteType {
 		platform, err := legitimizePlatform(o.dockerEngine, o.wkldType)
 		if err != nil {
 			return err
@@ -312,6 +329,7 @@ func (o *initSvcOpts) Execute() error {
 		},
 		Port:        o.port,
 		HealthCheck: hc,
+		SourcePath:  o.sourcePath,
 	})
 	if err != nil {
 		return err
@@ -440,6 +458,21 @@ func (o *initSvcOpts) shouldSkipAsking() (bool, error) {
 	return true, nil
 }
 
+func (o *initSvcOpts) askStaticSiteSource() error {
+	path, err := o.prompt.Get(
+		fmt.Sprintf(svcInitSourcePathPrompt, color.Emphasize("directory")),
+		svcInitSourcePathHelpPrompt,
+		nil,
+		prompt.WithDefaultInput(defaultStaticSiteSourcePath),
+		prompt.WithFinalMessage("Source directory:"),
+	)
+	if err != nil {
+		return fmt.Errorf("get source directory: %w", err)
+	}
+	o.sourcePath = path
+	return nil
+}
+
 // isDfSelected indicates if any Dockerfile is in use.
 func (o *initSvcOpts) askDockerfile() error {
 	if o.dockerfilePath != "" || o.image != "" {
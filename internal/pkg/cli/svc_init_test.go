@@ -73,6 +73,16 @@ func TestSvcInitOpts_Validate(t *testing.T) {
 			},
 			wantedErr: fmt.Errorf("--dockerfile and --image cannot be specified together"),
 		},
+		"fail if image is set for a static site": {
+			inAppName: "phonetool",
+			inImage:   "mockImage",
+			inSvcType: manifest.StaticSiteType,
+
+			setupMocks: func(m initSvcMocks) {
+				m.mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{}, nil)
+			},
+			wantedErr: fmt.Errorf("--dockerfile and --image cannot be specified for a Static Site"),
+		},
 		"fail if image not supported by App Runner": {
 			inAppName: "phonetool",
 			inImage:   "amazon/amazon-ecs-sample",
@@ -192,7 +202,7 @@ func TestSvcInitOpts_Ask(t *testing.T) {
 	}{
 		"invalid service type": {
 			inSvcType: "TestSvcType",
-			wantedErr: errors.New(`invalid service type TestSvcType: must be one of "Request-Driven Web Service", "Load Balanced Web Service", "Backend Service", "Worker Service"`),
+			wantedErr: errors.New(`invalid service type TestSvcType: must be one of "Request-Driven Web Service", "Load Balanced Web Service", "Backend Service", "Worker Service", "Static Site"`),
 		},
 		"invalid service name": {
 			inSvcType: wantedSvcType,
@@ -212,6 +222,29 @@ func TestSvcInitOpts_Ask(t *testing.T) {
 			},
 			wantedErr: nil,
 		},
+		"prompt for static site source directory and skip container prompts": {
+			inSvcType: manifest.StaticSiteType,
+			inSvcName: wantedSvcName,
+
+			setupMocks: func(m initSvcMocks) {
+				m.mockStore.EXPECT().GetService(mockAppName, wantedSvcName).Return(nil, &config.ErrNoSuchService{})
+				m.mockMftReader.EXPECT().ReadWorkloadManifest(wantedSvcName).Return(nil, &workspace.ErrFileNotExists{FileName: wantedSvcName})
+				m.mockPrompt.EXPECT().Get(gomock.Eq("What is the path to the directory of your static site's assets?"), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("build", nil)
+			},
+		},
+		"returns an error if fail to get static site source directory": {
+			inSvcType: manifest.StaticSiteType,
+			inSvcName: wantedSvcName,
+
+			setupMocks: func(m initSvcMocks) {
+				m.mockStore.EXPECT().GetService(mockAppName, wantedSvcName).Return(nil, &config.ErrNoSuchService{})
+				m.mockMftReader.EXPECT().ReadWorkloadManifest(wantedSvcName).Return(nil, &workspace.ErrFileNotExists{FileName: wantedSvcName})
+				m.mockPrompt.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", mockError)
+			},
+			wantedErr: fmt.Errorf("get source directory: mock error"),
+		},
 		"returns an error if fail to get service name": {
 			inSvcType:        wantedSvcType,
 			inSvcName:        "",
@@ -334,6 +367,10 @@ type: Request-Driven Web Service`), nil)
 						Value: manifest.WorkerServiceType,
 						Hint:  "Events to SQS to ECS on Fargate",
 					},
+					{
+						Value: manifest.StaticSiteType,
+						Hint:  "Internet to CloudFront to S3",
+					},
 				}), gomock.Any()).
 					Return(wantedSvcType, nil)
 				m.mockStore.EXPECT().GetService(mockAppName, wantedSvcName).Return(nil, &config.ErrNoSuchService{}).Times(2)
@@ -0,0 +1,327 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spf13/cobra"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/ecr"
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/aws/tags"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
+)
+
+const (
+	svcPromoteFromEnvPrompt     = "Which environment would you like to promote the image from?"
+	svcPromoteFromEnvHelpPrompt = "The image currently running in this environment will be deployed to the target environment."
+	svcPromoteToEnvPrompt       = "Which environment would you like to promote the image to?"
+	svcPromoteToEnvHelpPrompt   = "The service will be deployed to this environment without rebuilding its image."
+
+	fmtSvcPromoteStart = "Promoting image %s of service %s from environment %s to environment %s.\n"
+)
+
+var errSameFromAndToEnv = errors.New("the environment to promote from must be different than the environment to promote to")
+
+type promoteSvcVars struct {
+	appName         string
+	name            string
+	fromEnvName     string
+	toEnvName       string
+	resourceTags    map[string]string
+	forceNewUpdate  bool
+	disableRollback bool
+	forceUnlock     bool
+}
+
+type promoteSvcOpts struct {
+	promoteSvcVars
+
+	store                store
+	ws                   wsWlDirReader
+	sel                  wsSelector
+	newTaskDefDescriber  func(env *config.Environment) (taskDefinitionDescriber, error)
+	newImageDigestGetter func(region string) (imageDigestGetter, error)
+	newSvcDeployCmd      func(imageDigest string) (actionCommand, error)
+
+	// cached variables
+	deployCmd actionCommand
+}
+
+func newSvcPromoteOpts(vars promoteSvcVars) (*promoteSvcOpts, error) {
+	ws, err := workspace.New()
+	if err != nil {
+		return nil, fmt.Errorf("new workspace: %w", err)
+	}
+	sessProvider := sessions.ImmutableProvider(sessions.UserAgentExtras("svc promote"))
+	defaultSess, err := sessProvider.Default()
+	if err != nil {
+		return nil, err
+	}
+	store := config.NewSSMStore(identity.New(defaultSess), ssm.New(defaultSess), aws.StringValue(defaultSess.Config.Region))
+	prompter := prompt.New()
+	opts := &promoteSvcOpts{
+		promoteSvcVars: vars,
+		store:          store,
+		ws:             ws,
+		sel:            selector.NewWorkspaceSelect(prompter, store, ws),
+		newTaskDefDescriber: func(env *config.Environment) (taskDefinitionDescriber, error) {
+			sess, err := sessProvider.FromRole(env.ManagerRoleARN, env.Region)
+			if err != nil {
+				return nil, fmt.Errorf("create session from environment manager role %s in region %s: %w", env.ManagerRoleARN, env.Region, err)
+			}
+			return ecs.New(sess), nil
+		},
+		newImageDigestGetter: func(region string) (imageDigestGetter, error) {
+			// The ECR repositories of an application live in the application's account.
+			sess, err := sessProvider.DefaultWithRegion(region)
+			if err != nil {
+				return nil, fmt.Errorf("create default session with region %s: %w", region, err)
+			}
+			return ecr.New(sess), nil
+		},
+	}
+	opts.newSvcDeployCmd = func(imageDigest string) (actionCommand, error) {
+		cmd, err := newSvcDeployOpts(deployWkldVars{
+			appName: opts.appName,
+			name:    opts.name,
+			envName: opts.toEnvName,
+			resourceTags: tags.Merge(opts.resourceTags, map[string]string{
+				deploy.ImageDigestTagKey:  imageDigest,
+				deploy.PromotedFromTagKey: opts.fromEnvName,
+			}),
+			forceNewUpdate:  opts.forceNewUpdate,
+			disableRollback: opts.disableRollback,
+			forceUnlock:     opts.forceUnlock,
+		})
+		if err != nil {
+			return nil, err
+		}
+		cmd.imageDigest = imageDigest
+		return cmd, nil
+	}
+	return opts, nil
+}
+
+// Validate returns an error for any invalid optional flags.
+func (o *promoteSvcOpts) Validate() error {
+	if o.fromEnvName != "" && o.fromEnvName == o.toEnvName {
+		return errSameFromAndToEnv
+	}
+	if o.appName != "" && o.name != "" {
+		svc, err := o.store.GetService(o.appName, o.name)
+		if err != nil {
+			return fmt.Errorf("get service %s configuration: %w", o.name, err)
+		}
+		if err := validatePromotedSvcType(svc.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ask prompts for and validates any required flags.
+func (o *promoteSvcOpts) Ask() error {
+	if o.appName == "" {
+		// NOTE: This command is required to be executed under a workspace. We don't prompt for it.
+		return errNoAppInWorkspace
+	}
+	if _, err := o.store.GetApplication(o.appName); err != nil {
+		return fmt.Errorf("get application %s configuration: %w", o.appName, err)
+	}
+	if err := o.validateOrAskSvcName(); err != nil {
+		return err
+	}
+	fromEnv, err := o.validateOrAskEnvName(o.fromEnvName, svcPromoteFromEnvPrompt, svcPromoteFromEnvHelpPrompt)
+	if err != nil {
+		return err
+	}
+	o.fromEnvName = fromEnv
+	toEnv, err := o.validateOrAskEnvName(o.toEnvName, svcPromoteToEnvPrompt, svcPromoteToEnvHelpPrompt)
+	if err != nil {
+		return err
+	}
+	o.toEnvName = toEnv
+	if o.fromEnvName == o.toEnvName {
+		return errSameFromAndToEnv
+	}
+	return nil
+}
+
+// Execute deploys the image running in the source environment to the target environment.
+func (o *promoteSvcOpts) Execute() error {
+	svc, err := o.store.GetService(o.appName, o.name)
+	if err != nil {
+		return fmt.Errorf("get service %s configuration: %w", o.name, err)
+	}
+	if err := validatePromotedSvcType(svc.Type); err != nil {
+		return err
+	}
+	fromEnv, err := o.store.GetEnvironment(o.appName, o.fromEnvName)
+	if err != nil {
+		return fmt.Errorf("get environment %s configuration: %w", o.fromEnvName, err)
+	}
+	toEnv, err := o.store.GetEnvironment(o.appName, o.toEnvName)
+	if err != nil {
+		return fmt.Errorf("get environment %s configuration: %w", o.toEnvName, err)
+	}
+	if fromEnv.Region != toEnv.Region {
+		// Each region has its own ECR repository, so the digest doesn't exist in the target region.
+		return fmt.Errorf("environment %s is in region %s but environment %s is in region %s: images can only be promoted between environments in the same region",
+			fromEnv.Name, fromEnv.Region, toEnv.Name, toEnv.Region)
+	}
+	digest, err := o.runningImageDigest(fromEnv)
+	if err != nil {
+		return err
+	}
+	log.Infof(fmtSvcPromoteStart, color.HighlightResource(digest), color.HighlightUserInput(o.name),
+		color.HighlightUserInput(o.fromEnvName), color.HighlightUserInput(o.toEnvName))
+	cmd, err := o.newSvcDeployCmd(digest)
+	if err != nil {
+		return err
+	}
+	o.deployCmd = cmd
+	return cmd.Execute()
+}
+
+// RecommendActions returns follow-up actions the user can take after successfully executing the command.
+func (o *promoteSvcOpts) RecommendActions() error {
+	if o.deployCmd == nil {
+		return nil
+	}
+	return o.deployCmd.RecommendActions()
+}
+
+func (o *promoteSvcOpts) runningImageDigest(env *config.Environment) (string, error) {
+	describer, err := o.newTaskDefDescriber(env)
+	if err != nil {
+		return "", err
+	}
+	taskDef, err := describer.TaskDefinition(o.appName, env.Name, o.name)
+	if err != nil {
+		return "", err
+	}
+	// The main container is named after the service.
+	image, err := taskDef.Image(o.name)
+	if err != nil {
+		return "", fmt.Errorf("get image of service %s in environment %s: %w", o.name, env.Name, err)
+	}
+	if parts := strings.SplitN(image, "@", 2); len(parts) == 2 && parts[1] != "" {
+		return parts[1], nil
+	}
+	// The image was deployed by tag, resolve the tag to the digest that it currently points to.
+	repo, tag, err := parseECRImage(image)
+	if err != nil {
+		return "", fmt.Errorf("image %s of service %s in environment %s cannot be promoted: %w", image, o.name, env.Name, err)
+	}
+	getter, err := o.newImageDigestGetter(env.Region)
+	if err != nil {
+		return "", err
+	}
+	digest, err := getter.ImageDigest(repo, tag)
+	if err != nil {
+		return "", fmt.Errorf("get digest of image %s: %w", image, err)
+	}
+	return digest, nil
+}
+
+// parseECRImage returns the repository name and the tag of an ECR image URI referred to by tag.
+func parseECRImage(image string) (repo, tag string, err error) {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) != 2 || !strings.Contains(parts[0], ".dkr.ecr.") {
+		return "", "", errors.New("it is not stored in an Amazon ECR repository")
+	}
+	repo, tag = parts[1], "latest"
+	if i := strings.LastIndex(repo, ":"); i != -1 {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	return repo, tag, nil
+}
+
+// validatePromotedSvcType returns an error if services of the type don't run a container image that can be promoted.
+func validatePromotedSvcType(svcType string) error {
+	if svcType == manifest.RequestDrivenWebServiceType || svcType == manifest.StaticSiteType {
+		return fmt.Errorf("promoting a service is not supported for services with type: %s", svcType)
+	}
+	return nil
+}
+
+func (o *promoteSvcOpts) validateOrAskSvcName() error {
+	if o.name != "" {
+		names, err := o.ws.ListServices()
+		if err != nil {
+			return fmt.Errorf("list services in the workspace: %w", err)
+		}
+		for _, name := range names {
+			if o.name == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("service %s not found in the workspace", color.HighlightUserInput(o.name))
+	}
+	name, err := o.sel.Service("Select a service in your workspace", "")
+	if err != nil {
+		return fmt.Errorf("select service: %w", err)
+	}
+	o.name = name
+	return nil
+}
+
+func (o *promoteSvcOpts) validateOrAskEnvName(envName, msg, help string) (string, error) {
+	if envName != "" {
+		if _, err := o.store.GetEnvironment(o.appName, envName); err != nil {
+			return "", fmt.Errorf("get environment %s configuration: %w", envName, err)
+		}
+		return envName, nil
+	}
+	name, err := o.sel.Environment(msg, help, o.appName)
+	if err != nil {
+		return "", fmt.Errorf("select environment: %w", err)
+	}
+	return name, nil
+}
+
+// buildSvcPromoteCmd builds the `svc promote` subcommand.
+func buildSvcPromoteCmd() *cobra.Command {
+	vars := promoteSvcVars{}
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Deploys the image running in one environment to another environment.",
+		Long: `Deploys the image running in one environment to another environment.
+The image is deployed by digest without being rebuilt, and the target environment's manifest overrides still apply.`,
+		Example: `
+  Promotes the image of the "frontend" service running in the "test" environment to the "prod" environment.
+  /code $ copilot svc promote --name frontend --from test --to prod`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newSvcPromoteOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().StringVarP(&vars.name, nameFlag, nameFlagShort, "", svcFlagDescription)
+	cmd.Flags().StringVar(&vars.fromEnvName, fromEnvFlag, "", fromEnvFlagDescription)
+	cmd.Flags().StringVar(&vars.toEnvName, toEnvFlag, "", toEnvFlagDescription)
+	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
+	cmd.Flags().BoolVar(&vars.forceNewUpdate, forceFlag, false, forceFlagDescription)
+	cmd.Flags().BoolVar(&vars.disableRollback, noRollbackFlag, false, noRollbackFlagDescription)
+	cmd.Flags().BoolVar(&vars.forceUnlock, forceUnlockFlag, false, forceUnlockFlagDescription)
+	return cmd
+}
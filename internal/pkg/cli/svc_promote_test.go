@@ -0,0 +1,358 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+)
+
+func TestSvcPromoteOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inSvcName string
+		inFromEnv string
+		inToEnv   string
+
+		setupMocks func(m *mocks.Mockstore)
+
+		wantedError error
+	}{
+		"valid when environments are different": {
+			inFromEnv: "test",
+			inToEnv:   "prod",
+		},
+		"valid when environments are not provided": {},
+		"error when environments are the same": {
+			inFromEnv:   "test",
+			inToEnv:     "test",
+			wantedError: errSameFromAndToEnv,
+		},
+		"error if fail to get the service": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.Mockstore) {
+				m.EXPECT().GetService("phonetool", "frontend").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("get service frontend configuration: some error"),
+		},
+		"error if the service is a Request-Driven Web Service": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.Mockstore) {
+				m.EXPECT().GetService("phonetool", "frontend").Return(&config.Workload{Type: manifest.RequestDrivenWebServiceType}, nil)
+			},
+			wantedError: errors.New("promoting a service is not supported for services with type: Request-Driven Web Service"),
+		},
+		"error if the service is a Static Site": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.Mockstore) {
+				m.EXPECT().GetService("phonetool", "frontend").Return(&config.Workload{Type: manifest.StaticSiteType}, nil)
+			},
+			wantedError: errors.New("promoting a service is not supported for services with type: Static Site"),
+		},
+		"valid when the service runs a container image": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.Mockstore) {
+				m.EXPECT().GetService("phonetool", "frontend").Return(&config.Workload{Type: manifest.LoadBalancedWebServiceType}, nil)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mocks.NewMockstore(ctrl)
+			if tc.setupMocks != nil {
+				tc.setupMocks(store)
+			}
+			opts := promoteSvcOpts{
+				promoteSvcVars: promoteSvcVars{
+					appName:     "phonetool",
+					name:        tc.inSvcName,
+					fromEnvName: tc.inFromEnv,
+					toEnvName:   tc.inToEnv,
+				},
+				store: store,
+			}
+
+			err := opts.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+type svcPromoteAskMocks struct {
+	store *mocks.Mockstore
+	sel   *mocks.MockwsSelector
+	ws    *mocks.MockwsWlDirReader
+}
+
+func TestSvcPromoteOpts_Ask(t *testing.T) {
+	testCases := map[string]struct {
+		inAppName string
+		inSvcName string
+		inFromEnv string
+		inToEnv   string
+
+		setupMocks func(m *svcPromoteAskMocks)
+
+		wantedSvcName string
+		wantedFromEnv string
+		wantedToEnv   string
+		wantedError   error
+	}{
+		"error instead of prompting for application name if not provided": {
+			setupMocks: func(m *svcPromoteAskMocks) {
+				m.store.EXPECT().GetApplication(gomock.Any()).Times(0)
+			},
+			wantedError: errNoAppInWorkspace,
+		},
+		"validate instead of prompting for service and environment names": {
+			inAppName: "phonetool",
+			inSvcName: "frontend",
+			inFromEnv: "test",
+			inToEnv:   "prod",
+			setupMocks: func(m *svcPromoteAskMocks) {
+				m.store.EXPECT().GetApplication("phonetool")
+				m.ws.EXPECT().ListServices().Return([]string{"frontend"}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "test").Return(&config.Environment{Name: "test"}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "prod").Return(&config.Environment{Name: "prod"}, nil)
+				m.sel.EXPECT().Service(gomock.Any(), gomock.Any()).Times(0)
+				m.sel.EXPECT().Environment(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantedSvcName: "frontend",
+			wantedFromEnv: "test",
+			wantedToEnv:   "prod",
+		},
+		"error if the service is not in the workspace": {
+			inAppName: "phonetool",
+			inSvcName: "frontend",
+			setupMocks: func(m *svcPromoteAskMocks) {
+				m.store.EXPECT().GetApplication("phonetool")
+				m.ws.EXPECT().ListServices().Return([]string{"backend"}, nil)
+			},
+			wantedError: errors.New("service frontend not found in the workspace"),
+		},
+		"prompt for service and environment names": {
+			inAppName: "phonetool",
+			setupMocks: func(m *svcPromoteAskMocks) {
+				m.store.EXPECT().GetApplication("phonetool")
+				m.sel.EXPECT().Service(gomock.Any(), gomock.Any()).Return("frontend", nil)
+				m.sel.EXPECT().Environment(svcPromoteFromEnvPrompt, svcPromoteFromEnvHelpPrompt, "phonetool").Return("test", nil)
+				m.sel.EXPECT().Environment(svcPromoteToEnvPrompt, svcPromoteToEnvHelpPrompt, "phonetool").Return("prod", nil)
+			},
+			wantedSvcName: "frontend",
+			wantedFromEnv: "test",
+			wantedToEnv:   "prod",
+		},
+		"error if the selected environments are the same": {
+			inAppName: "phonetool",
+			inSvcName: "frontend",
+			inFromEnv: "test",
+			setupMocks: func(m *svcPromoteAskMocks) {
+				m.store.EXPECT().GetApplication("phonetool")
+				m.ws.EXPECT().ListServices().Return([]string{"frontend"}, nil)
+				m.store.EXPECT().GetEnvironment("phonetool", "test").Return(&config.Environment{Name: "test"}, nil)
+				m.sel.EXPECT().Environment(svcPromoteToEnvPrompt, svcPromoteToEnvHelpPrompt, "phonetool").Return("test", nil)
+			},
+			wantedError: errSameFromAndToEnv,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &svcPromoteAskMocks{
+				store: mocks.NewMockstore(ctrl),
+				sel:   mocks.NewMockwsSelector(ctrl),
+				ws:    mocks.NewMockwsWlDirReader(ctrl),
+			}
+			tc.setupMocks(m)
+			opts := promoteSvcOpts{
+				promoteSvcVars: promoteSvcVars{
+					appName:     tc.inAppName,
+					name:        tc.inSvcName,
+					fromEnvName: tc.inFromEnv,
+					toEnvName:   tc.inToEnv,
+				},
+				store: m.store,
+				sel:   m.sel,
+				ws:    m.ws,
+			}
+
+			err := opts.Ask()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedSvcName, opts.name)
+				require.Equal(t, tc.wantedFromEnv, opts.fromEnvName)
+				require.Equal(t, tc.wantedToEnv, opts.toEnvName)
+			}
+		})
+	}
+}
+
+type svcPromoteExecuteMocks struct {
+	store        *mocks.Mockstore
+	describer    *mocks.MocktaskDefinitionDescriber
+	digestGetter *mocks.MockimageDigestGetter
+	deployCmd    *mocks.MockactionCommand
+}
+
+func TestSvcPromoteOpts_Execute(t *testing.T) {
+	const (
+		mockApp    = "phonetool"
+		mockSvc    = "frontend"
+		mockDigest = "sha256:18f7d8a4e0c5b7a9e0e5d2f36e1c4f3a4b1c1c9a7c36e4f8a2e7d1b0f6d4c3b2"
+	)
+	mockTestEnv := &config.Environment{Name: "test", Region: "us-west-2"}
+	mockProdEnv := &config.Environment{Name: "prod", Region: "us-west-2"}
+	taskDefWithImage := func(image string) *ecs.TaskDefinition {
+		return &ecs.TaskDefinition{
+			ContainerDefinitions: []*awsecs.ContainerDefinition{
+				{
+					Name:  aws.String(mockSvc),
+					Image: aws.String(image),
+				},
+			},
+		}
+	}
+	mockError := errors.New("some error")
+
+	testCases := map[string]struct {
+		setupMocks func(m *svcPromoteExecuteMocks)
+
+		wantedDigest string
+		wantedError  error
+	}{
+		"error if the service is a Request-Driven Web Service": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.RequestDrivenWebServiceType}, nil)
+			},
+			wantedError: errors.New("promoting a service is not supported for services with type: Request-Driven Web Service"),
+		},
+		"error if the environments are in different regions": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.LoadBalancedWebServiceType}, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "test").Return(mockTestEnv, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "prod").Return(&config.Environment{Name: "prod", Region: "us-east-1"}, nil)
+			},
+			wantedError: errors.New("environment test is in region us-west-2 but environment prod is in region us-east-1: images can only be promoted between environments in the same region"),
+		},
+		"error if fail to get the task definition": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.LoadBalancedWebServiceType}, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "test").Return(mockTestEnv, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "prod").Return(mockProdEnv, nil)
+				m.describer.EXPECT().TaskDefinition(mockApp, "test", mockSvc).Return(nil, mockError)
+			},
+			wantedError: mockError,
+		},
+		"error if the running image is not stored in ECR": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.LoadBalancedWebServiceType}, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "test").Return(mockTestEnv, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "prod").Return(mockProdEnv, nil)
+				m.describer.EXPECT().TaskDefinition(mockApp, "test", mockSvc).Return(taskDefWithImage("nginx:latest"), nil)
+			},
+			wantedError: fmt.Errorf("image nginx:latest of service frontend in environment test cannot be promoted: it is not stored in an Amazon ECR repository"),
+		},
+		"error if fail to resolve the tag of the running image": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.LoadBalancedWebServiceType}, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "test").Return(mockTestEnv, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "prod").Return(mockProdEnv, nil)
+				m.describer.EXPECT().TaskDefinition(mockApp, "test", mockSvc).
+					Return(taskDefWithImage("123456789012.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:v1.0"), nil)
+				m.digestGetter.EXPECT().ImageDigest("phonetool/frontend", "v1.0").Return("", mockError)
+			},
+			wantedError: errors.New("get digest of image 123456789012.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:v1.0: some error"),
+		},
+		"deploy the digest that the tag of the running image points to": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.LoadBalancedWebServiceType}, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "test").Return(mockTestEnv, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "prod").Return(mockProdEnv, nil)
+				m.describer.EXPECT().TaskDefinition(mockApp, "test", mockSvc).
+					Return(taskDefWithImage("123456789012.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:v1.0"), nil)
+				m.digestGetter.EXPECT().ImageDigest("phonetool/frontend", "v1.0").Return(mockDigest, nil)
+				m.deployCmd.EXPECT().Execute().Return(nil)
+			},
+			wantedDigest: mockDigest,
+		},
+		"deploy the running image digest to the target environment": {
+			setupMocks: func(m *svcPromoteExecuteMocks) {
+				m.store.EXPECT().GetService(mockApp, mockSvc).Return(&config.Workload{Type: manifest.BackendServiceType}, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "test").Return(mockTestEnv, nil)
+				m.store.EXPECT().GetEnvironment(mockApp, "prod").Return(mockProdEnv, nil)
+				m.describer.EXPECT().TaskDefinition(mockApp, "test", mockSvc).
+					Return(taskDefWithImage("123456789012.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend@"+mockDigest), nil)
+				m.deployCmd.EXPECT().Execute().Return(nil)
+			},
+			wantedDigest: mockDigest,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &svcPromoteExecuteMocks{
+				store:        mocks.NewMockstore(ctrl),
+				describer:    mocks.NewMocktaskDefinitionDescriber(ctrl),
+				digestGetter: mocks.NewMockimageDigestGetter(ctrl),
+				deployCmd:    mocks.NewMockactionCommand(ctrl),
+			}
+			tc.setupMocks(m)
+			var gotDigest string
+			opts := promoteSvcOpts{
+				promoteSvcVars: promoteSvcVars{
+					appName:     mockApp,
+					name:        mockSvc,
+					fromEnvName: "test",
+					toEnvName:   "prod",
+				},
+				store: m.store,
+				newTaskDefDescriber: func(env *config.Environment) (taskDefinitionDescriber, error) {
+					require.Equal(t, mockTestEnv, env)
+					return m.describer, nil
+				},
+				newImageDigestGetter: func(region string) (imageDigestGetter, error) {
+					require.Equal(t, "us-west-2", region)
+					return m.digestGetter, nil
+				},
+				newSvcDeployCmd: func(imageDigest string) (actionCommand, error) {
+					gotDigest = imageDigest
+					return m.deployCmd, nil
+				},
+			}
+
+			err := opts.Execute()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedDigest, gotDigest)
+			}
+		})
+	}
+}
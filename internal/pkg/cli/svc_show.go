@@ -106,6 +106,14 @@ func newShowSvcOpts(vars showSvcVars) (*showSvcOpts, error) {
 				DeployStore:     deployStore,
 				EnableResources: opts.shouldOutputResources,
 			})
+		case manifest.StaticSiteType:
+			d, err = describe.NewStaticSiteDescriber(describe.NewServiceConfig{
+				App:             opts.appName,
+				Svc:             opts.svcName,
+				ConfigStore:     ssmStore,
+				DeployStore:     deployStore,
+				EnableResources: opts.shouldOutputResources,
+			})
 		default:
 			return fmt.Errorf("invalid service type %s", svc.Type)
 		}
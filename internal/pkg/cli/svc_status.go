@@ -66,6 +66,9 @@ func newSvcStatusOpts(vars svcStatusVars) (*svcStatusOpts, error) {
 			if err != nil {
 				return fmt.Errorf("retrieve %s from application %s: %w", o.appName, o.svcName, err)
 			}
+			if wkld.Type == manifest.StaticSiteType {
+				return fmt.Errorf("showing the status of a service is not supported for services with type: '%s'; run `copilot svc show` instead", manifest.StaticSiteType)
+			}
 			if wkld.Type == manifest.RequestDrivenWebServiceType {
 				d, err := describe.NewAppRunnerStatusDescriber(&describe.NewServiceStatusConfig{
 					App:         o.appName,
@@ -58,13 +58,16 @@ var (
 	errValueBadFormatWithPeriodUnderscore = errors.New("value must contain only alphanumeric characters and ._-")
 	errDDBAttributeBadFormat              = errors.New("value must be of the form <name>:<T> where T is one of S, N, or B")
 	errTooManyLSIKeys                     = errors.New("number of specified LSI sort keys must be 5 or less")
+	errValueNotAPositiveInt               = errors.New("value must be a positive integer")
+	errTooManyGSIs                        = errors.New("number of specified global secondary indexes must be 20 or less")
 
 	// Aurora-Serverless-specific errors.
-	errInvalidRDSNameCharacters    = errors.New("value must start with a letter")
-	errRDWSNotConnectedToVPC       = fmt.Errorf("%s requires a VPC connection", manifest.RequestDrivenWebServiceType)
-	fmtErrInvalidEngineType        = "invalid engine type %s: must be one of %s"
-	fmtErrInvalidDBNameCharacters  = "invalid database name %s: must contain only alphanumeric characters and underscore; should start with a letter"
-	errInvalidSecretNameCharacters = errors.New("value must contain only letters, numbers, periods, hyphens and underscores")
+	errInvalidRDSNameCharacters      = errors.New("value must start with a letter")
+	errRDWSNotConnectedToVPC         = fmt.Errorf("%s requires a VPC connection", manifest.RequestDrivenWebServiceType)
+	errStorageTypeNotSupportedByRDWS = fmt.Errorf("not supported for %s", manifest.RequestDrivenWebServiceType)
+	fmtErrInvalidEngineType          = "invalid engine type %s: must be one of %s"
+	fmtErrInvalidDBNameCharacters    = "invalid database name %s: must contain only alphanumeric characters and underscore; should start with a letter"
+	errInvalidSecretNameCharacters   = errors.New("value must contain only letters, numbers, periods, hyphens and underscores")
 
 	// Topic subscription errors.
 	errMissingPublishTopicField = errors.New("field `publish.topics[].name` cannot be empty")
@@ -346,8 +349,31 @@ func validateStorageType(val interface{}, opts validateStorageTypeOpts) error {
 		return fmt.Errorf(fmtErrInvalidStorageType, storageType, prettify(storageTypes))
 	}
 
-	if storageType == rdsStorageType {
+	switch storageType {
+	case rdsStorageType:
 		return validateAuroraStorageType(opts.ws, opts.workloadName)
+	case redisStorageType, openSearchStorageType:
+		return validateECSOnlyStorageType(storageType, opts.ws, opts.workloadName)
+	}
+	return nil
+}
+
+// validateECSOnlyStorageType returns an error if the workload is a Request-Driven Web Service,
+// since the storage type requires attaching a security group to the workload's tasks.
+func validateECSOnlyStorageType(storageType string, ws manifestReader, workloadName string) error {
+	if workloadName == "" {
+		return nil // Workload not yet selected while validating storage type flag.
+	}
+	mft, err := ws.ReadWorkloadManifest(workloadName)
+	if err != nil {
+		return fmt.Errorf("invalid storage type %s: read manifest file for %s: %w", storageType, workloadName, err)
+	}
+	mftType, err := mft.WorkloadType()
+	if err != nil {
+		return fmt.Errorf("invalid storage type %s: read type of workload from manifest file for %s: %w", storageType, workloadName, err)
+	}
+	if mftType == manifest.RequestDrivenWebServiceType {
+		return fmt.Errorf("invalid storage type %s: %w", storageType, errStorageTypeNotSupportedByRDWS)
 	}
 	return nil
 }
@@ -728,6 +754,46 @@ func validateLSIs(val interface{}) error {
 	return nil
 }
 
+func validateGSIs(val interface{}) error {
+	s, ok := val.([]string)
+	if !ok {
+		return errValueNotAStringSlice
+	}
+	if len(s) > 20 {
+		return errTooManyGSIs
+	}
+	for _, input := range s {
+		gsi, err := addon.DDBGlobalSecondaryIndexFromInput(input)
+		if err != nil {
+			return err
+		}
+		if err := dynamoTableNameValidation(aws.StringValue(gsi.Name)); err != nil {
+			return fmt.Errorf("validate name of global secondary index %s: %w", aws.StringValue(gsi.Name), err)
+		}
+		for _, key := range []*string{gsi.PartitionKey, gsi.SortKey} {
+			if key == nil {
+				continue
+			}
+			if err := dynamoAttributeNameValidation(aws.StringValue(key)); err != nil {
+				return fmt.Errorf("validate key %s of global secondary index %s: %w", aws.StringValue(key), aws.StringValue(gsi.Name), err)
+			}
+		}
+	}
+	return nil
+}
+
+func validatePositiveInt(val interface{}) error {
+	s, ok := val.(string)
+	if !ok {
+		return errValueNotAString
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return errValueNotAPositiveInt
+	}
+	return nil
+}
+
 func validateSubscribe(noSubscription bool, subscribeTags []string) error {
 	// --no-subscriptions and --subscribe are mutually exclusive.
 	if noSubscription && len(subscribeTags) != 0 {
@@ -454,6 +454,56 @@ network:
 				workloadName: "api",
 			},
 		},
+		"should allow SQS for a RDWS": {
+			input: "SQS",
+			optionals: validateStorageTypeOpts{
+				ws: mockManifestReader{
+					out: []byte(`
+name: api
+type: Request-Driven Web Service
+`),
+				},
+				workloadName: "api",
+			},
+		},
+		"should allow Redis if the workload type is not a RDWS": {
+			input: "Redis",
+			optionals: validateStorageTypeOpts{
+				ws: mockManifestReader{
+					out: []byte(`
+name: api
+type: Backend Service
+`),
+				},
+				workloadName: "api",
+			},
+		},
+		"should return an error if Redis is selected for a RDWS": {
+			input: "Redis",
+			optionals: validateStorageTypeOpts{
+				ws: mockManifestReader{
+					out: []byte(`
+name: api
+type: Request-Driven Web Service
+network:
+  vpc:
+    placement: private
+`),
+				},
+				workloadName: "api",
+			},
+			want: errors.New("invalid storage type Redis: not supported for Request-Driven Web Service"),
+		},
+		"should return an error if manifest file cannot be read while initializing an OpenSearch storage type": {
+			input: "OpenSearch",
+			optionals: validateStorageTypeOpts{
+				ws: mockManifestReader{
+					err: errors.New("some error"),
+				},
+				workloadName: "api",
+			},
+			want: errors.New("invalid storage type OpenSearch: read manifest file for api: some error"),
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -525,6 +575,73 @@ func TestValidateLSIs(t *testing.T) {
 	}
 }
 
+func TestValidateGSIs(t *testing.T) {
+	testCases := map[string]struct {
+		inputGSIs []string
+		wantError error
+	}{
+		"good case": {
+			inputGSIs: []string{"name=byEmail,partition=Email:S,sort=CreatedAt:N,projection=KEYS_ONLY"},
+		},
+		"bad gsi structure": {
+			inputGSIs: []string{"name=byEmail"},
+			wantError: errors.New("parse global secondary index name=byEmail: name and partition are required"),
+		},
+		"bad index name": {
+			inputGSIs: []string{"name=by Email,partition=Email:S"},
+			wantError: fmt.Errorf("validate name of global secondary index by Email: %w", errValueBadFormatWithPeriodUnderscore),
+		},
+		"too many gsis": {
+			inputGSIs: func() []string {
+				var gsis []string
+				for i := 0; i < 21; i++ {
+					gsis = append(gsis, fmt.Sprintf("name=index%d,partition=Email:S", i))
+				}
+				return gsis
+			}(),
+			wantError: errTooManyGSIs,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := validateGSIs(tc.inputGSIs)
+			if tc.wantError != nil {
+				require.EqualError(t, got, tc.wantError.Error())
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestValidatePositiveInt(t *testing.T) {
+	testCases := map[string]struct {
+		input     interface{}
+		wantError error
+	}{
+		"good case": {
+			input: "5",
+		},
+		"not a string": {
+			input:     5,
+			wantError: errValueNotAString,
+		},
+		"not a number": {
+			input:     "five",
+			wantError: errValueNotAPositiveInt,
+		},
+		"zero": {
+			input:     "0",
+			wantError: errValueNotAPositiveInt,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wantError, validatePositiveInt(tc.input))
+		})
+	}
+}
+
 func TestValidateCIDR(t *testing.T) {
 	testCases := map[string]struct {
 		inputCIDR string
@@ -20,6 +20,8 @@ type Application struct {
 	DomainHostedZoneID string            `json:"domainHostedZoneID"` // Existing domain hosted zone in Route53. An empty domain name means the user does not have one.
 	Version            string            `json:"version"`            // The version of the app layout in the underlying datastore (e.g. SSM).
 	Tags               map[string]string `json:"tags,omitempty"`     // Labels to apply to resources created within the app.
+
+	DeploymentRetention int `json:"deploymentRetention,omitempty"` // Number of deployment records kept per workload and environment. Zero keeps every record.
 }
 
 // CreateApplication instantiates a new application, validates its uniqueness and stores it in SSM.
@@ -121,6 +123,10 @@ func (s *Store) ListApplications() ([]*Application, error) {
 
 // DeleteApplication deletes the SSM parameter related to the application.
 func (s *Store) DeleteApplication(name string) error {
+	// Remove the deployment records left behind by workloads that were not deleted on their own.
+	if err := s.deleteParamsByPath(fmt.Sprintf(rootAppDeploymentsPath, name)); err != nil {
+		return fmt.Errorf("delete deployment records of application %s: %w", name, err)
+	}
 	paramName := fmt.Sprintf(fmtApplicationPath, name)
 
 	_, err := s.ssm.DeleteParameter(&ssm.DeleteParameterInput{
@@ -353,10 +353,35 @@ func TestDeleteApplication(t *testing.T) {
 	mockError := errors.New("mockError")
 
 	tests := map[string]struct {
-		mockDeleteParameter func(t *testing.T, in *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error)
+		mockGetParametersByPath func(t *testing.T, in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+		mockDeleteParameter     func(t *testing.T, in *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error)
 
 		want error
 	}{
+		"should delete the deployment records of the application": {
+			mockGetParametersByPath: func(t *testing.T, in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, fmt.Sprintf(rootAppDeploymentsPath, mockApplicationName), *in.Path)
+				require.True(t, *in.Recursive)
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Name: aws.String("/copilot/applications/mockApplicationName/deployments/frontend/test/1494505750000000000")},
+					},
+				}, nil
+			},
+			mockDeleteParameter: func(t *testing.T, in *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+				require.Contains(t, []string{
+					"/copilot/applications/mockApplicationName/deployments/frontend/test/1494505750000000000",
+					fmt.Sprintf(fmtApplicationPath, mockApplicationName),
+				}, *in.Name)
+				return &ssm.DeleteParameterOutput{}, nil
+			},
+		},
+		"should wrap errors from deleting the deployment records": {
+			mockGetParametersByPath: func(t *testing.T, in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return nil, mockError
+			},
+			want: fmt.Errorf("delete deployment records of application %s: %w", mockApplicationName, mockError),
+		},
 		"should return nil given success": {
 			mockDeleteParameter: func(t *testing.T, in *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
 				require.Equal(t, fmt.Sprintf(fmtApplicationPath, mockApplicationName), *in.Name)
@@ -387,8 +412,9 @@ func TestDeleteApplication(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			store := &Store{
 				ssm: &mockSSM{
-					t:                   t,
-					mockDeleteParameter: test.mockDeleteParameter,
+					t:                       t,
+					mockGetParametersByPath: test.mockGetParametersByPath,
+					mockDeleteParameter:     test.mockDeleteParameter,
 				},
 			}
 
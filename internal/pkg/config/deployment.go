@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// Deployment is a record of a workload deployed to an environment.
+type Deployment struct {
+	App          string    `json:"app"`                   // Name of the app this deployment belongs to.
+	Env          string    `json:"env"`                   // Name of the environment the workload was deployed to.
+	Name         string    `json:"name"`                  // Name of the deployed workload.
+	DeployedBy   string    `json:"deployedBy"`            // ARN of the IAM identity that deployed the workload.
+	DeployedAt   time.Time `json:"deployedAt"`            // Time that the deployment completed.
+	ImageDigest  string    `json:"imageDigest,omitempty"` // Digest of the container image built by Copilot, if any.
+	GitCommit    string    `json:"gitCommit,omitempty"`   // Output of "git describe" in the workspace, if it's a git repository.
+	GitDirty     bool      `json:"gitDirty"`              // Whether the workspace had uncommitted changes.
+	ManifestHash string    `json:"manifestHash"`          // SHA-256 hash of the workload's manifest file.
+}
+
+// CreateDeployment records a deployment of a workload to an environment.
+func (s *Store) CreateDeployment(d *Deployment) error {
+	// Name the parameter after the timestamp so that parameters are unique and ordered.
+	path := fmt.Sprintf(fmtDeploymentPath, d.App, d.Name, d.Env, strconv.FormatInt(d.DeployedAt.UnixNano(), 10))
+	data, err := marshal(d)
+	if err != nil {
+		return fmt.Errorf("serialize data: %w", err)
+	}
+	_, err = s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(path),
+		Description: aws.String(fmt.Sprintf("Deployment of %s to environment %s", d.Name, d.Env)),
+		Type:        aws.String(ssm.ParameterTypeString),
+		Value:       aws.String(data),
+	})
+	if err != nil {
+		return fmt.Errorf("create deployment record of %s to environment %s in application %s: %w", d.Name, d.Env, d.App, err)
+	}
+	return nil
+}
+
+// PruneDeployments deletes the oldest deployment records of a workload in an environment
+// so that only the most recent "keep" records remain.
+func (s *Store) PruneDeployments(appName, wkldName, envName string, keep int) error {
+	params, err := s.listParameters(fmt.Sprintf(fmtDeploymentsPath, appName, wkldName, envName), false)
+	if err != nil {
+		return fmt.Errorf("list deployment records of %s to environment %s in application %s: %w", wkldName, envName, appName, err)
+	}
+	if len(params) <= keep {
+		return nil
+	}
+	// Parameters are named after the deployment timestamp in nanoseconds.
+	timestamp := func(param *ssm.Parameter) int64 {
+		name := aws.StringValue(param.Name)
+		ts, _ := strconv.ParseInt(name[strings.LastIndex(name, "/")+1:], 10, 64)
+		return ts
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return timestamp(params[i]) < timestamp(params[j])
+	})
+	for _, param := range params[:len(params)-keep] {
+		if err := s.deleteParam(aws.StringValue(param.Name)); err != nil {
+			return fmt.Errorf("delete deployment record %s: %w", aws.StringValue(param.Name), err)
+		}
+	}
+	return nil
+}
+
+// DeleteDeployments removes all the deployment records of a workload.
+func (s *Store) DeleteDeployments(appName, wkldName string) error {
+	if err := s.deleteParamsByPath(fmt.Sprintf(rootDeploymentsPath, appName, wkldName)); err != nil {
+		return fmt.Errorf("delete deployment records of %s in application %s: %w", wkldName, appName, err)
+	}
+	return nil
+}
+
+// ListDeployments returns the deployments of a workload, most recent first.
+// If envName is empty, it returns the deployments to all environments.
+func (s *Store) ListDeployments(appName, wkldName, envName string) ([]*Deployment, error) {
+	path := fmt.Sprintf(rootDeploymentsPath, appName, wkldName)
+	if envName != "" {
+		path = fmt.Sprintf(fmtDeploymentsPath, appName, wkldName, envName)
+	}
+	serializedDeployments, err := s.listParamsByPath(path, envName == "")
+	if err != nil {
+		return nil, fmt.Errorf("list deployments of %s in application %s: %w", wkldName, appName, err)
+	}
+	var deployments []*Deployment
+	for _, serialized := range serializedDeployments {
+		var d Deployment
+		if err := json.Unmarshal([]byte(aws.StringValue(serialized)), &d); err != nil {
+			return nil, fmt.Errorf("read deployment of %s in application %s: %w", wkldName, appName, err)
+		}
+		deployments = append(deployments, &d)
+	}
+	sort.SliceStable(deployments, func(i, j int) bool {
+		return deployments[i].DeployedAt.After(deployments[j].DeployedAt)
+	})
+	return deployments, nil
+}
@@ -0,0 +1,282 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_CreateDeployment(t *testing.T) {
+	deployment := Deployment{
+		App:          "phonetool",
+		Env:          "prod",
+		Name:         "frontend",
+		DeployedBy:   "arn:aws:iam::123456789012:user/alice",
+		DeployedAt:   time.Unix(1494505750, 0).UTC(),
+		ImageDigest:  "sha256:abc",
+		GitCommit:    "v1.2.0-3-g4b5c6d7",
+		ManifestHash: "d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26",
+	}
+	deploymentString, err := marshal(deployment)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		mockPutParameter func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
+
+		wantedErr error
+	}{
+		"writes the deployment under the workload and environment path": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/deployments/frontend/prod/1494505750000000000", aws.StringValue(param.Name))
+				require.Equal(t, deploymentString, aws.StringValue(param.Value))
+				return &ssm.PutParameterOutput{}, nil
+			},
+		},
+		"wraps SSM errors": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("create deployment record of frontend to environment prod in application phonetool: some error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := &Store{
+				ssm: &mockSSM{
+					t:                t,
+					mockPutParameter: tc.mockPutParameter,
+				},
+			}
+
+			err := store.CreateDeployment(&deployment)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStore_PruneDeployments(t *testing.T) {
+	deploymentParams := func(n int) []*ssm.Parameter {
+		var params []*ssm.Parameter
+		// List the records newest first to make sure that they are sorted by timestamp.
+		for i := n; i > 0; i-- {
+			params = append(params, &ssm.Parameter{
+				Name: aws.String(fmt.Sprintf("/copilot/applications/phonetool/deployments/frontend/prod/%d", 1494505750000000000+i)),
+			})
+		}
+		return params
+	}
+	testCases := map[string]struct {
+		mockGetParametersByPath func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+		mockDeleteParameter     func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error)
+
+		wantedErr error
+	}{
+		"does not delete records within the limit": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return &ssm.GetParametersByPathOutput{
+					Parameters: deploymentParams(3),
+				}, nil
+			},
+		},
+		"deletes the oldest records beyond the limit": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/deployments/frontend/prod/", aws.StringValue(param.Path))
+				return &ssm.GetParametersByPathOutput{
+					Parameters: deploymentParams(5),
+				}, nil
+			},
+			mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+				require.Contains(t, []string{
+					"/copilot/applications/phonetool/deployments/frontend/prod/1494505750000000001",
+					"/copilot/applications/phonetool/deployments/frontend/prod/1494505750000000002",
+				}, aws.StringValue(param.Name))
+				return &ssm.DeleteParameterOutput{}, nil
+			},
+		},
+		"wraps errors from listing records": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("list deployment records of frontend to environment prod in application phonetool: some error"),
+		},
+		"wraps errors from deleting old records": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return &ssm.GetParametersByPathOutput{
+					Parameters: deploymentParams(4),
+				}, nil
+			},
+			mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("delete deployment record /copilot/applications/phonetool/deployments/frontend/prod/1494505750000000001: some error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := &Store{
+				ssm: &mockSSM{
+					t:                       t,
+					mockGetParametersByPath: tc.mockGetParametersByPath,
+					mockDeleteParameter:     tc.mockDeleteParameter,
+				},
+			}
+
+			err := store.PruneDeployments("phonetool", "frontend", "prod", 3)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStore_ListDeployments(t *testing.T) {
+	older := Deployment{App: "phonetool", Env: "test", Name: "frontend", DeployedAt: time.Unix(1494505750, 0).UTC()}
+	newer := Deployment{App: "phonetool", Env: "prod", Name: "frontend", DeployedAt: time.Unix(1494505850, 0).UTC()}
+	olderString, err := marshal(older)
+	require.NoError(t, err)
+	newerString, err := marshal(newer)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		inEnv                   string
+		mockGetParametersByPath func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+
+		wantedDeployments []*Deployment
+		wantedErr         error
+	}{
+		"lists deployments to every environment recursively, most recent first": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/deployments/frontend/", aws.StringValue(param.Path))
+				require.True(t, aws.BoolValue(param.Recursive))
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Value: aws.String(olderString)},
+						{Value: aws.String(newerString)},
+					},
+				}, nil
+			},
+			wantedDeployments: []*Deployment{&newer, &older},
+		},
+		"lists deployments to a single environment": {
+			inEnv: "prod",
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/deployments/frontend/prod/", aws.StringValue(param.Path))
+				require.False(t, aws.BoolValue(param.Recursive))
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Value: aws.String(newerString)},
+					},
+				}, nil
+			},
+			wantedDeployments: []*Deployment{&newer},
+		},
+		"with SSM error": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return nil, fmt.Errorf("broken")
+			},
+			wantedErr: errors.New("list deployments of frontend in application phonetool: broken"),
+		},
+		"with malformed json": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Value: aws.String("oops")},
+					},
+				}, nil
+			},
+			wantedErr: errors.New("read deployment of frontend in application phonetool: invalid character 'o' looking for beginning of value"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := &Store{
+				ssm: &mockSSM{
+					t:                       t,
+					mockGetParametersByPath: tc.mockGetParametersByPath,
+				},
+			}
+
+			deployments, err := store.ListDeployments("phonetool", "frontend", tc.inEnv)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedDeployments, deployments)
+			}
+		})
+	}
+}
+
+func TestStore_DeleteDeployments(t *testing.T) {
+	testCases := map[string]struct {
+		mockGetParametersByPath func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+		mockDeleteParameter     func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error)
+
+		wantedDeleted []string
+		wantedErr     error
+	}{
+		"deletes the records in every environment": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/deployments/frontend/", aws.StringValue(param.Path))
+				require.True(t, aws.BoolValue(param.Recursive))
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Name: aws.String("/copilot/applications/phonetool/deployments/frontend/test/1494505750000000000")},
+						{Name: aws.String("/copilot/applications/phonetool/deployments/frontend/prod/1494505850000000000")},
+					},
+				}, nil
+			},
+			wantedDeleted: []string{
+				"/copilot/applications/phonetool/deployments/frontend/test/1494505750000000000",
+				"/copilot/applications/phonetool/deployments/frontend/prod/1494505850000000000",
+			},
+		},
+		"wraps SSM errors": {
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("delete deployment records of frontend in application phonetool: some error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			store := &Store{
+				ssm: &mockSSM{
+					t:                       t,
+					mockGetParametersByPath: tc.mockGetParametersByPath,
+					mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+						deleted = append(deleted, aws.StringValue(param.Name))
+						return &ssm.DeleteParameterOutput{}, nil
+					},
+				},
+			}
+
+			err := store.DeleteDeployments("phonetool", "frontend")
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedDeleted, deleted)
+			}
+		})
+	}
+}
@@ -32,16 +32,35 @@ func (e *Environment) HasImportedCerts() bool {
 	return e.CustomConfig != nil && len(e.CustomConfig.ImportCertARNs) != 0
 }
 
+// HasInternalCerts return if the environment has imported certs for its internal load balancer.
+func (e *Environment) HasInternalCerts() bool {
+	return e.CustomConfig != nil && len(e.CustomConfig.InternalCertARNs) != 0
+}
+
+// HasWAF returns if the environment associates a web ACL with its public load balancer.
+func (e *Environment) HasWAF() bool {
+	return e.CustomConfig != nil && e.CustomConfig.WAF != nil
+}
+
+// HasEC2Capacity returns if the environment's cluster can place tasks on EC2 instances.
+func (e *Environment) HasEC2Capacity() bool {
+	return e.CustomConfig != nil && e.CustomConfig.EC2Capacity != nil
+}
+
 // CustomizeEnv represents the custom environment config.
 type CustomizeEnv struct {
-	ImportVPC      *ImportVPC `json:"importVPC,omitempty"`
-	VPCConfig      *AdjustVPC `json:"adjustVPC,omitempty"`
-	ImportCertARNs []string   `json:"importCertARNs,omitempty"`
+	ImportVPC        *ImportVPC   `json:"importVPC,omitempty"`
+	VPCConfig        *AdjustVPC   `json:"adjustVPC,omitempty"`
+	ImportCertARNs   []string     `json:"importCertARNs,omitempty"`
+	InternalCertARNs []string     `json:"internalCertARNs,omitempty"`
+	WAF              *WAF         `json:"waf,omitempty"`
+	EC2Capacity      *EC2Capacity `json:"ec2Capacity,omitempty"`
 }
 
 // IsEmpty returns if CustomizeEnv is an empty struct.
 func (c CustomizeEnv) IsEmpty() bool {
-	return c.ImportVPC == nil && c.VPCConfig == nil && len(c.ImportCertARNs) == 0
+	return c.ImportVPC == nil && c.VPCConfig == nil && len(c.ImportCertARNs) == 0 && len(c.InternalCertARNs) == 0 &&
+		c.WAF == nil && c.EC2Capacity == nil
 }
 
 // ImportVPC holds the fields to import VPC resources.
@@ -59,6 +78,24 @@ type AdjustVPC struct {
 	PrivateSubnetCIDRs []string `json:"privateSubnetCIDRs"`
 }
 
+// WAF holds the AWS WAF web ACL associated with the public load balancer of an environment.
+// Either an existing web ACL is imported, or one is created from AWS managed rule groups and a rate-based rule.
+type WAF struct {
+	WebACLARN    string   `json:"webACLARN,omitempty"`
+	ManagedRules []string `json:"managedRules,omitempty"`
+	RateLimit    int      `json:"rateLimit,omitempty"`
+}
+
+// EC2Capacity holds the Auto Scaling group of EC2 instances registered to the ECS cluster of an environment.
+// SpotPercentage of the instances are launched as Spot Instances, the rest as On-Demand Instances.
+type EC2Capacity struct {
+	InstanceTypes  []string `json:"instanceTypes"`
+	Arch           string   `json:"arch"`
+	MinSize        int      `json:"minSize"`
+	MaxSize        int      `json:"maxSize"`
+	SpotPercentage int      `json:"spotPercentage,omitempty"`
+}
+
 // Telemetry represents optional observability and monitoring configuration.
 type Telemetry struct {
 	EnableContainerInsights bool `json:"containerInsights"`
@@ -95,6 +132,26 @@ func (s *Store) CreateEnvironment(environment *Environment) error {
 	return nil
 }
 
+// UpdateEnvironment updates the configuration of an existing environment.
+func (s *Store) UpdateEnvironment(environment *Environment) error {
+	environmentPath := fmt.Sprintf(fmtEnvParamPath, environment.App, environment.Name)
+	data, err := marshal(environment)
+	if err != nil {
+		return fmt.Errorf("serializing environment %s: %w", environment.Name, err)
+	}
+
+	if _, err = s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(environmentPath),
+		Description: aws.String(fmt.Sprintf("The %s deployment stage", environment.Name)),
+		Type:        aws.String(ssm.ParameterTypeString),
+		Value:       aws.String(data),
+		Overwrite:   aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("update environment %s in application %s: %w", environment.Name, environment.App, err)
+	}
+	return nil
+}
+
 // GetEnvironment gets an environment belonging to a particular application by name. If no environment is found
 // it returns ErrNoSuchEnvironment.
 func (s *Store) GetEnvironment(appName string, environmentName string) (*Environment, error) {
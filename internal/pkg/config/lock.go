@@ -0,0 +1,204 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+const (
+	fmtLockPath         = "/copilot/applications/%s/locks/%s" // path for an advisory lock in an application
+	fmtLockTakeoverPath = "%s/takeovers/%d"                   // path that claims the takeover of a version of an expired lock
+
+	fmtEnvironmentLockID = "environments/%s"
+	fmtWorkloadLockID    = "workloads/%s/%s"
+	fmtPipelineLockID    = "pipelines/%s"
+
+	unknownLockOwner = "unknown"
+)
+
+// EnvironmentLockID returns the ID of the lock held while an environment is updated.
+func EnvironmentLockID(envName string) string {
+	return fmt.Sprintf(fmtEnvironmentLockID, envName)
+}
+
+// WorkloadLockID returns the ID of the lock held while a workload is deployed to an environment.
+func WorkloadLockID(envName, wkldName string) string {
+	return fmt.Sprintf(fmtWorkloadLockID, envName, wkldName)
+}
+
+// PipelineLockID returns the ID of the lock held while a pipeline is deployed.
+func PipelineLockID(pipelineName string) string {
+	return fmt.Sprintf(fmtPipelineLockID, pipelineName)
+}
+
+// Lock is an advisory lock that prevents concurrent updates to the same resource.
+type Lock struct {
+	App        string    `json:"app"`        // Name of the app this lock belongs to.
+	ID         string    `json:"id"`         // ID of the locked resource within the app.
+	Owner      string    `json:"owner"`      // ARN of the IAM identity that holds the lock.
+	AcquiredAt time.Time `json:"acquiredAt"` // Time that the lock was acquired.
+	ExpiresAt  time.Time `json:"expiresAt"`  // Time after which the lock can be taken over.
+}
+
+// ErrLocked means that a lock is held by someone else.
+type ErrLocked struct {
+	Lock *Lock
+}
+
+func (e *ErrLocked) Error() string {
+	return fmt.Sprintf("%s is locked by %s since %s", e.Lock.ID, e.Lock.Owner, e.Lock.AcquiredAt.Format(time.RFC3339))
+}
+
+// AcquireLock takes the lock with the given ID for the duration of ttl.
+// If the lock is held by someone else and hasn't expired, it returns an *ErrLocked.
+func (s *Store) AcquireLock(appName, id string, ttl time.Duration) (*Lock, error) {
+	now := time.Now().UTC()
+	lock := &Lock{
+		App:        appName,
+		ID:         id,
+		Owner:      s.callerARN(),
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	_, err := s.putLock(lock, false)
+	if err == nil {
+		return lock, nil
+	}
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != ssm.ErrCodeParameterAlreadyExists {
+		return nil, fmt.Errorf("acquire lock %s in application %s: %w", id, appName, err)
+	}
+
+	existing, version, err := s.getLock(appName, id)
+	if err != nil {
+		return nil, err
+	}
+	if now.Before(existing.ExpiresAt) {
+		return nil, &ErrLocked{Lock: existing}
+	}
+	// The previous owner didn't release the lock before it expired, take it over.
+	// SSM doesn't support conditional writes, so we first claim this version of the expired lock
+	// with a parameter that can only be created once. Only the caller that claims it may overwrite the lock.
+	if err := s.claimLockTakeover(appName, id, version, lock); err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterAlreadyExists {
+			return nil, s.errLocked(appName, id)
+		}
+		return nil, fmt.Errorf("take over expired lock %s in application %s: %w", id, appName, err)
+	}
+	newVersion, err := s.putLock(lock, true)
+	if err != nil {
+		return nil, fmt.Errorf("take over expired lock %s in application %s: %w", id, appName, err)
+	}
+	if newVersion != version+1 {
+		// The lock was written by someone else after we read it.
+		return nil, s.errLocked(appName, id)
+	}
+	return lock, nil
+}
+
+// ReleaseLock releases a lock previously returned by AcquireLock.
+// The lock is left untouched if it has been taken over by someone else in the meantime.
+func (s *Store) ReleaseLock(lock *Lock) error {
+	existing, _, err := s.getLock(lock.App, lock.ID)
+	if err != nil {
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == ssm.ErrCodeParameterNotFound {
+			return nil
+		}
+		return err
+	}
+	if existing.Owner != lock.Owner || !existing.AcquiredAt.Equal(lock.AcquiredAt) {
+		return nil
+	}
+	return s.ForceReleaseLock(lock.App, lock.ID)
+}
+
+// ForceReleaseLock releases the lock with the given ID regardless of who holds it.
+func (s *Store) ForceReleaseLock(appName, id string) error {
+	path := fmt.Sprintf(fmtLockPath, appName, id)
+	if err := s.deleteParam(path); err != nil {
+		return fmt.Errorf("release lock %s in application %s: %w", id, appName, err)
+	}
+	if err := s.deleteParamsByPath(path + "/takeovers"); err != nil {
+		return fmt.Errorf("delete takeover claims of lock %s in application %s: %w", id, appName, err)
+	}
+	return nil
+}
+
+// putLock writes the lock and returns the version of the parameter that holds it.
+func (s *Store) putLock(lock *Lock, overwrite bool) (int64, error) {
+	data, err := marshal(lock)
+	if err != nil {
+		return 0, fmt.Errorf("serialize data: %w", err)
+	}
+	out, err := s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(fmt.Sprintf(fmtLockPath, lock.App, lock.ID)),
+		Description: aws.String(fmt.Sprintf("Copilot lock for %s", lock.ID)),
+		Type:        aws.String(ssm.ParameterTypeString),
+		Value:       aws.String(data),
+		Overwrite:   aws.Bool(overwrite),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return aws.Int64Value(out.Version), nil
+}
+
+// claimLockTakeover creates a parameter that marks the given version of the lock as taken over by the new lock.
+// It fails with ParameterAlreadyExists if someone else already claimed that version.
+func (s *Store) claimLockTakeover(appName, id string, version int64, lock *Lock) error {
+	data, err := marshal(lock)
+	if err != nil {
+		return fmt.Errorf("serialize data: %w", err)
+	}
+	_, err = s.ssm.PutParameter(&ssm.PutParameterInput{
+		Name:        aws.String(fmt.Sprintf(fmtLockTakeoverPath, fmt.Sprintf(fmtLockPath, appName, id), version)),
+		Description: aws.String(fmt.Sprintf("Copilot takeover claim for version %d of lock %s", version, id)),
+		Type:        aws.String(ssm.ParameterTypeString),
+		Value:       aws.String(data),
+		Overwrite:   aws.Bool(false),
+	})
+	return err
+}
+
+// getLock returns the lock and the version of the parameter that holds it.
+func (s *Store) getLock(appName, id string) (*Lock, int64, error) {
+	param, err := s.ssm.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(fmt.Sprintf(fmtLockPath, appName, id)),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("get lock %s in application %s: %w", id, appName, err)
+	}
+	var lock Lock
+	if err := json.Unmarshal([]byte(aws.StringValue(param.Parameter.Value)), &lock); err != nil {
+		return nil, 0, fmt.Errorf("read lock %s in application %s: %w", id, appName, err)
+	}
+	return &lock, aws.Int64Value(param.Parameter.Version), nil
+}
+
+// errLocked returns an *ErrLocked with the current holder of the lock.
+func (s *Store) errLocked(appName, id string) error {
+	existing, _, err := s.getLock(appName, id)
+	if err != nil {
+		return err
+	}
+	return &ErrLocked{Lock: existing}
+}
+
+// callerARN returns the ARN of the caller with a best effort. If it fails to fetch the ARN,
+// this returns "unknown".
+func (s *Store) callerARN() string {
+	caller, err := s.sts.Get()
+	if err != nil || caller.ARN == "" {
+		return unknownLockOwner
+	}
+	return caller.ARN
+}
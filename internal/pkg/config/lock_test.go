@@ -0,0 +1,226 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_AcquireLock(t *testing.T) {
+	held := Lock{
+		App:        "phonetool",
+		ID:         "environments/test",
+		Owner:      "arn:aws:iam::123456789012:user/bob",
+		AcquiredAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+		ExpiresAt:  time.Now().Add(time.Hour).UTC(),
+	}
+	heldString, err := marshal(held)
+	require.NoError(t, err)
+	expired := held
+	expired.ExpiresAt = time.Now().Add(-time.Minute).UTC()
+	expiredString, err := marshal(expired)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		mockPutParameter func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
+		mockGetParameter func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+
+		wantedErr error
+	}{
+		"creates the lock if nobody holds it": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/locks/environments/test", aws.StringValue(param.Name))
+				require.False(t, aws.BoolValue(param.Overwrite))
+				require.Contains(t, aws.StringValue(param.Value), `"owner":"arn:aws:iam::123456789012:user/alice"`)
+				return &ssm.PutParameterOutput{}, nil
+			},
+		},
+		"returns ErrLocked if someone else holds the lock": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				return nil, awserr.New(ssm.ErrCodeParameterAlreadyExists, "Already exists", fmt.Errorf("Already Exists"))
+			},
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{Value: aws.String(heldString)},
+				}, nil
+			},
+			wantedErr: errors.New("environments/test is locked by arn:aws:iam::123456789012:user/bob since 2020-05-01T10:00:00Z"),
+		},
+		"takes over an expired lock": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				switch aws.StringValue(param.Name) {
+				case "/copilot/applications/phonetool/locks/environments/test/takeovers/3":
+					require.False(t, aws.BoolValue(param.Overwrite))
+					return &ssm.PutParameterOutput{Version: aws.Int64(1)}, nil
+				case "/copilot/applications/phonetool/locks/environments/test":
+					if !aws.BoolValue(param.Overwrite) {
+						return nil, awserr.New(ssm.ErrCodeParameterAlreadyExists, "Already exists", fmt.Errorf("Already Exists"))
+					}
+					return &ssm.PutParameterOutput{Version: aws.Int64(4)}, nil
+				}
+				return nil, fmt.Errorf("unexpected parameter %s", aws.StringValue(param.Name))
+			},
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{Value: aws.String(expiredString), Version: aws.Int64(3)},
+				}, nil
+			},
+		},
+		"returns ErrLocked if someone else already claimed the takeover of the expired lock": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				require.False(t, aws.BoolValue(param.Overwrite))
+				return nil, awserr.New(ssm.ErrCodeParameterAlreadyExists, "Already exists", fmt.Errorf("Already Exists"))
+			},
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{Value: aws.String(expiredString), Version: aws.Int64(3)},
+				}, nil
+			},
+			wantedErr: errors.New("environments/test is locked by arn:aws:iam::123456789012:user/bob since 2020-05-01T10:00:00Z"),
+		},
+		"returns ErrLocked if the lock was written by someone else during the takeover": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				switch {
+				case strings.HasSuffix(aws.StringValue(param.Name), "/takeovers/3"):
+					return &ssm.PutParameterOutput{Version: aws.Int64(1)}, nil
+				case aws.BoolValue(param.Overwrite):
+					return &ssm.PutParameterOutput{Version: aws.Int64(5)}, nil
+				}
+				return nil, awserr.New(ssm.ErrCodeParameterAlreadyExists, "Already exists", fmt.Errorf("Already Exists"))
+			},
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{Value: aws.String(expiredString), Version: aws.Int64(3)},
+				}, nil
+			},
+			wantedErr: errors.New("environments/test is locked by arn:aws:iam::123456789012:user/bob since 2020-05-01T10:00:00Z"),
+		},
+		"wraps SSM errors": {
+			mockPutParameter: func(t *testing.T, param *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("acquire lock environments/test in application phonetool: some error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := &Store{
+				sts: mockIdentityService{
+					mockIdentityServiceGet: func() (identity.Caller, error) {
+						return identity.Caller{ARN: "arn:aws:iam::123456789012:user/alice"}, nil
+					},
+				},
+				ssm: &mockSSM{
+					t:                t,
+					mockPutParameter: tc.mockPutParameter,
+					mockGetParameter: tc.mockGetParameter,
+				},
+			}
+
+			lock, err := store.AcquireLock("phonetool", EnvironmentLockID("test"), time.Hour)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "arn:aws:iam::123456789012:user/alice", lock.Owner)
+				require.Equal(t, time.Hour, lock.ExpiresAt.Sub(lock.AcquiredAt))
+			}
+		})
+	}
+}
+
+func TestStore_ReleaseLock(t *testing.T) {
+	lock := Lock{
+		App:        "phonetool",
+		ID:         "workloads/test/frontend",
+		Owner:      "arn:aws:iam::123456789012:user/alice",
+		AcquiredAt: time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+		ExpiresAt:  time.Date(2020, 5, 1, 11, 0, 0, 0, time.UTC),
+	}
+	lockString, err := marshal(lock)
+	require.NoError(t, err)
+	takenOver := lock
+	takenOver.Owner = "arn:aws:iam::123456789012:user/bob"
+	takenOverString, err := marshal(takenOver)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		mockGetParameter        func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+		mockGetParametersByPath func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+		mockDeleteParameter     func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error)
+
+		wantedErr error
+	}{
+		"deletes the lock if it's still held by the same owner": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String(lockString)}}, nil
+			},
+			mockGetParametersByPath: func(t *testing.T, param *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+				require.Equal(t, "/copilot/applications/phonetool/locks/workloads/test/frontend/takeovers", aws.StringValue(param.Path))
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []*ssm.Parameter{
+						{Name: aws.String("/copilot/applications/phonetool/locks/workloads/test/frontend/takeovers/3")},
+					},
+				}, nil
+			},
+			mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+				require.Contains(t, []string{
+					"/copilot/applications/phonetool/locks/workloads/test/frontend",
+					"/copilot/applications/phonetool/locks/workloads/test/frontend/takeovers/3",
+				}, aws.StringValue(param.Name))
+				return &ssm.DeleteParameterOutput{}, nil
+			},
+		},
+		"leaves the lock alone if it was taken over": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String(takenOverString)}}, nil
+			},
+		},
+		"does nothing if the lock no longer exists": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return nil, awserr.New(ssm.ErrCodeParameterNotFound, "No Parameter", fmt.Errorf("No Parameter"))
+			},
+		},
+		"wraps SSM errors": {
+			mockGetParameter: func(t *testing.T, param *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String(lockString)}}, nil
+			},
+			mockDeleteParameter: func(t *testing.T, param *ssm.DeleteParameterInput) (*ssm.DeleteParameterOutput, error) {
+				return nil, errors.New("some error")
+			},
+			wantedErr: errors.New("release lock workloads/test/frontend in application phonetool: some error"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := &Store{
+				ssm: &mockSSM{
+					t:                       t,
+					mockGetParameter:        tc.mockGetParameter,
+					mockGetParametersByPath: tc.mockGetParametersByPath,
+					mockDeleteParameter:     tc.mockDeleteParameter,
+				},
+			}
+
+			err := store.ReleaseLock(&lock)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"log"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
 )
@@ -29,12 +30,16 @@ const schemaVersion = "1.0"
 
 // schema formats supported in current schemaVersion. NOTE: May change to map in the future.
 const (
-	rootApplicationPath = "/copilot/applications/"
-	fmtApplicationPath  = "/copilot/applications/%s"
-	rootEnvParamPath    = "/copilot/applications/%s/environments/"
-	fmtEnvParamPath     = "/copilot/applications/%s/environments/%s" // path for an environment in an application
-	rootWkldParamPath   = "/copilot/applications/%s/components/"
-	fmtWkldParamPath    = "/copilot/applications/%s/components/%s" // path for a workload in an application
+	rootApplicationPath    = "/copilot/applications/"
+	fmtApplicationPath     = "/copilot/applications/%s"
+	rootEnvParamPath       = "/copilot/applications/%s/environments/"
+	fmtEnvParamPath        = "/copilot/applications/%s/environments/%s" // path for an environment in an application
+	rootWkldParamPath      = "/copilot/applications/%s/components/"
+	fmtWkldParamPath       = "/copilot/applications/%s/components/%s" // path for a workload in an application
+	rootAppDeploymentsPath = "/copilot/applications/%s/deployments/"
+	rootDeploymentsPath    = "/copilot/applications/%s/deployments/%s/"
+	fmtDeploymentsPath     = "/copilot/applications/%s/deployments/%s/%s/"
+	fmtDeploymentPath      = "/copilot/applications/%s/deployments/%s/%s/%s" // path for a deployment of a workload to an environment
 )
 
 // IAMIdentityGetter is the interface to get information about the IAM user or role whose credentials are used to make AWS requests.
@@ -67,30 +72,71 @@ func NewSSMStore(sts IAMIdentityGetter, ssm SSM, appRegion string) *Store {
 }
 
 func (s *Store) listParams(path string) ([]*string, error) {
+	return s.listParamsByPath(path, false)
+}
+
+func (s *Store) listParamsByPath(path string, recursive bool) ([]*string, error) {
+	params, err := s.listParameters(path, recursive)
+	if err != nil {
+		return nil, err
+	}
 	var serializedParams []*string
+	for _, param := range params {
+		serializedParams = append(serializedParams, param.Value)
+	}
+	return serializedParams, nil
+}
+
+func (s *Store) listParameters(path string, recursive bool) ([]*ssm.Parameter, error) {
+	var params []*ssm.Parameter
 
 	var nextToken *string
 	for {
-		params, err := s.ssm.GetParametersByPath(&ssm.GetParametersByPathInput{
+		out, err := s.ssm.GetParametersByPath(&ssm.GetParametersByPathInput{
 			Path:      aws.String(path),
-			Recursive: aws.Bool(false),
+			Recursive: aws.Bool(recursive),
 			NextToken: nextToken,
 		})
 
 		if err != nil {
 			return nil, err
 		}
+		params = append(params, out.Parameters...)
 
-		for _, param := range params.Parameters {
-			serializedParams = append(serializedParams, param.Value)
-		}
-
-		nextToken = params.NextToken
+		nextToken = out.NextToken
 		if nextToken == nil {
 			break
 		}
 	}
-	return serializedParams, nil
+	return params, nil
+}
+
+// deleteParamsByPath deletes all the parameters under the path and its sub-paths.
+func (s *Store) deleteParamsByPath(path string) error {
+	params, err := s.listParameters(path, true)
+	if err != nil {
+		return err
+	}
+	for _, param := range params {
+		if err := s.deleteParam(aws.StringValue(param.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteParam deletes a parameter, and returns nil if the parameter doesn't exist.
+func (s *Store) deleteParam(name string) error {
+	_, err := s.ssm.DeleteParameter(&ssm.DeleteParameterInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ssm.ErrCodeParameterNotFound {
+			return nil
+		}
+		return err
+	}
+	return nil
 }
 
 // Retrieves the caller's Account ID with a best effort. If it fails to fetch the Account ID,
@@ -25,6 +25,10 @@ func (m *mockSSM) PutParameter(in *ssm.PutParameterInput) (*ssm.PutParameterOutp
 }
 
 func (m *mockSSM) GetParametersByPath(in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+	if m.mockGetParametersByPath == nil {
+		// The path has no parameters, e.g. a workload that has no deployment records.
+		return &ssm.GetParametersByPathOutput{}, nil
+	}
 	return m.mockGetParametersByPath(m.t, in)
 }
 
@@ -247,6 +247,9 @@ func (s *Store) DeleteJob(appName, jobName string) error {
 }
 
 func (s *Store) deleteWorkload(appName, wkldName string) error {
+	if err := s.DeleteDeployments(appName, wkldName); err != nil {
+		return err
+	}
 	paramName := fmt.Sprintf(fmtWkldParamPath, appName, wkldName)
 	_, err := s.ssm.DeleteParameter(&ssm.DeleteParameterInput{
 		Name: aws.String(paramName),
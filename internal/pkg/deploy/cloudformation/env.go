@@ -30,6 +30,9 @@ func (cf CloudFormation) DeployAndRenderEnvironment(out progress.FileWriter, env
 	if err != nil {
 		return err
 	}
+	if env.CFNServiceRoleARN != "" {
+		s.RoleARN = aws.String(env.CFNServiceRoleARN)
+	}
 	spinner := progress.NewSpinner(out)
 	return cf.renderStackChanges(&renderStackChangesInput{
 		w:                out,
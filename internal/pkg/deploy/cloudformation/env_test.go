@@ -80,6 +80,14 @@ func TestCloudFormation_UpgradeEnvironment(t *testing.T) {
 							ParameterKey:   aws.String("NATWorkloads"),
 							ParameterValue: aws.String(""),
 						},
+						{
+							ParameterKey:   aws.String("CDNWorkloads"),
+							ParameterValue: aws.String(""),
+						},
+						{
+							ParameterKey:   aws.String("InternalALBWorkloads"),
+							ParameterValue: aws.String(""),
+						},
 						{
 							ParameterKey:   aws.String("EFSWorkloads"),
 							ParameterValue: aws.String(""),
@@ -252,6 +260,14 @@ func TestCloudFormation_UpgradeLegacyEnvironment(t *testing.T) {
 							ParameterKey:   aws.String("NATWorkloads"),
 							ParameterValue: aws.String(""),
 						},
+						{
+							ParameterKey:   aws.String("CDNWorkloads"),
+							ParameterValue: aws.String(""),
+						},
+						{
+							ParameterKey:   aws.String("InternalALBWorkloads"),
+							ParameterValue: aws.String(""),
+						},
 						{
 							ParameterKey:   aws.String("EFSWorkloads"),
 							ParameterValue: aws.String(""),
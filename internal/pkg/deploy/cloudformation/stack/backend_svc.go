@@ -19,6 +19,10 @@ import (
 // Parameter logical IDs for a backend service.
 const (
 	BackendServiceContainerPortParamKey = "ContainerPort"
+	BackendServiceRulePathParamKey      = "RulePath"
+	BackendServiceTargetContainerKey    = "TargetContainer"
+	BackendServiceTargetPortKey         = "TargetPort"
+	BackendServiceStickinessKey         = "Stickiness"
 )
 
 const (
@@ -34,19 +38,31 @@ type backendSvcReadParser interface {
 // BackendService represents the configuration needed to create a CloudFormation stack from a backend service manifest.
 type BackendService struct {
 	*ecsWkld
-	manifest *manifest.BackendService
+	manifest     *manifest.BackendService
+	httpsEnabled bool
 
 	parser backendSvcReadParser
 }
 
+// BackendServiceOption is used to configuring an optional field for BackendService.
+type BackendServiceOption func(s *BackendService)
+
+// WithInternalHTTPS routes traffic from the internal load balancer's HTTPS listener to a BackendService.
+func WithInternalHTTPS() func(s *BackendService) {
+	return func(s *BackendService) {
+		s.httpsEnabled = true
+	}
+}
+
 // NewBackendService creates a new BackendService stack from a manifest file.
-func NewBackendService(mft *manifest.BackendService, env, app string, rc RuntimeConfig) (*BackendService, error) {
+func NewBackendService(mft *manifest.BackendService, env, app string, rc RuntimeConfig,
+	opts ...BackendServiceOption) (*BackendService, error) {
 	parser := template.New()
 	addons, err := addon.New(aws.StringValue(mft.Name))
 	if err != nil {
 		return nil, fmt.Errorf("new addons: %w", err)
 	}
-	return &BackendService{
+	s := &BackendService{
 		ecsWkld: &ecsWkld{
 			wkld: &wkld{
 				name:   aws.StringValue(mft.Name),
@@ -64,7 +80,11 @@ func NewBackendService(mft *manifest.BackendService, env, app string, rc Runtime
 		manifest: mft,
 
 		parser: parser,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // Template returns the CloudFormation template for the backend service.
@@ -85,10 +105,14 @@ func (s *BackendService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	sidecars, err := convertSidecar(s.manifest.Sidecars)
+	sidecars, err := convertSidecar(s.manifest.Sidecars, s.manifest.InitContainers)
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for service %s: %w", s.name, err)
 	}
+	initContainers, err := convertInitContainers(s.manifest.InitContainers)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for service %s: %w", s.name, err)
+	}
 	publishers, err := convertPublish(s.manifest.Publish(), s.rc.AccountID, s.rc.Region, s.app, s.env, s.name)
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
@@ -117,12 +141,52 @@ func (s *BackendService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	preDeploy, err := convertPreDeploy(s.manifest.DeployConfig.PreDeploy)
+	if err != nil {
+		return "", fmt.Errorf(`convert "deployment.pre_deploy" for service %s: %w`, s.name, err)
+	}
+	var preDeployLambda string
+	if preDeploy != nil {
+		content, err := s.parser.Read(preDeployTaskPath)
+		if err != nil {
+			return "", fmt.Errorf("read pre-deploy task lambda: %w", err)
+		}
+		preDeployLambda = content.String()
+	}
+	var rulePriorityLambda string
+	var aliases, allowedSourceIPs []string
+	var deregistrationDelay *int64
+	var httpHealthCheck template.HTTPHealthCheckOpts
+	if s.manifest.InternalALBEnabled() {
+		content, err := s.parser.Read(lbWebSvcRulePriorityGeneratorPath)
+		if err != nil {
+			return "", fmt.Errorf("read rule priority lambda: %w", err)
+		}
+		rulePriorityLambda = content.String()
+		if aliases, err = convertAlias(s.manifest.RoutingRule.Alias); err != nil {
+			return "", err
+		}
+		deregistrationDelay = aws.Int64(60)
+		if s.manifest.RoutingRule.DeregistrationDelay != nil {
+			deregistrationDelay = aws.Int64(int64(s.manifest.RoutingRule.DeregistrationDelay.Seconds()))
+		}
+		for _, ipNet := range s.manifest.RoutingRule.AllowedSourceIps {
+			allowedSourceIPs = append(allowedSourceIPs, string(ipNet))
+		}
+		httpHealthCheck = convertHTTPHealthCheck(&s.manifest.RoutingRule.HealthCheck)
+	}
+	network := convertNetworkConfig(s.manifest.Network)
+	network.Ingress = convertServiceIngress(s.manifest.Network.VPC.Ingress, s.manifest.BackendServiceConfig.ImageConfig.Port)
+	network.IngressSource = s.rc.IngressSource
 	content, err := s.parser.ParseBackendService(template.WorkloadOpts{
 		Variables:                s.manifest.BackendServiceConfig.Variables,
 		Secrets:                  convertSecrets(s.manifest.BackendServiceConfig.Secrets),
 		NestedStack:              addonsOutputs,
 		AddonsExtraParams:        addonsParams,
+		EnvAddons:                convertEnvAddonsImports(s.manifest.EnvAddons),
+		Permissions:              convertPermissions(s.manifest.Permissions),
 		Sidecars:                 sidecars,
+		InitContainers:           initContainers,
 		Autoscaling:              autoscaling,
 		CapacityProviders:        capacityProviders,
 		DesiredCountOnSpot:       desiredCountOnSpot,
@@ -131,18 +195,31 @@ func (s *BackendService) Template() (string, error) {
 		HealthCheck:              convertContainerHealthCheck(s.manifest.BackendServiceConfig.ImageConfig.HealthCheck),
 		LogConfig:                convertLogging(s.manifest.Logging),
 		DockerLabels:             s.manifest.ImageConfig.Image.DockerLabels,
+		RulePriorityLambda:       rulePriorityLambda,
 		DesiredCountLambda:       desiredCountLambda.String(),
 		EnvControllerLambda:      envControllerLambda.String(),
 		Storage:                  convertStorageOpts(s.manifest.Name, s.manifest.Storage),
-		Network:                  convertNetworkConfig(s.manifest.Network),
+		Network:                  network,
 		DeploymentConfiguration:  convertDeploymentConfig(s.manifest.DeployConfig),
+		PreDeploy:                preDeploy,
+		PreDeployLambda:          preDeployLambda,
 		EntryPoint:               entrypoint,
 		Command:                  command,
-		DependsOn:                convertDependsOn(s.manifest.ImageConfig.Image.DependsOn),
+		DependsOn:                convertDependsOnWithInitContainers(s.manifest.ImageConfig.Image.DependsOn, s.manifest.InitContainers),
 		CredentialsParameter:     aws.StringValue(s.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint: s.rc.ServiceDiscoveryEndpoint,
+		ServiceConnect:           convertServiceConnect(s.manifest.Network.Connect, s.name, s.manifest.BackendServiceConfig.ImageConfig.Port),
 		Publish:                  publishers,
 		Platform:                 convertPlatform(s.manifest.Platform),
+		EC2:                      convertEC2(s.manifest.Platform),
+		ALBEnabled:               s.manifest.InternalALBEnabled(),
+		HTTPSListener:            s.manifest.InternalALBEnabled() && s.httpsEnabled,
+		Aliases:                  aliases,
+		HostedZoneID:             aws.StringValue(s.manifest.RoutingRule.HostedZone),
+		HTTPHealthCheck:          httpHealthCheck,
+		HTTPVersion:              convertHTTPVersion(s.manifest.RoutingRule.ProtocolVersion),
+		DeregistrationDelay:      deregistrationDelay,
+		AllowedSourceIps:         allowedSourceIPs,
 		Observability: template.ObservabilityOpts{
 			Tracing: strings.ToUpper(aws.StringValue(s.manifest.Observability.Tracing)),
 		},
@@ -167,7 +244,7 @@ func (s *BackendService) Parameters() ([]*cloudformation.Parameter, error) {
 	if s.manifest.BackendServiceConfig.ImageConfig.Port != nil {
 		containerPort = strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.BackendServiceConfig.ImageConfig.Port)), 10)
 	}
-	return append(svcParams, []*cloudformation.Parameter{
+	svcParams = append(svcParams, []*cloudformation.Parameter{
 		{
 			ParameterKey:   aws.String(BackendServiceContainerPortParamKey),
 			ParameterValue: aws.String(containerPort),
@@ -176,9 +253,47 @@ func (s *BackendService) Parameters() ([]*cloudformation.Parameter, error) {
 			ParameterKey:   aws.String(WorkloadEnvFileARNParamKey),
 			ParameterValue: aws.String(s.rc.EnvFileARN),
 		},
+	}...)
+	if !s.manifest.InternalALBEnabled() {
+		return svcParams, nil
+	}
+	targetContainer, targetPort := s.httpLoadBalancerTarget()
+	return append(svcParams, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(BackendServiceTargetContainerKey),
+			ParameterValue: targetContainer,
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceTargetPortKey),
+			ParameterValue: targetPort,
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceRulePathParamKey),
+			ParameterValue: s.manifest.RoutingRule.Path,
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceStickinessKey),
+			ParameterValue: aws.String(strconv.FormatBool(aws.BoolValue(s.manifest.RoutingRule.Stickiness))),
+		},
 	}...), nil
 }
 
+func (s *BackendService) httpLoadBalancerTarget() (targetContainer *string, targetPort *string) {
+	// Route load balancer traffic to main container by default.
+	targetContainer = aws.String(s.name)
+	targetPort = aws.String(strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.ImageConfig.Port)), 10))
+	if s.manifest.RoutingRule.TargetContainer != nil {
+		targetContainer = s.manifest.RoutingRule.TargetContainer
+	}
+	if s.manifest.RoutingRule.TargetContainerCamelCase != nil {
+		targetContainer = s.manifest.RoutingRule.TargetContainerCamelCase
+	}
+	if aws.StringValue(targetContainer) != s.name {
+		targetPort = s.manifest.Sidecars[aws.StringValue(targetContainer)].Port
+	}
+	return
+}
+
 // SerializedParameters returns the CloudFormation stack's parameters serialized
 // to a YAML document annotated with comments for readability to users.
 func (s *BackendService) SerializedParameters() (string, error) {
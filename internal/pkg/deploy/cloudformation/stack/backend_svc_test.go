@@ -112,6 +112,34 @@ Outputs:
 			},
 			wantedErr: fmt.Errorf("convert the sidecar configuration for service frontend: %w", errors.New("cannot parse port mapping from 80/80/80")),
 		},
+		"unavailable pre-deploy task lambda template": {
+			setUpManifest: func(svc *BackendService) {
+				mft := manifest.NewBackendService(baseProps)
+				mft.DeployConfig.PreDeploy = manifest.PreDeployTask{
+					Command: manifest.CommandOverride{
+						String: aws.String("./migrate up"),
+					},
+				}
+				svc.manifest = mft
+			},
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *BackendService) {
+				m := mocks.NewMockbackendSvcReadParser(ctrl)
+				m.EXPECT().Read(desiredCountGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("something")}, nil)
+				m.EXPECT().Read(envControllerPath).Return(&template.Content{Buffer: bytes.NewBufferString("something")}, nil)
+				m.EXPECT().Read(preDeployTaskPath).Return(nil, errors.New("some error"))
+				svc.parser = m
+				svc.addons = mockAddons{
+					tpl: `
+Resources:
+  AdditionalResourcesPolicy:
+    Type: AWS::IAM::ManagedPolicy
+Outputs:
+  AdditionalResourcesPolicyArn:
+    Value: hello`,
+				}
+			},
+			wantedErr: fmt.Errorf("read pre-deploy task lambda: some error"),
+		},
 		"failed parsing Auto Scaling template": {
 			setUpManifest: func(svc *BackendService) {
 				testBackendSvcManifestWithBadAutoScaling := manifest.NewBackendService(baseProps)
@@ -366,3 +394,58 @@ func TestBackendService_Parameters(t *testing.T) {
 		},
 	}, params)
 }
+
+func TestBackendService_ParametersWithInternalALB(t *testing.T) {
+	testBackendSvcManifest := manifest.NewBackendService(manifest.BackendServiceProps{
+		WorkloadProps: manifest.WorkloadProps{
+			Name:       testServiceName,
+			Dockerfile: testDockerfile,
+		},
+		Port: 8080,
+	})
+	testBackendSvcManifest.RoutingRule = manifest.RoutingRuleConfigOrBool{
+		RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+			Path:       aws.String("api"),
+			Stickiness: aws.Bool(true),
+		},
+	}
+
+	conf := &BackendService{
+		ecsWkld: &ecsWkld{
+			wkld: &wkld{
+				name: aws.StringValue(testBackendSvcManifest.Name),
+				env:  testEnvName,
+				app:  testAppName,
+				image: manifest.Image{
+					Location: aws.String("mockLocation"),
+				},
+			},
+			tc: testBackendSvcManifest.BackendServiceConfig.TaskConfig,
+		},
+		manifest: testBackendSvcManifest,
+	}
+
+	// WHEN
+	params, err := conf.Parameters()
+
+	// THEN
+	require.NoError(t, err)
+	require.Subset(t, params, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(BackendServiceTargetContainerKey),
+			ParameterValue: aws.String("frontend"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceTargetPortKey),
+			ParameterValue: aws.String("8080"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceRulePathParamKey),
+			ParameterValue: aws.String("api"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceStickinessKey),
+			ParameterValue: aws.String("true"),
+		},
+	})
+}
@@ -38,8 +38,10 @@ const (
 	envParamAppDNSDelegationRoleKey  = "AppDNSDelegationRole"
 	EnvParamAliasesKey               = "Aliases"
 	EnvParamALBWorkloadsKey          = "ALBWorkloads"
+	envParamInternalALBWorkloadsKey  = "InternalALBWorkloads"
 	envParamEFSWorkloadsKey          = "EFSWorkloads"
 	envParamNATWorkloadsKey          = "NATWorkloads"
+	EnvParamCDNWorkloadsKey          = "CDNWorkloads"
 	envParamCreateHTTPSListenerKey   = "CreateHTTPSListener"
 	EnvParamServiceDiscoveryEndpoint = "ServiceDiscoveryEndpoint"
 
@@ -93,9 +95,13 @@ func (e *EnvStackConfig) Template() (string, error) {
 		ArtifactBucketARN:      e.in.ArtifactBucketARN,
 		ArtifactBucketKeyARN:   e.in.ArtifactBucketKeyARN,
 
-		ImportCertARNs: e.in.ImportCertARNs,
-		VPCConfig:      e.vpcConfig(),
-		Telemetry:      e.telemetryConfig(),
+		ImportCertARNs:   e.in.ImportCertARNs,
+		InternalCertARNs: e.in.InternalCertARNs,
+		WAF:              e.wafConfig(),
+		EC2Capacity:      e.ec2CapacityConfig(),
+		VPCConfig:        e.vpcConfig(),
+		Telemetry:        e.telemetryConfig(),
+		Addons:           e.addonsConfig(),
 
 		Version:       e.in.Version,
 		LatestVersion: deploy.LatestEnvTemplateVersion,
@@ -151,6 +157,41 @@ func (e *EnvStackConfig) telemetryConfig() *template.Telemetry {
 	}
 }
 
+func (e *EnvStackConfig) wafConfig() *template.WAFOpts {
+	if e.in.WAF == nil {
+		return nil
+	}
+	return &template.WAFOpts{
+		WebACLARN:    e.in.WAF.WebACLARN,
+		ManagedRules: e.in.WAF.ManagedRules,
+		RateLimit:    e.in.WAF.RateLimit,
+	}
+}
+
+func (e *EnvStackConfig) ec2CapacityConfig() *template.EC2CapacityOpts {
+	if e.in.EC2Capacity == nil {
+		return nil
+	}
+	return &template.EC2CapacityOpts{
+		InstanceTypes:  e.in.EC2Capacity.InstanceTypes,
+		Arch:           e.in.EC2Capacity.Arch,
+		MinSize:        e.in.EC2Capacity.MinSize,
+		MaxSize:        e.in.EC2Capacity.MaxSize,
+		SpotPercentage: e.in.EC2Capacity.SpotPercentage,
+	}
+}
+
+func (e *EnvStackConfig) addonsConfig() *template.EnvAddonsOpts {
+	if e.in.Addons == nil || e.in.Addons.URL == "" {
+		return nil
+	}
+	return &template.EnvAddonsOpts{
+		URL:         e.in.Addons.URL,
+		ExtraParams: e.in.Addons.ExtraParams,
+		Outputs:     e.in.Addons.Outputs,
+	}
+}
+
 // Parameters returns the parameters to be passed into a environment CloudFormation template.
 func (e *EnvStackConfig) Parameters() ([]*cloudformation.Parameter, error) {
 	httpsListener := "false"
@@ -194,6 +235,10 @@ func (e *EnvStackConfig) Parameters() ([]*cloudformation.Parameter, error) {
 			ParameterKey:   aws.String(EnvParamALBWorkloadsKey),
 			ParameterValue: aws.String(""),
 		},
+		{
+			ParameterKey:   aws.String(envParamInternalALBWorkloadsKey),
+			ParameterValue: aws.String(""),
+		},
 		{
 			ParameterKey:   aws.String(envParamEFSWorkloadsKey),
 			ParameterValue: aws.String(""),
@@ -202,6 +247,10 @@ func (e *EnvStackConfig) Parameters() ([]*cloudformation.Parameter, error) {
 			ParameterKey:   aws.String(envParamNATWorkloadsKey),
 			ParameterValue: aws.String(""),
 		},
+		{
+			ParameterKey:   aws.String(EnvParamCDNWorkloadsKey),
+			ParameterValue: aws.String(""),
+		},
 	}, nil
 }
 
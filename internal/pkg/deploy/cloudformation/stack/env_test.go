@@ -48,6 +48,132 @@ func TestEnv_Template(t *testing.T) {
 			},
 			expectedOutput: mockTemplate,
 		},
+		"should render environment addons when present": {
+			mockDependencies: func(ctrl *gomock.Controller, e *EnvStackConfig) {
+				e.in.Addons = &deploy.EnvAddons{
+					URL:         "https://mockbucket.s3-us-west-2.amazonaws.com/addons.yml",
+					ExtraParams: "DiscoveryServiceArn: !GetAtt DiscoveryService.Arn",
+					Outputs:     []string{"UsersTableName", "UsersAccessPolicy"},
+				}
+				m := mocks.NewMockenvReadParser(ctrl)
+				m.EXPECT().ParseEnv(&template.EnvOpts{
+					AppName:                "project",
+					ScriptBucketName:       "mockbucket",
+					DNSCertValidatorLambda: "mockkey1",
+					DNSDelegationLambda:    "mockkey2",
+					CustomDomainLambda:     "mockkey4",
+					VPCConfig: template.VPCConfig{
+						Imported: nil,
+						Managed: template.ManagedVPC{
+							CIDR:               DefaultVPCCIDR,
+							PrivateSubnetCIDRs: strings.Split(DefaultPrivateSubnetCIDRs, ","),
+							PublicSubnetCIDRs:  strings.Split(DefaultPublicSubnetCIDRs, ","),
+						},
+					},
+					Addons: &template.EnvAddonsOpts{
+						URL:         "https://mockbucket.s3-us-west-2.amazonaws.com/addons.yml",
+						ExtraParams: "DiscoveryServiceArn: !GetAtt DiscoveryService.Arn",
+						Outputs:     []string{"UsersTableName", "UsersAccessPolicy"},
+					},
+					LatestVersion: deploy.LatestEnvTemplateVersion,
+				}, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("mockTemplate")}, nil)
+				e.parser = m
+			},
+			expectedOutput: mockTemplate,
+		},
+		"should render the internal load balancer certificates when imported": {
+			mockDependencies: func(ctrl *gomock.Controller, e *EnvStackConfig) {
+				e.in.InternalCertARNs = []string{"mockInternalCertARN"}
+				m := mocks.NewMockenvReadParser(ctrl)
+				m.EXPECT().ParseEnv(&template.EnvOpts{
+					AppName:                "project",
+					ScriptBucketName:       "mockbucket",
+					DNSCertValidatorLambda: "mockkey1",
+					DNSDelegationLambda:    "mockkey2",
+					CustomDomainLambda:     "mockkey4",
+					InternalCertARNs:       []string{"mockInternalCertARN"},
+					VPCConfig: template.VPCConfig{
+						Imported: nil,
+						Managed: template.ManagedVPC{
+							CIDR:               DefaultVPCCIDR,
+							PrivateSubnetCIDRs: strings.Split(DefaultPrivateSubnetCIDRs, ","),
+							PublicSubnetCIDRs:  strings.Split(DefaultPublicSubnetCIDRs, ","),
+						},
+					},
+					LatestVersion: deploy.LatestEnvTemplateVersion,
+				}, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("mockTemplate")}, nil)
+				e.parser = m
+			},
+			expectedOutput: mockTemplate,
+		},
+		"should render the web ACL of the public load balancer": {
+			mockDependencies: func(ctrl *gomock.Controller, e *EnvStackConfig) {
+				e.in.WAF = &config.WAF{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+					RateLimit:    2000,
+				}
+				m := mocks.NewMockenvReadParser(ctrl)
+				m.EXPECT().ParseEnv(&template.EnvOpts{
+					AppName:                "project",
+					ScriptBucketName:       "mockbucket",
+					DNSCertValidatorLambda: "mockkey1",
+					DNSDelegationLambda:    "mockkey2",
+					CustomDomainLambda:     "mockkey4",
+					WAF: &template.WAFOpts{
+						ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+						RateLimit:    2000,
+					},
+					VPCConfig: template.VPCConfig{
+						Imported: nil,
+						Managed: template.ManagedVPC{
+							CIDR:               DefaultVPCCIDR,
+							PrivateSubnetCIDRs: strings.Split(DefaultPrivateSubnetCIDRs, ","),
+							PublicSubnetCIDRs:  strings.Split(DefaultPublicSubnetCIDRs, ","),
+						},
+					},
+					LatestVersion: deploy.LatestEnvTemplateVersion,
+				}, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("mockTemplate")}, nil)
+				e.parser = m
+			},
+			expectedOutput: mockTemplate,
+		},
+		"should render the EC2 capacity of the cluster": {
+			mockDependencies: func(ctrl *gomock.Controller, e *EnvStackConfig) {
+				e.in.EC2Capacity = &config.EC2Capacity{
+					InstanceTypes:  []string{"m6g.large", "c6g.large"},
+					Arch:           "arm64",
+					MinSize:        1,
+					MaxSize:        10,
+					SpotPercentage: 50,
+				}
+				m := mocks.NewMockenvReadParser(ctrl)
+				m.EXPECT().ParseEnv(&template.EnvOpts{
+					AppName:                "project",
+					ScriptBucketName:       "mockbucket",
+					DNSCertValidatorLambda: "mockkey1",
+					DNSDelegationLambda:    "mockkey2",
+					CustomDomainLambda:     "mockkey4",
+					EC2Capacity: &template.EC2CapacityOpts{
+						InstanceTypes:  []string{"m6g.large", "c6g.large"},
+						Arch:           "arm64",
+						MinSize:        1,
+						MaxSize:        10,
+						SpotPercentage: 50,
+					},
+					VPCConfig: template.VPCConfig{
+						Imported: nil,
+						Managed: template.ManagedVPC{
+							CIDR:               DefaultVPCCIDR,
+							PrivateSubnetCIDRs: strings.Split(DefaultPrivateSubnetCIDRs, ","),
+							PublicSubnetCIDRs:  strings.Split(DefaultPublicSubnetCIDRs, ","),
+						},
+					},
+					LatestVersion: deploy.LatestEnvTemplateVersion,
+				}, gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("mockTemplate")}, nil)
+				e.parser = m
+			},
+			expectedOutput: mockTemplate,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -113,6 +239,10 @@ func TestEnv_Parameters(t *testing.T) {
 					ParameterKey:   aws.String(EnvParamALBWorkloadsKey),
 					ParameterValue: aws.String(""),
 				},
+				{
+					ParameterKey:   aws.String(envParamInternalALBWorkloadsKey),
+					ParameterValue: aws.String(""),
+				},
 				{
 					ParameterKey:   aws.String(envParamEFSWorkloadsKey),
 					ParameterValue: aws.String(""),
@@ -121,6 +251,10 @@ func TestEnv_Parameters(t *testing.T) {
 					ParameterKey:   aws.String(envParamNATWorkloadsKey),
 					ParameterValue: aws.String(""),
 				},
+				{
+					ParameterKey:   aws.String(EnvParamCDNWorkloadsKey),
+					ParameterValue: aws.String(""),
+				},
 				{
 					ParameterKey:   aws.String(EnvParamServiceDiscoveryEndpoint),
 					ParameterValue: aws.String("env.project.local"),
@@ -170,6 +304,10 @@ func TestEnv_Parameters(t *testing.T) {
 					ParameterKey:   aws.String(EnvParamALBWorkloadsKey),
 					ParameterValue: aws.String(""),
 				},
+				{
+					ParameterKey:   aws.String(envParamInternalALBWorkloadsKey),
+					ParameterValue: aws.String(""),
+				},
 				{
 					ParameterKey:   aws.String(envParamEFSWorkloadsKey),
 					ParameterValue: aws.String(""),
@@ -178,6 +316,10 @@ func TestEnv_Parameters(t *testing.T) {
 					ParameterKey:   aws.String(envParamNATWorkloadsKey),
 					ParameterValue: aws.String(""),
 				},
+				{
+					ParameterKey:   aws.String(EnvParamCDNWorkloadsKey),
+					ParameterValue: aws.String(""),
+				},
 			},
 		},
 	}
@@ -5,6 +5,7 @@ package stack
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -25,8 +26,14 @@ const (
 	envControllerPath                 = "custom-resources/env-controller.js"
 	nlbCertValidatorPath              = "custom-resources/nlb-cert-validator.js"
 	nlbCustomDomainPath               = "custom-resources/nlb-custom-domain.js"
+	dnsCertValidatorPath              = "custom-resources/dns-cert-validator.js"
+	preDeployTaskPath                 = "custom-resources/pre-deploy-task.js"
 )
 
+// defaultCDNCachePolicy is the managed cache policy of a CloudFront distribution if none is specified.
+// Caching is disabled by default since the responses of a web service are usually dynamic.
+const defaultCDNCachePolicy = "CachingDisabled"
+
 // Parameter logical IDs for a load balanced web service.
 const (
 	LBWebServiceHTTPSParamKey           = "HTTPSEnabled"
@@ -53,6 +60,7 @@ type LoadBalancedWebService struct {
 	certImported           bool
 	dnsDelegationEnabled   bool
 	publicSubnetCIDRBlocks []string
+	cdnPrefixListID        string
 	appInfo                deploy.AppInformation
 
 	parser loadBalancedWebSvcReadParser
@@ -68,6 +76,14 @@ func WithNLB(cidrBlocks []string) func(s *LoadBalancedWebService) {
 	}
 }
 
+// WithCDN sets the ID of the CloudFront managed prefix list that is allowed to reach the public load balancer
+// when a CloudFront distribution is placed in front of a LoadBalancedWebService.
+func WithCDN(prefixListID string) func(s *LoadBalancedWebService) {
+	return func(s *LoadBalancedWebService) {
+		s.cdnPrefixListID = prefixListID
+	}
+}
+
 // LoadBalancedWebServiceConfig contains fields to configure LoadBalancedWebService.
 type LoadBalancedWebServiceConfig struct {
 	App           *config.Application
@@ -155,10 +171,14 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	sidecars, err := convertSidecar(s.manifest.Sidecars)
+	sidecars, err := convertSidecar(s.manifest.Sidecars, s.manifest.InitContainers)
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for service %s: %w", s.name, err)
 	}
+	initContainers, err := convertInitContainers(s.manifest.InitContainers)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for service %s: %w", s.name, err)
+	}
 	publishers, err := convertPublish(s.manifest.Publish(), s.rc.AccountID, s.rc.Region, s.app, s.env, s.name)
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
@@ -187,6 +207,18 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	preDeploy, err := convertPreDeploy(s.manifest.DeployConfig.PreDeploy)
+	if err != nil {
+		return "", fmt.Errorf(`convert "deployment.pre_deploy" for service %s: %w`, s.name, err)
+	}
+	var preDeployLambda string
+	if preDeploy != nil {
+		content, err := s.parser.Read(preDeployTaskPath)
+		if err != nil {
+			return "", fmt.Errorf("read pre-deploy task lambda: %w", err)
+		}
+		preDeployLambda = content.String()
+	}
 
 	var aliases []string
 	if s.httpsEnabled {
@@ -205,10 +237,21 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 		allowedSourceIPs = append(allowedSourceIPs, string(ipNet))
 	}
 
+	listenerRules, targetGroups := s.convertListenerRules()
 	nlbConfig, err := s.convertNetworkLoadBalancer()
 	if err != nil {
 		return "", err
 	}
+	cdnConfig, err := s.convertCDN()
+	if err != nil {
+		return "", err
+	}
+	appDNSDelegationRole, appDNSName := nlbConfig.appDNSDelegationRole, nlbConfig.appDNSName
+	if cdnConfig.certValidatorLambda != "" {
+		appDNSDelegationRole, appDNSName = convertAppInformation(s.appInfo)
+	}
+	network := convertNetworkConfig(s.manifest.Network)
+	network.IngressSource = s.rc.IngressSource
 	content, err := s.parser.ParseLoadBalancedWebService(template.WorkloadOpts{
 		Variables:                      s.manifest.TaskConfig.Variables,
 		Secrets:                        convertSecrets(s.manifest.TaskConfig.Secrets),
@@ -217,7 +260,10 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 		UseImportedCerts:               s.certImported,
 		NestedStack:                    addonsOutputs,
 		AddonsExtraParams:              addonsParams,
+		EnvAddons:                      convertEnvAddonsImports(s.manifest.EnvAddons),
+		Permissions:                    convertPermissions(s.manifest.Permissions),
 		Sidecars:                       sidecars,
+		InitContainers:                 initContainers,
 		LogConfig:                      convertLogging(s.manifest.Logging),
 		DockerLabels:                   s.manifest.ImageConfig.Image.DockerLabels,
 		Autoscaling:                    autoscaling,
@@ -229,25 +275,34 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 		HTTPHealthCheck:                convertHTTPHealthCheck(&s.manifest.RoutingRule.HealthCheck),
 		DeregistrationDelay:            deregistrationDelay,
 		AllowedSourceIps:               allowedSourceIPs,
+		ALBListenerRules:               listenerRules,
+		ALBTargetGroups:                targetGroups,
+		WAF:                            convertWAF(s.manifest.RoutingRule.WAF),
+		CDN:                            cdnConfig.settings,
 		RulePriorityLambda:             rulePriorityLambda.String(),
 		DesiredCountLambda:             desiredCountLambda.String(),
 		EnvControllerLambda:            envControllerLambda.String(),
 		Storage:                        convertStorageOpts(s.manifest.Name, s.manifest.Storage),
-		Network:                        convertNetworkConfig(s.manifest.Network),
+		Network:                        network,
 		EntryPoint:                     entrypoint,
 		Command:                        command,
-		DependsOn:                      convertDependsOn(s.manifest.ImageConfig.Image.DependsOn),
+		DependsOn:                      convertDependsOnWithInitContainers(s.manifest.ImageConfig.Image.DependsOn, s.manifest.InitContainers),
 		CredentialsParameter:           aws.StringValue(s.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint:       s.rc.ServiceDiscoveryEndpoint,
+		ServiceConnect:                 convertServiceConnect(s.manifest.Network.Connect, s.name, s.manifest.ImageConfig.Port),
 		Publish:                        publishers,
 		Platform:                       convertPlatform(s.manifest.Platform),
+		EC2:                            convertEC2(s.manifest.Platform),
 		HTTPVersion:                    convertHTTPVersion(s.manifest.RoutingRule.ProtocolVersion),
 		NLB:                            nlbConfig.settings,
 		DeploymentConfiguration:        convertDeploymentConfig(s.manifest.DeployConfig),
-		AppDNSName:                     nlbConfig.appDNSName,
-		AppDNSDelegationRole:           nlbConfig.appDNSDelegationRole,
+		PreDeploy:                      preDeploy,
+		PreDeployLambda:                preDeployLambda,
+		AppDNSName:                     appDNSName,
+		AppDNSDelegationRole:           appDNSDelegationRole,
 		NLBCertValidatorFunctionLambda: nlbConfig.certValidatorLambda,
 		NLBCustomDomainFunctionLambda:  nlbConfig.customDomainLambda,
+		CDNCertValidatorLambda:         cdnConfig.certValidatorLambda,
 		ALBEnabled:                     !s.manifest.RoutingRule.Disabled(),
 		Observability: template.ObservabilityOpts{
 			Tracing: strings.ToUpper(aws.StringValue(s.manifest.Observability.Tracing)),
@@ -281,6 +336,93 @@ func (s *LoadBalancedWebService) httpLoadBalancerTarget() (targetContainer *stri
 	return
 }
 
+// convertListenerRules converts the additional routing rules of the manifest to listener rules,
+// along with the target groups of containers other than the target container that receive traffic from them.
+func (s *LoadBalancedWebService) convertListenerRules() ([]template.ALBListenerRule, []template.ALBTargetGroup) {
+	if s.manifest.RoutingRule.Disabled() || len(s.manifest.RoutingRule.AdditionalRules) == 0 {
+		return nil, nil
+	}
+	targetContainer, _ := s.httpLoadBalancerTarget()
+	var rules []template.ALBListenerRule
+	var targetGroups []template.ALBTargetGroup
+	added := make(map[string]bool)
+	for _, mftRule := range s.manifest.RoutingRule.AdditionalRules {
+		rule := template.ALBListenerRule{
+			Priority: aws.IntValue(mftRule.Priority),
+			Paths:    mftRule.PathPatterns(),
+			Hosts:    mftRule.Hosts,
+		}
+		for name, values := range mftRule.Headers {
+			rule.Headers = append(rule.Headers, template.ALBHeaderCondition{
+				Name:   name,
+				Values: values,
+			})
+		}
+		sort.Slice(rule.Headers, func(i, j int) bool { return rule.Headers[i].Name < rule.Headers[j].Name })
+		for key, value := range mftRule.QueryStrings {
+			rule.QueryStrings = append(rule.QueryStrings, template.ALBQueryStringCondition{
+				Key:   key,
+				Value: value,
+			})
+		}
+		sort.Slice(rule.QueryStrings, func(i, j int) bool { return rule.QueryStrings[i].Key < rule.QueryStrings[j].Key })
+		for _, ipNet := range mftRule.AllowedSourceIps {
+			rule.AllowedSourceIps = append(rule.AllowedSourceIps, string(ipNet))
+		}
+		switch {
+		case !mftRule.Redirect.IsEmpty():
+			rule.Redirect = convertRedirectAction(mftRule.Redirect)
+		case !mftRule.FixedResponse.IsEmpty():
+			rule.FixedResponse = &template.ALBFixedResponseAction{
+				StatusCode:  strconv.Itoa(aws.IntValue(mftRule.FixedResponse.StatusCode)),
+				ContentType: aws.StringValue(mftRule.FixedResponse.ContentType),
+				MessageBody: aws.StringValue(mftRule.FixedResponse.Body),
+			}
+		case len(mftRule.Targets) == 0:
+			// Forward to the target container of the service by default.
+			rule.Targets = []template.ALBWeightedTarget{
+				{
+					TargetGroup: "TargetGroup",
+					Weight:      1,
+				},
+			}
+		default:
+			for _, target := range mftRule.Targets {
+				weighted := template.ALBWeightedTarget{
+					TargetGroup: "TargetGroup",
+					Weight:      1,
+				}
+				if target.Weight != nil {
+					weighted.Weight = aws.IntValue(target.Weight)
+				}
+				container := aws.StringValue(target.Container)
+				if container != aws.StringValue(targetContainer) {
+					tg := template.ALBTargetGroup{
+						Container: container,
+						Port:      s.containerPortOf(container),
+					}
+					weighted.TargetGroup = tg.LogicalID()
+					if !added[container] {
+						targetGroups = append(targetGroups, tg)
+						added[container] = true
+					}
+				}
+				rule.Targets = append(rule.Targets, weighted)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, targetGroups
+}
+
+// containerPortOf returns the port exposed by the main container or a sidecar of the service.
+func (s *LoadBalancedWebService) containerPortOf(container string) string {
+	if container == s.name {
+		return s.containerPort()
+	}
+	return aws.StringValue(s.manifest.Sidecars[container].Port)
+}
+
 func (s *LoadBalancedWebService) containerPort() string {
 	return strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.ImageConfig.Port)), 10)
 }
@@ -332,7 +474,7 @@ func (s *LoadBalancedWebService) Parameters() ([]*cloudformation.Parameter, erro
 		}...)
 	}
 	if !s.manifest.NLBConfig.IsEmpty() {
-		port, _, err := manifest.ParsePortMapping(s.manifest.NLBConfig.Port)
+		port, _, err := manifest.ParsePortMapping(s.manifest.NLBConfig.Listener.Port)
 		if err != nil {
 			return nil, err
 		}
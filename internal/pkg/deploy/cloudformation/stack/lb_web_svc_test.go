@@ -359,6 +359,455 @@ Outputs:
 	}
 }
 
+func TestLoadBalancedWebService_convertListenerRules(t *testing.T) {
+	testCases := map[string]struct {
+		inRoutingRule manifest.RoutingRuleConfigOrBool
+		inSidecars    map[string]*manifest.SidecarConfig
+
+		wantedRules        []template.ALBListenerRule
+		wantedTargetGroups []template.ALBTargetGroup
+	}{
+		"no additional rules": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+				},
+			},
+		},
+		"converts conditions and forwards to the target group of the service by default": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+					AdditionalRules: []manifest.ListenerRule{
+						{
+							Priority: aws.Int(10),
+							Path:     aws.String("/api"),
+							Hosts:    []string{"api.example.com"},
+							Headers: map[string][]string{
+								"X-Version": {"2"},
+								"X-Canary":  {"true"},
+							},
+							QueryStrings: map[string]string{
+								"version": "2",
+							},
+							AllowedSourceIps: []manifest.IPNet{"10.0.0.0/24"},
+						},
+					},
+				},
+			},
+			wantedRules: []template.ALBListenerRule{
+				{
+					Priority: 10,
+					Paths:    []string{"/api", "/api/*"},
+					Hosts:    []string{"api.example.com"},
+					Headers: []template.ALBHeaderCondition{
+						{Name: "X-Canary", Values: []string{"true"}},
+						{Name: "X-Version", Values: []string{"2"}},
+					},
+					QueryStrings:     []template.ALBQueryStringCondition{{Key: "version", Value: "2"}},
+					AllowedSourceIps: []string{"10.0.0.0/24"},
+					Targets:          []template.ALBWeightedTarget{{TargetGroup: "TargetGroup", Weight: 1}},
+				},
+			},
+		},
+		"creates a target group for each additional container": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+					AdditionalRules: []manifest.ListenerRule{
+						{
+							Priority: aws.Int(10),
+							Path:     aws.String("/beta"),
+							Targets: []manifest.WeightedTarget{
+								{Container: aws.String("frontend"), Weight: aws.Int(90)},
+								{Container: aws.String("canary"), Weight: aws.Int(10)},
+							},
+						},
+						{
+							Priority: aws.Int(20),
+							Path:     aws.String("/preview"),
+							Targets: []manifest.WeightedTarget{
+								{Container: aws.String("canary")},
+							},
+						},
+					},
+				},
+			},
+			inSidecars: map[string]*manifest.SidecarConfig{
+				"canary": {
+					Port: aws.String("8080"),
+				},
+			},
+			wantedRules: []template.ALBListenerRule{
+				{
+					Priority: 10,
+					Paths:    []string{"/beta", "/beta/*"},
+					Targets: []template.ALBWeightedTarget{
+						{TargetGroup: "TargetGroup", Weight: 90},
+						{TargetGroup: "TargetGroupcanary", Weight: 10},
+					},
+				},
+				{
+					Priority: 20,
+					Paths:    []string{"/preview", "/preview/*"},
+					Targets: []template.ALBWeightedTarget{
+						{TargetGroup: "TargetGroupcanary", Weight: 1},
+					},
+				},
+			},
+			wantedTargetGroups: []template.ALBTargetGroup{
+				{Container: "canary", Port: "8080"},
+			},
+		},
+		"converts redirect and fixed response actions": {
+			inRoutingRule: manifest.RoutingRuleConfigOrBool{
+				RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+					Path: aws.String("/"),
+					AdditionalRules: []manifest.ListenerRule{
+						{
+							Priority: aws.Int(10),
+							Path:     aws.String("/old"),
+							Redirect: manifest.RedirectAction{
+								Path: aws.String("/new"),
+							},
+						},
+						{
+							Priority: aws.Int(20),
+							Path:     aws.String("/"),
+							FixedResponse: manifest.FixedResponseAction{
+								StatusCode:  aws.Int(503),
+								ContentType: aws.String("text/plain"),
+								Body:        aws.String("Down for maintenance"),
+							},
+						},
+					},
+				},
+			},
+			wantedRules: []template.ALBListenerRule{
+				{
+					Priority: 10,
+					Paths:    []string{"/old", "/old/*"},
+					Redirect: &template.ALBRedirectAction{
+						Path:       "/new",
+						StatusCode: "HTTP_301",
+					},
+				},
+				{
+					Priority: 20,
+					Paths:    []string{"/*"},
+					FixedResponse: &template.ALBFixedResponseAction{
+						StatusCode:  "503",
+						ContentType: "text/plain",
+						MessageBody: "Down for maintenance",
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			svc := &LoadBalancedWebService{
+				ecsWkld: &ecsWkld{
+					wkld: &wkld{
+						name: "frontend",
+					},
+				},
+				manifest: &manifest.LoadBalancedWebService{
+					Workload: manifest.Workload{
+						Name: aws.String("frontend"),
+					},
+					LoadBalancedWebServiceConfig: manifest.LoadBalancedWebServiceConfig{
+						ImageConfig: manifest.ImageWithPortAndHealthcheck{
+							ImageWithPort: manifest.ImageWithPort{
+								Port: aws.Uint16(80),
+							},
+						},
+						RoutingRule: tc.inRoutingRule,
+						Sidecars:    tc.inSidecars,
+					},
+				},
+			}
+
+			// WHEN
+			rules, targetGroups := svc.convertListenerRules()
+
+			// THEN
+			require.Equal(t, tc.wantedRules, rules)
+			require.Equal(t, tc.wantedTargetGroups, targetGroups)
+		})
+	}
+}
+
+func TestLoadBalancedWebService_convertNetworkLoadBalancer(t *testing.T) {
+	testCases := map[string]struct {
+		inNLB manifest.NetworkLoadBalancerConfiguration
+
+		wanted *template.NetworkLoadBalancer
+	}{
+		"no network load balancer": {},
+		"converts the main listener with defaults": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("443"),
+				},
+			},
+			wanted: &template.NetworkLoadBalancer{
+				PublicSubnetCIDRs: []string{"10.0.0.0/24"},
+				Listener: []template.NetworkLoadBalancerListener{
+					{
+						Port:            "443",
+						Protocol:        "TCP",
+						TargetContainer: "frontend",
+						TargetPort:      "443",
+					},
+				},
+				MainContainerPort: "80",
+				PortMappings: []template.NLBPortMapping{
+					{Port: "443", Protocol: "tcp"},
+				},
+			},
+		},
+		"exposes the health check port of a udp listener": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port: aws.String("161/udp"),
+					HealthCheck: manifest.NLBHealthCheckArgs{
+						Port: aws.Int(8080),
+					},
+				},
+			},
+			wanted: &template.NetworkLoadBalancer{
+				PublicSubnetCIDRs: []string{"10.0.0.0/24"},
+				Listener: []template.NetworkLoadBalancerListener{
+					{
+						Port:            "161",
+						Protocol:        "UDP",
+						TargetContainer: "frontend",
+						TargetPort:      "161",
+						HealthCheck: template.NLBHealthCheck{
+							Port: "8080",
+						},
+					},
+				},
+				MainContainerPort: "80",
+				PortMappings: []template.NLBPortMapping{
+					{Port: "161", Protocol: "udp"},
+					{Port: "8080", Protocol: "tcp"},
+				},
+			},
+		},
+		"converts additional listeners": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listener: manifest.NetworkLoadBalancerListener{
+					Port:       aws.String("443/tls"),
+					TargetPort: aws.Int(80),
+					ALPNPolicy: aws.String("HTTP2Preferred"),
+				},
+				Aliases:   manifest.Alias{String: aws.String("example.com")},
+				CrossZone: aws.Bool(true),
+				AdditionalListeners: []manifest.NetworkLoadBalancerListener{
+					{
+						Port:             aws.String("53/tcp_udp"),
+						PreserveClientIP: aws.Bool(true),
+						HealthCheck: manifest.NLBHealthCheckArgs{
+							Port: aws.Int(80),
+						},
+					},
+					{
+						Port:            aws.String("7777/udp"),
+						TargetContainer: aws.String("game"),
+					},
+				},
+			},
+			wanted: &template.NetworkLoadBalancer{
+				PublicSubnetCIDRs: []string{"10.0.0.0/24"},
+				Listener: []template.NetworkLoadBalancerListener{
+					{
+						Port:            "443",
+						Protocol:        "TLS",
+						TargetContainer: "frontend",
+						TargetPort:      "80",
+						ALPNPolicy:      aws.String("HTTP2Preferred"),
+					},
+					{
+						Port:             "53",
+						Protocol:         "TCP_UDP",
+						TargetContainer:  "frontend",
+						TargetPort:       "53",
+						PreserveClientIP: aws.Bool(true),
+						HealthCheck: template.NLBHealthCheck{
+							Port: "80",
+						},
+					},
+					{
+						Port:            "7777",
+						Protocol:        "UDP",
+						TargetContainer: "game",
+						TargetPort:      "7777",
+					},
+				},
+				MainContainerPort: "80",
+				PortMappings: []template.NLBPortMapping{
+					{Port: "53", Protocol: "tcp"},
+					{Port: "53", Protocol: "udp"},
+				},
+				CertificateRequired: true,
+				Aliases:             []string{"example.com"},
+				CrossZone:           aws.Bool(true),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			svc := &LoadBalancedWebService{
+				ecsWkld: &ecsWkld{
+					wkld: &wkld{
+						name: "frontend",
+					},
+				},
+				manifest: &manifest.LoadBalancedWebService{
+					Workload: manifest.Workload{
+						Name: aws.String("frontend"),
+					},
+					LoadBalancedWebServiceConfig: manifest.LoadBalancedWebServiceConfig{
+						ImageConfig: manifest.ImageWithPortAndHealthcheck{
+							ImageWithPort: manifest.ImageWithPort{
+								Port: aws.Uint16(80),
+							},
+						},
+						Sidecars: map[string]*manifest.SidecarConfig{
+							"game": {
+								Port: aws.String("7777/udp"),
+							},
+						},
+						NLBConfig: tc.inNLB,
+					},
+				},
+				publicSubnetCIDRBlocks: []string{"10.0.0.0/24"},
+			}
+
+			// WHEN
+			got, err := svc.convertNetworkLoadBalancer()
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got.settings)
+		})
+	}
+}
+
+func TestLoadBalancedWebService_convertCDN(t *testing.T) {
+	testCases := map[string]struct {
+		inCDN       manifest.CDNBoolOrArgs
+		setupParser func(m *mocks.MockloadBalancedWebSvcReadParser)
+
+		wanted      cdnConfig
+		wantedError error
+	}{
+		"no distribution if cdn is disabled": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Enable: aws.Bool(false),
+			},
+		},
+		"disables caching by default": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			wanted: cdnConfig{
+				settings: &template.CDNOpts{
+					CachePolicyID: "4135ea2d-6df8-44a3-9df3-4b5a84be39ad",
+					PrefixListID:  "pl-mockid",
+				},
+			},
+		},
+		"keeps the ID of a custom cache policy": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Advanced: manifest.CDNArgs{
+					CachePolicy:  aws.String("a1b2c3d4-5678-90ab-cdef-1234567890ab"),
+					OriginShield: aws.Bool(true),
+				},
+			},
+			wanted: cdnConfig{
+				settings: &template.CDNOpts{
+					CachePolicyID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+					OriginShield:  true,
+					PrefixListID:  "pl-mockid",
+				},
+			},
+		},
+		"error if the certificate validator cannot be read": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Advanced: manifest.CDNArgs{
+					Aliases: manifest.Alias{String: aws.String("www.test.phonetool.com")},
+				},
+			},
+			setupParser: func(m *mocks.MockloadBalancedWebSvcReadParser) {
+				m.EXPECT().Read(dnsCertValidatorPath).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("read CDN certificate validator lambda: some error"),
+		},
+		"reads the certificate validator for aliases": {
+			inCDN: manifest.CDNBoolOrArgs{
+				Advanced: manifest.CDNArgs{
+					CachePolicy: aws.String("CachingOptimized"),
+					Aliases:     manifest.Alias{StringSlice: []string{"www.test.phonetool.com"}},
+				},
+			},
+			setupParser: func(m *mocks.MockloadBalancedWebSvcReadParser) {
+				m.EXPECT().Read(dnsCertValidatorPath).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil)
+			},
+			wanted: cdnConfig{
+				settings: &template.CDNOpts{
+					CachePolicyID: "658327ea-f89d-4fab-a63d-7e88639e58f6",
+					Aliases:       []string{"www.test.phonetool.com"},
+					PrefixListID:  "pl-mockid",
+				},
+				certValidatorLambda: "lambda",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			parser := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+			if tc.setupParser != nil {
+				tc.setupParser(parser)
+			}
+			svc := &LoadBalancedWebService{
+				manifest: &manifest.LoadBalancedWebService{
+					LoadBalancedWebServiceConfig: manifest.LoadBalancedWebServiceConfig{
+						RoutingRule: manifest.RoutingRuleConfigOrBool{
+							RoutingRuleConfiguration: manifest.RoutingRuleConfiguration{
+								Path: aws.String("/"),
+								CDN:  tc.inCDN,
+							},
+						},
+					},
+				},
+				cdnPrefixListID: "pl-mockid",
+				parser:          parser,
+			}
+
+			// WHEN
+			got, err := svc.convertCDN()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
 func TestLoadBalancedWebService_Parameters(t *testing.T) {
 	baseProps := &manifest.LoadBalancedWebServiceProps{
 		WorkloadProps: &manifest.WorkloadProps{
@@ -671,7 +1120,9 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 		"nlb enabled": {
 			setupManifest: func(service *manifest.LoadBalancedWebService) {
 				service.NLBConfig = manifest.NetworkLoadBalancerConfiguration{
-					Port: aws.String("443/tcp"),
+					Listener: manifest.NetworkLoadBalancerListener{
+						Port: aws.String("443/tcp"),
+					},
 				}
 			},
 			expectedParams: append(expectedParams, []*cloudformation.Parameter{
@@ -716,10 +1167,12 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 		"nlb alias enabled": {
 			setupManifest: func(service *manifest.LoadBalancedWebService) {
 				service.NLBConfig = manifest.NetworkLoadBalancerConfiguration{
+					Listener: manifest.NetworkLoadBalancerListener{
+						Port: aws.String("443/tcp"),
+					},
 					Aliases: manifest.Alias{
 						StringSlice: []string{"example.com", "v1.example.com"},
 					},
-					Port: aws.String("443/tcp"),
 				}
 			},
 			expectedParams: append(expectedParams, []*cloudformation.Parameter{
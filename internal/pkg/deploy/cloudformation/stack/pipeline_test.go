@@ -7,7 +7,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -15,8 +17,10 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/template/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
 )
 
 const (
@@ -162,6 +166,124 @@ func TestPipelineStackConfig_Template(t *testing.T) {
 	}
 }
 
+func TestPipelineStackConfig_PreviewTemplate(t *testing.T) {
+	type statement struct {
+		Action    []string    `yaml:"Action"`
+		NotAction []string    `yaml:"NotAction"`
+		Resource  interface{} `yaml:"Resource"`
+	}
+	type cfn struct {
+		Resources struct {
+			PreviewCFNExecutionRole struct {
+				Properties struct {
+					Policies []struct {
+						PolicyDocument struct {
+							Statement []statement `yaml:"Statement"`
+						} `yaml:"PolicyDocument"`
+					} `yaml:"Policies"`
+				} `yaml:"Properties"`
+			} `yaml:"PreviewCFNExecutionRole"`
+			PreviewBuildProject struct {
+				Properties struct {
+					Triggers struct {
+						FilterGroups [][]struct {
+							Type    string `yaml:"Type"`
+							Pattern string `yaml:"Pattern"`
+						} `yaml:"FilterGroups"`
+					} `yaml:"Triggers"`
+				} `yaml:"Properties"`
+			} `yaml:"PreviewBuildProject"`
+			PreviewBranchDeletedRule *struct{} `yaml:"PreviewBranchDeletedRule"`
+		} `yaml:"Resources"`
+	}
+	testCases := map[string]struct {
+		inSource interface{}
+
+		wantedWebhookEvents []string
+		wantedDeletedRule   bool
+	}{
+		"GitHub repositories delete environments on pull request webhook events": {
+			inSource: &deploy.GitHubSource{
+				ProviderName:  manifest.GithubProviderName,
+				RepositoryURL: "https://github.com/aws/phonetool",
+				Branch:        defaultBranch,
+				ConnectionARN: "arn:aws:codestar-connections:us-west-2:1111:connection/abcd",
+			},
+			wantedWebhookEvents: []string{"PUSH", "PULL_REQUEST_MERGED, PULL_REQUEST_CLOSED"},
+		},
+		"Bitbucket repositories delete environments on pull request webhook events": {
+			inSource: &deploy.BitbucketSource{
+				ProviderName:  manifest.BitbucketProviderName,
+				RepositoryURL: "https://bitbucket.org/aws/phonetool",
+				Branch:        defaultBranch,
+				ConnectionARN: "arn:aws:codestar-connections:us-west-2:1111:connection/abcd",
+			},
+			wantedWebhookEvents: []string{"PUSH", "PULL_REQUEST_MERGED, PULL_REQUEST_CLOSED"},
+		},
+		"CodeCommit repositories delete environments on branch deletion events": {
+			inSource: &deploy.CodeCommitSource{
+				ProviderName:  manifest.CodeCommitProviderName,
+				RepositoryURL: "https://us-west-2.console.aws.amazon.com/codesuite/codecommit/repositories/phonetool/browse",
+				Branch:        defaultBranch,
+			},
+			wantedDeletedRule: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var build deploy.Build
+			build.Init(nil, "copilot/pipelines/phonetool-pipeline/")
+			ttl := 24 * time.Hour
+			var preview deploy.PreviewEnvironments
+			preview.Init(&manifest.Preview{
+				Branches: []string{"feature/*"},
+				TTL:      &ttl,
+			}, "https://ecs-cli-v2-release.s3.amazonaws.com/copilot-linux-v1.18.0")
+			in := mockCreatePipelineInput()
+			in.AppName = "phonetool"
+			in.Source = tc.inSource
+			in.Build = &build
+			in.Preview = &preview
+
+			// WHEN
+			tpl, err := NewPipelineStackConfig(in).Template()
+			require.NoError(t, err)
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal([]byte(tpl), &actual))
+
+			// THEN
+			policies := actual.Resources.PreviewCFNExecutionRole.Properties.Policies
+			require.NotEmpty(t, policies)
+			for _, policy := range policies {
+				for _, s := range policy.PolicyDocument.Statement {
+					require.Empty(t, s.NotAction, "the preview execution role must not be granted every action")
+					require.NotContains(t, s.Action, "*")
+					for _, action := range s.Action {
+						if !strings.HasPrefix(action, "iam:") || action == "iam:CreateServiceLinkedRole" {
+							continue
+						}
+						require.Equal(t, []interface{}{
+							"arn:${AWS::Partition}:iam::${AWS::AccountId}:role/phonetool-preview-*",
+							"arn:${AWS::Partition}:iam::${AWS::AccountId}:instance-profile/phonetool-preview-*",
+						}, s.Resource, "%s must only be allowed on preview environment roles", action)
+					}
+				}
+			}
+			var events []string
+			for _, group := range actual.Resources.PreviewBuildProject.Properties.Triggers.FilterGroups {
+				for _, filter := range group {
+					if filter.Type == "EVENT" {
+						events = append(events, filter.Pattern)
+					}
+				}
+			}
+			require.Equal(t, tc.wantedWebhookEvents, events)
+			require.Equal(t, tc.wantedDeletedRule, actual.Resources.PreviewBranchDeletedRule != nil)
+		})
+	}
+}
+
 func mockCreatePipelineInput() *deploy.CreatePipelineInput {
 	return &deploy.CreatePipelineInput{
 		AppName: projectName,
@@ -132,6 +132,8 @@ func (s *RequestDrivenWebService) Template() (string, error) {
 		Tags:              s.manifest.Tags,
 		NestedStack:       addonsOutputs,
 		AddonsExtraParams: addonsParams,
+		EnvAddons:         convertEnvAddonsImports(s.manifest.EnvAddons),
+		Permissions:       convertPermissions(s.manifest.Permissions),
 		EnableHealthCheck: !s.healthCheckConfig.IsEmpty(),
 
 		Alias:                s.manifest.Alias,
@@ -127,10 +127,14 @@ func (j *ScheduledJob) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	sidecars, err := convertSidecar(j.manifest.Sidecars)
+	sidecars, err := convertSidecar(j.manifest.Sidecars, j.manifest.InitContainers)
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for job %s: %w", j.name, err)
 	}
+	initContainers, err := convertInitContainers(j.manifest.InitContainers)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for job %s: %w", j.name, err)
+	}
 	publishers, err := convertPublish(j.manifest.Publish(), j.rc.AccountID, j.rc.Region, j.app, j.env, j.name)
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for job %s: %w`, j.name, err)
@@ -161,7 +165,10 @@ func (j *ScheduledJob) Template() (string, error) {
 		Secrets:                  convertSecrets(j.manifest.Secrets),
 		NestedStack:              addonsOutputs,
 		AddonsExtraParams:        addonsParams,
+		EnvAddons:                convertEnvAddonsImports(j.manifest.EnvAddons),
+		Permissions:              convertPermissions(j.manifest.Permissions),
 		Sidecars:                 sidecars,
+		InitContainers:           initContainers,
 		ScheduleExpression:       schedule,
 		StateMachine:             stateMachine,
 		HealthCheck:              convertContainerHealthCheck(j.manifest.ImageConfig.HealthCheck),
@@ -171,7 +178,7 @@ func (j *ScheduledJob) Template() (string, error) {
 		Network:                  convertNetworkConfig(j.manifest.Network),
 		EntryPoint:               entrypoint,
 		Command:                  command,
-		DependsOn:                convertDependsOn(j.manifest.ImageConfig.Image.DependsOn),
+		DependsOn:                convertDependsOnWithInitContainers(j.manifest.ImageConfig.Image.DependsOn, j.manifest.InitContainers),
 		CredentialsParameter:     aws.StringValue(j.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint: j.rc.ServiceDiscoveryEndpoint,
 		Publish:                  publishers,
@@ -231,9 +238,14 @@ func (j *ScheduledJob) awsSchedule() (string, error) {
 	if schedule == "" {
 		return "", fmt.Errorf(`missing required field "schedule" in manifest for job %s`, j.name)
 	}
+	return toAWSSchedule(schedule)
+}
+
+// toAWSSchedule converts a schedule string to the format required by Cloudwatch Events and Application Auto Scaling.
+func toAWSSchedule(schedule string) (string, error) {
 	// If the schedule uses default CloudWatch Events syntax, pass it through for server-side validation.
 	if match := awsScheduleRegexp.FindStringSubmatch(schedule); match != nil {
-		return aws.StringValue(j.manifest.On.Schedule), nil
+		return schedule, nil
 	}
 	// Try parsing the string as a cron expression to validate it.
 	if _, err := cron.ParseStandard(schedule); err != nil {
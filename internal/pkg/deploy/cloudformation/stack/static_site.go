@@ -0,0 +1,148 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/s3"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/template"
+)
+
+const staticSiteAssetsPath = "custom-resources/static-site-assets.js"
+
+type staticSiteReadParser interface {
+	template.ReadParser
+	ParseStaticSite(template.WorkloadOpts) (*template.Content, error)
+}
+
+// StaticSite represents the configuration needed to create a CloudFormation stack from a static site manifest.
+type StaticSite struct {
+	*wkld
+	manifest *manifest.StaticSite
+	appInfo  deploy.AppInformation
+
+	parser staticSiteReadParser
+}
+
+// StaticSiteConfig contains fields to configure StaticSite.
+type StaticSiteConfig struct {
+	App           *config.Application
+	Env           *config.Environment
+	Manifest      *manifest.StaticSite
+	RuntimeConfig RuntimeConfig
+	RootUserARN   string
+}
+
+// NewStaticSite creates a new CFN stack with an S3 bucket and a CloudFront distribution from a static site manifest.
+func NewStaticSite(conf StaticSiteConfig) (*StaticSite, error) {
+	parser := template.New()
+	var appInfo deploy.AppInformation
+	if conf.App.Domain != "" {
+		appInfo = deploy.AppInformation{
+			Name:                conf.App.Name,
+			Domain:              conf.App.Domain,
+			AccountPrincipalARN: conf.RootUserARN,
+		}
+	}
+	return &StaticSite{
+		wkld: &wkld{
+			name:   aws.StringValue(conf.Manifest.Name),
+			env:    conf.Env.Name,
+			app:    conf.App.Name,
+			rc:     conf.RuntimeConfig,
+			parser: parser,
+		},
+		manifest: conf.Manifest,
+		appInfo:  appInfo,
+
+		parser: parser,
+	}, nil
+}
+
+// Template returns the CloudFormation template for the static site.
+func (s *StaticSite) Template() (string, error) {
+	assetsLambda, err := s.parser.Read(staticSiteAssetsPath)
+	if err != nil {
+		return "", fmt.Errorf("read static site assets lambda: %w", err)
+	}
+	site := &template.StaticSiteOpts{
+		IndexDocument: aws.StringValue(s.manifest.IndexDocument),
+		ErrorDocument: aws.StringValue(s.manifest.ErrorDocument),
+	}
+	aliases, err := s.manifest.Aliases.ToStringSlice()
+	if err != nil {
+		return "", fmt.Errorf(`convert "aliases" to string slice: %w`, err)
+	}
+	var certValidatorLambda string
+	var dnsDelegationRole, dnsName *string
+	if len(aliases) != 0 {
+		content, err := s.parser.Read(dnsCertValidatorPath)
+		if err != nil {
+			return "", fmt.Errorf("read CDN certificate validator lambda: %w", err)
+		}
+		certValidatorLambda = content.String()
+		dnsDelegationRole, dnsName = convertAppInformation(s.appInfo)
+	}
+	content, err := s.parser.ParseStaticSite(template.WorkloadOpts{
+		WorkloadType: manifest.StaticSiteType,
+		StaticSite:   site,
+		CDN: &template.CDNOpts{
+			Aliases: aliases,
+		},
+		AppDNSName:             dnsName,
+		AppDNSDelegationRole:   dnsDelegationRole,
+		StaticSiteAssetsLambda: assetsLambda.String(),
+		CDNCertValidatorLambda: certValidatorLambda,
+	})
+	if err != nil {
+		return "", fmt.Errorf("parse static site template: %w", err)
+	}
+	return content.String(), nil
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+// The asset mapping file parameters are left empty if there is no mapping file, in which case the site bucket is left untouched.
+func (s *StaticSite) Parameters() ([]*cloudformation.Parameter, error) {
+	var bucket, key string
+	if s.rc.StaticSiteAssetMappingURL != "" {
+		var err error
+		bucket, key, err = s3.ParseURL(s.rc.StaticSiteAssetMappingURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse static site asset mapping URL: %w", err)
+		}
+	}
+	return []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(WorkloadAppNameParamKey),
+			ParameterValue: aws.String(s.app),
+		},
+		{
+			ParameterKey:   aws.String(WorkloadEnvNameParamKey),
+			ParameterValue: aws.String(s.env),
+		},
+		{
+			ParameterKey:   aws.String(WorkloadNameParamKey),
+			ParameterValue: aws.String(s.name),
+		},
+		{
+			ParameterKey:   aws.String(StaticSiteAssetMappingFileBucketParamKey),
+			ParameterValue: aws.String(bucket),
+		},
+		{
+			ParameterKey:   aws.String(StaticSiteAssetMappingFileKeyParamKey),
+			ParameterValue: aws.String(key),
+		},
+	}, nil
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized to a JSON document.
+func (s *StaticSite) SerializedParameters() (string, error) {
+	return s.templateConfiguration(s)
+}
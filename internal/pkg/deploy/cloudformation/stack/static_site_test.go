@@ -0,0 +1,201 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/template"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticSite_Template(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest  func(mft *manifest.StaticSite)
+		setupParser func(m *mocks.MockstaticSiteReadParser)
+
+		wantedTemplate string
+		wantedError    error
+	}{
+		"error reading the static site assets lambda": {
+			setupParser: func(m *mocks.MockstaticSiteReadParser) {
+				m.EXPECT().Read(staticSiteAssetsPath).Return(nil, errors.New("some error"))
+			},
+			wantedError: fmt.Errorf("read static site assets lambda: some error"),
+		},
+		"error parsing the template": {
+			setupParser: func(m *mocks.MockstaticSiteReadParser) {
+				m.EXPECT().Read(staticSiteAssetsPath).Return(&template.Content{Buffer: bytes.NewBufferString("assets")}, nil)
+				m.EXPECT().ParseStaticSite(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedError: fmt.Errorf("parse static site template: some error"),
+		},
+		"renders the template without aliases": {
+			setupParser: func(m *mocks.MockstaticSiteReadParser) {
+				m.EXPECT().Read(staticSiteAssetsPath).Return(&template.Content{Buffer: bytes.NewBufferString("assets")}, nil)
+				m.EXPECT().ParseStaticSite(template.WorkloadOpts{
+					WorkloadType: manifest.StaticSiteType,
+					StaticSite: &template.StaticSiteOpts{
+						IndexDocument: "index.html",
+					},
+					CDN:                    &template.CDNOpts{},
+					StaticSiteAssetsLambda: "assets",
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+			},
+			wantedTemplate: "template",
+		},
+		"renders the template with aliases": {
+			inManifest: func(mft *manifest.StaticSite) {
+				mft.ErrorDocument = aws.String("404.html")
+				mft.Aliases = manifest.Alias{
+					String: aws.String("www.example.com"),
+				}
+			},
+			setupParser: func(m *mocks.MockstaticSiteReadParser) {
+				m.EXPECT().Read(staticSiteAssetsPath).Return(&template.Content{Buffer: bytes.NewBufferString("assets")}, nil)
+				m.EXPECT().Read(dnsCertValidatorPath).Return(&template.Content{Buffer: bytes.NewBufferString("validator")}, nil)
+				m.EXPECT().ParseStaticSite(template.WorkloadOpts{
+					WorkloadType: manifest.StaticSiteType,
+					StaticSite: &template.StaticSiteOpts{
+						IndexDocument: "index.html",
+						ErrorDocument: "404.html",
+					},
+					CDN: &template.CDNOpts{
+						Aliases: []string{"www.example.com"},
+					},
+					AppDNSName:             aws.String("example.com"),
+					AppDNSDelegationRole:   aws.String("arn:aws:iam::123456789123:role/phonetool-DNSDelegationRole"),
+					StaticSiteAssetsLambda: "assets",
+					CDNCertValidatorLambda: "validator",
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+			},
+			wantedTemplate: "template",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			parser := mocks.NewMockstaticSiteReadParser(ctrl)
+			tc.setupParser(parser)
+			mft := manifest.NewStaticSite(manifest.StaticSiteProps{
+				Name:       "frontend",
+				SourcePath: "dist",
+			})
+			if tc.inManifest != nil {
+				tc.inManifest(mft)
+			}
+			site, err := NewStaticSite(StaticSiteConfig{
+				App: &config.Application{
+					Name:   "phonetool",
+					Domain: "example.com",
+				},
+				Env: &config.Environment{
+					Name: "test",
+				},
+				Manifest:    mft,
+				RootUserARN: "arn:aws:iam::123456789123:root",
+			})
+			require.NoError(t, err)
+			site.parser = parser
+
+			// WHEN
+			got, err := site.Template()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedTemplate, got)
+		})
+	}
+}
+
+func TestStaticSite_Parameters(t *testing.T) {
+	testCases := map[string]struct {
+		inMappingURL string
+
+		wantedBucket string
+		wantedKey    string
+		wantedError  error
+	}{
+		"error parsing the asset mapping URL": {
+			inMappingURL: "not-a-url",
+			wantedError:  errors.New(`parse static site asset mapping URL: cannot parse S3 URL not-a-url into bucket name and key`),
+		},
+		"leaves the asset mapping file empty without a mapping URL": {},
+		"sets the asset mapping file from the mapping URL": {
+			inMappingURL: "https://stackset-bucket.s3.us-west-2.amazonaws.com/manual/static-site/frontend/mappings/abc.json",
+			wantedBucket: "stackset-bucket",
+			wantedKey:    "manual/static-site/frontend/mappings/abc.json",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			site, err := NewStaticSite(StaticSiteConfig{
+				App: &config.Application{
+					Name: "phonetool",
+				},
+				Env: &config.Environment{
+					Name: "test",
+				},
+				Manifest: manifest.NewStaticSite(manifest.StaticSiteProps{
+					Name:       "frontend",
+					SourcePath: "dist",
+				}),
+				RuntimeConfig: RuntimeConfig{
+					StaticSiteAssetMappingURL: tc.inMappingURL,
+				},
+			})
+			require.NoError(t, err)
+
+			// WHEN
+			params, err := site.Parameters()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []*cloudformation.Parameter{
+				{
+					ParameterKey:   aws.String(WorkloadAppNameParamKey),
+					ParameterValue: aws.String("phonetool"),
+				},
+				{
+					ParameterKey:   aws.String(WorkloadEnvNameParamKey),
+					ParameterValue: aws.String("test"),
+				},
+				{
+					ParameterKey:   aws.String(WorkloadNameParamKey),
+					ParameterValue: aws.String("frontend"),
+				},
+				{
+					ParameterKey:   aws.String(StaticSiteAssetMappingFileBucketParamKey),
+					ParameterValue: aws.String(tc.wantedBucket),
+				},
+				{
+					ParameterKey:   aws.String(StaticSiteAssetMappingFileKeyParamKey),
+					ParameterValue: aws.String(tc.wantedKey),
+				},
+			}, params)
+		})
+	}
+}
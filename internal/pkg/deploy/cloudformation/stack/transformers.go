@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/crc32"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +38,9 @@ const (
 	defaultNLBProtocol     = manifest.TCP
 )
 
+// Condition that the main container and sidecars wait for on init containers.
+const initContainerDependsOnCondition = "SUCCESS"
+
 // Supported capacityproviders for Fargate services
 const (
 	capacityProviderFargateSpot = "FARGATE_SPOT"
@@ -51,12 +55,18 @@ const (
 	maxPercentDefault         = 200
 )
 
+// Statistic used to aggregate a custom autoscaling metric if none is specified.
+const defaultMetricStatistic = "Average"
+
+// Time to wait for the pre-deploy task to stop if no timeout is specified.
+const defaultPreDeployTimeout = 10 * time.Minute
+
 var (
 	taskDefOverrideRulePrefixes = []string{"Resources", "TaskDefinition", "Properties"}
 )
 
 // convertSidecar converts the manifest sidecar configuration into a format parsable by the templates pkg.
-func convertSidecar(s map[string]*manifest.SidecarConfig) ([]*template.SidecarOpts, error) {
+func convertSidecar(s map[string]*manifest.SidecarConfig, initContainers map[string]*manifest.InitContainerConfig) ([]*template.SidecarOpts, error) {
 	if s == nil {
 		return nil, nil
 	}
@@ -88,7 +98,7 @@ func convertSidecar(s map[string]*manifest.SidecarConfig) ([]*template.SidecarOp
 				MountPoints: mp,
 			},
 			DockerLabels: config.DockerLabels,
-			DependsOn:    convertDependsOn(config.DependsOn),
+			DependsOn:    convertDependsOnWithInitContainers(config.DependsOn, initContainers),
 			EntryPoint:   entrypoint,
 			HealthCheck:  convertContainerHealthCheck(config.HealthCheck),
 			Command:      command,
@@ -97,6 +107,44 @@ func convertSidecar(s map[string]*manifest.SidecarConfig) ([]*template.SidecarOp
 	return sidecars, nil
 }
 
+// convertInitContainers converts the manifest init containers configuration into a format parsable by the templates pkg.
+func convertInitContainers(in map[string]*manifest.InitContainerConfig) ([]*template.InitContainerOpts, error) {
+	if in == nil {
+		return nil, nil
+	}
+	names := make([]string, 0, len(in))
+	for name := range in {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Keep the order of the container definitions stable between deployments.
+	var initContainers []*template.InitContainerOpts
+	for _, name := range names {
+		config := in[name]
+		entrypoint, err := convertEntryPoint(config.EntryPoint)
+		if err != nil {
+			return nil, err
+		}
+		command, err := convertCommand(config.Command)
+		if err != nil {
+			return nil, err
+		}
+		initContainers = append(initContainers, &template.InitContainerOpts{
+			Name:       aws.String(name),
+			Image:      config.Image,
+			CredsParam: config.CredsParam,
+			Secrets:    convertSecrets(config.Secrets),
+			Variables:  config.Variables,
+			Storage: template.SidecarStorageOpts{
+				MountPoints: convertSidecarMountPoints(config.MountPoints),
+			},
+			DependsOn:  convertDependsOn(config.DependsOn),
+			EntryPoint: entrypoint,
+			Command:    command,
+		})
+	}
+	return initContainers, nil
+}
+
 func convertContainerHealthCheck(hc manifest.ContainerHealthCheck) *template.ContainerHealthCheck {
 	if hc.IsEmpty() {
 		return nil
@@ -124,6 +172,24 @@ func convertDependsOn(d manifest.DependsOn) map[string]string {
 	return dependsOn
 }
 
+// convertDependsOnWithInitContainers converts the depends on field of a container that must also wait for every init container to succeed.
+// Conditions set explicitly on an init container take precedence.
+func convertDependsOnWithInitContainers(d manifest.DependsOn, initContainers map[string]*manifest.InitContainerConfig) map[string]string {
+	dependsOn := convertDependsOn(d)
+	if len(initContainers) == 0 {
+		return dependsOn
+	}
+	if dependsOn == nil {
+		dependsOn = make(map[string]string)
+	}
+	for name := range initContainers {
+		if _, ok := dependsOn[name]; !ok {
+			dependsOn[name] = initContainerDependsOnCondition
+		}
+	}
+	return dependsOn
+}
+
 func convertAdvancedCount(a manifest.AdvancedCount) (*template.AdvancedCount, error) {
 	if a.IsEmpty() {
 		return nil, nil
@@ -214,10 +280,128 @@ func convertAutoscaling(a manifest.AdvancedCount) (*template.AutoscalingOpts, er
 			AcceptableBacklogPerTask: acceptableBacklog,
 		}
 	}
+	for _, metric := range a.CustomMetrics {
+		autoscalingOpts.CustomMetrics = append(autoscalingOpts.CustomMetrics, convertCustomMetricScaling(metric))
+	}
+	for i, schedule := range a.Schedules {
+		expression, err := toAWSSchedule(aws.StringValue(schedule.Cron))
+		if err != nil {
+			return nil, fmt.Errorf(`convert "schedules[%d]": %w`, i, err)
+		}
+		autoscalingOpts.Schedules = append(autoscalingOpts.Schedules, template.AutoscalingScheduleOpts{
+			Schedule:    expression,
+			TimeZone:    aws.StringValue(schedule.TimeZone),
+			MinCapacity: schedule.Min,
+			MaxCapacity: schedule.Max,
+		})
+	}
 	return &autoscalingOpts, nil
 }
 
+func convertCustomMetricScaling(in manifest.CustomMetricScaling) template.AutoscalingCustomMetricOpts {
+	out := template.AutoscalingCustomMetricOpts{
+		Target: aws.Float64Value(in.Target),
+	}
+	if in.Expression == nil {
+		out.CloudWatchMetricOpts = convertCloudWatchMetric(in.CloudWatchMetric)
+		return out
+	}
+	out.Expression = aws.StringValue(in.Expression)
+	for _, query := range in.Metrics {
+		out.Metrics = append(out.Metrics, template.CloudWatchMetricQueryOpts{
+			ID:                   aws.StringValue(query.ID),
+			CloudWatchMetricOpts: convertCloudWatchMetric(query.CloudWatchMetric),
+		})
+	}
+	return out
+}
+
+func convertCloudWatchMetric(in manifest.CloudWatchMetric) template.CloudWatchMetricOpts {
+	statistic := defaultMetricStatistic
+	if in.Statistic != nil {
+		statistic = aws.StringValue(in.Statistic)
+	}
+	return template.CloudWatchMetricOpts{
+		Namespace:  aws.StringValue(in.Namespace),
+		MetricName: aws.StringValue(in.MetricName),
+		Dimensions: in.Dimensions,
+		Statistic:  statistic,
+	}
+}
+
 // convertHTTPHealthCheck converts the ALB health check configuration into a format parsable by the templates pkg.
+func convertRedirectAction(in manifest.RedirectAction) *template.ALBRedirectAction {
+	statusCode := 301
+	if in.StatusCode != nil {
+		statusCode = aws.IntValue(in.StatusCode)
+	}
+	return &template.ALBRedirectAction{
+		Protocol:   aws.StringValue(in.Protocol),
+		Host:       aws.StringValue(in.Host),
+		Port:       aws.StringValue(in.Port),
+		Path:       aws.StringValue(in.Path),
+		Query:      aws.StringValue(in.Query),
+		StatusCode: fmt.Sprintf("HTTP_%d", statusCode),
+	}
+}
+
+func convertWAF(in manifest.WebACLArgsOrARN) *template.WAFOpts {
+	if in.IsEmpty() {
+		return nil
+	}
+	if in.ARN != nil {
+		return &template.WAFOpts{
+			WebACLARN: aws.StringValue(in.ARN),
+		}
+	}
+	return &template.WAFOpts{
+		ManagedRules: in.Advanced.ManagedRules,
+		RateLimit:    aws.IntValue(in.Advanced.RateLimit),
+	}
+}
+
+type cdnConfig struct {
+	settings *template.CDNOpts
+
+	// If the distribution has aliases this value is not empty.
+	certValidatorLambda string
+}
+
+func (s *LoadBalancedWebService) convertCDN() (cdnConfig, error) {
+	cdn := s.manifest.RoutingRule.CDN
+	if s.manifest.RoutingRule.Disabled() || !cdn.Enabled() {
+		return cdnConfig{}, nil
+	}
+	cachePolicyID := manifest.CDNManagedCachePolicyIDs[defaultCDNCachePolicy]
+	if policy := aws.StringValue(cdn.Advanced.CachePolicy); policy != "" {
+		cachePolicyID = policy
+		if id, ok := manifest.CDNManagedCachePolicyIDs[policy]; ok {
+			cachePolicyID = id
+		}
+	}
+	aliases, err := cdn.Advanced.Aliases.ToStringSlice()
+	if err != nil {
+		return cdnConfig{}, fmt.Errorf(`convert "http.cdn.aliases" to string slice: %w`, err)
+	}
+	config := cdnConfig{
+		settings: &template.CDNOpts{
+			CachePolicyID: cachePolicyID,
+			OriginShield:  aws.BoolValue(cdn.Advanced.OriginShield),
+			Aliases:       aliases,
+			PrefixListID:  s.cdnPrefixListID,
+		},
+	}
+	if len(aliases) == 0 {
+		return config, nil
+	}
+	certValidatorLambda, err := s.parser.Read(dnsCertValidatorPath)
+	if err != nil {
+		return cdnConfig{}, fmt.Errorf("read CDN certificate validator lambda: %w", err)
+	}
+	config.certValidatorLambda = certValidatorLambda.String()
+	return config, nil
+}
+
 func convertHTTPHealthCheck(hc *manifest.HealthCheckArgsOrString) template.HTTPHealthCheckOpts {
 	opts := template.HTTPHealthCheckOpts{
 		HealthCheckPath:    manifest.DefaultHealthCheckPath,
@@ -261,35 +445,17 @@ func (s *LoadBalancedWebService) convertNetworkLoadBalancer() (networkLoadBalanc
 		return networkLoadBalancerConfig{}, nil
 	}
 
-	// Parse listener port and protocol.
-	port, protocol, err := manifest.ParsePortMapping(nlbConfig.Port)
-	if err != nil {
-		return networkLoadBalancerConfig{}, err
-	}
-	if protocol == nil {
-		protocol = aws.String(defaultNLBProtocol)
-	}
-
-	// Configure target container and port.
-	targetContainer := s.name
-	if nlbConfig.TargetContainer != nil {
-		targetContainer = aws.StringValue(nlbConfig.TargetContainer)
-	}
-
-	// By default, the target port is the same as listener port.
-	targetPort := aws.StringValue(port)
-	if targetContainer != s.name {
-		// If the target container is a sidecar container, the target port is the exposed sidecar port.
-		sideCarPort := s.manifest.Sidecars[targetContainer].Port // We validated that a sidecar container exposes a port if it is a target container.
-		port, _, err := manifest.ParsePortMapping(sideCarPort)
+	var listeners []template.NetworkLoadBalancerListener
+	var certificateRequired bool
+	for _, mftListener := range nlbConfig.Listeners() {
+		listener, err := s.convertNetworkLoadBalancerListener(mftListener)
 		if err != nil {
 			return networkLoadBalancerConfig{}, err
 		}
-		targetPort = aws.StringValue(port)
-	}
-	// Finally, if a target port is explicitly specified, use that value.
-	if nlbConfig.TargetPort != nil {
-		targetPort = strconv.Itoa(aws.IntValue(nlbConfig.TargetPort))
+		if listener.Protocol == "TLS" {
+			certificateRequired = true
+		}
+		listeners = append(listeners, listener)
 	}
 
 	aliases, err := convertAlias(nlbConfig.Aliases)
@@ -297,33 +463,15 @@ func (s *LoadBalancedWebService) convertNetworkLoadBalancer() (networkLoadBalanc
 		return networkLoadBalancerConfig{}, fmt.Errorf(`convert "nlb.alias" to string slice: %w`, err)
 	}
 
-	hc := template.NLBHealthCheck{
-		HealthyThreshold:   nlbConfig.HealthCheck.HealthyThreshold,
-		UnhealthyThreshold: nlbConfig.HealthCheck.UnhealthyThreshold,
-	}
-	if nlbConfig.HealthCheck.Port != nil {
-		hc.Port = strconv.Itoa(aws.IntValue(nlbConfig.HealthCheck.Port))
-	}
-	if nlbConfig.HealthCheck.Timeout != nil {
-		hc.Timeout = aws.Int64(int64(nlbConfig.HealthCheck.Timeout.Seconds()))
-	}
-	if nlbConfig.HealthCheck.Interval != nil {
-		hc.Interval = aws.Int64(int64(nlbConfig.HealthCheck.Interval.Seconds()))
-	}
 	config := networkLoadBalancerConfig{
 		settings: &template.NetworkLoadBalancer{
-			PublicSubnetCIDRs: s.publicSubnetCIDRBlocks,
-			Listener: template.NetworkLoadBalancerListener{
-				Port:            aws.StringValue(port),
-				Protocol:        strings.ToUpper(aws.StringValue(protocol)),
-				TargetContainer: targetContainer,
-				TargetPort:      targetPort,
-				SSLPolicy:       nlbConfig.SSLPolicy,
-				Aliases:         aliases,
-				HealthCheck:     hc,
-				Stickiness:      nlbConfig.Stickiness,
-			},
-			MainContainerPort: s.containerPort(),
+			PublicSubnetCIDRs:   s.publicSubnetCIDRBlocks,
+			Listener:            listeners,
+			MainContainerPort:   s.containerPort(),
+			PortMappings:        s.nlbPortMappings(listeners),
+			CertificateRequired: certificateRequired,
+			Aliases:             aliases,
+			CrossZone:           nlbConfig.CrossZone,
 		},
 	}
 
@@ -346,6 +494,100 @@ func (s *LoadBalancedWebService) convertNetworkLoadBalancer() (networkLoadBalanc
 	return config, nil
 }
 
+func (s *LoadBalancedWebService) convertNetworkLoadBalancerListener(nlbListener manifest.NetworkLoadBalancerListener) (template.NetworkLoadBalancerListener, error) {
+	// Parse listener port and protocol.
+	port, protocol, err := manifest.ParsePortMapping(nlbListener.Port)
+	if err != nil {
+		return template.NetworkLoadBalancerListener{}, err
+	}
+	if protocol == nil {
+		protocol = aws.String(defaultNLBProtocol)
+	}
+
+	// Configure target container and port.
+	targetContainer := s.name
+	if nlbListener.TargetContainer != nil {
+		targetContainer = aws.StringValue(nlbListener.TargetContainer)
+	}
+
+	// By default, the target port is the same as listener port.
+	targetPort := aws.StringValue(port)
+	if targetContainer != s.name {
+		// If the target container is a sidecar container, the target port is the exposed sidecar port.
+		sideCarPort := s.manifest.Sidecars[targetContainer].Port // We validated that a sidecar container exposes a port if it is a target container.
+		port, _, err := manifest.ParsePortMapping(sideCarPort)
+		if err != nil {
+			return template.NetworkLoadBalancerListener{}, err
+		}
+		targetPort = aws.StringValue(port)
+	}
+	// Finally, if a target port is explicitly specified, use that value.
+	if nlbListener.TargetPort != nil {
+		targetPort = strconv.Itoa(aws.IntValue(nlbListener.TargetPort))
+	}
+
+	hc := template.NLBHealthCheck{
+		HealthyThreshold:   nlbListener.HealthCheck.HealthyThreshold,
+		UnhealthyThreshold: nlbListener.HealthCheck.UnhealthyThreshold,
+	}
+	if nlbListener.HealthCheck.Port != nil {
+		hc.Port = strconv.Itoa(aws.IntValue(nlbListener.HealthCheck.Port))
+	}
+	if nlbListener.HealthCheck.Timeout != nil {
+		hc.Timeout = aws.Int64(int64(nlbListener.HealthCheck.Timeout.Seconds()))
+	}
+	if nlbListener.HealthCheck.Interval != nil {
+		hc.Interval = aws.Int64(int64(nlbListener.HealthCheck.Interval.Seconds()))
+	}
+	return template.NetworkLoadBalancerListener{
+		Port:             aws.StringValue(port),
+		Protocol:         strings.ToUpper(aws.StringValue(protocol)),
+		TargetContainer:  targetContainer,
+		TargetPort:       targetPort,
+		SSLPolicy:        nlbListener.SSLPolicy,
+		ALPNPolicy:       nlbListener.ALPNPolicy,
+		Stickiness:       nlbListener.Stickiness,
+		PreserveClientIP: nlbListener.PreserveClientIP,
+		HealthCheck:      hc,
+	}, nil
+}
+
+// nlbPortMappings returns the distinct ports, other than the TCP container port, that the main container
+// needs to expose for the listeners and health checks that route traffic to it.
+func (s *LoadBalancedWebService) nlbPortMappings(listeners []template.NetworkLoadBalancerListener) []template.NLBPortMapping {
+	var mappings []template.NLBPortMapping
+	seen := map[template.NLBPortMapping]bool{
+		{Port: s.containerPort(), Protocol: "tcp"}: true,
+	}
+	for _, listener := range listeners {
+		if listener.TargetContainer != s.name {
+			continue
+		}
+		candidates := make([]template.NLBPortMapping, 0, 3)
+		for _, protocol := range listener.TargetIPProtocols() {
+			candidates = append(candidates, template.NLBPortMapping{
+				Port:     listener.TargetPort,
+				Protocol: protocol,
+			})
+		}
+		if listener.HealthCheck.Port != "" {
+			// Health checks are always made over TCP.
+			candidates = append(candidates, template.NLBPortMapping{
+				Port:     listener.HealthCheck.Port,
+				Protocol: "tcp",
+			})
+		}
+		for _, mapping := range candidates {
+			if seen[mapping] {
+				continue
+			}
+			seen[mapping] = true
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings
+}
+
 func convertExecuteCommand(e *manifest.ExecuteCommand) *template.ExecuteCommandOpts {
 	if e.Config.IsEmpty() && !aws.BoolValue(e.Enable) {
 		return nil
@@ -587,6 +829,43 @@ func convertNetworkConfig(network manifest.NetworkConfig) template.NetworkOpts {
 	return opts
 }
 
+// convertServiceIngress converts the services allowed to reach the workload into template options.
+// If no ports are specified, traffic is allowed on the defaultPort.
+func convertServiceIngress(ingress manifest.ServiceIngress, defaultPort *uint16) *template.ServiceIngressOpts {
+	if len(ingress.From) == 0 {
+		return nil
+	}
+	ports := ingress.Ports
+	if len(ports) == 0 && defaultPort != nil {
+		ports = []uint16{aws.Uint16Value(defaultPort)}
+	}
+	return &template.ServiceIngressOpts{
+		FromServices: ingress.From,
+		Ports:        ports,
+	}
+}
+
+// convertServiceConnect converts the Service Connect configuration into template options.
+// The main container is only exposed to other services if it has a port.
+func convertServiceConnect(connect manifest.ServiceConnectBoolOrArgs, svc string, port *uint16) *template.ServiceConnectOpts {
+	if !connect.Enabled() {
+		return nil
+	}
+	if port == nil {
+		return &template.ServiceConnectOpts{}
+	}
+	alias := svc
+	if connect.Advanced.Alias != nil {
+		alias = aws.StringValue(connect.Advanced.Alias)
+	}
+	return &template.ServiceConnectOpts{
+		Server: &template.ServiceConnectServerOpts{
+			Name:  svc,
+			Alias: alias,
+		},
+	}
+}
+
 func convertRDWSNetworkConfig(network manifest.RequestDrivenWebServiceNetworkConfig) template.NetworkOpts {
 	opts := template.NetworkOpts{}
 	if network.IsEmpty() {
@@ -629,6 +908,25 @@ func convertDeploymentConfig(deploymentConfig manifest.DeploymentConfiguration)
 	return deployConfigs
 }
 
+// convertPreDeploy converts the manifest pre-deploy task into a format parsable by the templates pkg.
+func convertPreDeploy(task manifest.PreDeployTask) (*template.PreDeployOpts, error) {
+	if task.IsEmpty() {
+		return nil, nil
+	}
+	command, err := convertCommand(task.Command)
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultPreDeployTimeout
+	if task.Timeout != nil {
+		timeout = *task.Timeout
+	}
+	return &template.PreDeployOpts{
+		Command:          command,
+		TimeoutInSeconds: int(timeout.Seconds()),
+	}, nil
+}
+
 func convertCommand(command manifest.CommandOverride) ([]string, error) {
 	out, err := command.ToStringSlice()
 	if err != nil {
@@ -801,6 +1099,24 @@ func convertPlatform(platform manifest.PlatformArgsOrString) template.RuntimePla
 	}
 }
 
+// convertEC2 returns the options to run the tasks on the EC2 instances of the environment, or nil if the tasks run on Fargate.
+func convertEC2(platform manifest.PlatformArgsOrString) *template.EC2Opts {
+	if platform.LaunchType() != manifest.LaunchTypeEC2 {
+		return nil
+	}
+	opts := &template.EC2Opts{
+		NetworkMode:       template.NetworkModeAWSVPC,
+		PlacementStrategy: template.PlacementStrategySpread,
+	}
+	if platform.NetworkMode() == manifest.NetworkModeBridge {
+		opts.NetworkMode = template.NetworkModeBridge
+	}
+	if platform.PlacementStrategy() == manifest.PlacementStrategyBinpack {
+		opts.PlacementStrategy = template.PlacementStrategyBinpack
+	}
+	return opts
+}
+
 func convertHTTPVersion(protocolVersion *string) *string {
 	if protocolVersion == nil {
 		return nil
@@ -809,6 +1125,48 @@ func convertHTTPVersion(protocolVersion *string) *string {
 	return &pv
 }
 
+func convertEnvAddonsImports(in manifest.EnvAddonsImports) *template.EnvAddonsImportsOpts {
+	if in.IsEmpty() {
+		return nil
+	}
+	return &template.EnvAddonsImportsOpts{
+		VariableOutputs:      in.Variables,
+		SecretOutputs:        in.Secrets,
+		PolicyOutputs:        in.Policies,
+		SecurityGroupOutputs: in.SecurityGroups,
+	}
+}
+
+func convertPermissions(in []manifest.IAMPermission) []template.PermissionOpts {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]template.PermissionOpts, len(in))
+	for i, p := range in {
+		effect := "Allow"
+		if p.Effect != nil {
+			effect = aws.StringValue(p.Effect)
+		}
+		var conditions map[string]map[string][]string
+		if len(p.Conditions) != 0 {
+			conditions = make(map[string]map[string][]string, len(p.Conditions))
+			for operator, keys := range p.Conditions {
+				conditions[operator] = make(map[string][]string, len(keys))
+				for key, values := range keys {
+					conditions[operator][key] = values
+				}
+			}
+		}
+		out[i] = template.PermissionOpts{
+			Effect:     effect,
+			Actions:    p.Actions,
+			Resources:  p.Resources,
+			Conditions: conditions,
+		}
+	}
+	return out
+}
+
 func convertSecrets(secrets map[string]manifest.Secret) map[string]template.Secret {
 	if len(secrets) == 0 {
 		return nil
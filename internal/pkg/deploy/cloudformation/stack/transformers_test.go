@@ -28,6 +28,7 @@ func Test_convertSidecar(t *testing.T) {
 		inDependsOn       map[string]string
 		inImageOverride   manifest.ImageOverride
 		inHealthCheck     manifest.ContainerHealthCheck
+		inInitContainers  map[string]*manifest.InitContainerConfig
 		circDepContainers []string
 
 		wanted    *template.SidecarOpts
@@ -52,6 +53,31 @@ func Test_convertSidecar(t *testing.T) {
 				Essential:  aws.Bool(true),
 			},
 		},
+		"waits for init containers to succeed": {
+			inPort:      aws.String("2000"),
+			inEssential: true,
+			inDependsOn: map[string]string{
+				"fetch": "complete",
+			},
+			inInitContainers: map[string]*manifest.InitContainerConfig{
+				"fetch":   {},
+				"migrate": {},
+			},
+
+			wanted: &template.SidecarOpts{
+				Name:       aws.String("foo"),
+				Port:       aws.String("2000"),
+				CredsParam: mockCredsParam,
+				Image:      mockImage,
+				Secrets:    mockSecrets,
+				Variables:  mockMap,
+				Essential:  aws.Bool(true),
+				DependsOn: map[string]string{
+					"fetch":   "COMPLETE",
+					"migrate": "SUCCESS",
+				},
+			},
+		},
 		"good port with protocol": {
 			inPort:      aws.String("2000/udp"),
 			inEssential: true,
@@ -221,7 +247,7 @@ func Test_convertSidecar(t *testing.T) {
 					HealthCheck:   tc.inHealthCheck,
 				},
 			}
-			got, err := convertSidecar(sidecar)
+			got, err := convertSidecar(sidecar, tc.inInitContainers)
 
 			if tc.wantedErr != nil {
 				require.EqualError(t, err, tc.wantedErr.Error())
@@ -233,6 +259,186 @@ func Test_convertSidecar(t *testing.T) {
 	}
 }
 
+func Test_convertInitContainers(t *testing.T) {
+	testCases := map[string]struct {
+		in map[string]*manifest.InitContainerConfig
+
+		wanted    []*template.InitContainerOpts
+		wantedErr error
+	}{
+		"returns nil if there are no init containers": {},
+		"returns an error if the command cannot be parsed": {
+			in: map[string]*manifest.InitContainerConfig{
+				"migrate": {
+					Image: aws.String("migrate"),
+					ImageOverride: manifest.ImageOverride{
+						Command: manifest.CommandOverride{
+							String: aws.String(`./migrate "up`),
+						},
+					},
+				},
+			},
+			wantedErr: fmt.Errorf(`convert "command" to string slice: convert string into tokens using shell-style rules: EOF found when expecting closing quote`),
+		},
+		"converts init containers sorted by name": {
+			in: map[string]*manifest.InitContainerConfig{
+				"migrate": {
+					Image:      aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/migrate"),
+					CredsParam: aws.String("mockCredsParam"),
+					Variables: map[string]string{
+						"DB_NAME": "frontend",
+					},
+					Secrets: map[string]manifest.Secret{
+						"DB_PASSWORD": {},
+					},
+					MountPoints: []manifest.SidecarMountPoint{
+						{
+							SourceVolume: aws.String("config"),
+							MountPointOpts: manifest.MountPointOpts{
+								ContainerPath: aws.String("/etc/config"),
+							},
+						},
+					},
+					DependsOn: manifest.DependsOn{
+						"fetch": "success",
+					},
+					ImageOverride: manifest.ImageOverride{
+						Command: manifest.CommandOverride{
+							StringSlice: []string{"./migrate", "up"},
+						},
+					},
+				},
+				"fetch": {
+					Image: aws.String("public.ecr.aws/aws-cli/aws-cli"),
+				},
+			},
+			wanted: []*template.InitContainerOpts{
+				{
+					Name:  aws.String("fetch"),
+					Image: aws.String("public.ecr.aws/aws-cli/aws-cli"),
+				},
+				{
+					Name:       aws.String("migrate"),
+					Image:      aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/migrate"),
+					CredsParam: aws.String("mockCredsParam"),
+					Variables: map[string]string{
+						"DB_NAME": "frontend",
+					},
+					Secrets: map[string]template.Secret{
+						"DB_PASSWORD": template.SecretFromSSMOrARN(""),
+					},
+					Storage: template.SidecarStorageOpts{
+						MountPoints: []*template.MountPoint{
+							{
+								SourceVolume:  aws.String("config"),
+								ContainerPath: aws.String("/etc/config"),
+								ReadOnly:      aws.Bool(true),
+							},
+						},
+					},
+					DependsOn: map[string]string{
+						"fetch": "SUCCESS",
+					},
+					Command: []string{"./migrate", "up"},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertInitContainers(tc.in)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func Test_convertDependsOnWithInitContainers(t *testing.T) {
+	testCases := map[string]struct {
+		inDependsOn      manifest.DependsOn
+		inInitContainers map[string]*manifest.InitContainerConfig
+
+		wanted map[string]string
+	}{
+		"returns nil without dependencies or init containers": {},
+		"keeps dependencies if there are no init containers": {
+			inDependsOn: manifest.DependsOn{
+				"nginx": "start",
+			},
+			wanted: map[string]string{
+				"nginx": "START",
+			},
+		},
+		"waits for every init container to succeed unless set explicitly": {
+			inDependsOn: manifest.DependsOn{
+				"nginx":   "start",
+				"migrate": "complete",
+			},
+			inInitContainers: map[string]*manifest.InitContainerConfig{
+				"fetch":   {},
+				"migrate": {},
+			},
+			wanted: map[string]string{
+				"nginx":   "START",
+				"fetch":   "SUCCESS",
+				"migrate": "COMPLETE",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertDependsOnWithInitContainers(tc.inDependsOn, tc.inInitContainers))
+		})
+	}
+}
+
+func Test_convertPreDeploy(t *testing.T) {
+	twoMinutes := 2 * time.Minute
+	testCases := map[string]struct {
+		in manifest.PreDeployTask
+
+		wanted *template.PreDeployOpts
+	}{
+		"returns nil if the pre-deploy task is not configured": {},
+		"uses the default timeout": {
+			in: manifest.PreDeployTask{
+				Command: manifest.CommandOverride{
+					String: aws.String("./migrate up"),
+				},
+			},
+			wanted: &template.PreDeployOpts{
+				Command:          []string{"./migrate", "up"},
+				TimeoutInSeconds: 600,
+			},
+		},
+		"converts the timeout to seconds": {
+			in: manifest.PreDeployTask{
+				Command: manifest.CommandOverride{
+					StringSlice: []string{"bin/rails", "db:migrate"},
+				},
+				Timeout: &twoMinutes,
+			},
+			wanted: &template.PreDeployOpts{
+				Command:          []string{"bin/rails", "db:migrate"},
+				TimeoutInSeconds: 120,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertPreDeploy(tc.in)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
 func Test_convertAdvancedCount(t *testing.T) {
 	mockRange := manifest.IntRangeBand("1-10")
 	mockPerc := manifest.Percentage(70)
@@ -498,6 +704,108 @@ func Test_convertAutoscaling(t *testing.T) {
 				},
 			},
 		},
+		"success with custom metrics and schedules": {
+			input: manifest.AdvancedCount{
+				Range: manifest.Range{
+					Value: &mockRange,
+				},
+				CustomMetrics: []manifest.CustomMetricScaling{
+					{
+						CloudWatchMetric: manifest.CloudWatchMetric{
+							Namespace:  aws.String("MyApp"),
+							MetricName: aws.String("ActiveConnections"),
+							Dimensions: map[string]string{
+								"Service": "api",
+							},
+						},
+						Target: aws.Float64(100),
+					},
+					{
+						Expression: aws.String("m1 / m2"),
+						Metrics: []manifest.CloudWatchMetricQuery{
+							{
+								ID: aws.String("m1"),
+								CloudWatchMetric: manifest.CloudWatchMetric{
+									Namespace:  aws.String("MyApp"),
+									MetricName: aws.String("Errors"),
+									Statistic:  aws.String("Sum"),
+								},
+							},
+						},
+						Target: aws.Float64(0.5),
+					},
+				},
+				Schedules: []manifest.ScheduledScaling{
+					{
+						Cron:     aws.String("0 9 * * 1-5"),
+						TimeZone: aws.String("America/New_York"),
+						Min:      aws.Int(20),
+					},
+					{
+						Cron: aws.String("cron(0 18 ? * MON-FRI *)"),
+						Min:  aws.Int(1),
+						Max:  aws.Int(50),
+					},
+				},
+			},
+			wanted: &template.AutoscalingOpts{
+				MaxCapacity: aws.Int(100),
+				MinCapacity: aws.Int(1),
+				CustomMetrics: []template.AutoscalingCustomMetricOpts{
+					{
+						CloudWatchMetricOpts: template.CloudWatchMetricOpts{
+							Namespace:  "MyApp",
+							MetricName: "ActiveConnections",
+							Dimensions: map[string]string{
+								"Service": "api",
+							},
+							Statistic: "Average",
+						},
+						Target: 100,
+					},
+					{
+						Expression: "m1 / m2",
+						Metrics: []template.CloudWatchMetricQueryOpts{
+							{
+								ID: "m1",
+								CloudWatchMetricOpts: template.CloudWatchMetricOpts{
+									Namespace:  "MyApp",
+									MetricName: "Errors",
+									Statistic:  "Sum",
+								},
+							},
+						},
+						Target: 0.5,
+					},
+				},
+				Schedules: []template.AutoscalingScheduleOpts{
+					{
+						Schedule:    "cron(0 9 ? * 2-6 *)",
+						TimeZone:    "America/New_York",
+						MinCapacity: aws.Int(20),
+					},
+					{
+						Schedule:    "cron(0 18 ? * MON-FRI *)",
+						MinCapacity: aws.Int(1),
+						MaxCapacity: aws.Int(50),
+					},
+				},
+			},
+		},
+		"invalid schedule": {
+			input: manifest.AdvancedCount{
+				Range: manifest.Range{
+					Value: &mockRange,
+				},
+				Schedules: []manifest.ScheduledScaling{
+					{
+						Cron: aws.String("every weekday"),
+						Min:  aws.Int(20),
+					},
+				},
+			},
+			wantedErr: fmt.Errorf(`convert "schedules[0]": schedule is not valid cron, rate, or preset: expected exactly 5 fields, found 2: [every weekday]`),
+		},
 		"returns nil if spot specified": {
 			input: manifest.AdvancedCount{
 				Spot: aws.Int(5),
@@ -1456,6 +1764,49 @@ func Test_convertPlatform(t *testing.T) {
 	}
 }
 
+func Test_convertEC2(t *testing.T) {
+	testCases := map[string]struct {
+		in  manifest.PlatformArgsOrString
+		out *template.EC2Opts
+	}{
+		"should return nil if the tasks run on Fargate": {
+			in: manifest.PlatformArgsOrString{
+				PlatformString: (*manifest.PlatformString)(aws.String("linux/arm64")),
+			},
+		},
+		"should default to awsvpc and spread on EC2": {
+			in: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					LaunchType: aws.String("EC2"),
+				},
+			},
+			out: &template.EC2Opts{
+				NetworkMode:       template.NetworkModeAWSVPC,
+				PlacementStrategy: template.PlacementStrategySpread,
+			},
+		},
+		"should return bridge and binpack": {
+			in: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					LaunchType:        aws.String("ec2"),
+					NetworkMode:       aws.String("bridge"),
+					PlacementStrategy: aws.String("binpack"),
+				},
+			},
+			out: &template.EC2Opts{
+				NetworkMode:       template.NetworkModeBridge,
+				PlacementStrategy: template.PlacementStrategyBinpack,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.out, convertEC2(tc.in))
+		})
+	}
+}
+
 func Test_convertHTTPVersion(t *testing.T) {
 	testCases := map[string]struct {
 		in     *string
@@ -1473,3 +1824,247 @@ func Test_convertHTTPVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertRedirectAction(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.RedirectAction
+		wanted *template.ALBRedirectAction
+	}{
+		"should default to a permanent redirect": {
+			in: manifest.RedirectAction{
+				Path: aws.String("/new"),
+			},
+			wanted: &template.ALBRedirectAction{
+				Path:       "/new",
+				StatusCode: "HTTP_301",
+			},
+		},
+		"should convert all fields": {
+			in: manifest.RedirectAction{
+				Protocol:   aws.String("HTTPS"),
+				Host:       aws.String("example.com"),
+				Port:       aws.String("443"),
+				Path:       aws.String("/#{path}"),
+				Query:      aws.String("#{query}"),
+				StatusCode: aws.Int(302),
+			},
+			wanted: &template.ALBRedirectAction{
+				Protocol:   "HTTPS",
+				Host:       "example.com",
+				Port:       "443",
+				Path:       "/#{path}",
+				Query:      "#{query}",
+				StatusCode: "HTTP_302",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertRedirectAction(tc.in))
+		})
+	}
+}
+
+func Test_convertWAF(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.WebACLArgsOrARN
+		wanted *template.WAFOpts
+	}{
+		"should return nil if waf is not configured": {},
+		"should convert an existing web ACL": {
+			in: manifest.WebACLArgsOrARN{
+				ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3"),
+			},
+			wanted: &template.WAFOpts{
+				WebACLARN: "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3",
+			},
+		},
+		"should convert managed rules and a rate limit": {
+			in: manifest.WebACLArgsOrARN{
+				Advanced: manifest.WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+					RateLimit:    aws.Int(2000),
+				},
+			},
+			wanted: &template.WAFOpts{
+				ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+				RateLimit:    2000,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertWAF(tc.in))
+		})
+	}
+}
+
+func Test_convertEnvAddonsImports(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.EnvAddonsImports
+		wanted *template.EnvAddonsImportsOpts
+	}{
+		"should return nil if the workload doesn't import any outputs": {},
+		"should return the outputs by how they're injected": {
+			in: manifest.EnvAddonsImports{
+				Variables:      []string{"UsersTableName"},
+				Secrets:        []string{"AuroraSecret"},
+				Policies:       []string{"UsersAccessPolicy"},
+				SecurityGroups: []string{"AuroraSecurityGroup"},
+			},
+			wanted: &template.EnvAddonsImportsOpts{
+				VariableOutputs:      []string{"UsersTableName"},
+				SecretOutputs:        []string{"AuroraSecret"},
+				PolicyOutputs:        []string{"UsersAccessPolicy"},
+				SecurityGroupOutputs: []string{"AuroraSecurityGroup"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertEnvAddonsImports(tc.in))
+		})
+	}
+}
+
+func Test_convertPermissions(t *testing.T) {
+	testCases := map[string]struct {
+		in     []manifest.IAMPermission
+		wanted []template.PermissionOpts
+	}{
+		"should return nil if there are no permissions": {},
+		"should default the effect to Allow": {
+			in: []manifest.IAMPermission{
+				{
+					Actions:   []string{"s3:GetObject"},
+					Resources: []string{"arn:aws:s3:::my-bucket/*"},
+				},
+				{
+					Effect:    aws.String("Deny"),
+					Actions:   []string{"s3:DeleteObject"},
+					Resources: []string{"*"},
+					Conditions: map[string]map[string]manifest.IAMConditionValues{
+						"StringEquals": {
+							"aws:PrincipalTag/team": {"payments"},
+						},
+					},
+				},
+			},
+			wanted: []template.PermissionOpts{
+				{
+					Effect:    "Allow",
+					Actions:   []string{"s3:GetObject"},
+					Resources: []string{"arn:aws:s3:::my-bucket/*"},
+				},
+				{
+					Effect:    "Deny",
+					Actions:   []string{"s3:DeleteObject"},
+					Resources: []string{"*"},
+					Conditions: map[string]map[string][]string{
+						"StringEquals": {
+							"aws:PrincipalTag/team": {"payments"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertPermissions(tc.in))
+		})
+	}
+}
+
+func Test_convertServiceIngress(t *testing.T) {
+	testCases := map[string]struct {
+		in          manifest.ServiceIngress
+		defaultPort *uint16
+		wanted      *template.ServiceIngressOpts
+	}{
+		"should return nil if no services are allowed": {
+			defaultPort: aws.Uint16(80),
+		},
+		"should default the ports to the container port": {
+			in: manifest.ServiceIngress{
+				From: []string{"frontend"},
+			},
+			defaultPort: aws.Uint16(8080),
+			wanted: &template.ServiceIngressOpts{
+				FromServices: []string{"frontend"},
+				Ports:        []uint16{8080},
+			},
+		},
+		"should use the ports specified in the manifest": {
+			in: manifest.ServiceIngress{
+				From:  []string{"frontend", "api"},
+				Ports: []uint16{80, 443},
+			},
+			defaultPort: aws.Uint16(8080),
+			wanted: &template.ServiceIngressOpts{
+				FromServices: []string{"frontend", "api"},
+				Ports:        []uint16{80, 443},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertServiceIngress(tc.in, tc.defaultPort))
+		})
+	}
+}
+
+func Test_convertServiceConnect(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.ServiceConnectBoolOrArgs
+		port   *uint16
+		wanted *template.ServiceConnectOpts
+	}{
+		"should return nil if service connect is not configured": {
+			port: aws.Uint16(80),
+		},
+		"should return nil if service connect is disabled": {
+			in: manifest.ServiceConnectBoolOrArgs{
+				Enable: aws.Bool(false),
+			},
+			port: aws.Uint16(80),
+		},
+		"should only configure a client if the service does not expose a port": {
+			in: manifest.ServiceConnectBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			wanted: &template.ServiceConnectOpts{},
+		},
+		"should default the alias to the service name": {
+			in: manifest.ServiceConnectBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			port: aws.Uint16(80),
+			wanted: &template.ServiceConnectOpts{
+				Server: &template.ServiceConnectServerOpts{
+					Name:  "api",
+					Alias: "api",
+				},
+			},
+		},
+		"should use the alias from the manifest": {
+			in: manifest.ServiceConnectBoolOrArgs{
+				Advanced: manifest.ServiceConnectArgs{
+					Alias: aws.String("backend"),
+				},
+			},
+			port: aws.Uint16(80),
+			wanted: &template.ServiceConnectOpts{
+				Server: &template.ServiceConnectServerOpts{
+					Name:  "api",
+					Alias: "backend",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertServiceConnect(tc.in, "api", tc.port))
+		})
+	}
+}
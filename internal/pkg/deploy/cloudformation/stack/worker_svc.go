@@ -83,10 +83,14 @@ func (s *WorkerService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	sidecars, err := convertSidecar(s.manifest.Sidecars)
+	sidecars, err := convertSidecar(s.manifest.Sidecars, s.manifest.InitContainers)
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for service %s: %w", s.name, err)
 	}
+	initContainers, err := convertInitContainers(s.manifest.InitContainers)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for service %s: %w", s.name, err)
+	}
 	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount)
 	if err != nil {
 		return "", fmt.Errorf("convert the advanced count configuration for service %s: %w", s.name, err)
@@ -110,6 +114,18 @@ func (s *WorkerService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	preDeploy, err := convertPreDeploy(s.manifest.DeployConfig.PreDeploy)
+	if err != nil {
+		return "", fmt.Errorf(`convert "deployment.pre_deploy" for service %s: %w`, s.name, err)
+	}
+	var preDeployLambda string
+	if preDeploy != nil {
+		content, err := s.parser.Read(preDeployTaskPath)
+		if err != nil {
+			return "", fmt.Errorf("read pre-deploy task lambda: %w", err)
+		}
+		preDeployLambda = content.String()
+	}
 	subscribe, err := convertSubscribe(s.manifest.Subscribe)
 	if err != nil {
 		return "", err
@@ -118,12 +134,18 @@ func (s *WorkerService) Template() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
 	}
+	network := convertNetworkConfig(s.manifest.Network)
+	network.Ingress = convertServiceIngress(s.manifest.Network.VPC.Ingress, nil)
+	network.IngressSource = s.rc.IngressSource
 	content, err := s.parser.ParseWorkerService(template.WorkloadOpts{
 		Variables:                      s.manifest.WorkerServiceConfig.Variables,
 		Secrets:                        convertSecrets(s.manifest.WorkerServiceConfig.Secrets),
 		NestedStack:                    addonsOutputs,
 		AddonsExtraParams:              addonsParams,
+		EnvAddons:                      convertEnvAddonsImports(s.manifest.EnvAddons),
+		Permissions:                    convertPermissions(s.manifest.Permissions),
 		Sidecars:                       sidecars,
+		InitContainers:                 initContainers,
 		Autoscaling:                    autoscaling,
 		CapacityProviders:              capacityProviders,
 		DesiredCountOnSpot:             desiredCountOnSpot,
@@ -136,16 +158,20 @@ func (s *WorkerService) Template() (string, error) {
 		EnvControllerLambda:            envControllerLambda.String(),
 		BacklogPerTaskCalculatorLambda: backlogPerTaskLambda.String(),
 		Storage:                        convertStorageOpts(s.manifest.Name, s.manifest.Storage),
-		Network:                        convertNetworkConfig(s.manifest.Network),
+		Network:                        network,
 		DeploymentConfiguration:        convertDeploymentConfig(s.manifest.DeployConfig),
+		PreDeploy:                      preDeploy,
+		PreDeployLambda:                preDeployLambda,
 		EntryPoint:                     entrypoint,
 		Command:                        command,
-		DependsOn:                      convertDependsOn(s.manifest.ImageConfig.Image.DependsOn),
+		DependsOn:                      convertDependsOnWithInitContainers(s.manifest.ImageConfig.Image.DependsOn, s.manifest.InitContainers),
 		CredentialsParameter:           aws.StringValue(s.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint:       s.rc.ServiceDiscoveryEndpoint,
+		ServiceConnect:                 convertServiceConnect(s.manifest.Network.Connect, s.name, nil),
 		Subscribe:                      subscribe,
 		Publish:                        publishers,
 		Platform:                       convertPlatform(s.manifest.Platform),
+		EC2:                            convertEC2(s.manifest.Platform),
 		Observability: template.ObservabilityOpts{
 			Tracing: strings.ToUpper(aws.StringValue(s.manifest.Observability.Tracing)),
 		},
@@ -55,6 +55,12 @@ const (
 	RDWkldHealthCheckUnhealthyThresholdParamKey = "HealthCheckUnhealthyThreshold"
 )
 
+// Parameter logical IDs for static sites.
+const (
+	StaticSiteAssetMappingFileBucketParamKey = "AssetMappingFileBucket"
+	StaticSiteAssetMappingFileKeyParamKey    = "AssetMappingFileKey"
+)
+
 const (
 	ecsWkldLogRetentionDefault = 30
 )
@@ -62,15 +68,17 @@ const (
 // RuntimeConfig represents configuration that's defined outside of the manifest file
 // that is needed to create a CloudFormation stack.
 type RuntimeConfig struct {
-	Image             *ECRImage         // Optional. Image location in an ECR repository.
-	AddonsTemplateURL string            // Optional. S3 object URL for the addons template.
-	EnvFileARN        string            // Optional. S3 object ARN for the env file.
-	AdditionalTags    map[string]string // AdditionalTags are labels applied to resources in the workload stack.
+	Image                     *ECRImage         // Optional. Image location in an ECR repository.
+	AddonsTemplateURL         string            // Optional. S3 object URL for the addons template.
+	EnvFileARN                string            // Optional. S3 object ARN for the env file.
+	StaticSiteAssetMappingURL string            // Optional. S3 object URL for the file that maps the static site assets to their path in the site bucket.
+	AdditionalTags            map[string]string // AdditionalTags are labels applied to resources in the workload stack.
 
 	// The target environment metadata.
 	ServiceDiscoveryEndpoint string // Endpoint for the service discovery namespace in the environment.
 	AccountID                string
 	Region                   string
+	IngressSource            bool // Whether other services in the environment accept traffic from the workload.
 }
 
 // ECRImage represents configuration about the pushed ECR image that is needed to
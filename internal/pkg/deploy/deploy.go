@@ -28,6 +28,10 @@ const (
 	PipelineTagKey = "copilot-pipeline"
 	// TaskTagKey is tag key for Copilot task.
 	TaskTagKey = "copilot-task"
+	// ImageDigestTagKey is tag key for the image digest deployed by a Copilot service stack.
+	ImageDigestTagKey = "copilot-image-digest"
+	// PromotedFromTagKey is tag key for the Copilot env that a service's image was promoted from.
+	PromotedFromTagKey = "copilot-promoted-from"
 )
 
 const (
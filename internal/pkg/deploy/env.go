@@ -13,7 +13,7 @@ const (
 	// LegacyEnvTemplateVersion is the version associated with the environment template before we started versioning.
 	LegacyEnvTemplateVersion = "v0.0.0"
 	// LatestEnvTemplateVersion is the latest version number available for environment templates.
-	LatestEnvTemplateVersion = "v1.9.0"
+	LatestEnvTemplateVersion = "v1.17.0"
 )
 
 // CreateEnvironmentInput holds the fields required to deploy an environment.
@@ -21,21 +21,33 @@ type CreateEnvironmentInput struct {
 	// The version of the environment template to create the stack. If empty, creates the legacy stack.
 	Version string
 
-	App                  AppInformation    // Information about the application that the environment belongs to, include app name, DNS name, the principal ARN of the account.
-	Name                 string            // Name of the environment, must be unique within an application.
-	Prod                 bool              // Whether or not this environment is a production environment.
-	AdditionalTags       map[string]string // AdditionalTags are labels applied to resources under the application.
-	ArtifactBucketARN    string            // ARN of the regional application bucket.
-	ArtifactBucketKeyARN string            // ARN of the KMS key used to encrypt the contents in the regional application bucket.
-	CustomResourcesURLs  map[string]string // Environment custom resource script S3 object URLs.
-	ImportVPCConfig      *config.ImportVPC // Optional configuration if users have an existing VPC.
-	AdjustVPCConfig      *config.AdjustVPC // Optional configuration if users want to override default VPC configuration.
-	ImportCertARNs       []string          // Optional configuration if users want to import certificates.
-	Telemetry            *config.Telemetry // Optional observability and monitoring configuration.
+	App                  AppInformation      // Information about the application that the environment belongs to, include app name, DNS name, the principal ARN of the account.
+	Name                 string              // Name of the environment, must be unique within an application.
+	Prod                 bool                // Whether or not this environment is a production environment.
+	AdditionalTags       map[string]string   // AdditionalTags are labels applied to resources under the application.
+	ArtifactBucketARN    string              // ARN of the regional application bucket.
+	ArtifactBucketKeyARN string              // ARN of the KMS key used to encrypt the contents in the regional application bucket.
+	CustomResourcesURLs  map[string]string   // Environment custom resource script S3 object URLs.
+	ImportVPCConfig      *config.ImportVPC   // Optional configuration if users have an existing VPC.
+	AdjustVPCConfig      *config.AdjustVPC   // Optional configuration if users want to override default VPC configuration.
+	ImportCertARNs       []string            // Optional configuration if users want to import certificates.
+	InternalCertARNs     []string            // Optional configuration if users want to import certificates for the internal load balancer.
+	WAF                  *config.WAF         // Optional web ACL associated with the public load balancer.
+	EC2Capacity          *config.EC2Capacity // Optional EC2 instances that the cluster can place tasks on.
+	Telemetry            *config.Telemetry   // Optional observability and monitoring configuration.
+	Addons               *EnvAddons          // Optional addons shared by all workloads in the environment.
 
 	CFNServiceRoleARN string // Optional. A service role ARN that CloudFormation should use to make calls to resources in the stack.
 }
 
+// EnvAddons holds the addons nested stack of an environment.
+// An EnvAddons without a URL removes the addons stack from the environment.
+type EnvAddons struct {
+	URL         string   // S3 object URL of the addons template.
+	ExtraParams string   // Additional user defined Parameters for the addons stack.
+	Outputs     []string // Outputs of the addons stack to export for workloads to import.
+}
+
 // CreateEnvironmentResponse holds the created environment on successful deployment.
 // Otherwise, the environment is set to nil and a descriptive error is returned.
 type CreateEnvironmentResponse struct {
@@ -13,6 +13,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/graph"
 
@@ -35,6 +36,8 @@ const (
 	defaultPipelineEnvironmentType = "LINUX_CONTAINER"
 
 	defaultPipelineArtifactsDir = "infrastructure"
+
+	defaultPreviewTTL = 72 * time.Hour
 )
 
 var (
@@ -74,6 +77,10 @@ type CreatePipelineInput struct {
 
 	// AdditionalTags are labels applied to resources under the application.
 	AdditionalTags map[string]string
+
+	// Preview holds the settings for short-lived per-branch environments.
+	// It is nil if the pipeline doesn't deploy preview environments.
+	Preview *PreviewEnvironments
 }
 
 // Build represents CodeBuild project used in the CodePipeline
@@ -104,6 +111,74 @@ func (b *Build) Init(mfBuild *manifest.Build, mfDirPath string) {
 	b.BuildspecPath = path
 }
 
+// PreviewEnvironments represents the CodeBuild project that creates an environment for each matching branch,
+// deploys all workloads to it, and tears it down once the branch is deleted or the environment expires.
+type PreviewEnvironments struct {
+	// Glob patterns of the branches that get a preview environment, e.g. "feature/*".
+	Branches []string
+	// How long a preview environment can live before it's deleted.
+	TTL time.Duration
+	// URL to download the copilot linux binary from.
+	BinaryURL string
+}
+
+// Init populates the fields in PreviewEnvironments by parsing the manifest file's "preview" section.
+func (p *PreviewEnvironments) Init(mfPreview *manifest.Preview, binaryURL string) {
+	ttl := defaultPreviewTTL
+	if mfPreview.TTL != nil {
+		ttl = *mfPreview.TTL
+	}
+	p.Branches = mfPreview.Branches
+	p.TTL = ttl
+	p.BinaryURL = binaryURL
+}
+
+// BranchPatterns returns the branch glob patterns as a single space-separated string.
+func (p *PreviewEnvironments) BranchPatterns() string {
+	return strings.Join(p.Branches, " ")
+}
+
+// HeadRefFilter returns a regular expression that matches the git references of all preview branches.
+// The expression can be used as a "HEAD_REF" webhook filter.
+func (p *PreviewEnvironments) HeadRefFilter() string {
+	var exprs []string
+	for _, branch := range p.Branches {
+		exprs = append(exprs, globToRegexp(branch))
+	}
+	return fmt.Sprintf("^refs/heads/(%s)$", strings.Join(exprs, "|"))
+}
+
+// TTLInSeconds returns the lifetime of a preview environment in seconds.
+func (p *PreviewEnvironments) TTLInSeconds() int {
+	return int(p.TTL.Seconds())
+}
+
+// globToRegexp converts a glob pattern to a regular expression.
+// Like bash pattern matching, which the buildspec uses to select branches, wildcards also match "/".
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	inClass := false
+	for _, r := range glob {
+		switch {
+		case inClass:
+			if r == ']' {
+				inClass = false
+			}
+			sb.WriteRune(r)
+		case r == '*':
+			sb.WriteString(".*")
+		case r == '?':
+			sb.WriteString(".")
+		case r == '[':
+			inClass = true
+			sb.WriteRune(r)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return sb.String()
+}
+
 // ArtifactBucket represents an S3 bucket used by the CodePipeline to store
 // intermediate artifacts produced by the pipeline.
 type ArtifactBucket struct {
@@ -6,6 +6,7 @@ package deploy
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/config"
 
@@ -267,6 +268,71 @@ func TestPipelineBuild_Init(t *testing.T) {
 	}
 }
 
+func TestPreviewEnvironments_Init(t *testing.T) {
+	sixHours := 6 * time.Hour
+	testCases := map[string]struct {
+		mfPreview       *manifest.Preview
+		expectedPreview PreviewEnvironments
+	}{
+		"set default ttl if not specified in manifest": {
+			mfPreview: &manifest.Preview{
+				Branches: []string{"feature/*"},
+			},
+			expectedPreview: PreviewEnvironments{
+				Branches:  []string{"feature/*"},
+				TTL:       72 * time.Hour,
+				BinaryURL: "https://copilot/copilot-linux-v1.0.0",
+			},
+		},
+		"set ttl according to manifest": {
+			mfPreview: &manifest.Preview{
+				Branches: []string{"feature/*", "fix/*"},
+				TTL:      &sixHours,
+			},
+			expectedPreview: PreviewEnvironments{
+				Branches:  []string{"feature/*", "fix/*"},
+				TTL:       6 * time.Hour,
+				BinaryURL: "https://copilot/copilot-linux-v1.0.0",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var preview PreviewEnvironments
+			preview.Init(tc.mfPreview, "https://copilot/copilot-linux-v1.0.0")
+			require.Equal(t, tc.expectedPreview, preview)
+		})
+	}
+}
+
+func TestPreviewEnvironments_HeadRefFilter(t *testing.T) {
+	testCases := map[string]struct {
+		branches []string
+		wanted   string
+	}{
+		"single pattern": {
+			branches: []string{"feature/*"},
+			wanted:   `^refs/heads/(feature/.*)$`,
+		},
+		"escapes regular expression metacharacters": {
+			branches: []string{"release-v1.?", "hotfix+*"},
+			wanted:   `^refs/heads/(release-v1\..|hotfix\+.*)$`,
+		},
+		"keeps character classes": {
+			branches: []string{"team-[ab]/*"},
+			wanted:   `^refs/heads/(team-[ab]/.*)$`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			preview := PreviewEnvironments{
+				Branches: tc.branches,
+			}
+			require.Equal(t, tc.wanted, preview.HeadRefFilter())
+		})
+	}
+}
+
 func TestParseOwnerAndRepo(t *testing.T) {
 	testCases := map[string]struct {
 		src            *GitHubSource
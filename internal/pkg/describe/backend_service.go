@@ -89,6 +89,7 @@ func (d *BackendServiceDescriber) Describe() (HumanJSONStringer, error) {
 
 	var configs []*ECSServiceConfig
 	var services []*ServiceDiscovery
+	var serviceConnects []*ServiceConnect
 	var envVars []*containerEnvVar
 	var secrets []*secret
 	for _, env := range environments {
@@ -116,6 +117,11 @@ func (d *BackendServiceDescriber) Describe() (HumanJSONStringer, error) {
 				Port:     port,
 				Endpoint: endpoint,
 			}, env)
+			svcOutputs, err := svcDescr.Outputs()
+			if err != nil {
+				return nil, fmt.Errorf("get stack outputs for service %s: %w", d.svc, err)
+			}
+			serviceConnects = appendServiceConnect(serviceConnects, svcOutputs[svcOutputServiceConnectEndpoint], env)
 		}
 		containerPlatform, err := svcDescr.Platform()
 		if err != nil {
@@ -164,6 +170,7 @@ func (d *BackendServiceDescriber) Describe() (HumanJSONStringer, error) {
 		App:              d.app,
 		Configurations:   configs,
 		ServiceDiscovery: services,
+		ServiceConnect:   serviceConnects,
 		Variables:        envVars,
 		Secrets:          secrets,
 		Resources:        resources,
@@ -179,6 +186,7 @@ type backendSvcDesc struct {
 	App              string               `json:"application"`
 	Configurations   ecsConfigurations    `json:"configurations"`
 	ServiceDiscovery serviceDiscoveries   `json:"serviceDiscovery"`
+	ServiceConnect   serviceConnects      `json:"serviceConnect,omitempty"`
 	Variables        containerEnvVars     `json:"variables"`
 	Secrets          secrets              `json:"secrets,omitempty"`
 	Resources        deployedSvcResources `json:"resources,omitempty"`
@@ -210,6 +218,11 @@ func (w *backendSvcDesc) HumanString() string {
 	fmt.Fprint(writer, color.Bold.Sprint("\nService Discovery\n\n"))
 	writer.Flush()
 	w.ServiceDiscovery.humanString(writer)
+	if len(w.ServiceConnect) != 0 {
+		fmt.Fprint(writer, color.Bold.Sprint("\nService Connect\n\n"))
+		writer.Flush()
+		w.ServiceConnect.humanString(writer)
+	}
 	fmt.Fprint(writer, color.Bold.Sprint("\nVariables\n\n"))
 	writer.Flush()
 	w.Variables.humanString(writer)
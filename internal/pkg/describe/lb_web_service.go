@@ -30,6 +30,7 @@ import (
 const (
 	envOutputPublicLoadBalancerDNSName = "PublicLoadBalancerDNSName"
 	envOutputSubdomain                 = "EnvironmentSubdomain"
+	envOutputWebACLArn                 = "WebACLArn"
 	svcParamHTTPSEnabled               = "HTTPSEnabled"
 
 	svcStackResourceALBTargetGroupLogicalID       = "TargetGroup"
@@ -37,6 +38,9 @@ const (
 	svcStackResourceHTTPSListenerRuleLogicalID    = "HTTPSListenerRule"
 	svcStackResourceHTTPSListenerRuleResourceType = "AWS::ElasticLoadBalancingV2::ListenerRule"
 	svcOutputPublicNLBDNSName                     = "PublicNetworkLoadBalancerDNSName"
+	svcOutputServiceConnectEndpoint               = "ServiceConnectEndpoint"
+	svcOutputWebACLArn                            = "WebACLArn"
+	svcOutputCloudFrontDistributionDomainName     = "CloudFrontDistributionDomainName"
 )
 
 type envDescriber interface {
@@ -127,6 +131,8 @@ func (d *LBWebServiceDescriber) Describe() (HumanJSONStringer, error) {
 	var routes []*WebServiceRoute
 	var configs []*ECSServiceConfig
 	var serviceDiscoveries []*ServiceDiscovery
+	var serviceConnects []*ServiceConnect
+	var webACLs []*WebACL
 	var envVars []*containerEnvVar
 	var secrets []*secret
 	for _, env := range environments {
@@ -177,6 +183,26 @@ func (d *LBWebServiceDescriber) Describe() (HumanJSONStringer, error) {
 			Port:     svcParams[cfnstack.LBWebServiceContainerPortParamKey],
 			Endpoint: endpoint,
 		}, env)
+		svcOutputs, err := svcDescr.Outputs()
+		if err != nil {
+			return nil, fmt.Errorf("get stack outputs for service %s: %w", d.svc, err)
+		}
+		if domain := svcOutputs[svcOutputCloudFrontDistributionDomainName]; domain != "" {
+			routes = append(routes, &WebServiceRoute{
+				Environment: env,
+				URL:         fmt.Sprintf("https://%s", domain),
+			})
+		}
+		serviceConnects = appendServiceConnect(serviceConnects, svcOutputs[svcOutputServiceConnectEndpoint], env)
+		webACLArn := svcOutputs[svcOutputWebACLArn]
+		if webACLArn == "" {
+			envOutputs, err := envDescr.Outputs()
+			if err != nil {
+				return nil, fmt.Errorf("get stack outputs for environment %s: %w", env, err)
+			}
+			webACLArn = envOutputs[envOutputWebACLArn]
+		}
+		webACLs = appendWebACL(webACLs, webACLArn, env)
 		envVars = append(envVars, flattenContainerEnvVars(env, webSvcEnvVars)...)
 		webSvcSecrets, err := svcDescr.Secrets()
 		if err != nil {
@@ -206,6 +232,8 @@ func (d *LBWebServiceDescriber) Describe() (HumanJSONStringer, error) {
 		Configurations:   configs,
 		Routes:           routes,
 		ServiceDiscovery: serviceDiscoveries,
+		ServiceConnect:   serviceConnects,
+		WAF:              webACLs,
 		Variables:        envVars,
 		Secrets:          secrets,
 		Resources:        resources,
@@ -293,6 +321,40 @@ func (s serviceDiscoveries) humanString(w io.Writer) {
 	}
 }
 
+// ServiceConnect contains serialized ECS Service Connect info for a service.
+type ServiceConnect struct {
+	Environment []string `json:"environment"`
+	Endpoint    string   `json:"endpoint"`
+}
+
+type serviceConnects []*ServiceConnect
+
+func (s serviceConnects) humanString(w io.Writer) {
+	headers := []string{"Environment", "Endpoint"}
+	fmt.Fprintf(w, "  %s\n", strings.Join(headers, "\t"))
+	fmt.Fprintf(w, "  %s\n", strings.Join(underline(headers), "\t"))
+	for _, sc := range s {
+		fmt.Fprintf(w, "  %s\t%s\n", strings.Join(sc.Environment, ", "), sc.Endpoint)
+	}
+}
+
+// WebACL contains serialized info of the AWS WAF web ACL that protects a service.
+type WebACL struct {
+	Environment []string `json:"environment"`
+	ARN         string   `json:"arn"`
+}
+
+type webACLs []*WebACL
+
+func (w webACLs) humanString(writer io.Writer) {
+	headers := []string{"Environment", "Web ACL"}
+	fmt.Fprintf(writer, "  %s\n", strings.Join(headers, "\t"))
+	fmt.Fprintf(writer, "  %s\n", strings.Join(underline(headers), "\t"))
+	for _, acl := range w {
+		fmt.Fprintf(writer, "  %s\t%s\n", strings.Join(acl.Environment, ", "), acl.ARN)
+	}
+}
+
 // webSvcDesc contains serialized parameters for a web service.
 type webSvcDesc struct {
 	Service          string               `json:"service"`
@@ -301,6 +363,8 @@ type webSvcDesc struct {
 	Configurations   ecsConfigurations    `json:"configurations"`
 	Routes           []*WebServiceRoute   `json:"routes"`
 	ServiceDiscovery serviceDiscoveries   `json:"serviceDiscovery"`
+	ServiceConnect   serviceConnects      `json:"serviceConnect,omitempty"`
+	WAF              webACLs              `json:"waf,omitempty"`
 	Variables        containerEnvVars     `json:"variables"`
 	Secrets          secrets              `json:"secrets,omitempty"`
 	Resources        deployedSvcResources `json:"resources,omitempty"`
@@ -340,6 +404,16 @@ func (w *webSvcDesc) HumanString() string {
 	fmt.Fprint(writer, color.Bold.Sprint("\nService Discovery\n\n"))
 	writer.Flush()
 	w.ServiceDiscovery.humanString(writer)
+	if len(w.ServiceConnect) != 0 {
+		fmt.Fprint(writer, color.Bold.Sprint("\nService Connect\n\n"))
+		writer.Flush()
+		w.ServiceConnect.humanString(writer)
+	}
+	if len(w.WAF) != 0 {
+		fmt.Fprint(writer, color.Bold.Sprint("\nWAF\n\n"))
+		writer.Flush()
+		w.WAF.humanString(writer)
+	}
 	fmt.Fprint(writer, color.Bold.Sprint("\nVariables\n\n"))
 	writer.Flush()
 	w.Variables.humanString(writer)
@@ -382,6 +456,40 @@ func IsStackNotExistsErr(err error) bool {
 	return true
 }
 
+// appendServiceConnect groups the environments that expose the same Service Connect endpoint.
+func appendServiceConnect(scs []*ServiceConnect, endpoint, env string) []*ServiceConnect {
+	if endpoint == "" {
+		return scs
+	}
+	for _, s := range scs {
+		if s.Endpoint == endpoint {
+			s.Environment = append(s.Environment, env)
+			return scs
+		}
+	}
+	return append(scs, &ServiceConnect{
+		Environment: []string{env},
+		Endpoint:    endpoint,
+	})
+}
+
+// appendWebACL groups the environments in which the service is protected by the same web ACL.
+func appendWebACL(acls []*WebACL, arn, env string) []*WebACL {
+	if arn == "" {
+		return acls
+	}
+	for _, acl := range acls {
+		if acl.ARN == arn {
+			acl.Environment = append(acl.Environment, env)
+			return acls
+		}
+	}
+	return append(acls, &WebACL{
+		Environment: []string{env},
+		ARN:         arn,
+	})
+}
+
 func appendServiceDiscovery(sds []*ServiceDiscovery, sd serviceDiscovery, env string) []*ServiceDiscovery {
 	exist := false
 	for _, s := range sds {
@@ -204,6 +204,8 @@ func TestLBWebServiceDescriber_Describe(t *testing.T) {
 					}, nil),
 					m.ecsDescriber.EXPECT().Params().Return(mockParams, nil),
 					m.envDescriber.EXPECT().ServiceDiscoveryEndpoint().Return("test.phonetool.local", nil),
+					m.ecsDescriber.EXPECT().Outputs().Return(map[string]string{}, nil),
+					m.envDescriber.EXPECT().Outputs().Return(map[string]string{}, nil),
 					m.ecsDescriber.EXPECT().Secrets().Return(nil, mockErr),
 				)
 			},
@@ -236,6 +238,8 @@ func TestLBWebServiceDescriber_Describe(t *testing.T) {
 					}, nil),
 					m.ecsDescriber.EXPECT().Params().Return(mockParams, nil),
 					m.envDescriber.EXPECT().ServiceDiscoveryEndpoint().Return("test.phonetool.local", nil),
+					m.ecsDescriber.EXPECT().Outputs().Return(map[string]string{}, nil),
+					m.envDescriber.EXPECT().Outputs().Return(map[string]string{}, nil),
 					m.ecsDescriber.EXPECT().Secrets().Return([]*ecs.ContainerSecret{
 						{
 							Name:      "GITHUB_WEBHOOK_SECRET",
@@ -280,6 +284,11 @@ func TestLBWebServiceDescriber_Describe(t *testing.T) {
 					}, nil),
 					m.ecsDescriber.EXPECT().Params().Return(mockParams, nil),
 					m.envDescriber.EXPECT().ServiceDiscoveryEndpoint().Return("test.phonetool.local", nil),
+					m.ecsDescriber.EXPECT().Outputs().Return(map[string]string{
+						svcOutputServiceConnectEndpoint:           "jobs:80",
+						svcOutputWebACLArn:                        "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/jobs/a1b2c3",
+						svcOutputCloudFrontDistributionDomainName: "d111111abcdef8.cloudfront.net",
+					}, nil),
 					m.ecsDescriber.EXPECT().Secrets().Return([]*ecs.ContainerSecret{
 						{
 							Name:      "GITHUB_WEBHOOK_SECRET",
@@ -309,6 +318,10 @@ func TestLBWebServiceDescriber_Describe(t *testing.T) {
 					}, nil),
 					m.ecsDescriber.EXPECT().Params().Return(mockProdParams, nil),
 					m.envDescriber.EXPECT().ServiceDiscoveryEndpoint().Return("prod.phonetool.local", nil),
+					m.ecsDescriber.EXPECT().Outputs().Return(map[string]string{}, nil),
+					m.envDescriber.EXPECT().Outputs().Return(map[string]string{
+						envOutputWebACLArn: "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/prod/d4e5f6",
+					}, nil),
 					m.ecsDescriber.EXPECT().Secrets().Return([]*ecs.ContainerSecret{
 						{
 							Name:      "SOME_OTHER_SECRET",
@@ -361,6 +374,10 @@ func TestLBWebServiceDescriber_Describe(t *testing.T) {
 						Environment: "test",
 						URL:         "http://abc.us-west-1.elb.amazonaws.com/*",
 					},
+					{
+						Environment: "test",
+						URL:         "https://d111111abcdef8.cloudfront.net",
+					},
 					{
 						Environment: "prod",
 						URL:         "http://abc.us-west-1.elb.amazonaws.com/*",
@@ -376,6 +393,22 @@ func TestLBWebServiceDescriber_Describe(t *testing.T) {
 						Namespace:   "jobs.prod.phonetool.local:5000",
 					},
 				},
+				ServiceConnect: []*ServiceConnect{
+					{
+						Environment: []string{"test"},
+						Endpoint:    "jobs:80",
+					},
+				},
+				WAF: []*WebACL{
+					{
+						Environment: []string{"test"},
+						ARN:         "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/jobs/a1b2c3",
+					},
+					{
+						Environment: []string{"prod"},
+						ARN:         "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/prod/d4e5f6",
+					},
+				},
 				Variables: []*containerEnvVar{
 					{
 						envVar: &envVar{
@@ -499,6 +532,12 @@ Service Discovery
   test         http://my-svc.test.my-app.local:5000
   prod         http://my-svc.prod.my-app.local:5000
 
+WAF
+
+  Environment  Web ACL
+  -----------  -------
+  test, prod   arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-svc/a1b2c3
+
 Variables
 
   Name                      Container   Environment  Value
@@ -522,7 +561,7 @@ Resources
   prod
     AWS::EC2::SecurityGroupIngress  ContainerSecurityGroupIngressFromPublicALB
 `,
-			wantedJSONString: "{\"service\":\"my-svc\",\"type\":\"Load Balanced Web Service\",\"application\":\"my-app\",\"configurations\":[{\"environment\":\"test\",\"port\":\"80\",\"cpu\":\"256\",\"memory\":\"512\",\"platform\":\"LINUX/X86_64\",\"tasks\":\"1\"},{\"environment\":\"prod\",\"port\":\"5000\",\"cpu\":\"512\",\"memory\":\"1024\",\"platform\":\"LINUX/ARM64\",\"tasks\":\"3\"}],\"routes\":[{\"environment\":\"test\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/frontend\"},{\"environment\":\"prod\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/backend\"}],\"serviceDiscovery\":[{\"environment\":[\"test\"],\"namespace\":\"http://my-svc.test.my-app.local:5000\"},{\"environment\":[\"prod\"],\"namespace\":\"http://my-svc.prod.my-app.local:5000\"}],\"variables\":[{\"environment\":\"test\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"test\",\"container\":\"containerA\"},{\"environment\":\"prod\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"prod\",\"container\":\"containerB\"},{\"environment\":\"prod\",\"name\":\"DIFFERENT_ENV_VAR\",\"value\":\"prod\",\"container\":\"containerB\"}],\"secrets\":[{\"name\":\"GITHUB_WEBHOOK_SECRET\",\"container\":\"containerA\",\"environment\":\"test\",\"valueFrom\":\"GH_WEBHOOK_SECRET\"},{\"name\":\"SOME_OTHER_SECRET\",\"container\":\"containerB\",\"environment\":\"prod\",\"valueFrom\":\"SHHHHH\"}],\"resources\":{\"prod\":[{\"type\":\"AWS::EC2::SecurityGroupIngress\",\"physicalID\":\"ContainerSecurityGroupIngressFromPublicALB\"}],\"test\":[{\"type\":\"AWS::EC2::SecurityGroup\",\"physicalID\":\"sg-0758ed6b233743530\"}]}}\n",
+			wantedJSONString: "{\"service\":\"my-svc\",\"type\":\"Load Balanced Web Service\",\"application\":\"my-app\",\"configurations\":[{\"environment\":\"test\",\"port\":\"80\",\"cpu\":\"256\",\"memory\":\"512\",\"platform\":\"LINUX/X86_64\",\"tasks\":\"1\"},{\"environment\":\"prod\",\"port\":\"5000\",\"cpu\":\"512\",\"memory\":\"1024\",\"platform\":\"LINUX/ARM64\",\"tasks\":\"3\"}],\"routes\":[{\"environment\":\"test\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/frontend\"},{\"environment\":\"prod\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/backend\"}],\"serviceDiscovery\":[{\"environment\":[\"test\"],\"namespace\":\"http://my-svc.test.my-app.local:5000\"},{\"environment\":[\"prod\"],\"namespace\":\"http://my-svc.prod.my-app.local:5000\"}],\"waf\":[{\"environment\":[\"test\",\"prod\"],\"arn\":\"arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-svc/a1b2c3\"}],\"variables\":[{\"environment\":\"test\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"test\",\"container\":\"containerA\"},{\"environment\":\"prod\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"prod\",\"container\":\"containerB\"},{\"environment\":\"prod\",\"name\":\"DIFFERENT_ENV_VAR\",\"value\":\"prod\",\"container\":\"containerB\"}],\"secrets\":[{\"name\":\"GITHUB_WEBHOOK_SECRET\",\"container\":\"containerA\",\"environment\":\"test\",\"valueFrom\":\"GH_WEBHOOK_SECRET\"},{\"name\":\"SOME_OTHER_SECRET\",\"container\":\"containerB\",\"environment\":\"prod\",\"valueFrom\":\"SHHHHH\"}],\"resources\":{\"prod\":[{\"type\":\"AWS::EC2::SecurityGroupIngress\",\"physicalID\":\"ContainerSecurityGroupIngressFromPublicALB\"}],\"test\":[{\"type\":\"AWS::EC2::SecurityGroup\",\"physicalID\":\"sg-0758ed6b233743530\"}]}}\n",
 		},
 	}
 
@@ -633,8 +672,14 @@ Resources
 				Secrets:          secrets,
 				Routes:           routes,
 				ServiceDiscovery: sds,
-				Resources:        resources,
-				environments:     []string{"test", "prod"},
+				WAF: []*WebACL{
+					{
+						Environment: []string{"test", "prod"},
+						ARN:         "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-svc/a1b2c3",
+					},
+				},
+				Resources:    resources,
+				environments: []string{"test", "prod"},
 			}
 			human := webSvc.HumanString()
 			json, _ := webSvc.JSONString()
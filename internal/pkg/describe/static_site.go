@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/copilot-cli/internal/pkg/describe/stack"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+)
+
+// StaticSiteDescriber retrieves information about a static site.
+type StaticSiteDescriber struct {
+	app             string
+	svc             string
+	enableResources bool
+
+	store               DeployedEnvServicesLister
+	initStackDescriber  func(string) (workloadStackDescriber, error)
+	siteStackDescribers map[string]workloadStackDescriber
+}
+
+// NewStaticSiteDescriber instantiates a static site describer.
+func NewStaticSiteDescriber(opt NewServiceConfig) (*StaticSiteDescriber, error) {
+	describer := &StaticSiteDescriber{
+		app:             opt.App,
+		svc:             opt.Svc,
+		enableResources: opt.EnableResources,
+		store:           opt.DeployStore,
+
+		siteStackDescribers: make(map[string]workloadStackDescriber),
+	}
+	describer.initStackDescriber = func(env string) (workloadStackDescriber, error) {
+		if describer, ok := describer.siteStackDescribers[env]; ok {
+			return describer, nil
+		}
+		d, err := newServiceStackDescriber(NewServiceConfig{
+			App:         opt.App,
+			Svc:         opt.Svc,
+			ConfigStore: opt.ConfigStore,
+		}, env)
+		if err != nil {
+			return nil, err
+		}
+		describer.siteStackDescribers[env] = d
+		return d, nil
+	}
+	return describer, nil
+}
+
+// Describe returns info of a static site.
+func (d *StaticSiteDescriber) Describe() (HumanJSONStringer, error) {
+	environments, err := d.store.ListEnvironmentsDeployedTo(d.app, d.svc)
+	if err != nil {
+		return nil, fmt.Errorf("list deployed environments for application %s: %w", d.app, err)
+	}
+
+	var routes []*WebServiceRoute
+	for _, env := range environments {
+		siteDescr, err := d.initStackDescriber(env)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := siteDescr.Outputs()
+		if err != nil {
+			return nil, fmt.Errorf("get stack outputs for environment %s: %w", env, err)
+		}
+		routes = append(routes, &WebServiceRoute{
+			Environment: env,
+			URL:         fmt.Sprintf("https://%s", outputs[svcOutputCloudFrontDistributionDomainName]),
+		})
+	}
+
+	resources := make(map[string][]*stack.Resource)
+	if d.enableResources {
+		for _, env := range environments {
+			siteDescr, err := d.initStackDescriber(env)
+			if err != nil {
+				return nil, err
+			}
+			stackResources, err := siteDescr.ServiceStackResources()
+			if err != nil {
+				return nil, fmt.Errorf("retrieve service resources: %w", err)
+			}
+			resources[env] = stackResources
+		}
+	}
+
+	return &staticSiteDesc{
+		Service:   d.svc,
+		Type:      manifest.StaticSiteType,
+		App:       d.app,
+		Routes:    routes,
+		Resources: resources,
+
+		environments: environments,
+	}, nil
+}
+
+// staticSiteDesc contains serialized parameters for a static site.
+type staticSiteDesc struct {
+	Service   string               `json:"service"`
+	Type      string               `json:"type"`
+	App       string               `json:"application"`
+	Routes    []*WebServiceRoute   `json:"routes"`
+	Resources deployedSvcResources `json:"resources,omitempty"`
+
+	environments []string `json:"-"`
+}
+
+// JSONString returns the stringified staticSiteDesc struct in json format.
+func (w *staticSiteDesc) JSONString() (string, error) {
+	b, err := json.Marshal(w)
+	if err != nil {
+		return "", fmt.Errorf("marshal static site description: %w", err)
+	}
+	return fmt.Sprintf("%s\n", b), nil
+}
+
+// HumanString returns the stringified staticSiteDesc struct in human readable format.
+func (w *staticSiteDesc) HumanString() string {
+	var b bytes.Buffer
+	writer := tabwriter.NewWriter(&b, minCellWidth, tabWidth, cellPaddingWidth, paddingChar, noAdditionalFormatting)
+	fmt.Fprint(writer, color.Bold.Sprint("About\n\n"))
+	writer.Flush()
+	fmt.Fprintf(writer, "  %s\t%s\n", "Application", w.App)
+	fmt.Fprintf(writer, "  %s\t%s\n", "Name", w.Service)
+	fmt.Fprintf(writer, "  %s\t%s\n", "Type", w.Type)
+	fmt.Fprint(writer, color.Bold.Sprint("\nRoutes\n\n"))
+	writer.Flush()
+	headers := []string{"Environment", "URL"}
+	fmt.Fprintf(writer, "  %s\n", strings.Join(headers, "\t"))
+	fmt.Fprintf(writer, "  %s\n", strings.Join(underline(headers), "\t"))
+	for _, route := range w.Routes {
+		fmt.Fprintf(writer, "  %s\t%s\n", route.Environment, route.URL)
+	}
+	if len(w.Resources) != 0 {
+		fmt.Fprint(writer, color.Bold.Sprint("\nResources\n"))
+		writer.Flush()
+
+		w.Resources.humanStringByEnv(writer, w.environments)
+	}
+	writer.Flush()
+	return b.String()
+}
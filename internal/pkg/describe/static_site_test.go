@@ -0,0 +1,174 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/copilot-cli/internal/pkg/describe/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/describe/stack"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticSiteDescriber_Describe(t *testing.T) {
+	const (
+		testApp = "phonetool"
+		testEnv = "test"
+		testSvc = "frontend"
+	)
+	mockErr := errors.New("some error")
+	testCases := map[string]struct {
+		shouldOutputResources bool
+
+		setupMocks func(store *mocks.MockDeployedEnvServicesLister, describer *mocks.MockworkloadStackDescriber)
+
+		wantedSite  *staticSiteDesc
+		wantedError error
+	}{
+		"return error if fail to list environment": {
+			setupMocks: func(store *mocks.MockDeployedEnvServicesLister, _ *mocks.MockworkloadStackDescriber) {
+				store.EXPECT().ListEnvironmentsDeployedTo(testApp, testSvc).Return(nil, mockErr)
+			},
+			wantedError: fmt.Errorf("list deployed environments for application phonetool: some error"),
+		},
+		"return error if fail to retrieve stack outputs": {
+			setupMocks: func(store *mocks.MockDeployedEnvServicesLister, describer *mocks.MockworkloadStackDescriber) {
+				gomock.InOrder(
+					store.EXPECT().ListEnvironmentsDeployedTo(testApp, testSvc).Return([]string{testEnv}, nil),
+					describer.EXPECT().Outputs().Return(nil, mockErr),
+				)
+			},
+			wantedError: fmt.Errorf("get stack outputs for environment test: some error"),
+		},
+		"return error if fail to retrieve service resources": {
+			shouldOutputResources: true,
+			setupMocks: func(store *mocks.MockDeployedEnvServicesLister, describer *mocks.MockworkloadStackDescriber) {
+				gomock.InOrder(
+					store.EXPECT().ListEnvironmentsDeployedTo(testApp, testSvc).Return([]string{testEnv}, nil),
+					describer.EXPECT().Outputs().Return(map[string]string{
+						svcOutputCloudFrontDistributionDomainName: "d1234.cloudfront.net",
+					}, nil),
+					describer.EXPECT().ServiceStackResources().Return(nil, mockErr),
+				)
+			},
+			wantedError: fmt.Errorf("retrieve service resources: some error"),
+		},
+		"success": {
+			shouldOutputResources: true,
+			setupMocks: func(store *mocks.MockDeployedEnvServicesLister, describer *mocks.MockworkloadStackDescriber) {
+				gomock.InOrder(
+					store.EXPECT().ListEnvironmentsDeployedTo(testApp, testSvc).Return([]string{testEnv}, nil),
+					describer.EXPECT().Outputs().Return(map[string]string{
+						svcOutputCloudFrontDistributionDomainName: "d1234.cloudfront.net",
+					}, nil),
+					describer.EXPECT().ServiceStackResources().Return([]*stack.Resource{
+						{
+							Type:       "AWS::S3::Bucket",
+							PhysicalID: "phonetool-test-frontend-bucket",
+						},
+					}, nil),
+				)
+			},
+			wantedSite: &staticSiteDesc{
+				Service: testSvc,
+				Type:    "Static Site",
+				App:     testApp,
+				Routes: []*WebServiceRoute{
+					{
+						Environment: testEnv,
+						URL:         "https://d1234.cloudfront.net",
+					},
+				},
+				Resources: map[string][]*stack.Resource{
+					testEnv: {
+						{
+							Type:       "AWS::S3::Bucket",
+							PhysicalID: "phonetool-test-frontend-bucket",
+						},
+					},
+				},
+				environments: []string{testEnv},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockStore := mocks.NewMockDeployedEnvServicesLister(ctrl)
+			mockDescriber := mocks.NewMockworkloadStackDescriber(ctrl)
+			tc.setupMocks(mockStore, mockDescriber)
+			d := &StaticSiteDescriber{
+				app:                testApp,
+				svc:                testSvc,
+				enableResources:    tc.shouldOutputResources,
+				store:              mockStore,
+				initStackDescriber: func(string) (workloadStackDescriber, error) { return mockDescriber, nil },
+			}
+
+			// WHEN
+			site, err := d.Describe()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedSite, site)
+		})
+	}
+}
+
+func TestStaticSiteDesc_String(t *testing.T) {
+	site := &staticSiteDesc{
+		Service: "frontend",
+		Type:    "Static Site",
+		App:     "phonetool",
+		Routes: []*WebServiceRoute{
+			{
+				Environment: "test",
+				URL:         "https://d1234.cloudfront.net",
+			},
+		},
+		Resources: map[string][]*stack.Resource{
+			"test": {
+				{
+					Type:       "AWS::S3::Bucket",
+					PhysicalID: "phonetool-test-frontend-bucket",
+				},
+			},
+		},
+		environments: []string{"test"},
+	}
+	wantedHumanString := `About
+
+  Application  phonetool
+  Name         frontend
+  Type         Static Site
+
+Routes
+
+  Environment  URL
+  -----------  ---
+  test         https://d1234.cloudfront.net
+
+Resources
+
+  test
+    AWS::S3::Bucket  phonetool-test-frontend-bucket
+`
+	wantedJSONString := "{\"service\":\"frontend\",\"type\":\"Static Site\",\"application\":\"phonetool\",\"routes\":[{\"environment\":\"test\",\"url\":\"https://d1234.cloudfront.net\"}],\"resources\":{\"test\":[{\"type\":\"AWS::S3::Bucket\",\"physicalID\":\"phonetool-test-frontend-bucket\"}]}}\n"
+
+	human := site.HumanString()
+	json, _ := site.JSONString()
+
+	require.Equal(t, wantedHumanString, human)
+	require.Equal(t, wantedJSONString, json)
+}
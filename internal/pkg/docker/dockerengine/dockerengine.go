@@ -59,14 +59,15 @@ func New(cmd Cmd) CmdClient {
 
 // BuildArguments holds the arguments that can be passed while building a container.
 type BuildArguments struct {
-	URI        string            // Required. Location of ECR Repo. Used to generate image name in conjunction with tag.
-	Tags       []string          // Optional. List of tags to apply to the image besides "latest".
-	Dockerfile string            // Required. Dockerfile to pass to `docker build` via --file flag.
-	Context    string            // Optional. Build context directory to pass to `docker build`.
-	Target     string            // Optional. The target build stage to pass to `docker build`.
-	CacheFrom  []string          // Optional. Images to consider as cache sources to pass to `docker build`
-	Platform   string            // Optional. OS/Arch to pass to `docker build`.
-	Args       map[string]string // Optional. Build args to pass via `--build-arg` flags. Equivalent to ARG directives in dockerfile.
+	URI         string            // Required. Location of ECR Repo. Used to generate image name in conjunction with tag.
+	Tags        []string          // Optional. List of tags to apply to the image besides "latest".
+	Dockerfile  string            // Required. Dockerfile to pass to `docker build` via --file flag.
+	Context     string            // Optional. Build context directory to pass to `docker build`.
+	Target      string            // Optional. The target build stage to pass to `docker build`.
+	CacheFrom   []string          // Optional. Images to consider as cache sources to pass to `docker build`
+	InlineCache bool              // Optional. Embed the BuildKit cache metadata in the image so that it can be used as a cache source.
+	Platform    string            // Optional. OS/Arch to pass to `docker build`.
+	Args        map[string]string // Optional. Build args to pass via `--build-arg` flags. Equivalent to ARG directives in dockerfile.
 }
 
 type dockerConfig struct {
@@ -94,6 +95,11 @@ func (c CmdClient) Build(in *BuildArguments) error {
 		args = append(args, "--cache-from", imageFrom)
 	}
 
+	// Export the build cache along with the image.
+	if in.InlineCache {
+		args = append(args, "--build-arg", "BUILDKIT_INLINE_CACHE=1")
+	}
+
 	// Add target option.
 	if in.Target != "" {
 		args = append(args, "--target", in.Target)
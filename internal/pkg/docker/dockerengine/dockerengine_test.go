@@ -32,14 +32,15 @@ func TestDockerCommand_Build(t *testing.T) {
 	var mockCmd *MockCmd
 
 	tests := map[string]struct {
-		path       string
-		context    string
-		tags       []string
-		args       map[string]string
-		target     string
-		cacheFrom  []string
-		envVars    map[string]string
-		setupMocks func(controller *gomock.Controller)
+		path        string
+		context     string
+		tags        []string
+		args        map[string]string
+		target      string
+		cacheFrom   []string
+		inlineCache bool
+		envVars     map[string]string
+		setupMocks  func(controller *gomock.Controller)
 
 		wantedError error
 	}{
@@ -155,6 +156,19 @@ func TestDockerCommand_Build(t *testing.T) {
 					"mockPath/to", "-f", "mockPath/to/mockDockerfile"}).Return(nil)
 			},
 		},
+		"exports inline cache metadata": {
+			path:        mockPath,
+			cacheFrom:   []string{"mockURI:latest"},
+			inlineCache: true,
+			setupMocks: func(c *gomock.Controller) {
+				mockCmd = NewMockCmd(c)
+				mockCmd.EXPECT().Run("docker", []string{"build",
+					"-t", mockURI,
+					"--cache-from", "mockURI:latest",
+					"--build-arg", "BUILDKIT_INLINE_CACHE=1",
+					"mockPath/to", "-f", "mockPath/to/mockDockerfile"}).Return(nil)
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -171,13 +185,14 @@ func TestDockerCommand_Build(t *testing.T) {
 				},
 			}
 			buildInput := BuildArguments{
-				Context:    tc.context,
-				Dockerfile: tc.path,
-				URI:        mockURI,
-				Args:       tc.args,
-				Target:     tc.target,
-				CacheFrom:  tc.cacheFrom,
-				Tags:       tc.tags,
+				Context:     tc.context,
+				Dockerfile:  tc.path,
+				URI:         mockURI,
+				Args:        tc.args,
+				Target:      tc.target,
+				CacheFrom:   tc.cacheFrom,
+				InlineCache: tc.inlineCache,
+				Tags:        tc.tags,
 			}
 			got := s.Build(&buildInput)
 
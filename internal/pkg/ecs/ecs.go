@@ -21,10 +21,11 @@ import (
 )
 
 const (
-	fmtWorkloadTaskDefinitionFamily = "%s-%s-%s"
-	fmtTaskTaskDefinitionFamily     = "copilot-%s"
-	clusterResourceType             = "ecs:cluster"
-	serviceResourceType             = "ecs:service"
+	fmtWorkloadTaskDefinitionFamily  = "%s-%s-%s"
+	fmtPreDeployTaskDefinitionFamily = "%s-%s-%s-pre-deploy"
+	fmtTaskTaskDefinitionFamily      = "copilot-%s"
+	clusterResourceType              = "ecs:cluster"
+	serviceResourceType              = "ecs:service"
 
 	taskStopReason = "Task stopped because the underlying CloudFormation stack was deleted."
 )
@@ -41,6 +42,7 @@ type ecsClient interface {
 	RunningTasksInFamily(cluster, family string) ([]*ecs.Task, error)
 	ServiceRunningTasks(clusterName, serviceName string) ([]*ecs.Task, error)
 	StoppedServiceTasks(cluster, service string) ([]*ecs.Task, error)
+	StoppedTasksInFamily(cluster, family string) ([]*ecs.Task, error)
 	StopTasks(tasks []string, opts ...ecs.StopTasksOpts) error
 	TaskDefinition(taskDefName string) (*ecs.TaskDefinition, error)
 	UpdateService(clusterName, serviceName string, opts ...ecs.UpdateServiceOpts) error
@@ -203,6 +205,19 @@ func (c Client) stopTasks(app, env string, filter ListTasksFilter) error {
 	return c.ecsClient.StopTasks(taskIDs, ecs.WithStopTaskCluster(clusterARN), ecs.WithStopTaskReason(taskStopReason))
 }
 
+// StoppedPreDeployTasks returns the stopped tasks that ran before deployments of the service.
+func (c Client) StoppedPreDeployTasks(app, env, svc string) ([]*ecs.Task, error) {
+	clusterARN, err := c.clusterARN(app, env)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := c.ecsClient.StoppedTasksInFamily(clusterARN, fmt.Sprintf(fmtPreDeployTaskDefinitionFamily, app, env, svc))
+	if err != nil {
+		return nil, fmt.Errorf("list stopped pre-deploy tasks of service %s: %w", svc, err)
+	}
+	return tasks, nil
+}
+
 // StopDefaultClusterTasks stops all copilot tasks from the given family in the default cluster.
 func (c Client) StopDefaultClusterTasks(familyName string) error {
 	tdFamily := fmt.Sprintf(fmtTaskTaskDefinitionFamily, familyName)
@@ -276,6 +291,7 @@ type NetworkConfiguration ecs.NetworkConfiguration
 
 // UnmarshalJSON implements custom logic to unmarshal only the network configuration from a state machine definition.
 // Example state machine definition:
+//
 //	 "Version": "1.0",
 //	 "Comment": "Run AWS Fargate task",
 //	 "StartAt": "Run Fargate Task",
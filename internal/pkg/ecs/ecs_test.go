@@ -898,6 +898,86 @@ func TestClient_StopOneOffTasks(t *testing.T) {
 	}
 }
 
+func TestClient_StoppedPreDeployTasks(t *testing.T) {
+	mockCluster := "arn:aws::ecs:cluster/abcd1234"
+	mockECSTask := []*ecs.Task{
+		{
+			TaskArn: aws.String("deadbeef"),
+		},
+	}
+	testCases := map[string]struct {
+		mockECS func(m *mocks.MockecsClient)
+		mockrg  func(m *mocks.MockresourceGetter)
+
+		wantTasks []*ecs.Task
+		wantErr   error
+	}{
+		"failure getting the cluster": {
+			mockECS: func(m *mocks.MockecsClient) {},
+			mockrg: func(m *mocks.MockresourceGetter) {
+				m.EXPECT().GetResourcesByTags(clusterResourceType, map[string]string{
+					"copilot-application": "phonetool",
+					"copilot-environment": "pdx",
+				}).Return(nil, errors.New("some error"))
+			},
+			wantErr: errors.New("get cluster resources for environment pdx: some error"),
+		},
+		"failure listing stopped tasks": {
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().StoppedTasksInFamily(mockCluster, "phonetool-pdx-api-pre-deploy").Return(nil, errors.New("some error"))
+			},
+			mockrg: func(m *mocks.MockresourceGetter) {
+				m.EXPECT().GetResourcesByTags(clusterResourceType, map[string]string{
+					"copilot-application": "phonetool",
+					"copilot-environment": "pdx",
+				}).Return([]*resourcegroups.Resource{{ARN: mockCluster}}, nil)
+			},
+			wantErr: errors.New("list stopped pre-deploy tasks of service api: some error"),
+		},
+		"success": {
+			mockECS: func(m *mocks.MockecsClient) {
+				m.EXPECT().StoppedTasksInFamily(mockCluster, "phonetool-pdx-api-pre-deploy").Return(mockECSTask, nil)
+			},
+			mockrg: func(m *mocks.MockresourceGetter) {
+				m.EXPECT().GetResourcesByTags(clusterResourceType, map[string]string{
+					"copilot-application": "phonetool",
+					"copilot-environment": "pdx",
+				}).Return([]*resourcegroups.Resource{{ARN: mockCluster}}, nil)
+			},
+			wantTasks: mockECSTask,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockECS := mocks.NewMockecsClient(ctrl)
+			mockrg := mocks.NewMockresourceGetter(ctrl)
+
+			tc.mockECS(mockECS)
+			tc.mockrg(mockrg)
+
+			c := Client{
+				ecsClient: mockECS,
+				rgGetter:  mockrg,
+			}
+
+			// WHEN
+			tasks, err := c.StoppedPreDeployTasks("phonetool", "pdx", "api")
+
+			// THEN
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantTasks, tasks)
+			}
+		})
+	}
+}
+
 func Test_StopDefaultClusterTasks(t *testing.T) {
 	mockECSTask := []*ecs.Task{
 		{
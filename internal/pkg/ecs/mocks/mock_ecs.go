@@ -197,6 +197,21 @@ func (mr *MockecsClientMockRecorder) StoppedServiceTasks(cluster, service interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoppedServiceTasks", reflect.TypeOf((*MockecsClient)(nil).StoppedServiceTasks), cluster, service)
 }
 
+// StoppedTasksInFamily mocks base method.
+func (m *MockecsClient) StoppedTasksInFamily(cluster, family string) ([]*ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoppedTasksInFamily", cluster, family)
+	ret0, _ := ret[0].([]*ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoppedTasksInFamily indicates an expected call of StoppedTasksInFamily.
+func (mr *MockecsClientMockRecorder) StoppedTasksInFamily(cluster, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoppedTasksInFamily", reflect.TypeOf((*MockecsClient)(nil).StoppedTasksInFamily), cluster, family)
+}
+
 // TaskDefinition mocks base method.
 func (m *MockecsClient) TaskDefinition(taskDefName string) (*ecs.TaskDefinition, error) {
 	m.ctrl.T.Helper()
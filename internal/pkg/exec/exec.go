@@ -70,6 +70,13 @@ func Stderr(writer io.Writer) CmdOption {
 	}
 }
 
+// Dir sets the internal *exec.Cmd's Dir field.
+func Dir(dir string) CmdOption {
+	return func(c *exec.Cmd) {
+		c.Dir = dir
+	}
+}
+
 // Run starts the named command and waits until it finishes.
 func (c *Cmd) Run(name string, args []string, opts ...CmdOption) error {
 	cmd := c.command(name, args, opts...)
@@ -89,6 +89,7 @@ type ServiceProps struct {
 	WorkloadProps
 	Port        uint16
 	HealthCheck manifest.ContainerHealthCheck
+	SourcePath  string
 	appDomain   *string
 }
 
@@ -297,6 +298,8 @@ func (w *WorkloadInitializer) newServiceManifest(i *ServiceProps) (encoding.Bina
 		return newBackendServiceManifest(i)
 	case manifest.WorkerServiceType:
 		return newWorkerServiceManifest(i)
+	case manifest.StaticSiteType:
+		return newStaticSiteManifest(i), nil
 	default:
 		return nil, fmt.Errorf("service type %s doesn't have a manifest", i.Type)
 	}
@@ -375,6 +378,13 @@ func newWorkerServiceManifest(i *ServiceProps) (*manifest.WorkerService, error)
 	}), nil
 }
 
+func newStaticSiteManifest(i *ServiceProps) *manifest.StaticSite {
+	return manifest.NewStaticSite(manifest.StaticSiteProps{
+		Name:       i.Name,
+		SourcePath: i.SourcePath,
+	})
+}
+
 // relativeDockerfilePath returns the path from the workspace root to the Dockerfile.
 func relativeDockerfilePath(ws Workspace, path string) (string, error) {
 	wsRoot, err := ws.Path()
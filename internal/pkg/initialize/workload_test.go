@@ -446,6 +446,7 @@ func TestWorkloadInitializer_Service(t *testing.T) {
 		inImage          string
 		inHealthCheck    manifest.ContainerHealthCheck
 		inTopics         []manifest.TopicSubscription
+		inSourcePath     string
 
 		mockWriter      func(m *mocks.MockWorkspace)
 		mockstore       func(m *mocks.MockStore)
@@ -764,6 +765,41 @@ func TestWorkloadInitializer_Service(t *testing.T) {
 				m.EXPECT().Stop(log.Ssuccessf(fmtAddWlToAppComplete, "service", "worker"))
 			},
 		},
+		"writes Static Site manifest": {
+			inSvcType:    manifest.StaticSiteType,
+			inAppName:    "app",
+			inSvcName:    "frontend",
+			inSourcePath: "dist",
+
+			mockWriter: func(m *mocks.MockWorkspace) {
+				m.EXPECT().WriteServiceManifest(gomock.Any(), "frontend").
+					Do(func(m *manifest.StaticSite, _ string) {
+						require.Equal(t, manifest.StaticSiteType, *m.Workload.Type)
+						require.Equal(t, "dist", *m.Source.Path)
+					}).Return("/frontend/manifest.yml", nil)
+			},
+			mockstore: func(m *mocks.MockStore) {
+				m.EXPECT().CreateService(&config.Workload{
+					Name: "frontend",
+					App:  "app",
+					Type: manifest.StaticSiteType,
+				}).Return(nil)
+				m.EXPECT().GetApplication("app").Return(&config.Application{
+					Name:      "app",
+					AccountID: "1234",
+				}, nil)
+			},
+			mockappDeployer: func(m *mocks.MockWorkloadAdder) {
+				m.EXPECT().AddServiceToApp(&config.Application{
+					Name:      "app",
+					AccountID: "1234",
+				}, "frontend")
+			},
+			mockProg: func(m *mocks.MockProg) {
+				m.EXPECT().Start(fmt.Sprintf(fmtAddWlToAppStart, "service", "frontend"))
+				m.EXPECT().Stop(log.Ssuccessf(fmtAddWlToAppComplete, "service", "frontend"))
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -809,6 +845,7 @@ func TestWorkloadInitializer_Service(t *testing.T) {
 				},
 				Port:        tc.inSvcPort,
 				HealthCheck: tc.inHealthCheck,
+				SourcePath:  tc.inSourcePath,
 			})
 
 			// THEN
@@ -28,13 +28,15 @@ type BackendServiceConfig struct {
 	ImageConfig      ImageWithHealthcheckAndOptionalPort `yaml:"image,flow"`
 	ImageOverride    `yaml:",inline"`
 	TaskConfig       `yaml:",inline"`
-	Logging          Logging                   `yaml:"logging,flow"`
-	Sidecars         map[string]*SidecarConfig `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
-	Network          NetworkConfig             `yaml:"network"`
-	PublishConfig    PublishConfig             `yaml:"publish"`
-	TaskDefOverrides []OverrideRule            `yaml:"taskdef_overrides"`
-	DeployConfig     DeploymentConfiguration   `yaml:"deployment"`
-	Observability    Observability             `yaml:"observability"`
+	RoutingRule      RoutingRuleConfigOrBool         `yaml:"http,flow"`
+	Logging          Logging                         `yaml:"logging,flow"`
+	Sidecars         map[string]*SidecarConfig       `yaml:"sidecars"`        // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	InitContainers   map[string]*InitContainerConfig `yaml:"init_containers"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Network          NetworkConfig                   `yaml:"network"`
+	PublishConfig    PublishConfig                   `yaml:"publish"`
+	TaskDefOverrides []OverrideRule                  `yaml:"taskdef_overrides"`
+	DeployConfig     DeploymentConfiguration         `yaml:"deployment"`
+	Observability    Observability                   `yaml:"observability"`
 }
 
 // BackendServiceProps represents the configuration needed to create a backend service.
@@ -92,6 +94,16 @@ func (s *BackendService) Publish() []Topic {
 	return s.BackendServiceConfig.PublishConfig.Topics
 }
 
+// InternalALBEnabled returns true if the service is routed through the environment's internal load balancer.
+func (s *BackendService) InternalALBEnabled() bool {
+	return !s.RoutingRule.IsEmpty() && !s.RoutingRule.Disabled()
+}
+
+// IngressSources returns the names of the services that are allowed to send traffic to the service.
+func (s *BackendService) IngressSources() []string {
+	return s.Network.VPC.Ingress.From
+}
+
 // BuildRequired returns if the service requires building from the local Dockerfile.
 func (s *BackendService) BuildRequired() (bool, error) {
 	return requiresBuild(s.ImageConfig.Image)
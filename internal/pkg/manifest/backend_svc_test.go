@@ -271,6 +271,45 @@ func TestBackendService_Port(t *testing.T) {
 	}
 }
 
+func TestBackendService_InternalALBEnabled(t *testing.T) {
+	testCases := map[string]struct {
+		mft *BackendService
+
+		wanted bool
+	}{
+		"returns false if http is not specified": {
+			mft: &BackendService{},
+		},
+		"returns false if http is disabled": {
+			mft: &BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						Enabled: aws.Bool(false),
+					},
+				},
+			},
+		},
+		"returns true if http is configured": {
+			mft: &BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/api"),
+						},
+					},
+				},
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.mft.InternalALBEnabled())
+		})
+	}
+}
+
 func TestBackendService_Publish(t *testing.T) {
 	testCases := map[string]struct {
 		mft *BackendService
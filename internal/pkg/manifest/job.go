@@ -41,9 +41,10 @@ type ScheduledJobConfig struct {
 	ImageConfig             ImageWithHealthcheck `yaml:"image,flow"`
 	ImageOverride           `yaml:",inline"`
 	TaskConfig              `yaml:",inline"`
-	Logging                 Logging                   `yaml:"logging,flow"`
-	Sidecars                map[string]*SidecarConfig `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
-	On                      JobTriggerConfig          `yaml:"on,flow"`
+	Logging                 Logging                         `yaml:"logging,flow"`
+	Sidecars                map[string]*SidecarConfig       `yaml:"sidecars"`        // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	InitContainers          map[string]*InitContainerConfig `yaml:"init_containers"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	On                      JobTriggerConfig                `yaml:"on,flow"`
 	JobFailureHandlerConfig `yaml:",inline"`
 	Network                 NetworkConfig  `yaml:"network"`
 	PublishConfig           PublishConfig  `yaml:"publish"`
@@ -31,6 +31,8 @@ const (
 
 var (
 	errUnmarshalHealthCheckArgs = errors.New("can't unmarshal healthcheck field into string or compose-style map")
+	errUnmarshalWAF             = errors.New(`cannot marshal "waf" field into string or map`)
+	errUnmarshalCDN             = errors.New(`cannot marshal "cdn" field into bool or map`)
 )
 
 // durationp is a utility function used to convert a time.Duration to a pointer. Useful for YAML unmarshaling
@@ -57,7 +59,8 @@ type LoadBalancedWebServiceConfig struct {
 	RoutingRule      RoutingRuleConfigOrBool `yaml:"http,flow"`
 	TaskConfig       `yaml:",inline"`
 	Logging          `yaml:"logging,flow"`
-	Sidecars         map[string]*SidecarConfig        `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Sidecars         map[string]*SidecarConfig        `yaml:"sidecars"`        // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	InitContainers   map[string]*InitContainerConfig  `yaml:"init_containers"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
 	Network          NetworkConfig                    `yaml:"network"`
 	PublishConfig    PublishConfig                    `yaml:"publish"`
 	TaskDefOverrides []OverrideRule                   `yaml:"taskdef_overrides"`
@@ -179,6 +182,31 @@ func (s *LoadBalancedWebService) EnvFile() string {
 	return aws.StringValue(s.TaskConfig.EnvFile)
 }
 
+// HTTPListenerRules returns the application load balancer listener rules of the service,
+// starting with the rule built from the main "http" configuration.
+func (s *LoadBalancedWebService) HTTPListenerRules() []ListenerRule {
+	if s.RoutingRule.Disabled() {
+		return nil
+	}
+	hosts, _ := s.RoutingRule.Alias.ToStringSlice()
+	main := ListenerRule{
+		Path:             s.RoutingRule.Path,
+		Hosts:            hosts,
+		AllowedSourceIps: s.RoutingRule.AllowedSourceIps,
+	}
+	return append([]ListenerRule{main}, s.RoutingRule.AdditionalRules...)
+}
+
+// HasWAF returns true if the service associates a web ACL with the public load balancer.
+func (s *LoadBalancedWebService) HasWAF() bool {
+	return !s.RoutingRule.Disabled() && !s.RoutingRule.WAF.IsEmpty()
+}
+
+// HasCDN returns true if the service places a CloudFront distribution in front of the public load balancer.
+func (s *LoadBalancedWebService) HasCDN() bool {
+	return !s.RoutingRule.Disabled() && s.RoutingRule.CDN.Enabled()
+}
+
 // ApplyEnv returns the service manifest with environment overrides.
 // If the environment passed in does not have any overrides then it returns itself.
 func (s LoadBalancedWebService) ApplyEnv(envName string) (WorkloadManifest, error) {
@@ -217,6 +245,11 @@ func (r *RoutingRuleConfigOrBool) Disabled() bool {
 	return r.Enabled != nil && !aws.BoolValue(r.Enabled)
 }
 
+// IsEmpty returns true if the routing rule configuration is not set.
+func (r *RoutingRuleConfigOrBool) IsEmpty() bool {
+	return r.Enabled == nil && r.RoutingRuleConfiguration.isEmpty()
+}
+
 // UnmarshalYAML implements the yaml(v3) interface. It allows https routing rule to be specified as a
 // bool or a struct alternately.
 func (r *RoutingRuleConfigOrBool) UnmarshalYAML(value *yaml.Node) error {
@@ -253,6 +286,14 @@ type RoutingRuleConfiguration struct {
 	TargetContainer          *string `yaml:"target_container"`
 	TargetContainerCamelCase *string `yaml:"targetContainer"` // "targetContainerCamelCase" for backwards compatibility
 	AllowedSourceIps         []IPNet `yaml:"allowed_source_ips"`
+	// HostedZone is the private hosted zone in which alias records are created for an internal load balancer.
+	HostedZone *string `yaml:"hosted_zone"`
+	// AdditionalRules are listener rules evaluated alongside the main routing rule of the service.
+	AdditionalRules []ListenerRule `yaml:"additional_rules"`
+	// WAF is the web ACL associated with the public load balancer.
+	WAF WebACLArgsOrARN `yaml:"waf"`
+	// CDN is the CloudFront distribution placed in front of the public load balancer.
+	CDN CDNBoolOrArgs `yaml:"cdn"`
 }
 
 func (r *RoutingRuleConfiguration) targetContainer() *string {
@@ -267,23 +308,226 @@ func (r *RoutingRuleConfiguration) targetContainer() *string {
 
 func (r *RoutingRuleConfiguration) isEmpty() bool {
 	return r.Path == nil && r.ProtocolVersion == nil && r.HealthCheck.IsEmpty() && r.Stickiness == nil && r.Alias.IsEmpty() &&
-		r.DeregistrationDelay == nil && r.TargetContainer == nil && r.TargetContainerCamelCase == nil && r.AllowedSourceIps == nil
+		r.DeregistrationDelay == nil && r.TargetContainer == nil && r.TargetContainerCamelCase == nil && r.AllowedSourceIps == nil &&
+		r.HostedZone == nil && r.AdditionalRules == nil && r.WAF.IsEmpty() && r.CDN.IsEmpty()
+}
+
+// WebACLArgsOrARN represents either the ARN of an existing AWS WAF web ACL
+// or the configuration of a web ACL to create.
+type WebACLArgsOrARN struct {
+	ARN      *string
+	Advanced WebACLArgs
+}
+
+// WebACLArgs holds the rules of a web ACL created by Copilot.
+type WebACLArgs struct {
+	ManagedRules []string `yaml:"managed_rules"`
+	RateLimit    *int     `yaml:"rate_limit"`
+}
+
+func (a *WebACLArgs) isEmpty() bool {
+	return len(a.ManagedRules) == 0 && a.RateLimit == nil
+}
+
+// IsEmpty returns true if no web ACL is configured.
+func (w *WebACLArgsOrARN) IsEmpty() bool {
+	return w.ARN == nil && w.Advanced.isEmpty()
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the WebACLArgsOrARN
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (w *WebACLArgsOrARN) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&w.Advanced); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+
+	if !w.Advanced.isEmpty() {
+		// Unmarshaled successfully to w.Advanced, reset w.ARN, and return.
+		w.ARN = nil
+		return nil
+	}
+
+	if err := value.Decode(&w.ARN); err != nil {
+		return errUnmarshalWAF
+	}
+	return nil
+}
+
+// CDNManagedCachePolicyIDs maps the names of the CloudFront managed cache policies to their IDs.
+var CDNManagedCachePolicyIDs = map[string]string{
+	"CachingOptimized":                       "658327ea-f89d-4fab-a63d-7e88639e58f6",
+	"CachingOptimizedForUncompressedObjects": "b2884449-e4de-46a7-ac36-70bc7f1ddd6d",
+	"CachingDisabled":                        "4135ea2d-6df8-44a3-9df3-4b5a84be39ad",
+}
+
+// CDNBoolOrArgs represents either a flag to enable a CloudFront distribution with the default settings
+// or the configuration of the distribution.
+type CDNBoolOrArgs struct {
+	Enable   *bool
+	Advanced CDNArgs
+}
+
+// CDNArgs holds the configuration of a CloudFront distribution.
+type CDNArgs struct {
+	CachePolicy  *string `yaml:"cache_policy"`  // Name of a managed cache policy or ID of a custom one.
+	OriginShield *bool   `yaml:"origin_shield"` // Adds an Origin Shield in the region of the environment.
+	Aliases      Alias   `yaml:"aliases"`
+}
+
+// IsEmpty returns true if CDNArgs has all zero members.
+func (a *CDNArgs) IsEmpty() bool {
+	return a.CachePolicy == nil && a.OriginShield == nil && a.Aliases.IsEmpty()
+}
+
+// IsEmpty returns true if no CloudFront distribution is configured.
+func (c *CDNBoolOrArgs) IsEmpty() bool {
+	return c.Enable == nil && c.Advanced.IsEmpty()
+}
+
+// Enabled returns true if a CloudFront distribution should be created.
+func (c *CDNBoolOrArgs) Enabled() bool {
+	if c.Enable != nil {
+		return aws.BoolValue(c.Enable)
+	}
+	return !c.Advanced.IsEmpty()
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the CDNBoolOrArgs
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (c *CDNBoolOrArgs) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&c.Advanced); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+
+	if !c.Advanced.IsEmpty() {
+		// Unmarshaled successfully to c.Advanced, unset c.Enable, and return.
+		c.Enable = nil
+		return nil
+	}
+
+	if err := value.Decode(&c.Enable); err != nil {
+		return errUnmarshalCDN
+	}
+	return nil
+}
+
+// ListenerRule holds the conditions and the action of an additional application load balancer listener rule.
+type ListenerRule struct {
+	Priority         *int                `yaml:"priority"`
+	Path             *string             `yaml:"path"`
+	Hosts            []string            `yaml:"hosts"`
+	Headers          map[string][]string `yaml:"headers"`
+	QueryStrings     map[string]string   `yaml:"query_strings"`
+	AllowedSourceIps []IPNet             `yaml:"allowed_source_ips"`
+	// Actions: at most one of the fields below can be set. The rule forwards to the service's target group otherwise.
+	Targets       []WeightedTarget    `yaml:"targets"`
+	Redirect      RedirectAction      `yaml:"redirect"`
+	FixedResponse FixedResponseAction `yaml:"fixed_response"`
+}
+
+// hasConditions returns true if at least one condition is set for the listener rule.
+func (r *ListenerRule) hasConditions() bool {
+	return r.Path != nil || len(r.Hosts) != 0 || len(r.Headers) != 0 || len(r.QueryStrings) != 0 || len(r.AllowedSourceIps) != 0
+}
+
+// PathPatterns returns the path patterns matched by the listener rule.
+// A path without wildcards matches both the path itself and everything under it.
+func (r *ListenerRule) PathPatterns() []string {
+	if r.Path == nil {
+		return nil
+	}
+	path := strings.Trim(aws.StringValue(r.Path), "/")
+	if path == "" {
+		return []string{"/*"}
+	}
+	if strings.ContainsAny(path, "*?") {
+		return []string{"/" + path}
+	}
+	return []string{"/" + path, "/" + path + "/*"}
+}
+
+// WeightedTarget represents a container that receives a weighted share of the traffic matched by a listener rule.
+type WeightedTarget struct {
+	Container *string `yaml:"container"`
+	Weight    *int    `yaml:"weight"`
+}
+
+// RedirectAction holds the configuration to redirect requests matched by a listener rule.
+type RedirectAction struct {
+	Protocol   *string `yaml:"protocol"`
+	Host       *string `yaml:"host"`
+	Port       *string `yaml:"port"`
+	Path       *string `yaml:"path"`
+	Query      *string `yaml:"query"`
+	StatusCode *int    `yaml:"status_code"`
+}
+
+// IsEmpty returns true if the redirect action is not configured.
+func (a *RedirectAction) IsEmpty() bool {
+	return a.Protocol == nil && a.Host == nil && a.Port == nil && a.Path == nil && a.Query == nil && a.StatusCode == nil
+}
+
+// FixedResponseAction holds the configuration to return a custom response to requests matched by a listener rule.
+type FixedResponseAction struct {
+	StatusCode  *int    `yaml:"status_code"`
+	ContentType *string `yaml:"content_type"`
+	Body        *string `yaml:"body"`
+}
+
+// IsEmpty returns true if the fixed response action is not configured.
+func (a *FixedResponseAction) IsEmpty() bool {
+	return a.StatusCode == nil && a.ContentType == nil && a.Body == nil
 }
 
 // NetworkLoadBalancerConfiguration holds options for a network load balancer
 type NetworkLoadBalancerConfiguration struct {
-	Port            *string            `yaml:"port"`
-	HealthCheck     NLBHealthCheckArgs `yaml:"healthcheck"`
-	TargetContainer *string            `yaml:"target_container"`
-	TargetPort      *int               `yaml:"target_port"`
-	SSLPolicy       *string            `yaml:"ssl_policy"`
-	Stickiness      *bool              `yaml:"stickiness"`
-	Aliases         Alias              `yaml:"alias"`
+	Listener            NetworkLoadBalancerListener   `yaml:",inline"`
+	Aliases             Alias                         `yaml:"alias"`
+	CrossZone           *bool                         `yaml:"cross_zone"`
+	AdditionalListeners []NetworkLoadBalancerListener `yaml:"additional_listeners"`
 }
 
+// IsEmpty returns true if the network load balancer is not configured.
 func (c *NetworkLoadBalancerConfiguration) IsEmpty() bool {
-	return c.Port == nil && c.HealthCheck.isEmpty() && c.TargetContainer == nil && c.TargetPort == nil &&
-		c.SSLPolicy == nil && c.Stickiness == nil && c.Aliases.IsEmpty()
+	return c.Listener.IsEmpty() && c.Aliases.IsEmpty() && c.CrossZone == nil && len(c.AdditionalListeners) == 0
+}
+
+// Listeners returns the main listener followed by the additional listeners of the network load balancer.
+func (c *NetworkLoadBalancerConfiguration) Listeners() []NetworkLoadBalancerListener {
+	if c.IsEmpty() {
+		return nil
+	}
+	return append([]NetworkLoadBalancerListener{c.Listener}, c.AdditionalListeners...)
+}
+
+// NetworkLoadBalancerListener holds options for a listener of a network load balancer.
+type NetworkLoadBalancerListener struct {
+	Port             *string            `yaml:"port"`
+	HealthCheck      NLBHealthCheckArgs `yaml:"healthcheck"`
+	TargetContainer  *string            `yaml:"target_container"`
+	TargetPort       *int               `yaml:"target_port"`
+	SSLPolicy        *string            `yaml:"ssl_policy"`
+	ALPNPolicy       *string            `yaml:"alpn_policy"`
+	Stickiness       *bool              `yaml:"stickiness"`
+	PreserveClientIP *bool              `yaml:"preserve_client_ip"`
+}
+
+// IsEmpty returns true if the listener is not configured.
+func (l *NetworkLoadBalancerListener) IsEmpty() bool {
+	return l.Port == nil && l.HealthCheck.isEmpty() && l.TargetContainer == nil && l.TargetPort == nil &&
+		l.SSLPolicy == nil && l.ALPNPolicy == nil && l.Stickiness == nil && l.PreserveClientIP == nil
 }
 
 // IPNet represents an IP network string. For example: 10.1.0.0/16
@@ -1466,6 +1466,223 @@ func TestRoutingRuleConfigOrBool_Disabled(t *testing.T) {
 	}
 }
 
+func TestLoadBalancedWebService_HTTPListenerRules(t *testing.T) {
+	testCases := map[string]struct {
+		in     LoadBalancedWebService
+		wanted []ListenerRule
+	}{
+		"no rules if http is disabled": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						Enabled: aws.Bool(false),
+					},
+				},
+			},
+		},
+		"main rule followed by additional rules": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path:             aws.String("/"),
+							Alias:            Alias{StringSlice: []string{"example.com"}},
+							AllowedSourceIps: []IPNet{"10.0.0.0/24"},
+							AdditionalRules: []ListenerRule{
+								{
+									Priority: aws.Int(10),
+									Path:     aws.String("/old"),
+									Redirect: RedirectAction{
+										Path: aws.String("/new"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wanted: []ListenerRule{
+				{
+					Path:             aws.String("/"),
+					Hosts:            []string{"example.com"},
+					AllowedSourceIps: []IPNet{"10.0.0.0/24"},
+				},
+				{
+					Priority: aws.Int(10),
+					Path:     aws.String("/old"),
+					Redirect: RedirectAction{
+						Path: aws.String("/new"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.HTTPListenerRules())
+		})
+	}
+}
+
+func TestListenerRule_PathPatterns(t *testing.T) {
+	testCases := map[string]struct {
+		in     *string
+		wanted []string
+	}{
+		"no path": {},
+		"root path": {
+			in:     aws.String("/"),
+			wanted: []string{"/*"},
+		},
+		"path without leading slash": {
+			in:     aws.String("api"),
+			wanted: []string{"/api", "/api/*"},
+		},
+		"path with trailing slash": {
+			in:     aws.String("/api/"),
+			wanted: []string{"/api", "/api/*"},
+		},
+		"path with wildcards": {
+			in:     aws.String("/api/*/users"),
+			wanted: []string{"/api/*/users"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rule := ListenerRule{Path: tc.in}
+			require.Equal(t, tc.wanted, rule.PathPatterns())
+		})
+	}
+}
+
+func TestWebACLArgsOrARN_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct WebACLArgsOrARN
+		wantedError  error
+	}{
+		"web ACL arn": {
+			inContent: []byte(`waf: arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mockName/mockID`),
+			wantedStruct: WebACLArgsOrARN{
+				ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mockName/mockID"),
+			},
+		},
+		"managed rules and rate limit": {
+			inContent: []byte(`waf:
+  managed_rules: [AWSManagedRulesCommonRuleSet]
+  rate_limit: 2000`),
+			wantedStruct: WebACLArgsOrARN{
+				Advanced: WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+					RateLimit:    aws.Int(2000),
+				},
+			},
+		},
+		"error if unmarshalable": {
+			inContent: []byte(`waf:
+  - AWSManagedRulesCommonRuleSet`),
+			wantedError: errUnmarshalWAF,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var rr RoutingRuleConfiguration
+			err := yaml.Unmarshal(tc.inContent, &rr)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, rr.WAF)
+		})
+	}
+}
+
+func TestCDNBoolOrArgs_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct CDNBoolOrArgs
+		wantedError  error
+	}{
+		"cdn enabled": {
+			inContent: []byte(`cdn: true`),
+			wantedStruct: CDNBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+		},
+		"cdn configuration": {
+			inContent: []byte(`cdn:
+  cache_policy: CachingOptimized
+  origin_shield: true
+  aliases: [www.example.com]`),
+			wantedStruct: CDNBoolOrArgs{
+				Advanced: CDNArgs{
+					CachePolicy:  aws.String("CachingOptimized"),
+					OriginShield: aws.Bool(true),
+					Aliases: Alias{
+						StringSlice: []string{"www.example.com"},
+					},
+				},
+			},
+		},
+		"error if unmarshalable": {
+			inContent: []byte(`cdn:
+  - www.example.com`),
+			wantedError: errUnmarshalCDN,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var rr RoutingRuleConfiguration
+			err := yaml.Unmarshal(tc.inContent, &rr)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, rr.CDN)
+		})
+	}
+}
+
+func TestCDNBoolOrArgs_Enabled(t *testing.T) {
+	testCases := map[string]struct {
+		in     CDNBoolOrArgs
+		wanted bool
+	}{
+		"false if empty": {},
+		"false if disabled": {
+			in: CDNBoolOrArgs{
+				Enable: aws.Bool(false),
+			},
+		},
+		"true if enabled": {
+			in: CDNBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+			wanted: true,
+		},
+		"true if configured": {
+			in: CDNBoolOrArgs{
+				Advanced: CDNArgs{
+					OriginShield: aws.Bool(true),
+				},
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.Enabled())
+		})
+	}
+}
+
 func TestNetworkLoadBalancerConfiguration_IsEmpty(t *testing.T) {
 	testCases := map[string]struct {
 		in     NetworkLoadBalancerConfiguration
@@ -1477,7 +1694,9 @@ func TestNetworkLoadBalancerConfiguration_IsEmpty(t *testing.T) {
 		},
 		"non empty": {
 			in: NetworkLoadBalancerConfiguration{
-				Port: aws.String("443"),
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443"),
+				},
 			},
 		},
 	}
@@ -1493,6 +1712,73 @@ func TestNetworkLoadBalancerConfiguration_IsEmpty(t *testing.T) {
 	}
 }
 
+func TestNetworkLoadBalancerConfiguration_UnmarshalYAML(t *testing.T) {
+	in := []byte(`port: 443/tls
+alpn_policy: HTTP2Preferred
+alias: example.com
+cross_zone: true
+additional_listeners:
+  - port: 7777/udp
+    target_container: game
+    healthcheck:
+      port: 8080`)
+
+	var nlb NetworkLoadBalancerConfiguration
+	require.NoError(t, yaml.Unmarshal(in, &nlb))
+	require.Equal(t, NetworkLoadBalancerConfiguration{
+		Listener: NetworkLoadBalancerListener{
+			Port:       aws.String("443/tls"),
+			ALPNPolicy: aws.String("HTTP2Preferred"),
+		},
+		Aliases:   Alias{String: aws.String("example.com")},
+		CrossZone: aws.Bool(true),
+		AdditionalListeners: []NetworkLoadBalancerListener{
+			{
+				Port:            aws.String("7777/udp"),
+				TargetContainer: aws.String("game"),
+				HealthCheck: NLBHealthCheckArgs{
+					Port: aws.Int(8080),
+				},
+			},
+		},
+	}, nlb)
+}
+
+func TestNetworkLoadBalancerConfiguration_Listeners(t *testing.T) {
+	testCases := map[string]struct {
+		in     NetworkLoadBalancerConfiguration
+		wanted []NetworkLoadBalancerListener
+	}{
+		"no listeners if the network load balancer is not configured": {},
+		"main listener followed by additional listeners": {
+			in: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443/tls"),
+				},
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port: aws.String("53/udp"),
+					},
+				},
+			},
+			wanted: []NetworkLoadBalancerListener{
+				{
+					Port: aws.String("443/tls"),
+				},
+				{
+					Port: aws.String("53/udp"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.Listeners())
+		})
+	}
+}
+
 func TestAlias_ToString(t *testing.T) {
 	testCases := map[string]struct {
 		inAlias Alias
@@ -1521,3 +1807,104 @@ func TestAlias_ToString(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBalancedWebService_HasWAF(t *testing.T) {
+	testCases := map[string]struct {
+		in     LoadBalancedWebService
+		wanted bool
+	}{
+		"false if http is disabled": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						Enabled: aws.Bool(false),
+					},
+				},
+			},
+		},
+		"false if waf is not configured": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/"),
+						},
+					},
+				},
+			},
+		},
+		"true if waf is configured": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/"),
+							WAF: WebACLArgsOrARN{
+								ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3"),
+							},
+						},
+					},
+				},
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.HasWAF())
+		})
+	}
+}
+
+func TestLoadBalancedWebService_HasCDN(t *testing.T) {
+	testCases := map[string]struct {
+		in     LoadBalancedWebService
+		wanted bool
+	}{
+		"false if http is disabled": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						Enabled: aws.Bool(false),
+					},
+				},
+			},
+		},
+		"false if cdn is disabled": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/"),
+							CDN: CDNBoolOrArgs{
+								Enable: aws.Bool(false),
+							},
+						},
+					},
+				},
+			},
+		},
+		"true if cdn is enabled": {
+			in: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/"),
+							CDN: CDNBoolOrArgs{
+								Enable: aws.Bool(true),
+							},
+						},
+					},
+				},
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.HasCDN())
+		})
+	}
+}
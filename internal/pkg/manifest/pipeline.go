@@ -6,6 +6,7 @@ package manifest
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/fatih/structs"
@@ -166,6 +167,7 @@ type Pipeline struct {
 	Source  *Source                    `yaml:"source"`
 	Build   *Build                     `yaml:"build"`
 	Stages  []PipelineStage            `yaml:"stages"`
+	Preview *Preview                   `yaml:"preview,omitempty"`
 
 	parser template.Parser
 }
@@ -182,6 +184,12 @@ type Build struct {
 	Buildspec string `yaml:"buildspec,omitempty"`
 }
 
+// Preview defines the branches that get deployed to a short-lived environment named after the branch.
+type Preview struct {
+	Branches []string       `yaml:"branches"`
+	TTL      *time.Duration `yaml:"ttl,omitempty"`
+}
+
 // PipelineStage represents a stage in the pipeline manifest
 type PipelineStage struct {
 	Name             string      `yaml:"name"`
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/aws/copilot-cli/internal/pkg/template/mocks"
@@ -303,6 +304,46 @@ stages:
 				},
 			},
 		},
+		"valid pipeline.yml with preview": {
+			inContent: `
+name: pipepiper
+version: 1
+
+source:
+  provider: GitHub
+  properties:
+    repository: aws/somethingCool
+    branch: main
+
+stages:
+    -
+      name: test
+
+preview:
+  branches: ["feature/*", "fix/*"]
+  ttl: 48h
+`,
+			expectedManifest: &Pipeline{
+				Name:    "pipepiper",
+				Version: Ver1,
+				Source: &Source{
+					ProviderName: "GitHub",
+					Properties: map[string]interface{}{
+						"repository": "aws/somethingCool",
+						"branch":     defaultGHBranch,
+					},
+				},
+				Stages: []PipelineStage{
+					{
+						Name: "test",
+					},
+				},
+				Preview: &Preview{
+					Branches: []string{"feature/*", "fix/*"},
+					TTL:      durationp(48 * time.Hour),
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
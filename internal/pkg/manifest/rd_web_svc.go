@@ -28,6 +28,8 @@ type RequestDrivenWebServiceConfig struct {
 	InstanceConfig                    AppRunnerInstanceConfig              `yaml:",inline"`
 	ImageConfig                       ImageWithPort                        `yaml:"image"`
 	Variables                         map[string]string                    `yaml:"variables"`
+	EnvAddons                         EnvAddonsImports                     `yaml:"env_addons"`
+	Permissions                       []IAMPermission                      `yaml:"permissions"`
 	StartCommand                      *string                              `yaml:"command"`
 	Tags                              map[string]string                    `yaml:"tags"`
 	PublishConfig                     PublishConfig                        `yaml:"publish"`
@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/copilot-cli/internal/pkg/template"
+	"github.com/imdario/mergo"
+)
+
+const (
+	staticSiteManifestPath = "workloads/services/static-site/manifest.yml"
+
+	defaultStaticSiteIndexDocument = "index.html"
+)
+
+// StaticSite holds the configuration to build and deploy a static website to Amazon S3 behind Amazon CloudFront.
+type StaticSite struct {
+	Workload         `yaml:",inline"`
+	StaticSiteConfig `yaml:",inline"`
+	// Use *StaticSiteConfig because of https://github.com/imdario/mergo/issues/146
+	Environments map[string]*StaticSiteConfig `yaml:",flow"` // Fields to override per environment.
+
+	parser template.Parser
+}
+
+// StaticSiteConfig holds the configuration that can be overridden per environments.
+type StaticSiteConfig struct {
+	Source        StaticSiteSource  `yaml:"source"`
+	IndexDocument *string           `yaml:"index_document"`
+	ErrorDocument *string           `yaml:"error_document"`
+	Aliases       Alias             `yaml:"aliases"`
+	Tags          map[string]string `yaml:"tags"`
+}
+
+// StaticSiteSource represents where the files of the site are and how to build them.
+type StaticSiteSource struct {
+	Path  *string `yaml:"path"`  // Directory, relative to the workspace root, whose files are uploaded.
+	Build *string `yaml:"build"` // Optional command run from the workspace root before uploading the files.
+}
+
+// StaticSiteProps contains properties for creating a new static site manifest.
+type StaticSiteProps struct {
+	Name       string
+	SourcePath string
+}
+
+// NewStaticSite creates a new static site manifest with default values.
+func NewStaticSite(props StaticSiteProps) *StaticSite {
+	site := newDefaultStaticSite()
+	site.Name = stringP(props.Name)
+	site.Source.Path = stringP(props.SourcePath)
+	site.parser = template.New()
+	return site
+}
+
+// MarshalBinary serializes the manifest object into a binary YAML document.
+// Implements the encoding.BinaryMarshaler interface.
+func (s *StaticSite) MarshalBinary() ([]byte, error) {
+	content, err := s.parser.Parse(staticSiteManifestPath, *s)
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// BuildRequired returns false since a static site is never built from a Dockerfile.
+func (s *StaticSite) BuildRequired() (bool, error) {
+	return false, nil
+}
+
+// ApplyEnv returns the static site manifest with environment overrides.
+// If the environment passed in does not have any overrides then it returns itself.
+func (s StaticSite) ApplyEnv(envName string) (WorkloadManifest, error) {
+	overrideConfig, ok := s.Environments[envName]
+	if !ok {
+		return &s, nil
+	}
+	if overrideConfig == nil {
+		return &s, nil
+	}
+	for _, t := range defaultTransformers {
+		// Apply overrides to the original static site s.
+		err := mergo.Merge(&s, StaticSite{
+			StaticSiteConfig: *overrideConfig,
+		}, mergo.WithOverride, mergo.WithTransformers(t))
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.Environments = nil
+	return &s, nil
+}
+
+// newDefaultStaticSite returns a static site with only the default values set.
+func newDefaultStaticSite() *StaticSite {
+	return &StaticSite{
+		Workload: Workload{
+			Type: aws.String(StaticSiteType),
+		},
+		StaticSiteConfig: StaticSiteConfig{
+			IndexDocument: aws.String(defaultStaticSiteIndexDocument),
+		},
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/copilot-cli/internal/pkg/template"
+	"github.com/aws/copilot-cli/internal/pkg/template/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStaticSite(t *testing.T) {
+	testCases := map[string]struct {
+		input StaticSiteProps
+
+		wanted *StaticSite
+	}{
+		"should return an instance of StaticSite": {
+			input: StaticSiteProps{
+				Name:       "frontend",
+				SourcePath: "dist",
+			},
+
+			wanted: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path: aws.String("dist"),
+					},
+					IndexDocument: aws.String("index.html"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			site := NewStaticSite(tc.input)
+
+			require.Equal(t, tc.wanted.Workload, site.Workload)
+			require.Equal(t, tc.wanted.StaticSiteConfig, site.StaticSiteConfig)
+			require.Nil(t, site.Environments)
+		})
+	}
+}
+
+func TestStaticSite_UnmarshalYaml(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wanted *StaticSite
+	}{
+		"should unmarshal every field": {
+			inContent: []byte(`name: frontend
+type: Static Site
+source:
+  path: dist
+  build: npm run build
+index_document: home.html
+error_document: 404.html
+aliases:
+  - example.com
+  - www.example.com
+tags:
+  team: web
+environments:
+  prod:
+    source:
+      build: npm run build:prod
+`),
+			wanted: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path:  aws.String("dist"),
+						Build: aws.String("npm run build"),
+					},
+					IndexDocument: aws.String("home.html"),
+					ErrorDocument: aws.String("404.html"),
+					Aliases: Alias{
+						StringSlice: []string{"example.com", "www.example.com"},
+					},
+					Tags: map[string]string{
+						"team": "web",
+					},
+				},
+				Environments: map[string]*StaticSiteConfig{
+					"prod": {
+						Source: StaticSiteSource{
+							Build: aws.String("npm run build:prod"),
+						},
+					},
+				},
+			},
+		},
+		"should keep the default index document": {
+			inContent: []byte(`name: frontend
+type: Static Site
+source:
+  path: dist
+`),
+			wanted: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path: aws.String("dist"),
+					},
+					IndexDocument: aws.String("index.html"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := UnmarshalWorkload(tc.inContent)
+
+			require.NoError(t, err)
+			site, ok := got.(*StaticSite)
+			require.True(t, ok)
+			require.Equal(t, tc.wanted.Workload, site.Workload)
+			require.Equal(t, tc.wanted.StaticSiteConfig, site.StaticSiteConfig)
+			require.Equal(t, tc.wanted.Environments, site.Environments)
+		})
+	}
+}
+
+func TestStaticSite_MarshalBinary(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest *StaticSite
+
+		wantedBinary []byte
+		wantedError  error
+	}{
+		"error parsing template": {
+			inManifest: &StaticSite{},
+
+			wantedError: errors.New("test error"),
+		},
+		"returns rendered content": {
+			inManifest: &StaticSite{},
+
+			wantedBinary: []byte("test content"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockParser := mocks.NewMockParser(ctrl)
+			tc.inManifest.parser = mockParser
+			var wantedTemplContent *template.Content = nil
+
+			if tc.wantedBinary != nil {
+				wantedTemplContent = &template.Content{Buffer: bytes.NewBufferString(string(tc.wantedBinary))}
+			}
+
+			mockParser.
+				EXPECT().
+				Parse(staticSiteManifestPath, *tc.inManifest, gomock.Any()).
+				Return(wantedTemplContent, tc.wantedError)
+
+			b, err := tc.inManifest.MarshalBinary()
+
+			require.Equal(t, tc.wantedError, err)
+			require.Equal(t, tc.wantedBinary, b)
+		})
+	}
+}
+
+func TestStaticSite_ApplyEnv(t *testing.T) {
+	testCases := map[string]struct {
+		in         *StaticSite
+		envToApply string
+
+		wanted *StaticSite
+	}{
+		"without existing environments": {
+			in: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path: aws.String("dist"),
+					},
+				},
+			},
+			envToApply: "prod",
+
+			wanted: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path: aws.String("dist"),
+					},
+				},
+			},
+		},
+		"with overrides": {
+			in: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path:  aws.String("dist"),
+						Build: aws.String("npm run build"),
+					},
+					IndexDocument: aws.String("index.html"),
+					Aliases: Alias{
+						StringSlice: []string{"test.example.com"},
+					},
+				},
+				Environments: map[string]*StaticSiteConfig{
+					"prod": {
+						Source: StaticSiteSource{
+							Build: aws.String("npm run build:prod"),
+						},
+						Aliases: Alias{
+							String: aws.String("example.com"),
+						},
+					},
+				},
+			},
+			envToApply: "prod",
+
+			wanted: &StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+					Type: aws.String(StaticSiteType),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path:  aws.String("dist"),
+						Build: aws.String("npm run build:prod"),
+					},
+					IndexDocument: aws.String("index.html"),
+					Aliases: Alias{
+						String: aws.String("example.com"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.in.ApplyEnv(tc.envToApply)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
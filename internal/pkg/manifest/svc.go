@@ -25,6 +25,8 @@ const (
 	BackendServiceType = "Backend Service"
 	// WorkerServiceType is a worker service that manages the consumption of messages.
 	WorkerServiceType = "Worker Service"
+	// StaticSiteType is a static website hosted in an S3 bucket and served by a CloudFront distribution.
+	StaticSiteType = "Static Site"
 )
 
 // ServiceTypes returns the list of supported service manifest types.
@@ -34,6 +36,7 @@ func ServiceTypes() []string {
 		LoadBalancedWebServiceType,
 		BackendServiceType,
 		WorkerServiceType,
+		StaticSiteType,
 	}
 }
 
@@ -184,13 +187,17 @@ type AdvancedCount struct {
 	ResponseTime *time.Duration `yaml:"response_time"`
 	QueueScaling QueueScaling   `yaml:"queue_delay"`
 
+	CustomMetrics []CustomMetricScaling `yaml:"custom_metrics"`
+	Schedules     []ScheduledScaling    `yaml:"schedules"`
+
 	workloadType string
 }
 
 // IsEmpty returns whether AdvancedCount is empty.
 func (a *AdvancedCount) IsEmpty() bool {
 	return a.Range.IsEmpty() && a.CPU == nil && a.Memory == nil &&
-		a.Requests == nil && a.ResponseTime == nil && a.Spot == nil && a.QueueScaling.IsEmpty()
+		a.Requests == nil && a.ResponseTime == nil && a.Spot == nil && a.QueueScaling.IsEmpty() &&
+		len(a.CustomMetrics) == 0 && len(a.Schedules) == 0
 }
 
 // IgnoreRange returns whether desiredCount is specified on spot capacity
@@ -205,17 +212,20 @@ func (a *AdvancedCount) hasAutoscaling() bool {
 func (a *AdvancedCount) validScalingFields() []string {
 	switch a.workloadType {
 	case LoadBalancedWebServiceType:
-		return []string{"cpu_percentage", "memory_percentage", "requests", "response_time"}
+		return []string{"cpu_percentage", "memory_percentage", "requests", "response_time", "custom_metrics", "schedules"}
 	case BackendServiceType:
-		return []string{"cpu_percentage", "memory_percentage"}
+		return []string{"cpu_percentage", "memory_percentage", "custom_metrics", "schedules"}
 	case WorkerServiceType:
-		return []string{"cpu_percentage", "memory_percentage", "queue_delay"}
+		return []string{"cpu_percentage", "memory_percentage", "queue_delay", "custom_metrics", "schedules"}
 	default:
 		return nil
 	}
 }
 
 func (a *AdvancedCount) hasScalingFieldsSet() bool {
+	if len(a.CustomMetrics) != 0 || len(a.Schedules) != 0 {
+		return true
+	}
 	switch a.workloadType {
 	case LoadBalancedWebServiceType:
 		return a.CPU != nil || a.Memory != nil || a.Requests != nil || a.ResponseTime != nil
@@ -235,6 +245,8 @@ func (a *AdvancedCount) unsetAutoscaling() {
 	a.Requests = nil
 	a.ResponseTime = nil
 	a.QueueScaling = QueueScaling{}
+	a.CustomMetrics = nil
+	a.Schedules = nil
 }
 
 // QueueScaling represents the configuration to scale a service based on a SQS queue.
@@ -258,6 +270,45 @@ func (qs *QueueScaling) AcceptableBacklogPerTask() (int, error) {
 	return int(v), nil
 }
 
+// metricExpressionQueryID is the ID of the metric math expression in the rendered scaling policy.
+const metricExpressionQueryID = "expression"
+
+// CustomMetricScaling represents a target tracking scaling policy on a CloudWatch metric
+// or on a metric math expression over multiple metrics.
+type CustomMetricScaling struct {
+	CloudWatchMetric `yaml:",inline"`
+	Expression       *string                 `yaml:"expression"` // Mutually exclusive with the CloudWatchMetric fields.
+	Metrics          []CloudWatchMetricQuery `yaml:"metrics"`    // Metrics referenced by the Expression.
+	Target           *float64                `yaml:"target"`
+}
+
+// CloudWatchMetric identifies a CloudWatch metric and the statistic to aggregate its data points with.
+type CloudWatchMetric struct {
+	Namespace  *string           `yaml:"namespace"`
+	MetricName *string           `yaml:"metric_name"`
+	Dimensions map[string]string `yaml:"dimensions"`
+	Statistic  *string           `yaml:"statistic"`
+}
+
+// IsEmpty returns true if the metric is not set.
+func (m *CloudWatchMetric) IsEmpty() bool {
+	return m.Namespace == nil && m.MetricName == nil && m.Dimensions == nil && m.Statistic == nil
+}
+
+// CloudWatchMetricQuery represents a metric that can be referenced by its ID in a metric math expression.
+type CloudWatchMetricQuery struct {
+	ID               *string `yaml:"id"`
+	CloudWatchMetric `yaml:",inline"`
+}
+
+// ScheduledScaling represents a change of the minimum and maximum number of tasks on a recurring schedule.
+type ScheduledScaling struct {
+	Cron     *string `yaml:"cron"`
+	TimeZone *string `yaml:"timezone"`
+	Min      *int    `yaml:"min"`
+	Max      *int    `yaml:"max"`
+}
+
 // IsTypeAService returns if manifest type is service.
 func IsTypeAService(t string) bool {
 	for _, serviceType := range ServiceTypes() {
@@ -56,6 +56,16 @@ sidecars:
     port: 2000/udp
     image: 123456789012.dkr.ecr.us-east-2.amazonaws.com/xray-daemon
     credentialsParameter: some arn
+init_containers:
+  migrate:
+    image: 123456789012.dkr.ecr.us-east-2.amazonaws.com/migrate
+    command: ["./migrate", "up"]
+    variables:
+      DB_NAME: frontend
+    depends_on:
+      fetch: success
+  fetch:
+    image: public.ecr.aws/aws-cli/aws-cli
 logging:
   destination:
     Name: cloudwatch
@@ -65,6 +75,10 @@ logging:
   secretOptions:
     LOG_TOKEN: LOG_TOKEN
   configFilePath: /extra.conf
+deployment:
+  pre_deploy:
+    command: ./migrate up
+    timeout: 5m
 environments:
   test:
     count: 3
@@ -137,6 +151,25 @@ environments:
 								CredsParam: aws.String("some arn"),
 							},
 						},
+						InitContainers: map[string]*InitContainerConfig{
+							"migrate": {
+								Image: aws.String("123456789012.dkr.ecr.us-east-2.amazonaws.com/migrate"),
+								Variables: map[string]string{
+									"DB_NAME": "frontend",
+								},
+								DependsOn: DependsOn{
+									"fetch": "success",
+								},
+								ImageOverride: ImageOverride{
+									Command: CommandOverride{
+										StringSlice: []string{"./migrate", "up"},
+									},
+								},
+							},
+							"fetch": {
+								Image: aws.String("public.ecr.aws/aws-cli/aws-cli"),
+							},
+						},
 						Logging: Logging{
 							Destination: map[string]string{
 								"exclude-pattern": "^.*[aeiou]$",
@@ -154,6 +187,14 @@ environments:
 								Placement: placementP(PublicSubnetPlacement),
 							},
 						},
+						DeployConfig: DeploymentConfiguration{
+							PreDeploy: PreDeployTask{
+								Command: CommandOverride{
+									String: aws.String("./migrate up"),
+								},
+								Timeout: durationp(5 * time.Minute),
+							},
+						},
 						TaskDefOverrides: []OverrideRule{
 							{
 								Path: "ContainerDefinitions[0].Ulimits[-].HardLimit",
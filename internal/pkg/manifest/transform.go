@@ -25,9 +25,12 @@ var defaultTransformers = []mergo.Transformers{
 	advancedCountTransformer{},
 	rangeTransformer{},
 	efsConfigOrBoolTransformer{},
+	serviceConnectBoolOrArgsTransformer{},
 	efsVolumeConfigurationTransformer{},
 	sqsQueueOrBoolTransformer{},
 	routingRuleConfigOrBoolTransformer{},
+	webACLArgsOrARNTransformer{},
+	cdnBoolOrArgsTransformer{},
 	secretTransformer{},
 }
 
@@ -135,11 +138,13 @@ func (t platformArgsOrStringTransformer) Transformer(typ reflect.Type) func(dst,
 	return func(dst, src reflect.Value) error {
 		dstStruct, srcStruct := dst.Interface().(PlatformArgsOrString), src.Interface().(PlatformArgsOrString)
 
+		// The launch type settings can only be specified in the map form, so they're kept when overriding the os/arch.
 		if srcStruct.PlatformString != nil {
-			dstStruct.PlatformArgs = PlatformArgs{}
+			dstStruct.PlatformArgs.OSFamily = nil
+			dstStruct.PlatformArgs.Arch = nil
 		}
 
-		if !srcStruct.PlatformArgs.isEmpty() {
+		if !srcStruct.PlatformArgs.isOSArchEmpty() {
 			dstStruct.PlatformString = nil
 		}
 
@@ -176,6 +181,58 @@ func (t healthCheckArgsOrStringTransformer) Transformer(typ reflect.Type) func(d
 	}
 }
 
+type webACLArgsOrARNTransformer struct{}
+
+// Transformer returns custom merge logic for WebACLArgsOrARN's fields.
+func (t webACLArgsOrARNTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(WebACLArgsOrARN{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(WebACLArgsOrARN), src.Interface().(WebACLArgsOrARN)
+
+		if srcStruct.ARN != nil {
+			dstStruct.Advanced = WebACLArgs{}
+		}
+
+		if !srcStruct.Advanced.isEmpty() {
+			dstStruct.ARN = nil
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+type cdnBoolOrArgsTransformer struct{}
+
+// Transformer returns custom merge logic for CDNBoolOrArgs's fields.
+func (t cdnBoolOrArgsTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(CDNBoolOrArgs{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(CDNBoolOrArgs), src.Interface().(CDNBoolOrArgs)
+
+		if !srcStruct.Advanced.IsEmpty() {
+			dstStruct.Enable = nil
+		}
+
+		if srcStruct.Enable != nil {
+			dstStruct.Advanced = CDNArgs{}
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
 type countTransformer struct{}
 
 // Transformer returns custom merge logic for Count's fields.
@@ -279,6 +336,31 @@ func (t efsConfigOrBoolTransformer) Transformer(typ reflect.Type) func(dst, src
 	}
 }
 
+type serviceConnectBoolOrArgsTransformer struct{}
+
+// Transformer returns custom merge logic for ServiceConnectBoolOrArgs's fields.
+func (t serviceConnectBoolOrArgsTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(ServiceConnectBoolOrArgs{}) {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(ServiceConnectBoolOrArgs), src.Interface().(ServiceConnectBoolOrArgs)
+
+		if !srcStruct.Advanced.IsEmpty() {
+			dstStruct.Enable = nil
+		}
+
+		if srcStruct.Enable != nil {
+			dstStruct.Advanced = ServiceConnectArgs{}
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
 type efsVolumeConfigurationTransformer struct{}
 
 // Transformer returns custom merge logic for EFSVolumeConfiguration's fields.
@@ -371,6 +371,42 @@ func TestPlatformArgsOrStringTransformer_Transformer(t *testing.T) {
 				p.PlatformString = &mockPlatformStr
 			},
 		},
+		"string kept if args only set the launch type": {
+			original: func(p *PlatformArgsOrString) {
+				p.PlatformString = &mockPlatformStr
+			},
+			override: func(p *PlatformArgsOrString) {
+				p.PlatformArgs = PlatformArgs{
+					LaunchType: aws.String("ec2"),
+				}
+			},
+			wanted: func(p *PlatformArgsOrString) {
+				p.PlatformString = &mockPlatformStr
+				p.PlatformArgs = PlatformArgs{
+					LaunchType: aws.String("ec2"),
+				}
+			},
+		},
+		"launch type kept if string is not nil": {
+			original: func(p *PlatformArgsOrString) {
+				p.PlatformArgs = PlatformArgs{
+					OSFamily:    aws.String("mock"),
+					Arch:        aws.String("platformTest"),
+					LaunchType:  aws.String("ec2"),
+					NetworkMode: aws.String("bridge"),
+				}
+			},
+			override: func(p *PlatformArgsOrString) {
+				p.PlatformString = &mockPlatformStr
+			},
+			wanted: func(p *PlatformArgsOrString) {
+				p.PlatformString = &mockPlatformStr
+				p.PlatformArgs = PlatformArgs{
+					LaunchType:  aws.String("ec2"),
+					NetworkMode: aws.String("bridge"),
+				}
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -456,6 +492,120 @@ func TestHealthCheckArgsOrStringTransformer_Transformer(t *testing.T) {
 	}
 }
 
+func TestWebACLArgsOrARNTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(w *WebACLArgsOrARN)
+		override func(w *WebACLArgsOrARN)
+		wanted   func(w *WebACLArgsOrARN)
+	}{
+		"arn set to empty if args is not nil": {
+			original: func(w *WebACLArgsOrARN) {
+				w.ARN = aws.String("mockARN")
+			},
+			override: func(w *WebACLArgsOrARN) {
+				w.Advanced = WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+				}
+			},
+			wanted: func(w *WebACLArgsOrARN) {
+				w.Advanced = WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet"},
+				}
+			},
+		},
+		"args set to empty if arn is not nil": {
+			original: func(w *WebACLArgsOrARN) {
+				w.Advanced = WebACLArgs{
+					RateLimit: aws.Int(2000),
+				}
+			},
+			override: func(w *WebACLArgsOrARN) {
+				w.ARN = aws.String("mockARN")
+			},
+			wanted: func(w *WebACLArgsOrARN) {
+				w.ARN = aws.String("mockARN")
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted WebACLArgsOrARN
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use custom transformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(webACLArgsOrARNTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestCDNBoolOrArgsTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(c *CDNBoolOrArgs)
+		override func(c *CDNBoolOrArgs)
+		wanted   func(c *CDNBoolOrArgs)
+	}{
+		"bool set to empty if args is not nil": {
+			original: func(c *CDNBoolOrArgs) {
+				c.Enable = aws.Bool(true)
+			},
+			override: func(c *CDNBoolOrArgs) {
+				c.Advanced = CDNArgs{
+					CachePolicy: aws.String("CachingOptimized"),
+				}
+			},
+			wanted: func(c *CDNBoolOrArgs) {
+				c.Advanced = CDNArgs{
+					CachePolicy: aws.String("CachingOptimized"),
+				}
+			},
+		},
+		"args set to empty if bool is not nil": {
+			original: func(c *CDNBoolOrArgs) {
+				c.Advanced = CDNArgs{
+					OriginShield: aws.Bool(true),
+				}
+			},
+			override: func(c *CDNBoolOrArgs) {
+				c.Enable = aws.Bool(false)
+			},
+			wanted: func(c *CDNBoolOrArgs) {
+				c.Enable = aws.Bool(false)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted CDNBoolOrArgs
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use custom transformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(cdnBoolOrArgsTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
 func TestCountTransformer_Transformer(t *testing.T) {
 	testCases := map[string]struct {
 		original func(c *Count)
@@ -704,6 +854,63 @@ func TestEfsConfigOrBoolTransformer_Transformer(t *testing.T) {
 	}
 }
 
+func TestServiceConnectBoolOrArgsTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(s *ServiceConnectBoolOrArgs)
+		override func(s *ServiceConnectBoolOrArgs)
+		wanted   func(s *ServiceConnectBoolOrArgs)
+	}{
+		"bool set to empty if config is not nil": {
+			original: func(s *ServiceConnectBoolOrArgs) {
+				s.Enable = aws.Bool(true)
+			},
+			override: func(s *ServiceConnectBoolOrArgs) {
+				s.Advanced = ServiceConnectArgs{
+					Alias: aws.String("api"),
+				}
+			},
+			wanted: func(s *ServiceConnectBoolOrArgs) {
+				s.Advanced = ServiceConnectArgs{
+					Alias: aws.String("api"),
+				}
+			},
+		},
+		"config set to empty if bool is not nil": {
+			original: func(s *ServiceConnectBoolOrArgs) {
+				s.Advanced = ServiceConnectArgs{
+					Alias: aws.String("api"),
+				}
+			},
+			override: func(s *ServiceConnectBoolOrArgs) {
+				s.Enable = aws.Bool(false)
+			},
+			wanted: func(s *ServiceConnectBoolOrArgs) {
+				s.Enable = aws.Bool(false)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted ServiceConnectBoolOrArgs
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use custom transformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(serviceConnectBoolOrArgsTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
 func TestEfsVolumeConfigurationTransformer_Transformer(t *testing.T) {
 	testCases := map[string]struct {
 		original func(e *EFSVolumeConfiguration)
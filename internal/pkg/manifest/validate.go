@@ -7,13 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/copilot-cli/internal/pkg/graph"
 	"github.com/dustin/go-humanize/english"
 )
@@ -30,12 +33,32 @@ const (
 	ephemeralMaxValueGiB = 200
 
 	envFileExt = ".env"
+
+	// Preview environments are swept once an hour, so a shorter TTL would not be honored.
+	minPreviewTTL = time.Hour
+
+	// Limits of application load balancer listener rules. Priority 50000 is reserved for the root path rule.
+	minListenerRulePriority        = 1
+	maxListenerRulePriority        = 49999
+	maxListenerRuleConditionValues = 5
+	maxTargetGroupWeight           = 999
+	maxFixedResponseBodyLength     = 1024
+
+	// Limits of the rate-based rule of a web ACL.
+	minWAFRateLimit = 100
+	maxWAFRateLimit = 2000000000
+
+	// The pre-deploy task is awaited by a Lambda function, which must report back before its 15 minute timeout.
+	minPreDeployTimeout = time.Minute
+	maxPreDeployTimeout = 14 * time.Minute
 )
 
 const (
 	// Protocols.
-	TCP = "TCP"
-	tls = "TLS"
+	TCP    = "TCP"
+	udp    = "UDP"
+	tcpUDP = "TCP_UDP"
+	tls    = "TLS"
 
 	// Tracing vendors.
 	awsXRAY = "awsxray"
@@ -44,18 +67,30 @@ const (
 var (
 	intRangeBandRegexp  = regexp.MustCompile(`^(\d+)-(\d+)$`)
 	volumesPathRegexp   = regexp.MustCompile(`^[a-zA-Z0-9\-\.\_/]+$`)
-	awsSNSTopicRegexp   = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)   // Validates that an expression contains only letters, numbers, underscores, and hyphens.
-	awsNameRegexp       = regexp.MustCompile(`^[a-z][a-z0-9\-]+$`) // Validates that an expression starts with a letter and only contains letters, numbers, and hyphens.
-	punctuationRegExp   = regexp.MustCompile(`[\.\-]{2,}`)         // Check for consecutive periods or dashes.
-	trailingPunctRegExp = regexp.MustCompile(`[\-\.]$`)            // Check for trailing dash or dot.
+	awsSNSTopicRegexp   = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)                                               // Validates that an expression contains only letters, numbers, underscores, and hyphens.
+	awsNameRegexp       = regexp.MustCompile(`^[a-z][a-z0-9\-]+$`)                                             // Validates that an expression starts with a letter and only contains letters, numbers, and hyphens.
+	punctuationRegExp   = regexp.MustCompile(`[\.\-]{2,}`)                                                     // Check for consecutive periods or dashes.
+	trailingPunctRegExp = regexp.MustCompile(`[\-\.]$`)                                                        // Check for trailing dash or dot.
+	cfnLogicalIDRegexp  = regexp.MustCompile(`^[a-zA-Z0-9]+$`)                                                 // Validates that an expression is a valid CloudFormation logical ID.
+	iamActionRegexp     = regexp.MustCompile(`^(\*|[a-zA-Z0-9-]+:[a-zA-Z0-9*?]+)$`)                            // Validates that an expression is an IAM action such as "s3:GetObject".
+	iamConditionRegexp  = regexp.MustCompile(`^([a-zA-Z]+:)?[a-zA-Z]+(IfExists)?$`)                            // Validates that an expression is an IAM condition operator such as "ForAnyValue:StringLike".
+	managedRuleRegexp   = regexp.MustCompile(`^AWSManagedRules[a-zA-Z0-9]+$`)                                  // Validates that an expression is an AWS managed rule group such as "AWSManagedRulesCommonRuleSet".
+	cachePolicyIDRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`) // Validates that an expression is the ID of a CloudFront cache policy.
+	metricQueryIDRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)                                           // Validates that an expression is the ID of a metric in a metric math expression.
 
 	essentialContainerDependsOnValidStatuses = []string{dependsOnStart, dependsOnHealthy}
 	dependsOnValidStatuses                   = []string{dependsOnStart, dependsOnComplete, dependsOnSuccess, dependsOnHealthy}
-	nlbValidProtocols                        = []string{TCP, tls}
+	nlbValidProtocols                        = []string{TCP, udp, tcpUDP, tls}
+	nlbALPNPolicies                          = []string{"HTTP1Only", "HTTP2Only", "HTTP2Optional", "HTTP2Preferred", "None"}
 	TracingValidVendors                      = []string{awsXRAY}
+	metricValidStatistics                    = []string{"Average", "Minimum", "Maximum", "SampleCount", "Sum"}
 	ecsRollingUpdateStrategies               = []string{ECSDefaultRollingUpdateStrategy, ECSRecreateRollingUpdateStrategy}
 
 	httpProtocolVersions = []string{"GRPC", "HTTP1", "HTTP2"}
+	iamEffects           = []string{"Allow", "Deny"}
+
+	redirectProtocols         = []string{"HTTP", "HTTPS", "#{protocol}"}
+	fixedResponseContentTypes = []string{"text/plain", "text/css", "text/html", "application/javascript", "application/json"}
 
 	invalidTaskDefOverridePathRegexp = []string{`Family`, `ContainerDefinitions\[\d+\].Name`}
 )
@@ -79,18 +114,39 @@ func (l LoadBalancedWebService) Validate() error {
 	}); err != nil {
 		return fmt.Errorf("validate HTTP load balancer target: %w", err)
 	}
+	for i, rule := range l.RoutingRule.AdditionalRules {
+		for j, target := range rule.Targets {
+			if err = validateTargetContainer(validateTargetContainerOpts{
+				mainContainerName: aws.StringValue(l.Name),
+				targetContainer:   target.Container,
+				sidecarConfig:     l.Sidecars,
+			}); err != nil {
+				return fmt.Errorf(`validate "http.additional_rules[%d].targets[%d]": %w`, i, j, err)
+			}
+		}
+	}
 	if err = validateTargetContainer(validateTargetContainerOpts{
 		mainContainerName: aws.StringValue(l.Name),
-		targetContainer:   l.NLBConfig.TargetContainer,
+		targetContainer:   l.NLBConfig.Listener.TargetContainer,
 		sidecarConfig:     l.Sidecars,
 	}); err != nil {
 		return fmt.Errorf("validate network load balancer target: %w", err)
 	}
+	for i, listener := range l.NLBConfig.AdditionalListeners {
+		if err = validateTargetContainer(validateTargetContainerOpts{
+			mainContainerName: aws.StringValue(l.Name),
+			targetContainer:   listener.TargetContainer,
+			sidecarConfig:     l.Sidecars,
+		}); err != nil {
+			return fmt.Errorf(`validate "nlb.additional_listeners[%d]" target: %w`, i, err)
+		}
+	}
 	if err = validateContainerDeps(validateDependenciesOpts{
-		sidecarConfig:     l.Sidecars,
-		imageConfig:       l.ImageConfig.Image,
-		mainContainerName: aws.StringValue(l.Name),
-		logging:           l.Logging,
+		sidecarConfig:       l.Sidecars,
+		initContainerConfig: l.InitContainers,
+		imageConfig:         l.ImageConfig.Image,
+		mainContainerName:   aws.StringValue(l.Name),
+		logging:             l.Logging,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
@@ -101,16 +157,44 @@ func (d DeploymentConfiguration) Validate() error {
 	if d.isEmpty() {
 		return nil
 	}
+	if d.Rolling != nil {
+		if err := validateRollingStrategy(aws.StringValue(d.Rolling)); err != nil {
+			return err
+		}
+	}
+	if err := d.PreDeploy.Validate(); err != nil {
+		return fmt.Errorf(`validate "pre_deploy": %w`, err)
+	}
+	return nil
+}
+
+func validateRollingStrategy(strategy string) error {
 	for _, validStrategy := range ecsRollingUpdateStrategies {
-		if strings.EqualFold(aws.StringValue(d.Rolling), validStrategy) {
+		if strings.EqualFold(strategy, validStrategy) {
 			return nil
 		}
 	}
 	return fmt.Errorf("invalid rolling deployment strategy %s, must be one of %s",
-		aws.StringValue(d.Rolling),
+		strategy,
 		english.WordSeries(ecsRollingUpdateStrategies, "or"))
 }
 
+// Validate returns nil if PreDeployTask is configured correctly.
+func (p PreDeployTask) Validate() error {
+	if p.IsEmpty() {
+		return nil
+	}
+	if p.Command.String == nil && len(p.Command.StringSlice) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField: "command",
+		}
+	}
+	if p.Timeout != nil && (*p.Timeout < minPreDeployTimeout || *p.Timeout > maxPreDeployTimeout) {
+		return fmt.Errorf(`"timeout" must be between %s and %s`, minPreDeployTimeout, maxPreDeployTimeout)
+	}
+	return nil
+}
+
 // Validate returns nil if LoadBalancedWebServiceConfig is configured correctly.
 func (l LoadBalancedWebServiceConfig) Validate() error {
 	var err error
@@ -131,6 +215,9 @@ func (l LoadBalancedWebServiceConfig) Validate() error {
 	if err = l.RoutingRule.Validate(); err != nil {
 		return fmt.Errorf(`validate "http": %w`, err)
 	}
+	if l.RoutingRule.HostedZone != nil {
+		return fmt.Errorf(`"http.hosted_zone" is not supported for %ss`, LoadBalancedWebServiceType)
+	}
 	if err = l.TaskConfig.Validate(); err != nil {
 		return err
 	}
@@ -142,9 +229,17 @@ func (l LoadBalancedWebServiceConfig) Validate() error {
 			return fmt.Errorf(`validate "sidecars[%s]": %w`, k, err)
 		}
 	}
+	for k, v := range l.InitContainers {
+		if err = v.Validate(); err != nil {
+			return fmt.Errorf(`validate "init_containers[%s]": %w`, k, err)
+		}
+	}
 	if err = l.Network.Validate(); err != nil {
 		return fmt.Errorf(`validate "network": %w`, err)
 	}
+	if !l.Network.VPC.Ingress.IsEmpty() {
+		return fmt.Errorf(`"network.vpc.ingress" is not supported for %ss`, LoadBalancedWebServiceType)
+	}
 	if err = l.PublishConfig.Validate(); err != nil {
 		return fmt.Errorf(`validate "publish": %w`, err)
 	}
@@ -169,6 +264,21 @@ func (l LoadBalancedWebServiceConfig) Validate() error {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if l.TaskConfig.IsEC2() {
+		if err = validateEC2(validateEC2Opts{
+			Spot:              l.Count.AdvancedCount.Spot,
+			SpotFrom:          l.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			EphemeralStorage:  l.Storage.Ephemeral,
+			NetworkMode:       l.Platform.NetworkMode(),
+			HasNLB:            !l.NLBConfig.IsEmpty(),
+			HasIngress:        !l.Network.VPC.Ingress.IsEmpty(),
+			HasSidecars:       len(l.Sidecars) != 0,
+			HasServiceConnect: l.Network.Connect.Enabled(),
+			HasPreDeploy:      !l.DeployConfig.PreDeploy.IsEmpty(),
+		}); err != nil {
+			return fmt.Errorf("validate EC2: %w", err)
+		}
+	}
 	if err = l.NLBConfig.Validate(); err != nil {
 		return fmt.Errorf(`validate "nlb": %w`, err)
 	}
@@ -187,11 +297,19 @@ func (b BackendService) Validate() error {
 	if err = b.Workload.Validate(); err != nil {
 		return err
 	}
-	if err = validateContainerDeps(validateDependenciesOpts{
-		sidecarConfig:     b.Sidecars,
-		imageConfig:       b.ImageConfig.Image,
+	if err = validateTargetContainer(validateTargetContainerOpts{
 		mainContainerName: aws.StringValue(b.Name),
-		logging:           b.Logging,
+		targetContainer:   b.RoutingRule.targetContainer(),
+		sidecarConfig:     b.Sidecars,
+	}); err != nil {
+		return fmt.Errorf("validate HTTP load balancer target: %w", err)
+	}
+	if err = validateContainerDeps(validateDependenciesOpts{
+		sidecarConfig:       b.Sidecars,
+		initContainerConfig: b.InitContainers,
+		imageConfig:         b.ImageConfig.Image,
+		mainContainerName:   aws.StringValue(b.Name),
+		logging:             b.Logging,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
@@ -207,6 +325,29 @@ func (b BackendServiceConfig) Validate() error {
 	if err = b.ImageOverride.Validate(); err != nil {
 		return err
 	}
+	if !b.RoutingRule.IsEmpty() {
+		if err = b.RoutingRule.Validate(); err != nil {
+			return fmt.Errorf(`validate "http": %w`, err)
+		}
+		if !b.RoutingRule.Disabled() && b.ImageConfig.Port == nil {
+			return &errFieldMustBeSpecified{
+				missingField:      "image.port",
+				conditionalFields: []string{"http"},
+			}
+		}
+		if len(b.RoutingRule.AdditionalRules) != 0 {
+			return fmt.Errorf(`"http.additional_rules" is not supported for %ss`, BackendServiceType)
+		}
+		if !b.RoutingRule.WAF.IsEmpty() {
+			return fmt.Errorf(`"http.waf" is not supported for %ss`, BackendServiceType)
+		}
+		if b.RoutingRule.HostedZone != nil && b.RoutingRule.Alias.IsEmpty() {
+			return &errFieldMustBeSpecified{
+				missingField:      "http.alias",
+				conditionalFields: []string{"http.hosted_zone"},
+			}
+		}
+	}
 	if err = b.TaskConfig.Validate(); err != nil {
 		return err
 	}
@@ -218,9 +359,26 @@ func (b BackendServiceConfig) Validate() error {
 			return fmt.Errorf(`validate "sidecars[%s]": %w`, k, err)
 		}
 	}
+	for k, v := range b.InitContainers {
+		if err = v.Validate(); err != nil {
+			return fmt.Errorf(`validate "init_containers[%s]": %w`, k, err)
+		}
+	}
 	if err = b.Network.Validate(); err != nil {
 		return fmt.Errorf(`validate "network": %w`, err)
 	}
+	if !b.Network.VPC.Ingress.IsEmpty() && len(b.Network.VPC.Ingress.Ports) == 0 && b.ImageConfig.Port == nil {
+		return &errFieldMustBeSpecified{
+			missingField:      "network.vpc.ingress.ports",
+			conditionalFields: []string{"network.vpc.ingress.from"},
+		}
+	}
+	if !b.Network.Connect.Advanced.IsEmpty() && b.ImageConfig.Port == nil {
+		return &errFieldMustBeSpecified{
+			missingField:      "image.port",
+			conditionalFields: []string{"network.connect.alias"},
+		}
+	}
 	if err = b.PublishConfig.Validate(); err != nil {
 		return fmt.Errorf(`validate "publish": %w`, err)
 	}
@@ -245,6 +403,20 @@ func (b BackendServiceConfig) Validate() error {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if b.TaskConfig.IsEC2() {
+		if err = validateEC2(validateEC2Opts{
+			Spot:              b.Count.AdvancedCount.Spot,
+			SpotFrom:          b.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			EphemeralStorage:  b.Storage.Ephemeral,
+			NetworkMode:       b.Platform.NetworkMode(),
+			HasIngress:        !b.Network.VPC.Ingress.IsEmpty(),
+			HasSidecars:       len(b.Sidecars) != 0,
+			HasServiceConnect: b.Network.Connect.Enabled(),
+			HasPreDeploy:      !b.DeployConfig.PreDeploy.IsEmpty(),
+		}); err != nil {
+			return fmt.Errorf("validate EC2: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -277,6 +449,15 @@ func (r RequestDrivenWebServiceConfig) Validate() error {
 	if err = r.Observability.Validate(); err != nil {
 		return fmt.Errorf(`validate "observability": %w`, err)
 	}
+	if err = r.EnvAddons.Validate(); err != nil {
+		return fmt.Errorf(`validate "env_addons": %w`, err)
+	}
+	if len(r.EnvAddons.SecurityGroups) != 0 {
+		return errors.New(`"env_addons.security_groups" is not supported for Request-Driven Web Services`)
+	}
+	if err = validateIAMPermissions(r.Permissions); err != nil {
+		return fmt.Errorf(`validate "permissions": %w`, err)
+	}
 	return nil
 }
 
@@ -293,10 +474,11 @@ func (w WorkerService) Validate() error {
 		return err
 	}
 	if err = validateContainerDeps(validateDependenciesOpts{
-		sidecarConfig:     w.Sidecars,
-		imageConfig:       w.ImageConfig.Image,
-		mainContainerName: aws.StringValue(w.Name),
-		logging:           w.Logging,
+		sidecarConfig:       w.Sidecars,
+		initContainerConfig: w.InitContainers,
+		imageConfig:         w.ImageConfig.Image,
+		mainContainerName:   aws.StringValue(w.Name),
+		logging:             w.Logging,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
@@ -323,9 +505,23 @@ func (w WorkerServiceConfig) Validate() error {
 			return fmt.Errorf(`validate "sidecars[%s]": %w`, k, err)
 		}
 	}
+	for k, v := range w.InitContainers {
+		if err = v.Validate(); err != nil {
+			return fmt.Errorf(`validate "init_containers[%s]": %w`, k, err)
+		}
+	}
 	if err = w.Network.Validate(); err != nil {
 		return fmt.Errorf(`validate "network": %w`, err)
 	}
+	if !w.Network.VPC.Ingress.IsEmpty() && len(w.Network.VPC.Ingress.Ports) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField:      "network.vpc.ingress.ports",
+			conditionalFields: []string{"network.vpc.ingress.from"},
+		}
+	}
+	if !w.Network.Connect.Advanced.IsEmpty() {
+		return fmt.Errorf(`"network.connect.alias" is not supported for %ss since they don't expose a port`, WorkerServiceType)
+	}
 	if err = w.Subscribe.Validate(); err != nil {
 		return fmt.Errorf(`validate "subscribe": %w`, err)
 	}
@@ -353,6 +549,55 @@ func (w WorkerServiceConfig) Validate() error {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if w.TaskConfig.IsEC2() {
+		if err = validateEC2(validateEC2Opts{
+			Spot:              w.Count.AdvancedCount.Spot,
+			SpotFrom:          w.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			EphemeralStorage:  w.Storage.Ephemeral,
+			NetworkMode:       w.Platform.NetworkMode(),
+			HasIngress:        !w.Network.VPC.Ingress.IsEmpty(),
+			HasSidecars:       len(w.Sidecars) != 0,
+			HasServiceConnect: w.Network.Connect.Enabled(),
+			HasPreDeploy:      !w.DeployConfig.PreDeploy.IsEmpty(),
+		}); err != nil {
+			return fmt.Errorf("validate EC2: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if StaticSite is configured correctly.
+func (s StaticSite) Validate() error {
+	if err := s.StaticSiteConfig.Validate(); err != nil {
+		return err
+	}
+	return s.Workload.Validate()
+}
+
+// Validate returns nil if StaticSiteConfig is configured correctly.
+func (s StaticSiteConfig) Validate() error {
+	if err := s.Source.Validate(); err != nil {
+		return fmt.Errorf(`validate "source": %w`, err)
+	}
+	if strings.HasPrefix(aws.StringValue(s.IndexDocument), "/") {
+		return fmt.Errorf(`"index_document" %q must be a path relative to "source.path"`, aws.StringValue(s.IndexDocument))
+	}
+	if strings.HasPrefix(aws.StringValue(s.ErrorDocument), "/") {
+		return fmt.Errorf(`"error_document" %q must be a path relative to "source.path"`, aws.StringValue(s.ErrorDocument))
+	}
+	if err := s.Aliases.Validate(); err != nil {
+		return fmt.Errorf(`validate "aliases": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if StaticSiteSource is configured correctly.
+func (s StaticSiteSource) Validate() error {
+	if aws.StringValue(s.Path) == "" {
+		return &errFieldMustBeSpecified{
+			missingField: "path",
+		}
+	}
 	return nil
 }
 
@@ -366,10 +611,11 @@ func (s ScheduledJob) Validate() error {
 		return err
 	}
 	if err = validateContainerDeps(validateDependenciesOpts{
-		sidecarConfig:     s.Sidecars,
-		imageConfig:       s.ImageConfig.Image,
-		mainContainerName: aws.StringValue(s.Name),
-		logging:           s.Logging,
+		sidecarConfig:       s.Sidecars,
+		initContainerConfig: s.InitContainers,
+		imageConfig:         s.ImageConfig.Image,
+		mainContainerName:   aws.StringValue(s.Name),
+		logging:             s.Logging,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
@@ -396,9 +642,20 @@ func (s ScheduledJobConfig) Validate() error {
 			return fmt.Errorf(`validate "sidecars[%s]": %w`, k, err)
 		}
 	}
+	for k, v := range s.InitContainers {
+		if err = v.Validate(); err != nil {
+			return fmt.Errorf(`validate "init_containers[%s]": %w`, k, err)
+		}
+	}
 	if err = s.Network.Validate(); err != nil {
 		return fmt.Errorf(`validate "network": %w`, err)
 	}
+	if !s.Network.VPC.Ingress.IsEmpty() {
+		return fmt.Errorf(`"network.vpc.ingress" is not supported for %ss`, ScheduledJobType)
+	}
+	if !s.Network.Connect.IsEmpty() {
+		return fmt.Errorf(`"network.connect" is not supported for %ss`, ScheduledJobType)
+	}
 	if err = s.On.Validate(); err != nil {
 		return fmt.Errorf(`validate "on": %w`, err)
 	}
@@ -429,6 +686,9 @@ func (s ScheduledJobConfig) Validate() error {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if s.TaskConfig.IsEC2() {
+		return fmt.Errorf(`"platform.launch_type" %s is not supported for %ss`, LaunchTypeEC2, ScheduledJobType)
+	}
 	return nil
 }
 
@@ -442,6 +702,32 @@ func (p Pipeline) Validate() error {
 			return fmt.Errorf(`validate "deployments" for pipeline stage %s: %w`, stg.Name, err)
 		}
 	}
+	if p.Preview != nil {
+		if err := p.Preview.Validate(); err != nil {
+			return fmt.Errorf(`validate "preview": %w`, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if Preview is configured correctly.
+func (p Preview) Validate() error {
+	if len(p.Branches) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField: "branches",
+		}
+	}
+	for _, branch := range p.Branches {
+		if branch == "" || strings.ContainsAny(branch, " \t") {
+			return fmt.Errorf(`branch pattern "%s" must be non-empty and cannot contain whitespace`, branch)
+		}
+		if _, err := path.Match(branch, ""); err != nil {
+			return fmt.Errorf(`branch pattern "%s" is invalid: %w`, branch, err)
+		}
+	}
+	if p.TTL != nil && *p.TTL < minPreviewTTL {
+		return fmt.Errorf(`"ttl" must be at least %s`, minPreviewTTL)
+	}
 	return nil
 }
 
@@ -654,6 +940,208 @@ func (r RoutingRuleConfiguration) Validate() error {
 			missingField: "path",
 		}
 	}
+	if err = r.WAF.Validate(); err != nil {
+		return fmt.Errorf(`validate "waf": %w`, err)
+	}
+	if err = r.CDN.Validate(); err != nil {
+		return fmt.Errorf(`validate "cdn": %w`, err)
+	}
+	if r.CDN.Enabled() && !r.Alias.IsEmpty() {
+		// The load balancer redirects HTTP requests to aliases to HTTPS, while CloudFront connects to it over HTTP.
+		return &errFieldMutualExclusive{
+			firstField:  "cdn",
+			secondField: "alias",
+		}
+	}
+	priorities := make(map[int]int)
+	for ind, rule := range r.AdditionalRules {
+		if err = rule.Validate(); err != nil {
+			return fmt.Errorf(`validate "additional_rules[%d]": %w`, ind, err)
+		}
+		priority := aws.IntValue(rule.Priority)
+		if prev, ok := priorities[priority]; ok {
+			return fmt.Errorf(`"additional_rules[%d]" and "additional_rules[%d]" cannot have the same priority %d`, prev, ind, priority)
+		}
+		priorities[priority] = ind
+	}
+	return nil
+}
+
+// Validate returns nil if WebACLArgsOrARN is configured correctly.
+func (w WebACLArgsOrARN) Validate() error {
+	if w.ARN != nil {
+		parsed, err := arn.Parse(aws.StringValue(w.ARN))
+		if err != nil || parsed.Service != "wafv2" || !strings.HasPrefix(parsed.Resource, "regional/webacl/") {
+			return fmt.Errorf("%s is not a valid regional AWS WAF web ACL ARN", aws.StringValue(w.ARN))
+		}
+		return nil
+	}
+	return w.Advanced.Validate()
+}
+
+// Validate returns nil if CDNBoolOrArgs is configured correctly.
+func (c CDNBoolOrArgs) Validate() error {
+	if c.Enable != nil {
+		return nil
+	}
+	return c.Advanced.Validate()
+}
+
+// Validate returns nil if CDNArgs is configured correctly.
+func (c CDNArgs) Validate() error {
+	if c.CachePolicy != nil {
+		policy := aws.StringValue(c.CachePolicy)
+		if _, ok := CDNManagedCachePolicyIDs[policy]; !ok && !cachePolicyIDRegexp.MatchString(policy) {
+			names := make([]string, 0, len(CDNManagedCachePolicyIDs))
+			for name := range CDNManagedCachePolicyIDs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf(`"cache_policy" value '%s' must be the ID of a cache policy or one of %s`, policy, english.WordSeries(names, "or"))
+		}
+	}
+	if err := c.Aliases.Validate(); err != nil {
+		return fmt.Errorf(`validate "aliases": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if WebACLArgs is configured correctly.
+func (w WebACLArgs) Validate() error {
+	if w.isEmpty() {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for ind, rule := range w.ManagedRules {
+		if !managedRuleRegexp.MatchString(rule) {
+			return fmt.Errorf(`"managed_rules[%d]" value '%s' is not the name of an AWS managed rule group`, ind, rule)
+		}
+		if seen[rule] {
+			return fmt.Errorf(`"managed_rules[%d]" value '%s' is a duplicate`, ind, rule)
+		}
+		seen[rule] = true
+	}
+	if w.RateLimit != nil && (aws.IntValue(w.RateLimit) < minWAFRateLimit || aws.IntValue(w.RateLimit) > maxWAFRateLimit) {
+		return fmt.Errorf(`"rate_limit" must be between %d and %d`, minWAFRateLimit, maxWAFRateLimit)
+	}
+	return nil
+}
+
+// Validate returns nil if ListenerRule is configured correctly.
+func (r ListenerRule) Validate() error {
+	if r.Priority == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "priority",
+		}
+	}
+	if priority := aws.IntValue(r.Priority); priority < minListenerRulePriority || priority > maxListenerRulePriority {
+		return fmt.Errorf(`"priority" must be between %d and %d`, minListenerRulePriority, maxListenerRulePriority)
+	}
+	if !r.hasConditions() {
+		return &errAtLeastOneFieldMustBeSpecified{
+			missingFields: []string{"path", "hosts", "headers", "query_strings", "allowed_source_ips"},
+		}
+	}
+	if n := r.conditionValues(); n > maxListenerRuleConditionValues {
+		return fmt.Errorf("listener rule has %d condition values, cannot exceed %d", n, maxListenerRuleConditionValues)
+	}
+	for name, values := range r.Headers {
+		if len(values) == 0 {
+			return fmt.Errorf(`"headers[%s]" must have at least one value`, name)
+		}
+	}
+	for ind, ip := range r.AllowedSourceIps {
+		if err := ip.Validate(); err != nil {
+			return fmt.Errorf(`validate "allowed_source_ips[%d]": %w`, ind, err)
+		}
+	}
+	if len(r.Targets) != 0 && !r.Redirect.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "targets",
+			secondField: "redirect",
+		}
+	}
+	if len(r.Targets) != 0 && !r.FixedResponse.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "targets",
+			secondField: "fixed_response",
+		}
+	}
+	if !r.Redirect.IsEmpty() && !r.FixedResponse.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "redirect",
+			secondField: "fixed_response",
+		}
+	}
+	for ind, target := range r.Targets {
+		if err := target.Validate(); err != nil {
+			return fmt.Errorf(`validate "targets[%d]": %w`, ind, err)
+		}
+	}
+	if err := r.Redirect.Validate(); err != nil {
+		return fmt.Errorf(`validate "redirect": %w`, err)
+	}
+	if err := r.FixedResponse.Validate(); err != nil {
+		return fmt.Errorf(`validate "fixed_response": %w`, err)
+	}
+	return nil
+}
+
+// conditionValues returns the number of values the load balancer evaluates for the rule's conditions.
+func (r ListenerRule) conditionValues() int {
+	n := len(r.PathPatterns()) + len(r.Hosts) + len(r.QueryStrings) + len(r.AllowedSourceIps)
+	for _, values := range r.Headers {
+		n += len(values)
+	}
+	return n
+}
+
+// Validate returns nil if WeightedTarget is configured correctly.
+func (t WeightedTarget) Validate() error {
+	if t.Container == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "container",
+		}
+	}
+	if t.Weight != nil && (aws.IntValue(t.Weight) < 0 || aws.IntValue(t.Weight) > maxTargetGroupWeight) {
+		return fmt.Errorf(`"weight" must be between 0 and %d`, maxTargetGroupWeight)
+	}
+	return nil
+}
+
+// Validate returns nil if RedirectAction is configured correctly.
+func (a RedirectAction) Validate() error {
+	if a.IsEmpty() {
+		return nil
+	}
+	if a.Protocol != nil && !contains(aws.StringValue(a.Protocol), redirectProtocols) {
+		return fmt.Errorf(`"protocol" field value '%s' must be one of %s`, aws.StringValue(a.Protocol), english.WordSeries(redirectProtocols, "or"))
+	}
+	if code := aws.IntValue(a.StatusCode); a.StatusCode != nil && code != 301 && code != 302 {
+		return fmt.Errorf(`"status_code" field value '%d' must be one of 301 or 302`, code)
+	}
+	return nil
+}
+
+// Validate returns nil if FixedResponseAction is configured correctly.
+func (a FixedResponseAction) Validate() error {
+	if a.IsEmpty() {
+		return nil
+	}
+	if a.StatusCode == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "status_code",
+		}
+	}
+	if code := aws.IntValue(a.StatusCode); (code < 200 || code > 299) && (code < 400 || code > 599) {
+		return fmt.Errorf(`"status_code" field value '%d' must be a 2XX, 4XX, or 5XX status code`, code)
+	}
+	if a.ContentType != nil && !contains(aws.StringValue(a.ContentType), fixedResponseContentTypes) {
+		return fmt.Errorf(`"content_type" field value '%s' must be one of %s`, aws.StringValue(a.ContentType), english.WordSeries(fixedResponseContentTypes, "or"))
+	}
+	if len(aws.StringValue(a.Body)) > maxFixedResponseBodyLength {
+		return fmt.Errorf(`"body" cannot be longer than %d characters`, maxFixedResponseBodyLength)
+	}
 	return nil
 }
 
@@ -699,19 +1187,67 @@ func (c NetworkLoadBalancerConfiguration) Validate() error {
 	if c.IsEmpty() {
 		return nil
 	}
-	if aws.StringValue(c.Port) == "" {
+	if err := c.Listener.Validate(); err != nil {
+		return err
+	}
+	ports := map[string]string{
+		nlbListenerPort(c.Listener): "port",
+	}
+	for i, listener := range c.AdditionalListeners {
+		if err := listener.Validate(); err != nil {
+			return fmt.Errorf(`validate "additional_listeners[%d]": %w`, i, err)
+		}
+		field := fmt.Sprintf("additional_listeners[%d]", i)
+		port := nlbListenerPort(listener)
+		if other, ok := ports[port]; ok {
+			return fmt.Errorf(`"%s" and "%s" cannot listen on the same port %s`, other, field, port)
+		}
+		ports[port] = field
+	}
+	if err := c.Aliases.Validate(); err != nil {
+		return fmt.Errorf(`validate "alias": %w`, err)
+	}
+	return nil
+}
+
+// nlbListenerPort returns the port of a listener without its protocol.
+func nlbListenerPort(l NetworkLoadBalancerListener) string {
+	port, _, _ := ParsePortMapping(l.Port)
+	return aws.StringValue(port)
+}
+
+// Validate returns nil if NetworkLoadBalancerListener is configured correctly.
+func (l NetworkLoadBalancerListener) Validate() error {
+	if aws.StringValue(l.Port) == "" {
 		return &errFieldMustBeSpecified{
 			missingField: "port",
 		}
 	}
-	if err := validateNLBPort(c.Port); err != nil {
+	if err := validateNLBPort(l.Port); err != nil {
 		return fmt.Errorf(`validate "port": %w`, err)
 	}
-	if err := c.HealthCheck.Validate(); err != nil {
+	if err := l.HealthCheck.Validate(); err != nil {
 		return fmt.Errorf(`validate "healthcheck": %w`, err)
 	}
-	if err := c.Aliases.Validate(); err != nil {
-		return fmt.Errorf(`validate "alias": %w`, err)
+	_, protocol, _ := ParsePortMapping(l.Port)
+	protocolVal := strings.ToUpper(aws.StringValue(protocol))
+	if l.ALPNPolicy != nil {
+		if protocolVal != tls {
+			return fmt.Errorf(`"alpn_policy" can only be specified with the %s protocol`, tls)
+		}
+		if !contains(aws.StringValue(l.ALPNPolicy), nlbALPNPolicies) {
+			return fmt.Errorf(`validate "alpn_policy": value must be one of %s`, english.WordSeries(nlbALPNPolicies, "or"))
+		}
+	}
+	if l.SSLPolicy != nil && protocolVal != tls {
+		return fmt.Errorf(`"ssl_policy" can only be specified with the %s protocol`, tls)
+	}
+	if l.PreserveClientIP != nil && !aws.BoolValue(l.PreserveClientIP) && (protocolVal == udp || protocolVal == tcpUDP) {
+		return fmt.Errorf(`"preserve_client_ip" cannot be disabled for the %s protocol`, protocolVal)
+	}
+	if protocolVal == udp && l.HealthCheck.Port == nil {
+		// Health checks are made over TCP, so they can't reach a target that only listens on a UDP port.
+		return fmt.Errorf(`"healthcheck.port" must be specified for the %s protocol`, udp)
 	}
 	return nil
 }
@@ -753,6 +1289,12 @@ func (t TaskConfig) Validate() error {
 	if err = t.Storage.Validate(); err != nil {
 		return fmt.Errorf(`validate "storage": %w`, err)
 	}
+	if err = t.EnvAddons.Validate(); err != nil {
+		return fmt.Errorf(`validate "env_addons": %w`, err)
+	}
+	if err = validateIAMPermissions(t.Permissions); err != nil {
+		return fmt.Errorf(`validate "permissions": %w`, err)
+	}
 	if t.EnvFile != nil {
 		envFile := aws.StringValue(t.EnvFile)
 		if filepath.Ext(envFile) != envFileExt {
@@ -762,12 +1304,77 @@ func (t TaskConfig) Validate() error {
 	return nil
 }
 
+// Validate returns nil if EnvAddonsImports is configured correctly.
+func (e EnvAddonsImports) Validate() error {
+	for _, outputs := range [][]string{e.Variables, e.Secrets, e.Policies, e.SecurityGroups} {
+		for _, output := range outputs {
+			if !cfnLogicalIDRegexp.MatchString(output) {
+				return fmt.Errorf("output name %q is invalid: must contain only alphanumeric characters", output)
+			}
+		}
+	}
+	return nil
+}
+
+func validateIAMPermissions(permissions []IAMPermission) error {
+	for idx, p := range permissions {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("statement %d: %w", idx+1, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if IAMPermission is configured correctly.
+func (p IAMPermission) Validate() error {
+	if p.Effect != nil && !contains(aws.StringValue(p.Effect), iamEffects) {
+		return fmt.Errorf(`"effect" %q must be one of %s`, aws.StringValue(p.Effect), english.WordSeries(iamEffects, "or"))
+	}
+	if len(p.Actions) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField: "actions",
+		}
+	}
+	for _, action := range p.Actions {
+		if !iamActionRegexp.MatchString(action) {
+			return fmt.Errorf(`action %q is invalid: must be "*" or of the form "<service>:<action>"`, action)
+		}
+	}
+	if len(p.Resources) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField: "resources",
+		}
+	}
+	for _, resource := range p.Resources {
+		if resource != "*" && !strings.HasPrefix(resource, "arn:") {
+			return fmt.Errorf(`resource %q is invalid: must be "*" or an ARN`, resource)
+		}
+	}
+	for operator, conditions := range p.Conditions {
+		if !iamConditionRegexp.MatchString(operator) {
+			return fmt.Errorf("condition operator %q is invalid", operator)
+		}
+		if len(conditions) == 0 {
+			return fmt.Errorf("condition operator %q must have at least one condition key", operator)
+		}
+		for key, values := range conditions {
+			if len(values) == 0 {
+				return fmt.Errorf("condition key %q of operator %q must have at least one value", key, operator)
+			}
+		}
+	}
+	return nil
+}
+
 // Validate returns nil if PlatformArgsOrString is configured correctly.
 func (p PlatformArgsOrString) Validate() error {
+	if err := p.validateLaunchType(); err != nil {
+		return err
+	}
 	if p.IsEmpty() {
 		return nil
 	}
-	if !p.PlatformArgs.isEmpty() {
+	if !p.PlatformArgs.isOSArchEmpty() {
 		return p.PlatformArgs.Validate()
 	}
 	if p.PlatformString != nil {
@@ -797,6 +1404,31 @@ func (p PlatformArgs) Validate() error {
 	return fmt.Errorf("platform pair %s is invalid: fields ('osfamily', 'architecture') must be one of %s", p.String(), prettyValidPlatforms)
 }
 
+func (p PlatformArgsOrString) validateLaunchType() error {
+	if !contains(p.LaunchType(), validLaunchTypes) {
+		return fmt.Errorf(`"launch_type" field value '%s' must be one of %s`, aws.StringValue(p.PlatformArgs.LaunchType), english.WordSeries(validLaunchTypes, "or"))
+	}
+	if p.LaunchType() != LaunchTypeEC2 {
+		if p.PlatformArgs.NetworkMode != nil {
+			return fmt.Errorf(`"network_mode" can only be specified when "launch_type" is %s`, LaunchTypeEC2)
+		}
+		if p.PlatformArgs.PlacementStrategy != nil {
+			return fmt.Errorf(`"placement_strategy" can only be specified when "launch_type" is %s`, LaunchTypeEC2)
+		}
+		return nil
+	}
+	if !contains(p.NetworkMode(), validNetworkModes) {
+		return fmt.Errorf(`"network_mode" field value '%s' must be one of %s`, aws.StringValue(p.PlatformArgs.NetworkMode), english.WordSeries(validNetworkModes, "or"))
+	}
+	if !contains(p.PlacementStrategy(), validPlacementStrategies) {
+		return fmt.Errorf(`"placement_strategy" field value '%s' must be one of %s`, aws.StringValue(p.PlatformArgs.PlacementStrategy), english.WordSeries(validPlacementStrategies, "or"))
+	}
+	if isWindowsPlatform(p) {
+		return fmt.Errorf(`"launch_type" %s is not supported for Windows containers`, LaunchTypeEC2)
+	}
+	return nil
+}
+
 // Validate returns nil if PlatformString is configured correctly.
 func (p PlatformString) Validate() error {
 	args := strings.Split(string(p), "/")
@@ -863,6 +1495,145 @@ func (a AdvancedCount) Validate() error {
 			return fmt.Errorf(`validate "memory_percentage": %w`, err)
 		}
 	}
+	for ind, metric := range a.CustomMetrics {
+		if err := metric.Validate(); err != nil {
+			return fmt.Errorf(`validate "custom_metrics[%d]": %w`, ind, err)
+		}
+	}
+	for ind, schedule := range a.Schedules {
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf(`validate "schedules[%d]": %w`, ind, err)
+		}
+	}
+	if len(a.Schedules) == 0 {
+		return nil
+	}
+	min, max, err := a.Range.Parse()
+	if err != nil {
+		return fmt.Errorf(`parse "range": %w`, err)
+	}
+	for ind, schedule := range a.Schedules {
+		if err := schedule.validateRange(min, max); err != nil {
+			return fmt.Errorf(`validate "schedules[%d]": %w`, ind, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if CustomMetricScaling is configured correctly.
+func (c CustomMetricScaling) Validate() error {
+	if c.Target == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "target",
+		}
+	}
+	if aws.Float64Value(c.Target) <= 0 {
+		return errors.New(`"target" must be greater than 0`)
+	}
+	if c.Expression == nil {
+		if len(c.Metrics) != 0 {
+			return &errFieldMustBeSpecified{
+				missingField:      "expression",
+				conditionalFields: []string{"metrics"},
+			}
+		}
+		return c.CloudWatchMetric.Validate()
+	}
+	if !c.CloudWatchMetric.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "expression",
+			secondField: "namespace/metric_name/dimensions/statistic",
+		}
+	}
+	if len(c.Metrics) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField:      "metrics",
+			conditionalFields: []string{"expression"},
+		}
+	}
+	ids := make(map[string]bool)
+	for ind, query := range c.Metrics {
+		if err := query.Validate(); err != nil {
+			return fmt.Errorf(`validate "metrics[%d]": %w`, ind, err)
+		}
+		id := aws.StringValue(query.ID)
+		if ids[id] {
+			return fmt.Errorf(`"metrics[%d]" cannot have the same "id" %s as another metric`, ind, id)
+		}
+		ids[id] = true
+	}
+	return nil
+}
+
+// Validate returns nil if CloudWatchMetric is configured correctly.
+func (m CloudWatchMetric) Validate() error {
+	if m.Namespace == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "namespace",
+		}
+	}
+	if m.MetricName == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "metric_name",
+		}
+	}
+	if m.Statistic != nil && !contains(aws.StringValue(m.Statistic), metricValidStatistics) {
+		return fmt.Errorf(`"statistic" field value '%s' must be one of %s`, aws.StringValue(m.Statistic), english.WordSeries(metricValidStatistics, "or"))
+	}
+	return nil
+}
+
+// Validate returns nil if CloudWatchMetricQuery is configured correctly.
+func (q CloudWatchMetricQuery) Validate() error {
+	if q.ID == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "id",
+		}
+	}
+	id := aws.StringValue(q.ID)
+	if !metricQueryIDRegexp.MatchString(id) {
+		return fmt.Errorf(`"id" %s must start with a lowercase letter and contain only letters, numbers, and underscores`, id)
+	}
+	if id == metricExpressionQueryID {
+		return fmt.Errorf(`"id" cannot be %s as it is reserved for the expression`, metricExpressionQueryID)
+	}
+	return q.CloudWatchMetric.Validate()
+}
+
+// Validate returns nil if ScheduledScaling is configured correctly.
+func (s ScheduledScaling) Validate() error {
+	if s.Cron == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "cron",
+		}
+	}
+	if s.Min == nil && s.Max == nil {
+		return &errAtLeastOneFieldMustBeSpecified{
+			missingFields: []string{"min", "max"},
+		}
+	}
+	if s.Min != nil && s.Max != nil && aws.IntValue(s.Min) > aws.IntValue(s.Max) {
+		return &errMinGreaterThanMax{
+			min: aws.IntValue(s.Min),
+			max: aws.IntValue(s.Max),
+		}
+	}
+	if s.TimeZone != nil {
+		if _, err := time.LoadLocation(aws.StringValue(s.TimeZone)); err != nil {
+			return fmt.Errorf(`"timezone" %s is not a valid IANA time zone: %w`, aws.StringValue(s.TimeZone), err)
+		}
+	}
+	return nil
+}
+
+// validateRange returns an error if the scheduled capacity is outside of the range of tasks of the service.
+func (s ScheduledScaling) validateRange(rangeMin, rangeMax int) error {
+	if min := aws.IntValue(s.Min); s.Min != nil && (min < rangeMin || min > rangeMax) {
+		return fmt.Errorf(`"min" %d must be within the range %d-%d`, min, rangeMin, rangeMax)
+	}
+	if max := aws.IntValue(s.Max); s.Max != nil && (max < rangeMin || max > rangeMax) {
+		return fmt.Errorf(`"max" %d must be within the range %d-%d`, max, rangeMin, rangeMax)
+	}
 	return nil
 }
 
@@ -1103,6 +1874,24 @@ func (s SidecarConfig) Validate() error {
 	return s.ImageOverride.Validate()
 }
 
+// Validate returns nil if InitContainerConfig is configured correctly.
+func (i InitContainerConfig) Validate() error {
+	if i.Image == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "image",
+		}
+	}
+	for ind, mp := range i.MountPoints {
+		if err := mp.Validate(); err != nil {
+			return fmt.Errorf(`validate "mount_points[%d]": %w`, ind, err)
+		}
+	}
+	if err := i.DependsOn.Validate(); err != nil {
+		return fmt.Errorf(`validate "depends_on": %w`, err)
+	}
+	return i.ImageOverride.Validate()
+}
+
 // Validate returns nil if SidecarMountPoint is configured correctly.
 func (s SidecarMountPoint) Validate() error {
 	if aws.StringValue(s.SourceVolume) == "" {
@@ -1121,6 +1910,22 @@ func (n NetworkConfig) Validate() error {
 	if err := n.VPC.Validate(); err != nil {
 		return fmt.Errorf(`validate "vpc": %w`, err)
 	}
+	if err := n.Connect.Validate(); err != nil {
+		return fmt.Errorf(`validate "connect": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if ServiceConnectBoolOrArgs is configured correctly.
+func (s ServiceConnectBoolOrArgs) Validate() error {
+	return s.Advanced.Validate()
+}
+
+// Validate returns nil if ServiceConnectArgs is configured correctly.
+func (s ServiceConnectArgs) Validate() error {
+	if s.Alias != nil && aws.StringValue(s.Alias) == "" {
+		return errors.New(`"alias" cannot be empty`)
+	}
 	return nil
 }
 
@@ -1158,6 +1963,33 @@ func (v vpcConfig) Validate() error {
 			return fmt.Errorf(`validate "placement": %w`, err)
 		}
 	}
+	if err := v.Ingress.Validate(); err != nil {
+		return fmt.Errorf(`validate "ingress": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if ServiceIngress is configured correctly.
+func (i ServiceIngress) Validate() error {
+	if i.IsEmpty() {
+		return nil
+	}
+	if len(i.From) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField:      "from",
+			conditionalFields: []string{"ports"},
+		}
+	}
+	for _, svc := range i.From {
+		if svc == "" {
+			return errors.New(`"from" must not contain empty service names`)
+		}
+	}
+	for _, port := range i.Ports {
+		if port == 0 {
+			return errors.New(`"ports" must not contain port 0`)
+		}
+	}
 	return nil
 }
 
@@ -1190,6 +2022,9 @@ func (r AppRunnerInstanceConfig) Validate() error {
 	if err := r.Platform.Validate(); err != nil {
 		return fmt.Errorf(`validate "platform": %w`, err)
 	}
+	if r.Platform.PlatformArgs.LaunchType != nil {
+		return errors.New(`"platform.launch_type" is not supported for App Runner services`)
+	}
 	// Error out if user added Windows as platform in manifest.
 	if isWindowsPlatform(r.Platform) {
 		return ErrAppRunnerInvalidPlatformWindows
@@ -1334,10 +2169,11 @@ func (s Secret) Validate() error {
 }
 
 type validateDependenciesOpts struct {
-	mainContainerName string
-	sidecarConfig     map[string]*SidecarConfig
-	imageConfig       Image
-	logging           Logging
+	mainContainerName   string
+	sidecarConfig       map[string]*SidecarConfig
+	initContainerConfig map[string]*InitContainerConfig
+	imageConfig         Image
+	logging             Logging
 }
 
 type containerDependency struct {
@@ -1361,6 +2197,18 @@ type validateARMOpts struct {
 	SpotFrom *int
 }
 
+type validateEC2Opts struct {
+	Spot              *int
+	SpotFrom          *int
+	EphemeralStorage  *int
+	NetworkMode       string
+	HasNLB            bool
+	HasIngress        bool
+	HasSidecars       bool
+	HasServiceConnect bool
+	HasPreDeploy      bool
+}
+
 func validateTargetContainer(opts validateTargetContainerOpts) error {
 	if opts.targetContainer == nil {
 		return nil
@@ -1382,7 +2230,7 @@ func validateTargetContainer(opts validateTargetContainerOpts) error {
 func validateContainerDeps(opts validateDependenciesOpts) error {
 	containerDependencies := make(map[string]containerDependency)
 	containerDependencies[opts.mainContainerName] = containerDependency{
-		dependsOn:   opts.imageConfig.DependsOn,
+		dependsOn:   dependsOnInitContainers(opts.imageConfig.DependsOn, opts.initContainerConfig),
 		isEssential: true,
 	}
 	if !opts.logging.IsEmpty() {
@@ -1390,16 +2238,40 @@ func validateContainerDeps(opts validateDependenciesOpts) error {
 	}
 	for name, config := range opts.sidecarConfig {
 		containerDependencies[name] = containerDependency{
-			dependsOn:   config.DependsOn,
+			dependsOn:   dependsOnInitContainers(config.DependsOn, opts.initContainerConfig),
 			isEssential: config.Essential == nil || aws.BoolValue(config.Essential),
 		}
 	}
+	for name, config := range opts.initContainerConfig {
+		if _, ok := containerDependencies[name]; ok {
+			return fmt.Errorf("init container %s cannot have the same name as another container", name)
+		}
+		containerDependencies[name] = containerDependency{
+			dependsOn: config.DependsOn,
+		}
+	}
 	if err := validateDepsForEssentialContainers(containerDependencies); err != nil {
 		return err
 	}
 	return validateNoCircularDependencies(containerDependencies)
 }
 
+// dependsOnInitContainers returns the dependencies of a container that waits for every init container to complete successfully.
+// Dependencies that are explicitly set on the container take precedence.
+func dependsOnInitContainers(deps DependsOn, initContainers map[string]*InitContainerConfig) DependsOn {
+	if len(initContainers) == 0 {
+		return deps
+	}
+	out := make(DependsOn, len(deps)+len(initContainers))
+	for name := range initContainers {
+		out[name] = dependsOnSuccess
+	}
+	for name, status := range deps {
+		out[name] = status
+	}
+	return out
+}
+
 func validateDepsForEssentialContainers(deps map[string]containerDependency) error {
 	for name, containerDep := range deps {
 		for dep, status := range containerDep.dependsOn {
@@ -1516,6 +2388,31 @@ func validateARM(opts validateARMOpts) error {
 	return nil
 }
 
+func validateEC2(opts validateEC2Opts) error {
+	if opts.Spot != nil || opts.SpotFrom != nil {
+		return errors.New(`'Fargate Spot' is not supported when deploying on EC2 instances; configure Spot Instances on the environment instead`)
+	}
+	if opts.EphemeralStorage != nil {
+		return errors.New(`"storage.ephemeral" is not supported when deploying on EC2 instances`)
+	}
+	if opts.NetworkMode == NetworkModeBridge && opts.HasNLB {
+		return fmt.Errorf(`"nlb" is not supported when "platform.network_mode" is %s`, NetworkModeBridge)
+	}
+	if opts.NetworkMode == NetworkModeBridge && opts.HasIngress {
+		return fmt.Errorf(`"network.vpc.ingress" is not supported when "platform.network_mode" is %s`, NetworkModeBridge)
+	}
+	if opts.NetworkMode == NetworkModeBridge && opts.HasSidecars {
+		return fmt.Errorf(`"sidecars" are not supported when "platform.network_mode" is %s`, NetworkModeBridge)
+	}
+	if opts.NetworkMode == NetworkModeBridge && opts.HasServiceConnect {
+		return fmt.Errorf(`"network.connect" is not supported when "platform.network_mode" is %s`, NetworkModeBridge)
+	}
+	if opts.HasPreDeploy {
+		return errors.New(`"deployment.pre_deploy" is not supported when deploying on EC2 instances`)
+	}
+	return nil
+}
+
 func contains(name string, names []string) bool {
 	for _, n := range names {
 		if name == n {
@@ -60,6 +60,9 @@ func Test_ValidateAudit(t *testing.T) {
 		"worker service": {
 			mft: &manifest.WorkerService{},
 		},
+		"static site": {
+			mft: &manifest.StaticSite{},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -6,6 +6,7 @@ package manifest
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -57,6 +58,45 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "http": `,
 		},
+		"error if http hosted zone is specified": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path:       stringP("/"),
+							Alias:      Alias{String: aws.String("example.com")},
+							HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"http.hosted_zone" is not supported for Load Balanced Web Services`),
+		},
+		"error if an additional rule target container doesn't exist": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("api")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: stringP("/"),
+							AdditionalRules: []ListenerRule{
+								{
+									Priority: aws.Int(10),
+									Path:     aws.String("/beta"),
+									Targets: []WeightedTarget{
+										{Container: aws.String("api"), Weight: aws.Int(90)},
+										{Container: aws.String("canary"), Weight: aws.Int(10)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`validate "http.additional_rules[0].targets[1]": target container canary doesn't exist`),
+		},
 		"error if fail to validate sidecars": {
 			lbConfig: LoadBalancedWebService{
 				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
@@ -82,7 +122,7 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -95,6 +135,27 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "network": `,
 		},
+		"error if ingress is specified": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						VPC: vpcConfig{
+							Ingress: ServiceIngress{
+								From:  []string{"api"},
+								Ports: []uint16{80},
+							},
+						},
+					},
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: stringP("/"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"network.vpc.ingress" is not supported for Load Balanced Web Services`),
+		},
 		"error if fail to validate publish config": {
 			lbConfig: LoadBalancedWebService{
 				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
@@ -171,13 +232,43 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 						},
 					},
 					NLBConfig: NetworkLoadBalancerConfiguration{
-						Port:            aws.String("443"),
-						TargetContainer: aws.String("foo"),
+						Listener: NetworkLoadBalancerListener{
+							Port:            aws.String("443"),
+							TargetContainer: aws.String("foo"),
+						},
 					},
 				},
 			},
 			wantedErrorMsgPrefix: `validate network load balancer target: `,
 		},
+		"error if fail to validate the target of an additional network load balancer listener": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: stringP("/"),
+						},
+					},
+					NLBConfig: NetworkLoadBalancerConfiguration{
+						Listener: NetworkLoadBalancerListener{
+							Port: aws.String("443"),
+						},
+						AdditionalListeners: []NetworkLoadBalancerListener{
+							{
+								Port:            aws.String("53/udp"),
+								TargetContainer: aws.String("foo"),
+								HealthCheck: NLBHealthCheckArgs{
+									Port: aws.Int(8080),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "nlb.additional_listeners[0]" target: `,
+		},
 		"error if fail to validate dependencies": {
 			lbConfig: LoadBalancedWebService{
 				Workload: Workload{Name: aws.String("mockName")},
@@ -245,6 +336,35 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate ARM: `,
 		},
+		"error if fail to validate EC2": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{
+					Name: aws.String("mockName"),
+				},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Platform: PlatformArgsOrString{
+							PlatformArgs: PlatformArgs{
+								LaunchType: aws.String("ec2"),
+							},
+						},
+						Count: Count{
+							AdvancedCount: AdvancedCount{
+								Spot:         aws.Int(123),
+								workloadType: LoadBalancedWebServiceType,
+							},
+						},
+					},
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: stringP("/"),
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate EC2: `,
+		},
 		"error if neither of http or nlb is enabled": {
 			lbConfig: LoadBalancedWebService{
 				Workload: Workload{
@@ -276,7 +396,9 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 						Enabled: aws.Bool(false),
 					},
 					NLBConfig: NetworkLoadBalancerConfiguration{
-						Port: aws.String("80"),
+						Listener: NetworkLoadBalancerListener{
+							Port: aws.String("80"),
+						},
 					},
 				},
 			},
@@ -299,7 +421,9 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 						Enabled: aws.Bool(false),
 					},
 					NLBConfig: NetworkLoadBalancerConfiguration{
-						Port: aws.String("80"),
+						Listener: NetworkLoadBalancerListener{
+							Port: aws.String("80"),
+						},
 					},
 				},
 			},
@@ -393,7 +517,7 @@ func TestBackendService_Validate(t *testing.T) {
 				BackendServiceConfig: BackendServiceConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -401,6 +525,154 @@ func TestBackendService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "network": `,
 		},
+		"error if ingress ports are missing and the image doesn't expose a port": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						VPC: vpcConfig{
+							Ingress: ServiceIngress{
+								From: []string{"api"},
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"network.vpc.ingress.ports" must be specified if "network.vpc.ingress.from" is specified`),
+		},
+		"error if service connect alias is specified and the image doesn't expose a port": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						Connect: ServiceConnectBoolOrArgs{
+							Advanced: ServiceConnectArgs{
+								Alias: aws.String("api"),
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"image.port" must be specified if "network.connect.alias" is specified`),
+		},
+		"error if fail to validate http": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Stickiness: aws.Bool(true),
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "http": `,
+		},
+		"error if http is specified and the image doesn't expose a port": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/api"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"image.port" must be specified if "http" is specified`),
+		},
+		"error if http hosted zone is specified without an alias": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+							},
+							Port: aws.Uint16(8080),
+						},
+					},
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path:       aws.String("/api"),
+							HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"http.alias" must be specified if "http.hosted_zone" is specified`),
+		},
+		"error if http additional rules are specified": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+							},
+							Port: aws.Uint16(8080),
+						},
+					},
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/api"),
+							AdditionalRules: []ListenerRule{
+								{
+									Priority: aws.Int(10),
+									Path:     aws.String("/admin"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"http.additional_rules" is not supported for Backend Services`),
+		},
+		"error if http waf is specified": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+							},
+							Port: aws.Uint16(8080),
+						},
+					},
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path: aws.String("/api"),
+							WAF: WebACLArgsOrARN{
+								ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mockName/mockID"),
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"http.waf" is not supported for Backend Services`),
+		},
+		"error if http target container doesn't exist": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("api")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+							},
+							Port: aws.Uint16(8080),
+						},
+					},
+					RoutingRule: RoutingRuleConfigOrBool{
+						RoutingRuleConfiguration: RoutingRuleConfiguration{
+							Path:            aws.String("/api"),
+							TargetContainer: aws.String("envoy"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`validate HTTP load balancer target: target container envoy doesn't exist`),
+		},
 		"error if fail to validate publish config": {
 			config: BackendService{
 				BackendServiceConfig: BackendServiceConfig{
@@ -613,6 +885,22 @@ func TestRequestDrivenWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "observability": `,
 		},
+		"error if env addons imports security groups": {
+			config: RequestDrivenWebService{
+				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
+					ImageConfig: ImageWithPort{
+						Image: Image{
+							Location: stringP("mockLocation"),
+						},
+						Port: uint16P(80),
+					},
+					EnvAddons: EnvAddonsImports{
+						SecurityGroups: []string{"AuroraSecurityGroup"},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`"env_addons.security_groups" is not supported for Request-Driven Web Services`),
+		},
 		"error if name is not set": {
 			config: RequestDrivenWebService{
 				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
@@ -689,7 +977,7 @@ func TestWorkerService_Validate(t *testing.T) {
 				WorkerServiceConfig: WorkerServiceConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -697,6 +985,36 @@ func TestWorkerService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "network": `,
 		},
+		"error if ingress ports are missing": {
+			config: WorkerService{
+				WorkerServiceConfig: WorkerServiceConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						VPC: vpcConfig{
+							Ingress: ServiceIngress{
+								From: []string{"api"},
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"network.vpc.ingress.ports" must be specified if "network.vpc.ingress.from" is specified`),
+		},
+		"error if service connect alias is specified": {
+			config: WorkerService{
+				WorkerServiceConfig: WorkerServiceConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						Connect: ServiceConnectBoolOrArgs{
+							Advanced: ServiceConnectArgs{
+								Alias: aws.String("api"),
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"network.connect.alias" is not supported for Worker Services since they don't expose a port`),
+		},
 		"error if fail to validate subscribe": {
 			config: WorkerService{
 				WorkerServiceConfig: WorkerServiceConfig{
@@ -838,52 +1156,125 @@ func TestWorkerService_Validate(t *testing.T) {
 	}
 }
 
-func TestScheduledJob_Validate(t *testing.T) {
-	testImageConfig := ImageWithHealthcheck{
-		Image: Image{
-			Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
-		},
-	}
+func TestStaticSite_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		config ScheduledJob
+		config StaticSite
 
 		wantedError          error
 		wantedErrorMsgPrefix string
 	}{
-		"error if fail to validate image": {
-			config: ScheduledJob{
-				ScheduledJobConfig: ScheduledJobConfig{
-					ImageConfig: ImageWithHealthcheck{
-						Image: Image{
-							Build:    BuildArgsOrString{BuildString: aws.String("mockBuild")},
-							Location: aws.String("mockLocation"),
-						},
+		"error if source path is missing": {
+			config: StaticSite{},
+
+			wantedError: fmt.Errorf(`validate "source": "path" must be specified`),
+		},
+		"error if index document is an absolute path": {
+			config: StaticSite{
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path: aws.String("dist"),
 					},
+					IndexDocument: aws.String("/index.html"),
 				},
 			},
-			wantedErrorMsgPrefix: `validate "image": `,
+
+			wantedError: fmt.Errorf(`"index_document" "/index.html" must be a path relative to "source.path"`),
 		},
-		"error if fail to validate sidecars": {
-			config: ScheduledJob{
-				ScheduledJobConfig: ScheduledJobConfig{
-					ImageConfig: testImageConfig,
-					Sidecars: map[string]*SidecarConfig{
-						"foo": {
-							DependsOn: DependsOn{
-								"foo": "bar",
-							},
-						},
+		"error if error document is an absolute path": {
+			config: StaticSite{
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path: aws.String("dist"),
 					},
+					ErrorDocument: aws.String("/404.html"),
 				},
 			},
-			wantedErrorMsgPrefix: `validate "sidecars[foo]": `,
+
+			wantedError: fmt.Errorf(`"error_document" "/404.html" must be a path relative to "source.path"`),
+		},
+		"valid": {
+			config: StaticSite{
+				Workload: Workload{
+					Name: aws.String("frontend"),
+				},
+				StaticSiteConfig: StaticSiteConfig{
+					Source: StaticSiteSource{
+						Path:  aws.String("dist"),
+						Build: aws.String("npm run build"),
+					},
+					IndexDocument: aws.String("index.html"),
+					ErrorDocument: aws.String("errors/404.html"),
+					Aliases: Alias{
+						String: aws.String("example.com"),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestScheduledJob_Validate(t *testing.T) {
+	testImageConfig := ImageWithHealthcheck{
+		Image: Image{
+			Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+		},
+	}
+	testCases := map[string]struct {
+		config ScheduledJob
+
+		wantedError          error
+		wantedErrorMsgPrefix string
+	}{
+		"error if fail to validate image": {
+			config: ScheduledJob{
+				ScheduledJobConfig: ScheduledJobConfig{
+					ImageConfig: ImageWithHealthcheck{
+						Image: Image{
+							Build:    BuildArgsOrString{BuildString: aws.String("mockBuild")},
+							Location: aws.String("mockLocation"),
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "image": `,
+		},
+		"error if fail to validate sidecars": {
+			config: ScheduledJob{
+				ScheduledJobConfig: ScheduledJobConfig{
+					ImageConfig: testImageConfig,
+					Sidecars: map[string]*SidecarConfig{
+						"foo": {
+							DependsOn: DependsOn{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "sidecars[foo]": `,
 		},
 		"error if fail to validate network": {
 			config: ScheduledJob{
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -891,6 +1282,35 @@ func TestScheduledJob_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "network": `,
 		},
+		"error if ingress is specified": {
+			config: ScheduledJob{
+				ScheduledJobConfig: ScheduledJobConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						VPC: vpcConfig{
+							Ingress: ServiceIngress{
+								From:  []string{"api"},
+								Ports: []uint16{80},
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"network.vpc.ingress" is not supported for Scheduled Jobs`),
+		},
+		"error if service connect is specified": {
+			config: ScheduledJob{
+				ScheduledJobConfig: ScheduledJobConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						Connect: ServiceConnectBoolOrArgs{
+							Enable: aws.Bool(true),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`"network.connect" is not supported for Scheduled Jobs`),
+		},
 		"error if fail to validate on": {
 			config: ScheduledJob{
 				ScheduledJobConfig: ScheduledJobConfig{
@@ -1027,6 +1447,13 @@ func TestPipelineManifest_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "deployments" for pipeline stage test:`,
 		},
+		"should validate preview": {
+			Pipeline: Pipeline{
+				Name:    "release",
+				Preview: &Preview{},
+			},
+			wantedErrorMsgPrefix: `validate "preview":`,
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -1045,6 +1472,53 @@ func TestPipelineManifest_Validate(t *testing.T) {
 	}
 }
 
+func TestPreview_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     Preview
+		wanted error
+	}{
+		"error if branches are not specified": {
+			wanted: errors.New(`"branches" must be specified`),
+		},
+		"error if a branch pattern contains whitespace": {
+			in: Preview{
+				Branches: []string{"feature/ *"},
+			},
+			wanted: errors.New(`branch pattern "feature/ *" must be non-empty and cannot contain whitespace`),
+		},
+		"error if a branch pattern is malformed": {
+			in: Preview{
+				Branches: []string{"feature/["},
+			},
+			wanted: errors.New(`branch pattern "feature/[" is invalid: syntax error in pattern`),
+		},
+		"error if ttl is too short": {
+			in: Preview{
+				Branches: []string{"feature/*"},
+				TTL:      durationp(30 * time.Minute),
+			},
+			wanted: errors.New(`"ttl" must be at least 1h0m0s`),
+		},
+		"success": {
+			in: Preview{
+				Branches: []string{"feature/*", "release-?"},
+				TTL:      durationp(24 * time.Hour),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual := tc.in.Validate()
+
+			if tc.wanted == nil {
+				require.NoError(t, actual)
+			} else {
+				require.EqualError(t, actual, tc.wanted.Error())
+			}
+		})
+	}
+}
+
 func TestDeployments_Validate(t *testing.T) {
 	testCases := map[string]struct {
 		in     Deployments
@@ -1216,6 +1690,56 @@ func TestRoutingRule_Validate(t *testing.T) {
 			RoutingRule:          RoutingRuleConfiguration{},
 			wantedErrorMsgPrefix: `"path" must be specified`,
 		},
+		"error if an additional rule is invalid": {
+			RoutingRule: RoutingRuleConfiguration{
+				Path: stringP("/"),
+				AdditionalRules: []ListenerRule{
+					{
+						Priority: aws.Int(10),
+						Path:     aws.String("/api"),
+					},
+					{
+						Path: aws.String("/admin"),
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "additional_rules[1]": "priority" must be specified`,
+		},
+		"error if additional rules have the same priority": {
+			RoutingRule: RoutingRuleConfiguration{
+				Path: stringP("/"),
+				AdditionalRules: []ListenerRule{
+					{
+						Priority: aws.Int(10),
+						Path:     aws.String("/api"),
+					},
+					{
+						Priority: aws.Int(10),
+						Hosts:    []string{"example.com"},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`"additional_rules[0]" and "additional_rules[1]" cannot have the same priority 10`),
+		},
+		"error if cdn is enabled with an alias": {
+			RoutingRule: RoutingRuleConfiguration{
+				Path:  stringP("/"),
+				Alias: Alias{String: aws.String("example.com")},
+				CDN: CDNBoolOrArgs{
+					Enable: aws.Bool(true),
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "cdn" and "alias"`),
+		},
+		"error if waf is invalid": {
+			RoutingRule: RoutingRuleConfiguration{
+				Path: stringP("/"),
+				WAF: WebACLArgsOrARN{
+					ARN: aws.String("mockARN"),
+				},
+			},
+			wantedErrorMsgPrefix: `validate "waf": `,
+		},
 		"should not error if protocol version is not uppercase": {
 			RoutingRule: RoutingRuleConfiguration{
 				Path:            stringP("/"),
@@ -1241,182 +1765,757 @@ func TestRoutingRule_Validate(t *testing.T) {
 	}
 }
 
-func TestNetworkLoadBalancerConfiguration_Validate(t *testing.T) {
+func TestWebACLArgsOrARN_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		nlb NetworkLoadBalancerConfiguration
+		in WebACLArgsOrARN
 
-		wantedErrorMsgPrefix string
-		wantedError          error
+		wantedError error
 	}{
-		"success if empty": {
-			nlb: NetworkLoadBalancerConfiguration{},
-		},
-		"error if port unspecified": {
-			nlb: NetworkLoadBalancerConfiguration{
-				TargetContainer: aws.String("main"),
-			},
-			wantedErrorMsgPrefix: `validate "nlb": `,
-			wantedError:          fmt.Errorf(`"port" must be specified`),
-		},
-		"error parsing port": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("sabotage/this/string"),
-			},
-			wantedErrorMsgPrefix: `validate "nlb": `,
-			wantedError:          fmt.Errorf(`validate "port": cannot parse port mapping from sabotage/this/string`),
-		},
-		"success if port is specified without protocol": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("443"),
+		"error if the arn is not a web ACL arn": {
+			in: WebACLArgsOrARN{
+				ARN: aws.String("arn:aws:waf::123456789012:webacl/mockID"),
 			},
+			wantedError: errors.New("arn:aws:waf::123456789012:webacl/mockID is not a valid regional AWS WAF web ACL ARN"),
 		},
-		"fail if protocol is not recognized": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("443/tps"),
+		"error if a managed rule is not an AWS managed rule group": {
+			in: WebACLArgsOrARN{
+				Advanced: WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet", "MyRuleGroup"},
+				},
 			},
-			wantedErrorMsgPrefix: `validate "nlb": `,
-			wantedError:          fmt.Errorf(`validate "port": invalid protocol tps; valid protocols include TCP and TLS`),
+			wantedError: errors.New(`"managed_rules[1]" value 'MyRuleGroup' is not the name of an AWS managed rule group`),
 		},
-		"success if tcp": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("443/tcp"),
+		"error if a managed rule is duplicated": {
+			in: WebACLArgsOrARN{
+				Advanced: WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet", "AWSManagedRulesCommonRuleSet"},
+				},
 			},
+			wantedError: errors.New(`"managed_rules[1]" value 'AWSManagedRulesCommonRuleSet' is a duplicate`),
 		},
-		"error if udp": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("161/udp"),
+		"error if the rate limit is out of range": {
+			in: WebACLArgsOrARN{
+				Advanced: WebACLArgs{
+					RateLimit: aws.Int(50),
+				},
 			},
-			wantedError: fmt.Errorf(`validate "port": invalid protocol udp; valid protocols include TCP and TLS`),
+			wantedError: errors.New(`"rate_limit" must be between 100 and 2000000000`),
 		},
-		"success if tls": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("443/tls"),
+		"valid web ACL arn": {
+			in: WebACLArgsOrARN{
+				ARN: aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mockName/mockID"),
 			},
 		},
-		"error if tcp_udp": {
-			nlb: NetworkLoadBalancerConfiguration{
-				Port: aws.String("443/TCP_udp"),
+		"valid managed rules and rate limit": {
+			in: WebACLArgsOrARN{
+				Advanced: WebACLArgs{
+					ManagedRules: []string{"AWSManagedRulesCommonRuleSet", "AWSManagedRulesAmazonIpReputationList"},
+					RateLimit:    aws.Int(2000),
+				},
 			},
-			wantedError: fmt.Errorf(`validate "port": invalid protocol TCP_udp; valid protocols include TCP and TLS`),
 		},
 	}
-
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.nlb.Validate()
+			gotErr := tc.in.Validate()
 
 			if tc.wantedError != nil {
 				require.EqualError(t, gotErr, tc.wantedError.Error())
 				return
 			}
-			if tc.wantedErrorMsgPrefix != "" {
-				require.Error(t, gotErr)
-				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
-				return
-			}
 			require.NoError(t, gotErr)
 		})
 	}
 }
 
-func TestIPNet_Validate(t *testing.T) {
-	testCases := map[string]struct {
-		in     IPNet
-		wanted error
-	}{
-		"should return an error if IPNet is not valid": {
-			in:     IPNet("badIPNet"),
-			wanted: errors.New("parse IPNet badIPNet: invalid CIDR address: badIPNet"),
-		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
-
-			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
-			} else {
-				require.NoError(t, err)
-			}
-		})
-	}
-}
-
-func TestTaskConfig_Validate(t *testing.T) {
-	mockPerc := Percentage(70)
+func TestCDNBoolOrArgs_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		TaskConfig TaskConfig
+		in CDNBoolOrArgs
 
-		wantedError          error
-		wantedErrorMsgPrefix string
+		wantedError error
 	}{
-		"error if fail to validate platform": {
-			TaskConfig: TaskConfig{
-				Platform: PlatformArgsOrString{
-					PlatformString: (*PlatformString)(aws.String("")),
+		"error if the cache policy is neither a managed policy nor an ID": {
+			in: CDNBoolOrArgs{
+				Advanced: CDNArgs{
+					CachePolicy: aws.String("MyPolicy"),
 				},
 			},
-			wantedErrorMsgPrefix: `validate "platform": `,
+			wantedError: errors.New(`"cache_policy" value 'MyPolicy' must be the ID of a cache policy or one of CachingDisabled, CachingOptimized or CachingOptimizedForUncompressedObjects`),
 		},
-		"error if fail to validate count": {
-			TaskConfig: TaskConfig{
-				Count: Count{
-					AdvancedCount: AdvancedCount{
-						Spot: aws.Int(123),
-						CPU:  &mockPerc,
-					},
-				},
+		"valid bool": {
+			in: CDNBoolOrArgs{
+				Enable: aws.Bool(true),
 			},
-			wantedErrorMsgPrefix: `validate "count": `,
 		},
-		"error if fail to validate storage": {
-			TaskConfig: TaskConfig{
-				Storage: Storage{
-					Volumes: map[string]*Volume{
-						"foo": {
-							EFS: EFSConfigOrBool{
-								Advanced: EFSVolumeConfiguration{
-									UID:          aws.Uint32(123),
-									FileSystemID: aws.String("mockID"),
-								},
-							},
-						},
+		"valid managed cache policy": {
+			in: CDNBoolOrArgs{
+				Advanced: CDNArgs{
+					CachePolicy:  aws.String("CachingOptimized"),
+					OriginShield: aws.Bool(true),
+					Aliases: Alias{
+						StringSlice: []string{"www.example.com"},
 					},
 				},
 			},
-			wantedErrorMsgPrefix: `validate "storage": `,
 		},
-		"error if invalid env file": {
-			TaskConfig: TaskConfig{
-				EnvFile: aws.String("foo"),
+		"valid custom cache policy": {
+			in: CDNBoolOrArgs{
+				Advanced: CDNArgs{
+					CachePolicy: aws.String("a1b2c3d4-5678-90ab-cdef-1234567890ab"),
+				},
 			},
-			wantedError: fmt.Errorf("environment file foo must have a .env file extension"),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.TaskConfig.Validate()
+			gotErr := tc.in.Validate()
 
 			if tc.wantedError != nil {
 				require.EqualError(t, gotErr, tc.wantedError.Error())
 				return
 			}
-			if tc.wantedErrorMsgPrefix != "" {
-				require.Error(t, gotErr)
-				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
-				return
-			}
 			require.NoError(t, gotErr)
 		})
 	}
 }
 
-func TestPlatformArgsOrString_Validate(t *testing.T) {
+func TestListenerRule_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     PlatformArgsOrString
-		wanted error
+		in ListenerRule
+
+		wantedErrorMsgPrefix string
+		wantedError          error
 	}{
-		"error if platform string is invalid": {
+		"error if priority is missing": {
+			in: ListenerRule{
+				Path: aws.String("/api"),
+			},
+			wantedError: fmt.Errorf(`"priority" must be specified`),
+		},
+		"error if priority is out of range": {
+			in: ListenerRule{
+				Priority: aws.Int(50000),
+				Path:     aws.String("/api"),
+			},
+			wantedError: fmt.Errorf(`"priority" must be between 1 and 49999`),
+		},
+		"error if no condition is specified": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+			},
+			wantedError: fmt.Errorf(`must specify at least one of "path", "hosts", "headers", "query_strings" or "allowed_source_ips"`),
+		},
+		"error if there are too many condition values": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/api"),
+				Hosts:    []string{"a.example.com", "b.example.com"},
+				Headers: map[string][]string{
+					"X-Canary": {"true", "yes"},
+				},
+			},
+			wantedError: fmt.Errorf("listener rule has 6 condition values, cannot exceed 5"),
+		},
+		"error if a header has no value": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Headers: map[string][]string{
+					"X-Canary": {},
+				},
+			},
+			wantedError: fmt.Errorf(`"headers[X-Canary]" must have at least one value`),
+		},
+		"error if one of allowed_source_ips is not valid": {
+			in: ListenerRule{
+				Priority:         aws.Int(1),
+				AllowedSourceIps: []IPNet{"badIP"},
+			},
+			wantedErrorMsgPrefix: `validate "allowed_source_ips[0]": `,
+		},
+		"error if both targets and redirect are specified": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/old"),
+				Targets: []WeightedTarget{
+					{Container: aws.String("web")},
+				},
+				Redirect: RedirectAction{
+					Path: aws.String("/new"),
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "targets" and "redirect"`),
+		},
+		"error if both redirect and fixed_response are specified": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/old"),
+				Redirect: RedirectAction{
+					Path: aws.String("/new"),
+				},
+				FixedResponse: FixedResponseAction{
+					StatusCode: aws.Int(503),
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "redirect" and "fixed_response"`),
+		},
+		"error if a target has no container": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/api"),
+				Targets: []WeightedTarget{
+					{Weight: aws.Int(10)},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "targets[0]": "container" must be specified`),
+		},
+		"error if a target weight is out of range": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/api"),
+				Targets: []WeightedTarget{
+					{Container: aws.String("web"), Weight: aws.Int(1000)},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "targets[0]": "weight" must be between 0 and 999`),
+		},
+		"error if the redirect protocol is invalid": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/old"),
+				Redirect: RedirectAction{
+					Protocol: aws.String("FTP"),
+				},
+			},
+			wantedError: fmt.Errorf(`validate "redirect": "protocol" field value 'FTP' must be one of HTTP, HTTPS or #{protocol}`),
+		},
+		"error if the redirect status code is invalid": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/old"),
+				Redirect: RedirectAction{
+					Path:       aws.String("/new"),
+					StatusCode: aws.Int(307),
+				},
+			},
+			wantedError: fmt.Errorf(`validate "redirect": "status_code" field value '307' must be one of 301 or 302`),
+		},
+		"error if the fixed response status code is missing": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/maintenance"),
+				FixedResponse: FixedResponseAction{
+					Body: aws.String("down for maintenance"),
+				},
+			},
+			wantedError: fmt.Errorf(`validate "fixed_response": "status_code" must be specified`),
+		},
+		"error if the fixed response status code is invalid": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/maintenance"),
+				FixedResponse: FixedResponseAction{
+					StatusCode: aws.Int(302),
+				},
+			},
+			wantedError: fmt.Errorf(`validate "fixed_response": "status_code" field value '302' must be a 2XX, 4XX, or 5XX status code`),
+		},
+		"error if the fixed response content type is invalid": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/maintenance"),
+				FixedResponse: FixedResponseAction{
+					StatusCode:  aws.Int(503),
+					ContentType: aws.String("image/png"),
+				},
+			},
+			wantedErrorMsgPrefix: `validate "fixed_response": "content_type" field value 'image/png' must be one of`,
+		},
+		"error if the fixed response body is too long": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/maintenance"),
+				FixedResponse: FixedResponseAction{
+					StatusCode: aws.Int(503),
+					Body:       aws.String(strings.Repeat("a", 1025)),
+				},
+			},
+			wantedError: fmt.Errorf(`validate "fixed_response": "body" cannot be longer than 1024 characters`),
+		},
+		"valid weighted forward rule": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/"),
+				Hosts:    []string{"api.example.com"},
+				QueryStrings: map[string]string{
+					"version": "2",
+				},
+				Targets: []WeightedTarget{
+					{Container: aws.String("web"), Weight: aws.Int(90)},
+					{Container: aws.String("canary"), Weight: aws.Int(10)},
+				},
+			},
+		},
+		"valid fixed response rule": {
+			in: ListenerRule{
+				Priority: aws.Int(1),
+				Path:     aws.String("/maintenance"),
+				FixedResponse: FixedResponseAction{
+					StatusCode:  aws.Int(503),
+					ContentType: aws.String("text/html"),
+					Body:        aws.String("<h1>Down for maintenance</h1>"),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.in.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestNetworkLoadBalancerConfiguration_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		nlb NetworkLoadBalancerConfiguration
+
+		wantedErrorMsgPrefix string
+		wantedError          error
+	}{
+		"success if empty": {
+			nlb: NetworkLoadBalancerConfiguration{},
+		},
+		"error if port unspecified": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					TargetContainer: aws.String("main"),
+				},
+			},
+			wantedErrorMsgPrefix: `validate "nlb": `,
+			wantedError:          fmt.Errorf(`"port" must be specified`),
+		},
+		"error parsing port": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("sabotage/this/string"),
+				},
+			},
+			wantedErrorMsgPrefix: `validate "nlb": `,
+			wantedError:          fmt.Errorf(`validate "port": cannot parse port mapping from sabotage/this/string`),
+		},
+		"success if port is specified without protocol": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443"),
+				},
+			},
+		},
+		"fail if protocol is not recognized": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443/tps"),
+				},
+			},
+			wantedErrorMsgPrefix: `validate "nlb": `,
+			wantedError:          fmt.Errorf(`validate "port": invalid protocol tps; valid protocols include TCP, UDP, TCP_UDP and TLS`),
+		},
+		"success if tcp": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443/tcp"),
+				},
+			},
+		},
+		"success if udp": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("161/udp"),
+					HealthCheck: NLBHealthCheckArgs{
+						Port: aws.Int(8080),
+					},
+				},
+			},
+		},
+		"error if udp without a health check port": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("161/udp"),
+				},
+			},
+			wantedError: fmt.Errorf(`"healthcheck.port" must be specified for the UDP protocol`),
+		},
+		"success if tls": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443/tls"),
+				},
+			},
+		},
+		"success if tcp_udp": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("53/TCP_udp"),
+				},
+			},
+		},
+		"error if alpn policy is specified without tls": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port:       aws.String("443/tcp"),
+					ALPNPolicy: aws.String("HTTP2Preferred"),
+				},
+			},
+			wantedError: fmt.Errorf(`"alpn_policy" can only be specified with the TLS protocol`),
+		},
+		"error if ssl policy is specified without tls": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port:      aws.String("443/tcp"),
+					SSLPolicy: aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+				},
+			},
+			wantedError: fmt.Errorf(`"ssl_policy" can only be specified with the TLS protocol`),
+		},
+		"error if alpn policy is invalid": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port:       aws.String("443/tls"),
+					ALPNPolicy: aws.String("HTTP3Only"),
+				},
+			},
+			wantedError: fmt.Errorf(`validate "alpn_policy": value must be one of HTTP1Only, HTTP2Only, HTTP2Optional, HTTP2Preferred or None`),
+		},
+		"error if client IP preservation is disabled for udp": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port:             aws.String("53/udp"),
+					PreserveClientIP: aws.Bool(false),
+				},
+			},
+			wantedError: fmt.Errorf(`"preserve_client_ip" cannot be disabled for the UDP protocol`),
+		},
+		"error if an additional listener is invalid": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("443/tls"),
+				},
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						TargetPort: aws.Int(8080),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_listeners[0]": "port" must be specified`),
+		},
+		"error if listeners use the same port": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port: aws.String("53/tcp"),
+				},
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port: aws.String("443/tls"),
+					},
+					{
+						Port: aws.String("53/udp"),
+						HealthCheck: NLBHealthCheckArgs{
+							Port: aws.Int(8080),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`"port" and "additional_listeners[1]" cannot listen on the same port 53`),
+		},
+		"success with additional listeners": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Listener: NetworkLoadBalancerListener{
+					Port:       aws.String("443/tls"),
+					ALPNPolicy: aws.String("HTTP2Preferred"),
+				},
+				CrossZone: aws.Bool(true),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port:             aws.String("7777/udp"),
+						TargetContainer:  aws.String("game"),
+						PreserveClientIP: aws.Bool(true),
+						HealthCheck: NLBHealthCheckArgs{
+							Port: aws.Int(8080),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.nlb.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestIPNet_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     IPNet
+		wanted error
+	}{
+		"should return an error if IPNet is not valid": {
+			in:     IPNet("badIPNet"),
+			wanted: errors.New("parse IPNet badIPNet: invalid CIDR address: badIPNet"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTaskConfig_Validate(t *testing.T) {
+	mockPerc := Percentage(70)
+	testCases := map[string]struct {
+		TaskConfig TaskConfig
+
+		wantedError          error
+		wantedErrorMsgPrefix string
+	}{
+		"error if fail to validate platform": {
+			TaskConfig: TaskConfig{
+				Platform: PlatformArgsOrString{
+					PlatformString: (*PlatformString)(aws.String("")),
+				},
+			},
+			wantedErrorMsgPrefix: `validate "platform": `,
+		},
+		"error if fail to validate count": {
+			TaskConfig: TaskConfig{
+				Count: Count{
+					AdvancedCount: AdvancedCount{
+						Spot: aws.Int(123),
+						CPU:  &mockPerc,
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "count": `,
+		},
+		"error if fail to validate storage": {
+			TaskConfig: TaskConfig{
+				Storage: Storage{
+					Volumes: map[string]*Volume{
+						"foo": {
+							EFS: EFSConfigOrBool{
+								Advanced: EFSVolumeConfiguration{
+									UID:          aws.Uint32(123),
+									FileSystemID: aws.String("mockID"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "storage": `,
+		},
+		"error if invalid env file": {
+			TaskConfig: TaskConfig{
+				EnvFile: aws.String("foo"),
+			},
+			wantedError: fmt.Errorf("environment file foo must have a .env file extension"),
+		},
+		"error if fail to validate env addons": {
+			TaskConfig: TaskConfig{
+				EnvAddons: EnvAddonsImports{
+					Variables: []string{"users-table"},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "env_addons": `,
+		},
+		"error if fail to validate permissions": {
+			TaskConfig: TaskConfig{
+				Permissions: []IAMPermission{
+					{
+						Resources: []string{"*"},
+					},
+				},
+			},
+			wantedError: errors.New(`validate "permissions": statement 1: "actions" must be specified`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.TaskConfig.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestEnvAddonsImports_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     EnvAddonsImports
+		wanted error
+	}{
+		"valid if empty": {
+			in: EnvAddonsImports{},
+		},
+		"valid if all outputs are logical IDs": {
+			in: EnvAddonsImports{
+				Variables:      []string{"UsersTableName"},
+				Secrets:        []string{"AuroraSecret"},
+				Policies:       []string{"UsersAccessPolicy"},
+				SecurityGroups: []string{"AuroraSecurityGroup"},
+			},
+		},
+		"error if an output name contains special characters": {
+			in: EnvAddonsImports{
+				Policies: []string{"Users_AccessPolicy"},
+			},
+			wanted: fmt.Errorf(`output name "Users_AccessPolicy" is invalid: must contain only alphanumeric characters`),
+		},
+		"error if an output name is empty": {
+			in: EnvAddonsImports{
+				Secrets: []string{""},
+			},
+			wanted: fmt.Errorf(`output name "" is invalid: must contain only alphanumeric characters`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestIAMPermission_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     IAMPermission
+		wanted error
+	}{
+		"valid statement with conditions": {
+			in: IAMPermission{
+				Effect:    aws.String("Deny"),
+				Actions:   []string{"s3:GetObject", "s3:List*", "*"},
+				Resources: []string{"arn:aws:s3:::my-bucket/*"},
+				Conditions: map[string]map[string]IAMConditionValues{
+					"ForAnyValue:StringLikeIfExists": {
+						"aws:PrincipalTag/team": {"payments", "billing"},
+					},
+				},
+			},
+		},
+		"error if effect is invalid": {
+			in: IAMPermission{
+				Effect:    aws.String("allow"),
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"*"},
+			},
+			wanted: errors.New(`"effect" "allow" must be one of Allow or Deny`),
+		},
+		"error if action is invalid": {
+			in: IAMPermission{
+				Actions:   []string{"GetObject"},
+				Resources: []string{"*"},
+			},
+			wanted: errors.New(`action "GetObject" is invalid: must be "*" or of the form "<service>:<action>"`),
+		},
+		"error if resources are missing": {
+			in: IAMPermission{
+				Actions: []string{"s3:GetObject"},
+			},
+			wanted: errors.New(`"resources" must be specified`),
+		},
+		"error if resource is not an ARN": {
+			in: IAMPermission{
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"my-bucket"},
+			},
+			wanted: errors.New(`resource "my-bucket" is invalid: must be "*" or an ARN`),
+		},
+		"error if condition operator is invalid": {
+			in: IAMPermission{
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"*"},
+				Conditions: map[string]map[string]IAMConditionValues{
+					"String Equals": {
+						"aws:PrincipalTag/team": {"payments"},
+					},
+				},
+			},
+			wanted: errors.New(`condition operator "String Equals" is invalid`),
+		},
+		"error if condition key has no value": {
+			in: IAMPermission{
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"*"},
+				Conditions: map[string]map[string]IAMConditionValues{
+					"StringEquals": {
+						"aws:PrincipalTag/team": {},
+					},
+				},
+			},
+			wanted: errors.New(`condition key "aws:PrincipalTag/team" of operator "StringEquals" must have at least one value`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestPlatformArgsOrString_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     PlatformArgsOrString
+		wanted error
+	}{
+		"error if platform string is invalid": {
 			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("foobar/amd64"))},
 			wanted: fmt.Errorf("platform 'foobar/amd64' is invalid; valid platforms are: linux/amd64, linux/x86_64, linux/arm, linux/arm64, windows/amd64 and windows/x86_64"),
 		},
@@ -1470,6 +2569,77 @@ func TestPlatformArgsOrString_Validate(t *testing.T) {
 			},
 			wanted: nil,
 		},
+		"error if launch type is invalid": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					LaunchType: aws.String("external"),
+				},
+			},
+			wanted: fmt.Errorf(`"launch_type" field value 'external' must be one of fargate or ec2`),
+		},
+		"error if network mode is specified for fargate": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					NetworkMode: aws.String("bridge"),
+				},
+			},
+			wanted: fmt.Errorf(`"network_mode" can only be specified when "launch_type" is ec2`),
+		},
+		"error if placement strategy is specified for fargate": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					LaunchType:        aws.String("fargate"),
+					PlacementStrategy: aws.String("binpack"),
+				},
+			},
+			wanted: fmt.Errorf(`"placement_strategy" can only be specified when "launch_type" is ec2`),
+		},
+		"error if network mode is invalid": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					LaunchType:  aws.String("ec2"),
+					NetworkMode: aws.String("host"),
+				},
+			},
+			wanted: fmt.Errorf(`"network_mode" field value 'host' must be one of awsvpc or bridge`),
+		},
+		"error if placement strategy is invalid": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					LaunchType:        aws.String("ec2"),
+					PlacementStrategy: aws.String("random"),
+				},
+			},
+			wanted: fmt.Errorf(`"placement_strategy" field value 'random' must be one of spread or binpack`),
+		},
+		"error if ec2 launch type is used with windows": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily:   aws.String("windows_server_2019_core"),
+					Arch:       aws.String("x86_64"),
+					LaunchType: aws.String("ec2"),
+				},
+			},
+			wanted: fmt.Errorf(`"launch_type" ec2 is not supported for Windows containers`),
+		},
+		"return nil if only the launch type is specified": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					LaunchType:        aws.String("EC2"),
+					NetworkMode:       aws.String("bridge"),
+					PlacementStrategy: aws.String("binpack"),
+				},
+			},
+		},
+		"return nil if ec2 launch type is used with arm64": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily:   aws.String("linux"),
+					Arch:       aws.String("arm64"),
+					LaunchType: aws.String("ec2"),
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -1527,7 +2697,7 @@ func TestAdvancedCount_Validate(t *testing.T) {
 				CPU:          &mockPerc,
 				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify one, not both, of "spot" and "range/cpu_percentage/memory_percentage/requests/response_time"`),
+			wantedError: fmt.Errorf(`must specify one, not both, of "spot" and "range/cpu_percentage/memory_percentage/requests/response_time/custom_metrics/schedules"`),
 		},
 		"error if fail to validate range": {
 			AdvancedCount: AdvancedCount{
@@ -1543,7 +2713,7 @@ func TestAdvancedCount_Validate(t *testing.T) {
 				Requests:     aws.Int(123),
 				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, requests or response_time" are specified`),
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, requests, response_time, custom_metrics or schedules" are specified`),
 		},
 		"error if range is specified but no autoscaling fields are specified for a Load Balanced Web Service": {
 			AdvancedCount: AdvancedCount{
@@ -1552,7 +2722,7 @@ func TestAdvancedCount_Validate(t *testing.T) {
 				},
 				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "requests" or "response_time" if "range" is specified`),
+			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "requests", "response_time", "custom_metrics" or "schedules" if "range" is specified`),
 		},
 		"error if range is specified but no autoscaling fields are specified for a Backend Service": {
 			AdvancedCount: AdvancedCount{
@@ -1561,7 +2731,7 @@ func TestAdvancedCount_Validate(t *testing.T) {
 				},
 				workloadType: BackendServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage" or "memory_percentage" if "range" is specified`),
+			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "custom_metrics" or "schedules" if "range" is specified`),
 		},
 		"error if range is specified but no autoscaling fields are specified for a Worker Service": {
 			AdvancedCount: AdvancedCount{
@@ -1570,74 +2740,389 @@ func TestAdvancedCount_Validate(t *testing.T) {
 				},
 				workloadType: WorkerServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage" or "queue_delay" if "range" is specified`),
+			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "queue_delay", "custom_metrics" or "schedules" if "range" is specified`),
 		},
 		"error if range is missing when autoscaling fields are set for Backend Service": {
 			AdvancedCount: AdvancedCount{
 				CPU:          &mockPerc,
 				workloadType: BackendServiceType,
 			},
-			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage or memory_percentage" are specified`),
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, custom_metrics or schedules" are specified`),
+		},
+		"error if range is missing when autoscaling fields are set for Worker Service": {
+			AdvancedCount: AdvancedCount{
+				CPU:          &mockPerc,
+				workloadType: WorkerServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, queue_delay, custom_metrics or schedules" are specified`),
+		},
+		"wrap error from queue_delay on failure": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					RangeConfig: RangeConfig{
+						Min:      aws.Int(1),
+						Max:      aws.Int(10),
+						SpotFrom: aws.Int(6),
+					},
+				},
+				QueueScaling: QueueScaling{
+					AcceptableLatency: nil,
+					AvgProcessingTime: durationp(1 * time.Second),
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "queue_delay": `,
+		},
+		"error if CPU perc is not valid": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU:          &invalidPerc,
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "cpu_percentage": `,
+		},
+		"error if memory perc is not valid": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				Memory:       &invalidPerc,
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "memory_percentage": `,
+		},
+		"valid with custom metrics and schedules within the range": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("2-20")),
+				},
+				CustomMetrics: []CustomMetricScaling{
+					{
+						CloudWatchMetric: CloudWatchMetric{
+							Namespace:  aws.String("MyApp"),
+							MetricName: aws.String("ActiveConnections"),
+						},
+						Target: aws.Float64(100),
+					},
+				},
+				Schedules: []ScheduledScaling{
+					{
+						Cron: aws.String("0 9 * * 1-5"),
+						Min:  aws.Int(8),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+		},
+		"error if range is missing when schedules are set": {
+			AdvancedCount: AdvancedCount{
+				Schedules: []ScheduledScaling{
+					{
+						Cron: aws.String("0 9 * * 1-5"),
+						Min:  aws.Int(8),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, custom_metrics or schedules" are specified`),
+		},
+		"wrap error from custom metrics on failure": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CustomMetrics: []CustomMetricScaling{
+					{
+						Target: aws.Float64(10),
+					},
+				},
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedError: fmt.Errorf(`validate "custom_metrics[0]": "namespace" must be specified`),
+		},
+		"error if a schedule is outside of the range": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					RangeConfig: RangeConfig{
+						Min: aws.Int(2),
+						Max: aws.Int(10),
+					},
+				},
+				Schedules: []ScheduledScaling{
+					{
+						Cron: aws.String("0 9 * * 1-5"),
+						Min:  aws.Int(4),
+						Max:  aws.Int(10),
+					},
+					{
+						Cron: aws.String("0 18 * * 1-5"),
+						Max:  aws.Int(20),
+					},
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: fmt.Errorf(`validate "schedules[1]": "max" 20 must be within the range 2-10`),
+		},
+		"error if a schedule is below the range": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("2-10")),
+				},
+				Schedules: []ScheduledScaling{
+					{
+						Cron: aws.String("0 18 * * 1-5"),
+						Min:  aws.Int(1),
+					},
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: fmt.Errorf(`validate "schedules[0]": "min" 1 must be within the range 2-10`),
+		},
+		"wrap error from an invalid schedule": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("2-10")),
+				},
+				Schedules: []ScheduledScaling{
+					{
+						Min: aws.Int(4),
+					},
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: fmt.Errorf(`validate "schedules[0]": "cron" must be specified`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.AdvancedCount.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestCustomMetricScaling_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in          CustomMetricScaling
+		wantedError error
+	}{
+		"error if target is missing": {
+			in: CustomMetricScaling{
+				CloudWatchMetric: CloudWatchMetric{
+					Namespace:  aws.String("MyApp"),
+					MetricName: aws.String("ActiveConnections"),
+				},
+			},
+			wantedError: errors.New(`"target" must be specified`),
+		},
+		"error if target is not positive": {
+			in: CustomMetricScaling{
+				CloudWatchMetric: CloudWatchMetric{
+					Namespace:  aws.String("MyApp"),
+					MetricName: aws.String("ActiveConnections"),
+				},
+				Target: aws.Float64(0),
+			},
+			wantedError: errors.New(`"target" must be greater than 0`),
+		},
+		"error if metric name is missing": {
+			in: CustomMetricScaling{
+				CloudWatchMetric: CloudWatchMetric{
+					Namespace: aws.String("MyApp"),
+				},
+				Target: aws.Float64(100),
+			},
+			wantedError: errors.New(`"metric_name" must be specified`),
+		},
+		"error if statistic is invalid": {
+			in: CustomMetricScaling{
+				CloudWatchMetric: CloudWatchMetric{
+					Namespace:  aws.String("MyApp"),
+					MetricName: aws.String("ActiveConnections"),
+					Statistic:  aws.String("p99"),
+				},
+				Target: aws.Float64(100),
+			},
+			wantedError: errors.New(`"statistic" field value 'p99' must be one of Average, Minimum, Maximum, SampleCount or Sum`),
+		},
+		"error if metrics are specified without an expression": {
+			in: CustomMetricScaling{
+				Metrics: []CloudWatchMetricQuery{
+					{
+						ID: aws.String("m1"),
+					},
+				},
+				Target: aws.Float64(100),
+			},
+			wantedError: errors.New(`"expression" must be specified if "metrics" is specified`),
+		},
+		"error if both an expression and a metric are specified": {
+			in: CustomMetricScaling{
+				CloudWatchMetric: CloudWatchMetric{
+					Namespace: aws.String("MyApp"),
+				},
+				Expression: aws.String("m1 / m2"),
+				Target:     aws.Float64(100),
+			},
+			wantedError: errors.New(`must specify one, not both, of "expression" and "namespace/metric_name/dimensions/statistic"`),
+		},
+		"error if an expression has no metrics": {
+			in: CustomMetricScaling{
+				Expression: aws.String("m1 / m2"),
+				Target:     aws.Float64(100),
+			},
+			wantedError: errors.New(`"metrics" must be specified if "expression" is specified`),
+		},
+		"error if a metric id is invalid": {
+			in: CustomMetricScaling{
+				Expression: aws.String("m1 / m2"),
+				Metrics: []CloudWatchMetricQuery{
+					{
+						ID: aws.String("M1"),
+					},
+				},
+				Target: aws.Float64(100),
+			},
+			wantedError: errors.New(`validate "metrics[0]": "id" M1 must start with a lowercase letter and contain only letters, numbers, and underscores`),
+		},
+		"error if a metric id is reserved": {
+			in: CustomMetricScaling{
+				Expression: aws.String("m1 / m2"),
+				Metrics: []CloudWatchMetricQuery{
+					{
+						ID: aws.String("expression"),
+					},
+				},
+				Target: aws.Float64(100),
+			},
+			wantedError: errors.New(`validate "metrics[0]": "id" cannot be expression as it is reserved for the expression`),
+		},
+		"error if metric ids are duplicated": {
+			in: CustomMetricScaling{
+				Expression: aws.String("m1 / m2"),
+				Metrics: []CloudWatchMetricQuery{
+					{
+						ID: aws.String("m1"),
+						CloudWatchMetric: CloudWatchMetric{
+							Namespace:  aws.String("MyApp"),
+							MetricName: aws.String("Errors"),
+						},
+					},
+					{
+						ID: aws.String("m1"),
+						CloudWatchMetric: CloudWatchMetric{
+							Namespace:  aws.String("MyApp"),
+							MetricName: aws.String("Requests"),
+						},
+					},
+				},
+				Target: aws.Float64(100),
+			},
+			wantedError: errors.New(`"metrics[1]" cannot have the same "id" m1 as another metric`),
+		},
+		"valid metric math expression": {
+			in: CustomMetricScaling{
+				Expression: aws.String("m1 / m2"),
+				Metrics: []CloudWatchMetricQuery{
+					{
+						ID: aws.String("m1"),
+						CloudWatchMetric: CloudWatchMetric{
+							Namespace:  aws.String("MyApp"),
+							MetricName: aws.String("Errors"),
+							Statistic:  aws.String("Sum"),
+						},
+					},
+					{
+						ID: aws.String("m2"),
+						CloudWatchMetric: CloudWatchMetric{
+							Namespace:  aws.String("MyApp"),
+							MetricName: aws.String("Requests"),
+							Dimensions: map[string]string{
+								"Service": "api",
+							},
+						},
+					},
+				},
+				Target: aws.Float64(0.5),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScheduledScaling_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in          ScheduledScaling
+		wantedError error
+	}{
+		"error if cron is missing": {
+			in: ScheduledScaling{
+				Min: aws.Int(1),
+			},
+			wantedError: errors.New(`"cron" must be specified`),
 		},
-		"error if range is missing when autoscaling fields are set for Worker Service": {
-			AdvancedCount: AdvancedCount{
-				CPU:          &mockPerc,
-				workloadType: WorkerServiceType,
+		"error if neither min nor max is specified": {
+			in: ScheduledScaling{
+				Cron: aws.String("0 9 * * 1-5"),
 			},
-			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage or queue_delay" are specified`),
+			wantedError: errors.New(`must specify at least one of "min" or "max"`),
 		},
-		"wrap error from queue_delay on failure": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					RangeConfig: RangeConfig{
-						Min:      aws.Int(1),
-						Max:      aws.Int(10),
-						SpotFrom: aws.Int(6),
-					},
-				},
-				QueueScaling: QueueScaling{
-					AcceptableLatency: nil,
-					AvgProcessingTime: durationp(1 * time.Second),
-				},
-				workloadType: WorkerServiceType,
+		"error if min is greater than max": {
+			in: ScheduledScaling{
+				Cron: aws.String("0 9 * * 1-5"),
+				Min:  aws.Int(6),
+				Max:  aws.Int(4),
 			},
-			wantedErrorMsgPrefix: `validate "queue_delay": `,
+			wantedError: errors.New(`min value 6 cannot be greater than max value 4`),
 		},
-		"error if CPU perc is not valid": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(stringP("1-2")),
-				},
-				CPU:          &invalidPerc,
-				workloadType: LoadBalancedWebServiceType,
+		"error if the time zone is invalid": {
+			in: ScheduledScaling{
+				Cron:     aws.String("0 9 * * 1-5"),
+				TimeZone: aws.String("Mars/Olympus_Mons"),
+				Min:      aws.Int(4),
 			},
-			wantedErrorMsgPrefix: `validate "cpu_percentage": `,
+			wantedError: errors.New(`"timezone" Mars/Olympus_Mons is not a valid IANA time zone: unknown time zone Mars/Olympus_Mons`),
 		},
-		"error if memory perc is not valid": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(stringP("1-2")),
-				},
-				Memory:       &invalidPerc,
-				workloadType: LoadBalancedWebServiceType,
+		"valid schedule": {
+			in: ScheduledScaling{
+				Cron:     aws.String("0 9 * * 1-5"),
+				TimeZone: aws.String("America/New_York"),
+				Min:      aws.Int(4),
+				Max:      aws.Int(10),
 			},
-			wantedErrorMsgPrefix: `validate "memory_percentage": `,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.AdvancedCount.Validate()
+			err := tc.in.Validate()
 
 			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
-				return
-			}
-			if tc.wantedErrorMsgPrefix != "" {
-				require.Error(t, gotErr)
-				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
-				return
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
 			}
-			require.NoError(t, gotErr)
 		})
 	}
 }
@@ -1998,6 +3483,56 @@ func TestSidecarConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestInitContainerConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config InitContainerConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if image is not set": {
+			config:            InitContainerConfig{},
+			wantedErrorPrefix: `"image" must be specified`,
+		},
+		"error if fail to validate mount_points": {
+			config: InitContainerConfig{
+				Image: aws.String("migrate"),
+				MountPoints: []SidecarMountPoint{
+					{},
+				},
+			},
+			wantedErrorPrefix: `validate "mount_points[0]": `,
+		},
+		"error if fail to validate depends_on": {
+			config: InitContainerConfig{
+				Image: aws.String("migrate"),
+				DependsOn: DependsOn{
+					"foo": "bar",
+				},
+			},
+			wantedErrorPrefix: `validate "depends_on": `,
+		},
+		"success": {
+			config: InitContainerConfig{
+				Image: aws.String("migrate"),
+				DependsOn: DependsOn{
+					"fetch": "success",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
 func TestSidecarMountPoint_Validate(t *testing.T) {
 	testCases := map[string]struct {
 		in     SidecarMountPoint
@@ -2144,6 +3679,14 @@ func TestVpcConfig_Validate(t *testing.T) {
 			},
 			wantedErrorPrefix: `validate "placement": `,
 		},
+		"error if fail to validate ingress": {
+			config: vpcConfig{
+				Ingress: ServiceIngress{
+					Ports: []uint16{80},
+				},
+			},
+			wantedErrorPrefix: `validate "ingress": `,
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -2158,6 +3701,91 @@ func TestVpcConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestServiceIngress_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     ServiceIngress
+		wanted error
+	}{
+		"valid if empty": {},
+		"valid with services and ports": {
+			in: ServiceIngress{
+				From:  []string{"api", "frontend"},
+				Ports: []uint16{8080, 9090},
+			},
+		},
+		"error if ports are specified without services": {
+			in: ServiceIngress{
+				Ports: []uint16{8080},
+			},
+			wanted: errors.New(`"from" must be specified if "ports" is specified`),
+		},
+		"error if a service name is empty": {
+			in: ServiceIngress{
+				From: []string{"api", ""},
+			},
+			wanted: errors.New(`"from" must not contain empty service names`),
+		},
+		"error if a port is 0": {
+			in: ServiceIngress{
+				From:  []string{"api"},
+				Ports: []uint16{0},
+			},
+			wanted: errors.New(`"ports" must not contain port 0`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServiceConnectBoolOrArgs_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     ServiceConnectBoolOrArgs
+		wanted error
+	}{
+		"valid if empty": {},
+		"valid if enabled": {
+			in: ServiceConnectBoolOrArgs{
+				Enable: aws.Bool(true),
+			},
+		},
+		"valid with an alias": {
+			in: ServiceConnectBoolOrArgs{
+				Advanced: ServiceConnectArgs{
+					Alias: aws.String("api"),
+				},
+			},
+		},
+		"error if alias is empty": {
+			in: ServiceConnectBoolOrArgs{
+				Advanced: ServiceConnectArgs{
+					Alias: aws.String(""),
+				},
+			},
+			wanted: errors.New(`"alias" cannot be empty`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestPlacement_Validate(t *testing.T) {
 	mockEmptyPlacement := Placement("")
 	mockInvalidPlacement := Placement("external")
@@ -2644,6 +4272,88 @@ func TestValidateContainerDeps(t *testing.T) {
 			},
 			wanted: fmt.Errorf("circular container dependency chain includes the following containers: [alpha beta gamma]"),
 		},
+		"should return an error if an init container has the same name as a sidecar": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				sidecarConfig: map[string]*SidecarConfig{
+					"beta": {},
+				},
+				initContainerConfig: map[string]*InitContainerConfig{
+					"beta": {
+						Image: aws.String("migrate"),
+					},
+				},
+			},
+			wanted: fmt.Errorf("init container beta cannot have the same name as another container"),
+		},
+		"should return an error if an init container dependency does not exist": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				initContainerConfig: map[string]*InitContainerConfig{
+					"migrate": {
+						DependsOn: DependsOn{
+							"fetch": "success",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("container fetch does not exist"),
+		},
+		"should return an error if an init container depends on a container that waits for it": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				sidecarConfig: map[string]*SidecarConfig{
+					"beta": {},
+				},
+				initContainerConfig: map[string]*InitContainerConfig{
+					"migrate": {
+						DependsOn: DependsOn{
+							"beta": "start",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("circular container dependency chain includes the following containers: [beta migrate]"),
+		},
+		"should return an error if init container dependencies graph is cyclic": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				initContainerConfig: map[string]*InitContainerConfig{
+					"fetch": {
+						DependsOn: DependsOn{
+							"migrate": "success",
+						},
+					},
+					"migrate": {
+						DependsOn: DependsOn{
+							"fetch": "success",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("circular container dependency chain includes the following containers: [fetch migrate]"),
+		},
+		"success with init containers": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"beta": "start",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"beta": {},
+				},
+				initContainerConfig: map[string]*InitContainerConfig{
+					"fetch": {},
+					"migrate": {
+						DependsOn: DependsOn{
+							"fetch": "success",
+						},
+					},
+				},
+			},
+		},
 		"success": {
 			in: validateDependenciesOpts{
 				mainContainerName: "alpha",
@@ -2763,6 +4473,87 @@ func TestValidateARM(t *testing.T) {
 	}
 }
 
+func TestValidateEC2(t *testing.T) {
+	testCases := map[string]struct {
+		in          validateEC2Opts
+		wantedError error
+	}{
+		"should return an error if Spot specified inline": {
+			in: validateEC2Opts{
+				Spot: aws.Int(2),
+			},
+			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on EC2 instances; configure Spot Instances on the environment instead`),
+		},
+		"should return an error if Spot specified with spot_from": {
+			in: validateEC2Opts{
+				SpotFrom: aws.Int(2),
+			},
+			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on EC2 instances; configure Spot Instances on the environment instead`),
+		},
+		"should return an error if ephemeral storage is specified": {
+			in: validateEC2Opts{
+				EphemeralStorage: aws.Int(50),
+			},
+			wantedError: fmt.Errorf(`"storage.ephemeral" is not supported when deploying on EC2 instances`),
+		},
+		"should return an error if a network load balancer is used with the bridge network mode": {
+			in: validateEC2Opts{
+				NetworkMode: NetworkModeBridge,
+				HasNLB:      true,
+			},
+			wantedError: fmt.Errorf(`"nlb" is not supported when "platform.network_mode" is bridge`),
+		},
+		"should return an error if service ingress is configured with the bridge network mode": {
+			in: validateEC2Opts{
+				NetworkMode: NetworkModeBridge,
+				HasIngress:  true,
+			},
+			wantedError: fmt.Errorf(`"network.vpc.ingress" is not supported when "platform.network_mode" is bridge`),
+		},
+		"should return an error if sidecars are configured with the bridge network mode": {
+			in: validateEC2Opts{
+				NetworkMode: NetworkModeBridge,
+				HasSidecars: true,
+			},
+			wantedError: fmt.Errorf(`"sidecars" are not supported when "platform.network_mode" is bridge`),
+		},
+		"should return an error if service connect is enabled with the bridge network mode": {
+			in: validateEC2Opts{
+				NetworkMode:       NetworkModeBridge,
+				HasServiceConnect: true,
+			},
+			wantedError: fmt.Errorf(`"network.connect" is not supported when "platform.network_mode" is bridge`),
+		},
+		"should return nil if sidecars and service connect are configured with the awsvpc network mode": {
+			in: validateEC2Opts{
+				NetworkMode:       NetworkModeAWSVPC,
+				HasSidecars:       true,
+				HasServiceConnect: true,
+			},
+		},
+		"should return an error if a pre-deploy task is configured": {
+			in: validateEC2Opts{
+				HasPreDeploy: true,
+			},
+			wantedError: fmt.Errorf(`"deployment.pre_deploy" is not supported when deploying on EC2 instances`),
+		},
+		"should return nil if Spot not specified": {
+			in: validateEC2Opts{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateEC2(tc.in)
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestDeploymentConfiguration_Validate(t *testing.T) {
 	testCases := map[string]struct {
 		deployConfig DeploymentConfiguration
@@ -2787,6 +4578,41 @@ func TestDeploymentConfiguration_Validate(t *testing.T) {
 		"ok if deployment is empty": {
 			deployConfig: DeploymentConfiguration{},
 		},
+		"error if pre-deploy task has no command": {
+			deployConfig: DeploymentConfiguration{
+				PreDeploy: PreDeployTask{
+					Timeout: durationp(5 * time.Minute),
+				},
+			},
+			wanted: `validate "pre_deploy": "command" must be specified`,
+		},
+		"error if pre-deploy timeout is too short": {
+			deployConfig: DeploymentConfiguration{
+				PreDeploy: PreDeployTask{
+					Command: CommandOverride{String: aws.String("./migrate")},
+					Timeout: durationp(30 * time.Second),
+				},
+			},
+			wanted: `validate "pre_deploy": "timeout" must be between 1m0s and 14m0s`,
+		},
+		"error if pre-deploy timeout is too long": {
+			deployConfig: DeploymentConfiguration{
+				PreDeploy: PreDeployTask{
+					Command: CommandOverride{StringSlice: []string{"./migrate", "up"}},
+					Timeout: durationp(15 * time.Minute),
+				},
+			},
+			wanted: `validate "pre_deploy": "timeout" must be between 1m0s and 14m0s`,
+		},
+		"ok if pre-deploy task is configured with a rolling strategy": {
+			deployConfig: DeploymentConfiguration{
+				Rolling: aws.String("recreate"),
+				PreDeploy: PreDeployTask{
+					Command: CommandOverride{String: aws.String("./migrate")},
+					Timeout: durationp(10 * time.Minute),
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
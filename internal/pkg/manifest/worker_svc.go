@@ -36,19 +36,25 @@ func (s *WorkerService) Publish() []Topic {
 	return s.WorkerServiceConfig.PublishConfig.Topics
 }
 
+// IngressSources returns the names of the services that are allowed to send traffic to the service.
+func (s *WorkerService) IngressSources() []string {
+	return s.Network.VPC.Ingress.From
+}
+
 // WorkerServiceConfig holds the configuration that can be overridden per environments.
 type WorkerServiceConfig struct {
 	ImageConfig      ImageWithHealthcheck `yaml:"image,flow"`
 	ImageOverride    `yaml:",inline"`
 	TaskConfig       `yaml:",inline"`
-	Logging          Logging                   `yaml:"logging,flow"`
-	Sidecars         map[string]*SidecarConfig `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
-	Subscribe        SubscribeConfig           `yaml:"subscribe"`
-	PublishConfig    PublishConfig             `yaml:"publish"`
-	Network          NetworkConfig             `yaml:"network"`
-	TaskDefOverrides []OverrideRule            `yaml:"taskdef_overrides"`
-	DeployConfig     DeploymentConfiguration   `yaml:"deployment"`
-	Observability    Observability             `yaml:"observability"`
+	Logging          Logging                         `yaml:"logging,flow"`
+	Sidecars         map[string]*SidecarConfig       `yaml:"sidecars"`        // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	InitContainers   map[string]*InitContainerConfig `yaml:"init_containers"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Subscribe        SubscribeConfig                 `yaml:"subscribe"`
+	PublishConfig    PublishConfig                   `yaml:"publish"`
+	Network          NetworkConfig                   `yaml:"network"`
+	TaskDefOverrides []OverrideRule                  `yaml:"taskdef_overrides"`
+	DeployConfig     DeploymentConfiguration         `yaml:"deployment"`
+	Observability    Observability                   `yaml:"observability"`
 }
 
 // SubscribeConfig represents the configurable options for setting up subscriptions.
@@ -44,6 +44,8 @@ var (
 	errUnmarshalEntryPoint = errors.New(`unable to unmarshal "entrypoint" into string or slice of strings`)
 	errUnmarshalAlias      = errors.New(`unable to unmarshal "alias" into string or slice of strings`)
 	errUnmarshalCommand    = errors.New(`unable to unmarshal "command" into string or slice of strings`)
+
+	errUnmarshalServiceConnect = errors.New(`unable to unmarshal "connect" field into boolean or service connect configuration`)
 )
 
 // WorkloadTypes returns the list of all manifest types.
@@ -79,6 +81,8 @@ func UnmarshalWorkload(in []byte) (WorkloadManifest, error) {
 		m = newDefaultBackendService()
 	case WorkerServiceType:
 		m = newDefaultWorkerService()
+	case StaticSiteType:
+		m = newDefaultStaticSite()
 	case ScheduledJobType:
 		m = newDefaultScheduledJob()
 	default:
@@ -103,6 +107,51 @@ type Workload struct {
 	Type *string `yaml:"type"` // must be one of the supported manifest types.
 }
 
+// EnvAddonsImports holds the outputs of the environment addons stack that the workload imports.
+type EnvAddonsImports struct {
+	Variables      []string `yaml:"variables"`       // Outputs injected as environment variables.
+	Secrets        []string `yaml:"secrets"`         // Outputs injected as secrets.
+	Policies       []string `yaml:"policies"`        // Outputs attached as IAM managed policies to the task role.
+	SecurityGroups []string `yaml:"security_groups"` // Outputs attached as security groups to the tasks.
+}
+
+// IsEmpty returns true if the workload doesn't import any environment addons outputs.
+func (e *EnvAddonsImports) IsEmpty() bool {
+	return len(e.Variables) == 0 && len(e.Secrets) == 0 && len(e.Policies) == 0 && len(e.SecurityGroups) == 0
+}
+
+// IAMPermission holds an IAM policy statement that is added to the role of the workload's containers.
+type IAMPermission struct {
+	Effect     *string                                  `yaml:"effect"` // Defaults to "Allow".
+	Actions    []string                                 `yaml:"actions"`
+	Resources  []string                                 `yaml:"resources"`
+	Conditions map[string]map[string]IAMConditionValues `yaml:"conditions"` // Condition operator to condition keys and their values.
+}
+
+// IAMConditionValues holds the values that an IAM condition key is compared to.
+// It can be unmarshaled from a single string or a list of strings.
+type IAMConditionValues []string
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the IAMConditionValues
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (v *IAMConditionValues) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var s string
+		if err := value.Decode(&s); err != nil {
+			return err
+		}
+		*v = IAMConditionValues{s}
+		return nil
+	}
+	var values []string
+	if err := value.Decode(&values); err != nil {
+		return err
+	}
+	*v = values
+	return nil
+}
+
 // Image represents the workload's container image.
 type Image struct {
 	Build        BuildArgsOrString `yaml:"build"`           // Build an image from a Dockerfile.
@@ -372,12 +421,68 @@ type Topic struct {
 
 // NetworkConfig represents options for network connection to AWS resources within a VPC.
 type NetworkConfig struct {
-	VPC vpcConfig `yaml:"vpc"`
+	VPC     vpcConfig                `yaml:"vpc"`
+	Connect ServiceConnectBoolOrArgs `yaml:"connect"`
 }
 
 // IsEmpty returns empty if the struct has all zero members.
 func (c *NetworkConfig) IsEmpty() bool {
-	return c.VPC.isEmpty()
+	return c.VPC.isEmpty() && c.Connect.IsEmpty()
+}
+
+// ServiceConnectBoolOrArgs represents ECS Service Connect configuration.
+// It can be specified as a boolean or as a map with advanced configuration.
+type ServiceConnectBoolOrArgs struct {
+	Enable   *bool
+	Advanced ServiceConnectArgs
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (s *ServiceConnectBoolOrArgs) IsEmpty() bool {
+	return s.Enable == nil && s.Advanced.IsEmpty()
+}
+
+// Enabled returns true if the service should join the environment's Service Connect namespace.
+func (s *ServiceConnectBoolOrArgs) Enabled() bool {
+	if s.Enable != nil {
+		return aws.BoolValue(s.Enable)
+	}
+	return !s.Advanced.IsEmpty()
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the ServiceConnectBoolOrArgs
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (s *ServiceConnectBoolOrArgs) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&s.Advanced); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+
+	if !s.Advanced.IsEmpty() {
+		// Unmarshaled successfully to s.Advanced, unset s.Enable, and return.
+		s.Enable = nil
+		return nil
+	}
+
+	if err := value.Decode(&s.Enable); err != nil {
+		return errUnmarshalServiceConnect
+	}
+	return nil
+}
+
+// ServiceConnectArgs includes the advanced configuration for ECS Service Connect.
+type ServiceConnectArgs struct {
+	Alias *string `yaml:"alias"` // DNS name that other services use to connect to this service.
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (s *ServiceConnectArgs) IsEmpty() bool {
+	return s.Alias == nil
 }
 
 // Placement represents where to place tasks (public or private subnets).
@@ -386,11 +491,23 @@ type Placement string
 // vpcConfig represents the security groups and subnets attached to a task.
 type vpcConfig struct {
 	*Placement     `yaml:"placement"`
-	SecurityGroups []string `yaml:"security_groups"`
+	SecurityGroups []string       `yaml:"security_groups"`
+	Ingress        ServiceIngress `yaml:"ingress"`
 }
 
 func (c *vpcConfig) isEmpty() bool {
-	return c.Placement == nil && c.SecurityGroups == nil
+	return c.Placement == nil && c.SecurityGroups == nil && c.Ingress.IsEmpty()
+}
+
+// ServiceIngress represents the services in the environment that are allowed to send traffic to a service's tasks.
+type ServiceIngress struct {
+	From  []string `yaml:"from"`  // Names of the services allowed to send traffic.
+	Ports []uint16 `yaml:"ports"` // Defaults to the port of the main container.
+}
+
+// IsEmpty returns true if the service accepts traffic from any service in the environment.
+func (i *ServiceIngress) IsEmpty() bool {
+	return len(i.From) == 0 && len(i.Ports) == 0
 }
 
 // PlatformArgsOrString is a custom type which supports unmarshaling yaml which
@@ -441,10 +558,37 @@ func (p *PlatformArgsOrString) Arch() string {
 	return strings.ToLower(aws.StringValue(p.PlatformArgs.Arch))
 }
 
-// PlatformArgs represents the specifics of a target OS.
+// LaunchType returns the lowercase launch type of the tasks, defaults to "fargate".
+func (p *PlatformArgsOrString) LaunchType() string {
+	if p.PlatformArgs.LaunchType == nil {
+		return LaunchTypeFargate
+	}
+	return strings.ToLower(aws.StringValue(p.PlatformArgs.LaunchType))
+}
+
+// NetworkMode returns the lowercase network mode of the tasks, defaults to "awsvpc".
+func (p *PlatformArgsOrString) NetworkMode() string {
+	if p.PlatformArgs.NetworkMode == nil {
+		return NetworkModeAWSVPC
+	}
+	return strings.ToLower(aws.StringValue(p.PlatformArgs.NetworkMode))
+}
+
+// PlacementStrategy returns the lowercase strategy to place tasks on EC2 instances, defaults to "spread".
+func (p *PlatformArgsOrString) PlacementStrategy() string {
+	if p.PlatformArgs.PlacementStrategy == nil {
+		return PlacementStrategySpread
+	}
+	return strings.ToLower(aws.StringValue(p.PlatformArgs.PlacementStrategy))
+}
+
+// PlatformArgs represents the specifics of a target OS and where the tasks run.
 type PlatformArgs struct {
-	OSFamily *string `yaml:"osfamily,omitempty"`
-	Arch     *string `yaml:"architecture,omitempty"`
+	OSFamily          *string `yaml:"osfamily,omitempty"`
+	Arch              *string `yaml:"architecture,omitempty"`
+	LaunchType        *string `yaml:"launch_type,omitempty"`
+	NetworkMode       *string `yaml:"network_mode,omitempty"`
+	PlacementStrategy *string `yaml:"placement_strategy,omitempty"`
 }
 
 // PlatformString represents the string format of Platform.
@@ -455,12 +599,16 @@ func (p *PlatformArgs) String() string {
 	return fmt.Sprintf("('%s', '%s')", aws.StringValue(p.OSFamily), aws.StringValue(p.Arch))
 }
 
-// IsEmpty returns if the platform field is empty.
+// IsEmpty returns if the operating system and architecture of the platform field are empty.
 func (p *PlatformArgsOrString) IsEmpty() bool {
-	return p.PlatformString == nil && p.PlatformArgs.isEmpty()
+	return p.PlatformString == nil && p.PlatformArgs.isOSArchEmpty()
 }
 
 func (p *PlatformArgs) isEmpty() bool {
+	return p.isOSArchEmpty() && p.LaunchType == nil && p.NetworkMode == nil && p.PlacementStrategy == nil
+}
+
+func (p *PlatformArgs) isOSArchEmpty() bool {
 	return p.OSFamily == nil && p.Arch == nil
 }
 
@@ -38,6 +38,14 @@ const (
 	// deployment strategies
 	ECSDefaultRollingUpdateStrategy  = "default"
 	ECSRecreateRollingUpdateStrategy = "recreate"
+
+	// Launch types, network modes and placement strategies of ECS tasks.
+	LaunchTypeFargate        = "fargate"
+	LaunchTypeEC2            = "ec2"
+	NetworkModeAWSVPC        = "awsvpc"
+	NetworkModeBridge        = "bridge"
+	PlacementStrategySpread  = "spread"
+	PlacementStrategyBinpack = "binpack"
 )
 
 // Platform related settings.
@@ -64,6 +72,9 @@ var (
 		{OSFamily: aws.String(OSWindowsServer2019Full), Arch: aws.String(ArchX86)},
 		{OSFamily: aws.String(OSWindowsServer2019Full), Arch: aws.String(ArchAMD64)},
 	}
+	validLaunchTypes         = []string{LaunchTypeFargate, LaunchTypeEC2}
+	validNetworkModes        = []string{NetworkModeAWSVPC, NetworkModeBridge}
+	validPlacementStrategies = []string{PlacementStrategySpread, PlacementStrategyBinpack}
 )
 
 // ImageWithHealthcheck represents a container image with health check.
@@ -80,11 +91,23 @@ type ImageWithPortAndHealthcheck struct {
 
 // DeploymentConfiguration represents the deployment strategies for a service.
 type DeploymentConfiguration struct {
-	Rolling *string `yaml:"rolling"`
+	Rolling   *string       `yaml:"rolling"`
+	PreDeploy PreDeployTask `yaml:"pre_deploy"`
 }
 
 func (d *DeploymentConfiguration) isEmpty() bool {
-	return d == nil || d.Rolling == nil
+	return d == nil || (d.Rolling == nil && d.PreDeploy.IsEmpty())
+}
+
+// PreDeployTask represents a one-off task that must exit successfully before the service is updated.
+type PreDeployTask struct {
+	Command CommandOverride `yaml:"command"`
+	Timeout *time.Duration  `yaml:"timeout"`
+}
+
+// IsEmpty returns true if the pre-deploy task is not configured.
+func (p *PreDeployTask) IsEmpty() bool {
+	return p.Command.String == nil && p.Command.StringSlice == nil && p.Timeout == nil
 }
 
 // ImageWithHealthcheckAndOptionalPort represents a container image with an optional exposed port and health check.
@@ -110,6 +133,8 @@ type TaskConfig struct {
 	EnvFile        *string              `yaml:"env_file"`
 	Secrets        map[string]Secret    `yaml:"secrets"`
 	Storage        Storage              `yaml:"storage"`
+	EnvAddons      EnvAddonsImports     `yaml:"env_addons"`
+	Permissions    []IAMPermission      `yaml:"permissions"`
 }
 
 // ContainerPlatform returns the platform for the service.
@@ -133,6 +158,11 @@ func (t TaskConfig) IsARM() bool {
 	return IsArmArch(t.Platform.Arch())
 }
 
+// IsEC2 returns whether or not the tasks run on the EC2 instances of the environment instead of Fargate.
+func (t TaskConfig) IsEC2() bool {
+	return t.Platform.LaunchType() == LaunchTypeEC2
+}
+
 // Secret represents an identifier for sensitive data stored in either SSM or SecretsManager.
 type Secret struct {
 	from               *string              // SSM Parameter name or ARN to a secret.
@@ -231,6 +261,18 @@ type SidecarConfig struct {
 	ImageOverride `yaml:",inline"`
 }
 
+// InitContainerConfig represents the configurable options for setting up an init container.
+// Init containers run to completion before the main container and sidecars start.
+type InitContainerConfig struct {
+	Image         *string             `yaml:"image"`
+	CredsParam    *string             `yaml:"credentialsParameter"`
+	Variables     map[string]string   `yaml:"variables"`
+	Secrets       map[string]Secret   `yaml:"secrets"`
+	MountPoints   []SidecarMountPoint `yaml:"mount_points"`
+	DependsOn     DependsOn           `yaml:"depends_on"`
+	ImageOverride `yaml:",inline"`
+}
+
 // OverrideRule holds the manifest overriding rule for CloudFormation template.
 type OverrideRule struct {
 	Path  string    `yaml:"path"`
@@ -317,6 +317,58 @@ func TestBuildArgs_UnmarshalYAML(t *testing.T) {
 	}
 }
 
+func TestIAMPermission_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct IAMPermission
+	}{
+		"condition values as a string": {
+			inContent: []byte(`actions: ["s3:GetObject"]
+resources: ["arn:aws:s3:::my-bucket/*"]
+conditions:
+  StringEquals:
+    aws:PrincipalTag/team: payments`),
+			wantedStruct: IAMPermission{
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"arn:aws:s3:::my-bucket/*"},
+				Conditions: map[string]map[string]IAMConditionValues{
+					"StringEquals": {
+						"aws:PrincipalTag/team": {"payments"},
+					},
+				},
+			},
+		},
+		"condition values as a list": {
+			inContent: []byte(`effect: Deny
+actions: ["s3:DeleteObject"]
+resources: ["*"]
+conditions:
+  StringNotEquals:
+    aws:PrincipalTag/team: [payments, billing]`),
+			wantedStruct: IAMPermission{
+				Effect:    aws.String("Deny"),
+				Actions:   []string{"s3:DeleteObject"},
+				Resources: []string{"*"},
+				Conditions: map[string]map[string]IAMConditionValues{
+					"StringNotEquals": {
+						"aws:PrincipalTag/team": {"payments", "billing"},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var p IAMPermission
+			err := yaml.Unmarshal(tc.inContent, &p)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, p)
+		})
+	}
+}
+
 func TestPlatformArgsOrString_UnmarshalYAML(t *testing.T) {
 	testCases := map[string]struct {
 		inContent []byte
@@ -337,6 +389,19 @@ func TestPlatformArgsOrString_UnmarshalYAML(t *testing.T) {
   archie: leg64`),
 			wantedError: errUnmarshalPlatformOpts,
 		},
+		"unmarshals the launch type without osfamily and architecture": {
+			inContent: []byte(`platform:
+  launch_type: ec2
+  network_mode: bridge
+  placement_strategy: binpack`),
+			wantedStruct: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					LaunchType:        aws.String("ec2"),
+					NetworkMode:       aws.String("bridge"),
+					PlacementStrategy: aws.String("binpack"),
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -346,9 +411,7 @@ func TestPlatformArgsOrString_UnmarshalYAML(t *testing.T) {
 				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.wantedStruct.PlatformString, p.Platform.PlatformString)
-				require.Equal(t, tc.wantedStruct.PlatformArgs.OSFamily, p.Platform.PlatformArgs.OSFamily)
-				require.Equal(t, tc.wantedStruct.PlatformArgs.Arch, p.Platform.PlatformArgs.Arch)
+				require.Equal(t, tc.wantedStruct, p.Platform)
 			}
 		})
 	}
@@ -602,6 +665,13 @@ func TestNetworkConfig_IsEmpty(t *testing.T) {
 				},
 			},
 		},
+		"non empty service connect config": {
+			in: NetworkConfig{
+				Connect: ServiceConnectBoolOrArgs{
+					Enable: aws.Bool(true),
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -646,6 +716,38 @@ network:
 				},
 			},
 		},
+		"unmarshals service connect as a boolean": {
+			data: `
+network:
+  connect: true
+`,
+			wantedConfig: &NetworkConfig{
+				Connect: ServiceConnectBoolOrArgs{
+					Enable: aws.Bool(true),
+				},
+			},
+		},
+		"unmarshals service connect with an alias": {
+			data: `
+network:
+  connect:
+    alias: api
+`,
+			wantedConfig: &NetworkConfig{
+				Connect: ServiceConnectBoolOrArgs{
+					Advanced: ServiceConnectArgs{
+						Alias: aws.String("api"),
+					},
+				},
+			},
+		},
+		"error if service connect is neither a boolean nor a map": {
+			data: `
+network:
+  connect: [api]
+`,
+			wantedErr: errUnmarshalServiceConnect,
+		},
 	}
 
 	for name, tc := range testCases {
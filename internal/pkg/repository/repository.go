@@ -21,6 +21,7 @@ type ContainerLoginBuildPusher interface {
 // Registry gets information of repositories.
 type Registry interface {
 	RepositoryURI(name string) (string, error)
+	ImageDigest(repoName, tag string) (string, error)
 	Auth() (string, string, error)
 }
 
@@ -80,6 +81,15 @@ func (r *Repository) BuildAndPush(docker ContainerLoginBuildPusher, args *docker
 	return digest, nil
 }
 
+// Digest returns the digest of the image in the repository with the given tag.
+func (r *Repository) Digest(tag string) (string, error) {
+	digest, err := r.registry.ImageDigest(r.name, tag)
+	if err != nil {
+		return "", fmt.Errorf("get digest of image %s:%s: %w", r.name, tag, err)
+	}
+	return digest, nil
+}
+
 // URI returns the uri of the repository.
 func (r *Repository) URI() (string, error) {
 	if r.uri != "" {
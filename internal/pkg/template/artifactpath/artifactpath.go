@@ -11,10 +11,13 @@ import (
 )
 
 const (
-	s3ArtifactDirName         = "manual"
-	s3TemplateDirName         = "templates"
-	s3ArtifactAddonsDirName   = "addons"
-	s3ArtifactEnvFilesDirName = "env-files"
+	s3ArtifactDirName           = "manual"
+	s3TemplateDirName           = "templates"
+	s3ArtifactAddonsDirName     = "addons"
+	s3ArtifactEnvFilesDirName   = "env-files"
+	s3StaticSiteDirName         = "static-site"
+	s3StaticSiteAssetsDirName   = "assets"
+	s3StaticSiteMappingsDirName = "mappings"
 )
 
 // MkdirSHA prefixes the key with the SHA256 hash of the contents of "manual/<hash>/key".
@@ -39,3 +42,15 @@ func CFNTemplate(key string, content []byte) string {
 func EnvFiles(key string, content []byte) string {
 	return path.Join(s3ArtifactDirName, s3ArtifactEnvFilesDirName, key, fmt.Sprintf("%x.env", sha256.Sum256(content)))
 }
+
+// StaticSiteAsset returns the path to store a static site asset with sha256 of the content.
+// Example: manual/static-site/key/assets/sha.
+func StaticSiteAsset(key string, content []byte) string {
+	return path.Join(s3ArtifactDirName, s3StaticSiteDirName, key, s3StaticSiteAssetsDirName, fmt.Sprintf("%x", sha256.Sum256(content)))
+}
+
+// StaticSiteAssetMapping returns the path to store the file that maps static site assets to their destination with sha256 of the content.
+// Example: manual/static-site/key/mappings/sha.json.
+func StaticSiteAssetMapping(key string, content []byte) string {
+	return path.Join(s3ArtifactDirName, s3StaticSiteDirName, key, s3StaticSiteMappingsDirName, fmt.Sprintf("%x.json", sha256.Sum256(content)))
+}
@@ -23,6 +23,7 @@ var (
 		"lambdas",
 		"vpc-resources",
 		"nat-gateways",
+		"ec2-capacity",
 	}
 )
 
@@ -39,13 +40,24 @@ type EnvOpts struct {
 	ArtifactBucketARN         string
 	ArtifactBucketKeyARN      string
 
-	VPCConfig      VPCConfig
-	ImportCertARNs []string
-	Telemetry      *Telemetry
+	VPCConfig        VPCConfig
+	ImportCertARNs   []string
+	InternalCertARNs []string
+	WAF              *WAFOpts
+	EC2Capacity      *EC2CapacityOpts
+	Telemetry        *Telemetry
+	Addons           *EnvAddonsOpts
 
 	LatestVersion string
 }
 
+// EnvAddonsOpts holds configuration for the addons nested stack shared by all workloads in an environment.
+type EnvAddonsOpts struct {
+	URL         string   // S3 object URL of the merged addons template.
+	ExtraParams string   // Additional user defined Parameters for the addons stack.
+	Outputs     []string // Outputs of the addons stack to export from the environment stack.
+}
+
 type VPCConfig struct {
 	Imported *ImportVPC // If not-nil, use the imported VPC resources instead of the Managed VPC.
 	Managed  ManagedVPC
@@ -66,6 +78,27 @@ type ManagedVPC struct {
 	PrivateSubnetCIDRs []string
 }
 
+// WAFOpts holds configuration for the AWS WAF web ACL associated with a public load balancer.
+type WAFOpts struct {
+	WebACLARN    string // ARN of an existing web ACL. If empty, a web ACL is created from the rules below.
+	ManagedRules []string
+	RateLimit    int
+}
+
+// EC2CapacityOpts holds configuration for the Auto Scaling group capacity provider of an environment's cluster.
+type EC2CapacityOpts struct {
+	InstanceTypes  []string
+	Arch           string // Either "x86_64" or "arm64", selects the ECS-optimized AMI of the instances.
+	MinSize        int
+	MaxSize        int
+	SpotPercentage int
+}
+
+// OnDemandPercentage returns the percentage of instances launched as On-Demand Instances.
+func (o EC2CapacityOpts) OnDemandPercentage() int {
+	return 100 - o.SpotPercentage
+}
+
 // Telemetry represents optional observability and monitoring configuration.
 type Telemetry struct {
 	EnableContainerInsights bool
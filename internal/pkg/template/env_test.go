@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestTemplate_ParseEnv(t *testing.T) {
@@ -22,6 +23,7 @@ func TestTemplate_ParseEnv(t *testing.T) {
 				"templates/environment/partials/lambdas.yml":                  []byte("lambdas"),
 				"templates/environment/partials/vpc-resources.yml":            []byte("vpc-resources"),
 				"templates/environment/partials/nat-gateways.yml":             []byte("nat-gateways"),
+				"templates/environment/partials/ec2-capacity.yml":             []byte("ec2-capacity"),
 			},
 		},
 	}
@@ -33,3 +35,88 @@ func TestTemplate_ParseEnv(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "test", c.String())
 }
+
+func TestTemplate_ParseEnv_ManagerRolePermissions(t *testing.T) {
+	type statement struct {
+		Sid       string      `yaml:"Sid"`
+		Action    []string    `yaml:"Action"`
+		Condition interface{} `yaml:"Condition"`
+	}
+	type cfn struct {
+		Resources struct {
+			EnvironmentManagerRole struct {
+				Properties struct {
+					Policies []struct {
+						PolicyDocument struct {
+							Statement []statement `yaml:"Statement"`
+						} `yaml:"PolicyDocument"`
+					} `yaml:"Policies"`
+				} `yaml:"Properties"`
+			} `yaml:"EnvironmentManagerRole"`
+		} `yaml:"Resources"`
+	}
+	testCases := map[string]struct {
+		wantedActions         []string
+		wantedTagRestrictions bool
+	}{
+		"BackupStorage": {
+			wantedActions:         []string{"dynamodb:CreateBackup", "rds:CreateDBClusterSnapshot"},
+			wantedTagRestrictions: true,
+		},
+		"CreateClusterSnapshots": {
+			wantedActions: []string{"rds:CreateDBClusterSnapshot"},
+		},
+		"CopyBuckets": {
+			wantedActions: []string{"s3:CreateBucket", "s3:PutObject"},
+		},
+		"DescribeSecrets": {
+			wantedActions: []string{"ssm:DescribeParameters"},
+		},
+		"ListSecretTags": {
+			wantedActions: []string{"ssm:ListTagsForResource"},
+		},
+		"CreateSecretsManagerSecret": {
+			wantedActions:         []string{"secretsmanager:CreateSecret", "secretsmanager:TagResource"},
+			wantedTagRestrictions: true,
+		},
+		"UpdateSecretsManagerSecret": {
+			wantedActions:         []string{"secretsmanager:PutSecretValue", "secretsmanager:TagResource", "secretsmanager:RotateSecret"},
+			wantedTagRestrictions: true,
+		},
+		"InvokeSecretRotationFunction": {
+			wantedActions:         []string{"lambda:InvokeFunction"},
+			wantedTagRestrictions: true,
+		},
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseEnv(&EnvOpts{
+		AppName:              "phonetool",
+		ArtifactBucketARN:    "arn:aws:s3:::stackset-bucket",
+		ArtifactBucketKeyARN: "arn:aws:kms:us-west-2:123456789012:key/1234",
+	}, WithFuncs(map[string]interface{}{
+		"inc": IncFunc,
+	}))
+	require.NoError(t, err)
+	var actual cfn
+	require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual))
+	statements := make(map[string]statement)
+	for _, policy := range actual.Resources.EnvironmentManagerRole.Properties.Policies {
+		for _, s := range policy.PolicyDocument.Statement {
+			statements[s.Sid] = s
+		}
+	}
+
+	// THEN
+	for sid, tc := range testCases {
+		t.Run(sid, func(t *testing.T) {
+			s, ok := statements[sid]
+			require.True(t, ok, "statement %s must exist", sid)
+			require.ElementsMatch(t, tc.wantedActions, s.Action)
+			require.Equal(t, tc.wantedTagRestrictions, s.Condition != nil)
+		})
+	}
+}
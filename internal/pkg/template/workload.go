@@ -5,7 +5,10 @@ package template
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
@@ -33,6 +36,7 @@ const (
 	backendSvcTplName   = "backend"
 	workerSvcTplName    = "worker"
 	scheduledJobTplName = "scheduled-job"
+	staticSiteTplName   = "static-site"
 )
 
 // Constants for workload options.
@@ -50,6 +54,12 @@ const (
 
 	ArchX86   = "X86_64"
 	ArchARM64 = "ARM64"
+
+	// EC2 launch type configuration.
+	NetworkModeAWSVPC        = "awsvpc"
+	NetworkModeBridge        = "bridge"
+	PlacementStrategySpread  = "spread"
+	PlacementStrategyBinpack = "binpack"
 )
 
 // Constants for ARN options.
@@ -72,6 +82,8 @@ var (
 		"servicediscovery",
 		"addons",
 		"sidecars",
+		"init-containers",
+		"pre-deploy",
 		"logconfig",
 		"autoscaling",
 		"eventrule",
@@ -80,6 +92,10 @@ var (
 		"efs-access-point",
 		"https-listener",
 		"http-listener",
+		"alb-listener-rule",
+		"web-acl",
+		"cdn",
+		"cdn-aliases",
 		"env-controller",
 		"mount-points",
 		"volumes",
@@ -90,6 +106,8 @@ var (
 		"subscribe",
 		"nlb",
 		"vpc-connector",
+		"service-security-group",
+		"internal-listener",
 	}
 
 	// Operating systems to determine Fargate platform versions.
@@ -108,6 +126,22 @@ type WorkloadNestedStackOpts struct {
 	SecurityGroupOutputs []string
 }
 
+// EnvAddonsImportsOpts holds the outputs of the environment addons stack imported by the workload.
+type EnvAddonsImportsOpts struct {
+	VariableOutputs      []string
+	SecretOutputs        []string
+	PolicyOutputs        []string
+	SecurityGroupOutputs []string
+}
+
+// PermissionOpts holds an IAM policy statement from the manifest that is added to the role of the workload's containers.
+type PermissionOpts struct {
+	Effect     string
+	Actions    []string
+	Resources  []string
+	Conditions map[string]map[string][]string // Condition operator to condition keys and their values.
+}
+
 // SidecarOpts holds configuration that's needed if the service has sidecar containers.
 type SidecarOpts struct {
 	Name         *string
@@ -126,6 +160,19 @@ type SidecarOpts struct {
 	HealthCheck  *ContainerHealthCheck
 }
 
+// InitContainerOpts holds configuration for an init container that runs to completion before the other containers start.
+type InitContainerOpts struct {
+	Name       *string
+	Image      *string
+	CredsParam *string
+	Variables  map[string]string
+	Secrets    map[string]Secret
+	Storage    SidecarStorageOpts
+	DependsOn  map[string]string
+	EntryPoint []string
+	Command    []string
+}
+
 // SidecarStorageOpts holds data structures for rendering Mount Points inside of a sidecar.
 type SidecarStorageOpts struct {
 	MountPoints []*MountPoint
@@ -209,6 +256,68 @@ type HTTPHealthCheckOpts struct {
 	GracePeriod         *int64
 }
 
+// ALBListenerRule holds configuration for an additional listener rule of an application load balancer.
+type ALBListenerRule struct {
+	Priority         int
+	Paths            []string
+	Hosts            []string
+	Headers          []ALBHeaderCondition
+	QueryStrings     []ALBQueryStringCondition
+	AllowedSourceIps []string
+
+	// Only one of the actions below is set. Targets is set when the rule forwards requests.
+	Targets       []ALBWeightedTarget
+	Redirect      *ALBRedirectAction
+	FixedResponse *ALBFixedResponseAction
+}
+
+// ALBHeaderCondition holds the values matched against an HTTP header.
+type ALBHeaderCondition struct {
+	Name   string
+	Values []string
+}
+
+// ALBQueryStringCondition holds a key/value pair matched against the query string.
+type ALBQueryStringCondition struct {
+	Key   string
+	Value string
+}
+
+// ALBWeightedTarget holds the logical ID of a target group and the weight of traffic forwarded to it.
+type ALBWeightedTarget struct {
+	TargetGroup string
+	Weight      int
+}
+
+// ALBRedirectAction holds configuration to redirect requests.
+// Empty fields keep the corresponding component of the original request.
+type ALBRedirectAction struct {
+	Protocol   string
+	Host       string
+	Port       string
+	Path       string
+	Query      string
+	StatusCode string
+}
+
+// ALBFixedResponseAction holds configuration to return a custom response.
+type ALBFixedResponseAction struct {
+	StatusCode  string
+	ContentType string
+	MessageBody string
+}
+
+// ALBTargetGroup holds configuration for an additional target group that routes traffic to a container.
+type ALBTargetGroup struct {
+	Container string
+	Port      string
+}
+
+// LogicalID returns the CloudFormation logical ID of the target group.
+func (tg ALBTargetGroup) LogicalID() string {
+	return "TargetGroup" + StripNonAlphaNumFunc(tg.Container)
+}
+
 // A Secret represents an SSM or SecretsManager secret that can be rendered in CloudFormation.
 type Secret interface {
 	RequiresSub() bool
@@ -248,7 +357,11 @@ func (s secretsManagerName) RequiresSub() bool {
 }
 
 // ValueFrom returns the resource ID of the SecretsManager secret for populating the ARN.
+// The "name:jsonKey" shorthand is expanded to the "name:jsonKey:versionStage:versionId" format of ECS.
 func (s secretsManagerName) ValueFrom() string {
+	if strings.Count(s.value, ":") == 1 {
+		return fmt.Sprintf("secret:%s::", s.value)
+	}
 	return fmt.Sprintf("secret:%s", s.value)
 }
 
@@ -274,11 +387,33 @@ type NetworkLoadBalancerListener struct {
 	TargetContainer string
 	TargetPort      string
 
-	SSLPolicy *string // The SSL policy applied when using TLS protocol.
+	SSLPolicy  *string // The SSL policy applied when using TLS protocol.
+	ALPNPolicy *string // The ALPN policy applied when using TLS protocol.
+
+	Stickiness       *bool
+	PreserveClientIP *bool
+	HealthCheck      NLBHealthCheck
+}
 
-	Aliases     []string
-	Stickiness  *bool
-	HealthCheck NLBHealthCheck
+// TargetGroupProtocol returns the protocol of the target group that receives the traffic of the listener.
+func (l NetworkLoadBalancerListener) TargetGroupProtocol() string {
+	if l.Protocol == "TLS" {
+		// TLS is terminated at the load balancer.
+		return "TCP"
+	}
+	return l.Protocol
+}
+
+// TargetIPProtocols returns the IP protocols of the traffic that the listener routes to its target.
+func (l NetworkLoadBalancerListener) TargetIPProtocols() []string {
+	switch l.Protocol {
+	case "UDP":
+		return []string{"udp"}
+	case "TCP_UDP":
+		return []string{"tcp", "udp"}
+	default:
+		return []string{"tcp"}
+	}
 }
 
 // NLBHealthCheck holds configuration for Network Load Balancer health check.
@@ -290,11 +425,69 @@ type NLBHealthCheck struct {
 	Interval           *int64
 }
 
+// NLBPortMapping holds a port and IP protocol that a container exposes to a Network Load Balancer.
+type NLBPortMapping struct {
+	Port     string
+	Protocol string // Either "tcp" or "udp".
+}
+
+// CDNOpts holds configuration for a CloudFront distribution in front of the public load balancer.
+type CDNOpts struct {
+	CachePolicyID string
+	OriginShield  bool
+	Aliases       []string
+	PrefixListID  string // ID of the CloudFront managed prefix list that is allowed to reach the load balancer.
+}
+
+// CertificateAliases returns the aliases of the distribution in the format expected by the certificate validator.
+func (c CDNOpts) CertificateAliases() (string, error) {
+	out, err := json.Marshal(map[string][]string{
+		"cdn": c.Aliases,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// StaticSiteOpts holds configuration for a static site served from an S3 bucket by a CloudFront distribution.
+type StaticSiteOpts struct {
+	IndexDocument string
+	ErrorDocument string
+}
+
 // NetworkLoadBalancer holds configuration that's needed for a Network Load Balancer.
 type NetworkLoadBalancer struct {
-	PublicSubnetCIDRs []string
-	Listener          NetworkLoadBalancerListener
-	MainContainerPort string
+	PublicSubnetCIDRs   []string
+	Listener            []NetworkLoadBalancerListener // The first listener is the main listener of the service.
+	MainContainerPort   string
+	PortMappings        []NLBPortMapping // Ports, other than the main container port, that the main container exposes to the listeners.
+	CertificateRequired bool             // True if any listener terminates TLS connections.
+	Aliases             []string
+	CrossZone           *bool
+}
+
+// IngressRules returns the distinct ports and IP protocols that the Network Load Balancer needs to reach the targets of its listeners.
+func (nlb NetworkLoadBalancer) IngressRules() []NLBPortMapping {
+	var rules []NLBPortMapping
+	seen := make(map[NLBPortMapping]bool)
+	add := func(rule NLBPortMapping) {
+		if seen[rule] {
+			return
+		}
+		seen[rule] = true
+		rules = append(rules, rule)
+	}
+	for _, listener := range nlb.Listener {
+		for _, protocol := range listener.TargetIPProtocols() {
+			add(NLBPortMapping{Port: listener.TargetPort, Protocol: protocol})
+		}
+		if listener.HealthCheck.Port != "" {
+			// Health checks are always made over TCP.
+			add(NLBPortMapping{Port: listener.HealthCheck.Port, Protocol: "tcp"})
+		}
+	}
+	return rules
 }
 
 // AdvancedCount holds configuration for autoscaling and capacity provider
@@ -331,6 +524,9 @@ type AutoscalingOpts struct {
 	Requests     *float64
 	ResponseTime *float64
 	QueueDelay   *AutoscalingQueueDelayOpts
+
+	CustomMetrics []AutoscalingCustomMetricOpts
+	Schedules     []AutoscalingScheduleOpts
 }
 
 // AutoscalingQueueDelayOpts holds configuration to scale SQS queues.
@@ -338,6 +534,37 @@ type AutoscalingQueueDelayOpts struct {
 	AcceptableBacklogPerTask int
 }
 
+// AutoscalingCustomMetricOpts holds configuration to track a target value of a custom CloudWatch metric.
+// Either the CloudWatchMetricOpts or the Expression and its Metrics are set.
+type AutoscalingCustomMetricOpts struct {
+	CloudWatchMetricOpts
+	Expression string
+	Metrics    []CloudWatchMetricQueryOpts
+	Target     float64
+}
+
+// CloudWatchMetricOpts holds configuration to identify a CloudWatch metric.
+type CloudWatchMetricOpts struct {
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+	Statistic  string
+}
+
+// CloudWatchMetricQueryOpts holds configuration for a metric referenced in a metric math expression.
+type CloudWatchMetricQueryOpts struct {
+	ID string
+	CloudWatchMetricOpts
+}
+
+// AutoscalingScheduleOpts holds configuration to change the capacity of a service on a schedule.
+type AutoscalingScheduleOpts struct {
+	Schedule    string // The schedule expression, such as "cron(0 9 ? * MON-FRI *)".
+	TimeZone    string
+	MinCapacity *int
+	MaxCapacity *int
+}
+
 // ObservabilityOpts holds configurations for observability.
 type ObservabilityOpts struct {
 	Tracing string // The name of the vendor used for tracing.
@@ -351,6 +578,12 @@ type DeploymentConfigurationOpts struct {
 	MaxPercent int
 }
 
+// PreDeployOpts holds configuration for the one-off task that runs before the service is updated.
+type PreDeployOpts struct {
+	Command          []string
+	TimeoutInSeconds int
+}
+
 // ExecuteCommandOpts holds configuration that's needed for ECS Execute Command.
 type ExecuteCommandOpts struct{}
 
@@ -419,6 +652,30 @@ type NetworkOpts struct {
 	AssignPublicIP string
 	SubnetsType    string
 	SecurityGroups []string
+	Ingress        *ServiceIngressOpts // If set, the service only accepts traffic from these services instead of the whole environment.
+	IngressSource  bool                // If set, other services in the environment accept traffic from this service.
+}
+
+// HasServiceSecurityGroup returns true if the tasks need their own security group to take part in service-to-service ingress rules.
+func (n NetworkOpts) HasServiceSecurityGroup() bool {
+	return n.Ingress != nil || n.IngressSource
+}
+
+// ServiceIngressOpts holds the services that are allowed to send traffic to the service, and on which ports.
+type ServiceIngressOpts struct {
+	FromServices []string
+	Ports        []uint16
+}
+
+// ServiceConnectOpts holds configuration for ECS Service Connect.
+type ServiceConnectOpts struct {
+	Server *ServiceConnectServerOpts // Nil if the service only connects to other services.
+}
+
+// ServiceConnectServerOpts holds the configuration to expose the main container through Service Connect.
+type ServiceConnectServerOpts struct {
+	Name  string // Name of the port mapping of the main container.
+	Alias string // DNS name that other services use to connect to the service.
 }
 
 // RuntimePlatformOpts holds configuration needed for Platform configuration.
@@ -452,6 +709,17 @@ func (p RuntimePlatformOpts) isEmpty() bool {
 	return p.OS == "" && p.Arch == ""
 }
 
+// EC2Opts holds configuration for tasks that run on the EC2 instances of the environment instead of Fargate.
+type EC2Opts struct {
+	NetworkMode       string // Either "awsvpc" or "bridge".
+	PlacementStrategy string // Either "spread" or "binpack".
+}
+
+// IsBridgeNetworkMode returns true if the tasks use the Docker's built-in virtual network of the instance.
+func (o *EC2Opts) IsBridgeNetworkMode() bool {
+	return o != nil && o.NetworkMode == NetworkModeBridge
+}
+
 // WorkloadOpts holds optional data that can be provided to enable features in a workload stack template.
 type WorkloadOpts struct {
 	// Additional options that are common between **all** workload templates.
@@ -463,7 +731,10 @@ type WorkloadOpts struct {
 	Tags                     map[string]string        // Used by App Runner workloads to tag App Runner service resources
 	NestedStack              *WorkloadNestedStackOpts // Outputs from nested stacks such as the addons stack.
 	AddonsExtraParams        string                   // Additional user defined Parameters for the addons stack.
+	EnvAddons                *EnvAddonsImportsOpts    // Outputs imported from the environment addons stack.
+	Permissions              []PermissionOpts         // IAM policy statements added to the task or instance role.
 	Sidecars                 []*SidecarOpts
+	InitContainers           []*InitContainerOpts
 	LogConfig                *LogConfigOpts
 	Autoscaling              *AutoscalingOpts
 	CapacityProviders        []*CapacityProviderStrategy
@@ -472,6 +743,7 @@ type WorkloadOpts struct {
 	Network                  NetworkOpts
 	ExecuteCommand           *ExecuteCommandOpts
 	Platform                 RuntimePlatformOpts
+	EC2                      *EC2Opts // Set if the tasks run on the EC2 instances of the environment.
 	EntryPoint               []string
 	Command                  []string
 	DomainAlias              string
@@ -479,8 +751,10 @@ type WorkloadOpts struct {
 	DependsOn                map[string]string
 	Publish                  *PublishOpts
 	ServiceDiscoveryEndpoint string
+	ServiceConnect           *ServiceConnectOpts
 	HTTPVersion              *string
 	ALBEnabled               bool
+	HostedZoneID             string // Private hosted zone in which aliases of the internal load balancer are created.
 
 	// Additional options for service templates.
 	WorkloadType            string
@@ -488,8 +762,13 @@ type WorkloadOpts struct {
 	HTTPHealthCheck         HTTPHealthCheckOpts
 	DeregistrationDelay     *int64
 	AllowedSourceIps        []string
+	ALBListenerRules        []ALBListenerRule
+	ALBTargetGroups         []ALBTargetGroup // Target groups of containers that receive traffic from listener rules.
+	WAF                     *WAFOpts
+	CDN                     *CDNOpts
 	NLB                     *NetworkLoadBalancer
 	DeploymentConfiguration DeploymentConfigurationOpts
+	PreDeploy               *PreDeployOpts // Set if a one-off task must succeed before the service is updated.
 
 	// Lambda functions.
 	RulePriorityLambda             string
@@ -499,6 +778,8 @@ type WorkloadOpts struct {
 	BacklogPerTaskCalculatorLambda string
 	NLBCertValidatorFunctionLambda string
 	NLBCustomDomainFunctionLambda  string
+	CDNCertValidatorLambda         string
+	PreDeployLambda                string
 
 	// Additional options for job templates.
 	ScheduleExpression string
@@ -519,6 +800,10 @@ type WorkloadOpts struct {
 
 	// Additional options for worker service templates.
 	Subscribe *SubscribeOpts
+
+	// Additional options for static site templates.
+	StaticSite             *StaticSiteOpts
+	StaticSiteAssetsLambda string
 }
 
 // ParseLoadBalancedWebService parses a load balanced web service's CloudFormation template
@@ -543,6 +828,11 @@ func (t *Template) ParseWorkerService(data WorkloadOpts) (*Content, error) {
 	return t.parseSvc(workerSvcTplName, data, withSvcParsingFuncs())
 }
 
+// ParseStaticSite parses a static site's CloudFormation template with the specified data object and returns its content.
+func (t *Template) ParseStaticSite(data WorkloadOpts) (*Content, error) {
+	return t.parseSvc(staticSiteTplName, data, withSvcParsingFuncs())
+}
+
 // ParseScheduledJob parses a scheduled job's Cloudformation Template
 func (t *Template) ParseScheduledJob(data WorkloadOpts) (*Content, error) {
 	return t.parseJob(scheduledJobTplName, data, withSvcParsingFuncs())
@@ -585,8 +875,10 @@ func withSvcParsingFuncs() ParseOption {
 		return t.Funcs(map[string]interface{}{
 			"toSnakeCase":         ToSnakeCaseFunc,
 			"hasSecrets":          hasSecrets,
+			"hasManagedPolicies":  hasManagedPolicies,
 			"fmtSlice":            FmtSliceFunc,
 			"quoteSlice":          QuoteSliceFunc,
+			"quote":               strconv.Quote,
 			"randomUUID":          randomUUIDFunc,
 			"jsonMountPoints":     generateMountPointJSON,
 			"jsonSNSTopics":       generateSNSJSON,
@@ -607,6 +899,19 @@ func hasSecrets(opts WorkloadOpts) bool {
 	if opts.NestedStack != nil && (len(opts.NestedStack.SecretOutputs) > 0) {
 		return true
 	}
+	if opts.EnvAddons != nil && (len(opts.EnvAddons.SecretOutputs) > 0) {
+		return true
+	}
+	return false
+}
+
+func hasManagedPolicies(opts WorkloadOpts) bool {
+	if opts.NestedStack != nil && (len(opts.NestedStack.PolicyOutputs) > 0) {
+		return true
+	}
+	if opts.EnvAddons != nil && (len(opts.EnvAddons.PolicyOutputs) > 0) {
+		return true
+	}
 	return false
 }
 
@@ -626,6 +931,12 @@ func envControllerParameters(o WorkloadOpts) []string {
 			parameters = append(parameters, "ALBWorkloads,")
 		}
 		parameters = append(parameters, "Aliases,") // YAML needs the comma separator; resolved in EnvContr.
+		if o.ALBEnabled && o.CDN != nil {
+			parameters = append(parameters, "CDNWorkloads,")
+		}
+	}
+	if o.WorkloadType == "Backend Service" && o.ALBEnabled {
+		parameters = append(parameters, "InternalALBWorkloads,")
 	}
 	if o.Network.SubnetsType == PrivateSubnetsPlacement {
 		parameters = append(parameters, "NATWorkloads,")
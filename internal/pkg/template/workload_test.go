@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
@@ -41,6 +42,8 @@ func TestTemplate_ParseSvc(t *testing.T) {
 					"templates/workloads/partials/cf/servicediscovery.yml":                []byte("servicediscovery"),
 					"templates/workloads/partials/cf/addons.yml":                          []byte("addons"),
 					"templates/workloads/partials/cf/sidecars.yml":                        []byte("sidecars"),
+					"templates/workloads/partials/cf/init-containers.yml":                 []byte("init-containers"),
+					"templates/workloads/partials/cf/pre-deploy.yml":                      []byte("pre-deploy"),
 					"templates/workloads/partials/cf/logconfig.yml":                       []byte("logconfig"),
 					"templates/workloads/partials/cf/autoscaling.yml":                     []byte("autoscaling"),
 					"templates/workloads/partials/cf/state-machine-definition.json.yml":   []byte("state-machine-definition"),
@@ -49,6 +52,10 @@ func TestTemplate_ParseSvc(t *testing.T) {
 					"templates/workloads/partials/cf/efs-access-point.yml":                []byte("efs-access-point"),
 					"templates/workloads/partials/cf/https-listener.yml":                  []byte("https-listener"),
 					"templates/workloads/partials/cf/http-listener.yml":                   []byte("http-listener"),
+					"templates/workloads/partials/cf/alb-listener-rule.yml":               []byte("alb-listener-rule"),
+					"templates/workloads/partials/cf/web-acl.yml":                         []byte("web-acl"),
+					"templates/workloads/partials/cf/cdn.yml":                             []byte("cdn"),
+					"templates/workloads/partials/cf/cdn-aliases.yml":                     []byte("cdn-aliases"),
 					"templates/workloads/partials/cf/env-controller.yml":                  []byte("env-controller"),
 					"templates/workloads/partials/cf/mount-points.yml":                    []byte("mount-points"),
 					"templates/workloads/partials/cf/volumes.yml":                         []byte("volumes"),
@@ -59,6 +66,8 @@ func TestTemplate_ParseSvc(t *testing.T) {
 					"templates/workloads/partials/cf/subscribe.yml":                       []byte("subscribe"),
 					"templates/workloads/partials/cf/nlb.yml":                             []byte("nlb"),
 					"templates/workloads/partials/cf/vpc-connector.yml":                   []byte("vpc-connector"),
+					"templates/workloads/partials/cf/service-security-group.yml":          []byte("service-security-group"),
+					"templates/workloads/partials/cf/internal-listener.yml":               []byte("internal-listener"),
 				}
 			},
 			wantedContent: `  loggroup
@@ -73,6 +82,8 @@ func TestTemplate_ParseSvc(t *testing.T) {
   servicediscovery
   addons
   sidecars
+  init-containers
+  pre-deploy
   logconfig
   autoscaling
   eventrule
@@ -81,6 +92,10 @@ func TestTemplate_ParseSvc(t *testing.T) {
   efs-access-point
   https-listener
   http-listener
+  alb-listener-rule
+  web-acl
+  cdn
+  cdn-aliases
   env-controller
   mount-points
   volumes
@@ -91,6 +106,8 @@ func TestTemplate_ParseSvc(t *testing.T) {
   subscribe
   nlb
   vpc-connector
+  service-security-group
+  internal-listener
 `,
 		},
 	}
@@ -146,6 +163,14 @@ func TestHasSecrets(t *testing.T) {
 			},
 			wanted: true,
 		},
+		"env addons has secrets": {
+			in: WorkloadOpts{
+				EnvAddons: &EnvAddonsImportsOpts{
+					SecretOutputs: []string{"MySecretArn"},
+				},
+			},
+			wanted: true,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -155,6 +180,48 @@ func TestHasSecrets(t *testing.T) {
 	}
 }
 
+func TestHasManagedPolicies(t *testing.T) {
+	testCases := map[string]struct {
+		in     WorkloadOpts
+		wanted bool
+	}{
+		"no nested stacks": {
+			in:     WorkloadOpts{},
+			wanted: false,
+		},
+		"nested stack without policies": {
+			in: WorkloadOpts{
+				NestedStack: &WorkloadNestedStackOpts{
+					VariableOutputs: []string{"MyTableName"},
+				},
+			},
+			wanted: false,
+		},
+		"nested has policies": {
+			in: WorkloadOpts{
+				NestedStack: &WorkloadNestedStackOpts{
+					PolicyOutputs: []string{"MyTableAccessPolicy"},
+				},
+			},
+			wanted: true,
+		},
+		"env addons has policies": {
+			in: WorkloadOpts{
+				EnvAddons: &EnvAddonsImportsOpts{
+					PolicyOutputs: []string{"MyTableAccessPolicy"},
+				},
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, hasManagedPolicies(tc.in))
+		})
+	}
+}
+
 func TestTemplate_ParseNetwork(t *testing.T) {
 	type cfn struct {
 		Resources struct {
@@ -207,6 +274,44 @@ func TestTemplate_ParseNetwork(t *testing.T) {
      - Fn::ImportValue: !Sub '${AppName}-${EnvName}-EnvironmentSecurityGroup'
      - "sg-1bcf1d5b"
      - "sg-asdasdas"
+`,
+		},
+		"should render the service security group when other services accept traffic from the service": {
+			input: NetworkOpts{
+				AssignPublicIP: "DISABLED",
+				SubnetsType:    "PrivateSubnets",
+				IngressSource:  true,
+			},
+			wantedNetworkConfig: `
+ AwsvpcConfiguration:
+   AssignPublicIp: DISABLED
+   Subnets:
+     Fn::Split:
+       - ','
+       - Fn::ImportValue: !Sub '${AppName}-${EnvName}-PrivateSubnets'
+   SecurityGroups:
+     - Fn::ImportValue: !Sub '${AppName}-${EnvName}-EnvironmentSecurityGroup'
+     - !Ref ServiceSecurityGroup
+`,
+		},
+		"should not render the environment security group when service ingress is restricted": {
+			input: NetworkOpts{
+				AssignPublicIP: "DISABLED",
+				SubnetsType:    "PrivateSubnets",
+				Ingress: &ServiceIngressOpts{
+					FromServices: []string{"frontend"},
+					Ports:        []uint16{8080},
+				},
+			},
+			wantedNetworkConfig: `
+ AwsvpcConfiguration:
+   AssignPublicIp: DISABLED
+   Subnets:
+     Fn::Split:
+       - ','
+       - Fn::ImportValue: !Sub '${AppName}-${EnvName}-PrivateSubnets'
+   SecurityGroups:
+     - !Ref ServiceSecurityGroup
 `,
 		},
 	}
@@ -234,6 +339,1205 @@ func TestTemplate_ParseNetwork(t *testing.T) {
 	}
 }
 
+func TestTemplate_ParseServiceIngress(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseBackendService(WorkloadOpts{
+		Network: NetworkOpts{
+			AssignPublicIP: "DISABLED",
+			SubnetsType:    "PrivateSubnets",
+			Ingress: &ServiceIngressOpts{
+				FromServices: []string{"front-end", "worker"},
+				Ports:        []uint16{80, 8080},
+			},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse backend service")
+	var actual cfn
+	require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+	require.Equal(t, "AWS::EC2::SecurityGroup", actual.Resources["ServiceSecurityGroup"].Type)
+	for _, id := range []string{
+		"ServiceSecurityGroupIngressFromfrontendPort80",
+		"ServiceSecurityGroupIngressFromfrontendPort8080",
+		"ServiceSecurityGroupIngressFromworkerPort80",
+		"ServiceSecurityGroupIngressFromworkerPort8080",
+	} {
+		require.Contains(t, actual.Resources, id)
+		require.Equal(t, "AWS::EC2::SecurityGroupIngress", actual.Resources[id].Type)
+	}
+	rule := actual.Resources["ServiceSecurityGroupIngressFromfrontendPort8080"].Properties
+	require.Equal(t, 8080, rule["FromPort"])
+	require.Equal(t, 8080, rule["ToPort"])
+	require.Equal(t, map[string]interface{}{
+		"Fn::ImportValue": "${AppName}-${EnvName}-front-end-ServiceSecurityGroup",
+	}, rule["SourceSecurityGroupId"])
+}
+
+func TestTemplate_ParseServiceIngressWithManagedEFS(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseBackendService(WorkloadOpts{
+		Network: NetworkOpts{
+			AssignPublicIP: "DISABLED",
+			SubnetsType:    "PrivateSubnets",
+			Ingress: &ServiceIngressOpts{
+				FromServices: []string{"frontend"},
+				Ports:        []uint16{8080},
+			},
+		},
+		Storage: &StorageOpts{
+			ManagedVolumeInfo: &ManagedVolumeCreationInfo{
+				Name:    aws.String("efs"),
+				DirName: aws.String("api"),
+				UID:     aws.Uint32(1000),
+				GID:     aws.Uint32(1000),
+			},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse backend service")
+	var actual cfn
+	require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+	rule := actual.Resources["EFSSecurityGroupIngressFromServiceSecurityGroup"]
+	require.Equal(t, "AWS::EC2::SecurityGroupIngress", rule.Type)
+	require.Equal(t, 2049, rule.Properties["FromPort"])
+	require.Equal(t, "EnvControllerAction.EFSSecurityGroup", rule.Properties["GroupId"])
+}
+
+func TestTemplate_ParseServiceConnect(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			Service struct {
+				Properties struct {
+					ServiceConnectConfiguration map[string]interface{} `yaml:"ServiceConnectConfiguration"`
+				} `yaml:"Properties"`
+			} `yaml:"Service"`
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						PortMappings []map[string]interface{} `yaml:"PortMappings"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+		Outputs map[string]interface{} `yaml:"Outputs"`
+	}
+
+	testCases := map[string]struct {
+		input *ServiceConnectOpts
+
+		wantedConfig       string
+		wantedPortMappings []map[string]interface{}
+		wantedOutput       bool
+	}{
+		"should only configure a client": {
+			input: &ServiceConnectOpts{},
+			wantedConfig: `
+Enabled: true
+Namespace: test.phonetool.local
+`,
+			wantedPortMappings: []map[string]interface{}{
+				{"ContainerPort": "ContainerPort"},
+			},
+		},
+		"should expose the main container": {
+			input: &ServiceConnectOpts{
+				Server: &ServiceConnectServerOpts{
+					Name:  "api",
+					Alias: "backend",
+				},
+			},
+			wantedConfig: `
+Enabled: true
+Namespace: test.phonetool.local
+Services:
+  - PortName: api
+    DiscoveryName: !Sub '${WorkloadName}-sc'
+    ClientAliases:
+      - Port: !Ref ContainerPort
+        DnsName: backend
+`,
+			wantedPortMappings: []map[string]interface{}{
+				{"ContainerPort": "ContainerPort", "Name": "api"},
+			},
+			wantedOutput: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+			wanted := make(map[string]interface{})
+			require.NoError(t, yaml.Unmarshal([]byte(tc.wantedConfig), &wanted), "unmarshal wanted config")
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:             "Load Balanced Web Service",
+				ServiceDiscoveryEndpoint: "test.phonetool.local",
+				ServiceConnect:           tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			require.Equal(t, wanted, actual.Resources.Service.Properties.ServiceConnectConfiguration)
+			require.Equal(t, tc.wantedPortMappings, actual.Resources.TaskDefinition.Properties.ContainerDefinitions[0].PortMappings)
+			_, ok := actual.Outputs["ServiceConnectEndpoint"]
+			require.Equal(t, tc.wantedOutput, ok)
+		})
+	}
+}
+
+func TestTemplate_ParseInternalALB(t *testing.T) {
+	type cfn struct {
+		Parameters map[string]interface{} `yaml:"Parameters"`
+		Resources  map[string]struct {
+			Type       string                 `yaml:"Type"`
+			DependsOn  interface{}            `yaml:"DependsOn"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		inHTTPS      bool
+		inAliases    []string
+		inHostedZone string
+
+		wantedRules     []string
+		wantedListener  string
+		wantedDNSRecord bool
+	}{
+		"should forward traffic from the internal HTTP listener": {
+			wantedRules:    []string{"InternalHTTPListenerRule"},
+			wantedListener: "EnvControllerAction.InternalHTTPListenerArn",
+		},
+		"should redirect HTTP to the internal HTTPS listener and create aliases in the hosted zone": {
+			inHTTPS:         true,
+			inAliases:       []string{"api.internal.example.com"},
+			inHostedZone:    "Z0873220N255IR3MTNR4",
+			wantedRules:     []string{"InternalHTTPListenerRuleWithDomain", "InternalHTTPSListenerRule"},
+			wantedListener:  "EnvControllerAction.InternalHTTPSListenerArn",
+			wantedDNSRecord: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				WorkloadType:        "Backend Service",
+				ALBEnabled:          true,
+				HTTPSListener:       tc.inHTTPS,
+				Aliases:             tc.inAliases,
+				HostedZoneID:        tc.inHostedZone,
+				HTTPHealthCheck:     HTTPHealthCheckOpts{HealthCheckPath: "/"},
+				DeregistrationDelay: aws.Int64(60),
+				Network: NetworkOpts{
+					AssignPublicIP: "DISABLED",
+					SubnetsType:    "PrivateSubnets",
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			for _, param := range []string{"TargetContainer", "TargetPort", "RulePath", "Stickiness"} {
+				require.Contains(t, actual.Parameters, param)
+			}
+			require.Equal(t, "AWS::ElasticLoadBalancingV2::TargetGroup", actual.Resources["TargetGroup"].Type)
+			wantedDependsOn := []interface{}{"EnvControllerAction"}
+			for _, rule := range tc.wantedRules {
+				wantedDependsOn = append(wantedDependsOn, rule)
+			}
+			require.Equal(t, wantedDependsOn, actual.Resources["Service"].DependsOn)
+			for _, rule := range tc.wantedRules {
+				require.Equal(t, "AWS::ElasticLoadBalancingV2::ListenerRule", actual.Resources[rule].Type)
+			}
+			forwardRule := actual.Resources[tc.wantedRules[len(tc.wantedRules)-1]]
+			require.Equal(t, tc.wantedListener, forwardRule.Properties["ListenerArn"])
+			_, ok := actual.Resources["InternalLoadBalancerDNSAlias"]
+			require.Equal(t, tc.wantedDNSRecord, ok)
+		})
+	}
+}
+
+func TestTemplate_ParseALBListenerRules(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			DependsOn  interface{}            `yaml:"DependsOn"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+	rules := []ALBListenerRule{
+		{
+			Priority: 10,
+			Paths:    []string{"/beta", "/beta/*"},
+			Headers: []ALBHeaderCondition{
+				{Name: "X-Canary", Values: []string{"true"}},
+			},
+			Targets: []ALBWeightedTarget{
+				{TargetGroup: "TargetGroup", Weight: 90},
+				{TargetGroup: "TargetGroupcanary", Weight: 10},
+			},
+		},
+		{
+			Priority: 20,
+			Paths:    []string{"/old", "/old/*"},
+			Redirect: &ALBRedirectAction{
+				Path:       "/new",
+				StatusCode: "HTTP_301",
+			},
+		},
+		{
+			Priority:     30,
+			Hosts:        []string{"maintenance.example.com"},
+			QueryStrings: []ALBQueryStringCondition{{Key: "debug", Value: "true"}},
+			FixedResponse: &ALBFixedResponseAction{
+				StatusCode:  "503",
+				ContentType: "text/html",
+				MessageBody: "<h1>Down for maintenance</h1>",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		inHTTPS bool
+
+		wantedRulePrefix string
+		wantedListener   string
+	}{
+		"should add rules to the HTTP listener": {
+			wantedRulePrefix: "HTTPListenerRule",
+			wantedListener:   "EnvControllerAction.HTTPListenerArn",
+		},
+		"should add rules to the HTTPS listener": {
+			inHTTPS:          true,
+			wantedRulePrefix: "HTTPSListenerRule",
+			wantedListener:   "EnvControllerAction.HTTPSListenerArn",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:        "Load Balanced Web Service",
+				ALBEnabled:          true,
+				HTTPSListener:       tc.inHTTPS,
+				HTTPHealthCheck:     HTTPHealthCheckOpts{HealthCheckPath: "/"},
+				DeregistrationDelay: aws.Int64(60),
+				ALBListenerRules:    rules,
+				ALBTargetGroups: []ALBTargetGroup{
+					{Container: "canary", Port: "8080"},
+				},
+				Network: NetworkOpts{
+					AssignPublicIP: "ENABLED",
+					SubnetsType:    "PublicSubnets",
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			require.Equal(t, "AWS::ElasticLoadBalancingV2::TargetGroup", actual.Resources["TargetGroupcanary"].Type)
+			require.Contains(t, actual.Resources["Service"].DependsOn, tc.wantedRulePrefix+"10")
+			require.Contains(t, actual.Resources["Service"].Properties["LoadBalancers"], map[string]interface{}{
+				"ContainerName":  "canary",
+				"ContainerPort":  8080,
+				"TargetGroupArn": "TargetGroupcanary",
+			})
+
+			forward := actual.Resources[tc.wantedRulePrefix+"10"].Properties
+			require.Equal(t, tc.wantedListener, forward["ListenerArn"])
+			require.Equal(t, 10, forward["Priority"])
+			require.Equal(t, []interface{}{
+				map[string]interface{}{
+					"Type": "forward",
+					"ForwardConfig": map[string]interface{}{
+						"TargetGroups": []interface{}{
+							map[string]interface{}{"TargetGroupArn": "TargetGroup", "Weight": 90},
+							map[string]interface{}{"TargetGroupArn": "TargetGroupcanary", "Weight": 10},
+						},
+					},
+				},
+			}, forward["Actions"])
+			require.Equal(t, []interface{}{
+				map[string]interface{}{
+					"Field": "http-header",
+					"HttpHeaderConfig": map[string]interface{}{
+						"HttpHeaderName": "X-Canary",
+						"Values":         []interface{}{"true"},
+					},
+				},
+				map[string]interface{}{
+					"Field": "path-pattern",
+					"PathPatternConfig": map[string]interface{}{
+						"Values": []interface{}{"/beta", "/beta/*"},
+					},
+				},
+			}, forward["Conditions"])
+
+			redirect := actual.Resources[tc.wantedRulePrefix+"20"].Properties
+			require.Equal(t, []interface{}{
+				map[string]interface{}{
+					"Type": "redirect",
+					"RedirectConfig": map[string]interface{}{
+						"Path":       "/new",
+						"StatusCode": "HTTP_301",
+					},
+				},
+			}, redirect["Actions"])
+
+			fixedResponse := actual.Resources[tc.wantedRulePrefix+"30"].Properties
+			require.Equal(t, []interface{}{
+				map[string]interface{}{
+					"Type": "fixed-response",
+					"FixedResponseConfig": map[string]interface{}{
+						"StatusCode":  "503",
+						"ContentType": "text/html",
+						"MessageBody": "<h1>Down for maintenance</h1>",
+					},
+				},
+			}, fixedResponse["Actions"])
+			require.Equal(t, []interface{}{
+				map[string]interface{}{
+					"Field": "host-header",
+					"HostHeaderConfig": map[string]interface{}{
+						"Values": []interface{}{"maintenance.example.com"},
+					},
+				},
+				map[string]interface{}{
+					"Field": "query-string",
+					"QueryStringConfig": map[string]interface{}{
+						"Values": []interface{}{
+							map[string]interface{}{"Key": "debug", "Value": "true"},
+						},
+					},
+				},
+			}, fixedResponse["Conditions"])
+		})
+	}
+}
+
+func TestTemplate_ParseWebACL(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+		Outputs map[string]struct {
+			Value string `yaml:"Value"`
+		} `yaml:"Outputs"`
+	}
+	testCases := map[string]struct {
+		inWAF *WAFOpts
+
+		wantedWebACL     bool
+		wantedWebACLArn  string
+		wantedRulesCount int
+	}{
+		"should associate an existing web ACL": {
+			inWAF: &WAFOpts{
+				WebACLARN: "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3",
+			},
+			wantedWebACLArn: "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/mock/a1b2c3",
+		},
+		"should create a web ACL with managed rules and a rate limit": {
+			inWAF: &WAFOpts{
+				ManagedRules: []string{"AWSManagedRulesCommonRuleSet", "AWSManagedRulesSQLiRuleSet"},
+				RateLimit:    1000,
+			},
+			wantedWebACL:     true,
+			wantedWebACLArn:  "WebACL.Arn",
+			wantedRulesCount: 3,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:        "Load Balanced Web Service",
+				ALBEnabled:          true,
+				HTTPHealthCheck:     HTTPHealthCheckOpts{HealthCheckPath: "/"},
+				DeregistrationDelay: aws.Int64(60),
+				WAF:                 tc.inWAF,
+				Network: NetworkOpts{
+					AssignPublicIP: "ENABLED",
+					SubnetsType:    "PublicSubnets",
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			association := actual.Resources["WebACLAssociation"]
+			require.Equal(t, "AWS::WAFv2::WebACLAssociation", association.Type)
+			require.Equal(t, "EnvControllerAction.PublicLoadBalancerArn", association.Properties["ResourceArn"])
+			require.Equal(t, tc.wantedWebACLArn, association.Properties["WebACLArn"])
+			require.Equal(t, tc.wantedWebACLArn, actual.Outputs["WebACLArn"].Value)
+
+			webACL, ok := actual.Resources["WebACL"]
+			require.Equal(t, tc.wantedWebACL, ok)
+			if tc.wantedWebACL {
+				require.Equal(t, "AWS::WAFv2::WebACL", webACL.Type)
+				require.Len(t, webACL.Properties["Rules"], tc.wantedRulesCount)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseEC2(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+	testCases := map[string]struct {
+		inEC2 *EC2Opts
+
+		wantedNetworkMode       string
+		wantedCompatibility     string
+		wantedNetworkConfig     bool
+		wantedPlacement         interface{}
+		wantedTargetType        string
+		wantedDNSRecordsCount   int
+		wantedServiceRegistries []interface{}
+	}{
+		"should run on Fargate by default": {
+			wantedNetworkMode:     "awsvpc",
+			wantedCompatibility:   "FARGATE",
+			wantedNetworkConfig:   true,
+			wantedTargetType:      "ip",
+			wantedDNSRecordsCount: 2,
+			wantedServiceRegistries: []interface{}{
+				map[string]interface{}{
+					"RegistryArn": "DiscoveryService.Arn",
+					"Port":        "ContainerPort",
+				},
+			},
+		},
+		"should spread awsvpc tasks across EC2 instances": {
+			inEC2: &EC2Opts{
+				NetworkMode:       NetworkModeAWSVPC,
+				PlacementStrategy: PlacementStrategySpread,
+			},
+			wantedNetworkMode:   "awsvpc",
+			wantedCompatibility: "EC2",
+			wantedNetworkConfig: true,
+			wantedPlacement: []interface{}{
+				map[string]interface{}{"Type": "spread", "Field": "attribute:ecs.availability-zone"},
+				map[string]interface{}{"Type": "spread", "Field": "instanceId"},
+			},
+			wantedTargetType:      "ip",
+			wantedDNSRecordsCount: 2,
+			wantedServiceRegistries: []interface{}{
+				map[string]interface{}{
+					"RegistryArn": "DiscoveryService.Arn",
+					"Port":        "ContainerPort",
+				},
+			},
+		},
+		"should binpack bridge tasks on EC2 instances": {
+			inEC2: &EC2Opts{
+				NetworkMode:       NetworkModeBridge,
+				PlacementStrategy: PlacementStrategyBinpack,
+			},
+			wantedNetworkMode:   "bridge",
+			wantedCompatibility: "EC2",
+			wantedPlacement: []interface{}{
+				map[string]interface{}{"Type": "binpack", "Field": "memory"},
+			},
+			wantedTargetType:      "instance",
+			wantedDNSRecordsCount: 1,
+			wantedServiceRegistries: []interface{}{
+				map[string]interface{}{
+					"RegistryArn":   "DiscoveryService.Arn",
+					"ContainerName": "WorkloadName",
+					"ContainerPort": "ContainerPort",
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:        "Load Balanced Web Service",
+				ALBEnabled:          true,
+				HTTPHealthCheck:     HTTPHealthCheckOpts{HealthCheckPath: "/"},
+				DeregistrationDelay: aws.Int64(60),
+				EC2:                 tc.inEC2,
+				Network: NetworkOpts{
+					AssignPublicIP: "ENABLED",
+					SubnetsType:    "PublicSubnets",
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			taskDef := actual.Resources["TaskDefinition"].Properties
+			require.Equal(t, tc.wantedNetworkMode, taskDef["NetworkMode"])
+			require.Equal(t, []interface{}{tc.wantedCompatibility}, taskDef["RequiresCompatibilities"])
+
+			service := actual.Resources["Service"].Properties
+			_, ok := service["NetworkConfiguration"]
+			require.Equal(t, tc.wantedNetworkConfig, ok)
+			if tc.inEC2 != nil {
+				require.NotContains(t, service, "LaunchType")
+				require.NotContains(t, service, "PlatformVersion")
+				require.Len(t, service["CapacityProviderStrategy"], 1)
+			} else {
+				require.Equal(t, "FARGATE", service["LaunchType"])
+			}
+			require.Equal(t, tc.wantedPlacement, service["PlacementStrategies"])
+			require.Equal(t, tc.wantedServiceRegistries, service["ServiceRegistries"])
+			require.Equal(t, tc.wantedTargetType, actual.Resources["TargetGroup"].Properties["TargetType"])
+
+			dnsConfig := actual.Resources["DiscoveryService"].Properties["DnsConfig"].(map[string]interface{})
+			require.Len(t, dnsConfig["DnsRecords"], tc.wantedDNSRecordsCount)
+		})
+	}
+}
+
+func TestTemplate_ParseAutoscaling(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+	testCases := map[string]struct {
+		inAutoscaling *AutoscalingOpts
+
+		wantedScheduledActions interface{}
+		wantedCustomMetrics    []interface{}
+	}{
+		"should render scheduled actions": {
+			inAutoscaling: &AutoscalingOpts{
+				MinCapacity: aws.Int(1),
+				MaxCapacity: aws.Int(10),
+				Schedules: []AutoscalingScheduleOpts{
+					{
+						Schedule:    "cron(0 9 ? * MON-FRI *)",
+						TimeZone:    "America/New_York",
+						MinCapacity: aws.Int(6),
+					},
+					{
+						Schedule:    "cron(0 18 ? * MON-FRI *)",
+						MinCapacity: aws.Int(1),
+						MaxCapacity: aws.Int(4),
+					},
+				},
+			},
+			wantedScheduledActions: []interface{}{
+				map[string]interface{}{
+					"ScheduledActionName": "${WorkloadName}-schedule-0",
+					"Schedule":            "cron(0 9 ? * MON-FRI *)",
+					"Timezone":            "America/New_York",
+					"ScalableTargetAction": map[string]interface{}{
+						"MinCapacity": 6,
+					},
+				},
+				map[string]interface{}{
+					"ScheduledActionName": "${WorkloadName}-schedule-1",
+					"Schedule":            "cron(0 18 ? * MON-FRI *)",
+					"ScalableTargetAction": map[string]interface{}{
+						"MinCapacity": 1,
+						"MaxCapacity": 4,
+					},
+				},
+			},
+		},
+		"should render custom metric target tracking policies": {
+			inAutoscaling: &AutoscalingOpts{
+				MinCapacity: aws.Int(1),
+				MaxCapacity: aws.Int(10),
+				CustomMetrics: []AutoscalingCustomMetricOpts{
+					{
+						CloudWatchMetricOpts: CloudWatchMetricOpts{
+							Namespace:  "MyApp",
+							MetricName: "ActiveConnections",
+							Dimensions: map[string]string{
+								"Service": "api",
+							},
+							Statistic: "Average",
+						},
+						Target: 100,
+					},
+					{
+						Expression: `m1 / m2`,
+						Metrics: []CloudWatchMetricQueryOpts{
+							{
+								ID: "m1",
+								CloudWatchMetricOpts: CloudWatchMetricOpts{
+									Namespace:  "MyApp",
+									MetricName: "Errors",
+									Statistic:  "Sum",
+								},
+							},
+							{
+								ID: "m2",
+								CloudWatchMetricOpts: CloudWatchMetricOpts{
+									Namespace:  "MyApp",
+									MetricName: "Requests",
+									Statistic:  "Sum",
+								},
+							},
+						},
+						Target: 0.5,
+					},
+				},
+			},
+			wantedCustomMetrics: []interface{}{
+				map[string]interface{}{
+					"Namespace":  "MyApp",
+					"MetricName": "ActiveConnections",
+					"Statistic":  "Average",
+					"Dimensions": []interface{}{
+						map[string]interface{}{"Name": "Service", "Value": "api"},
+					},
+				},
+				map[string]interface{}{
+					"Metrics": []interface{}{
+						map[string]interface{}{
+							"Id": "m1",
+							"MetricStat": map[string]interface{}{
+								"Metric": map[string]interface{}{"Namespace": "MyApp", "MetricName": "Errors"},
+								"Stat":   "Sum",
+							},
+							"ReturnData": false,
+						},
+						map[string]interface{}{
+							"Id": "m2",
+							"MetricStat": map[string]interface{}{
+								"Metric": map[string]interface{}{"Namespace": "MyApp", "MetricName": "Requests"},
+								"Stat":   "Sum",
+							},
+							"ReturnData": false,
+						},
+						map[string]interface{}{
+							"Id":         "expression",
+							"Expression": "m1 / m2",
+							"ReturnData": true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				WorkloadType:    "Backend Service",
+				HTTPHealthCheck: HTTPHealthCheckOpts{HealthCheckPath: "/"},
+				Autoscaling:     tc.inAutoscaling,
+				Network: NetworkOpts{
+					AssignPublicIP: "ENABLED",
+					SubnetsType:    "PublicSubnets",
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			require.Equal(t, tc.wantedScheduledActions, actual.Resources["AutoScalingTarget"].Properties["ScheduledActions"])
+			for i, wanted := range tc.wantedCustomMetrics {
+				policy, ok := actual.Resources[fmt.Sprintf("AutoScalingPolicyCustomMetric%d", i)]
+				require.True(t, ok)
+				config := policy.Properties["TargetTrackingScalingPolicyConfiguration"].(map[string]interface{})
+				require.Equal(t, wanted, config["CustomizedMetricSpecification"])
+			}
+			_, ok := actual.Resources[fmt.Sprintf("AutoScalingPolicyCustomMetric%d", len(tc.wantedCustomMetrics))]
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestTemplate_ParseInitContainers(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []map[string]interface{} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+		WorkloadType:        "Load Balanced Web Service",
+		ALBEnabled:          true,
+		HTTPHealthCheck:     HTTPHealthCheckOpts{HealthCheckPath: "/"},
+		DeregistrationDelay: aws.Int64(60),
+		DependsOn: map[string]string{
+			"migrate": "SUCCESS",
+		},
+		Sidecars: []*SidecarOpts{
+			{
+				Name:  aws.String("nginx"),
+				Image: aws.String("public.ecr.aws/nginx/nginx"),
+				DependsOn: map[string]string{
+					"migrate": "SUCCESS",
+				},
+			},
+		},
+		InitContainers: []*InitContainerOpts{
+			{
+				Name:    aws.String("migrate"),
+				Image:   aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/migrate"),
+				Command: []string{"./migrate", "up"},
+				Variables: map[string]string{
+					"DB_NAME": "frontend",
+				},
+			},
+		},
+		Network: NetworkOpts{
+			AssignPublicIP: "ENABLED",
+			SubnetsType:    "PublicSubnets",
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse load balanced web service")
+	var actual cfn
+	require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+	containers := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+	require.Len(t, containers, 3)
+	wantedDependsOn := []interface{}{
+		map[string]interface{}{"Condition": "SUCCESS", "ContainerName": "migrate"},
+	}
+	require.Equal(t, wantedDependsOn, containers[0]["DependsOn"])
+	require.Equal(t, "nginx", containers[1]["Name"])
+	require.Equal(t, wantedDependsOn, containers[1]["DependsOn"])
+
+	initContainer := containers[2]
+	require.Equal(t, "migrate", initContainer["Name"])
+	require.Equal(t, "123456789012.dkr.ecr.us-west-2.amazonaws.com/migrate", initContainer["Image"])
+	require.Equal(t, false, initContainer["Essential"])
+	require.Equal(t, []interface{}{"./migrate", "up"}, initContainer["Command"])
+	require.Contains(t, initContainer["Environment"], map[string]interface{}{"Name": "DB_NAME", "Value": "frontend"})
+	require.NotContains(t, initContainer, "DependsOn")
+}
+
+func TestTemplate_ParsePreDeploy(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			Service struct {
+				DependsOn []string `yaml:"DependsOn"`
+			} `yaml:"Service"`
+			PreDeployTaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []map[string]interface{} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"PreDeployTaskDefinition"`
+			PreDeployAction struct {
+				Type       string                 `yaml:"Type"`
+				Properties map[string]interface{} `yaml:"Properties"`
+			} `yaml:"PreDeployAction"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseBackendService(WorkloadOpts{
+		WorkloadType: "Backend Service",
+		Variables: map[string]string{
+			"LOG_LEVEL": "info",
+		},
+		PreDeploy: &PreDeployOpts{
+			Command:          []string{"./migrate", "up"},
+			TimeoutInSeconds: 600,
+		},
+		Network: NetworkOpts{
+			AssignPublicIP: "DISABLED",
+			SubnetsType:    "PrivateSubnets",
+		},
+		PreDeployLambda: "lambda",
+	})
+
+	// THEN
+	require.NoError(t, err, "parse backend service")
+	var actual cfn
+	require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+	require.Contains(t, actual.Resources.Service.DependsOn, "PreDeployAction")
+
+	containers := actual.Resources.PreDeployTaskDefinition.Properties.ContainerDefinitions
+	require.Len(t, containers, 1)
+	require.Equal(t, []interface{}{"./migrate", "up"}, containers[0]["Command"])
+	require.Contains(t, containers[0]["Environment"], map[string]interface{}{"Name": "LOG_LEVEL", "Value": "info"})
+
+	action := actual.Resources.PreDeployAction
+	require.Equal(t, "Custom::PreDeployTask", action.Type)
+	require.Equal(t, 600, action.Properties["TimeoutInSeconds"])
+	require.Equal(t, "DISABLED", action.Properties["AssignPublicIp"])
+	require.Equal(t, "LATEST", action.Properties["PlatformVersion"])
+}
+
+func TestTemplate_ParseCDN(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+		Outputs map[string]struct {
+			Value string `yaml:"Value"`
+		} `yaml:"Outputs"`
+	}
+	testCases := map[string]struct {
+		inCDN *CDNOpts
+
+		wantedCertificate bool
+		wantedAliasRecord bool
+	}{
+		"should create a distribution without aliases": {
+			inCDN: &CDNOpts{
+				CachePolicyID: "4135ea2d-6df8-44a3-9df3-4b5a84be39ad",
+				PrefixListID:  "pl-mockid",
+			},
+		},
+		"should create a certificate and alias records for aliases": {
+			inCDN: &CDNOpts{
+				CachePolicyID: "658327ea-f89d-4fab-a63d-7e88639e58f6",
+				OriginShield:  true,
+				Aliases:       []string{"www.test.my-app.example.com"},
+				PrefixListID:  "pl-mockid",
+			},
+			wantedCertificate: true,
+			wantedAliasRecord: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:         "Load Balanced Web Service",
+				ALBEnabled:           true,
+				HTTPHealthCheck:      HTTPHealthCheckOpts{HealthCheckPath: "/"},
+				DeregistrationDelay:  aws.Int64(60),
+				CDN:                  tc.inCDN,
+				AppDNSName:           aws.String("example.com"),
+				AppDNSDelegationRole: aws.String("arn:aws:iam::123456789012:role/my-app-DNSDelegationRole"),
+				Network: NetworkOpts{
+					AssignPublicIP: "ENABLED",
+					SubnetsType:    "PublicSubnets",
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			distribution := actual.Resources["CloudFrontDistribution"]
+			require.Equal(t, "AWS::CloudFront::Distribution", distribution.Type)
+			require.Equal(t, "CloudFrontDistribution.DomainName", actual.Outputs["CloudFrontDistributionDomainName"].Value)
+			ingress := actual.Resources["PublicLoadBalancerIngressFromCDN"]
+			require.Equal(t, "EnvControllerAction.PublicLoadBalancerSecurityGroup", ingress.Properties["GroupId"])
+			require.Equal(t, "pl-mockid", ingress.Properties["SourcePrefixListId"])
+
+			cert, ok := actual.Resources["CDNCertificate"]
+			require.Equal(t, tc.wantedCertificate, ok)
+			if tc.wantedCertificate {
+				require.Equal(t, "us-east-1", cert.Properties["Region"])
+				require.Equal(t, `{"cdn":["www.test.my-app.example.com"]}`, cert.Properties["Aliases"])
+			}
+			_, ok = actual.Resources["CDNAliasRecord0"]
+			require.Equal(t, tc.wantedAliasRecord, ok)
+		})
+	}
+}
+
+func TestTemplate_ParseStaticSite(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+		Outputs map[string]struct {
+			Value string `yaml:"Value"`
+		} `yaml:"Outputs"`
+	}
+	testCases := map[string]struct {
+		inStaticSite *StaticSiteOpts
+		inCDN        *CDNOpts
+
+		wantedCertificate bool
+	}{
+		"should not create the alias records without aliases": {
+			inStaticSite: &StaticSiteOpts{
+				IndexDocument: "index.html",
+			},
+			inCDN: &CDNOpts{},
+		},
+		"should create the alias records": {
+			inStaticSite: &StaticSiteOpts{
+				IndexDocument: "index.html",
+				ErrorDocument: "404.html",
+			},
+			inCDN: &CDNOpts{
+				Aliases: []string{"www.my-app.example.com"},
+			},
+			wantedCertificate: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseStaticSite(WorkloadOpts{
+				WorkloadType:         "Static Site",
+				StaticSite:           tc.inStaticSite,
+				CDN:                  tc.inCDN,
+				AppDNSName:           aws.String("example.com"),
+				AppDNSDelegationRole: aws.String("arn:aws:iam::123456789012:role/my-app-DNSDelegationRole"),
+			})
+
+			// THEN
+			require.NoError(t, err, "parse static site")
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+			require.Equal(t, "AWS::S3::Bucket", actual.Resources["Bucket"].Type)
+			require.Equal(t, "AWS::CloudFront::Distribution", actual.Resources["CloudFrontDistribution"].Type)
+			require.Equal(t, "CloudFrontDistribution.DomainName", actual.Outputs["CloudFrontDistributionDomainName"].Value)
+
+			// The assets are always managed by the stack so that the site bucket isn't emptied when no new files are uploaded.
+			_, ok := actual.Resources["StaticSiteAssets"]
+			require.True(t, ok)
+			_, ok = actual.Resources["CDNCertificate"]
+			require.Equal(t, tc.wantedCertificate, ok)
+			_, ok = actual.Resources["CDNAliasRecord0"]
+			require.Equal(t, tc.wantedCertificate, ok)
+		})
+	}
+}
+
+func TestTemplate_ParseNLB(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+		WorkloadType:        "Load Balanced Web Service",
+		DeregistrationDelay: aws.Int64(60),
+		NLB: &NetworkLoadBalancer{
+			PublicSubnetCIDRs: []string{"10.0.0.0/24"},
+			Listener: []NetworkLoadBalancerListener{
+				{
+					Port:            "443",
+					Protocol:        "TLS",
+					TargetContainer: "frontend",
+					TargetPort:      "80",
+					ALPNPolicy:      aws.String("HTTP2Preferred"),
+				},
+				{
+					Port:            "53",
+					Protocol:        "TCP_UDP",
+					TargetContainer: "frontend",
+					TargetPort:      "53",
+					HealthCheck: NLBHealthCheck{
+						Port: "80",
+					},
+				},
+			},
+			MainContainerPort: "80",
+			PortMappings: []NLBPortMapping{
+				{Port: "53", Protocol: "tcp"},
+				{Port: "53", Protocol: "udp"},
+			},
+			CertificateRequired: true,
+			CrossZone:           aws.Bool(true),
+		},
+		Network: NetworkOpts{
+			AssignPublicIP: "ENABLED",
+			SubnetsType:    "PublicSubnets",
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse load balanced web service")
+	var actual cfn
+	require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual config")
+
+	tlsListener := actual.Resources["NLBListener"]
+	require.Equal(t, "AWS::ElasticLoadBalancingV2::Listener", tlsListener.Type)
+	require.Equal(t, "TLS", tlsListener.Properties["Protocol"])
+	require.Equal(t, []interface{}{"HTTP2Preferred"}, tlsListener.Properties["AlpnPolicy"])
+	require.Equal(t, "TCP", actual.Resources["NLBTargetGroup"].Properties["Protocol"])
+
+	tcpUDPListener := actual.Resources["NLBListener1"]
+	require.Equal(t, "AWS::ElasticLoadBalancingV2::Listener", tcpUDPListener.Type)
+	require.Equal(t, "TCP_UDP", tcpUDPListener.Properties["Protocol"])
+	require.Equal(t, 53, tcpUDPListener.Properties["Port"])
+	tcpUDPTargetGroup := actual.Resources["NLBTargetGroup1"]
+	require.Equal(t, "TCP_UDP", tcpUDPTargetGroup.Properties["Protocol"])
+	require.Equal(t, 80, tcpUDPTargetGroup.Properties["HealthCheckPort"])
+}
+
+func TestTemplate_ParsePermissions(t *testing.T) {
+	type policy struct {
+		PolicyName     string                 `yaml:"PolicyName"`
+		PolicyDocument map[string]interface{} `yaml:"PolicyDocument"`
+	}
+	type role struct {
+		Properties struct {
+			Policies []policy `yaml:"Policies"`
+		} `yaml:"Properties"`
+	}
+	type cfn struct {
+		Resources struct {
+			TaskRole     role `yaml:"TaskRole"`
+			InstanceRole role `yaml:"InstanceRole"`
+		} `yaml:"Resources"`
+	}
+	opts := WorkloadOpts{
+		Permissions: []PermissionOpts{
+			{
+				Effect:    "Allow",
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"arn:aws:s3:::my-bucket/*"},
+			},
+			{
+				Effect:    "Deny",
+				Actions:   []string{"s3:DeleteObject", "s3:PutObject"},
+				Resources: []string{"*"},
+				Conditions: map[string]map[string][]string{
+					"ForAnyValue:StringEquals": {
+						"aws:PrincipalTag/team": {"payments", "billing"},
+					},
+				},
+			},
+		},
+	}
+	wantedDocument := `
+Version: '2012-10-17'
+Statement:
+  - Effect: 'Allow'
+    Action: ["s3:GetObject"]
+    Resource: ["arn:aws:s3:::my-bucket/*"]
+  - Effect: 'Deny'
+    Action: ["s3:DeleteObject", "s3:PutObject"]
+    Resource: ["*"]
+    Condition:
+      "ForAnyValue:StringEquals":
+        "aws:PrincipalTag/team": ["payments", "billing"]
+`
+	testCases := map[string]struct {
+		parse    func(tpl *Template) (*Content, error)
+		policies func(c cfn) []policy
+	}{
+		"should add the statements to the task role": {
+			parse: func(tpl *Template) (*Content, error) {
+				return tpl.ParseBackendService(opts)
+			},
+			policies: func(c cfn) []policy {
+				return c.Resources.TaskRole.Properties.Policies
+			},
+		},
+		"should add the statements to the instance role": {
+			parse: func(tpl *Template) (*Content, error) {
+				return tpl.ParseRequestDrivenWebService(opts)
+			},
+			policies: func(c cfn) []policy {
+				return c.Resources.InstanceRole.Properties.Policies
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			wanted := make(map[string]interface{})
+			require.NoError(t, yaml.Unmarshal([]byte(wantedDocument), &wanted), "unmarshal wanted policy document")
+
+			// WHEN
+			content, err := tc.parse(New())
+
+			// THEN
+			require.NoError(t, err)
+			var actual cfn
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &actual), "unmarshal actual template")
+			var found bool
+			for _, p := range tc.policies(actual) {
+				if p.PolicyName != "ManifestPermissions" {
+					continue
+				}
+				found = true
+				require.Equal(t, wanted, p.PolicyDocument)
+			}
+			require.True(t, found, "ManifestPermissions policy not found")
+		})
+	}
+}
+
 func TestRuntimePlatformOpts_Version(t *testing.T) {
 	testCases := map[string]struct {
 		in       RuntimePlatformOpts
@@ -313,4 +1617,6 @@ func TestSecretsManagerName_Service(t *testing.T) {
 
 func TestSecretsManagerName_ValueFrom(t *testing.T) {
 	require.Equal(t, "secret:aes128-1a2b3c", SecretFromSecretsManager("aes128-1a2b3c").ValueFrom())
+	require.Equal(t, "secret:demo/test/mysql:password::", SecretFromSecretsManager("demo/test/mysql:password").ValueFrom())
+	require.Equal(t, "secret:demo/test/mysql:password:AWSPREVIOUS:", SecretFromSecretsManager("demo/test/mysql:password:AWSPREVIOUS:").ValueFrom())
 }
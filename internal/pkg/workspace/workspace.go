@@ -39,6 +39,8 @@ const (
 	CopilotDirName = "copilot"
 	// SummaryFileName is the name of the file that is associated with the application.
 	SummaryFileName = ".workspace"
+	// EnvironmentsDirName is the name of the directory under copilot/ that holds resources shared by an environment, such as addons.
+	EnvironmentsDirName = "environments"
 
 	addonsDirName             = "addons"
 	pipelinesDirName          = "pipelines"
@@ -387,6 +389,24 @@ func (ws *Workspace) WriteAddon(content encoding.BinaryMarshaler, svc, name stri
 	return ws.write(data, svc, addonsDirName, fname)
 }
 
+// DeleteAddon removes the file under the service's "addons/" directory.
+// If the file does not exist, returns ErrFileNotExists.
+func (ws *Workspace) DeleteAddon(svc, fname string) error {
+	copilotPath, err := ws.copilotDirPath()
+	if err != nil {
+		return err
+	}
+	filename := filepath.Join(copilotPath, svc, addonsDirName, fname)
+	exist, err := ws.fsUtils.Exists(filename)
+	if err != nil {
+		return fmt.Errorf("check if addon file %s exists: %w", filename, err)
+	}
+	if !exist {
+		return &ErrFileNotExists{FileName: filename}
+	}
+	return ws.fsUtils.Remove(filename)
+}
+
 // FileStat wraps the os.Stat function.
 type FileStat interface {
 	Stat(name string) (os.FileInfo, error)
@@ -878,6 +878,57 @@ func TestWorkspace_WriteAddon(t *testing.T) {
 	}
 }
 
+func TestWorkspace_DeleteAddon(t *testing.T) {
+	testCases := map[string]struct {
+		fs func() afero.Fs
+
+		wantedErr error
+	}{
+		"returns an error if the file does not exist": {
+			fs: func() afero.Fs {
+				fs := afero.NewMemMapFs()
+				fs.MkdirAll("/copilot/webhook/addons", 0755)
+				return fs
+			},
+			wantedErr: &ErrFileNotExists{FileName: "/copilot/webhook/addons/s3.yml"},
+		},
+		"deletes the addon file": {
+			fs: func() afero.Fs {
+				fs := afero.NewMemMapFs()
+				fs.MkdirAll("/copilot/webhook/addons", 0755)
+				f, _ := fs.Create("/copilot/webhook/addons/s3.yml")
+				defer f.Close()
+				return fs
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			fs := tc.fs()
+			ws := &Workspace{
+				copilotDir: "/copilot",
+				fsUtils: &afero.Afero{
+					Fs: fs,
+				},
+			}
+
+			// WHEN
+			err := ws.DeleteAddon("webhook", "s3.yml")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			_, statErr := fs.Stat("/copilot/webhook/addons/s3.yml")
+			require.True(t, os.IsNotExist(statErr))
+		})
+	}
+}
+
 func TestWorkspace_ReadPipelineManifest(t *testing.T) {
 	copilotDir := "/copilot"
 	testCases := map[string]struct {